@@ -0,0 +1,371 @@
+package main
+
+import (
+	"TeamTickBackend/app"
+	"TeamTickBackend/config"
+	db "TeamTickBackend/dal"
+	"TeamTickBackend/dal/models"
+	appErrors "TeamTickBackend/pkg/errors"
+	"TeamTickBackend/pkg/logger"
+	service "TeamTickBackend/services"
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// 与注册接口一致的长度限制
+const (
+	minUsernameLength = 3
+	maxUsernameLength = 50
+	minPasswordLength = 6
+	maxPasswordLength = 128
+)
+
+// adminServices 运维子命令使用的服务，与HTTP接口共用同一套业务逻辑
+type adminServices struct {
+	container *app.AppContainer
+	auth      *service.AuthService
+	tokens    *service.TokenService
+	logins    *service.LoginGuard
+	groups    *service.GroupsService
+	tasks     *service.TaskService
+	// consistency 冗余列一致性检查
+	consistency *service.ConsistencyService
+	// admin 平台管理操作，除创建用户与一致性检查外，运维命令都通过它执行并写入操作日志
+	admin *service.AdminService
+}
+
+// cliActor 运维命令在管理操作日志中的操作者
+var cliActor = service.AdminActor{Username: "cli"}
+
+func newAdminServices(cfg *config.Config, appLogger *logger.Logger) (*adminServices, error) {
+	if cfg.Database.Driver == db.DriverMemory {
+		return nil, errors.New("admin commands require a persistent database, the memory driver is not supported")
+	}
+	container, err := app.NewAppContainer(cfg, appLogger)
+	if err != nil {
+		return nil, err
+	}
+	factory := container.DaoFactory
+	services := &adminServices{
+		container: container,
+		auth:      service.NewAuthService(factory.UserDAO, factory.TransactionManager, container.JwtHandler, nil, nil, container.ServiceLogger),
+		tokens: service.NewTokenService(
+			factory.RefreshTokenDAO,
+			factory.UserSessionDAO,
+			factory.UserDAO,
+			factory.TransactionManager,
+			container.JwtHandler,
+			container.Revocations,
+			cfg.JWT.RefreshTokenExpiry,
+			container.ServiceLogger,
+		),
+		logins: service.NewLoginGuard(factory.LoginAttemptDAO, cfg.LoginProtection, container.ServiceLogger),
+		groups: service.NewGroupsService(
+			factory.GroupDAO,
+			factory.GroupMemberDAO,
+			factory.JoinApplicationDAO,
+			factory.UserDAO,
+			factory.DenormalizationDAO,
+			factory.GroupRoleChangeDAO,
+			factory.GroupRoleDAO,
+			factory.TransactionManager,
+			container.ServiceLogger,
+		),
+		tasks: service.NewTaskService(
+			factory.TaskDAO,
+			factory.TaskRecordDAO,
+			factory.TransactionManager,
+			factory.GroupDAO,
+			factory.DenormalizationDAO,
+			factory.UserDAO,
+			container.ServiceLogger,
+		),
+		consistency: service.NewConsistencyService(factory.DenormalizationDAO, factory.TransactionManager, container.ServiceLogger),
+	}
+	services.admin = service.NewAdminService(
+		factory.UserDAO,
+		factory.GroupDAO,
+		factory.AdminActionDAO,
+		factory.PlatformStatsDAO,
+		factory.TransactionManager,
+		services.groups,
+		services.tokens,
+		service.NewPasswordService(
+			factory.UserDAO,
+			factory.PasswordResetTokenDAO,
+			factory.TransactionManager,
+			services.tokens,
+			container.PasswordResetSender,
+			cfg.PasswordReset.TokenExpiry,
+			container.ServiceLogger,
+		),
+		services.tasks,
+		services.logins,
+		cfg.Admin.UserIDs,
+		container.ServiceLogger,
+	)
+	return services, nil
+}
+
+// runAdmin 执行 user、group、task、recount-members、check-consistency 子命令
+func runAdmin(cfg *config.Config, appLogger *logger.Logger, command string, args []string) error {
+	services, err := newAdminServices(cfg, appLogger)
+	if err != nil {
+		return err
+	}
+	defer services.container.Close()
+	ctx := context.Background()
+
+	switch command {
+	case "user":
+		return services.runUser(ctx, args)
+	case "group":
+		return services.runGroup(ctx, args)
+	case "task":
+		return services.runTask(ctx, args)
+	case "recount-members":
+		return services.recountMembers(ctx, args)
+	case "check-consistency":
+		return services.checkConsistency(ctx, args)
+	default:
+		return fmt.Errorf("unknown command %q", command)
+	}
+}
+
+func (s *adminServices) runUser(ctx context.Context, args []string) error {
+	if len(args) < 2 {
+		return errors.New("usage: user create|reset-password <username> [password] | user revoke-sessions|unlock <username> | user set-role <username> user|super_admin")
+	}
+	action, username := args[0], args[1]
+	switch action {
+	case "revoke-sessions":
+		return s.revokeSessions(ctx, username)
+	case "unlock":
+		return s.unlockLogin(ctx, username)
+	case "set-role":
+		if len(args) != 3 {
+			return errors.New("usage: user set-role <username> user|super_admin")
+		}
+		return s.setPlatformRole(ctx, username, args[2])
+	}
+	password, err := passwordArg(args[2:])
+	if err != nil {
+		return err
+	}
+	switch action {
+	case "create":
+		if len(username) < minUsernameLength || len(username) > maxUsernameLength {
+			return fmt.Errorf("username must be %d-%d characters", minUsernameLength, maxUsernameLength)
+		}
+		user, err := s.auth.AuthRegister(ctx, username, password)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("created user %s (id %d)\n", user.Username, user.UserID)
+		return nil
+	case "reset-password":
+		user, err := s.lookupUser(ctx, username)
+		if err != nil {
+			return err
+		}
+		// 与修改密码一致，重置后吊销已有会话
+		if _, err := s.admin.ResetPassword(ctx, cliActor, user.UserID, password); err != nil {
+			return err
+		}
+		fmt.Printf("password of user %s has been reset, running servers revoke its sessions within %s\n",
+			username, s.container.Config.JWT.RevocationSyncInterval)
+		return nil
+	default:
+		return fmt.Errorf("unknown user action %q", action)
+	}
+}
+
+// revokeSessions 吊销用户的全部登录会话，运行中的服务在一个同步间隔内生效
+func (s *adminServices) revokeSessions(ctx context.Context, username string) error {
+	user, err := s.lookupUser(ctx, username)
+	if err != nil {
+		return err
+	}
+	if _, err := s.admin.RevokeSessions(ctx, cliActor, user.UserID); err != nil {
+		return err
+	}
+	fmt.Printf("all sessions of user %s have been revoked, running servers apply it within %s\n",
+		username, s.container.Config.JWT.RevocationSyncInterval)
+	return nil
+}
+
+// unlockLogin 清除用户名的登录失败计数与锁定，立即对所有实例生效
+func (s *adminServices) unlockLogin(ctx context.Context, username string) error {
+	unlocked, err := s.admin.UnlockLogin(ctx, cliActor, username, "")
+	if err != nil {
+		return err
+	}
+	if !unlocked {
+		fmt.Printf("user %s has no recorded login failures\n", username)
+		return nil
+	}
+	fmt.Printf("login lockout of user %s has been cleared\n", username)
+	return nil
+}
+
+// setPlatformRole 设置平台角色，用于指定第一个超级管理员
+func (s *adminServices) setPlatformRole(ctx context.Context, username, role string) error {
+	user, err := s.lookupUser(ctx, username)
+	if err != nil {
+		return err
+	}
+	if _, err := s.admin.SetPlatformRole(ctx, cliActor, user.UserID, role); err != nil {
+		return err
+	}
+	fmt.Printf("platform role of user %s is now %s\n", username, role)
+	return nil
+}
+
+// lookupUser 按用户名查找运维命令操作的用户
+func (s *adminServices) lookupUser(ctx context.Context, username string) (*models.User, error) {
+	user, err := s.container.DaoFactory.UserDAO.GetByUsername(ctx, username)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, appErrors.ErrUserNotFound
+		}
+		return nil, err
+	}
+	return user, nil
+}
+
+func (s *adminServices) runGroup(ctx context.Context, args []string) error {
+	if len(args) != 3 || args[0] != "transfer-owner" {
+		return errors.New("usage: group transfer-owner <groupID> <userID>")
+	}
+	groupID, err := idArg("groupID", args[1])
+	if err != nil {
+		return err
+	}
+	userID, err := idArg("userID", args[2])
+	if err != nil {
+		return err
+	}
+	group, err := s.admin.TransferGroupOwnership(ctx, cliActor, groupID, userID)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("group %d (%s) is now owned by %s (id %d)\n", group.GroupID, group.GroupName, group.CreatorName, group.CreatorID)
+	return nil
+}
+
+func (s *adminServices) runTask(ctx context.Context, args []string) error {
+	if len(args) != 2 || args[0] != "close" {
+		return errors.New("usage: task close <taskID>")
+	}
+	taskID, err := idArg("taskID", args[1])
+	if err != nil {
+		return err
+	}
+	task, err := s.admin.CloseTask(ctx, cliActor, taskID)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("task %d (%s) closed at %s\n", task.TaskID, task.TaskName, task.EndTime.Format(time.RFC3339))
+	return nil
+}
+
+func (s *adminServices) recountMembers(ctx context.Context, args []string) error {
+	var groupIDs []int
+	for _, arg := range args {
+		groupID, err := idArg("groupID", arg)
+		if err != nil {
+			return err
+		}
+		groupIDs = append(groupIDs, groupID)
+	}
+	results, err := s.admin.RecountMembers(ctx, cliActor, groupIDs...)
+	if err != nil {
+		return err
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "GROUP\tBEFORE\tAFTER\tFIXED")
+	fixed := 0
+	for _, result := range results {
+		changed := result.Before != result.After
+		if changed {
+			fixed++
+		}
+		fmt.Fprintf(w, "%d\t%d\t%d\t%t\n", result.GroupID, result.Before, result.After, changed)
+	}
+	w.Flush()
+	fmt.Printf("%d of %d groups fixed\n", fixed, len(results))
+	return nil
+}
+
+// checkConsistency 列出冗余列中与来源数据不一致的行数，--repair 时修复
+func (s *adminServices) checkConsistency(ctx context.Context, args []string) error {
+	repair := false
+	for _, arg := range args {
+		if arg != "--repair" {
+			return errors.New("usage: check-consistency [--repair]")
+		}
+		repair = true
+	}
+	drifts, err := s.consistency.Check(ctx)
+	if err != nil {
+		return err
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "TABLE\tCOLUMN\tSOURCE\tDRIFT")
+	var total int64
+	for _, drift := range drifts {
+		total += drift.Rows
+		fmt.Fprintf(w, "%s\t%s\t%s\t%d\n", drift.Table, drift.Column, drift.Source, drift.Rows)
+	}
+	w.Flush()
+	if total == 0 || !repair {
+		fmt.Printf("%d rows out of sync\n", total)
+		return nil
+	}
+	repaired, err := s.consistency.Repair(ctx)
+	if err != nil {
+		return err
+	}
+	var fixed int64
+	for _, drift := range repaired {
+		fixed += drift.Rows
+	}
+	fmt.Printf("%d rows repaired\n", fixed)
+	return nil
+}
+
+func idArg(name, raw string) (int, error) {
+	id, err := strconv.Atoi(raw)
+	if err != nil || id <= 0 {
+		return 0, fmt.Errorf("invalid %s %q", name, raw)
+	}
+	return id, nil
+}
+
+// passwordArg 未在命令行给出密码时从标准输入读取一行，避免密码出现在进程列表与shell历史中
+func passwordArg(args []string) (string, error) {
+	var password string
+	if len(args) > 0 {
+		password = args[0]
+	} else {
+		fmt.Fprint(os.Stderr, "password: ")
+		line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+		if err != nil && line == "" {
+			return "", fmt.Errorf("read password: %w", err)
+		}
+		password = strings.TrimRight(line, "\r\n")
+	}
+	if len(password) < minPasswordLength || len(password) > maxPasswordLength {
+		return "", fmt.Errorf("password must be %d-%d characters", minPasswordLength, maxPasswordLength)
+	}
+	return password, nil
+}
@@ -1,32 +1,128 @@
 package app
 
 import (
+	"TeamTickBackend/config"
 	db "TeamTickBackend/dal"
 	"TeamTickBackend/dal/dao"
 	"TeamTickBackend/pkg"
+	"TeamTickBackend/pkg/health"
+	"TeamTickBackend/pkg/logger"
+	"TeamTickBackend/pkg/metrics"
+	"TeamTickBackend/pkg/notify"
+	"TeamTickBackend/pkg/oidc"
+	"TeamTickBackend/pkg/revocation"
+	"context"
+	"fmt"
+	"log/slog"
 
 	"gorm.io/gorm"
 )
 
 type AppContainer struct {
-	Db         *gorm.DB
-	DaoFactory *dao.DAOFactory
-	JwtHandler pkg.JwtHandler
+	Config *config.Config
+	Logger *logger.Logger
+	// ServiceLogger 服务层日志，构造服务时传入
+	ServiceLogger *slog.Logger
+	Db            *gorm.DB
+	DaoFactory    *dao.DAOFactory
+	JwtHandler    pkg.JwtHandler
+	// Revocations 令牌吊销存储，JwtHandler 解析令牌时据此拒绝已吊销的令牌
+	Revocations *revocation.Store
+	// PasswordResetSender 重置密码令牌的投递渠道，功能关闭时为nil
+	PasswordResetSender notify.Sender
+	// OIDCProvider 统一身份认证的身份提供方，功能关闭时为nil
+	OIDCProvider oidc.Provider
+	Health       *health.Registry
+	Metrics      *metrics.Metrics
 }
 
-func NewAppContainer() *AppContainer {
-	db := db.InitDB()
-	if db == nil {
-		panic("Failed to initialize database")
+// NewAppContainer 按配置初始化所有依赖，log 同时被设置为全局Logger
+func NewAppContainer(cfg *config.Config, log *logger.Logger) (*AppContainer, error) {
+	logger.SetDefault(log)
+
+	var gormDB *gorm.DB
+	var daoFactory *dao.DAOFactory
+	if cfg.Database.Driver == db.DriverMemory {
+		daoFactory = dao.NewMemoryDAOFactory()
+	} else {
+		var err error
+		gormDB, err = db.InitDB(cfg.Database, log.Module("gorm"))
+		if err != nil {
+			return nil, fmt.Errorf("initialize database: %w", err)
+		}
+		daoFactory = dao.NewDAOFactory(gormDB)
+	}
+	appMetrics := metrics.New()
+	daoFactory.TransactionManager = dao.WithObserver(daoFactory.TransactionManager, appMetrics.ObserveTransaction)
+	if gormDB != nil {
+		sqlDB, err := gormDB.DB()
+		if err != nil {
+			return nil, fmt.Errorf("get sql.DB: %w", err)
+		}
+		appMetrics.RegisterDB(cfg.Database.Driver, sqlDB)
 	}
-	daoFactory := dao.NewDAOFactory(db)
-	jwtHandler, err := pkg.NewJwtHandler()
+	revocations := revocation.NewStore(
+		daoFactory.TokenRevocationDAO,
+		cfg.JWT.TokenExpiry,
+		cfg.JWT.RevocationSyncInterval,
+		log.Module("auth"),
+	)
+	if err := revocations.Sync(context.Background()); err != nil {
+		return nil, fmt.Errorf("load token revocations: %w", err)
+	}
+	jwtHandler, err := pkg.NewJwtHandler(&cfg.JWT, revocations, log.Module("auth"))
 	if err != nil {
-		panic("Failed to initialize JWT handler")
+		return nil, fmt.Errorf("initialize JWT handler: %w", err)
+	}
+	var passwordResetSender notify.Sender
+	if cfg.PasswordReset.Enabled {
+		passwordResetSender, err = notify.NewSender(cfg.PasswordReset, log.Module("notify"))
+		if err != nil {
+			return nil, fmt.Errorf("initialize password reset sender: %w", err)
+		}
+	}
+	var oidcProvider oidc.Provider
+	if cfg.OIDC.Enabled {
+		oidcProvider = oidc.NewProvider(cfg.OIDC)
+	}
+	container := &AppContainer{
+		Config:              cfg,
+		Logger:              log,
+		ServiceLogger:       log.Module("services"),
+		Db:                  gormDB,
+		DaoFactory:          daoFactory,
+		JwtHandler:          jwtHandler,
+		Revocations:         revocations,
+		PasswordResetSender: passwordResetSender,
+		OIDCProvider:        oidcProvider,
+		Health:              health.NewRegistry(),
+		Metrics:             appMetrics,
+	}
+	if gormDB != nil {
+		container.RegisterReadinessCheck("database", func(ctx context.Context) error {
+			sqlDB, err := gormDB.DB()
+			if err != nil {
+				return err
+			}
+			return sqlDB.PingContext(ctx)
+		})
 	}
-	return &AppContainer{
-		Db:         db,
-		DaoFactory: daoFactory,
-		JwtHandler: jwtHandler,
+	return container, nil
+}
+
+// RegisterReadinessCheck 注册 /readyz 的就绪检查，新的子系统在此注册其依赖
+func (c *AppContainer) RegisterReadinessCheck(name string, check health.CheckFunc) {
+	c.Health.Register(name, check)
+}
+
+// Close 释放容器持有的资源，在HTTP服务停止后调用
+func (c *AppContainer) Close() error {
+	if c.Db == nil {
+		return nil
+	}
+	sqlDB, err := c.Db.DB()
+	if err != nil {
+		return err
 	}
+	return sqlDB.Close()
 }
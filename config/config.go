@@ -0,0 +1,536 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// 环境变量覆盖前缀，例如 TEAMTICK_DATABASE_DSN 覆盖 database.dsn
+const envPrefix = "TEAMTICK"
+
+// Config 应用的全部配置
+type Config struct {
+	Env      string         `yaml:"env" toml:"env"`
+	Server   ServerConfig   `yaml:"server" toml:"server"`
+	Database DatabaseConfig `yaml:"database" toml:"database"`
+	Log      LogConfig      `yaml:"log" toml:"log"`
+	JWT      JWTConfig      `yaml:"jwt" toml:"jwt"`
+	Features FeatureConfig  `yaml:"features" toml:"features"`
+	Metrics  MetricsConfig  `yaml:"metrics" toml:"metrics"`
+	// PasswordReset 自助重置密码
+	PasswordReset PasswordResetConfig `yaml:"password_reset" toml:"password_reset"`
+	// LoginProtection 登录防暴力破解
+	LoginProtection LoginProtectionConfig `yaml:"login_protection" toml:"login_protection"`
+	Admin           AdminConfig           `yaml:"admin" toml:"admin"`
+	TwoFactor       TwoFactorConfig       `yaml:"two_factor" toml:"two_factor"`
+	// OIDC 通过学校统一身份认证（OpenID Connect）登录
+	OIDC OIDCConfig `yaml:"oidc" toml:"oidc"`
+	// AccessTokens 用户自行创建的个人访问令牌，供脚本和第三方集成调用API
+	AccessTokens AccessTokensConfig `yaml:"access_tokens" toml:"access_tokens"`
+	// Consistency 冗余存储的用户名、组名、任务名的定期检查
+	Consistency ConsistencyConfig `yaml:"consistency" toml:"consistency"`
+	// GroupInvites 用户组邀请码与邀请链接
+	GroupInvites GroupInvitesConfig `yaml:"group_invites" toml:"group_invites"`
+}
+
+// ServerConfig HTTP服务配置
+type ServerConfig struct {
+	Addr         string        `yaml:"addr" toml:"addr"`
+	Mode         string        `yaml:"mode" toml:"mode"`
+	ReadTimeout  time.Duration `yaml:"read_timeout" toml:"read_timeout"`
+	WriteTimeout time.Duration `yaml:"write_timeout" toml:"write_timeout"`
+	// ShutdownTimeout 收到退出信号后等待进行中请求完成的最长时间
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout" toml:"shutdown_timeout"`
+	// TrustedProxies 可信反向代理的IP或CIDR，仅信任来自这些地址的 X-Forwarded-For；为空时直接使用连接地址
+	TrustedProxies []string `yaml:"trusted_proxies" toml:"trusted_proxies"`
+}
+
+// DatabaseConfig 数据库连接配置
+type DatabaseConfig struct {
+	Driver          string        `yaml:"driver" toml:"driver"`
+	DSN             string        `yaml:"dsn" toml:"dsn"`
+	MaxIdleConns    int           `yaml:"max_idle_conns" toml:"max_idle_conns"`
+	MaxOpenConns    int           `yaml:"max_open_conns" toml:"max_open_conns"`
+	ConnMaxLifetime time.Duration `yaml:"conn_max_lifetime" toml:"conn_max_lifetime"`
+	LogLevel        string        `yaml:"log_level" toml:"log_level"`
+	// SlowThreshold 超过该耗时的SQL以warn级别记录
+	SlowThreshold time.Duration `yaml:"slow_threshold" toml:"slow_threshold"`
+	// AutoMigrate 启动时自动执行未执行的迁移，仅建议在开发环境开启
+	AutoMigrate bool `yaml:"auto_migrate" toml:"auto_migrate"`
+}
+
+// LogConfig 日志配置
+type LogConfig struct {
+	Level  string `yaml:"level" toml:"level"`
+	Format string `yaml:"format" toml:"format"`
+	// Modules 按模块覆盖日志级别，例如 gorm: debug 输出所有SQL
+	Modules map[string]string `yaml:"modules" toml:"modules"`
+}
+
+// MetricsConfig Prometheus指标配置
+type MetricsConfig struct {
+	Enabled bool   `yaml:"enabled" toml:"enabled"`
+	Path    string `yaml:"path" toml:"path"`
+}
+
+// PasswordResetConfig 自助重置密码配置
+type PasswordResetConfig struct {
+	Enabled bool `yaml:"enabled" toml:"enabled"`
+	// Sender 重置令牌的投递方式：log 写入日志，file 追加到 FilePath，二者仅用于开发环境
+	Sender      string        `yaml:"sender" toml:"sender"`
+	FilePath    string        `yaml:"file_path" toml:"file_path"`
+	TokenExpiry time.Duration `yaml:"token_expiry" toml:"token_expiry"`
+}
+
+// LoginProtectionConfig 登录失败按用户名与IP分别计数，状态保存在数据库中，多实例共享
+type LoginProtectionConfig struct {
+	Enabled bool `yaml:"enabled" toml:"enabled"`
+	// FreeAttempts 同一用户名连续失败该次数以内不限制，之后每次失败的等待时间从 BaseDelay 起翻倍
+	FreeAttempts int           `yaml:"free_attempts" toml:"free_attempts"`
+	BaseDelay    time.Duration `yaml:"base_delay" toml:"base_delay"`
+	MaxDelay     time.Duration `yaml:"max_delay" toml:"max_delay"`
+	// LockoutThreshold 同一用户名连续失败达到该次数后锁定 LockoutDuration
+	LockoutThreshold int           `yaml:"lockout_threshold" toml:"lockout_threshold"`
+	LockoutDuration  time.Duration `yaml:"lockout_duration" toml:"lockout_duration"`
+	// IPLockoutThreshold 同一IP失败达到该次数后锁定该IP，NAT后的多个用户共享计数，因此阈值较高
+	IPLockoutThreshold int `yaml:"ip_lockout_threshold" toml:"ip_lockout_threshold"`
+	// FailureWindow 距上次失败超过该时间后重新计数
+	FailureWindow time.Duration `yaml:"failure_window" toml:"failure_window"`
+}
+
+// TwoFactorConfig TOTP两步验证
+type TwoFactorConfig struct {
+	// Issuer 显示在验证器应用中的服务名称
+	Issuer string `yaml:"issuer" toml:"issuer"`
+	// ChallengeExpiry 两步登录中密码校验通过后提交验证码的时限
+	ChallengeExpiry time.Duration `yaml:"challenge_expiry" toml:"challenge_expiry"`
+	// MaxChallengeAttempts 同一次登录挑战允许的验证码错误次数
+	MaxChallengeAttempts int `yaml:"max_challenge_attempts" toml:"max_challenge_attempts"`
+	// RecoveryCodes 每次生成的恢复码数量
+	RecoveryCodes int `yaml:"recovery_codes" toml:"recovery_codes"`
+}
+
+// OIDCConfig OpenID Connect 授权码登录（PKCE）配置
+type OIDCConfig struct {
+	Enabled bool `yaml:"enabled" toml:"enabled"`
+	// IssuerURL 身份提供方地址，启动后从 {IssuerURL}/.well-known/openid-configuration 获取端点
+	IssuerURL string `yaml:"issuer_url" toml:"issuer_url"`
+	ClientID  string `yaml:"client_id" toml:"client_id"`
+	// ClientSecret 机密客户端的密钥，公共客户端留空，仅依赖 PKCE
+	ClientSecret string `yaml:"client_secret" toml:"client_secret"`
+	// RedirectURL 在身份提供方登记的前端回调页面，前端取得 code 和 state 后调用 /auth/oidc/callback
+	RedirectURL string   `yaml:"redirect_url" toml:"redirect_url"`
+	Scopes      []string `yaml:"scopes" toml:"scopes"`
+	// UsernameClaim 自动创建用户时用作用户名的声明，缺失或不可用时生成随机用户名
+	UsernameClaim string `yaml:"username_claim" toml:"username_claim"`
+	// AutoProvision 首次登录的身份没有关联用户时自动创建用户，关闭后只能由已登录用户主动关联
+	AutoProvision bool `yaml:"auto_provision" toml:"auto_provision"`
+	// StateExpiry 发起授权到回调完成的时限
+	StateExpiry time.Duration `yaml:"state_expiry" toml:"state_expiry"`
+}
+
+// AccessTokensConfig 个人访问令牌配置
+type AccessTokensConfig struct {
+	Enabled bool `yaml:"enabled" toml:"enabled"`
+	// DefaultExpiry 创建时未指定有效期时使用，令牌必须有过期时间
+	DefaultExpiry time.Duration `yaml:"default_expiry" toml:"default_expiry"`
+	// MaxExpiry 允许的最长有效期
+	MaxExpiry time.Duration `yaml:"max_expiry" toml:"max_expiry"`
+	// MaxPerUser 每个用户同时有效的令牌数量上限
+	MaxPerUser int `yaml:"max_per_user" toml:"max_per_user"`
+}
+
+// ConsistencyConfig 冗余列一致性检查配置
+type ConsistencyConfig struct {
+	// CheckInterval 服务运行时的检查间隔，为0时不定期检查，仍可使用 check-consistency 命令
+	CheckInterval time.Duration `yaml:"check_interval" toml:"check_interval"`
+	// Repair 发现不一致时按来源数据修复，关闭时只记录日志
+	Repair bool `yaml:"repair" toml:"repair"`
+}
+
+// GroupInvitesConfig 用户组邀请码配置
+type GroupInvitesConfig struct {
+	// LinkBaseURL 前端加入页面地址，邀请链接为该地址加上 code 查询参数；为空时只返回邀请码
+	LinkBaseURL string `yaml:"link_base_url" toml:"link_base_url"`
+	// MaxPerGroup 每个用户组同时有效的邀请码数量上限
+	MaxPerGroup int `yaml:"max_per_group" toml:"max_per_group"`
+}
+
+// AdminConfig 平台管理员
+type AdminConfig struct {
+	// UserIDs 可以调用 /admin 接口的用户ID，不受平台角色影响；平台角色为 super_admin 的用户同样可以调用
+	UserIDs []int `yaml:"user_ids" toml:"user_ids"`
+}
+
+// FeatureConfig 功能开关
+type FeatureConfig struct {
+	AllowRegistration bool `yaml:"allow_registration" toml:"allow_registration"`
+}
+
+// Default 返回开发环境下的默认配置
+func Default() *Config {
+	return &Config{
+		Env: "development",
+		Server: ServerConfig{
+			Addr:            ":8080",
+			Mode:            "debug",
+			ReadTimeout:     15 * time.Second,
+			WriteTimeout:    15 * time.Second,
+			ShutdownTimeout: 30 * time.Second,
+		},
+		Database: DatabaseConfig{
+			Driver:          "mysql",
+			DSN:             "root:root@tcp(localhost:3306)/teamtick?charset=utf8&parseTime=True&loc=Local",
+			MaxIdleConns:    10,
+			MaxOpenConns:    100,
+			ConnMaxLifetime: time.Hour,
+			LogLevel:        "info",
+			SlowThreshold:   200 * time.Millisecond,
+		},
+		Log: LogConfig{
+			Level:  "info",
+			Format: "text",
+		},
+		JWT: JWTConfig{
+			Issuer:                 "teamtick-backend",
+			TokenExpiry:            30 * time.Minute,
+			RefreshTokenExpiry:     30 * 24 * time.Hour,
+			RevocationSyncInterval: 10 * time.Second,
+		},
+		Features: FeatureConfig{
+			AllowRegistration: true,
+		},
+		Metrics: MetricsConfig{
+			Enabled: false,
+			Path:    "/metrics",
+		},
+		PasswordReset: PasswordResetConfig{
+			Enabled:     true,
+			Sender:      "log",
+			FilePath:    "password_resets.log",
+			TokenExpiry: 30 * time.Minute,
+		},
+		LoginProtection: LoginProtectionConfig{
+			Enabled:            true,
+			FreeAttempts:       3,
+			BaseDelay:          time.Second,
+			MaxDelay:           5 * time.Minute,
+			LockoutThreshold:   10,
+			LockoutDuration:    30 * time.Minute,
+			IPLockoutThreshold: 100,
+			FailureWindow:      time.Hour,
+		},
+		TwoFactor: TwoFactorConfig{
+			Issuer:               "TeamTick",
+			ChallengeExpiry:      5 * time.Minute,
+			MaxChallengeAttempts: 5,
+			RecoveryCodes:        10,
+		},
+		OIDC: OIDCConfig{
+			Scopes:        []string{"openid", "profile", "email"},
+			UsernameClaim: "preferred_username",
+			AutoProvision: true,
+			StateExpiry:   10 * time.Minute,
+		},
+		AccessTokens: AccessTokensConfig{
+			Enabled:       true,
+			DefaultExpiry: 90 * 24 * time.Hour,
+			MaxExpiry:     365 * 24 * time.Hour,
+			MaxPerUser:    20,
+		},
+		Consistency: ConsistencyConfig{
+			CheckInterval: time.Hour,
+			Repair:        true,
+		},
+		GroupInvites: GroupInvitesConfig{
+			MaxPerGroup: 50,
+		},
+	}
+}
+
+// Load 按 默认值 -> 配置文件 -> 环境变量 的顺序加载配置并校验，非生产环境未配置JWT密钥时使用开发密钥
+// path为空时不读取配置文件，仅使用默认值和环境变量
+func Load(path string) (*Config, error) {
+	cfg := Default()
+	if path != "" {
+		if err := loadFile(path, cfg); err != nil {
+			return nil, err
+		}
+	}
+	if err := applyEnv(cfg); err != nil {
+		return nil, err
+	}
+	cfg.JWT.applyDevSecret(cfg.IsProduction())
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// loadFile 根据扩展名解析YAML或TOML配置文件
+func loadFile(path string, cfg *Config) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("read config file %s: %w", path, err)
+	}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(content, cfg)
+	case ".toml":
+		err = toml.Unmarshal(content, cfg)
+	default:
+		return fmt.Errorf("unsupported config file type: %s", path)
+	}
+	if err != nil {
+		return fmt.Errorf("parse config file %s: %w", path, err)
+	}
+	return nil
+}
+
+// applyEnv 使用环境变量覆盖配置项
+func applyEnv(cfg *Config) error {
+	// 兼容旧的环境变量
+	if env := os.Getenv("APP_ENV"); env != "" {
+		cfg.Env = env
+	}
+	if secretKey := os.Getenv("JWT_SECRET_KEY"); secretKey != "" {
+		cfg.JWT.SecretKey = secretKey
+	}
+	if issuer := os.Getenv("JWT_ISSUER"); issuer != "" {
+		cfg.JWT.Issuer = issuer
+	}
+	if minutes := os.Getenv("JWT_EXPIRY_MINUTES"); minutes != "" {
+		if expiry, err := time.ParseDuration(minutes + "m"); err == nil {
+			cfg.JWT.TokenExpiry = expiry
+		}
+	}
+	return overrideFromEnv(reflect.ValueOf(cfg).Elem(), envPrefix)
+}
+
+// overrideFromEnv 递归遍历配置结构体，变量名由前缀和yaml标签拼接而成
+func overrideFromEnv(v reflect.Value, prefix string) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := strings.Split(field.Tag.Get("yaml"), ",")[0]
+		if tag == "" || tag == "-" {
+			continue
+		}
+		name := prefix + "_" + strings.ToUpper(tag)
+		fv := v.Field(i)
+		if fv.Kind() == reflect.Struct {
+			if err := overrideFromEnv(fv, name); err != nil {
+				return err
+			}
+			continue
+		}
+		raw, ok := os.LookupEnv(name)
+		if !ok {
+			continue
+		}
+		if err := setValue(fv, raw); err != nil {
+			return fmt.Errorf("invalid value for %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+func setValue(fv reflect.Value, raw string) error {
+	if fv.Type() == reflect.TypeOf(time.Duration(0)) {
+		d, err := time.ParseDuration(raw)
+		if err != nil {
+			return err
+		}
+		fv.SetInt(int64(d))
+		return nil
+	}
+	switch fv.Kind() {
+	case reflect.String:
+		fv.SetString(raw)
+	case reflect.Int, reflect.Int64:
+		n, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return err
+		}
+		fv.SetInt(n)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return err
+		}
+		fv.SetBool(b)
+	case reflect.Map:
+		// 格式为 key=value,key2=value2
+		if fv.Type().Key().Kind() != reflect.String || fv.Type().Elem().Kind() != reflect.String {
+			return fmt.Errorf("unsupported map type %s", fv.Type())
+		}
+		items := make(map[string]string)
+		for _, item := range strings.Split(raw, ",") {
+			key, value, ok := strings.Cut(strings.TrimSpace(item), "=")
+			if !ok || key == "" {
+				continue
+			}
+			items[strings.TrimSpace(key)] = strings.TrimSpace(value)
+		}
+		fv.Set(reflect.ValueOf(items))
+	case reflect.Slice:
+		// 格式为 item1,item2
+		items := reflect.MakeSlice(fv.Type(), 0, 0)
+		for _, item := range strings.Split(raw, ",") {
+			if item = strings.TrimSpace(item); item == "" {
+				continue
+			}
+			elem := reflect.New(fv.Type().Elem()).Elem()
+			if elem.Kind() != reflect.String && elem.Kind() != reflect.Int {
+				return fmt.Errorf("unsupported slice type %s", fv.Type())
+			}
+			if err := setValue(elem, item); err != nil {
+				return err
+			}
+			items = reflect.Append(items, elem)
+		}
+		fv.Set(items)
+	default:
+		return fmt.Errorf("unsupported type %s", fv.Type())
+	}
+	return nil
+}
+
+// IsProduction 是否为生产或预发环境
+func (c *Config) IsProduction() bool {
+	return c.Env == "production" || c.Env == "staging"
+}
+
+// Validate 启动时校验配置
+func (c *Config) Validate() error {
+	var errs []error
+	if c.Server.Addr == "" {
+		errs = append(errs, errors.New("server.addr is required"))
+	}
+	if !oneOf(c.Server.Mode, "debug", "release", "test") {
+		errs = append(errs, fmt.Errorf("server.mode must be one of debug, release, test, got %q", c.Server.Mode))
+	}
+	if c.Server.ReadTimeout < 0 || c.Server.WriteTimeout < 0 || c.Server.ShutdownTimeout < 0 {
+		errs = append(errs, errors.New("server timeouts must not be negative"))
+	}
+	if !oneOf(c.Database.Driver, "mysql", "postgres", "sqlite", "memory") {
+		errs = append(errs, fmt.Errorf("database.driver must be one of mysql, postgres, sqlite, memory, got %q", c.Database.Driver))
+	}
+	if c.Database.DSN == "" && c.Database.Driver != "memory" {
+		errs = append(errs, errors.New("database.dsn is required"))
+	}
+	if c.Database.MaxIdleConns < 0 || c.Database.MaxOpenConns < 0 {
+		errs = append(errs, errors.New("database connection pool sizes must not be negative"))
+	}
+	if c.Database.MaxOpenConns > 0 && c.Database.MaxIdleConns > c.Database.MaxOpenConns {
+		errs = append(errs, errors.New("database.max_idle_conns must not exceed database.max_open_conns"))
+	}
+	if !oneOf(c.Database.LogLevel, "silent", "error", "warn", "info") {
+		errs = append(errs, fmt.Errorf("database.log_level must be one of silent, error, warn, info, got %q", c.Database.LogLevel))
+	}
+	if !oneOf(c.Log.Level, "debug", "info", "warn", "error") {
+		errs = append(errs, fmt.Errorf("log.level must be one of debug, info, warn, error, got %q", c.Log.Level))
+	}
+	for module, level := range c.Log.Modules {
+		if !oneOf(level, "debug", "info", "warn", "error") {
+			errs = append(errs, fmt.Errorf("log.modules.%s must be one of debug, info, warn, error, got %q", module, level))
+		}
+	}
+	if !oneOf(c.Log.Format, "text", "json") {
+		errs = append(errs, fmt.Errorf("log.format must be text or json, got %q", c.Log.Format))
+	}
+	if c.Metrics.Enabled && !strings.HasPrefix(c.Metrics.Path, "/") {
+		errs = append(errs, fmt.Errorf("metrics.path must start with /, got %q", c.Metrics.Path))
+	}
+	if c.PasswordReset.Enabled {
+		if !oneOf(c.PasswordReset.Sender, "log", "file") {
+			errs = append(errs, fmt.Errorf("password_reset.sender must be log or file, got %q", c.PasswordReset.Sender))
+		} else if c.IsProduction() {
+			errs = append(errs, fmt.Errorf("password_reset.sender %q is for development only, disable password_reset in production until a real sender is configured", c.PasswordReset.Sender))
+		}
+		if c.PasswordReset.Sender == "file" && c.PasswordReset.FilePath == "" {
+			errs = append(errs, errors.New("password_reset.file_path is required for the file sender"))
+		}
+		if c.PasswordReset.TokenExpiry <= 0 {
+			errs = append(errs, errors.New("password_reset.token_expiry must be positive"))
+		}
+	}
+	for _, proxy := range c.Server.TrustedProxies {
+		if net.ParseIP(proxy) == nil {
+			if _, _, err := net.ParseCIDR(proxy); err != nil {
+				errs = append(errs, fmt.Errorf("server.trusted_proxies: invalid IP or CIDR %q", proxy))
+			}
+		}
+	}
+	if c.LoginProtection.Enabled {
+		p := c.LoginProtection
+		if p.FreeAttempts < 0 || p.LockoutThreshold <= 0 || p.IPLockoutThreshold <= 0 {
+			errs = append(errs, errors.New("login_protection attempts and thresholds must be positive"))
+		}
+		if p.BaseDelay <= 0 || p.MaxDelay < p.BaseDelay || p.LockoutDuration <= 0 || p.FailureWindow <= 0 {
+			errs = append(errs, errors.New("login_protection delays must be positive and max_delay must not be less than base_delay"))
+		}
+	}
+	if c.TwoFactor.Issuer == "" || strings.Contains(c.TwoFactor.Issuer, ":") {
+		errs = append(errs, errors.New("two_factor.issuer must be non-empty and must not contain ':'"))
+	}
+	if c.TwoFactor.ChallengeExpiry <= 0 || c.TwoFactor.MaxChallengeAttempts <= 0 || c.TwoFactor.RecoveryCodes <= 0 {
+		errs = append(errs, errors.New("two_factor challenge_expiry, max_challenge_attempts and recovery_codes must be positive"))
+	}
+	if c.OIDC.Enabled {
+		if u, err := url.Parse(c.OIDC.IssuerURL); err != nil || !oneOf(u.Scheme, "http", "https") || u.Host == "" {
+			errs = append(errs, fmt.Errorf("oidc.issuer_url must be an http(s) URL, got %q", c.OIDC.IssuerURL))
+		}
+		if c.OIDC.ClientID == "" || c.OIDC.RedirectURL == "" {
+			errs = append(errs, errors.New("oidc.client_id and oidc.redirect_url are required"))
+		}
+		if !slices.Contains(c.OIDC.Scopes, "openid") {
+			errs = append(errs, errors.New("oidc.scopes must include openid"))
+		}
+		if c.OIDC.StateExpiry <= 0 {
+			errs = append(errs, errors.New("oidc.state_expiry must be positive"))
+		}
+	}
+	if c.AccessTokens.Enabled {
+		t := c.AccessTokens
+		if t.DefaultExpiry <= 0 || t.MaxExpiry < t.DefaultExpiry || t.MaxPerUser <= 0 {
+			errs = append(errs, errors.New("access_tokens expiry and max_per_user must be positive and max_expiry must not be less than default_expiry"))
+		}
+	}
+	if c.Consistency.CheckInterval < 0 {
+		errs = append(errs, errors.New("consistency.check_interval must not be negative"))
+	}
+	if link := c.GroupInvites.LinkBaseURL; link != "" {
+		if u, err := url.Parse(link); err != nil || !oneOf(u.Scheme, "http", "https") || u.Host == "" {
+			errs = append(errs, fmt.Errorf("group_invites.link_base_url must be an http(s) URL, got %q", link))
+		}
+	}
+	if c.GroupInvites.MaxPerGroup <= 0 {
+		errs = append(errs, errors.New("group_invites.max_per_group must be positive"))
+	}
+	if err := c.JWT.validate(); err != nil {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+func oneOf(value string, options ...string) bool {
+	for _, option := range options {
+		if value == option {
+			return true
+		}
+	}
+	return false
+}
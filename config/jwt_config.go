@@ -2,46 +2,97 @@ package config
 
 import (
 	"errors"
-	"os"
+	"fmt"
 	"time"
 )
 
+// 非生产环境未配置密钥时使用的开发密钥
+const devSecretKey = "dev_secure_key_change_in_production_32chars"
+
 type JWTConfig struct {
-	SecretKey    []byte
-	Issuer       string
-	TokenExpiry  time.Duration
+	// SecretKey HS256共享密钥，未配置 Keys 时用于签发令牌；配置 Keys 后仅用于校验切换前签发的不带kid的令牌，可留空
+	SecretKey   string        `yaml:"secret_key" toml:"secret_key"`
+	Issuer      string        `yaml:"issuer" toml:"issuer"`
+	TokenExpiry time.Duration `yaml:"token_expiry" toml:"token_expiry"`
+	// RefreshTokenExpiry 刷新令牌有效期，每次刷新后重新计算
+	RefreshTokenExpiry time.Duration `yaml:"refresh_token_expiry" toml:"refresh_token_expiry"`
+	// RevocationSyncInterval 从数据库同步令牌吊销记录的间隔，即其它实例上的吊销最长生效延迟
+	RevocationSyncInterval time.Duration `yaml:"revocation_sync_interval" toml:"revocation_sync_interval"`
+	// Keys 非对称签名密钥，配置后使用已生效的密钥中 ActiveFrom 最晚的一个签发令牌，并通过 /.well-known/jwks.json 公开全部未过期的公钥
+	Keys []JWTKeyConfig `yaml:"keys" toml:"keys"`
 }
 
-// GetJWTConfig 获取JWT配置
-func GetJWTConfig() (*JWTConfig, error) {
-	// 从环境变量中读取密钥，优先级高
-	secretKey := os.Getenv("JWT_SECRET_KEY")
+// JWTKeyConfig 一个签名密钥，按 ActiveFrom 和 ExpiresAt 预先安排轮换
+type JWTKeyConfig struct {
+	// KID 写入令牌头部的密钥ID，校验时据此选择公钥
+	KID string `yaml:"kid" toml:"kid"`
+	// Algorithm RS256 或 EdDSA（Ed25519）
+	Algorithm string `yaml:"algorithm" toml:"algorithm"`
+	// PrivateKeyFile PEM格式私钥（PKCS#8，RSA也可为PKCS#1）
+	PrivateKeyFile string `yaml:"private_key_file" toml:"private_key_file"`
+	// PublicKeyFile PEM格式公钥（PKIX），只配置公钥的密钥仅用于校验，适用于已停止签发、等待旧令牌过期的密钥
+	PublicKeyFile string `yaml:"public_key_file" toml:"public_key_file"`
+	// ActiveFrom 开始用于签发的时间，为空表示立即生效；生效前已在JWKS中公开，便于其它服务提前缓存
+	ActiveFrom time.Time `yaml:"active_from" toml:"active_from"`
+	// ExpiresAt 之后不再接受该密钥签发的令牌并从JWKS中移除，为空表示不过期；应晚于下一个密钥生效时间至少一个 token_expiry
+	ExpiresAt time.Time `yaml:"expires_at" toml:"expires_at"`
+}
 
-	if secretKey == "" {
-		env := os.Getenv("APP_ENV")
-		if env != "production" && env != "staging" {
-			secretKey = "dev_secure_key_change_in_production_32chars"
-		} else {
-			return nil, errors.New("JWT_SECRET_KEY environment variable is not set")
-		}
+// applyDevSecret 非生产环境未配置任何密钥时回退到开发密钥，由 Load 在校验前调用
+func (c *JWTConfig) applyDevSecret(production bool) {
+	if !production && len(c.Keys) == 0 && c.SecretKey == "" {
+		c.SecretKey = devSecretKey
 	}
+}
 
-	tokenExpiry := 30 * time.Minute
-	if os.Getenv("JWT_EXPIRY_MINUTES") != "" {
-		if minutes, err := time.ParseDuration(os.Getenv("JWT_EXPIRY_MINUTES") + "m"); err == nil {
-			tokenExpiry = minutes
+// validate 校验JWT配置
+func (c *JWTConfig) validate() error {
+	if len(c.Keys) > 0 {
+		if err := validateKeys(c.Keys); err != nil {
+			return err
 		}
+	} else if c.SecretKey == "" {
+		return errors.New("jwt.secret_key (JWT_SECRET_KEY) is required in production and staging")
 	}
-
-	issuer := os.Getenv("JWT_ISSUER")
-	if issuer == "" {
-		issuer = "teamtick-backend"
+	if c.Issuer == "" {
+		return errors.New("jwt.issuer is required")
 	}
-
-	return &JWTConfig{
-		SecretKey:    []byte(secretKey),
-		Issuer:       issuer,
-		TokenExpiry:  tokenExpiry,
-	},nil
+	if c.TokenExpiry <= 0 {
+		return errors.New("jwt.token_expiry must be positive")
+	}
+	if c.RefreshTokenExpiry <= c.TokenExpiry {
+		return errors.New("jwt.refresh_token_expiry must be longer than jwt.token_expiry")
+	}
+	if c.RevocationSyncInterval <= 0 {
+		return errors.New("jwt.revocation_sync_interval must be positive")
+	}
+	return nil
 }
 
+func validateKeys(keys []JWTKeyConfig) error {
+	seen := make(map[string]bool, len(keys))
+	signing := false
+	for i, key := range keys {
+		if key.KID == "" {
+			return fmt.Errorf("jwt.keys[%d].kid is required", i)
+		}
+		if seen[key.KID] {
+			return fmt.Errorf("jwt.keys: duplicate kid %q", key.KID)
+		}
+		seen[key.KID] = true
+		if key.Algorithm != "RS256" && key.Algorithm != "EdDSA" {
+			return fmt.Errorf("jwt.keys[%s].algorithm must be RS256 or EdDSA, got %q", key.KID, key.Algorithm)
+		}
+		if (key.PrivateKeyFile == "") == (key.PublicKeyFile == "") {
+			return fmt.Errorf("jwt.keys[%s]: exactly one of private_key_file and public_key_file is required", key.KID)
+		}
+		if !key.ExpiresAt.IsZero() && !key.ExpiresAt.After(key.ActiveFrom) {
+			return fmt.Errorf("jwt.keys[%s].expires_at must be after active_from", key.KID)
+		}
+		signing = signing || key.PrivateKeyFile != ""
+	}
+	if !signing {
+		return errors.New("jwt.keys must contain at least one key with a private_key_file")
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+package impl
+
+import (
+	"TeamTickBackend/dal/models"
+	"context"
+
+	"gorm.io/gorm"
+)
+
+type AdminActionDAOMySQLImpl struct {
+	DB *gorm.DB
+}
+
+// Create 追加一条管理操作日志
+func (dao *AdminActionDAOMySQLImpl) Create(ctx context.Context, action *models.AdminAction, tx ...*gorm.DB) error {
+	db := dao.DB
+	if len(tx) > 0 && tx[0] != nil {
+		db = tx[0]
+	}
+	return db.WithContext(ctx).Create(action).Error
+}
+
+// List 按条件查询，按ID倒序（即时间倒序）分页
+func (dao *AdminActionDAOMySQLImpl) List(ctx context.Context, filter models.AdminActionFilter, offset, limit int, tx ...*gorm.DB) ([]*models.AdminAction, int64, error) {
+	db := dao.DB
+	if len(tx) > 0 && tx[0] != nil {
+		db = tx[0]
+	}
+	query := db.WithContext(ctx).Model(&models.AdminAction{})
+	if filter.ActorID > 0 {
+		query = query.Where("actor_id = ?", filter.ActorID)
+	}
+	if filter.Action != "" {
+		query = query.Where("action = ?", filter.Action)
+	}
+	if filter.TargetType != "" {
+		query = query.Where("target_type = ?", filter.TargetType)
+	}
+	if filter.TargetID > 0 {
+		query = query.Where("target_id = ?", filter.TargetID)
+	}
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var actions []*models.AdminAction
+	err := query.Order("id DESC").Offset(offset).Limit(limit).Find(&actions).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return actions, total, nil
+}
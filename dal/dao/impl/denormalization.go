@@ -0,0 +1,111 @@
+package impl
+
+import (
+	"TeamTickBackend/dal/models"
+	"context"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// denormalizedColumn 冗余存储的列：table.column 的值应等于 sourceTable 中 sourceKey = table.key 的行的 sourceColumn
+type denormalizedColumn struct {
+	table        string
+	column       string
+	key          string
+	source       string
+	sourceTable  string
+	sourceKey    string
+	sourceColumn string
+}
+
+// denormalizedColumns 所有冗余存储名称的列，新增冗余列时需同时加入此处与内存实现
+var denormalizedColumns = []denormalizedColumn{
+	{"groups", "creator_name", "creator_id", models.DenormSourceUser, "users", "user_id", "username"},
+	{"group_member", "username", "user_id", models.DenormSourceUser, "users", "user_id", "username"},
+	{"group_member", "group_name", "group_id", models.DenormSourceGroup, "groups", "group_id", "group_name"},
+	{"tasks_record", "username", "user_id", models.DenormSourceUser, "users", "user_id", "username"},
+	{"tasks_record", "group_name", "group_id", models.DenormSourceGroup, "groups", "group_id", "group_name"},
+	{"tasks_record", "task_name", "task_id", models.DenormSourceTask, "tasks", "task_id", "task_name"},
+	{"check_application", "username", "user_id", models.DenormSourceUser, "users", "user_id", "username"},
+	{"check_application", "admin_username", "admin_id", models.DenormSourceUser, "users", "user_id", "username"},
+	{"check_application", "task_name", "task_id", models.DenormSourceTask, "tasks", "task_id", "task_name"},
+	{"join_application", "username", "user_id", models.DenormSourceUser, "users", "user_id", "username"},
+	{"group_invites", "created_by_name", "created_by", models.DenormSourceUser, "users", "user_id", "username"},
+}
+
+type DenormalizationDAOMySQLImpl struct {
+	DB *gorm.DB
+}
+
+// CountDrift 统计每个冗余列中与来源数据不一致的行数
+// MySQL 默认排序规则下比较不区分大小写，只改变大小写的差异不会被统计
+func (dao *DenormalizationDAOMySQLImpl) CountDrift(ctx context.Context, tx ...*gorm.DB) ([]models.ColumnDrift, error) {
+	db := dao.DB
+	if len(tx) > 0 && tx[0] != nil {
+		db = tx[0]
+	}
+	drifts := make([]models.ColumnDrift, 0, len(denormalizedColumns))
+	for _, c := range denormalizedColumns {
+		var rows int64
+		query := "SELECT COUNT(*) FROM " + quoteIdent(db, c.table) + " WHERE " + c.mismatch(db)
+		if err := db.WithContext(ctx).Raw(query).Scan(&rows).Error; err != nil {
+			return nil, err
+		}
+		drifts = append(drifts, c.drift(rows))
+	}
+	return drifts, nil
+}
+
+// Repair 按来源数据重新填写冗余列
+// 指定 sourceID 时（改名后同步）无条件改写该来源的所有行，否则只改写不一致的行
+func (dao *DenormalizationDAOMySQLImpl) Repair(ctx context.Context, source string, sourceID int, tx ...*gorm.DB) ([]models.ColumnDrift, error) {
+	db := dao.DB
+	if len(tx) > 0 && tx[0] != nil {
+		db = tx[0]
+	}
+	var repaired []models.ColumnDrift
+	for _, c := range denormalizedColumns {
+		if source != "" && c.source != source {
+			continue
+		}
+		table := quoteIdent(db, c.table)
+		query := "UPDATE " + table + " SET " + quoteIdent(db, c.column) + " = (" + c.sourceValue(db) + ")"
+		var args []interface{}
+		if sourceID > 0 {
+			query += " WHERE " + table + "." + quoteIdent(db, c.key) + " = ? AND EXISTS (" + c.sourceValue(db) + ")"
+			args = append(args, sourceID)
+		} else {
+			query += " WHERE " + c.mismatch(db)
+		}
+		result := db.WithContext(ctx).Exec(query, args...)
+		if result.Error != nil {
+			return nil, result.Error
+		}
+		repaired = append(repaired, c.drift(result.RowsAffected))
+	}
+	return repaired, nil
+}
+
+// sourceValue 查询来源值的子查询，引用外层的 table.key
+func (c denormalizedColumn) sourceValue(db *gorm.DB) string {
+	return "SELECT src." + quoteIdent(db, c.sourceColumn) + " FROM " + quoteIdent(db, c.sourceTable) + " src" +
+		" WHERE src." + quoteIdent(db, c.sourceKey) + " = " + quoteIdent(db, c.table) + "." + quoteIdent(db, c.key)
+}
+
+// mismatch 来源行存在且值不同的条件
+func (c denormalizedColumn) mismatch(db *gorm.DB) string {
+	return "EXISTS (" + c.sourceValue(db) + " AND src." + quoteIdent(db, c.sourceColumn) +
+		" <> " + quoteIdent(db, c.table) + "." + quoteIdent(db, c.column) + ")"
+}
+
+func (c denormalizedColumn) drift(rows int64) models.ColumnDrift {
+	return models.ColumnDrift{Table: c.table, Column: c.column, Source: c.source, Rows: rows}
+}
+
+// quoteIdent 按数据库方言引用标识符，groups 在 MySQL 中是保留字
+func quoteIdent(db *gorm.DB, name string) string {
+	var buf strings.Builder
+	db.Dialector.QuoteTo(&buf, name)
+	return buf.String()
+}
@@ -0,0 +1,98 @@
+package impl
+
+import (
+	"TeamTickBackend/dal/models"
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type GroupInviteDAOMySQLImpl struct {
+	DB *gorm.DB
+}
+
+// Create 创建邀请码
+func (dao *GroupInviteDAOMySQLImpl) Create(ctx context.Context, invite *models.GroupInvite, tx ...*gorm.DB) error {
+	db := dao.DB
+	if len(tx) > 0 && tx[0] != nil {
+		db = tx[0]
+	}
+	return db.WithContext(ctx).Create(invite).Error
+}
+
+// GetByID 通过ID查询
+func (dao *GroupInviteDAOMySQLImpl) GetByID(ctx context.Context, id int, tx ...*gorm.DB) (*models.GroupInvite, error) {
+	var invite models.GroupInvite
+	db := dao.DB
+	if len(tx) > 0 && tx[0] != nil {
+		db = tx[0]
+	}
+	err := db.WithContext(ctx).Where("id = ?", id).First(&invite).Error
+	if err != nil {
+		return nil, err
+	}
+	return &invite, nil
+}
+
+// GetByCode 通过邀请码查询
+func (dao *GroupInviteDAOMySQLImpl) GetByCode(ctx context.Context, code string, tx ...*gorm.DB) (*models.GroupInvite, error) {
+	var invite models.GroupInvite
+	db := dao.DB
+	if len(tx) > 0 && tx[0] != nil {
+		db = tx[0]
+	}
+	err := db.WithContext(ctx).Where("code = ?", code).First(&invite).Error
+	if err != nil {
+		return nil, err
+	}
+	return &invite, nil
+}
+
+// ListByGroupID 查询用户组未撤销的邀请码，按创建时间倒序
+func (dao *GroupInviteDAOMySQLImpl) ListByGroupID(ctx context.Context, groupID int, tx ...*gorm.DB) ([]*models.GroupInvite, error) {
+	var invites []*models.GroupInvite
+	db := dao.DB
+	if len(tx) > 0 && tx[0] != nil {
+		db = tx[0]
+	}
+	err := db.WithContext(ctx).
+		Where("group_id = ? AND revoked_at IS NULL", groupID).
+		Order("id DESC").
+		Find(&invites).Error
+	return invites, err
+}
+
+// Revoke 以 revoked_at IS NULL 为条件更新，只能撤销本组的邀请码
+func (dao *GroupInviteDAOMySQLImpl) Revoke(ctx context.Context, id, groupID int, revokedAt time.Time, tx ...*gorm.DB) (bool, error) {
+	db := dao.DB
+	if len(tx) > 0 && tx[0] != nil {
+		db = tx[0]
+	}
+	result := db.WithContext(ctx).
+		Model(&models.GroupInvite{}).
+		Where("id = ? AND group_id = ? AND revoked_at IS NULL", id, groupID).
+		Update("revoked_at", revokedAt)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// ConsumeUse 将可用条件写在 UPDATE 的 WHERE 中，并发使用时不会超出次数上限
+func (dao *GroupInviteDAOMySQLImpl) ConsumeUse(ctx context.Context, id int, now time.Time, tx ...*gorm.DB) (bool, error) {
+	db := dao.DB
+	if len(tx) > 0 && tx[0] != nil {
+		db = tx[0]
+	}
+	result := db.WithContext(ctx).
+		Model(&models.GroupInvite{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Where("expires_at IS NULL OR expires_at > ?", now).
+		Where("max_uses = 0 OR uses < max_uses").
+		Update("uses", gorm.Expr("uses + 1"))
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
@@ -58,3 +58,61 @@ func (dao *GroupMemberDAOMySQLImpl) Delete(ctx context.Context, groupID int, use
 		Where("group_id = ? AND user_id = ?", groupID, userID).
 		Delete(&models.GroupMember{}).Error
 }
+
+// UpdateRole 更新组员角色
+func (dao *GroupMemberDAOMySQLImpl) UpdateRole(ctx context.Context, groupID, userID int, role string, tx ...*gorm.DB) error {
+	db := dao.DB
+	if len(tx) > 0 && tx[0] != nil {
+		db = tx[0]
+	}
+	return db.WithContext(ctx).
+		Model(&models.GroupMember{}).
+		Where("group_id = ? AND user_id = ?", groupID, userID).
+		Update("role", role).Error
+}
+
+// UpdateRoleID 设置组员的自定义角色
+func (dao *GroupMemberDAOMySQLImpl) UpdateRoleID(ctx context.Context, groupID, userID, roleID int, tx ...*gorm.DB) error {
+	db := dao.DB
+	if len(tx) > 0 && tx[0] != nil {
+		db = tx[0]
+	}
+	return db.WithContext(ctx).
+		Model(&models.GroupMember{}).
+		Where("group_id = ? AND user_id = ?", groupID, userID).
+		Update("role_id", roleID).Error
+}
+
+// GetMembersByGroupIDs 查询多个用户组的成员
+func (dao *GroupMemberDAOMySQLImpl) GetMembersByGroupIDs(ctx context.Context, groupIDs []int, tx ...*gorm.DB) ([]*models.GroupMember, error) {
+	var members []*models.GroupMember
+	if len(groupIDs) == 0 {
+		return members, nil
+	}
+	db := dao.DB
+	if len(tx) > 0 && tx[0] != nil {
+		db = tx[0]
+	}
+	err := db.WithContext(ctx).Where("group_id IN ?", groupIDs).Find(&members).Error
+	if err != nil {
+		return nil, err
+	}
+	return members, nil
+}
+
+// GetByUserIDAndGroupIDs 查询用户在指定用户组中的成员记录
+func (dao *GroupMemberDAOMySQLImpl) GetByUserIDAndGroupIDs(ctx context.Context, userID int, groupIDs []int, tx ...*gorm.DB) ([]*models.GroupMember, error) {
+	var members []*models.GroupMember
+	if len(groupIDs) == 0 {
+		return members, nil
+	}
+	db := dao.DB
+	if len(tx) > 0 && tx[0] != nil {
+		db = tx[0]
+	}
+	err := db.WithContext(ctx).Where("user_id = ? AND group_id IN ?", userID, groupIDs).Find(&members).Error
+	if err != nil {
+		return nil, err
+	}
+	return members, nil
+}
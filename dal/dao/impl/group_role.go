@@ -0,0 +1,72 @@
+package impl
+
+import (
+	"TeamTickBackend/dal/models"
+	"context"
+
+	"gorm.io/gorm"
+)
+
+type GroupRoleDAOMySQLImpl struct {
+	DB *gorm.DB
+}
+
+// Create 创建自定义角色
+func (dao *GroupRoleDAOMySQLImpl) Create(ctx context.Context, role *models.GroupRole, tx ...*gorm.DB) error {
+	db := dao.DB
+	if len(tx) > 0 && tx[0] != nil {
+		db = tx[0]
+	}
+	return db.WithContext(ctx).Create(role).Error
+}
+
+// GetByID 通过ID查询
+func (dao *GroupRoleDAOMySQLImpl) GetByID(ctx context.Context, id int, tx ...*gorm.DB) (*models.GroupRole, error) {
+	var role models.GroupRole
+	db := dao.DB
+	if len(tx) > 0 && tx[0] != nil {
+		db = tx[0]
+	}
+	err := db.WithContext(ctx).Where("id = ?", id).First(&role).Error
+	if err != nil {
+		return nil, err
+	}
+	return &role, nil
+}
+
+// ListByGroupID 查询用户组的全部自定义角色，按创建顺序
+func (dao *GroupRoleDAOMySQLImpl) ListByGroupID(ctx context.Context, groupID int, tx ...*gorm.DB) ([]*models.GroupRole, error) {
+	var roles []*models.GroupRole
+	db := dao.DB
+	if len(tx) > 0 && tx[0] != nil {
+		db = tx[0]
+	}
+	err := db.WithContext(ctx).Where("group_id = ?", groupID).Order("id ASC").Find(&roles).Error
+	return roles, err
+}
+
+// Update 更新角色的名称、说明与权限
+func (dao *GroupRoleDAOMySQLImpl) Update(ctx context.Context, role *models.GroupRole, tx ...*gorm.DB) error {
+	db := dao.DB
+	if len(tx) > 0 && tx[0] != nil {
+		db = tx[0]
+	}
+	return db.WithContext(ctx).
+		Model(&models.GroupRole{}).
+		Where("id = ?", role.ID).
+		Updates(map[string]interface{}{
+			"name":        role.Name,
+			"description": role.Description,
+			"permissions": role.Permissions,
+			"updated_at":  role.UpdatedAt,
+		}).Error
+}
+
+// Delete 删除自定义角色
+func (dao *GroupRoleDAOMySQLImpl) Delete(ctx context.Context, id int, tx ...*gorm.DB) error {
+	db := dao.DB
+	if len(tx) > 0 && tx[0] != nil {
+		db = tx[0]
+	}
+	return db.WithContext(ctx).Where("id = ?", id).Delete(&models.GroupRole{}).Error
+}
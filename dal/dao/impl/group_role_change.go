@@ -0,0 +1,40 @@
+package impl
+
+import (
+	"TeamTickBackend/dal/models"
+	"context"
+
+	"gorm.io/gorm"
+)
+
+type GroupRoleChangeDAOMySQLImpl struct {
+	DB *gorm.DB
+}
+
+// Create 追加一条角色变更记录
+func (dao *GroupRoleChangeDAOMySQLImpl) Create(ctx context.Context, change *models.GroupRoleChange, tx ...*gorm.DB) error {
+	db := dao.DB
+	if len(tx) > 0 && tx[0] != nil {
+		db = tx[0]
+	}
+	return db.WithContext(ctx).Create(change).Error
+}
+
+// ListByGroupID 按ID倒序（即时间倒序）分页
+func (dao *GroupRoleChangeDAOMySQLImpl) ListByGroupID(ctx context.Context, groupID, offset, limit int, tx ...*gorm.DB) ([]*models.GroupRoleChange, int64, error) {
+	db := dao.DB
+	if len(tx) > 0 && tx[0] != nil {
+		db = tx[0]
+	}
+	query := db.WithContext(ctx).Model(&models.GroupRoleChange{}).Where("group_id = ?", groupID)
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var changes []*models.GroupRoleChange
+	err := query.Order("id DESC").Offset(offset).Limit(limit).Find(&changes).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return changes, total, nil
+}
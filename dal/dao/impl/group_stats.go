@@ -0,0 +1,67 @@
+package impl
+
+import (
+	"TeamTickBackend/dal/models"
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type GroupStatsDAOMySQLImpl struct {
+	DB *gorm.DB
+}
+
+// Collect 按用户组分组统计任务数、进行中的任务数与签到次数
+func (dao *GroupStatsDAOMySQLImpl) Collect(ctx context.Context, groupIDs []int, now time.Time, tx ...*gorm.DB) (map[int]*models.GroupActivity, error) {
+	activities := make(map[int]*models.GroupActivity)
+	if len(groupIDs) == 0 {
+		return activities, nil
+	}
+	db := dao.DB
+	if len(tx) > 0 && tx[0] != nil {
+		db = tx[0]
+	}
+	db = db.WithContext(ctx)
+	activity := func(groupID int) *models.GroupActivity {
+		if activities[groupID] == nil {
+			activities[groupID] = &models.GroupActivity{}
+		}
+		return activities[groupID]
+	}
+
+	var tasks []struct {
+		GroupID     int
+		Tasks       int64
+		ActiveTasks int64
+	}
+	err := db.Model(&models.Task{}).
+		Select("group_id, COUNT(*) AS tasks, SUM(CASE WHEN ? BETWEEN start_time AND end_time THEN 1 ELSE 0 END) AS active_tasks", now).
+		Where("group_id IN ?", groupIDs).
+		Group("group_id").
+		Scan(&tasks).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range tasks {
+		activity(row.GroupID).Tasks = row.Tasks
+		activity(row.GroupID).ActiveTasks = row.ActiveTasks
+	}
+
+	var records []struct {
+		GroupID  int
+		CheckIns int64
+	}
+	err = db.Model(&models.TaskRecord{}).
+		Select("group_id, COUNT(*) AS check_ins").
+		Where("group_id IN ?", groupIDs).
+		Group("group_id").
+		Scan(&records).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range records {
+		activity(row.GroupID).CheckIns = row.CheckIns
+	}
+	return activities, nil
+}
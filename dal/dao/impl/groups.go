@@ -3,8 +3,10 @@ package impl
 import (
 	"TeamTickBackend/dal/models"
 	"context"
+	"strconv"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type GroupDAOMySQLImpl struct {
@@ -34,18 +36,19 @@ func (dao *GroupDAOMySQLImpl) GetByGroupID(ctx context.Context, groupID int, tx
 	return &group, nil
 }
 
-// GetGroupsByUserID 通过user_id获取用户所在的所有用户组
+// GetGroupsByUserID 通过user_id获取用户所在的所有用户组，以及用户作为管理员的用户组的全部下级用户组
 func (dao *GroupDAOMySQLImpl) GetGroupsByUserID(ctx context.Context, userID int, tx ...*gorm.DB) ([]*models.Group, error) {
 	var groups []*models.Group
 	db := dao.DB
 	if len(tx) > 0 && tx[0] != nil {
 		db = tx[0]
 	}
-	err := db.WithContext(ctx).Table("`groups` g").
-		Select("g.*").
-		Joins("JOIN group_member gm ON g.group_id = gm.group_id").
-		Where("gm.user_id = ?", userID).
-		Find(&groups).Error
+	query := groupTreeCTE(db, "managed", "SELECT group_id, 0 FROM group_member WHERE user_id = ? AND role = ?", false) +
+		" SELECT g.* FROM " + quoteIdent(db, models.Group{}.TableName()) + " g" +
+		" WHERE g.group_id IN (SELECT group_id FROM group_member WHERE user_id = ?)" +
+		" OR g.group_id IN (SELECT group_id FROM managed)" +
+		" ORDER BY g.group_id"
+	err := db.WithContext(ctx).Raw(query, userID, "admin", userID).Scan(&groups).Error
 	if err != nil {
 		return nil, err
 	}
@@ -53,6 +56,7 @@ func (dao *GroupDAOMySQLImpl) GetGroupsByUserID(ctx context.Context, userID int,
 }
 
 // GetGroupsByUserIDAndfilter 通过user_id和filter获取用户所在的所有用户组
+// created 包含用户作为管理员的用户组的全部下级用户组
 func (dao *GroupDAOMySQLImpl) GetGroupsByUserIDAndfilter(ctx context.Context, userID int, filter string, tx ...*gorm.DB) ([]*models.Group, error) {
 	var groups []*models.Group
 	db := dao.DB
@@ -61,17 +65,17 @@ func (dao *GroupDAOMySQLImpl) GetGroupsByUserIDAndfilter(ctx context.Context, us
 	}
 	if filter == "created" {
 		role := "admin"
-		err := db.WithContext(ctx).Table("`groups` g").
-			Select("g.*").
-			Joins("JOIN group_member gm ON g.group_id = gm.group_id").
-			Where("gm.user_id = ? AND gm.role = ?", userID, role).
-			Find(&groups).Error
+		query := groupTreeCTE(db, "managed", "SELECT group_id, 0 FROM group_member WHERE user_id = ? AND role = ?", false) +
+			" SELECT g.* FROM " + quoteIdent(db, models.Group{}.TableName()) + " g" +
+			" WHERE g.group_id IN (SELECT group_id FROM managed)" +
+			" ORDER BY g.group_id"
+		err := db.WithContext(ctx).Raw(query, userID, role).Scan(&groups).Error
 		if err != nil {
 			return nil, err
 		}
 	} else if filter == "joined" {
 		role := "member"
-		err := db.WithContext(ctx).Table("`groups` g").
+		err := db.WithContext(ctx).Table("? AS g", clause.Table{Name: models.Group{}.TableName()}).
 			Select("g.*").
 			Joins("JOIN group_member gm ON g.group_id = gm.group_id").
 			Where("gm.user_id = ? AND gm.role = ?", userID, role).
@@ -122,3 +126,164 @@ func (dao *GroupDAOMySQLImpl) Delete(ctx context.Context, groupID int, tx ...*go
 	}
 	return db.WithContext(ctx).Where("group_id = ?", groupID).Delete(&models.Group{}).Error
 }
+
+// List 查询所有用户组
+func (dao *GroupDAOMySQLImpl) List(ctx context.Context, tx ...*gorm.DB) ([]*models.Group, error) {
+	var groups []*models.Group
+	db := dao.DB
+	if len(tx) > 0 && tx[0] != nil {
+		db = tx[0]
+	}
+	err := db.WithContext(ctx).Order("group_id").Find(&groups).Error
+	if err != nil {
+		return nil, err
+	}
+	return groups, nil
+}
+
+// UpdateCreator 更新用户组创建者
+func (dao *GroupDAOMySQLImpl) UpdateCreator(ctx context.Context, groupID, creatorID int, creatorName string, tx ...*gorm.DB) error {
+	db := dao.DB
+	if len(tx) > 0 && tx[0] != nil {
+		db = tx[0]
+	}
+	return db.WithContext(ctx).
+		Model(&models.Group{}).
+		Where("group_id = ?", groupID).
+		Updates(map[string]interface{}{
+			"creator_id":   creatorID,
+			"creator_name": creatorName,
+		}).Error
+}
+
+// SetMemberNum 将组成员数量设置为指定值，用于按 group_member 重新统计
+func (dao *GroupDAOMySQLImpl) SetMemberNum(ctx context.Context, groupID, memberNum int, tx ...*gorm.DB) error {
+	db := dao.DB
+	if len(tx) > 0 && tx[0] != nil {
+		db = tx[0]
+	}
+	return db.WithContext(ctx).
+		Model(&models.Group{}).
+		Where("group_id = ?", groupID).
+		Update("member_num", memberNum).Error
+}
+
+// UpdateRequireAdmin2FA 更新是否要求组管理员开启两步验证
+func (dao *GroupDAOMySQLImpl) UpdateRequireAdmin2FA(ctx context.Context, groupID int, require bool, tx ...*gorm.DB) error {
+	db := dao.DB
+	if len(tx) > 0 && tx[0] != nil {
+		db = tx[0]
+	}
+	return db.WithContext(ctx).
+		Model(&models.Group{}).
+		Where("group_id = ?", groupID).
+		Update("require_admin_2fa", require).Error
+}
+
+// UpdateJoinPolicy 更新加入方式
+func (dao *GroupDAOMySQLImpl) UpdateJoinPolicy(ctx context.Context, groupID int, policy string, tx ...*gorm.DB) error {
+	db := dao.DB
+	if len(tx) > 0 && tx[0] != nil {
+		db = tx[0]
+	}
+	return db.WithContext(ctx).
+		Model(&models.Group{}).
+		Where("group_id = ?", groupID).
+		Update("join_policy", policy).Error
+}
+
+// Search 按名称或创建者用户名模糊查询，按用户组ID排序分页
+func (dao *GroupDAOMySQLImpl) Search(ctx context.Context, keyword string, offset, limit int, tx ...*gorm.DB) ([]*models.Group, int64, error) {
+	db := dao.DB
+	if len(tx) > 0 && tx[0] != nil {
+		db = tx[0]
+	}
+	query := db.WithContext(ctx).Model(&models.Group{})
+	if keyword != "" {
+		pattern := likePattern(keyword)
+		query = query.Where("group_name LIKE ? ESCAPE '!' OR creator_name LIKE ? ESCAPE '!'", pattern, pattern)
+	}
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var groups []*models.Group
+	err := query.Order("group_id").Offset(offset).Limit(limit).Find(&groups).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return groups, total, nil
+}
+
+// UpdateParent 设置上级用户组
+func (dao *GroupDAOMySQLImpl) UpdateParent(ctx context.Context, groupID, parentID int, tx ...*gorm.DB) error {
+	db := dao.DB
+	if len(tx) > 0 && tx[0] != nil {
+		db = tx[0]
+	}
+	return db.WithContext(ctx).
+		Model(&models.Group{}).
+		Where("group_id = ?", groupID).
+		Update("parent_id", parentID).Error
+}
+
+// ReparentChildren 将直接下级用户组移到新的上级用户组下
+func (dao *GroupDAOMySQLImpl) ReparentChildren(ctx context.Context, parentID, newParentID int, tx ...*gorm.DB) error {
+	db := dao.DB
+	if len(tx) > 0 && tx[0] != nil {
+		db = tx[0]
+	}
+	return db.WithContext(ctx).
+		Model(&models.Group{}).
+		Where("parent_id = ?", parentID).
+		Update("parent_id", newParentID).Error
+}
+
+// GetAncestors 通过递归查询获取全部上级用户组，由近及远排列
+func (dao *GroupDAOMySQLImpl) GetAncestors(ctx context.Context, groupID int, tx ...*gorm.DB) ([]*models.Group, error) {
+	var groups []*models.Group
+	db := dao.DB
+	if len(tx) > 0 && tx[0] != nil {
+		db = tx[0]
+	}
+	table := quoteIdent(db, models.Group{}.TableName())
+	query := groupTreeCTE(db, "ancestors", "SELECT parent_id, 1 FROM "+table+" WHERE group_id = ? AND parent_id <> 0", true) +
+		" SELECT g.* FROM " + table + " g JOIN ancestors a ON g.group_id = a.group_id" +
+		" ORDER BY a.depth"
+	err := db.WithContext(ctx).Raw(query, groupID).Scan(&groups).Error
+	if err != nil {
+		return nil, err
+	}
+	return groups, nil
+}
+
+// GetDescendants 通过递归查询获取全部下级用户组，按层级与用户组ID排列
+func (dao *GroupDAOMySQLImpl) GetDescendants(ctx context.Context, groupID int, tx ...*gorm.DB) ([]*models.Group, error) {
+	var groups []*models.Group
+	db := dao.DB
+	if len(tx) > 0 && tx[0] != nil {
+		db = tx[0]
+	}
+	table := quoteIdent(db, models.Group{}.TableName())
+	query := groupTreeCTE(db, "descendants", "SELECT group_id, 1 FROM "+table+" WHERE parent_id = ?", false) +
+		" SELECT g.* FROM " + table + " g JOIN descendants d ON g.group_id = d.group_id" +
+		" ORDER BY d.depth, g.group_id"
+	err := db.WithContext(ctx).Raw(query, groupID).Scan(&groups).Error
+	if err != nil {
+		return nil, err
+	}
+	return groups, nil
+}
+
+// groupTreeCTE 生成沿 parent_id 递归的 WITH 子句，结果为 name (group_id, depth)
+// seed 需选出起始的 (group_id, depth) 两列；up 为 true 时向上查找上级用户组，否则向下查找下级用户组
+// 递归层数以 GroupMaxDepth 为限，数据异常成环时也不会无限递归
+func groupTreeCTE(db *gorm.DB, name, seed string, up bool) string {
+	table := quoteIdent(db, models.Group{}.TableName())
+	step := "SELECT g.group_id, t.depth + 1 FROM " + table + " g JOIN " + name + " t ON g.parent_id = t.group_id WHERE "
+	if up {
+		step = "SELECT g.parent_id, t.depth + 1 FROM " + table + " g JOIN " + name + " t ON g.group_id = t.group_id WHERE g.parent_id <> 0 AND "
+	}
+	return "WITH RECURSIVE " + name + " (group_id, depth) AS (" + seed + " UNION ALL " + step +
+		"t.depth < " + strconv.Itoa(models.GroupMaxDepth) + ")"
+}
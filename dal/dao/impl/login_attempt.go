@@ -0,0 +1,93 @@
+package impl
+
+import (
+	"TeamTickBackend/dal/models"
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type LoginAttemptDAOMySQLImpl struct {
+	DB *gorm.DB
+}
+
+// Get 查询登录失败计数
+func (dao *LoginAttemptDAOMySQLImpl) Get(ctx context.Context, scope, subject string, tx ...*gorm.DB) (*models.LoginAttempt, error) {
+	var attempt models.LoginAttempt
+	db := dao.DB
+	if len(tx) > 0 && tx[0] != nil {
+		db = tx[0]
+	}
+	err := db.WithContext(ctx).Where("scope = ? AND subject = ?", scope, subject).First(&attempt).Error
+	if err != nil {
+		return nil, err
+	}
+	return &attempt, nil
+}
+
+// RecordFailure 首次失败时插入记录，(scope, subject) 已存在时在数据库中原子累加，多实例并发时计数不丢失
+func (dao *LoginAttemptDAOMySQLImpl) RecordFailure(ctx context.Context, scope, subject string, now, windowStart time.Time, tx ...*gorm.DB) (*models.LoginAttempt, error) {
+	db := dao.DB
+	if len(tx) > 0 && tx[0] != nil {
+		db = tx[0]
+	}
+	attempt := &models.LoginAttempt{Scope: scope, Subject: subject, Failures: 1, LastFailureAt: now}
+	result := db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(attempt)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 1 {
+		return attempt, nil
+	}
+	err := db.WithContext(ctx).
+		Model(&models.LoginAttempt{}).
+		Where("scope = ? AND subject = ?", scope, subject).
+		Updates(map[string]interface{}{
+			"failures":        gorm.Expr("CASE WHEN last_failure_at < ? THEN 1 ELSE failures + 1 END", windowStart),
+			"blocked_until":   gorm.Expr("CASE WHEN last_failure_at < ? THEN NULL ELSE blocked_until END", windowStart),
+			"last_failure_at": now,
+		}).Error
+	if err != nil {
+		return nil, err
+	}
+	return dao.Get(ctx, scope, subject, db)
+}
+
+// ExtendBlock 仅当新的截止时间更晚时更新
+func (dao *LoginAttemptDAOMySQLImpl) ExtendBlock(ctx context.Context, scope, subject string, until time.Time, tx ...*gorm.DB) error {
+	db := dao.DB
+	if len(tx) > 0 && tx[0] != nil {
+		db = tx[0]
+	}
+	return db.WithContext(ctx).
+		Model(&models.LoginAttempt{}).
+		Where("scope = ? AND subject = ? AND (blocked_until IS NULL OR blocked_until < ?)", scope, subject, until).
+		Update("blocked_until", until).Error
+}
+
+// Delete 删除登录失败计数
+func (dao *LoginAttemptDAOMySQLImpl) Delete(ctx context.Context, scope, subject string, tx ...*gorm.DB) (bool, error) {
+	db := dao.DB
+	if len(tx) > 0 && tx[0] != nil {
+		db = tx[0]
+	}
+	result := db.WithContext(ctx).Where("scope = ? AND subject = ?", scope, subject).Delete(&models.LoginAttempt{})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// DeleteStale 清理已失去作用的计数记录
+func (dao *LoginAttemptDAOMySQLImpl) DeleteStale(ctx context.Context, before time.Time, tx ...*gorm.DB) (int64, error) {
+	db := dao.DB
+	if len(tx) > 0 && tx[0] != nil {
+		db = tx[0]
+	}
+	result := db.WithContext(ctx).
+		Where("last_failure_at < ? AND (blocked_until IS NULL OR blocked_until < ?)", before, before).
+		Delete(&models.LoginAttempt{})
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,97 @@
+package impl
+
+import (
+	"TeamTickBackend/dal/models"
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type UserIdentityDAOMySQLImpl struct {
+	DB *gorm.DB
+}
+
+// GetByIssuerSubject 通过身份提供方和用户标识查询关联
+func (dao *UserIdentityDAOMySQLImpl) GetByIssuerSubject(ctx context.Context, issuer, subject string, tx ...*gorm.DB) (*models.UserIdentity, error) {
+	var identity models.UserIdentity
+	db := dao.DB
+	if len(tx) > 0 && tx[0] != nil {
+		db = tx[0]
+	}
+	err := db.WithContext(ctx).Where("issuer = ? AND subject = ?", issuer, subject).First(&identity).Error
+	if err != nil {
+		return nil, err
+	}
+	return &identity, nil
+}
+
+// Create 创建身份关联
+func (dao *UserIdentityDAOMySQLImpl) Create(ctx context.Context, identity *models.UserIdentity, tx ...*gorm.DB) error {
+	db := dao.DB
+	if len(tx) > 0 && tx[0] != nil {
+		db = tx[0]
+	}
+	return db.WithContext(ctx).Create(identity).Error
+}
+
+// GetByUserID 查询用户关联的全部外部身份
+func (dao *UserIdentityDAOMySQLImpl) GetByUserID(ctx context.Context, userID int, tx ...*gorm.DB) ([]*models.UserIdentity, error) {
+	var identities []*models.UserIdentity
+	db := dao.DB
+	if len(tx) > 0 && tx[0] != nil {
+		db = tx[0]
+	}
+	err := db.WithContext(ctx).Where("user_id = ?", userID).Order("id").Find(&identities).Error
+	return identities, err
+}
+
+type OIDCLoginStateDAOMySQLImpl struct {
+	DB *gorm.DB
+}
+
+// Create 保存授权请求状态
+func (dao *OIDCLoginStateDAOMySQLImpl) Create(ctx context.Context, state *models.OIDCLoginState, tx ...*gorm.DB) error {
+	db := dao.DB
+	if len(tx) > 0 && tx[0] != nil {
+		db = tx[0]
+	}
+	return db.WithContext(ctx).Create(state).Error
+}
+
+// GetByStateHash 通过state摘要查询授权请求状态
+func (dao *OIDCLoginStateDAOMySQLImpl) GetByStateHash(ctx context.Context, stateHash string, tx ...*gorm.DB) (*models.OIDCLoginState, error) {
+	var state models.OIDCLoginState
+	db := dao.DB
+	if len(tx) > 0 && tx[0] != nil {
+		db = tx[0]
+	}
+	err := db.WithContext(ctx).Where("state_hash = ?", stateHash).First(&state).Error
+	if err != nil {
+		return nil, err
+	}
+	return &state, nil
+}
+
+// Delete 删除授权请求状态，以影响行数判断是否由本次请求消费
+func (dao *OIDCLoginStateDAOMySQLImpl) Delete(ctx context.Context, id int, tx ...*gorm.DB) (bool, error) {
+	db := dao.DB
+	if len(tx) > 0 && tx[0] != nil {
+		db = tx[0]
+	}
+	result := db.WithContext(ctx).Where("id = ?", id).Delete(&models.OIDCLoginState{})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// DeleteExpired 清理已过期的授权请求状态
+func (dao *OIDCLoginStateDAOMySQLImpl) DeleteExpired(ctx context.Context, now time.Time, tx ...*gorm.DB) (int64, error) {
+	db := dao.DB
+	if len(tx) > 0 && tx[0] != nil {
+		db = tx[0]
+	}
+	result := db.WithContext(ctx).Where("expires_at <= ?", now).Delete(&models.OIDCLoginState{})
+	return result.RowsAffected, result.Error
+}
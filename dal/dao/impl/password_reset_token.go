@@ -0,0 +1,64 @@
+package impl
+
+import (
+	"TeamTickBackend/dal/models"
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type PasswordResetTokenDAOMySQLImpl struct {
+	DB *gorm.DB
+}
+
+// Create 创建重置密码令牌
+func (dao *PasswordResetTokenDAOMySQLImpl) Create(ctx context.Context, token *models.PasswordResetToken, tx ...*gorm.DB) error {
+	db := dao.DB
+	if len(tx) > 0 && tx[0] != nil {
+		db = tx[0]
+	}
+	return db.WithContext(ctx).Create(token).Error
+}
+
+// GetByTokenHash 通过令牌摘要查询重置密码令牌
+func (dao *PasswordResetTokenDAOMySQLImpl) GetByTokenHash(ctx context.Context, tokenHash string, tx ...*gorm.DB) (*models.PasswordResetToken, error) {
+	var token models.PasswordResetToken
+	db := dao.DB
+	if len(tx) > 0 && tx[0] != nil {
+		db = tx[0]
+	}
+	err := db.WithContext(ctx).Where("token_hash = ?", tokenHash).First(&token).Error
+	if err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+// MarkUsed 以 used_at IS NULL 为条件更新，并发请求中只有一个能成功
+func (dao *PasswordResetTokenDAOMySQLImpl) MarkUsed(ctx context.Context, id int, usedAt time.Time, tx ...*gorm.DB) (bool, error) {
+	db := dao.DB
+	if len(tx) > 0 && tx[0] != nil {
+		db = tx[0]
+	}
+	result := db.WithContext(ctx).
+		Model(&models.PasswordResetToken{}).
+		Where("id = ? AND used_at IS NULL", id).
+		Update("used_at", usedAt)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// InvalidateByUserID 作废用户所有未使用的令牌
+func (dao *PasswordResetTokenDAOMySQLImpl) InvalidateByUserID(ctx context.Context, userID int, usedAt time.Time, tx ...*gorm.DB) error {
+	db := dao.DB
+	if len(tx) > 0 && tx[0] != nil {
+		db = tx[0]
+	}
+	return db.WithContext(ctx).
+		Model(&models.PasswordResetToken{}).
+		Where("user_id = ? AND used_at IS NULL", userID).
+		Update("used_at", usedAt).Error
+}
@@ -0,0 +1,92 @@
+package impl
+
+import (
+	"TeamTickBackend/dal/models"
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type PersonalAccessTokenDAOMySQLImpl struct {
+	DB *gorm.DB
+}
+
+// Create 创建个人访问令牌
+func (dao *PersonalAccessTokenDAOMySQLImpl) Create(ctx context.Context, token *models.PersonalAccessToken, tx ...*gorm.DB) error {
+	db := dao.DB
+	if len(tx) > 0 && tx[0] != nil {
+		db = tx[0]
+	}
+	return db.WithContext(ctx).Create(token).Error
+}
+
+// GetByTokenHash 通过令牌摘要查询
+func (dao *PersonalAccessTokenDAOMySQLImpl) GetByTokenHash(ctx context.Context, tokenHash string, tx ...*gorm.DB) (*models.PersonalAccessToken, error) {
+	var token models.PersonalAccessToken
+	db := dao.DB
+	if len(tx) > 0 && tx[0] != nil {
+		db = tx[0]
+	}
+	err := db.WithContext(ctx).Where("token_hash = ?", tokenHash).First(&token).Error
+	if err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+// ListByUserID 查询用户未吊销的令牌，按创建时间倒序
+func (dao *PersonalAccessTokenDAOMySQLImpl) ListByUserID(ctx context.Context, userID int, tx ...*gorm.DB) ([]*models.PersonalAccessToken, error) {
+	var tokens []*models.PersonalAccessToken
+	db := dao.DB
+	if len(tx) > 0 && tx[0] != nil {
+		db = tx[0]
+	}
+	err := db.WithContext(ctx).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Order("id DESC").
+		Find(&tokens).Error
+	return tokens, err
+}
+
+// CountActiveByUserID 统计用户未吊销且未过期的令牌数量
+func (dao *PersonalAccessTokenDAOMySQLImpl) CountActiveByUserID(ctx context.Context, userID int, now time.Time, tx ...*gorm.DB) (int64, error) {
+	var count int64
+	db := dao.DB
+	if len(tx) > 0 && tx[0] != nil {
+		db = tx[0]
+	}
+	err := db.WithContext(ctx).
+		Model(&models.PersonalAccessToken{}).
+		Where("user_id = ? AND revoked_at IS NULL AND expires_at > ?", userID, now).
+		Count(&count).Error
+	return count, err
+}
+
+// Revoke 以 revoked_at IS NULL 为条件更新，只能吊销自己的令牌
+func (dao *PersonalAccessTokenDAOMySQLImpl) Revoke(ctx context.Context, id, userID int, revokedAt time.Time, tx ...*gorm.DB) (bool, error) {
+	db := dao.DB
+	if len(tx) > 0 && tx[0] != nil {
+		db = tx[0]
+	}
+	result := db.WithContext(ctx).
+		Model(&models.PersonalAccessToken{}).
+		Where("id = ? AND user_id = ? AND revoked_at IS NULL", id, userID).
+		Update("revoked_at", revokedAt)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// UpdateLastUsed 记录最近使用时间与IP
+func (dao *PersonalAccessTokenDAOMySQLImpl) UpdateLastUsed(ctx context.Context, id int, usedAt time.Time, ip string, tx ...*gorm.DB) error {
+	db := dao.DB
+	if len(tx) > 0 && tx[0] != nil {
+		db = tx[0]
+	}
+	return db.WithContext(ctx).
+		Model(&models.PersonalAccessToken{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{"last_used_at": usedAt, "last_used_ip": ip}).Error
+}
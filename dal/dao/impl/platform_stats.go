@@ -0,0 +1,44 @@
+package impl
+
+import (
+	"TeamTickBackend/dal/models"
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type PlatformStatsDAOMySQLImpl struct {
+	DB *gorm.DB
+}
+
+// Collect 逐项统计平台概况
+func (dao *PlatformStatsDAOMySQLImpl) Collect(ctx context.Context, now time.Time, tx ...*gorm.DB) (*models.PlatformStats, error) {
+	db := dao.DB
+	if len(tx) > 0 && tx[0] != nil {
+		db = tx[0]
+	}
+	db = db.WithContext(ctx)
+	var stats models.PlatformStats
+	counts := []struct {
+		target *int64
+		query  *gorm.DB
+	}{
+		{&stats.Users, db.Model(&models.User{})},
+		{&stats.DisabledUsers, db.Model(&models.User{}).Where("disabled_at IS NOT NULL")},
+		{&stats.SuperAdmins, db.Model(&models.User{}).Where("platform_role = ?", models.PlatformRoleSuperAdmin)},
+		{&stats.Groups, db.Model(&models.Group{})},
+		{&stats.Tasks, db.Model(&models.Task{})},
+		{&stats.ActiveTasks, db.Model(&models.Task{}).Where("? BETWEEN start_time AND end_time", now)},
+		{&stats.CheckIns, db.Model(&models.TaskRecord{})},
+		{&stats.CheckInsLast24h, db.Model(&models.TaskRecord{}).Where("signed_time > ?", now.Add(-24*time.Hour))},
+		{&stats.PendingAudits, db.Model(&models.CheckApplication{}).Where("status = ?", "pending")},
+		{&stats.PendingJoinRequests, db.Model(&models.JoinApplication{}).Where("status = ?", "pending")},
+	}
+	for _, c := range counts {
+		if err := c.query.Count(c.target).Error; err != nil {
+			return nil, err
+		}
+	}
+	return &stats, nil
+}
@@ -0,0 +1,76 @@
+package impl
+
+import (
+	"TeamTickBackend/dal/models"
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type RefreshTokenDAOMySQLImpl struct {
+	DB *gorm.DB
+}
+
+// Create 创建刷新令牌
+func (dao *RefreshTokenDAOMySQLImpl) Create(ctx context.Context, token *models.RefreshToken, tx ...*gorm.DB) error {
+	db := dao.DB
+	if len(tx) > 0 && tx[0] != nil {
+		db = tx[0]
+	}
+	return db.WithContext(ctx).Create(token).Error
+}
+
+// GetByTokenHash 通过令牌摘要查询刷新令牌
+func (dao *RefreshTokenDAOMySQLImpl) GetByTokenHash(ctx context.Context, tokenHash string, tx ...*gorm.DB) (*models.RefreshToken, error) {
+	var token models.RefreshToken
+	db := dao.DB
+	if len(tx) > 0 && tx[0] != nil {
+		db = tx[0]
+	}
+	err := db.WithContext(ctx).Where("token_hash = ?", tokenHash).First(&token).Error
+	if err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+// MarkUsed 以 used_at IS NULL 为条件更新，并发请求中只有一个能成功
+func (dao *RefreshTokenDAOMySQLImpl) MarkUsed(ctx context.Context, id int, usedAt time.Time, tx ...*gorm.DB) (bool, error) {
+	db := dao.DB
+	if len(tx) > 0 && tx[0] != nil {
+		db = tx[0]
+	}
+	result := db.WithContext(ctx).
+		Model(&models.RefreshToken{}).
+		Where("id = ? AND used_at IS NULL", id).
+		Update("used_at", usedAt)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// RevokeFamily 吊销令牌家族中所有未吊销的令牌
+func (dao *RefreshTokenDAOMySQLImpl) RevokeFamily(ctx context.Context, familyID string, revokedAt time.Time, tx ...*gorm.DB) error {
+	db := dao.DB
+	if len(tx) > 0 && tx[0] != nil {
+		db = tx[0]
+	}
+	return db.WithContext(ctx).
+		Model(&models.RefreshToken{}).
+		Where("family_id = ? AND revoked_at IS NULL", familyID).
+		Update("revoked_at", revokedAt).Error
+}
+
+// RevokeByUserID 吊销用户所有未吊销的刷新令牌
+func (dao *RefreshTokenDAOMySQLImpl) RevokeByUserID(ctx context.Context, userID int, revokedAt time.Time, tx ...*gorm.DB) error {
+	db := dao.DB
+	if len(tx) > 0 && tx[0] != nil {
+		db = tx[0]
+	}
+	return db.WithContext(ctx).
+		Model(&models.RefreshToken{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Update("revoked_at", revokedAt).Error
+}
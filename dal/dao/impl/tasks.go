@@ -42,7 +42,7 @@ func (dao *TaskDAOMySQLImpl) GetActiveTasksByGroupID(ctx context.Context, groupI
 	if len(tx) > 0 && tx[0] != nil {
 		db = tx[0]
 	}
-	err := db.WithContext(ctx).Where("group_id=? AND NOW() BETWEEN start_time AND end_time", groupID).Find(&tasks).Error
+	err := db.WithContext(ctx).Where("group_id=? AND ? BETWEEN start_time AND end_time", groupID, time.Now()).Find(&tasks).Error
 	if err != nil {
 		return nil, err
 	}
@@ -64,56 +64,42 @@ func (dao *TaskDAOMySQLImpl) GetEndedTasksByGroupID(ctx context.Context, groupID
 	return tasks, nil
 }
 
-// GetByUserID 获取用户当前所属的所有用户组的签到任务
+// GetByUserID 获取用户当前所属的所有用户组及其上级用户组的签到任务
 func (dao *TaskDAOMySQLImpl) GetByUserID(ctx context.Context, userID int, tx ...*gorm.DB) ([]*models.Task, error) {
 	var tasks []*models.Task
 	db := dao.DB
 	if len(tx) > 0 && tx[0] != nil {
 		db = tx[0]
 	}
-	err := db.WithContext(ctx).Table("tasks t").
-		Select("t.*").
-		Joins("JOIN group_member gm ON t.group_id = gm.group_id").
-		Where("gm.user_id = ?", userID).
-		Find(&tasks).Error
+	err := db.WithContext(ctx).Raw(userTasksQuery(db), userID).Scan(&tasks).Error
 	if err != nil {
 		return nil, err
 	}
 	return tasks, nil
 }
 
-// GetEndedTasksByUserID 获取用户当前所属的所有用户组的已结束任务
+// GetEndedTasksByUserID 获取用户当前所属的所有用户组及其上级用户组的已结束任务
 func (dao *TaskDAOMySQLImpl) GetEndedTasksByUserID(ctx context.Context, userID int, tx ...*gorm.DB) ([]*models.Task, error) {
 	var tasks []*models.Task
 	db := dao.DB
 	if len(tx) > 0 && tx[0] != nil {
 		db = tx[0]
 	}
-	err := db.WithContext(ctx).Table("tasks t").
-		Select("t.*").
-		Joins("JOIN group_member gm ON t.group_id = gm.group_id").
-		Where("gm.user_id = ? AND t.end_time<?", userID, time.Now()).
-		Order("t.end_time ASC").
-		Find(&tasks).Error
+	err := db.WithContext(ctx).Raw(userTasksQuery(db)+" AND t.end_time < ? ORDER BY t.end_time ASC", userID, time.Now()).Scan(&tasks).Error
 	if err != nil {
 		return nil, err
 	}
 	return tasks, nil
 }
 
-// GetActiveTasksByUserID 获取用户当前所属的所有用户组的待签到任务
+// GetActiveTasksByUserID 获取用户当前所属的所有用户组及其上级用户组的待签到任务
 func (dao *TaskDAOMySQLImpl) GetActiveTasksByUserID(ctx context.Context, userID int, tx ...*gorm.DB) ([]*models.Task, error) {
 	var tasks []*models.Task
 	db := dao.DB
 	if len(tx) > 0 && tx[0] != nil {
 		db = tx[0]
 	}
-	err := db.WithContext(ctx).Table("tasks t").
-		Select("t.*").
-		Joins("JOIN group_member gm ON t.group_id = gm.group_id").
-		Where("gm.user_id = ? AND NOW() BETWEEN t.start_time AND t.end_time", userID).
-		Order("t.end_time ASC").
-		Find(&tasks).Error
+	err := db.WithContext(ctx).Raw(userTasksQuery(db)+" AND ? BETWEEN t.start_time AND t.end_time ORDER BY t.end_time ASC", userID, time.Now()).Scan(&tasks).Error
 
 	if err != nil {
 		return nil, err
@@ -176,4 +162,10 @@ func (dao *TaskDAOMySQLImpl) Delete(ctx context.Context, taskID int, tx ...*gorm
 		db = tx[0]
 	}
 	return db.WithContext(ctx).Where("task_id=?", taskID).Delete(&models.Task{}).Error
-}
\ No newline at end of file
+}
+
+// userTasksQuery 查询用户所属用户组及其全部上级用户组的任务，上级用户组的任务对下级用户组成员同样生效
+func userTasksQuery(db *gorm.DB) string {
+	return groupTreeCTE(db, "tree", "SELECT group_id, 0 FROM group_member WHERE user_id = ?", true) +
+		" SELECT t.* FROM tasks t WHERE t.group_id IN (SELECT group_id FROM tree)"
+}
@@ -0,0 +1,85 @@
+package impl
+
+import (
+	"TeamTickBackend/dal/models"
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type TokenRevocationDAOMySQLImpl struct {
+	DB *gorm.DB
+}
+
+// Create 写入吊销记录，(kind, value) 冲突时忽略
+func (dao *TokenRevocationDAOMySQLImpl) Create(ctx context.Context, revocation *models.TokenRevocation, tx ...*gorm.DB) error {
+	db := dao.DB
+	if len(tx) > 0 && tx[0] != nil {
+		db = tx[0]
+	}
+	return db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(revocation).Error
+}
+
+// ListSince 查询 revoked_at 不早于since且在now时仍未过期的记录
+func (dao *TokenRevocationDAOMySQLImpl) ListSince(ctx context.Context, since, now time.Time, tx ...*gorm.DB) ([]*models.TokenRevocation, error) {
+	var revocations []*models.TokenRevocation
+	db := dao.DB
+	if len(tx) > 0 && tx[0] != nil {
+		db = tx[0]
+	}
+	err := db.WithContext(ctx).
+		Where("revoked_at >= ? AND expires_at > ?", since, now).
+		Find(&revocations).Error
+	if err != nil {
+		return nil, err
+	}
+	return revocations, nil
+}
+
+// DeleteExpired 清理已过期的吊销记录
+func (dao *TokenRevocationDAOMySQLImpl) DeleteExpired(ctx context.Context, now time.Time, tx ...*gorm.DB) (int64, error) {
+	db := dao.DB
+	if len(tx) > 0 && tx[0] != nil {
+		db = tx[0]
+	}
+	result := db.WithContext(ctx).Where("expires_at <= ?", now).Delete(&models.TokenRevocation{})
+	return result.RowsAffected, result.Error
+}
+
+// SaveUserCutoff 写入或更新用户级吊销时间
+func (dao *TokenRevocationDAOMySQLImpl) SaveUserCutoff(ctx context.Context, cutoff *models.UserTokenCutoff, tx ...*gorm.DB) error {
+	db := dao.DB
+	if len(tx) > 0 && tx[0] != nil {
+		db = tx[0]
+	}
+	return db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"revoked_before"}),
+	}).Create(cutoff).Error
+}
+
+// ListUserCutoffsSince 查询吊销时间不早于since的用户级吊销
+func (dao *TokenRevocationDAOMySQLImpl) ListUserCutoffsSince(ctx context.Context, since int64, tx ...*gorm.DB) ([]*models.UserTokenCutoff, error) {
+	var cutoffs []*models.UserTokenCutoff
+	db := dao.DB
+	if len(tx) > 0 && tx[0] != nil {
+		db = tx[0]
+	}
+	err := db.WithContext(ctx).Where("revoked_before >= ?", since).Find(&cutoffs).Error
+	if err != nil {
+		return nil, err
+	}
+	return cutoffs, nil
+}
+
+// DeleteUserCutoffsBefore 清理早于before的用户级吊销
+func (dao *TokenRevocationDAOMySQLImpl) DeleteUserCutoffsBefore(ctx context.Context, before int64, tx ...*gorm.DB) (int64, error) {
+	db := dao.DB
+	if len(tx) > 0 && tx[0] != nil {
+		db = tx[0]
+	}
+	result := db.WithContext(ctx).Where("revoked_before < ?", before).Delete(&models.UserTokenCutoff{})
+	return result.RowsAffected, result.Error
+}
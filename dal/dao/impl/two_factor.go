@@ -0,0 +1,194 @@
+package impl
+
+import (
+	"TeamTickBackend/dal/models"
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type TOTPSecretDAOMySQLImpl struct {
+	DB *gorm.DB
+}
+
+// Get 查询用户的TOTP密钥
+func (dao *TOTPSecretDAOMySQLImpl) Get(ctx context.Context, userID int, tx ...*gorm.DB) (*models.TOTPSecret, error) {
+	var secret models.TOTPSecret
+	db := dao.DB
+	if len(tx) > 0 && tx[0] != nil {
+		db = tx[0]
+	}
+	err := db.WithContext(ctx).Where("user_id = ?", userID).First(&secret).Error
+	if err != nil {
+		return nil, err
+	}
+	return &secret, nil
+}
+
+// Save 写入或替换用户的密钥，user_id 冲突时覆盖全部字段
+func (dao *TOTPSecretDAOMySQLImpl) Save(ctx context.Context, secret *models.TOTPSecret, tx ...*gorm.DB) error {
+	db := dao.DB
+	if len(tx) > 0 && tx[0] != nil {
+		db = tx[0]
+	}
+	return db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"secret", "confirmed_at", "last_used_step", "created_at"}),
+	}).Create(secret).Error
+}
+
+// Confirm 标记密钥已确认绑定
+func (dao *TOTPSecretDAOMySQLImpl) Confirm(ctx context.Context, userID int, confirmedAt time.Time, tx ...*gorm.DB) error {
+	db := dao.DB
+	if len(tx) > 0 && tx[0] != nil {
+		db = tx[0]
+	}
+	return db.WithContext(ctx).
+		Model(&models.TOTPSecret{}).
+		Where("user_id = ?", userID).
+		Update("confirmed_at", confirmedAt).Error
+}
+
+// AdvanceStep 以 last_used_step < step 为条件更新，并发提交同一验证码时只有一个能成功
+func (dao *TOTPSecretDAOMySQLImpl) AdvanceStep(ctx context.Context, userID int, step int64, tx ...*gorm.DB) (bool, error) {
+	db := dao.DB
+	if len(tx) > 0 && tx[0] != nil {
+		db = tx[0]
+	}
+	result := db.WithContext(ctx).
+		Model(&models.TOTPSecret{}).
+		Where("user_id = ? AND last_used_step < ?", userID, step).
+		Update("last_used_step", step)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// Delete 删除用户的TOTP密钥
+func (dao *TOTPSecretDAOMySQLImpl) Delete(ctx context.Context, userID int, tx ...*gorm.DB) error {
+	db := dao.DB
+	if len(tx) > 0 && tx[0] != nil {
+		db = tx[0]
+	}
+	return db.WithContext(ctx).Where("user_id = ?", userID).Delete(&models.TOTPSecret{}).Error
+}
+
+type RecoveryCodeDAOMySQLImpl struct {
+	DB *gorm.DB
+}
+
+// CreateBatch 批量创建恢复码
+func (dao *RecoveryCodeDAOMySQLImpl) CreateBatch(ctx context.Context, codes []*models.RecoveryCode, tx ...*gorm.DB) error {
+	db := dao.DB
+	if len(tx) > 0 && tx[0] != nil {
+		db = tx[0]
+	}
+	return db.WithContext(ctx).Create(codes).Error
+}
+
+// DeleteByUserID 删除用户的全部恢复码
+func (dao *RecoveryCodeDAOMySQLImpl) DeleteByUserID(ctx context.Context, userID int, tx ...*gorm.DB) error {
+	db := dao.DB
+	if len(tx) > 0 && tx[0] != nil {
+		db = tx[0]
+	}
+	return db.WithContext(ctx).Where("user_id = ?", userID).Delete(&models.RecoveryCode{}).Error
+}
+
+// MarkUsed 以 used_at IS NULL 为条件更新，恢复码只能使用一次
+func (dao *RecoveryCodeDAOMySQLImpl) MarkUsed(ctx context.Context, userID int, codeHash string, usedAt time.Time, tx ...*gorm.DB) (bool, error) {
+	db := dao.DB
+	if len(tx) > 0 && tx[0] != nil {
+		db = tx[0]
+	}
+	result := db.WithContext(ctx).
+		Model(&models.RecoveryCode{}).
+		Where("user_id = ? AND code_hash = ? AND used_at IS NULL", userID, codeHash).
+		Update("used_at", usedAt)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// CountUnused 统计用户未使用的恢复码数量
+func (dao *RecoveryCodeDAOMySQLImpl) CountUnused(ctx context.Context, userID int, tx ...*gorm.DB) (int64, error) {
+	var count int64
+	db := dao.DB
+	if len(tx) > 0 && tx[0] != nil {
+		db = tx[0]
+	}
+	err := db.WithContext(ctx).
+		Model(&models.RecoveryCode{}).
+		Where("user_id = ? AND used_at IS NULL", userID).
+		Count(&count).Error
+	return count, err
+}
+
+type MFAChallengeDAOMySQLImpl struct {
+	DB *gorm.DB
+}
+
+// Create 创建两步登录挑战
+func (dao *MFAChallengeDAOMySQLImpl) Create(ctx context.Context, challenge *models.MFAChallenge, tx ...*gorm.DB) error {
+	db := dao.DB
+	if len(tx) > 0 && tx[0] != nil {
+		db = tx[0]
+	}
+	return db.WithContext(ctx).Create(challenge).Error
+}
+
+// GetByTokenHash 通过令牌摘要查询挑战
+func (dao *MFAChallengeDAOMySQLImpl) GetByTokenHash(ctx context.Context, tokenHash string, tx ...*gorm.DB) (*models.MFAChallenge, error) {
+	var challenge models.MFAChallenge
+	db := dao.DB
+	if len(tx) > 0 && tx[0] != nil {
+		db = tx[0]
+	}
+	err := db.WithContext(ctx).Where("token_hash = ?", tokenHash).First(&challenge).Error
+	if err != nil {
+		return nil, err
+	}
+	return &challenge, nil
+}
+
+// IncrementAttempts 在数据库中原子累加验证码错误次数
+func (dao *MFAChallengeDAOMySQLImpl) IncrementAttempts(ctx context.Context, id int, tx ...*gorm.DB) error {
+	db := dao.DB
+	if len(tx) > 0 && tx[0] != nil {
+		db = tx[0]
+	}
+	return db.WithContext(ctx).
+		Model(&models.MFAChallenge{}).
+		Where("id = ?", id).
+		Update("attempts", gorm.Expr("attempts + 1")).Error
+}
+
+// MarkUsed 以 used_at IS NULL 为条件更新，挑战只能完成一次
+func (dao *MFAChallengeDAOMySQLImpl) MarkUsed(ctx context.Context, id int, usedAt time.Time, tx ...*gorm.DB) (bool, error) {
+	db := dao.DB
+	if len(tx) > 0 && tx[0] != nil {
+		db = tx[0]
+	}
+	result := db.WithContext(ctx).
+		Model(&models.MFAChallenge{}).
+		Where("id = ? AND used_at IS NULL", id).
+		Update("used_at", usedAt)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// DeleteExpired 清理已过期的挑战
+func (dao *MFAChallengeDAOMySQLImpl) DeleteExpired(ctx context.Context, now time.Time, tx ...*gorm.DB) (int64, error) {
+	db := dao.DB
+	if len(tx) > 0 && tx[0] != nil {
+		db = tx[0]
+	}
+	result := db.WithContext(ctx).Where("expires_at <= ?", now).Delete(&models.MFAChallenge{})
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,117 @@
+package impl
+
+import (
+	"TeamTickBackend/dal/models"
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type UserSessionDAOMySQLImpl struct {
+	DB *gorm.DB
+}
+
+// Create 创建登录会话
+func (dao *UserSessionDAOMySQLImpl) Create(ctx context.Context, session *models.UserSession, tx ...*gorm.DB) error {
+	db := dao.DB
+	if len(tx) > 0 && tx[0] != nil {
+		db = tx[0]
+	}
+	return db.WithContext(ctx).Create(session).Error
+}
+
+// GetByID 通过主键查询会话
+func (dao *UserSessionDAOMySQLImpl) GetByID(ctx context.Context, id int, tx ...*gorm.DB) (*models.UserSession, error) {
+	var session models.UserSession
+	db := dao.DB
+	if len(tx) > 0 && tx[0] != nil {
+		db = tx[0]
+	}
+	err := db.WithContext(ctx).Where("id = ?", id).First(&session).Error
+	if err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+// GetBySessionID 通过会话ID（sid）查询会话
+func (dao *UserSessionDAOMySQLImpl) GetBySessionID(ctx context.Context, sessionID string, tx ...*gorm.DB) (*models.UserSession, error) {
+	var session models.UserSession
+	db := dao.DB
+	if len(tx) > 0 && tx[0] != nil {
+		db = tx[0]
+	}
+	err := db.WithContext(ctx).Where("session_id = ?", sessionID).First(&session).Error
+	if err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+// ListActiveByUserID 查询用户未吊销且未过期的会话，按最近活跃时间倒序
+func (dao *UserSessionDAOMySQLImpl) ListActiveByUserID(ctx context.Context, userID int, now time.Time, tx ...*gorm.DB) ([]*models.UserSession, error) {
+	var sessions []*models.UserSession
+	db := dao.DB
+	if len(tx) > 0 && tx[0] != nil {
+		db = tx[0]
+	}
+	err := db.WithContext(ctx).
+		Where("user_id = ? AND revoked_at IS NULL AND expires_at > ?", userID, now).
+		Order("last_seen_at DESC").
+		Order("id DESC").
+		Find(&sessions).Error
+	return sessions, err
+}
+
+// UpdateLastSeen 记录最近活跃时间与IP
+func (dao *UserSessionDAOMySQLImpl) UpdateLastSeen(ctx context.Context, sessionID string, seenAt time.Time, ip string, tx ...*gorm.DB) error {
+	db := dao.DB
+	if len(tx) > 0 && tx[0] != nil {
+		db = tx[0]
+	}
+	return db.WithContext(ctx).
+		Model(&models.UserSession{}).
+		Where("session_id = ?", sessionID).
+		Updates(map[string]interface{}{"last_seen_at": seenAt, "last_seen_ip": ip}).Error
+}
+
+// UpdateExpiresAt 刷新令牌轮换后顺延会话的过期时间
+func (dao *UserSessionDAOMySQLImpl) UpdateExpiresAt(ctx context.Context, sessionID string, expiresAt time.Time, tx ...*gorm.DB) error {
+	db := dao.DB
+	if len(tx) > 0 && tx[0] != nil {
+		db = tx[0]
+	}
+	return db.WithContext(ctx).
+		Model(&models.UserSession{}).
+		Where("session_id = ?", sessionID).
+		Update("expires_at", expiresAt).Error
+}
+
+// RevokeBySessionID 以 revoked_at IS NULL 为条件更新，返回是否吊销成功
+func (dao *UserSessionDAOMySQLImpl) RevokeBySessionID(ctx context.Context, sessionID string, revokedAt time.Time, tx ...*gorm.DB) (bool, error) {
+	db := dao.DB
+	if len(tx) > 0 && tx[0] != nil {
+		db = tx[0]
+	}
+	result := db.WithContext(ctx).
+		Model(&models.UserSession{}).
+		Where("session_id = ? AND revoked_at IS NULL", sessionID).
+		Update("revoked_at", revokedAt)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// RevokeByUserID 吊销用户所有未吊销的会话
+func (dao *UserSessionDAOMySQLImpl) RevokeByUserID(ctx context.Context, userID int, revokedAt time.Time, tx ...*gorm.DB) error {
+	db := dao.DB
+	if len(tx) > 0 && tx[0] != nil {
+		db = tx[0]
+	}
+	return db.WithContext(ctx).
+		Model(&models.UserSession{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Update("revoked_at", revokedAt).Error
+}
@@ -3,6 +3,8 @@ package impl
 import (
 	"TeamTickBackend/dal/models"
 	"context"
+	"strings"
+	"time"
 
 	"gorm.io/gorm"
 )
@@ -47,3 +49,139 @@ func (dao *UserDAOMySQLImpl) GetByID(ctx context.Context, id int, tx ...*gorm.DB
 	}
 	return &user, nil
 }
+
+// UpdatePassword 更新用户密码（已加密）
+func (dao *UserDAOMySQLImpl) UpdatePassword(ctx context.Context, userID int, password string, tx ...*gorm.DB) error {
+	db := dao.DB
+	if len(tx) > 0 && tx[0] != nil {
+		db = tx[0]
+	}
+	return db.WithContext(ctx).
+		Model(&models.User{}).
+		Where("user_id = ?", userID).
+		Update("password", password).Error
+}
+
+// UpdateTwoFactorEnabled 更新用户是否开启两步验证
+func (dao *UserDAOMySQLImpl) UpdateTwoFactorEnabled(ctx context.Context, userID int, enabled bool, tx ...*gorm.DB) error {
+	db := dao.DB
+	if len(tx) > 0 && tx[0] != nil {
+		db = tx[0]
+	}
+	return db.WithContext(ctx).
+		Model(&models.User{}).
+		Where("user_id = ?", userID).
+		Update("two_factor_enabled", enabled).Error
+}
+
+// UpdateProfile 更新个人资料与隐私设置，空字符串与false同样写入
+func (dao *UserDAOMySQLImpl) UpdateProfile(ctx context.Context, userID int, profile *models.User, tx ...*gorm.DB) error {
+	db := dao.DB
+	if len(tx) > 0 && tx[0] != nil {
+		db = tx[0]
+	}
+	return db.WithContext(ctx).
+		Model(&models.User{}).
+		Where("user_id = ?", userID).
+		Updates(map[string]interface{}{
+			"display_name":         profile.DisplayName,
+			"real_name":            profile.RealName,
+			"student_number":       profile.StudentNumber,
+			"email":                profile.Email,
+			"phone":                profile.Phone,
+			"avatar_url":           profile.AvatarURL,
+			"share_real_name":      profile.ShareRealName,
+			"share_student_number": profile.ShareStudentNumber,
+			"share_email":          profile.ShareEmail,
+			"share_phone":          profile.SharePhone,
+		}).Error
+}
+
+// GetByIDs 批量查询用户
+func (dao *UserDAOMySQLImpl) GetByIDs(ctx context.Context, ids []int, tx ...*gorm.DB) ([]*models.User, error) {
+	var users []*models.User
+	if len(ids) == 0 {
+		return users, nil
+	}
+	db := dao.DB
+	if len(tx) > 0 && tx[0] != nil {
+		db = tx[0]
+	}
+	err := db.WithContext(ctx).Where("user_id IN ?", ids).Find(&users).Error
+	return users, err
+}
+
+// UpdateUsername 修改用户名，与其他用户重名时返回 gorm.ErrDuplicatedKey
+func (dao *UserDAOMySQLImpl) UpdateUsername(ctx context.Context, userID int, username string, tx ...*gorm.DB) error {
+	db := dao.DB
+	if len(tx) > 0 && tx[0] != nil {
+		db = tx[0]
+	}
+	return db.WithContext(ctx).
+		Model(&models.User{}).
+		Where("user_id = ?", userID).
+		Update("username", username).Error
+}
+
+// Search 按用户名、昵称、真实姓名、学号或邮箱模糊查询，按用户ID排序分页
+func (dao *UserDAOMySQLImpl) Search(ctx context.Context, keyword string, disabled *bool, offset, limit int, tx ...*gorm.DB) ([]*models.User, int64, error) {
+	db := dao.DB
+	if len(tx) > 0 && tx[0] != nil {
+		db = tx[0]
+	}
+	query := db.WithContext(ctx).Model(&models.User{})
+	if keyword != "" {
+		pattern := likePattern(keyword)
+		query = query.Where("username LIKE ? ESCAPE '!' OR display_name LIKE ? ESCAPE '!' OR real_name LIKE ? ESCAPE '!' OR student_number LIKE ? ESCAPE '!' OR email LIKE ? ESCAPE '!'",
+			pattern, pattern, pattern, pattern, pattern)
+	}
+	if disabled != nil {
+		if *disabled {
+			query = query.Where("disabled_at IS NOT NULL")
+		} else {
+			query = query.Where("disabled_at IS NULL")
+		}
+	}
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var users []*models.User
+	err := query.Order("user_id").Offset(offset).Limit(limit).Find(&users).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return users, total, nil
+}
+
+// UpdateDisabled 设置停用时间与原因，disabledAt 为nil时恢复账号
+func (dao *UserDAOMySQLImpl) UpdateDisabled(ctx context.Context, userID int, disabledAt *time.Time, reason string, tx ...*gorm.DB) error {
+	db := dao.DB
+	if len(tx) > 0 && tx[0] != nil {
+		db = tx[0]
+	}
+	return db.WithContext(ctx).
+		Model(&models.User{}).
+		Where("user_id = ?", userID).
+		Updates(map[string]interface{}{
+			"disabled_at":     disabledAt,
+			"disabled_reason": reason,
+		}).Error
+}
+
+// UpdatePlatformRole 更新平台角色
+func (dao *UserDAOMySQLImpl) UpdatePlatformRole(ctx context.Context, userID int, role string, tx ...*gorm.DB) error {
+	db := dao.DB
+	if len(tx) > 0 && tx[0] != nil {
+		db = tx[0]
+	}
+	return db.WithContext(ctx).
+		Model(&models.User{}).
+		Where("user_id = ?", userID).
+		Update("platform_role", role).Error
+}
+
+// likePattern 生成包含关键字的 LIKE 模式，以 ! 转义通配符，查询中需带 ESCAPE '!'
+func likePattern(keyword string) string {
+	return "%" + strings.NewReplacer("!", "!!", "%", "!%", "_", "!_").Replace(keyword) + "%"
+}
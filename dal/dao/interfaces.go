@@ -3,6 +3,7 @@ package dao
 import (
 	"TeamTickBackend/dal/models"
 	"context"
+	"time"
 
 	"gorm.io/gorm"
 )
@@ -12,6 +13,17 @@ type UserDAO interface {
 	Create(ctx context.Context, user *models.User, tx ...*gorm.DB) error
 	GetByUsername(ctx context.Context, username string, tx ...*gorm.DB) (*models.User, error)
 	GetByID(ctx context.Context, id int, tx ...*gorm.DB) (*models.User, error)
+	UpdatePassword(ctx context.Context, userID int, password string, tx ...*gorm.DB) error
+	UpdateTwoFactorEnabled(ctx context.Context, userID int, enabled bool, tx ...*gorm.DB) error
+	// UpdateProfile 以 profile 中的个人资料与隐私设置覆盖用户的对应字段
+	UpdateProfile(ctx context.Context, userID int, profile *models.User, tx ...*gorm.DB) error
+	GetByIDs(ctx context.Context, ids []int, tx ...*gorm.DB) ([]*models.User, error)
+	UpdateUsername(ctx context.Context, userID int, username string, tx ...*gorm.DB) error
+	// Search 按用户名、昵称、真实姓名、学号或邮箱模糊查询，disabled 不为nil时按是否停用过滤，返回当前页与总数
+	Search(ctx context.Context, keyword string, disabled *bool, offset, limit int, tx ...*gorm.DB) ([]*models.User, int64, error)
+	// UpdateDisabled 设置停用时间与原因，disabledAt 为nil时恢复账号
+	UpdateDisabled(ctx context.Context, userID int, disabledAt *time.Time, reason string, tx ...*gorm.DB) error
+	UpdatePlatformRole(ctx context.Context, userID int, role string, tx ...*gorm.DB) error
 }
 
 // TaskDAO 任务数据访问接口
@@ -37,6 +49,21 @@ type GroupDAO interface {
 	UpdateMemberNum(ctx context.Context, groupID int, increment bool, tx ...*gorm.DB) error
 	GetGroupsByUserIDAndfilter(ctx context.Context, userID int, filter string, tx ...*gorm.DB) ([]*models.Group, error)
 	Delete(ctx context.Context, groupID int, tx ...*gorm.DB) error
+	List(ctx context.Context, tx ...*gorm.DB) ([]*models.Group, error)
+	UpdateCreator(ctx context.Context, groupID, creatorID int, creatorName string, tx ...*gorm.DB) error
+	SetMemberNum(ctx context.Context, groupID, memberNum int, tx ...*gorm.DB) error
+	UpdateRequireAdmin2FA(ctx context.Context, groupID int, require bool, tx ...*gorm.DB) error
+	UpdateJoinPolicy(ctx context.Context, groupID int, policy string, tx ...*gorm.DB) error
+	// Search 按名称或创建者用户名模糊查询，返回当前页与总数
+	Search(ctx context.Context, keyword string, offset, limit int, tx ...*gorm.DB) ([]*models.Group, int64, error)
+	// UpdateParent 设置上级用户组，为0时成为顶级用户组
+	UpdateParent(ctx context.Context, groupID, parentID int, tx ...*gorm.DB) error
+	// ReparentChildren 将 parentID 的直接下级用户组移到 newParentID 下
+	ReparentChildren(ctx context.Context, parentID, newParentID int, tx ...*gorm.DB) error
+	// GetAncestors 查询全部上级用户组，由近及远排列
+	GetAncestors(ctx context.Context, groupID int, tx ...*gorm.DB) ([]*models.Group, error)
+	// GetDescendants 查询全部下级用户组，按层级与用户组ID排列
+	GetDescendants(ctx context.Context, groupID int, tx ...*gorm.DB) ([]*models.Group, error)
 }
 
 // GroupMemberDAO 组成员数据访问接口
@@ -45,6 +72,13 @@ type GroupMemberDAO interface {
 	GetMembersByGroupID(ctx context.Context, groupID int, tx ...*gorm.DB) ([]*models.GroupMember, error)
 	GetMemberByGroupIDAndUserID(ctx context.Context, groupID int, userID int, tx ...*gorm.DB) (*models.GroupMember, error)
 	Delete(ctx context.Context, groupID int, userID int, tx ...*gorm.DB) error
+	UpdateRole(ctx context.Context, groupID, userID int, role string, tx ...*gorm.DB) error
+	// UpdateRoleID 设置组员的自定义角色，为0时取消自定义角色
+	UpdateRoleID(ctx context.Context, groupID, userID, roleID int, tx ...*gorm.DB) error
+	// GetMembersByGroupIDs 查询多个用户组的成员
+	GetMembersByGroupIDs(ctx context.Context, groupIDs []int, tx ...*gorm.DB) ([]*models.GroupMember, error)
+	// GetByUserIDAndGroupIDs 查询用户在指定用户组中的成员记录，不是成员的用户组不出现在结果中
+	GetByUserIDAndGroupIDs(ctx context.Context, userID int, groupIDs []int, tx ...*gorm.DB) ([]*models.GroupMember, error)
 }
 
 // TaskRecordDAO 签到记录数据访问接口
@@ -75,3 +109,186 @@ type CheckApplicationDAO interface {
 	GetByTaskIDAndUserID(ctx context.Context, taskID int, userID int, tx ...*gorm.DB) (*models.CheckApplication, error)
 	GetByID(ctx context.Context, id int, tx ...*gorm.DB) (*models.CheckApplication, error)
 }
+
+// RefreshTokenDAO 刷新令牌数据访问接口
+type RefreshTokenDAO interface {
+	Create(ctx context.Context, token *models.RefreshToken, tx ...*gorm.DB) error
+	GetByTokenHash(ctx context.Context, tokenHash string, tx ...*gorm.DB) (*models.RefreshToken, error)
+	// MarkUsed 仅当令牌尚未使用时标记为已使用，返回是否标记成功，用于并发轮换时判定重复使用
+	MarkUsed(ctx context.Context, id int, usedAt time.Time, tx ...*gorm.DB) (bool, error)
+	RevokeFamily(ctx context.Context, familyID string, revokedAt time.Time, tx ...*gorm.DB) error
+	// RevokeByUserID 吊销用户所有未吊销的刷新令牌
+	RevokeByUserID(ctx context.Context, userID int, revokedAt time.Time, tx ...*gorm.DB) error
+}
+
+// PasswordResetTokenDAO 重置密码令牌数据访问接口
+type PasswordResetTokenDAO interface {
+	Create(ctx context.Context, token *models.PasswordResetToken, tx ...*gorm.DB) error
+	GetByTokenHash(ctx context.Context, tokenHash string, tx ...*gorm.DB) (*models.PasswordResetToken, error)
+	// MarkUsed 仅当令牌尚未使用时标记为已使用，返回是否标记成功，保证令牌只能使用一次
+	MarkUsed(ctx context.Context, id int, usedAt time.Time, tx ...*gorm.DB) (bool, error)
+	// InvalidateByUserID 作废用户所有未使用的令牌
+	InvalidateByUserID(ctx context.Context, userID int, usedAt time.Time, tx ...*gorm.DB) error
+}
+
+// TokenRevocationDAO 访问令牌吊销记录数据访问接口
+type TokenRevocationDAO interface {
+	// Create 写入吊销记录，同一jti或sid重复吊销时忽略
+	Create(ctx context.Context, revocation *models.TokenRevocation, tx ...*gorm.DB) error
+	// ListSince 查询 revoked_at 不早于since且在now时仍未过期的记录
+	ListSince(ctx context.Context, since, now time.Time, tx ...*gorm.DB) ([]*models.TokenRevocation, error)
+	DeleteExpired(ctx context.Context, now time.Time, tx ...*gorm.DB) (int64, error)
+	// SaveUserCutoff 写入或更新用户级吊销时间
+	SaveUserCutoff(ctx context.Context, cutoff *models.UserTokenCutoff, tx ...*gorm.DB) error
+	// ListUserCutoffsSince 查询吊销时间不早于since（毫秒时间戳）的用户级吊销
+	ListUserCutoffsSince(ctx context.Context, since int64, tx ...*gorm.DB) ([]*models.UserTokenCutoff, error)
+	// DeleteUserCutoffsBefore 清理早于before的用户级吊销，此前签发的令牌均已过期
+	DeleteUserCutoffsBefore(ctx context.Context, before int64, tx ...*gorm.DB) (int64, error)
+}
+
+// LoginAttemptDAO 登录失败计数数据访问接口
+type LoginAttemptDAO interface {
+	Get(ctx context.Context, scope, subject string, tx ...*gorm.DB) (*models.LoginAttempt, error)
+	// RecordFailure 原子地累加失败次数并返回最新记录；上次失败早于windowStart时从1重新计数并清除封禁
+	RecordFailure(ctx context.Context, scope, subject string, now, windowStart time.Time, tx ...*gorm.DB) (*models.LoginAttempt, error)
+	// ExtendBlock 将封禁截止时间延长到until，已有更晚的截止时间时保持不变
+	ExtendBlock(ctx context.Context, scope, subject string, until time.Time, tx ...*gorm.DB) error
+	// Delete 删除计数记录，返回记录是否存在
+	Delete(ctx context.Context, scope, subject string, tx ...*gorm.DB) (bool, error)
+	// DeleteStale 清理最近失败与封禁截止时间都早于before的记录
+	DeleteStale(ctx context.Context, before time.Time, tx ...*gorm.DB) (int64, error)
+}
+
+// TOTPSecretDAO TOTP密钥数据访问接口
+type TOTPSecretDAO interface {
+	Get(ctx context.Context, userID int, tx ...*gorm.DB) (*models.TOTPSecret, error)
+	// Save 写入或替换用户的密钥
+	Save(ctx context.Context, secret *models.TOTPSecret, tx ...*gorm.DB) error
+	Confirm(ctx context.Context, userID int, confirmedAt time.Time, tx ...*gorm.DB) error
+	// AdvanceStep 仅当step大于最近使用的时间步时更新，返回是否更新成功，同一验证码只能使用一次
+	AdvanceStep(ctx context.Context, userID int, step int64, tx ...*gorm.DB) (bool, error)
+	Delete(ctx context.Context, userID int, tx ...*gorm.DB) error
+}
+
+// RecoveryCodeDAO 两步验证恢复码数据访问接口
+type RecoveryCodeDAO interface {
+	CreateBatch(ctx context.Context, codes []*models.RecoveryCode, tx ...*gorm.DB) error
+	DeleteByUserID(ctx context.Context, userID int, tx ...*gorm.DB) error
+	// MarkUsed 仅当恢复码属于该用户且未使用时标记为已使用，返回是否标记成功
+	MarkUsed(ctx context.Context, userID int, codeHash string, usedAt time.Time, tx ...*gorm.DB) (bool, error)
+	CountUnused(ctx context.Context, userID int, tx ...*gorm.DB) (int64, error)
+}
+
+// MFAChallengeDAO 两步登录挑战数据访问接口
+type MFAChallengeDAO interface {
+	Create(ctx context.Context, challenge *models.MFAChallenge, tx ...*gorm.DB) error
+	GetByTokenHash(ctx context.Context, tokenHash string, tx ...*gorm.DB) (*models.MFAChallenge, error)
+	IncrementAttempts(ctx context.Context, id int, tx ...*gorm.DB) error
+	// MarkUsed 仅当挑战尚未完成时标记为已完成，返回是否标记成功
+	MarkUsed(ctx context.Context, id int, usedAt time.Time, tx ...*gorm.DB) (bool, error)
+	DeleteExpired(ctx context.Context, now time.Time, tx ...*gorm.DB) (int64, error)
+}
+
+// UserIdentityDAO 外部身份关联数据访问接口
+type UserIdentityDAO interface {
+	GetByIssuerSubject(ctx context.Context, issuer, subject string, tx ...*gorm.DB) (*models.UserIdentity, error)
+	Create(ctx context.Context, identity *models.UserIdentity, tx ...*gorm.DB) error
+	GetByUserID(ctx context.Context, userID int, tx ...*gorm.DB) ([]*models.UserIdentity, error)
+}
+
+// OIDCLoginStateDAO OIDC授权请求状态数据访问接口
+type OIDCLoginStateDAO interface {
+	Create(ctx context.Context, state *models.OIDCLoginState, tx ...*gorm.DB) error
+	GetByStateHash(ctx context.Context, stateHash string, tx ...*gorm.DB) (*models.OIDCLoginState, error)
+	// Delete 删除状态，返回是否删除成功，并发回调同一 state 时只有一个能成功
+	Delete(ctx context.Context, id int, tx ...*gorm.DB) (bool, error)
+	DeleteExpired(ctx context.Context, now time.Time, tx ...*gorm.DB) (int64, error)
+}
+
+// PersonalAccessTokenDAO 个人访问令牌数据访问接口
+type PersonalAccessTokenDAO interface {
+	Create(ctx context.Context, token *models.PersonalAccessToken, tx ...*gorm.DB) error
+	GetByTokenHash(ctx context.Context, tokenHash string, tx ...*gorm.DB) (*models.PersonalAccessToken, error)
+	// ListByUserID 查询用户未吊销的令牌，包括已过期的
+	ListByUserID(ctx context.Context, userID int, tx ...*gorm.DB) ([]*models.PersonalAccessToken, error)
+	// CountActiveByUserID 统计用户未吊销且未过期的令牌数量
+	CountActiveByUserID(ctx context.Context, userID int, now time.Time, tx ...*gorm.DB) (int64, error)
+	// Revoke 吊销属于该用户且尚未吊销的令牌，返回是否吊销成功
+	Revoke(ctx context.Context, id, userID int, revokedAt time.Time, tx ...*gorm.DB) (bool, error)
+	UpdateLastUsed(ctx context.Context, id int, usedAt time.Time, ip string, tx ...*gorm.DB) error
+}
+
+// GroupInviteDAO 用户组邀请码数据访问接口
+type GroupInviteDAO interface {
+	Create(ctx context.Context, invite *models.GroupInvite, tx ...*gorm.DB) error
+	GetByID(ctx context.Context, id int, tx ...*gorm.DB) (*models.GroupInvite, error)
+	GetByCode(ctx context.Context, code string, tx ...*gorm.DB) (*models.GroupInvite, error)
+	// ListByGroupID 查询用户组未撤销的邀请码，包括已过期与已用完的，按创建时间倒序
+	ListByGroupID(ctx context.Context, groupID int, tx ...*gorm.DB) ([]*models.GroupInvite, error)
+	// Revoke 撤销属于该用户组且尚未撤销的邀请码，返回是否撤销成功
+	Revoke(ctx context.Context, id, groupID int, revokedAt time.Time, tx ...*gorm.DB) (bool, error)
+	// ConsumeUse 邀请码仍可使用时将使用次数加1，返回是否成功；并发使用最后一个名额时只有一个能成功
+	ConsumeUse(ctx context.Context, id int, now time.Time, tx ...*gorm.DB) (bool, error)
+}
+
+// GroupRoleChangeDAO 组内角色变更记录数据访问接口，记录只追加，不提供修改与删除
+type GroupRoleChangeDAO interface {
+	Create(ctx context.Context, change *models.GroupRoleChange, tx ...*gorm.DB) error
+	// ListByGroupID 查询用户组的角色变更记录，按时间倒序，返回当前页与总数
+	ListByGroupID(ctx context.Context, groupID, offset, limit int, tx ...*gorm.DB) ([]*models.GroupRoleChange, int64, error)
+}
+
+// GroupRoleDAO 用户组自定义角色数据访问接口
+type GroupRoleDAO interface {
+	// Create 同一用户组内角色名称重复时返回 gorm.ErrDuplicatedKey
+	Create(ctx context.Context, role *models.GroupRole, tx ...*gorm.DB) error
+	GetByID(ctx context.Context, id int, tx ...*gorm.DB) (*models.GroupRole, error)
+	// ListByGroupID 查询用户组的全部自定义角色，按创建顺序
+	ListByGroupID(ctx context.Context, groupID int, tx ...*gorm.DB) ([]*models.GroupRole, error)
+	// Update 更新角色的名称、说明与权限，名称重复时同样返回 gorm.ErrDuplicatedKey
+	Update(ctx context.Context, role *models.GroupRole, tx ...*gorm.DB) error
+	Delete(ctx context.Context, id int, tx ...*gorm.DB) error
+}
+
+// UserSessionDAO 登录会话数据访问接口
+type UserSessionDAO interface {
+	Create(ctx context.Context, session *models.UserSession, tx ...*gorm.DB) error
+	GetByID(ctx context.Context, id int, tx ...*gorm.DB) (*models.UserSession, error)
+	GetBySessionID(ctx context.Context, sessionID string, tx ...*gorm.DB) (*models.UserSession, error)
+	// ListActiveByUserID 查询用户未吊销且未过期的会话，按最近活跃时间倒序
+	ListActiveByUserID(ctx context.Context, userID int, now time.Time, tx ...*gorm.DB) ([]*models.UserSession, error)
+	UpdateLastSeen(ctx context.Context, sessionID string, seenAt time.Time, ip string, tx ...*gorm.DB) error
+	UpdateExpiresAt(ctx context.Context, sessionID string, expiresAt time.Time, tx ...*gorm.DB) error
+	// RevokeBySessionID 吊销尚未吊销的会话，返回是否吊销成功
+	RevokeBySessionID(ctx context.Context, sessionID string, revokedAt time.Time, tx ...*gorm.DB) (bool, error)
+	// RevokeByUserID 吊销用户所有未吊销的会话
+	RevokeByUserID(ctx context.Context, userID int, revokedAt time.Time, tx ...*gorm.DB) error
+}
+
+// DenormalizationDAO 维护其他表中冗余存储的用户名、用户组名称与任务名称
+type DenormalizationDAO interface {
+	// CountDrift 统计每个冗余列中与来源数据不一致的行数，来源行已删除的不计入
+	CountDrift(ctx context.Context, tx ...*gorm.DB) ([]models.ColumnDrift, error)
+	// Repair 按来源数据重新填写冗余列，返回每列更新的行数；
+	// source 为空时处理全部来源，sourceID 为0时处理该来源的所有行
+	Repair(ctx context.Context, source string, sourceID int, tx ...*gorm.DB) ([]models.ColumnDrift, error)
+}
+
+// AdminActionDAO 平台管理操作日志数据访问接口，日志只追加，不提供修改与删除
+type AdminActionDAO interface {
+	Create(ctx context.Context, action *models.AdminAction, tx ...*gorm.DB) error
+	// List 按条件查询，按时间倒序，返回当前页与总数
+	List(ctx context.Context, filter models.AdminActionFilter, offset, limit int, tx ...*gorm.DB) ([]*models.AdminAction, int64, error)
+}
+
+// PlatformStatsDAO 统计平台概况
+type PlatformStatsDAO interface {
+	// Collect 统计各表的数量，进行中的任务与近24小时签到以now为准
+	Collect(ctx context.Context, now time.Time, tx ...*gorm.DB) (*models.PlatformStats, error)
+}
+
+// GroupStatsDAO 按用户组统计签到数据
+type GroupStatsDAO interface {
+	// Collect 统计各用户组自身的任务数、进行中的任务数与签到次数，进行中的任务以now为准；没有数据的用户组不出现在结果中
+	Collect(ctx context.Context, groupIDs []int, now time.Time, tx ...*gorm.DB) (map[int]*models.GroupActivity, error)
+}
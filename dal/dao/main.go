@@ -2,6 +2,7 @@ package dao
 
 import (
 	"TeamTickBackend/dal/dao/impl"
+	"TeamTickBackend/dal/dao/memory"
 
 	"gorm.io/gorm"
 )
@@ -10,25 +11,94 @@ type DAOFactory struct {
 	Db                 *gorm.DB
 	TransactionManager TransactionManager
 
-	UserDAO             UserDAO
-	GroupDAO            GroupDAO
-	TaskDAO             TaskDAO
-	GroupMemberDAO      GroupMemberDAO
-	TaskRecordDAO       TaskRecordDAO
-	JoinApplicationDAO  JoinApplicationDAO
-	CheckApplicationDAO CheckApplicationDAO
+	UserDAO                UserDAO
+	GroupDAO               GroupDAO
+	TaskDAO                TaskDAO
+	GroupMemberDAO         GroupMemberDAO
+	TaskRecordDAO          TaskRecordDAO
+	JoinApplicationDAO     JoinApplicationDAO
+	CheckApplicationDAO    CheckApplicationDAO
+	RefreshTokenDAO        RefreshTokenDAO
+	TokenRevocationDAO     TokenRevocationDAO
+	PasswordResetTokenDAO  PasswordResetTokenDAO
+	LoginAttemptDAO        LoginAttemptDAO
+	TOTPSecretDAO          TOTPSecretDAO
+	RecoveryCodeDAO        RecoveryCodeDAO
+	MFAChallengeDAO        MFAChallengeDAO
+	UserIdentityDAO        UserIdentityDAO
+	OIDCLoginStateDAO      OIDCLoginStateDAO
+	PersonalAccessTokenDAO PersonalAccessTokenDAO
+	UserSessionDAO         UserSessionDAO
+	DenormalizationDAO     DenormalizationDAO
+	AdminActionDAO         AdminActionDAO
+	PlatformStatsDAO       PlatformStatsDAO
+	GroupInviteDAO         GroupInviteDAO
+	GroupRoleChangeDAO     GroupRoleChangeDAO
+	GroupRoleDAO           GroupRoleDAO
+	GroupStatsDAO          GroupStatsDAO
 }
 
 func NewDAOFactory(db *gorm.DB) *DAOFactory {
 	return &DAOFactory{
-		Db:                  db,
-		TransactionManager:  NewTransactionManager(db),
-		UserDAO:             &impl.UserDAOMySQLImpl{DB: db},
-		GroupDAO:            &impl.GroupDAOMySQLImpl{DB: db},
-		TaskDAO:             &impl.TaskDAOMySQLImpl{DB: db},
-		GroupMemberDAO:      &impl.GroupMemberDAOMySQLImpl{DB: db},
-		TaskRecordDAO:       &impl.TaskRecordDAOMySQLImpl{DB: db},
-		JoinApplicationDAO:  &impl.JoinApplicationDAOMySQLImpl{DB: db},
-		CheckApplicationDAO: &impl.CheckApplicationDAOMySQLImpl{DB: db},
+		Db:                     db,
+		TransactionManager:     NewTransactionManager(db),
+		UserDAO:                &impl.UserDAOMySQLImpl{DB: db},
+		GroupDAO:               &impl.GroupDAOMySQLImpl{DB: db},
+		TaskDAO:                &impl.TaskDAOMySQLImpl{DB: db},
+		GroupMemberDAO:         &impl.GroupMemberDAOMySQLImpl{DB: db},
+		TaskRecordDAO:          &impl.TaskRecordDAOMySQLImpl{DB: db},
+		JoinApplicationDAO:     &impl.JoinApplicationDAOMySQLImpl{DB: db},
+		CheckApplicationDAO:    &impl.CheckApplicationDAOMySQLImpl{DB: db},
+		RefreshTokenDAO:        &impl.RefreshTokenDAOMySQLImpl{DB: db},
+		TokenRevocationDAO:     &impl.TokenRevocationDAOMySQLImpl{DB: db},
+		PasswordResetTokenDAO:  &impl.PasswordResetTokenDAOMySQLImpl{DB: db},
+		LoginAttemptDAO:        &impl.LoginAttemptDAOMySQLImpl{DB: db},
+		TOTPSecretDAO:          &impl.TOTPSecretDAOMySQLImpl{DB: db},
+		RecoveryCodeDAO:        &impl.RecoveryCodeDAOMySQLImpl{DB: db},
+		MFAChallengeDAO:        &impl.MFAChallengeDAOMySQLImpl{DB: db},
+		UserIdentityDAO:        &impl.UserIdentityDAOMySQLImpl{DB: db},
+		OIDCLoginStateDAO:      &impl.OIDCLoginStateDAOMySQLImpl{DB: db},
+		PersonalAccessTokenDAO: &impl.PersonalAccessTokenDAOMySQLImpl{DB: db},
+		UserSessionDAO:         &impl.UserSessionDAOMySQLImpl{DB: db},
+		DenormalizationDAO:     &impl.DenormalizationDAOMySQLImpl{DB: db},
+		AdminActionDAO:         &impl.AdminActionDAOMySQLImpl{DB: db},
+		PlatformStatsDAO:       &impl.PlatformStatsDAOMySQLImpl{DB: db},
+		GroupInviteDAO:         &impl.GroupInviteDAOMySQLImpl{DB: db},
+		GroupRoleChangeDAO:     &impl.GroupRoleChangeDAOMySQLImpl{DB: db},
+		GroupRoleDAO:           &impl.GroupRoleDAOMySQLImpl{DB: db},
+		GroupStatsDAO:          &impl.GroupStatsDAOMySQLImpl{DB: db},
+	}
+}
+
+// NewMemoryDAOFactory 创建基于内存存储的DAO工厂，无需数据库，Db字段为nil
+func NewMemoryDAOFactory() *DAOFactory {
+	store := memory.NewStore()
+	return &DAOFactory{
+		TransactionManager:     memory.NewTransactionManager(store),
+		UserDAO:                &memory.UserDAOMemoryImpl{Store: store},
+		GroupDAO:               &memory.GroupDAOMemoryImpl{Store: store},
+		TaskDAO:                &memory.TaskDAOMemoryImpl{Store: store},
+		GroupMemberDAO:         &memory.GroupMemberDAOMemoryImpl{Store: store},
+		TaskRecordDAO:          &memory.TaskRecordDAOMemoryImpl{Store: store},
+		JoinApplicationDAO:     &memory.JoinApplicationDAOMemoryImpl{Store: store},
+		CheckApplicationDAO:    &memory.CheckApplicationDAOMemoryImpl{Store: store},
+		RefreshTokenDAO:        &memory.RefreshTokenDAOMemoryImpl{Store: store},
+		TokenRevocationDAO:     &memory.TokenRevocationDAOMemoryImpl{Store: store},
+		PasswordResetTokenDAO:  &memory.PasswordResetTokenDAOMemoryImpl{Store: store},
+		LoginAttemptDAO:        &memory.LoginAttemptDAOMemoryImpl{Store: store},
+		TOTPSecretDAO:          &memory.TOTPSecretDAOMemoryImpl{Store: store},
+		RecoveryCodeDAO:        &memory.RecoveryCodeDAOMemoryImpl{Store: store},
+		MFAChallengeDAO:        &memory.MFAChallengeDAOMemoryImpl{Store: store},
+		UserIdentityDAO:        &memory.UserIdentityDAOMemoryImpl{Store: store},
+		OIDCLoginStateDAO:      &memory.OIDCLoginStateDAOMemoryImpl{Store: store},
+		PersonalAccessTokenDAO: &memory.PersonalAccessTokenDAOMemoryImpl{Store: store},
+		UserSessionDAO:         &memory.UserSessionDAOMemoryImpl{Store: store},
+		DenormalizationDAO:     &memory.DenormalizationDAOMemoryImpl{Store: store},
+		AdminActionDAO:         &memory.AdminActionDAOMemoryImpl{Store: store},
+		PlatformStatsDAO:       &memory.PlatformStatsDAOMemoryImpl{Store: store},
+		GroupInviteDAO:         &memory.GroupInviteDAOMemoryImpl{Store: store},
+		GroupRoleChangeDAO:     &memory.GroupRoleChangeDAOMemoryImpl{Store: store},
+		GroupRoleDAO:           &memory.GroupRoleDAOMemoryImpl{Store: store},
+		GroupStatsDAO:          &memory.GroupStatsDAOMemoryImpl{Store: store},
 	}
 }
@@ -0,0 +1,43 @@
+package memory
+
+import (
+	"TeamTickBackend/dal/models"
+	"context"
+	"slices"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type AdminActionDAOMemoryImpl struct {
+	Store *Store
+}
+
+// Create 追加一条管理操作日志
+func (dao *AdminActionDAOMemoryImpl) Create(ctx context.Context, action *models.AdminAction, tx ...*gorm.DB) error {
+	return dao.Store.write(ctx, tx, func(data *tables) error {
+		action.ID = data.adminActions.newID()
+		action.CreatedAt = orNow(action.CreatedAt, time.Now())
+		data.adminActions.insert(action)
+		return nil
+	})
+}
+
+// List 按条件查询，按ID倒序（即时间倒序）分页
+func (dao *AdminActionDAOMemoryImpl) List(ctx context.Context, filter models.AdminActionFilter, offset, limit int, tx ...*gorm.DB) ([]*models.AdminAction, int64, error) {
+	var actions []*models.AdminAction
+	err := dao.Store.read(ctx, func(data *tables) error {
+		actions = data.adminActions.find(func(a *models.AdminAction) bool {
+			return (filter.ActorID == 0 || a.ActorID == filter.ActorID) &&
+				(filter.Action == "" || a.Action == filter.Action) &&
+				(filter.TargetType == "" || a.TargetType == filter.TargetType) &&
+				(filter.TargetID == 0 || a.TargetID == filter.TargetID)
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	slices.Reverse(actions)
+	return page(actions, offset, limit), int64(len(actions)), nil
+}
@@ -0,0 +1,81 @@
+package memory
+
+import (
+	"TeamTickBackend/dal/models"
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type CheckApplicationDAOMemoryImpl struct {
+	Store *Store
+}
+
+// Create 创建签到申请
+func (dao *CheckApplicationDAOMemoryImpl) Create(ctx context.Context, application *models.CheckApplication, tx ...*gorm.DB) error {
+	return dao.Store.write(ctx, tx, func(data *tables) error {
+		now := time.Now()
+		application.ID = data.checkApplications.newID()
+		application.RequestAt = orNow(application.RequestAt, now)
+		application.CreatedAt = orNow(application.CreatedAt, now)
+		application.UpdatedAt = orNow(application.UpdatedAt, now)
+		if application.Status == "" {
+			application.Status = "pending"
+		}
+		data.checkApplications.insert(application)
+		return nil
+	})
+}
+
+// GetByID 通过id查询签到申请
+func (dao *CheckApplicationDAOMemoryImpl) GetByID(ctx context.Context, id int, tx ...*gorm.DB) (*models.CheckApplication, error) {
+	var application *models.CheckApplication
+	err := dao.Store.read(ctx, func(data *tables) (err error) {
+		application, err = data.checkApplications.first(func(a *models.CheckApplication) bool { return a.ID == id })
+		return err
+	})
+	return application, err
+}
+
+// GetByGroupID 通过group_id查询当前组的所有任务签到申请
+func (dao *CheckApplicationDAOMemoryImpl) GetByGroupID(ctx context.Context, groupID int, tx ...*gorm.DB) ([]*models.CheckApplication, error) {
+	return dao.find(ctx, func(a *models.CheckApplication) bool { return a.GroupID == groupID })
+}
+
+// GetByUserID 通过user_id查询签到申请
+func (dao *CheckApplicationDAOMemoryImpl) GetByUserID(ctx context.Context, userID int, tx ...*gorm.DB) ([]*models.CheckApplication, error) {
+	return dao.find(ctx, func(a *models.CheckApplication) bool { return a.UserID == userID })
+}
+
+// Update 更新签到申请（管理员审批）
+func (dao *CheckApplicationDAOMemoryImpl) Update(ctx context.Context, status string, requestID int, tx ...*gorm.DB) error {
+	return dao.Store.write(ctx, tx, func(data *tables) error {
+		data.checkApplications.update(func(a *models.CheckApplication) bool { return a.ID == requestID }, func(a *models.CheckApplication) {
+			a.Status = status
+			a.UpdatedAt = time.Now()
+		})
+		return nil
+	})
+}
+
+// GetByTaskIDAndUserID 通过task_id和user_id查询签到申请
+func (dao *CheckApplicationDAOMemoryImpl) GetByTaskIDAndUserID(ctx context.Context, taskID int, userID int, tx ...*gorm.DB) (*models.CheckApplication, error) {
+	var application *models.CheckApplication
+	err := dao.Store.read(ctx, func(data *tables) (err error) {
+		application, err = data.checkApplications.first(func(a *models.CheckApplication) bool {
+			return a.TaskID == taskID && a.UserID == userID
+		})
+		return err
+	})
+	return application, err
+}
+
+func (dao *CheckApplicationDAOMemoryImpl) find(ctx context.Context, match func(*models.CheckApplication) bool) ([]*models.CheckApplication, error) {
+	var applications []*models.CheckApplication
+	err := dao.Store.read(ctx, func(data *tables) error {
+		applications = data.checkApplications.find(match)
+		return nil
+	})
+	return applications, err
+}
@@ -0,0 +1,128 @@
+package memory
+
+import (
+	"TeamTickBackend/dal/models"
+	"context"
+
+	"gorm.io/gorm"
+)
+
+type DenormalizationDAOMemoryImpl struct {
+	Store *Store
+}
+
+// CountDrift 统计每个冗余列中与来源数据不一致的行数
+func (dao *DenormalizationDAOMemoryImpl) CountDrift(ctx context.Context, tx ...*gorm.DB) ([]models.ColumnDrift, error) {
+	var drifts []models.ColumnDrift
+	err := dao.Store.read(ctx, func(data *tables) error {
+		drifts = data.syncDenormalized("", 0, false)
+		return nil
+	})
+	return drifts, err
+}
+
+// Repair 按来源数据重新填写冗余列，与数据库实现一致，指定 sourceID 时该来源的所有行都计入更新行数
+func (dao *DenormalizationDAOMemoryImpl) Repair(ctx context.Context, source string, sourceID int, tx ...*gorm.DB) ([]models.ColumnDrift, error) {
+	var repaired []models.ColumnDrift
+	err := dao.Store.write(ctx, tx, func(data *tables) error {
+		repaired = data.syncDenormalized(source, sourceID, true)
+		return nil
+	})
+	return repaired, err
+}
+
+// syncDenormalized 比较冗余列与来源数据，repair 为 true 时改写，列的顺序与数据库实现相同
+func (t *tables) syncDenormalized(source string, sourceID int, repair bool) []models.ColumnDrift {
+	usernames := make(map[int]string, len(t.users.rows))
+	for _, u := range t.users.rows {
+		usernames[u.UserID] = u.Username
+	}
+	groupNames := make(map[int]string, len(t.groups.rows))
+	for _, g := range t.groups.rows {
+		groupNames[g.GroupID] = g.GroupName
+	}
+	taskNames := make(map[int]string, len(t.tasks.rows))
+	for _, task := range t.tasks.rows {
+		taskNames[task.TaskID] = task.TaskName
+	}
+	names := map[string]map[int]string{
+		models.DenormSourceUser:  usernames,
+		models.DenormSourceGroup: groupNames,
+		models.DenormSourceTask:  taskNames,
+	}
+
+	columns := []struct {
+		drift models.ColumnDrift
+		sync  func(values map[int]string) int64
+	}{
+		{models.ColumnDrift{Table: "groups", Column: "creator_name", Source: models.DenormSourceUser}, func(values map[int]string) int64 {
+			return syncColumn(&t.groups, func(g *models.Group) (int, *string) { return g.CreatorID, &g.CreatorName }, values, sourceID, repair)
+		}},
+		{models.ColumnDrift{Table: "group_member", Column: "username", Source: models.DenormSourceUser}, func(values map[int]string) int64 {
+			return syncColumn(&t.groupMembers, func(m *models.GroupMember) (int, *string) { return m.UserID, &m.Username }, values, sourceID, repair)
+		}},
+		{models.ColumnDrift{Table: "group_member", Column: "group_name", Source: models.DenormSourceGroup}, func(values map[int]string) int64 {
+			return syncColumn(&t.groupMembers, func(m *models.GroupMember) (int, *string) { return m.GroupID, &m.GroupName }, values, sourceID, repair)
+		}},
+		{models.ColumnDrift{Table: "tasks_record", Column: "username", Source: models.DenormSourceUser}, func(values map[int]string) int64 {
+			return syncColumn(&t.taskRecords, func(r *models.TaskRecord) (int, *string) { return r.UserID, &r.Username }, values, sourceID, repair)
+		}},
+		{models.ColumnDrift{Table: "tasks_record", Column: "group_name", Source: models.DenormSourceGroup}, func(values map[int]string) int64 {
+			return syncColumn(&t.taskRecords, func(r *models.TaskRecord) (int, *string) { return r.GroupID, &r.GroupName }, values, sourceID, repair)
+		}},
+		{models.ColumnDrift{Table: "tasks_record", Column: "task_name", Source: models.DenormSourceTask}, func(values map[int]string) int64 {
+			return syncColumn(&t.taskRecords, func(r *models.TaskRecord) (int, *string) { return r.TaskID, &r.TaskName }, values, sourceID, repair)
+		}},
+		{models.ColumnDrift{Table: "check_application", Column: "username", Source: models.DenormSourceUser}, func(values map[int]string) int64 {
+			return syncColumn(&t.checkApplications, func(a *models.CheckApplication) (int, *string) { return a.UserID, &a.Username }, values, sourceID, repair)
+		}},
+		{models.ColumnDrift{Table: "check_application", Column: "admin_username", Source: models.DenormSourceUser}, func(values map[int]string) int64 {
+			return syncColumn(&t.checkApplications, func(a *models.CheckApplication) (int, *string) { return a.AdminID, &a.AdminUsername }, values, sourceID, repair)
+		}},
+		{models.ColumnDrift{Table: "check_application", Column: "task_name", Source: models.DenormSourceTask}, func(values map[int]string) int64 {
+			return syncColumn(&t.checkApplications, func(a *models.CheckApplication) (int, *string) { return a.TaskID, &a.TaskName }, values, sourceID, repair)
+		}},
+		{models.ColumnDrift{Table: "join_application", Column: "username", Source: models.DenormSourceUser}, func(values map[int]string) int64 {
+			return syncColumn(&t.joinApplications, func(a *models.JoinApplication) (int, *string) { return a.UserID, &a.Username }, values, sourceID, repair)
+		}},
+		{models.ColumnDrift{Table: "group_invites", Column: "created_by_name", Source: models.DenormSourceUser}, func(values map[int]string) int64 {
+			return syncColumn(&t.groupInvites, func(i *models.GroupInvite) (int, *string) { return i.CreatedBy, &i.CreatedByName }, values, sourceID, repair)
+		}},
+	}
+
+	var drifts []models.ColumnDrift
+	for _, c := range columns {
+		if source != "" && c.drift.Source != source {
+			continue
+		}
+		drift := c.drift
+		drift.Rows = c.sync(names[drift.Source])
+		drifts = append(drifts, drift)
+	}
+	return drifts
+}
+
+// syncColumn 处理一列：sourceID 为0时统计（并修复）不一致的行，否则改写该来源的所有行；来源行不存在时跳过
+func syncColumn[T any](t *table[T], field func(*T) (int, *string), values map[int]string, sourceID int, repair bool) int64 {
+	var rows int64
+	for _, row := range t.rows {
+		key, column := field(row)
+		value, ok := values[key]
+		if !ok {
+			continue
+		}
+		if sourceID > 0 {
+			if key != sourceID {
+				continue
+			}
+		} else if *column == value {
+			continue
+		}
+		rows++
+		if repair {
+			t.touch(row)
+			*column = value
+		}
+	}
+	return rows
+}
@@ -0,0 +1,91 @@
+package memory
+
+import (
+	"TeamTickBackend/dal/models"
+	"context"
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type GroupInviteDAOMemoryImpl struct {
+	Store *Store
+}
+
+// Create 创建邀请码，邀请码唯一（idx_groupinvite_code）
+func (dao *GroupInviteDAOMemoryImpl) Create(ctx context.Context, invite *models.GroupInvite, tx ...*gorm.DB) error {
+	return dao.Store.write(ctx, tx, func(data *tables) error {
+		if data.groupInvites.exists(func(i *models.GroupInvite) bool { return i.Code == invite.Code }) {
+			return gorm.ErrDuplicatedKey
+		}
+		invite.ID = data.groupInvites.newID()
+		invite.CreatedAt = orNow(invite.CreatedAt, time.Now())
+		if invite.Role == "" {
+			invite.Role = "member"
+		}
+		data.groupInvites.insert(invite)
+		return nil
+	})
+}
+
+// GetByID 通过ID查询
+func (dao *GroupInviteDAOMemoryImpl) GetByID(ctx context.Context, id int, tx ...*gorm.DB) (*models.GroupInvite, error) {
+	var invite *models.GroupInvite
+	err := dao.Store.read(ctx, func(data *tables) (err error) {
+		invite, err = data.groupInvites.first(func(i *models.GroupInvite) bool { return i.ID == id })
+		return err
+	})
+	return invite, err
+}
+
+// GetByCode 通过邀请码查询
+func (dao *GroupInviteDAOMemoryImpl) GetByCode(ctx context.Context, code string, tx ...*gorm.DB) (*models.GroupInvite, error) {
+	var invite *models.GroupInvite
+	err := dao.Store.read(ctx, func(data *tables) (err error) {
+		invite, err = data.groupInvites.first(func(i *models.GroupInvite) bool { return i.Code == code })
+		return err
+	})
+	return invite, err
+}
+
+// ListByGroupID 查询用户组未撤销的邀请码，按创建时间倒序
+func (dao *GroupInviteDAOMemoryImpl) ListByGroupID(ctx context.Context, groupID int, tx ...*gorm.DB) ([]*models.GroupInvite, error) {
+	var invites []*models.GroupInvite
+	err := dao.Store.read(ctx, func(data *tables) error {
+		invites = data.groupInvites.find(func(i *models.GroupInvite) bool {
+			return i.GroupID == groupID && i.RevokedAt == nil
+		})
+		return nil
+	})
+	sort.Slice(invites, func(i, j int) bool { return invites[i].ID > invites[j].ID })
+	return invites, err
+}
+
+// Revoke 撤销属于该用户组且尚未撤销的邀请码
+func (dao *GroupInviteDAOMemoryImpl) Revoke(ctx context.Context, id, groupID int, revokedAt time.Time, tx ...*gorm.DB) (bool, error) {
+	var affected int
+	err := dao.Store.write(ctx, tx, func(data *tables) error {
+		affected = data.groupInvites.update(func(i *models.GroupInvite) bool {
+			return i.ID == id && i.GroupID == groupID && i.RevokedAt == nil
+		}, func(i *models.GroupInvite) {
+			i.RevokedAt = &revokedAt
+		})
+		return nil
+	})
+	return affected == 1, err
+}
+
+// ConsumeUse 邀请码仍可使用时将使用次数加1
+func (dao *GroupInviteDAOMemoryImpl) ConsumeUse(ctx context.Context, id int, now time.Time, tx ...*gorm.DB) (bool, error) {
+	var affected int
+	err := dao.Store.write(ctx, tx, func(data *tables) error {
+		affected = data.groupInvites.update(func(i *models.GroupInvite) bool {
+			return i.ID == id && i.Usable(now)
+		}, func(i *models.GroupInvite) {
+			i.Uses++
+		})
+		return nil
+	})
+	return affected == 1, err
+}
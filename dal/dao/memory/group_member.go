@@ -0,0 +1,114 @@
+package memory
+
+import (
+	"TeamTickBackend/dal/models"
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type GroupMemberDAOMemoryImpl struct {
+	Store *Store
+}
+
+// Create 创建组员
+func (dao *GroupMemberDAOMemoryImpl) Create(ctx context.Context, member *models.GroupMember, tx ...*gorm.DB) error {
+	return dao.Store.write(ctx, tx, func(data *tables) error {
+		now := time.Now()
+		member.JoinedAt = orNow(member.JoinedAt, now)
+		member.CreatedAt = orNow(member.CreatedAt, now)
+		if member.Role == "" {
+			member.Role = "member"
+		}
+		data.groupMembers.insert(member)
+		return nil
+	})
+}
+
+// GetMembersByGroupID 通过group_id查询组中的所有成员信息
+func (dao *GroupMemberDAOMemoryImpl) GetMembersByGroupID(ctx context.Context, groupID int, tx ...*gorm.DB) ([]*models.GroupMember, error) {
+	var members []*models.GroupMember
+	err := dao.Store.read(ctx, func(data *tables) error {
+		members = data.groupMembers.find(func(m *models.GroupMember) bool { return m.GroupID == groupID })
+		return nil
+	})
+	return members, err
+}
+
+// GetMemberByGroupIDAndUserID 通过group_id和user_id查询特定组员信息
+func (dao *GroupMemberDAOMemoryImpl) GetMemberByGroupIDAndUserID(ctx context.Context, groupID int, userID int, tx ...*gorm.DB) (*models.GroupMember, error) {
+	var member *models.GroupMember
+	err := dao.Store.read(ctx, func(data *tables) (err error) {
+		member, err = data.groupMembers.first(func(m *models.GroupMember) bool {
+			return m.GroupID == groupID && m.UserID == userID
+		})
+		return err
+	})
+	return member, err
+}
+
+// Delete 删除组员
+func (dao *GroupMemberDAOMemoryImpl) Delete(ctx context.Context, groupID int, userID int, tx ...*gorm.DB) error {
+	return dao.Store.write(ctx, tx, func(data *tables) error {
+		data.groupMembers.delete(func(m *models.GroupMember) bool {
+			return m.GroupID == groupID && m.UserID == userID
+		})
+		return nil
+	})
+}
+
+// UpdateRole 更新组员角色
+func (dao *GroupMemberDAOMemoryImpl) UpdateRole(ctx context.Context, groupID, userID int, role string, tx ...*gorm.DB) error {
+	return dao.Store.write(ctx, tx, func(data *tables) error {
+		data.groupMembers.update(func(m *models.GroupMember) bool {
+			return m.GroupID == groupID && m.UserID == userID
+		}, func(m *models.GroupMember) {
+			m.Role = role
+		})
+		return nil
+	})
+}
+
+// UpdateRoleID 设置组员的自定义角色
+func (dao *GroupMemberDAOMemoryImpl) UpdateRoleID(ctx context.Context, groupID, userID, roleID int, tx ...*gorm.DB) error {
+	return dao.Store.write(ctx, tx, func(data *tables) error {
+		data.groupMembers.update(func(m *models.GroupMember) bool {
+			return m.GroupID == groupID && m.UserID == userID
+		}, func(m *models.GroupMember) {
+			m.RoleID = roleID
+		})
+		return nil
+	})
+}
+
+// GetMembersByGroupIDs 查询多个用户组的成员
+func (dao *GroupMemberDAOMemoryImpl) GetMembersByGroupIDs(ctx context.Context, groupIDs []int, tx ...*gorm.DB) ([]*models.GroupMember, error) {
+	ids := intSet(groupIDs)
+	var members []*models.GroupMember
+	err := dao.Store.read(ctx, func(data *tables) error {
+		members = data.groupMembers.find(func(m *models.GroupMember) bool { return ids[m.GroupID] })
+		return nil
+	})
+	return members, err
+}
+
+// GetByUserIDAndGroupIDs 查询用户在指定用户组中的成员记录
+func (dao *GroupMemberDAOMemoryImpl) GetByUserIDAndGroupIDs(ctx context.Context, userID int, groupIDs []int, tx ...*gorm.DB) ([]*models.GroupMember, error) {
+	ids := intSet(groupIDs)
+	var members []*models.GroupMember
+	err := dao.Store.read(ctx, func(data *tables) error {
+		members = data.groupMembers.find(func(m *models.GroupMember) bool { return m.UserID == userID && ids[m.GroupID] })
+		return nil
+	})
+	return members, err
+}
+
+// intSet 对应 IN (...) 条件
+func intSet(ids []int) map[int]bool {
+	set := make(map[int]bool, len(ids))
+	for _, id := range ids {
+		set[id] = true
+	}
+	return set
+}
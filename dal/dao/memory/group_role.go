@@ -0,0 +1,76 @@
+package memory
+
+import (
+	"TeamTickBackend/dal/models"
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type GroupRoleDAOMemoryImpl struct {
+	Store *Store
+}
+
+// Create 创建自定义角色，同一用户组内名称唯一（idx_grouprole_groupid_name）
+func (dao *GroupRoleDAOMemoryImpl) Create(ctx context.Context, role *models.GroupRole, tx ...*gorm.DB) error {
+	return dao.Store.write(ctx, tx, func(data *tables) error {
+		if data.groupRoles.exists(func(r *models.GroupRole) bool {
+			return r.GroupID == role.GroupID && r.Name == role.Name
+		}) {
+			return gorm.ErrDuplicatedKey
+		}
+		now := time.Now()
+		role.ID = data.groupRoles.newID()
+		role.CreatedAt = orNow(role.CreatedAt, now)
+		role.UpdatedAt = orNow(role.UpdatedAt, now)
+		data.groupRoles.insert(role)
+		return nil
+	})
+}
+
+// GetByID 通过ID查询
+func (dao *GroupRoleDAOMemoryImpl) GetByID(ctx context.Context, id int, tx ...*gorm.DB) (*models.GroupRole, error) {
+	var role *models.GroupRole
+	err := dao.Store.read(ctx, func(data *tables) (err error) {
+		role, err = data.groupRoles.first(func(r *models.GroupRole) bool { return r.ID == id })
+		return err
+	})
+	return role, err
+}
+
+// ListByGroupID 查询用户组的全部自定义角色，按创建顺序
+func (dao *GroupRoleDAOMemoryImpl) ListByGroupID(ctx context.Context, groupID int, tx ...*gorm.DB) ([]*models.GroupRole, error) {
+	var roles []*models.GroupRole
+	err := dao.Store.read(ctx, func(data *tables) error {
+		roles = data.groupRoles.find(func(r *models.GroupRole) bool { return r.GroupID == groupID })
+		return nil
+	})
+	return roles, err
+}
+
+// Update 更新角色的名称、说明与权限
+func (dao *GroupRoleDAOMemoryImpl) Update(ctx context.Context, role *models.GroupRole, tx ...*gorm.DB) error {
+	return dao.Store.write(ctx, tx, func(data *tables) error {
+		if data.groupRoles.exists(func(r *models.GroupRole) bool {
+			return r.GroupID == role.GroupID && r.Name == role.Name && r.ID != role.ID
+		}) {
+			return gorm.ErrDuplicatedKey
+		}
+		data.groupRoles.update(func(r *models.GroupRole) bool { return r.ID == role.ID }, func(r *models.GroupRole) {
+			r.Name = role.Name
+			r.Description = role.Description
+			r.Permissions = role.Permissions
+			r.UpdatedAt = orNow(role.UpdatedAt, time.Now())
+		})
+		return nil
+	})
+}
+
+// Delete 删除自定义角色
+func (dao *GroupRoleDAOMemoryImpl) Delete(ctx context.Context, id int, tx ...*gorm.DB) error {
+	return dao.Store.write(ctx, tx, func(data *tables) error {
+		data.groupRoles.delete(func(r *models.GroupRole) bool { return r.ID == id })
+		return nil
+	})
+}
@@ -0,0 +1,40 @@
+package memory
+
+import (
+	"TeamTickBackend/dal/models"
+	"context"
+	"slices"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type GroupRoleChangeDAOMemoryImpl struct {
+	Store *Store
+}
+
+// Create 追加一条角色变更记录
+func (dao *GroupRoleChangeDAOMemoryImpl) Create(ctx context.Context, change *models.GroupRoleChange, tx ...*gorm.DB) error {
+	return dao.Store.write(ctx, tx, func(data *tables) error {
+		change.ID = data.groupRoleChanges.newID()
+		change.CreatedAt = orNow(change.CreatedAt, time.Now())
+		data.groupRoleChanges.insert(change)
+		return nil
+	})
+}
+
+// ListByGroupID 按ID倒序（即时间倒序）分页
+func (dao *GroupRoleChangeDAOMemoryImpl) ListByGroupID(ctx context.Context, groupID, offset, limit int, tx ...*gorm.DB) ([]*models.GroupRoleChange, int64, error) {
+	var changes []*models.GroupRoleChange
+	err := dao.Store.read(ctx, func(data *tables) error {
+		changes = data.groupRoleChanges.find(func(c *models.GroupRoleChange) bool {
+			return c.GroupID == groupID
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	slices.Reverse(changes)
+	return page(changes, offset, limit), int64(len(changes)), nil
+}
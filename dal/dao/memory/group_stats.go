@@ -0,0 +1,46 @@
+package memory
+
+import (
+	"TeamTickBackend/dal/models"
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type GroupStatsDAOMemoryImpl struct {
+	Store *Store
+}
+
+// Collect 按用户组分组统计任务数、进行中的任务数与签到次数
+func (dao *GroupStatsDAOMemoryImpl) Collect(ctx context.Context, groupIDs []int, now time.Time, tx ...*gorm.DB) (map[int]*models.GroupActivity, error) {
+	ids := intSet(groupIDs)
+	activities := make(map[int]*models.GroupActivity)
+	activity := func(groupID int) *models.GroupActivity {
+		if activities[groupID] == nil {
+			activities[groupID] = &models.GroupActivity{}
+		}
+		return activities[groupID]
+	}
+	err := dao.Store.read(ctx, func(data *tables) error {
+		for _, t := range data.tasks.rows {
+			if !ids[t.GroupID] {
+				continue
+			}
+			activity(t.GroupID).Tasks++
+			if isActive(t, now) {
+				activity(t.GroupID).ActiveTasks++
+			}
+		}
+		for _, r := range data.taskRecords.rows {
+			if ids[r.GroupID] {
+				activity(r.GroupID).CheckIns++
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return activities, nil
+}
@@ -0,0 +1,292 @@
+package memory
+
+import (
+	"TeamTickBackend/dal/models"
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type GroupDAOMemoryImpl struct {
+	Store *Store
+}
+
+// Create 创建组
+func (dao *GroupDAOMemoryImpl) Create(ctx context.Context, group *models.Group, tx ...*gorm.DB) error {
+	return dao.Store.write(ctx, tx, func(data *tables) error {
+		now := time.Now()
+		group.GroupID = data.groups.newID()
+		group.CreatedAt = orNow(group.CreatedAt, now)
+		group.UpdatedAt = orNow(group.UpdatedAt, now)
+		if group.MemberNum == 0 {
+			group.MemberNum = 1
+		}
+		if group.JoinPolicy == "" {
+			group.JoinPolicy = models.JoinPolicyApproval
+		}
+		data.groups.insert(group)
+		return nil
+	})
+}
+
+// GetByGroupID 通过group_id查询组信息
+func (dao *GroupDAOMemoryImpl) GetByGroupID(ctx context.Context, groupID int, tx ...*gorm.DB) (*models.Group, error) {
+	var group *models.Group
+	err := dao.Store.read(ctx, func(data *tables) (err error) {
+		group, err = data.groups.first(func(g *models.Group) bool { return g.GroupID == groupID })
+		return err
+	})
+	return group, err
+}
+
+// GetGroupsByUserID 通过user_id获取用户所在的所有用户组，以及用户作为管理员的用户组的全部下级用户组
+func (dao *GroupDAOMemoryImpl) GetGroupsByUserID(ctx context.Context, userID int, tx ...*gorm.DB) ([]*models.Group, error) {
+	var groups []*models.Group
+	err := dao.Store.read(ctx, func(data *tables) error {
+		managed := managedGroupIDs(data, userID)
+		groups = data.groups.find(func(g *models.Group) bool {
+			return managed[g.GroupID] || data.groupMembers.exists(func(m *models.GroupMember) bool {
+				return m.GroupID == g.GroupID && m.UserID == userID
+			})
+		})
+		return nil
+	})
+	return groups, err
+}
+
+// GetGroupsByUserIDAndfilter 通过user_id和filter获取用户所在的所有用户组
+// created 包含用户作为管理员的用户组的全部下级用户组
+func (dao *GroupDAOMemoryImpl) GetGroupsByUserIDAndfilter(ctx context.Context, userID int, filter string, tx ...*gorm.DB) ([]*models.Group, error) {
+	var groups []*models.Group
+	err := dao.Store.read(ctx, func(data *tables) error {
+		switch filter {
+		case "created":
+			managed := managedGroupIDs(data, userID)
+			groups = data.groups.find(func(g *models.Group) bool { return managed[g.GroupID] })
+		case "joined":
+			groups = joinGroups(data, func(m *models.GroupMember) bool { return m.UserID == userID && m.Role == "member" })
+		}
+		return nil
+	})
+	return groups, err
+}
+
+// UpdateMessage 更新组信息
+func (dao *GroupDAOMemoryImpl) UpdateMessage(ctx context.Context, groupID int, groupName, description string, tx ...*gorm.DB) error {
+	return dao.Store.write(ctx, tx, func(data *tables) error {
+		data.groups.update(func(g *models.Group) bool { return g.GroupID == groupID }, func(g *models.Group) {
+			g.GroupName = groupName
+			g.Description = description
+			g.UpdatedAt = time.Now()
+		})
+		return nil
+	})
+}
+
+// UpdateMemberNum 更新组成员数量
+func (dao *GroupDAOMemoryImpl) UpdateMemberNum(ctx context.Context, groupID int, increment bool, tx ...*gorm.DB) error {
+	return dao.Store.write(ctx, tx, func(data *tables) error {
+		data.groups.update(func(g *models.Group) bool { return g.GroupID == groupID }, func(g *models.Group) {
+			if increment {
+				g.MemberNum++
+			} else {
+				g.MemberNum--
+			}
+			g.UpdatedAt = time.Now()
+		})
+		return nil
+	})
+}
+
+// Delete 删除用户组
+func (dao *GroupDAOMemoryImpl) Delete(ctx context.Context, groupID int, tx ...*gorm.DB) error {
+	return dao.Store.write(ctx, tx, func(data *tables) error {
+		data.groups.delete(func(g *models.Group) bool { return g.GroupID == groupID })
+		return nil
+	})
+}
+
+// List 查询所有用户组
+func (dao *GroupDAOMemoryImpl) List(ctx context.Context, tx ...*gorm.DB) ([]*models.Group, error) {
+	var groups []*models.Group
+	err := dao.Store.read(ctx, func(data *tables) error {
+		groups = data.groups.find(func(*models.Group) bool { return true })
+		return nil
+	})
+	return groups, err
+}
+
+// UpdateCreator 更新用户组创建者
+func (dao *GroupDAOMemoryImpl) UpdateCreator(ctx context.Context, groupID, creatorID int, creatorName string, tx ...*gorm.DB) error {
+	return dao.Store.write(ctx, tx, func(data *tables) error {
+		data.groups.update(func(g *models.Group) bool { return g.GroupID == groupID }, func(g *models.Group) {
+			g.CreatorID = creatorID
+			g.CreatorName = creatorName
+			g.UpdatedAt = time.Now()
+		})
+		return nil
+	})
+}
+
+// SetMemberNum 将组成员数量设置为指定值
+func (dao *GroupDAOMemoryImpl) SetMemberNum(ctx context.Context, groupID, memberNum int, tx ...*gorm.DB) error {
+	return dao.Store.write(ctx, tx, func(data *tables) error {
+		data.groups.update(func(g *models.Group) bool { return g.GroupID == groupID }, func(g *models.Group) {
+			g.MemberNum = memberNum
+			g.UpdatedAt = time.Now()
+		})
+		return nil
+	})
+}
+
+// joinGroups 等价于 groups JOIN group_member，每条匹配的成员记录对应一行结果
+func joinGroups(data *tables, match func(*models.GroupMember) bool) []*models.Group {
+	groups := make([]*models.Group, 0)
+	for _, group := range data.groups.rows {
+		for _, member := range data.groupMembers.rows {
+			if member.GroupID == group.GroupID && match(member) {
+				copied := *group
+				groups = append(groups, &copied)
+			}
+		}
+	}
+	return groups
+}
+
+// UpdateRequireAdmin2FA 更新是否要求组管理员开启两步验证
+func (dao *GroupDAOMemoryImpl) UpdateRequireAdmin2FA(ctx context.Context, groupID int, require bool, tx ...*gorm.DB) error {
+	return dao.Store.write(ctx, tx, func(data *tables) error {
+		data.groups.update(func(g *models.Group) bool { return g.GroupID == groupID }, func(g *models.Group) {
+			g.RequireAdmin2FA = require
+			g.UpdatedAt = time.Now()
+		})
+		return nil
+	})
+}
+
+// UpdateJoinPolicy 更新加入方式
+func (dao *GroupDAOMemoryImpl) UpdateJoinPolicy(ctx context.Context, groupID int, policy string, tx ...*gorm.DB) error {
+	return dao.Store.write(ctx, tx, func(data *tables) error {
+		data.groups.update(func(g *models.Group) bool { return g.GroupID == groupID }, func(g *models.Group) {
+			g.JoinPolicy = policy
+			g.UpdatedAt = time.Now()
+		})
+		return nil
+	})
+}
+
+// Search 按名称或创建者用户名模糊查询，不区分大小写
+func (dao *GroupDAOMemoryImpl) Search(ctx context.Context, keyword string, offset, limit int, tx ...*gorm.DB) ([]*models.Group, int64, error) {
+	var groups []*models.Group
+	err := dao.Store.read(ctx, func(data *tables) error {
+		groups = data.groups.find(func(g *models.Group) bool { return containsFold(keyword, g.GroupName, g.CreatorName) })
+		return nil
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	return page(groups, offset, limit), int64(len(groups)), nil
+}
+
+// UpdateParent 设置上级用户组
+func (dao *GroupDAOMemoryImpl) UpdateParent(ctx context.Context, groupID, parentID int, tx ...*gorm.DB) error {
+	return dao.Store.write(ctx, tx, func(data *tables) error {
+		data.groups.update(func(g *models.Group) bool { return g.GroupID == groupID }, func(g *models.Group) {
+			g.ParentID = parentID
+			g.UpdatedAt = time.Now()
+		})
+		return nil
+	})
+}
+
+// ReparentChildren 将直接下级用户组移到新的上级用户组下
+func (dao *GroupDAOMemoryImpl) ReparentChildren(ctx context.Context, parentID, newParentID int, tx ...*gorm.DB) error {
+	return dao.Store.write(ctx, tx, func(data *tables) error {
+		data.groups.update(func(g *models.Group) bool { return g.ParentID == parentID }, func(g *models.Group) {
+			g.ParentID = newParentID
+			g.UpdatedAt = time.Now()
+		})
+		return nil
+	})
+}
+
+// GetAncestors 查询全部上级用户组，由近及远排列
+func (dao *GroupDAOMemoryImpl) GetAncestors(ctx context.Context, groupID int, tx ...*gorm.DB) ([]*models.Group, error) {
+	groups := make([]*models.Group, 0)
+	err := dao.Store.read(ctx, func(data *tables) error {
+		for _, id := range ancestorIDs(data, groupID) {
+			if group, err := data.groups.first(func(g *models.Group) bool { return g.GroupID == id }); err == nil {
+				groups = append(groups, group)
+			}
+		}
+		return nil
+	})
+	return groups, err
+}
+
+// GetDescendants 查询全部下级用户组，按层级与用户组ID排列
+func (dao *GroupDAOMemoryImpl) GetDescendants(ctx context.Context, groupID int, tx ...*gorm.DB) ([]*models.Group, error) {
+	groups := make([]*models.Group, 0)
+	err := dao.Store.read(ctx, func(data *tables) error {
+		level := []int{groupID}
+		for depth := 1; depth <= models.GroupMaxDepth && len(level) > 0; depth++ {
+			parents := make(map[int]bool, len(level))
+			for _, id := range level {
+				parents[id] = true
+			}
+			children := data.groups.find(func(g *models.Group) bool { return parents[g.ParentID] })
+			groups = append(groups, children...)
+			level = level[:0]
+			for _, child := range children {
+				level = append(level, child.GroupID)
+			}
+		}
+		return nil
+	})
+	return groups, err
+}
+
+// ancestorIDs 对应向上的递归查询，由近及远返回上级用户组ID
+func ancestorIDs(data *tables, groupID int) []int {
+	ids := make([]int, 0)
+	current := groupID
+	for depth := 1; depth <= models.GroupMaxDepth; depth++ {
+		group, err := data.groups.first(func(g *models.Group) bool { return g.GroupID == current })
+		if err != nil || group.ParentID == 0 {
+			break
+		}
+		ids = append(ids, group.ParentID)
+		current = group.ParentID
+	}
+	return ids
+}
+
+// managedGroupIDs 用户作为管理员的用户组及其全部下级用户组
+func managedGroupIDs(data *tables, userID int) map[int]bool {
+	managed := make(map[int]bool)
+	level := make([]int, 0)
+	for _, member := range data.groupMembers.rows {
+		if member.UserID == userID && member.Role == "admin" {
+			managed[member.GroupID] = true
+			level = append(level, member.GroupID)
+		}
+	}
+	for depth := 1; depth <= models.GroupMaxDepth && len(level) > 0; depth++ {
+		next := make([]int, 0)
+		for _, group := range data.groups.rows {
+			if group.ParentID == 0 || managed[group.GroupID] {
+				continue
+			}
+			for _, id := range level {
+				if group.ParentID == id {
+					managed[group.GroupID] = true
+					next = append(next, group.GroupID)
+					break
+				}
+			}
+		}
+		level = next
+	}
+	return managed
+}
@@ -0,0 +1,89 @@
+package memory
+
+import (
+	"TeamTickBackend/dal/models"
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type JoinApplicationDAOMemoryImpl struct {
+	Store *Store
+}
+
+// Create 创建加入申请，同一用户对同一用户组只能有一条申请（idx_groupid_userid）
+func (dao *JoinApplicationDAOMemoryImpl) Create(ctx context.Context, application *models.JoinApplication, tx ...*gorm.DB) error {
+	return dao.Store.write(ctx, tx, func(data *tables) error {
+		if data.joinApplications.exists(func(a *models.JoinApplication) bool {
+			return a.GroupID == application.GroupID && a.UserID == application.UserID
+		}) {
+			return gorm.ErrDuplicatedKey
+		}
+		now := time.Now()
+		application.RequestID = data.joinApplications.newID()
+		application.CreatedAt = orNow(application.CreatedAt, now)
+		application.UpdatedAt = orNow(application.UpdatedAt, now)
+		if application.Status == "" {
+			application.Status = "pending"
+		}
+		data.joinApplications.insert(application)
+		return nil
+	})
+}
+
+// GetByGroupIDAndStatus 通过group_id和status查询加入申请
+func (dao *JoinApplicationDAOMemoryImpl) GetByGroupIDAndStatus(ctx context.Context, groupID int, status string, tx ...*gorm.DB) ([]*models.JoinApplication, error) {
+	return dao.find(ctx, func(a *models.JoinApplication) bool { return a.GroupID == groupID && a.Status == status })
+}
+
+// GetByGroupID 通过group_id查询所有加入申请
+func (dao *JoinApplicationDAOMemoryImpl) GetByGroupID(ctx context.Context, groupID int, tx ...*gorm.DB) ([]*models.JoinApplication, error) {
+	return dao.find(ctx, func(a *models.JoinApplication) bool { return a.GroupID == groupID })
+}
+
+// GetByUserID 通过user_id查询加入申请
+func (dao *JoinApplicationDAOMemoryImpl) GetByUserID(ctx context.Context, userID int, tx ...*gorm.DB) ([]*models.JoinApplication, error) {
+	return dao.find(ctx, func(a *models.JoinApplication) bool { return a.UserID == userID })
+}
+
+// UpdateStatus 更新加入申请状态(管理员审批)
+func (dao *JoinApplicationDAOMemoryImpl) UpdateStatus(ctx context.Context, requestID int, status string, tx ...*gorm.DB) error {
+	return dao.update(ctx, requestID, func(a *models.JoinApplication) { a.Status = status }, tx)
+}
+
+// UpdateRejectReason 更新拒绝理由
+func (dao *JoinApplicationDAOMemoryImpl) UpdateRejectReason(ctx context.Context, requestID int, rejectReason string, tx ...*gorm.DB) error {
+	return dao.update(ctx, requestID, func(a *models.JoinApplication) { a.RejectReason = rejectReason }, tx)
+}
+
+// GetByGroupIDAndUserID 通过group_id和user_id查询加入申请
+func (dao *JoinApplicationDAOMemoryImpl) GetByGroupIDAndUserID(ctx context.Context, groupID int, userID int, tx ...*gorm.DB) (*models.JoinApplication, error) {
+	var application *models.JoinApplication
+	err := dao.Store.read(ctx, func(data *tables) (err error) {
+		application, err = data.joinApplications.first(func(a *models.JoinApplication) bool {
+			return a.GroupID == groupID && a.UserID == userID
+		})
+		return err
+	})
+	return application, err
+}
+
+func (dao *JoinApplicationDAOMemoryImpl) find(ctx context.Context, match func(*models.JoinApplication) bool) ([]*models.JoinApplication, error) {
+	var applications []*models.JoinApplication
+	err := dao.Store.read(ctx, func(data *tables) error {
+		applications = data.joinApplications.find(match)
+		return nil
+	})
+	return applications, err
+}
+
+func (dao *JoinApplicationDAOMemoryImpl) update(ctx context.Context, requestID int, apply func(*models.JoinApplication), tx []*gorm.DB) error {
+	return dao.Store.write(ctx, tx, func(data *tables) error {
+		data.joinApplications.update(func(a *models.JoinApplication) bool { return a.RequestID == requestID }, func(a *models.JoinApplication) {
+			apply(a)
+			a.UpdatedAt = time.Now()
+		})
+		return nil
+	})
+}
@@ -0,0 +1,90 @@
+package memory
+
+import (
+	"TeamTickBackend/dal/models"
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type LoginAttemptDAOMemoryImpl struct {
+	Store *Store
+}
+
+// Get 查询登录失败计数
+func (dao *LoginAttemptDAOMemoryImpl) Get(ctx context.Context, scope, subject string, tx ...*gorm.DB) (*models.LoginAttempt, error) {
+	var attempt *models.LoginAttempt
+	err := dao.Store.read(ctx, func(data *tables) (err error) {
+		attempt, err = data.loginAttempts.first(func(a *models.LoginAttempt) bool {
+			return a.Scope == scope && a.Subject == subject
+		})
+		return err
+	})
+	return attempt, err
+}
+
+// RecordFailure 累加失败次数，(scope, subject) 唯一（idx_loginattempt_scope_subject）
+func (dao *LoginAttemptDAOMemoryImpl) RecordFailure(ctx context.Context, scope, subject string, now, windowStart time.Time, tx ...*gorm.DB) (*models.LoginAttempt, error) {
+	var attempt *models.LoginAttempt
+	err := dao.Store.write(ctx, tx, func(data *tables) error {
+		match := func(a *models.LoginAttempt) bool { return a.Scope == scope && a.Subject == subject }
+		affected := data.loginAttempts.update(match, func(a *models.LoginAttempt) {
+			if a.LastFailureAt.Before(windowStart) {
+				a.Failures = 0
+				a.BlockedUntil = nil
+			}
+			a.Failures++
+			a.LastFailureAt = now
+		})
+		if affected == 0 {
+			data.loginAttempts.insert(&models.LoginAttempt{
+				ID:            data.loginAttempts.newID(),
+				Scope:         scope,
+				Subject:       subject,
+				Failures:      1,
+				LastFailureAt: now,
+			})
+		}
+		var err error
+		attempt, err = data.loginAttempts.first(match)
+		return err
+	})
+	return attempt, err
+}
+
+// ExtendBlock 仅当新的截止时间更晚时更新
+func (dao *LoginAttemptDAOMemoryImpl) ExtendBlock(ctx context.Context, scope, subject string, until time.Time, tx ...*gorm.DB) error {
+	return dao.Store.write(ctx, tx, func(data *tables) error {
+		data.loginAttempts.update(func(a *models.LoginAttempt) bool {
+			return a.Scope == scope && a.Subject == subject && (a.BlockedUntil == nil || a.BlockedUntil.Before(until))
+		}, func(a *models.LoginAttempt) {
+			a.BlockedUntil = &until
+		})
+		return nil
+	})
+}
+
+// Delete 删除登录失败计数
+func (dao *LoginAttemptDAOMemoryImpl) Delete(ctx context.Context, scope, subject string, tx ...*gorm.DB) (bool, error) {
+	var affected int
+	err := dao.Store.write(ctx, tx, func(data *tables) error {
+		affected = data.loginAttempts.delete(func(a *models.LoginAttempt) bool {
+			return a.Scope == scope && a.Subject == subject
+		})
+		return nil
+	})
+	return affected > 0, err
+}
+
+// DeleteStale 清理已失去作用的计数记录
+func (dao *LoginAttemptDAOMemoryImpl) DeleteStale(ctx context.Context, before time.Time, tx ...*gorm.DB) (int64, error) {
+	var affected int
+	err := dao.Store.write(ctx, tx, func(data *tables) error {
+		affected = data.loginAttempts.delete(func(a *models.LoginAttempt) bool {
+			return a.LastFailureAt.Before(before) && (a.BlockedUntil == nil || a.BlockedUntil.Before(before))
+		})
+		return nil
+	})
+	return int64(affected), err
+}
@@ -0,0 +1,97 @@
+package memory
+
+import (
+	"TeamTickBackend/dal/models"
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type UserIdentityDAOMemoryImpl struct {
+	Store *Store
+}
+
+// GetByIssuerSubject 通过身份提供方和用户标识查询关联
+func (dao *UserIdentityDAOMemoryImpl) GetByIssuerSubject(ctx context.Context, issuer, subject string, tx ...*gorm.DB) (*models.UserIdentity, error) {
+	var identity *models.UserIdentity
+	err := dao.Store.read(ctx, func(data *tables) (err error) {
+		identity, err = data.userIdentities.first(func(i *models.UserIdentity) bool {
+			return i.Issuer == issuer && i.Subject == subject
+		})
+		return err
+	})
+	return identity, err
+}
+
+// Create 创建身份关联，issuer + subject 唯一（idx_useridentity_issuer_subject）
+func (dao *UserIdentityDAOMemoryImpl) Create(ctx context.Context, identity *models.UserIdentity, tx ...*gorm.DB) error {
+	return dao.Store.write(ctx, tx, func(data *tables) error {
+		if data.userIdentities.exists(func(i *models.UserIdentity) bool {
+			return i.Issuer == identity.Issuer && i.Subject == identity.Subject
+		}) {
+			return gorm.ErrDuplicatedKey
+		}
+		identity.ID = data.userIdentities.newID()
+		identity.CreatedAt = orNow(identity.CreatedAt, time.Now())
+		data.userIdentities.insert(identity)
+		return nil
+	})
+}
+
+// GetByUserID 查询用户关联的全部外部身份
+func (dao *UserIdentityDAOMemoryImpl) GetByUserID(ctx context.Context, userID int, tx ...*gorm.DB) ([]*models.UserIdentity, error) {
+	var identities []*models.UserIdentity
+	err := dao.Store.read(ctx, func(data *tables) error {
+		identities = data.userIdentities.find(func(i *models.UserIdentity) bool { return i.UserID == userID })
+		return nil
+	})
+	return identities, err
+}
+
+type OIDCLoginStateDAOMemoryImpl struct {
+	Store *Store
+}
+
+// Create 保存授权请求状态，state摘要唯一（idx_oidcstate_statehash）
+func (dao *OIDCLoginStateDAOMemoryImpl) Create(ctx context.Context, state *models.OIDCLoginState, tx ...*gorm.DB) error {
+	return dao.Store.write(ctx, tx, func(data *tables) error {
+		if data.oidcLoginStates.exists(func(s *models.OIDCLoginState) bool { return s.StateHash == state.StateHash }) {
+			return gorm.ErrDuplicatedKey
+		}
+		state.ID = data.oidcLoginStates.newID()
+		state.CreatedAt = orNow(state.CreatedAt, time.Now())
+		data.oidcLoginStates.insert(state)
+		return nil
+	})
+}
+
+// GetByStateHash 通过state摘要查询授权请求状态
+func (dao *OIDCLoginStateDAOMemoryImpl) GetByStateHash(ctx context.Context, stateHash string, tx ...*gorm.DB) (*models.OIDCLoginState, error) {
+	var state *models.OIDCLoginState
+	err := dao.Store.read(ctx, func(data *tables) (err error) {
+		state, err = data.oidcLoginStates.first(func(s *models.OIDCLoginState) bool { return s.StateHash == stateHash })
+		return err
+	})
+	return state, err
+}
+
+// Delete 删除授权请求状态，返回是否删除成功
+func (dao *OIDCLoginStateDAOMemoryImpl) Delete(ctx context.Context, id int, tx ...*gorm.DB) (bool, error) {
+	var affected int
+	err := dao.Store.write(ctx, tx, func(data *tables) error {
+		affected = data.oidcLoginStates.delete(func(s *models.OIDCLoginState) bool { return s.ID == id })
+		return nil
+	})
+	return affected == 1, err
+}
+
+// DeleteExpired 清理已过期的授权请求状态
+func (dao *OIDCLoginStateDAOMemoryImpl) DeleteExpired(ctx context.Context, now time.Time, tx ...*gorm.DB) (int64, error) {
+	var affected int
+	err := dao.Store.write(ctx, tx, func(data *tables) error {
+		affected = data.oidcLoginStates.delete(func(s *models.OIDCLoginState) bool { return !s.ExpiresAt.After(now) })
+		return nil
+	})
+	return int64(affected), err
+}
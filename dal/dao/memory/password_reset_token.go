@@ -0,0 +1,62 @@
+package memory
+
+import (
+	"TeamTickBackend/dal/models"
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type PasswordResetTokenDAOMemoryImpl struct {
+	Store *Store
+}
+
+// Create 创建重置密码令牌，令牌摘要唯一（idx_pwreset_tokenhash）
+func (dao *PasswordResetTokenDAOMemoryImpl) Create(ctx context.Context, token *models.PasswordResetToken, tx ...*gorm.DB) error {
+	return dao.Store.write(ctx, tx, func(data *tables) error {
+		if data.passwordResetTokens.exists(func(t *models.PasswordResetToken) bool { return t.TokenHash == token.TokenHash }) {
+			return gorm.ErrDuplicatedKey
+		}
+		token.ID = data.passwordResetTokens.newID()
+		token.CreatedAt = orNow(token.CreatedAt, time.Now())
+		data.passwordResetTokens.insert(token)
+		return nil
+	})
+}
+
+// GetByTokenHash 通过令牌摘要查询重置密码令牌
+func (dao *PasswordResetTokenDAOMemoryImpl) GetByTokenHash(ctx context.Context, tokenHash string, tx ...*gorm.DB) (*models.PasswordResetToken, error) {
+	var token *models.PasswordResetToken
+	err := dao.Store.read(ctx, func(data *tables) (err error) {
+		token, err = data.passwordResetTokens.first(func(t *models.PasswordResetToken) bool { return t.TokenHash == tokenHash })
+		return err
+	})
+	return token, err
+}
+
+// MarkUsed 仅当令牌尚未使用时标记为已使用
+func (dao *PasswordResetTokenDAOMemoryImpl) MarkUsed(ctx context.Context, id int, usedAt time.Time, tx ...*gorm.DB) (bool, error) {
+	var affected int
+	err := dao.Store.write(ctx, tx, func(data *tables) error {
+		affected = data.passwordResetTokens.update(func(t *models.PasswordResetToken) bool {
+			return t.ID == id && t.UsedAt == nil
+		}, func(t *models.PasswordResetToken) {
+			t.UsedAt = &usedAt
+		})
+		return nil
+	})
+	return affected == 1, err
+}
+
+// InvalidateByUserID 作废用户所有未使用的令牌
+func (dao *PasswordResetTokenDAOMemoryImpl) InvalidateByUserID(ctx context.Context, userID int, usedAt time.Time, tx ...*gorm.DB) error {
+	return dao.Store.write(ctx, tx, func(data *tables) error {
+		data.passwordResetTokens.update(func(t *models.PasswordResetToken) bool {
+			return t.UserID == userID && t.UsedAt == nil
+		}, func(t *models.PasswordResetToken) {
+			t.UsedAt = &usedAt
+		})
+		return nil
+	})
+}
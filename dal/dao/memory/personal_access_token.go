@@ -0,0 +1,87 @@
+package memory
+
+import (
+	"TeamTickBackend/dal/models"
+	"context"
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type PersonalAccessTokenDAOMemoryImpl struct {
+	Store *Store
+}
+
+// Create 创建个人访问令牌，令牌摘要唯一（idx_pat_tokenhash）
+func (dao *PersonalAccessTokenDAOMemoryImpl) Create(ctx context.Context, token *models.PersonalAccessToken, tx ...*gorm.DB) error {
+	return dao.Store.write(ctx, tx, func(data *tables) error {
+		if data.accessTokens.exists(func(t *models.PersonalAccessToken) bool { return t.TokenHash == token.TokenHash }) {
+			return gorm.ErrDuplicatedKey
+		}
+		token.ID = data.accessTokens.newID()
+		token.CreatedAt = orNow(token.CreatedAt, time.Now())
+		data.accessTokens.insert(token)
+		return nil
+	})
+}
+
+// GetByTokenHash 通过令牌摘要查询
+func (dao *PersonalAccessTokenDAOMemoryImpl) GetByTokenHash(ctx context.Context, tokenHash string, tx ...*gorm.DB) (*models.PersonalAccessToken, error) {
+	var token *models.PersonalAccessToken
+	err := dao.Store.read(ctx, func(data *tables) (err error) {
+		token, err = data.accessTokens.first(func(t *models.PersonalAccessToken) bool { return t.TokenHash == tokenHash })
+		return err
+	})
+	return token, err
+}
+
+// ListByUserID 查询用户未吊销的令牌，按创建时间倒序
+func (dao *PersonalAccessTokenDAOMemoryImpl) ListByUserID(ctx context.Context, userID int, tx ...*gorm.DB) ([]*models.PersonalAccessToken, error) {
+	var tokens []*models.PersonalAccessToken
+	err := dao.Store.read(ctx, func(data *tables) error {
+		tokens = data.accessTokens.find(func(t *models.PersonalAccessToken) bool {
+			return t.UserID == userID && t.RevokedAt == nil
+		})
+		return nil
+	})
+	sort.Slice(tokens, func(i, j int) bool { return tokens[i].ID > tokens[j].ID })
+	return tokens, err
+}
+
+// CountActiveByUserID 统计用户未吊销且未过期的令牌数量
+func (dao *PersonalAccessTokenDAOMemoryImpl) CountActiveByUserID(ctx context.Context, userID int, now time.Time, tx ...*gorm.DB) (int64, error) {
+	var count int
+	err := dao.Store.read(ctx, func(data *tables) error {
+		count = len(data.accessTokens.find(func(t *models.PersonalAccessToken) bool {
+			return t.UserID == userID && t.RevokedAt == nil && t.ExpiresAt.After(now)
+		}))
+		return nil
+	})
+	return int64(count), err
+}
+
+// Revoke 吊销属于该用户且尚未吊销的令牌
+func (dao *PersonalAccessTokenDAOMemoryImpl) Revoke(ctx context.Context, id, userID int, revokedAt time.Time, tx ...*gorm.DB) (bool, error) {
+	var affected int
+	err := dao.Store.write(ctx, tx, func(data *tables) error {
+		affected = data.accessTokens.update(func(t *models.PersonalAccessToken) bool {
+			return t.ID == id && t.UserID == userID && t.RevokedAt == nil
+		}, func(t *models.PersonalAccessToken) {
+			t.RevokedAt = &revokedAt
+		})
+		return nil
+	})
+	return affected == 1, err
+}
+
+// UpdateLastUsed 记录最近使用时间与IP
+func (dao *PersonalAccessTokenDAOMemoryImpl) UpdateLastUsed(ctx context.Context, id int, usedAt time.Time, ip string, tx ...*gorm.DB) error {
+	return dao.Store.write(ctx, tx, func(data *tables) error {
+		data.accessTokens.update(func(t *models.PersonalAccessToken) bool { return t.ID == id }, func(t *models.PersonalAccessToken) {
+			t.LastUsedAt = &usedAt
+			t.LastUsedIP = ip
+		})
+		return nil
+	})
+}
@@ -0,0 +1,58 @@
+package memory
+
+import (
+	"TeamTickBackend/dal/models"
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type PlatformStatsDAOMemoryImpl struct {
+	Store *Store
+}
+
+// Collect 逐项统计平台概况
+func (dao *PlatformStatsDAOMemoryImpl) Collect(ctx context.Context, now time.Time, tx ...*gorm.DB) (*models.PlatformStats, error) {
+	var stats models.PlatformStats
+	err := dao.Store.read(ctx, func(data *tables) error {
+		for _, u := range data.users.rows {
+			stats.Users++
+			if u.DisabledAt != nil {
+				stats.DisabledUsers++
+			}
+			if u.PlatformRole == models.PlatformRoleSuperAdmin {
+				stats.SuperAdmins++
+			}
+		}
+		stats.Groups = int64(len(data.groups.rows))
+		for _, t := range data.tasks.rows {
+			stats.Tasks++
+			if isActive(t, now) {
+				stats.ActiveTasks++
+			}
+		}
+		since := now.Add(-24 * time.Hour)
+		for _, r := range data.taskRecords.rows {
+			stats.CheckIns++
+			if r.SignedTime.After(since) {
+				stats.CheckInsLast24h++
+			}
+		}
+		for _, a := range data.checkApplications.rows {
+			if a.Status == "pending" {
+				stats.PendingAudits++
+			}
+		}
+		for _, a := range data.joinApplications.rows {
+			if a.Status == "pending" {
+				stats.PendingJoinRequests++
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &stats, nil
+}
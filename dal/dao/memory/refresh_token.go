@@ -0,0 +1,74 @@
+package memory
+
+import (
+	"TeamTickBackend/dal/models"
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type RefreshTokenDAOMemoryImpl struct {
+	Store *Store
+}
+
+// Create 创建刷新令牌，令牌摘要唯一（idx_refresh_tokenhash）
+func (dao *RefreshTokenDAOMemoryImpl) Create(ctx context.Context, token *models.RefreshToken, tx ...*gorm.DB) error {
+	return dao.Store.write(ctx, tx, func(data *tables) error {
+		if data.refreshTokens.exists(func(t *models.RefreshToken) bool { return t.TokenHash == token.TokenHash }) {
+			return gorm.ErrDuplicatedKey
+		}
+		token.ID = data.refreshTokens.newID()
+		token.CreatedAt = orNow(token.CreatedAt, time.Now())
+		data.refreshTokens.insert(token)
+		return nil
+	})
+}
+
+// GetByTokenHash 通过令牌摘要查询刷新令牌
+func (dao *RefreshTokenDAOMemoryImpl) GetByTokenHash(ctx context.Context, tokenHash string, tx ...*gorm.DB) (*models.RefreshToken, error) {
+	var token *models.RefreshToken
+	err := dao.Store.read(ctx, func(data *tables) (err error) {
+		token, err = data.refreshTokens.first(func(t *models.RefreshToken) bool { return t.TokenHash == tokenHash })
+		return err
+	})
+	return token, err
+}
+
+// MarkUsed 仅当令牌尚未使用时标记为已使用
+func (dao *RefreshTokenDAOMemoryImpl) MarkUsed(ctx context.Context, id int, usedAt time.Time, tx ...*gorm.DB) (bool, error) {
+	var affected int
+	err := dao.Store.write(ctx, tx, func(data *tables) error {
+		affected = data.refreshTokens.update(func(t *models.RefreshToken) bool {
+			return t.ID == id && t.UsedAt == nil
+		}, func(t *models.RefreshToken) {
+			t.UsedAt = &usedAt
+		})
+		return nil
+	})
+	return affected == 1, err
+}
+
+// RevokeFamily 吊销令牌家族中所有未吊销的令牌
+func (dao *RefreshTokenDAOMemoryImpl) RevokeFamily(ctx context.Context, familyID string, revokedAt time.Time, tx ...*gorm.DB) error {
+	return dao.Store.write(ctx, tx, func(data *tables) error {
+		data.refreshTokens.update(func(t *models.RefreshToken) bool {
+			return t.FamilyID == familyID && t.RevokedAt == nil
+		}, func(t *models.RefreshToken) {
+			t.RevokedAt = &revokedAt
+		})
+		return nil
+	})
+}
+
+// RevokeByUserID 吊销用户所有未吊销的刷新令牌
+func (dao *RefreshTokenDAOMemoryImpl) RevokeByUserID(ctx context.Context, userID int, revokedAt time.Time, tx ...*gorm.DB) error {
+	return dao.Store.write(ctx, tx, func(data *tables) error {
+		data.refreshTokens.update(func(t *models.RefreshToken) bool {
+			return t.UserID == userID && t.RevokedAt == nil
+		}, func(t *models.RefreshToken) {
+			t.RevokedAt = &revokedAt
+		})
+		return nil
+	})
+}
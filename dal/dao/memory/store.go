@@ -0,0 +1,286 @@
+package memory
+
+import (
+	"TeamTickBackend/dal/models"
+	"context"
+	"sync"
+
+	"gorm.io/gorm"
+)
+
+// table 单张内存表，按插入顺序保存行，与数据库按主键的默认顺序一致
+type table[T any] struct {
+	rows   []*T
+	nextID int
+	undo   *undoLog
+}
+
+// newID 分配自增主键，与数据库一致，回滚不会收回已分配的主键
+func (t *table[T]) newID() int {
+	t.nextID++
+	return t.nextID
+}
+
+func (t *table[T]) insert(row *T) {
+	copied := *row
+	inserted := &copied
+	t.rows = append(t.rows, inserted)
+	t.undo.add(func() { t.remove(inserted) })
+}
+
+// first 返回第一条满足条件的行的副本
+func (t *table[T]) first(match func(*T) bool) (*T, error) {
+	for _, row := range t.rows {
+		if match(row) {
+			copied := *row
+			return &copied, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+// exists 是否存在满足条件的行，用于唯一约束校验
+func (t *table[T]) exists(match func(*T) bool) bool {
+	for _, row := range t.rows {
+		if match(row) {
+			return true
+		}
+	}
+	return false
+}
+
+// find 返回所有满足条件的行的副本
+func (t *table[T]) find(match func(*T) bool) []*T {
+	result := make([]*T, 0)
+	for _, row := range t.rows {
+		if match(row) {
+			copied := *row
+			result = append(result, &copied)
+		}
+	}
+	return result
+}
+
+// update 对所有满足条件的行执行修改，返回影响的行数
+func (t *table[T]) update(match func(*T) bool, apply func(*T)) int {
+	affected := 0
+	for _, row := range t.rows {
+		if match(row) {
+			t.touch(row)
+			apply(row)
+			affected++
+		}
+	}
+	return affected
+}
+
+// touch 在原地修改一行之前调用，事务回滚时恢复该行修改前的内容
+func (t *table[T]) touch(row *T) {
+	if t.undo == nil || !t.undo.recording {
+		return
+	}
+	before := *row
+	t.undo.add(func() { *row = before })
+}
+
+// delete 删除所有满足条件的行，返回影响的行数
+func (t *table[T]) delete(match func(*T) bool) int {
+	kept := t.rows[:0]
+	var removed []*T
+	var positions []int
+	for i, row := range t.rows {
+		if !match(row) {
+			kept = append(kept, row)
+			continue
+		}
+		removed = append(removed, row)
+		positions = append(positions, i)
+	}
+	affected := len(t.rows) - len(kept)
+	// 清理尾部引用，便于回收
+	for i := len(kept); i < len(t.rows); i++ {
+		t.rows[i] = nil
+	}
+	t.rows = kept
+	if affected > 0 {
+		t.undo.add(func() {
+			for i, row := range removed {
+				t.restore(positions[i], row)
+			}
+		})
+	}
+	return affected
+}
+
+// remove 按行的指针移除一行，用于撤销插入
+func (t *table[T]) remove(target *T) {
+	for i, row := range t.rows {
+		if row == target {
+			t.rows = append(t.rows[:i], t.rows[i+1:]...)
+			return
+		}
+	}
+}
+
+// restore 将删除的行放回原来的位置，用于撤销删除；其间表中行数变化时放到不超出末尾的位置
+func (t *table[T]) restore(position int, row *T) {
+	position = min(position, len(t.rows))
+	t.rows = append(t.rows, nil)
+	copy(t.rows[position+1:], t.rows[position:])
+	t.rows[position] = row
+}
+
+// undoLog 事务的撤销日志，只记录事务内的写操作，回滚时倒序执行
+type undoLog struct {
+	recording bool
+	entries   []func()
+}
+
+func (l *undoLog) add(entry func()) {
+	if l == nil || !l.recording {
+		return
+	}
+	l.entries = append(l.entries, entry)
+}
+
+// rollback 倒序撤销事务内的写操作，事务外的写操作不受影响
+func (l *undoLog) rollback() {
+	for i := len(l.entries) - 1; i >= 0; i-- {
+		l.entries[i]()
+	}
+}
+
+// tables 内存数据库中的全部表
+type tables struct {
+	users               table[models.User]
+	groups              table[models.Group]
+	groupMembers        table[models.GroupMember]
+	tasks               table[models.Task]
+	taskRecords         table[models.TaskRecord]
+	joinApplications    table[models.JoinApplication]
+	checkApplications   table[models.CheckApplication]
+	refreshTokens       table[models.RefreshToken]
+	tokenRevocations    table[models.TokenRevocation]
+	userTokenCutoffs    table[models.UserTokenCutoff]
+	passwordResetTokens table[models.PasswordResetToken]
+	loginAttempts       table[models.LoginAttempt]
+	totpSecrets         table[models.TOTPSecret]
+	recoveryCodes       table[models.RecoveryCode]
+	mfaChallenges       table[models.MFAChallenge]
+	userIdentities      table[models.UserIdentity]
+	oidcLoginStates     table[models.OIDCLoginState]
+	accessTokens        table[models.PersonalAccessToken]
+	userSessions        table[models.UserSession]
+	adminActions        table[models.AdminAction]
+	groupInvites        table[models.GroupInvite]
+	groupRoleChanges    table[models.GroupRoleChange]
+	groupRoles          table[models.GroupRole]
+}
+
+// bind 让所有表共用同一份撤销日志
+func (t *tables) bind(undo *undoLog) {
+	t.users.undo = undo
+	t.groups.undo = undo
+	t.groupMembers.undo = undo
+	t.tasks.undo = undo
+	t.taskRecords.undo = undo
+	t.joinApplications.undo = undo
+	t.checkApplications.undo = undo
+	t.refreshTokens.undo = undo
+	t.tokenRevocations.undo = undo
+	t.userTokenCutoffs.undo = undo
+	t.passwordResetTokens.undo = undo
+	t.loginAttempts.undo = undo
+	t.totpSecrets.undo = undo
+	t.recoveryCodes.undo = undo
+	t.mfaChallenges.undo = undo
+	t.userIdentities.undo = undo
+	t.oidcLoginStates.undo = undo
+	t.accessTokens.undo = undo
+	t.userSessions.undo = undo
+	t.adminActions.undo = undo
+	t.groupInvites.undo = undo
+	t.groupRoleChanges.undo = undo
+	t.groupRoles.undo = undo
+}
+
+// Store 内存数据库，供本地开发与测试使用，进程退出后数据丢失
+type Store struct {
+	mu   sync.RWMutex
+	txMu sync.Mutex
+	data tables
+	// tx 进行中事务的标识，DAO 传入该标识时写操作记入撤销日志
+	tx   *gorm.DB
+	undo undoLog
+}
+
+func NewStore() *Store {
+	s := &Store{}
+	s.data.bind(&s.undo)
+	return s
+}
+
+// read 在读锁下访问数据
+func (s *Store) read(ctx context.Context, fn func(data *tables) error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return fn(&s.data)
+}
+
+// write 在写锁下修改数据，tx 为进行中事务的标识时记入撤销日志；
+// 不带 tx 的写操作与数据库的自动提交一致，不会因其他请求的事务回滚而丢失
+func (s *Store) write(ctx context.Context, tx []*gorm.DB, fn func(data *tables) error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.undo.recording = len(tx) > 0 && tx[0] != nil && tx[0] == s.tx
+	defer func() { s.undo.recording = false }()
+	return fn(&s.data)
+}
+
+// TransactionManager 内存事务管理器
+// 事务之间串行执行，回调收到的tx仅作为事务标识，内存DAO据此把事务内的写操作记入撤销日志，
+// 回调返回错误或panic时倒序撤销这些写操作；不支持嵌套事务
+type TransactionManager struct {
+	store *Store
+}
+
+func NewTransactionManager(store *Store) *TransactionManager {
+	return &TransactionManager{store: store}
+}
+
+// WithTransaction 提供与 gorm.DB.Transaction 一致的提交/回滚语义
+func (m *TransactionManager) WithTransaction(ctx context.Context, fn func(tx *gorm.DB) error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	m.store.txMu.Lock()
+	defer m.store.txMu.Unlock()
+
+	tx := &gorm.DB{}
+	m.store.mu.Lock()
+	m.store.tx = tx
+	m.store.mu.Unlock()
+
+	committed := false
+	defer func() {
+		m.store.mu.Lock()
+		defer m.store.mu.Unlock()
+		if !committed {
+			m.store.undo.rollback()
+		}
+		m.store.undo.entries = nil
+		m.store.tx = nil
+	}()
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+	committed = true
+	return nil
+}
@@ -0,0 +1,80 @@
+package memory
+
+import (
+	"TeamTickBackend/dal/models"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestWithTransaction_RollbackKeepsConcurrentWrites(t *testing.T) {
+	store := NewStore()
+	manager := NewTransactionManager(store)
+	users := &UserDAOMemoryImpl{Store: store}
+	tokens := &PersonalAccessTokenDAOMemoryImpl{Store: store}
+	ctx := context.Background()
+	require.NoError(t, users.Create(ctx, &models.User{Username: "alice", Password: "x"}))
+
+	started := make(chan struct{})
+	outsideDone := make(chan error)
+	go func() {
+		<-started
+		// 事务进行中其他请求不在事务内写入的数据
+		outsideDone <- tokens.Create(ctx, &models.PersonalAccessToken{UserID: 1, Name: "ci", TokenHash: "hash", ExpiresAt: time.Now().Add(time.Hour)})
+	}()
+
+	errRollback := errors.New("rollback")
+	err := manager.WithTransaction(ctx, func(tx *gorm.DB) error {
+		require.NoError(t, users.Create(ctx, &models.User{Username: "bob", Password: "x"}, tx))
+		require.NoError(t, users.UpdatePassword(ctx, 1, "changed", tx))
+		close(started)
+		require.NoError(t, <-outsideDone)
+		return errRollback
+	})
+	assert.ErrorIs(t, err, errRollback)
+
+	// 事务内的写操作全部撤销
+	_, err = users.GetByUsername(ctx, "bob")
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	alice, err := users.GetByUsername(ctx, "alice")
+	require.NoError(t, err)
+	assert.Equal(t, "x", alice.Password)
+	// 事务外的写操作保留
+	saved, err := tokens.ListByUserID(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, saved, 1)
+	assert.Equal(t, "ci", saved[0].Name)
+}
+
+func TestWithTransaction_RollbackRestoresDeletedRows(t *testing.T) {
+	store := NewStore()
+	manager := NewTransactionManager(store)
+	members := &GroupMemberDAOMemoryImpl{Store: store}
+	ctx := context.Background()
+	for userID := 1; userID <= 3; userID++ {
+		require.NoError(t, members.Create(ctx, &models.GroupMember{GroupID: 1, UserID: userID, Role: "member"}))
+	}
+
+	err := manager.WithTransaction(ctx, func(tx *gorm.DB) error {
+		require.NoError(t, members.Delete(ctx, 1, 2, tx))
+		require.NoError(t, members.UpdateRole(ctx, 1, 3, "admin", tx))
+		// 不带 tx 的写操作不属于事务
+		require.NoError(t, members.Create(ctx, &models.GroupMember{GroupID: 1, UserID: 4, Role: "member"}))
+		return errors.New("rollback")
+	})
+	require.Error(t, err)
+
+	list, err := members.GetMembersByGroupID(ctx, 1)
+	require.NoError(t, err)
+	userIDs := make([]int, 0, len(list))
+	for _, member := range list {
+		userIDs = append(userIDs, member.UserID)
+		assert.Equal(t, "member", member.Role)
+	}
+	assert.Equal(t, []int{1, 2, 3, 4}, userIDs)
+}
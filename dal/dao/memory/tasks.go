@@ -0,0 +1,152 @@
+package memory
+
+import (
+	"TeamTickBackend/dal/models"
+	"context"
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type TaskDAOMemoryImpl struct {
+	Store *Store
+}
+
+// Create 创建签到任务
+func (dao *TaskDAOMemoryImpl) Create(ctx context.Context, task *models.Task, tx ...*gorm.DB) error {
+	return dao.Store.write(ctx, tx, func(data *tables) error {
+		now := time.Now()
+		task.TaskID = data.tasks.newID()
+		task.StartTime = orNow(task.StartTime, now)
+		task.CreatedAt = orNow(task.CreatedAt, now)
+		task.UpdatedAt = orNow(task.UpdatedAt, now)
+		if task.Radius == 0 {
+			task.Radius = 50
+		}
+		data.tasks.insert(task)
+		return nil
+	})
+}
+
+// GetByGroupID 按group_id查询所有签到任务（包含进行中以及已结束）
+func (dao *TaskDAOMemoryImpl) GetByGroupID(ctx context.Context, groupID int, tx ...*gorm.DB) ([]*models.Task, error) {
+	return dao.findTasks(ctx, func(t *models.Task) bool { return t.GroupID == groupID })
+}
+
+// GetActiveTasksByGroupID 按group_id查询当前进行中的签到任务
+func (dao *TaskDAOMemoryImpl) GetActiveTasksByGroupID(ctx context.Context, groupID int, tx ...*gorm.DB) ([]*models.Task, error) {
+	now := time.Now()
+	return dao.findTasks(ctx, func(t *models.Task) bool { return t.GroupID == groupID && isActive(t, now) })
+}
+
+// GetEndedTasksByGroupID 按group_id查询当前已结束的签到任务
+func (dao *TaskDAOMemoryImpl) GetEndedTasksByGroupID(ctx context.Context, groupID int, tx ...*gorm.DB) ([]*models.Task, error) {
+	now := time.Now()
+	return dao.findTasks(ctx, func(t *models.Task) bool { return t.GroupID == groupID && t.EndTime.Before(now) })
+}
+
+// GetByUserID 获取用户当前所属的所有用户组的签到任务
+func (dao *TaskDAOMemoryImpl) GetByUserID(ctx context.Context, userID int, tx ...*gorm.DB) ([]*models.Task, error) {
+	return dao.findUserTasks(ctx, userID, func(t *models.Task) bool { return true }, false)
+}
+
+// GetEndedTasksByUserID 获取用户当前所属的所有用户组的已结束任务
+func (dao *TaskDAOMemoryImpl) GetEndedTasksByUserID(ctx context.Context, userID int, tx ...*gorm.DB) ([]*models.Task, error) {
+	now := time.Now()
+	return dao.findUserTasks(ctx, userID, func(t *models.Task) bool { return t.EndTime.Before(now) }, true)
+}
+
+// GetActiveTasksByUserID 获取用户当前所属的所有用户组的待签到任务
+func (dao *TaskDAOMemoryImpl) GetActiveTasksByUserID(ctx context.Context, userID int, tx ...*gorm.DB) ([]*models.Task, error) {
+	now := time.Now()
+	return dao.findUserTasks(ctx, userID, func(t *models.Task) bool { return isActive(t, now) }, true)
+}
+
+// GetByTaskID 按task_id查询签到任务
+func (dao *TaskDAOMemoryImpl) GetByTaskID(ctx context.Context, taskID int, tx ...*gorm.DB) (*models.Task, error) {
+	var task *models.Task
+	err := dao.Store.read(ctx, func(data *tables) (err error) {
+		task, err = data.tasks.first(func(t *models.Task) bool { return t.TaskID == taskID })
+		return err
+	})
+	return task, err
+}
+
+// UpdateTask 更新签到任务，wifi与nfc字段为空时保持原值
+func (dao *TaskDAOMemoryImpl) UpdateTask(ctx context.Context, taskID int, newTask *models.Task, tx ...*gorm.DB) error {
+	return dao.Store.write(ctx, tx, func(data *tables) error {
+		data.tasks.update(func(t *models.Task) bool { return t.TaskID == taskID }, func(t *models.Task) {
+			t.TaskName = newTask.TaskName
+			t.Description = newTask.Description
+			t.StartTime = newTask.StartTime
+			t.EndTime = newTask.EndTime
+			t.Latitude = newTask.Latitude
+			t.Longitude = newTask.Longitude
+			t.Radius = newTask.Radius
+			t.GPS = newTask.GPS
+			t.Face = newTask.Face
+			t.WiFi = newTask.WiFi
+			t.NFC = newTask.NFC
+			if newTask.SSID != "" {
+				t.SSID = newTask.SSID
+			}
+			if newTask.BSSID != "" {
+				t.BSSID = newTask.BSSID
+			}
+			if newTask.TagID != "" {
+				t.TagID = newTask.TagID
+			}
+			if newTask.TagName != "" {
+				t.TagName = newTask.TagName
+			}
+			t.UpdatedAt = time.Now()
+		})
+		return nil
+	})
+}
+
+// Delete 删除签到任务
+func (dao *TaskDAOMemoryImpl) Delete(ctx context.Context, taskID int, tx ...*gorm.DB) error {
+	return dao.Store.write(ctx, tx, func(data *tables) error {
+		data.tasks.delete(func(t *models.Task) bool { return t.TaskID == taskID })
+		return nil
+	})
+}
+
+func (dao *TaskDAOMemoryImpl) findTasks(ctx context.Context, match func(*models.Task) bool) ([]*models.Task, error) {
+	var tasks []*models.Task
+	err := dao.Store.read(ctx, func(data *tables) error {
+		tasks = data.tasks.find(match)
+		return nil
+	})
+	return tasks, err
+}
+
+// findUserTasks 对应用户所属用户组及其全部上级用户组的任务查询，orderByEndTime 对应 ORDER BY end_time ASC
+func (dao *TaskDAOMemoryImpl) findUserTasks(ctx context.Context, userID int, match func(*models.Task) bool, orderByEndTime bool) ([]*models.Task, error) {
+	tasks := make([]*models.Task, 0)
+	err := dao.Store.read(ctx, func(data *tables) error {
+		tree := make(map[int]bool)
+		for _, member := range data.groupMembers.rows {
+			if member.UserID != userID {
+				continue
+			}
+			tree[member.GroupID] = true
+			for _, id := range ancestorIDs(data, member.GroupID) {
+				tree[id] = true
+			}
+		}
+		tasks = data.tasks.find(func(t *models.Task) bool { return tree[t.GroupID] && match(t) })
+		return nil
+	})
+	if orderByEndTime {
+		sort.SliceStable(tasks, func(i, j int) bool { return tasks[i].EndTime.Before(tasks[j].EndTime) })
+	}
+	return tasks, err
+}
+
+// isActive 对应 ? BETWEEN start_time AND end_time
+func isActive(task *models.Task, now time.Time) bool {
+	return !now.Before(task.StartTime) && !now.After(task.EndTime)
+}
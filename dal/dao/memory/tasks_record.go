@@ -0,0 +1,66 @@
+package memory
+
+import (
+	"TeamTickBackend/dal/models"
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type TaskRecordDAOMemoryImpl struct {
+	Store *Store
+}
+
+// Create 创建签到记录，同一任务同一用户只能有一条记录（idx_task_user_id）
+func (dao *TaskRecordDAOMemoryImpl) Create(ctx context.Context, record *models.TaskRecord, tx ...*gorm.DB) error {
+	return dao.Store.write(ctx, tx, func(data *tables) error {
+		if data.taskRecords.exists(func(r *models.TaskRecord) bool {
+			return r.TaskID == record.TaskID && r.UserID == record.UserID
+		}) {
+			return gorm.ErrDuplicatedKey
+		}
+		now := time.Now()
+		record.RecordID = data.taskRecords.newID()
+		record.SignedTime = orNow(record.SignedTime, now)
+		record.CreatedAt = orNow(record.CreatedAt, now)
+		record.UpdatedAt = orNow(record.UpdatedAt, now)
+		if record.Status == 0 {
+			record.Status = 1
+		}
+		data.taskRecords.insert(record)
+		return nil
+	})
+}
+
+// GetByTaskID 通过task_id查询组内成员签到记录
+func (dao *TaskRecordDAOMemoryImpl) GetByTaskID(ctx context.Context, taskID int, tx ...*gorm.DB) ([]*models.TaskRecord, error) {
+	var records []*models.TaskRecord
+	err := dao.Store.read(ctx, func(data *tables) error {
+		records = data.taskRecords.find(func(r *models.TaskRecord) bool { return r.TaskID == taskID })
+		return nil
+	})
+	return records, err
+}
+
+// GetByUserID 通过user_id查询个人所有签到记录
+func (dao *TaskRecordDAOMemoryImpl) GetByUserID(ctx context.Context, userID int, tx ...*gorm.DB) ([]*models.TaskRecord, error) {
+	var records []*models.TaskRecord
+	err := dao.Store.read(ctx, func(data *tables) error {
+		records = data.taskRecords.find(func(r *models.TaskRecord) bool { return r.UserID == userID })
+		return nil
+	})
+	return records, err
+}
+
+// GetByTaskIDAndUserID 通过task_id和user_id查询指定签到记录
+func (dao *TaskRecordDAOMemoryImpl) GetByTaskIDAndUserID(ctx context.Context, taskID, userID int, tx ...*gorm.DB) (*models.TaskRecord, error) {
+	var record *models.TaskRecord
+	err := dao.Store.read(ctx, func(data *tables) (err error) {
+		record, err = data.taskRecords.first(func(r *models.TaskRecord) bool {
+			return r.TaskID == taskID && r.UserID == userID
+		})
+		return err
+	})
+	return record, err
+}
@@ -0,0 +1,90 @@
+package memory
+
+import (
+	"TeamTickBackend/dal/models"
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type TokenRevocationDAOMemoryImpl struct {
+	Store *Store
+}
+
+// Create 写入吊销记录，(kind, value) 已存在时忽略
+func (dao *TokenRevocationDAOMemoryImpl) Create(ctx context.Context, revocation *models.TokenRevocation, tx ...*gorm.DB) error {
+	return dao.Store.write(ctx, tx, func(data *tables) error {
+		if data.tokenRevocations.exists(func(r *models.TokenRevocation) bool {
+			return r.Kind == revocation.Kind && r.Value == revocation.Value
+		}) {
+			return nil
+		}
+		revocation.ID = data.tokenRevocations.newID()
+		data.tokenRevocations.insert(revocation)
+		return nil
+	})
+}
+
+// ListSince 查询 revoked_at 不早于since且在now时仍未过期的记录
+func (dao *TokenRevocationDAOMemoryImpl) ListSince(ctx context.Context, since, now time.Time, tx ...*gorm.DB) ([]*models.TokenRevocation, error) {
+	var revocations []*models.TokenRevocation
+	err := dao.Store.read(ctx, func(data *tables) error {
+		revocations = data.tokenRevocations.find(func(r *models.TokenRevocation) bool {
+			return !r.RevokedAt.Before(since) && r.ExpiresAt.After(now)
+		})
+		return nil
+	})
+	return revocations, err
+}
+
+// DeleteExpired 清理已过期的吊销记录
+func (dao *TokenRevocationDAOMemoryImpl) DeleteExpired(ctx context.Context, now time.Time, tx ...*gorm.DB) (int64, error) {
+	var affected int
+	err := dao.Store.write(ctx, tx, func(data *tables) error {
+		affected = data.tokenRevocations.delete(func(r *models.TokenRevocation) bool {
+			return !r.ExpiresAt.After(now)
+		})
+		return nil
+	})
+	return int64(affected), err
+}
+
+// SaveUserCutoff 写入或更新用户级吊销时间
+func (dao *TokenRevocationDAOMemoryImpl) SaveUserCutoff(ctx context.Context, cutoff *models.UserTokenCutoff, tx ...*gorm.DB) error {
+	return dao.Store.write(ctx, tx, func(data *tables) error {
+		updated := data.userTokenCutoffs.update(func(c *models.UserTokenCutoff) bool {
+			return c.UserID == cutoff.UserID
+		}, func(c *models.UserTokenCutoff) {
+			c.RevokedBefore = cutoff.RevokedBefore
+		})
+		if updated == 0 {
+			data.userTokenCutoffs.insert(cutoff)
+		}
+		return nil
+	})
+}
+
+// ListUserCutoffsSince 查询吊销时间不早于since的用户级吊销
+func (dao *TokenRevocationDAOMemoryImpl) ListUserCutoffsSince(ctx context.Context, since int64, tx ...*gorm.DB) ([]*models.UserTokenCutoff, error) {
+	var cutoffs []*models.UserTokenCutoff
+	err := dao.Store.read(ctx, func(data *tables) error {
+		cutoffs = data.userTokenCutoffs.find(func(c *models.UserTokenCutoff) bool {
+			return c.RevokedBefore >= since
+		})
+		return nil
+	})
+	return cutoffs, err
+}
+
+// DeleteUserCutoffsBefore 清理早于before的用户级吊销
+func (dao *TokenRevocationDAOMemoryImpl) DeleteUserCutoffsBefore(ctx context.Context, before int64, tx ...*gorm.DB) (int64, error) {
+	var affected int
+	err := dao.Store.write(ctx, tx, func(data *tables) error {
+		affected = data.userTokenCutoffs.delete(func(c *models.UserTokenCutoff) bool {
+			return c.RevokedBefore < before
+		})
+		return nil
+	})
+	return int64(affected), err
+}
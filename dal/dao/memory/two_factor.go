@@ -0,0 +1,177 @@
+package memory
+
+import (
+	"TeamTickBackend/dal/models"
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type TOTPSecretDAOMemoryImpl struct {
+	Store *Store
+}
+
+// Get 查询用户的TOTP密钥
+func (dao *TOTPSecretDAOMemoryImpl) Get(ctx context.Context, userID int, tx ...*gorm.DB) (*models.TOTPSecret, error) {
+	var secret *models.TOTPSecret
+	err := dao.Store.read(ctx, func(data *tables) (err error) {
+		secret, err = data.totpSecrets.first(func(s *models.TOTPSecret) bool { return s.UserID == userID })
+		return err
+	})
+	return secret, err
+}
+
+// Save 写入或替换用户的密钥
+func (dao *TOTPSecretDAOMemoryImpl) Save(ctx context.Context, secret *models.TOTPSecret, tx ...*gorm.DB) error {
+	return dao.Store.write(ctx, tx, func(data *tables) error {
+		data.totpSecrets.delete(func(s *models.TOTPSecret) bool { return s.UserID == secret.UserID })
+		secret.CreatedAt = orNow(secret.CreatedAt, time.Now())
+		data.totpSecrets.insert(secret)
+		return nil
+	})
+}
+
+// Confirm 标记密钥已确认绑定
+func (dao *TOTPSecretDAOMemoryImpl) Confirm(ctx context.Context, userID int, confirmedAt time.Time, tx ...*gorm.DB) error {
+	return dao.Store.write(ctx, tx, func(data *tables) error {
+		data.totpSecrets.update(func(s *models.TOTPSecret) bool { return s.UserID == userID }, func(s *models.TOTPSecret) {
+			s.ConfirmedAt = &confirmedAt
+		})
+		return nil
+	})
+}
+
+// AdvanceStep 仅当step大于最近使用的时间步时更新
+func (dao *TOTPSecretDAOMemoryImpl) AdvanceStep(ctx context.Context, userID int, step int64, tx ...*gorm.DB) (bool, error) {
+	var affected int
+	err := dao.Store.write(ctx, tx, func(data *tables) error {
+		affected = data.totpSecrets.update(func(s *models.TOTPSecret) bool {
+			return s.UserID == userID && s.LastUsedStep < step
+		}, func(s *models.TOTPSecret) {
+			s.LastUsedStep = step
+		})
+		return nil
+	})
+	return affected == 1, err
+}
+
+// Delete 删除用户的TOTP密钥
+func (dao *TOTPSecretDAOMemoryImpl) Delete(ctx context.Context, userID int, tx ...*gorm.DB) error {
+	return dao.Store.write(ctx, tx, func(data *tables) error {
+		data.totpSecrets.delete(func(s *models.TOTPSecret) bool { return s.UserID == userID })
+		return nil
+	})
+}
+
+type RecoveryCodeDAOMemoryImpl struct {
+	Store *Store
+}
+
+// CreateBatch 批量创建恢复码
+func (dao *RecoveryCodeDAOMemoryImpl) CreateBatch(ctx context.Context, codes []*models.RecoveryCode, tx ...*gorm.DB) error {
+	return dao.Store.write(ctx, tx, func(data *tables) error {
+		now := time.Now()
+		for _, code := range codes {
+			code.ID = data.recoveryCodes.newID()
+			code.CreatedAt = orNow(code.CreatedAt, now)
+			data.recoveryCodes.insert(code)
+		}
+		return nil
+	})
+}
+
+// DeleteByUserID 删除用户的全部恢复码
+func (dao *RecoveryCodeDAOMemoryImpl) DeleteByUserID(ctx context.Context, userID int, tx ...*gorm.DB) error {
+	return dao.Store.write(ctx, tx, func(data *tables) error {
+		data.recoveryCodes.delete(func(c *models.RecoveryCode) bool { return c.UserID == userID })
+		return nil
+	})
+}
+
+// MarkUsed 仅当恢复码属于该用户且未使用时标记为已使用
+func (dao *RecoveryCodeDAOMemoryImpl) MarkUsed(ctx context.Context, userID int, codeHash string, usedAt time.Time, tx ...*gorm.DB) (bool, error) {
+	var affected int
+	err := dao.Store.write(ctx, tx, func(data *tables) error {
+		affected = data.recoveryCodes.update(func(c *models.RecoveryCode) bool {
+			return c.UserID == userID && c.CodeHash == codeHash && c.UsedAt == nil
+		}, func(c *models.RecoveryCode) {
+			c.UsedAt = &usedAt
+		})
+		return nil
+	})
+	return affected > 0, err
+}
+
+// CountUnused 统计用户未使用的恢复码数量
+func (dao *RecoveryCodeDAOMemoryImpl) CountUnused(ctx context.Context, userID int, tx ...*gorm.DB) (int64, error) {
+	var count int
+	err := dao.Store.read(ctx, func(data *tables) error {
+		count = len(data.recoveryCodes.find(func(c *models.RecoveryCode) bool {
+			return c.UserID == userID && c.UsedAt == nil
+		}))
+		return nil
+	})
+	return int64(count), err
+}
+
+type MFAChallengeDAOMemoryImpl struct {
+	Store *Store
+}
+
+// Create 创建两步登录挑战，令牌摘要唯一（idx_mfachallenge_tokenhash）
+func (dao *MFAChallengeDAOMemoryImpl) Create(ctx context.Context, challenge *models.MFAChallenge, tx ...*gorm.DB) error {
+	return dao.Store.write(ctx, tx, func(data *tables) error {
+		if data.mfaChallenges.exists(func(c *models.MFAChallenge) bool { return c.TokenHash == challenge.TokenHash }) {
+			return gorm.ErrDuplicatedKey
+		}
+		challenge.ID = data.mfaChallenges.newID()
+		challenge.CreatedAt = orNow(challenge.CreatedAt, time.Now())
+		data.mfaChallenges.insert(challenge)
+		return nil
+	})
+}
+
+// GetByTokenHash 通过令牌摘要查询挑战
+func (dao *MFAChallengeDAOMemoryImpl) GetByTokenHash(ctx context.Context, tokenHash string, tx ...*gorm.DB) (*models.MFAChallenge, error) {
+	var challenge *models.MFAChallenge
+	err := dao.Store.read(ctx, func(data *tables) (err error) {
+		challenge, err = data.mfaChallenges.first(func(c *models.MFAChallenge) bool { return c.TokenHash == tokenHash })
+		return err
+	})
+	return challenge, err
+}
+
+// IncrementAttempts 累加验证码错误次数
+func (dao *MFAChallengeDAOMemoryImpl) IncrementAttempts(ctx context.Context, id int, tx ...*gorm.DB) error {
+	return dao.Store.write(ctx, tx, func(data *tables) error {
+		data.mfaChallenges.update(func(c *models.MFAChallenge) bool { return c.ID == id }, func(c *models.MFAChallenge) {
+			c.Attempts++
+		})
+		return nil
+	})
+}
+
+// MarkUsed 仅当挑战尚未完成时标记为已完成
+func (dao *MFAChallengeDAOMemoryImpl) MarkUsed(ctx context.Context, id int, usedAt time.Time, tx ...*gorm.DB) (bool, error) {
+	var affected int
+	err := dao.Store.write(ctx, tx, func(data *tables) error {
+		affected = data.mfaChallenges.update(func(c *models.MFAChallenge) bool {
+			return c.ID == id && c.UsedAt == nil
+		}, func(c *models.MFAChallenge) {
+			c.UsedAt = &usedAt
+		})
+		return nil
+	})
+	return affected == 1, err
+}
+
+// DeleteExpired 清理已过期的挑战
+func (dao *MFAChallengeDAOMemoryImpl) DeleteExpired(ctx context.Context, now time.Time, tx ...*gorm.DB) (int64, error) {
+	var affected int
+	err := dao.Store.write(ctx, tx, func(data *tables) error {
+		affected = data.mfaChallenges.delete(func(c *models.MFAChallenge) bool { return !c.ExpiresAt.After(now) })
+		return nil
+	})
+	return int64(affected), err
+}
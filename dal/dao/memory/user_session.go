@@ -0,0 +1,114 @@
+package memory
+
+import (
+	"TeamTickBackend/dal/models"
+	"context"
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type UserSessionDAOMemoryImpl struct {
+	Store *Store
+}
+
+// Create 创建登录会话，会话ID唯一（idx_session_sessionid）
+func (dao *UserSessionDAOMemoryImpl) Create(ctx context.Context, session *models.UserSession, tx ...*gorm.DB) error {
+	return dao.Store.write(ctx, tx, func(data *tables) error {
+		if data.userSessions.exists(func(s *models.UserSession) bool { return s.SessionID == session.SessionID }) {
+			return gorm.ErrDuplicatedKey
+		}
+		now := time.Now()
+		session.ID = data.userSessions.newID()
+		session.CreatedAt = orNow(session.CreatedAt, now)
+		session.LastSeenAt = orNow(session.LastSeenAt, now)
+		data.userSessions.insert(session)
+		return nil
+	})
+}
+
+// GetByID 通过主键查询会话
+func (dao *UserSessionDAOMemoryImpl) GetByID(ctx context.Context, id int, tx ...*gorm.DB) (*models.UserSession, error) {
+	var session *models.UserSession
+	err := dao.Store.read(ctx, func(data *tables) (err error) {
+		session, err = data.userSessions.first(func(s *models.UserSession) bool { return s.ID == id })
+		return err
+	})
+	return session, err
+}
+
+// GetBySessionID 通过会话ID（sid）查询会话
+func (dao *UserSessionDAOMemoryImpl) GetBySessionID(ctx context.Context, sessionID string, tx ...*gorm.DB) (*models.UserSession, error) {
+	var session *models.UserSession
+	err := dao.Store.read(ctx, func(data *tables) (err error) {
+		session, err = data.userSessions.first(func(s *models.UserSession) bool { return s.SessionID == sessionID })
+		return err
+	})
+	return session, err
+}
+
+// ListActiveByUserID 查询用户未吊销且未过期的会话，按最近活跃时间倒序
+func (dao *UserSessionDAOMemoryImpl) ListActiveByUserID(ctx context.Context, userID int, now time.Time, tx ...*gorm.DB) ([]*models.UserSession, error) {
+	var sessions []*models.UserSession
+	err := dao.Store.read(ctx, func(data *tables) error {
+		sessions = data.userSessions.find(func(s *models.UserSession) bool {
+			return s.UserID == userID && s.RevokedAt == nil && s.ExpiresAt.After(now)
+		})
+		return nil
+	})
+	sort.Slice(sessions, func(i, j int) bool {
+		if !sessions[i].LastSeenAt.Equal(sessions[j].LastSeenAt) {
+			return sessions[i].LastSeenAt.After(sessions[j].LastSeenAt)
+		}
+		return sessions[i].ID > sessions[j].ID
+	})
+	return sessions, err
+}
+
+// UpdateLastSeen 记录最近活跃时间与IP
+func (dao *UserSessionDAOMemoryImpl) UpdateLastSeen(ctx context.Context, sessionID string, seenAt time.Time, ip string, tx ...*gorm.DB) error {
+	return dao.Store.write(ctx, tx, func(data *tables) error {
+		data.userSessions.update(func(s *models.UserSession) bool { return s.SessionID == sessionID }, func(s *models.UserSession) {
+			s.LastSeenAt = seenAt
+			s.LastSeenIP = ip
+		})
+		return nil
+	})
+}
+
+// UpdateExpiresAt 刷新令牌轮换后顺延会话的过期时间
+func (dao *UserSessionDAOMemoryImpl) UpdateExpiresAt(ctx context.Context, sessionID string, expiresAt time.Time, tx ...*gorm.DB) error {
+	return dao.Store.write(ctx, tx, func(data *tables) error {
+		data.userSessions.update(func(s *models.UserSession) bool { return s.SessionID == sessionID }, func(s *models.UserSession) {
+			s.ExpiresAt = expiresAt
+		})
+		return nil
+	})
+}
+
+// RevokeBySessionID 吊销尚未吊销的会话
+func (dao *UserSessionDAOMemoryImpl) RevokeBySessionID(ctx context.Context, sessionID string, revokedAt time.Time, tx ...*gorm.DB) (bool, error) {
+	var affected int
+	err := dao.Store.write(ctx, tx, func(data *tables) error {
+		affected = data.userSessions.update(func(s *models.UserSession) bool {
+			return s.SessionID == sessionID && s.RevokedAt == nil
+		}, func(s *models.UserSession) {
+			s.RevokedAt = &revokedAt
+		})
+		return nil
+	})
+	return affected == 1, err
+}
+
+// RevokeByUserID 吊销用户所有未吊销的会话
+func (dao *UserSessionDAOMemoryImpl) RevokeByUserID(ctx context.Context, userID int, revokedAt time.Time, tx ...*gorm.DB) error {
+	return dao.Store.write(ctx, tx, func(data *tables) error {
+		data.userSessions.update(func(s *models.UserSession) bool {
+			return s.UserID == userID && s.RevokedAt == nil
+		}, func(s *models.UserSession) {
+			s.RevokedAt = &revokedAt
+		})
+		return nil
+	})
+}
@@ -0,0 +1,197 @@
+package memory
+
+import (
+	"TeamTickBackend/dal/models"
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type UserDAOMemoryImpl struct {
+	Store *Store
+}
+
+// Create 创建用户，用户名唯一（idx_username）
+func (dao *UserDAOMemoryImpl) Create(ctx context.Context, user *models.User, tx ...*gorm.DB) error {
+	return dao.Store.write(ctx, tx, func(data *tables) error {
+		if data.users.exists(func(u *models.User) bool { return u.Username == user.Username }) {
+			return gorm.ErrDuplicatedKey
+		}
+		now := time.Now()
+		user.UserID = data.users.newID()
+		user.CreatedAt = orNow(user.CreatedAt, now)
+		user.UpdatedAt = orNow(user.UpdatedAt, now)
+		// 与gorm一致：带默认值的字段为零值时不写入，使用数据库默认值
+		user.ShareRealName = true
+		user.ShareStudentNumber = true
+		if user.PlatformRole == "" {
+			user.PlatformRole = models.PlatformRoleUser
+		}
+		data.users.insert(user)
+		return nil
+	})
+}
+
+// GetByUsername 通过username查询用户信息
+func (dao *UserDAOMemoryImpl) GetByUsername(ctx context.Context, username string, tx ...*gorm.DB) (*models.User, error) {
+	var user *models.User
+	err := dao.Store.read(ctx, func(data *tables) (err error) {
+		user, err = data.users.first(func(u *models.User) bool { return u.Username == username })
+		return err
+	})
+	return user, err
+}
+
+// GetByID 通过id查询用户信息
+func (dao *UserDAOMemoryImpl) GetByID(ctx context.Context, id int, tx ...*gorm.DB) (*models.User, error) {
+	var user *models.User
+	err := dao.Store.read(ctx, func(data *tables) (err error) {
+		user, err = data.users.first(func(u *models.User) bool { return u.UserID == id })
+		return err
+	})
+	return user, err
+}
+
+// UpdatePassword 更新用户密码（已加密）
+func (dao *UserDAOMemoryImpl) UpdatePassword(ctx context.Context, userID int, password string, tx ...*gorm.DB) error {
+	return dao.Store.write(ctx, tx, func(data *tables) error {
+		data.users.update(func(u *models.User) bool { return u.UserID == userID }, func(u *models.User) {
+			u.Password = password
+			u.UpdatedAt = time.Now()
+		})
+		return nil
+	})
+}
+
+// UpdateTwoFactorEnabled 更新用户是否开启两步验证
+func (dao *UserDAOMemoryImpl) UpdateTwoFactorEnabled(ctx context.Context, userID int, enabled bool, tx ...*gorm.DB) error {
+	return dao.Store.write(ctx, tx, func(data *tables) error {
+		data.users.update(func(u *models.User) bool { return u.UserID == userID }, func(u *models.User) {
+			u.TwoFactorEnabled = enabled
+			u.UpdatedAt = time.Now()
+		})
+		return nil
+	})
+}
+
+// UpdateProfile 更新个人资料与隐私设置
+func (dao *UserDAOMemoryImpl) UpdateProfile(ctx context.Context, userID int, profile *models.User, tx ...*gorm.DB) error {
+	return dao.Store.write(ctx, tx, func(data *tables) error {
+		data.users.update(func(u *models.User) bool { return u.UserID == userID }, func(u *models.User) {
+			u.DisplayName = profile.DisplayName
+			u.RealName = profile.RealName
+			u.StudentNumber = profile.StudentNumber
+			u.Email = profile.Email
+			u.Phone = profile.Phone
+			u.AvatarURL = profile.AvatarURL
+			u.ShareRealName = profile.ShareRealName
+			u.ShareStudentNumber = profile.ShareStudentNumber
+			u.ShareEmail = profile.ShareEmail
+			u.SharePhone = profile.SharePhone
+			u.UpdatedAt = time.Now()
+		})
+		return nil
+	})
+}
+
+// GetByIDs 批量查询用户
+func (dao *UserDAOMemoryImpl) GetByIDs(ctx context.Context, ids []int, tx ...*gorm.DB) ([]*models.User, error) {
+	var users []*models.User
+	err := dao.Store.read(ctx, func(data *tables) error {
+		users = data.users.find(func(u *models.User) bool { return slices.Contains(ids, u.UserID) })
+		return nil
+	})
+	return users, err
+}
+
+// orNow 零值时间使用当前时间，对应数据库的 DEFAULT CURRENT_TIMESTAMP
+func orNow(t, now time.Time) time.Time {
+	if t.IsZero() {
+		return now
+	}
+	return t
+}
+
+// UpdateUsername 修改用户名，与其他用户重名时返回 gorm.ErrDuplicatedKey
+func (dao *UserDAOMemoryImpl) UpdateUsername(ctx context.Context, userID int, username string, tx ...*gorm.DB) error {
+	return dao.Store.write(ctx, tx, func(data *tables) error {
+		if data.users.exists(func(u *models.User) bool { return u.Username == username && u.UserID != userID }) {
+			return gorm.ErrDuplicatedKey
+		}
+		data.users.update(func(u *models.User) bool { return u.UserID == userID }, func(u *models.User) {
+			u.Username = username
+			u.UpdatedAt = time.Now()
+		})
+		return nil
+	})
+}
+
+// Search 按用户名、昵称、真实姓名、学号或邮箱模糊查询，与数据库默认排序规则一致不区分大小写
+func (dao *UserDAOMemoryImpl) Search(ctx context.Context, keyword string, disabled *bool, offset, limit int, tx ...*gorm.DB) ([]*models.User, int64, error) {
+	var users []*models.User
+	err := dao.Store.read(ctx, func(data *tables) error {
+		users = data.users.find(func(u *models.User) bool {
+			if disabled != nil && (u.DisabledAt != nil) != *disabled {
+				return false
+			}
+			return containsFold(keyword, u.Username, u.DisplayName, u.RealName, u.StudentNumber, u.Email)
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	return page(users, offset, limit), int64(len(users)), nil
+}
+
+// UpdateDisabled 设置停用时间与原因，disabledAt 为nil时恢复账号
+func (dao *UserDAOMemoryImpl) UpdateDisabled(ctx context.Context, userID int, disabledAt *time.Time, reason string, tx ...*gorm.DB) error {
+	return dao.Store.write(ctx, tx, func(data *tables) error {
+		data.users.update(func(u *models.User) bool { return u.UserID == userID }, func(u *models.User) {
+			u.DisabledAt = disabledAt
+			u.DisabledReason = reason
+			u.UpdatedAt = time.Now()
+		})
+		return nil
+	})
+}
+
+// UpdatePlatformRole 更新平台角色
+func (dao *UserDAOMemoryImpl) UpdatePlatformRole(ctx context.Context, userID int, role string, tx ...*gorm.DB) error {
+	return dao.Store.write(ctx, tx, func(data *tables) error {
+		data.users.update(func(u *models.User) bool { return u.UserID == userID }, func(u *models.User) {
+			u.PlatformRole = role
+			u.UpdatedAt = time.Now()
+		})
+		return nil
+	})
+}
+
+// containsFold 关键字为空，或任一字段不区分大小写地包含关键字
+func containsFold(keyword string, fields ...string) bool {
+	if keyword == "" {
+		return true
+	}
+	keyword = strings.ToLower(keyword)
+	for _, field := range fields {
+		if strings.Contains(strings.ToLower(field), keyword) {
+			return true
+		}
+	}
+	return false
+}
+
+// page 取 rows 中 [offset, offset+limit) 的部分，对应 OFFSET/LIMIT
+func page[T any](rows []*T, offset, limit int) []*T {
+	if offset >= len(rows) {
+		return []*T{}
+	}
+	rows = rows[offset:]
+	if limit < len(rows) {
+		rows = rows[:limit]
+	}
+	return rows
+}
@@ -2,6 +2,7 @@ package dao
 
 import (
 	"context"
+	"time"
 
 	"gorm.io/gorm"
 )
@@ -22,3 +23,24 @@ func NewTransactionManager(db *gorm.DB) TransactionManager {
 func (t *TransactionManagerImpl) WithTransaction(ctx context.Context, fn func(tx *gorm.DB) error) error {
 	return t.db.WithContext(ctx).Transaction(fn)
 }
+
+// TransactionObserver 事务结束后回调，err非nil表示事务已回滚
+type TransactionObserver func(ctx context.Context, duration time.Duration, err error)
+
+// observedTransactionManager 在任意 TransactionManager 外记录事务耗时
+type observedTransactionManager struct {
+	next     TransactionManager
+	observer TransactionObserver
+}
+
+// WithObserver 包装事务管理器，每次 WithTransaction 结束后调用observer
+func WithObserver(tm TransactionManager, observer TransactionObserver) TransactionManager {
+	return &observedTransactionManager{next: tm, observer: observer}
+}
+
+func (t *observedTransactionManager) WithTransaction(ctx context.Context, fn func(tx *gorm.DB) error) error {
+	start := time.Now()
+	err := t.next.WithTransaction(ctx, fn)
+	t.observer(ctx, time.Since(start), err)
+	return err
+}
@@ -1,49 +1,75 @@
 package db
 
 import (
-	"log"
+	"context"
+	"fmt"
+	"log/slog"
 
-	"TeamTickBackend/dal/models"
+	"TeamTickBackend/config"
+	"TeamTickBackend/dal/migrations"
+	"TeamTickBackend/pkg/logger"
 
-	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
-	"gorm.io/gorm/logger"
+	gormlogger "gorm.io/gorm/logger"
 )
 
-// 错误处理待完善，数据库配置考虑写到配置文件，后面做修改
-func InitDB() *gorm.DB {
-	// 连接到Docker中的MySQL
-	var dsn = "root:root@tcp(localhost:3306)/teamtick?charset=utf8&parseTime=True&loc=Local"
-	db, err := gorm.Open(mysql.Open(dsn), &gorm.Config{
+// InitDB 连接数据库并配置连接池，SQL日志输出到传入的logger
+func InitDB(cfg config.DatabaseConfig, log *slog.Logger) (*gorm.DB, error) {
+	dialector, err := openDialector(cfg.Driver, cfg.DSN)
+	if err != nil {
+		return nil, err
+	}
+	db, err := gorm.Open(dialector, &gorm.Config{
 		SkipDefaultTransaction: true,
 		PrepareStmt:            true,
-		Logger:                 logger.Default.LogMode(logger.Info),
+		Logger:                 logger.NewGormLogger(log, gormLogLevel(cfg.LogLevel), cfg.SlowThreshold),
+		// 将各驱动的唯一键冲突等错误统一转换为 gorm.ErrDuplicatedKey
+		TranslateError: true,
 	})
 	if err != nil {
-		log.Fatalf("Cannot open database: %v", err)
-		return nil
+		return nil, fmt.Errorf("open database: %w", err)
 	}
 	sqlDB, err := db.DB()
 	if err != nil {
-		log.Fatalf("Cannot configure database: %v", err)
+		return nil, fmt.Errorf("configure database: %w", err)
 	}
-	//最大连接数等配置，仅作参考
-	sqlDB.SetMaxIdleConns(10)
-	sqlDB.SetMaxOpenConns(100)
-
-	// 自动迁移所有表结构
-	err = db.AutoMigrate(
-		&models.User{},
-		&models.Group{},
-		&models.GroupMember{},
-		&models.Task{},
-		&models.TaskRecord{},
-		&models.CheckApplication{},
-		&models.JoinApplication{},
-	)
-	if err != nil {
-		log.Fatalf("Failed to migrate database: %v", err)
+	//连接池配置
+	sqlDB.SetMaxIdleConns(cfg.MaxIdleConns)
+	sqlDB.SetMaxOpenConns(cfg.MaxOpenConns)
+	sqlDB.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+
+	if err := checkServerVersion(context.Background(), db, cfg.Driver); err != nil {
+		return nil, err
 	}
 
-	return db
+	// 开发环境可在启动时自动执行版本化迁移，生产环境应通过 migrate 子命令显式执行
+	if cfg.AutoMigrate {
+		migrator, err := migrations.NewMigrator(db)
+		if err != nil {
+			return nil, fmt.Errorf("load migrations: %w", err)
+		}
+		executed, err := migrator.Up(context.Background())
+		if err != nil {
+			return nil, fmt.Errorf("migrate database: %w", err)
+		}
+		for _, migration := range executed {
+			log.Info("migration applied", slog.Int("version", migration.Version), slog.String("name", migration.Name))
+		}
+	}
+
+	return db, nil
+}
+
+// gormLogLevel 将配置中的日志级别转换为GORM日志级别
+func gormLogLevel(level string) gormlogger.LogLevel {
+	switch level {
+	case "silent":
+		return gormlogger.Silent
+	case "error":
+		return gormlogger.Error
+	case "warn":
+		return gormlogger.Warn
+	default:
+		return gormlogger.Info
+	}
 }
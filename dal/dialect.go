@@ -0,0 +1,35 @@
+package db
+
+import (
+	"fmt"
+
+	"gorm.io/driver/mysql"
+	"gorm.io/driver/postgres"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// 支持的数据库驱动
+const (
+	DriverMySQL    = "mysql"
+	DriverPostgres = "postgres"
+	DriverSQLite   = "sqlite"
+	// DriverMemory 不连接数据库，使用内存DAO，见 dao.NewMemoryDAOFactory
+	DriverMemory = "memory"
+)
+
+// openDialector 根据配置的驱动名称选择对应的GORM方言
+// 方言差异（标识符引用、列类型、唯一键冲突错误等）交由GORM方言处理，
+// DAO层与模型只使用三种数据库都支持的写法
+func openDialector(driver, dsn string) (gorm.Dialector, error) {
+	switch driver {
+	case DriverMySQL:
+		return mysql.Open(dsn), nil
+	case DriverPostgres:
+		return postgres.Open(dsn), nil
+	case DriverSQLite:
+		return sqlite.Open(dsn), nil
+	default:
+		return nil, fmt.Errorf("unsupported database driver: %q", driver)
+	}
+}
@@ -0,0 +1,67 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"path/filepath"
+	"testing"
+
+	"TeamTickBackend/config"
+	"TeamTickBackend/dal/dao/impl"
+	"TeamTickBackend/dal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// openSQLite 按生产配置打开临时SQLite数据库并执行迁移
+func openSQLite(t *testing.T) *gorm.DB {
+	db, err := InitDB(config.DatabaseConfig{
+		Driver:       DriverSQLite,
+		DSN:          filepath.Join(t.TempDir(), "teamtick.db"),
+		MaxIdleConns: 1,
+		MaxOpenConns: 1,
+		LogLevel:     "silent",
+		AutoMigrate:  true,
+	}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	require.NoError(t, err)
+	return db
+}
+
+func TestSQLite_DuplicateKeyTranslated(t *testing.T) {
+	db := openSQLite(t)
+	users := &impl.UserDAOMySQLImpl{DB: db}
+	ctx := context.Background()
+
+	require.NoError(t, users.Create(ctx, &models.User{Username: "alice", Password: "x"}))
+	err := users.Create(ctx, &models.User{Username: "alice", Password: "y"})
+	assert.ErrorIs(t, err, gorm.ErrDuplicatedKey)
+
+	// 事务中的冲突同样被转换，服务层据此返回业务错误
+	err = db.Transaction(func(tx *gorm.DB) error {
+		return users.Create(ctx, &models.User{Username: "alice", Password: "z"}, tx)
+	})
+	assert.ErrorIs(t, err, gorm.ErrDuplicatedKey)
+}
+
+func TestSQLite_CheckConstraintViolation(t *testing.T) {
+	db := openSQLite(t)
+	members := &impl.GroupMemberDAOMySQLImpl{DB: db}
+	ctx := context.Background()
+
+	require.NoError(t, members.Create(ctx, &models.GroupMember{GroupID: 1, UserID: 1, Username: "alice", Role: "member"}))
+	// group_member.role 只允许 admin 与 member，SQLite方言不转换CHECK错误，不能被当作唯一键冲突
+	err := members.UpdateRole(ctx, 1, 1, "owner")
+	assert.ErrorContains(t, err, "CHECK constraint failed")
+	assert.False(t, errors.Is(err, gorm.ErrDuplicatedKey))
+	stored, err := members.GetMemberByGroupIDAndUserID(ctx, 1, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "member", stored.Role)
+
+	err = members.Create(ctx, &models.GroupMember{GroupID: 1, UserID: 2, Username: "bob", Role: "owner"})
+	assert.ErrorContains(t, err, "CHECK constraint failed")
+	assert.False(t, errors.Is(err, gorm.ErrDuplicatedKey))
+}
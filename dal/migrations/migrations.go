@@ -0,0 +1,163 @@
+package migrations
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"embed"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"gorm.io/gorm"
+)
+
+//go:embed sql/*.sql
+var sqlFiles embed.FS
+
+// 迁移文件命名规则：<版本号>_<名称>.<up|down>.sql，例如 0001_init.up.sql
+var fileNamePattern = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+// Migration 一个版本的迁移，Up/Down 为未渲染的SQL模板
+type Migration struct {
+	Version  int
+	Name     string
+	Up       string
+	Down     string
+	Checksum string
+}
+
+// dialectTypes 迁移模板中可引用的方言相关列类型
+type dialectTypes struct {
+	PrimaryKey string
+	DateTime   string
+	LongText   string
+	Float      string
+}
+
+var dialects = map[string]dialectTypes{
+	"mysql": {
+		PrimaryKey: "INT NOT NULL AUTO_INCREMENT PRIMARY KEY",
+		DateTime:   "DATETIME",
+		LongText:   "MEDIUMTEXT",
+		Float:      "FLOAT",
+	},
+	"postgres": {
+		PrimaryKey: "SERIAL PRIMARY KEY",
+		DateTime:   "TIMESTAMPTZ",
+		LongText:   "TEXT",
+		Float:      "DOUBLE PRECISION",
+	},
+	"sqlite": {
+		PrimaryKey: "INTEGER PRIMARY KEY AUTOINCREMENT",
+		DateTime:   "DATETIME",
+		LongText:   "TEXT",
+		Float:      "REAL",
+	},
+}
+
+// load 读取内嵌的迁移文件并按版本号排序
+func load() ([]*Migration, error) {
+	entries, err := fs.ReadDir(sqlFiles, "sql")
+	if err != nil {
+		return nil, err
+	}
+	byVersion := make(map[int]*Migration)
+	for _, entry := range entries {
+		matches := fileNamePattern.FindStringSubmatch(entry.Name())
+		if matches == nil {
+			return nil, fmt.Errorf("invalid migration file name: %s", entry.Name())
+		}
+		version, _ := strconv.Atoi(matches[1])
+		content, err := sqlFiles.ReadFile(path.Join("sql", entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: matches[2]}
+			byVersion[version] = m
+		} else if m.Name != matches[2] {
+			return nil, fmt.Errorf("migration version %d has conflicting names %q and %q", version, m.Name, matches[2])
+		}
+		if matches[3] == "up" {
+			m.Up = string(content)
+		} else {
+			m.Down = string(content)
+		}
+	}
+
+	migrations := make([]*Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" || m.Down == "" {
+			return nil, fmt.Errorf("migration %04d_%s must have both up and down files", m.Version, m.Name)
+		}
+		sum := sha256.Sum256([]byte(m.Up + "\n--\n" + m.Down))
+		m.Checksum = hex.EncodeToString(sum[:])
+		migrations = append(migrations, m)
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+	return migrations, nil
+}
+
+// render 按当前数据库方言渲染SQL模板，并拆分为逐条执行的语句
+func render(db *gorm.DB, source string) ([]string, error) {
+	types, ok := dialects[db.Dialector.Name()]
+	if !ok {
+		return nil, fmt.Errorf("migrations do not support dialect %q", db.Dialector.Name())
+	}
+	quote := func(name string) string {
+		var buf strings.Builder
+		db.Dialector.QuoteTo(&buf, name)
+		return buf.String()
+	}
+	tmpl, err := template.New("migration").Funcs(template.FuncMap{
+		"quote": quote,
+		// dropIndex MySQL 删除索引时需要指定表名
+		"dropIndex": func(index, table string) string {
+			if db.Dialector.Name() == "mysql" {
+				return "DROP INDEX " + quote(index) + " ON " + quote(table)
+			}
+			return "DROP INDEX " + quote(index)
+		},
+	}).Parse(source)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, types); err != nil {
+		return nil, err
+	}
+	return splitStatements(buf.String()), nil
+}
+
+// splitStatements 以行尾分号拆分语句，忽略注释行与空语句
+// 部分驱动（如MySQL默认配置）不支持一次执行多条语句
+func splitStatements(script string) []string {
+	var statements []string
+	var current strings.Builder
+	for _, line := range strings.Split(script, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "--") {
+			continue
+		}
+		current.WriteString(line)
+		current.WriteString("\n")
+		if strings.HasSuffix(trimmed, ";") {
+			statement := strings.TrimSuffix(strings.TrimSpace(current.String()), ";")
+			statements = append(statements, statement)
+			current.Reset()
+		}
+	}
+	if rest := strings.TrimSpace(current.String()); rest != "" {
+		statements = append(statements, rest)
+	}
+	return statements
+}
@@ -0,0 +1,280 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// 记录已执行迁移的表
+const schemaTable = "schema_migrations"
+
+const schemaTableDDL = `CREATE TABLE IF NOT EXISTS schema_migrations (
+    version INT NOT NULL PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    checksum VARCHAR(64) NOT NULL,
+    applied_at {{.DateTime}} NOT NULL
+);`
+
+// appliedMigration schema_migrations 表中的一行
+type appliedMigration struct {
+	Version   int       `gorm:"column:version;primaryKey;autoIncrement:false"`
+	Name      string    `gorm:"column:name"`
+	Checksum  string    `gorm:"column:checksum"`
+	AppliedAt time.Time `gorm:"column:applied_at"`
+}
+
+func (appliedMigration) TableName() string {
+	return schemaTable
+}
+
+// Status 单个迁移的执行状态
+type Status struct {
+	Version          int
+	Name             string
+	Applied          bool
+	AppliedAt        *time.Time
+	ChecksumMismatch bool
+	// Missing 表示数据库中已执行但当前程序中不存在的迁移
+	Missing bool
+}
+
+// Migrator 执行版本化的数据库迁移
+type Migrator struct {
+	db         *gorm.DB
+	migrations []*Migration
+}
+
+func NewMigrator(db *gorm.DB) (*Migrator, error) {
+	migrations, err := load()
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{db: db, migrations: migrations}, nil
+}
+
+// Latest 当前程序包含的最新迁移版本
+func (m *Migrator) Latest() int {
+	if len(m.migrations) == 0 {
+		return 0
+	}
+	return m.migrations[len(m.migrations)-1].Version
+}
+
+// Status 列出所有迁移及其执行状态
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var statuses []Status
+	for _, migration := range m.migrations {
+		status := Status{Version: migration.Version, Name: migration.Name}
+		if record, ok := applied[migration.Version]; ok {
+			appliedAt := record.AppliedAt
+			status.Applied = true
+			status.AppliedAt = &appliedAt
+			status.ChecksumMismatch = record.Checksum != migration.Checksum
+			delete(applied, migration.Version)
+		}
+		statuses = append(statuses, status)
+	}
+	for _, record := range applied {
+		appliedAt := record.AppliedAt
+		statuses = append(statuses, Status{
+			Version:   record.Version,
+			Name:      record.Name,
+			Applied:   true,
+			AppliedAt: &appliedAt,
+			Missing:   true,
+		})
+	}
+	return statuses, nil
+}
+
+// Up 执行所有未执行的迁移
+func (m *Migrator) Up(ctx context.Context) ([]*Migration, error) {
+	return m.To(ctx, m.Latest())
+}
+
+// Down 回滚最近执行的steps个迁移
+func (m *Migrator) Down(ctx context.Context, steps int) ([]*Migration, error) {
+	if steps <= 0 {
+		return nil, nil
+	}
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+	target := 0
+	count := 0
+	for i := len(m.migrations) - 1; i >= 0; i-- {
+		if _, ok := applied[m.migrations[i].Version]; !ok {
+			continue
+		}
+		count++
+		if count > steps {
+			target = m.migrations[i].Version
+			break
+		}
+	}
+	return m.To(ctx, target)
+}
+
+// To 迁移到指定版本：高于当前版本时依次执行up，低于当前版本时依次执行down
+func (m *Migrator) To(ctx context.Context, version int) ([]*Migration, error) {
+	if version < 0 {
+		return nil, fmt.Errorf("invalid target version %d", version)
+	}
+	if version != 0 && m.find(version) == nil {
+		return nil, fmt.Errorf("unknown migration version %d", version)
+	}
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := m.verify(applied); err != nil {
+		return nil, err
+	}
+
+	var executed []*Migration
+	// 先回滚高于目标版本的迁移（倒序）
+	for i := len(m.migrations) - 1; i >= 0; i-- {
+		migration := m.migrations[i]
+		if migration.Version <= version {
+			break
+		}
+		if _, ok := applied[migration.Version]; !ok {
+			continue
+		}
+		if err := m.run(ctx, migration, false); err != nil {
+			return executed, err
+		}
+		executed = append(executed, migration)
+	}
+	// 再执行不高于目标版本的未执行迁移（正序）
+	for _, migration := range m.migrations {
+		if migration.Version > version {
+			break
+		}
+		if _, ok := applied[migration.Version]; ok {
+			continue
+		}
+		if err := m.run(ctx, migration, true); err != nil {
+			return executed, err
+		}
+		executed = append(executed, migration)
+	}
+	return executed, nil
+}
+
+// Baseline 将不高于指定版本的迁移标记为已执行但不实际运行，
+// 用于接管此前由 AutoMigrate 创建的已有数据库
+func (m *Migrator) Baseline(ctx context.Context, version int) error {
+	if m.find(version) == nil {
+		return fmt.Errorf("unknown migration version %d", version)
+	}
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return err
+	}
+	return m.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, migration := range m.migrations {
+			if migration.Version > version {
+				break
+			}
+			if _, ok := applied[migration.Version]; ok {
+				continue
+			}
+			if err := tx.Create(m.record(migration)).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// run 在事务中执行单个迁移并更新 schema_migrations
+// 注意：MySQL 的DDL语句会隐式提交，失败时可能需要人工处理
+func (m *Migrator) run(ctx context.Context, migration *Migration, up bool) error {
+	source := migration.Down
+	if up {
+		source = migration.Up
+	}
+	statements, err := render(m.db, source)
+	if err != nil {
+		return fmt.Errorf("render migration %04d_%s: %w", migration.Version, migration.Name, err)
+	}
+	err = m.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, statement := range statements {
+			if err := tx.Exec(statement).Error; err != nil {
+				return err
+			}
+		}
+		if up {
+			return tx.Create(m.record(migration)).Error
+		}
+		return tx.Delete(&appliedMigration{}, migration.Version).Error
+	})
+	if err != nil {
+		direction := "down"
+		if up {
+			direction = "up"
+		}
+		return fmt.Errorf("migration %04d_%s %s failed: %w", migration.Version, migration.Name, direction, err)
+	}
+	return nil
+}
+
+// applied 确保 schema_migrations 存在并返回已执行的迁移
+func (m *Migrator) applied(ctx context.Context) (map[int]appliedMigration, error) {
+	statements, err := render(m.db, schemaTableDDL)
+	if err != nil {
+		return nil, err
+	}
+	db := m.db.WithContext(ctx)
+	for _, statement := range statements {
+		if err := db.Exec(statement).Error; err != nil {
+			return nil, fmt.Errorf("create %s: %w", schemaTable, err)
+		}
+	}
+	var records []appliedMigration
+	if err := db.Order("version").Find(&records).Error; err != nil {
+		return nil, err
+	}
+	applied := make(map[int]appliedMigration, len(records))
+	for _, record := range records {
+		applied[record.Version] = record
+	}
+	return applied, nil
+}
+
+// verify 已执行的迁移文件不允许再被修改
+func (m *Migrator) verify(applied map[int]appliedMigration) error {
+	for _, migration := range m.migrations {
+		if record, ok := applied[migration.Version]; ok && record.Checksum != migration.Checksum {
+			return fmt.Errorf("checksum mismatch for applied migration %04d_%s", migration.Version, migration.Name)
+		}
+	}
+	return nil
+}
+
+func (m *Migrator) find(version int) *Migration {
+	for _, migration := range m.migrations {
+		if migration.Version == version {
+			return migration
+		}
+	}
+	return nil
+}
+
+func (m *Migrator) record(migration *Migration) *appliedMigration {
+	return &appliedMigration{
+		Version:   migration.Version,
+		Name:      migration.Name,
+		Checksum:  migration.Checksum,
+		AppliedAt: time.Now(),
+	}
+}
@@ -0,0 +1,107 @@
+package migrations
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+// openSQLite 在临时目录中创建SQLite数据库，迁移文件在三种方言下使用同一套模板
+func openSQLite(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "teamtick.db")), &gorm.Config{
+		Logger: gormlogger.Discard,
+	})
+	require.NoError(t, err)
+	return db
+}
+
+func TestMigrator_UpDownUp(t *testing.T) {
+	db := openSQLite(t)
+	migrator, err := NewMigrator(db)
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	executed, err := migrator.Up(ctx)
+	require.NoError(t, err)
+	require.Len(t, executed, len(migrator.migrations))
+	assert.True(t, db.Migrator().HasTable("users"))
+	assert.True(t, db.Migrator().HasTable("group_roles"))
+	statuses, err := migrator.Status(ctx)
+	require.NoError(t, err)
+	for _, status := range statuses {
+		assert.True(t, status.Applied, "migration %04d_%s", status.Version, status.Name)
+		assert.False(t, status.ChecksumMismatch)
+		assert.False(t, status.Missing)
+	}
+
+	// 再次执行不会重复迁移
+	executed, err = migrator.Up(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, executed)
+
+	// 回滚最近一个迁移后状态随之变化
+	executed, err = migrator.Down(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, executed, 1)
+	assert.Equal(t, migrator.Latest(), executed[0].Version)
+	statuses, err = migrator.Status(ctx)
+	require.NoError(t, err)
+	assert.False(t, statuses[len(statuses)-1].Applied)
+	assert.True(t, statuses[len(statuses)-2].Applied)
+
+	executed, err = migrator.To(ctx, 0)
+	require.NoError(t, err)
+	assert.Len(t, executed, len(migrator.migrations)-1)
+	assert.False(t, db.Migrator().HasTable("users"))
+	assert.False(t, db.Migrator().HasTable("group_roles"))
+
+	executed, err = migrator.Up(ctx)
+	require.NoError(t, err)
+	assert.Len(t, executed, len(migrator.migrations))
+	assert.True(t, db.Migrator().HasTable("users"))
+}
+
+func TestMigrator_ChecksumMismatch(t *testing.T) {
+	db := openSQLite(t)
+	migrator, err := NewMigrator(db)
+	require.NoError(t, err)
+	ctx := context.Background()
+	_, err = migrator.To(ctx, 1)
+	require.NoError(t, err)
+
+	// 已执行的迁移文件被修改
+	require.NoError(t, db.Model(&appliedMigration{}).Where("version = ?", 1).Update("checksum", "tampered").Error)
+
+	statuses, err := migrator.Status(ctx)
+	require.NoError(t, err)
+	assert.True(t, statuses[0].ChecksumMismatch)
+	assert.False(t, statuses[1].Applied)
+	_, err = migrator.Up(ctx)
+	assert.ErrorContains(t, err, "checksum mismatch for applied migration 0001_init")
+	statuses, err = migrator.Status(ctx)
+	require.NoError(t, err)
+	assert.False(t, statuses[1].Applied)
+}
+
+func TestMigrator_StatusReportsMissing(t *testing.T) {
+	db := openSQLite(t)
+	migrator, err := NewMigrator(db)
+	require.NoError(t, err)
+	ctx := context.Background()
+	_, err = migrator.Up(ctx)
+	require.NoError(t, err)
+
+	// 数据库由更新的程序版本迁移过
+	require.NoError(t, db.Create(&appliedMigration{Version: 9999, Name: "future", Checksum: "x"}).Error)
+	statuses, err := migrator.Status(ctx)
+	require.NoError(t, err)
+	last := statuses[len(statuses)-1]
+	assert.Equal(t, 9999, last.Version)
+	assert.True(t, last.Missing)
+}
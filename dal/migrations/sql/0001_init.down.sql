@@ -0,0 +1,7 @@
+DROP TABLE join_application;
+DROP TABLE check_application;
+DROP TABLE tasks_record;
+DROP TABLE tasks;
+DROP TABLE group_member;
+DROP TABLE {{quote "groups"}};
+DROP TABLE users;
@@ -0,0 +1,113 @@
+-- 初始表结构，与原 AutoMigrate 生成的结构保持一致
+CREATE TABLE users (
+    user_id {{.PrimaryKey}},
+    username VARCHAR(50) NOT NULL,
+    password VARCHAR(128) NOT NULL,
+    created_at {{.DateTime}} NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at {{.DateTime}} NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE UNIQUE INDEX idx_username ON users (username);
+
+CREATE TABLE {{quote "groups"}} (
+    group_id {{.PrimaryKey}},
+    group_name VARCHAR(50) NOT NULL,
+    description VARCHAR(1024),
+    creator_id INT NOT NULL,
+    creator_name VARCHAR(50) NOT NULL,
+    member_num INT NOT NULL DEFAULT 1,
+    created_at {{.DateTime}} NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at {{.DateTime}} NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX idx_creatorid ON {{quote "groups"}} (creator_id);
+
+CREATE TABLE group_member (
+    group_id INT NOT NULL,
+    user_id INT NOT NULL,
+    group_name VARCHAR(50) NOT NULL,
+    username VARCHAR(50) NOT NULL,
+    role VARCHAR(20) NOT NULL DEFAULT 'member' CHECK (role IN ('admin', 'member')),
+    joined_at {{.DateTime}} NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    created_at {{.DateTime}} NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX idx_groupid_userid_role ON group_member (group_id, user_id, role);
+CREATE INDEX idx_groupid ON group_member (group_id);
+CREATE INDEX idx_userid ON group_member (user_id);
+
+CREATE TABLE tasks (
+    task_id {{.PrimaryKey}},
+    task_name VARCHAR(50) NOT NULL,
+    description VARCHAR(512),
+    group_id INT NOT NULL,
+    start_time {{.DateTime}} NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    end_time {{.DateTime}} NOT NULL,
+    latitude {{.Float}},
+    longitude {{.Float}},
+    radius INT NOT NULL DEFAULT 50,
+    ssid VARCHAR(50),
+    bssid VARCHAR(50),
+    tagid VARCHAR(50),
+    tagname VARCHAR(50),
+    gps BOOLEAN DEFAULT FALSE,
+    face BOOLEAN DEFAULT FALSE,
+    wifi BOOLEAN DEFAULT FALSE,
+    nfc BOOLEAN DEFAULT FALSE,
+    created_at {{.DateTime}} NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at {{.DateTime}} NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX idx_group_id ON tasks (group_id);
+
+CREATE TABLE tasks_record (
+    record_id {{.PrimaryKey}},
+    task_id INT NOT NULL,
+    task_name VARCHAR(50) NOT NULL,
+    group_id INT NOT NULL,
+    group_name VARCHAR(50) NOT NULL,
+    user_id INT NOT NULL,
+    username VARCHAR(50) NOT NULL,
+    signed_time {{.DateTime}} NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    latitude {{.Float}},
+    longitude {{.Float}},
+    face_data {{.LongText}},
+    ssid VARCHAR(50),
+    bssid VARCHAR(50),
+    tagid VARCHAR(50),
+    tagname VARCHAR(50),
+    status INT NOT NULL DEFAULT 1,
+    created_at {{.DateTime}} NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at {{.DateTime}} NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE UNIQUE INDEX idx_task_user_id ON tasks_record (task_id, user_id);
+CREATE INDEX idx_record_userid ON tasks_record (user_id);
+
+CREATE TABLE check_application (
+    id {{.PrimaryKey}},
+    group_id INT NOT NULL,
+    task_id INT NOT NULL,
+    task_name VARCHAR(50) NOT NULL,
+    user_id INT NOT NULL,
+    username VARCHAR(50) NOT NULL,
+    request_at {{.DateTime}} NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    status VARCHAR(20) DEFAULT 'pending' CHECK (status IN ('pending', 'approved', 'rejected')),
+    reason VARCHAR(1024) NOT NULL,
+    image {{.LongText}},
+    admin_id INT NOT NULL,
+    admin_username VARCHAR(50) NOT NULL,
+    processed_at {{.DateTime}} NULL,
+    created_at {{.DateTime}} NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at {{.DateTime}} NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX idx_taskid_status ON check_application (task_id, status);
+
+CREATE TABLE join_application (
+    request_id {{.PrimaryKey}},
+    group_id INT NOT NULL,
+    user_id INT NOT NULL,
+    username VARCHAR(50) NOT NULL,
+    reason VARCHAR(512) NOT NULL,
+    reject_reason VARCHAR(512),
+    status VARCHAR(20) DEFAULT 'pending' CHECK (status IN ('pending', 'accepted', 'rejected')),
+    created_at {{.DateTime}} NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at {{.DateTime}} NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX idx_groupid_status ON join_application (group_id, status);
+CREATE UNIQUE INDEX idx_groupid_userid ON join_application (group_id, user_id);
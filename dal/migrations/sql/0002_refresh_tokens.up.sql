@@ -0,0 +1,16 @@
+-- 刷新令牌，仅保存摘要；family_id 标识一次登录产生的令牌链
+CREATE TABLE refresh_tokens (
+    id {{.PrimaryKey}},
+    user_id INT NOT NULL,
+    family_id VARCHAR(64) NOT NULL,
+    token_hash VARCHAR(64) NOT NULL,
+    device_id VARCHAR(128) NOT NULL DEFAULT '',
+    device_name VARCHAR(128) NOT NULL DEFAULT '',
+    expires_at {{.DateTime}} NOT NULL,
+    used_at {{.DateTime}} NULL,
+    revoked_at {{.DateTime}} NULL,
+    created_at {{.DateTime}} NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE UNIQUE INDEX idx_refresh_tokenhash ON refresh_tokens (token_hash);
+CREATE INDEX idx_refresh_userid ON refresh_tokens (user_id);
+CREATE INDEX idx_refresh_familyid ON refresh_tokens (family_id);
@@ -0,0 +1,2 @@
+DROP TABLE user_token_cutoffs;
+DROP TABLE token_revocations;
@@ -0,0 +1,19 @@
+-- 被吊销的访问令牌(jti)与会话(sid)，expires_at 之后可清理
+CREATE TABLE token_revocations (
+    id {{.PrimaryKey}},
+    kind VARCHAR(16) NOT NULL,
+    value VARCHAR(64) NOT NULL,
+    user_id INT NOT NULL,
+    expires_at {{.DateTime}} NOT NULL,
+    revoked_at {{.DateTime}} NOT NULL
+);
+CREATE UNIQUE INDEX idx_revocation_kind_value ON token_revocations (kind, value);
+CREATE INDEX idx_revocation_expiresat ON token_revocations (expires_at);
+CREATE INDEX idx_revocation_revokedat ON token_revocations (revoked_at);
+
+-- 用户级吊销，revoked_before 为毫秒时间戳
+CREATE TABLE user_token_cutoffs (
+    user_id INT NOT NULL PRIMARY KEY,
+    revoked_before BIGINT NOT NULL
+);
+CREATE INDEX idx_cutoff_revokedbefore ON user_token_cutoffs (revoked_before);
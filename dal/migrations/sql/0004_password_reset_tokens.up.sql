@@ -0,0 +1,11 @@
+-- 自助重置密码令牌，仅保存摘要；used_at 非空表示已使用或已被新令牌作废
+CREATE TABLE password_reset_tokens (
+    id {{.PrimaryKey}},
+    user_id INT NOT NULL,
+    token_hash VARCHAR(64) NOT NULL,
+    expires_at {{.DateTime}} NOT NULL,
+    used_at {{.DateTime}} NULL,
+    created_at {{.DateTime}} NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE UNIQUE INDEX idx_pwreset_tokenhash ON password_reset_tokens (token_hash);
+CREATE INDEX idx_pwreset_userid ON password_reset_tokens (user_id);
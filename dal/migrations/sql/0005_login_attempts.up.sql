@@ -0,0 +1,11 @@
+-- 登录失败计数，scope 为 username 或 ip；blocked_until 之前拒绝该用户名或IP登录
+CREATE TABLE login_attempts (
+    id {{.PrimaryKey}},
+    scope VARCHAR(16) NOT NULL,
+    subject VARCHAR(64) NOT NULL,
+    failures INT NOT NULL DEFAULT 0,
+    last_failure_at {{.DateTime}} NOT NULL,
+    blocked_until {{.DateTime}} NULL
+);
+CREATE UNIQUE INDEX idx_loginattempt_scope_subject ON login_attempts (scope, subject);
+CREATE INDEX idx_loginattempt_lastfailureat ON login_attempts (last_failure_at);
@@ -0,0 +1,5 @@
+DROP TABLE mfa_challenges;
+DROP TABLE totp_recovery_codes;
+DROP TABLE user_totp_secrets;
+ALTER TABLE {{quote "groups"}} DROP COLUMN require_admin_2fa;
+ALTER TABLE users DROP COLUMN two_factor_enabled;
@@ -0,0 +1,36 @@
+ALTER TABLE users ADD COLUMN two_factor_enabled BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE {{quote "groups"}} ADD COLUMN require_admin_2fa BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- TOTP密钥，confirmed_at 为空表示尚未完成绑定；last_used_step 为最近一次使用的时间步，防止验证码重放
+CREATE TABLE user_totp_secrets (
+    user_id INT NOT NULL PRIMARY KEY,
+    secret VARCHAR(64) NOT NULL,
+    confirmed_at {{.DateTime}} NULL,
+    last_used_step BIGINT NOT NULL DEFAULT 0,
+    created_at {{.DateTime}} NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- 两步验证恢复码，仅保存摘要，每个只能使用一次
+CREATE TABLE totp_recovery_codes (
+    id {{.PrimaryKey}},
+    user_id INT NOT NULL,
+    code_hash VARCHAR(64) NOT NULL,
+    used_at {{.DateTime}} NULL,
+    created_at {{.DateTime}} NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX idx_recoverycode_userid ON totp_recovery_codes (user_id);
+
+-- 两步登录的第一步通过后签发的挑战令牌，仅保存摘要
+CREATE TABLE mfa_challenges (
+    id {{.PrimaryKey}},
+    user_id INT NOT NULL,
+    token_hash VARCHAR(64) NOT NULL,
+    device_id VARCHAR(128) NOT NULL DEFAULT '',
+    device_name VARCHAR(128) NOT NULL DEFAULT '',
+    attempts INT NOT NULL DEFAULT 0,
+    expires_at {{.DateTime}} NOT NULL,
+    used_at {{.DateTime}} NULL,
+    created_at {{.DateTime}} NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE UNIQUE INDEX idx_mfachallenge_tokenhash ON mfa_challenges (token_hash);
+CREATE INDEX idx_mfachallenge_expiresat ON mfa_challenges (expires_at);
@@ -0,0 +1,25 @@
+-- 外部身份与本地用户的关联，同一身份提供方的 subject 只能关联一个用户
+CREATE TABLE user_identities (
+    id {{.PrimaryKey}},
+    user_id INT NOT NULL,
+    issuer VARCHAR(255) NOT NULL,
+    subject VARCHAR(255) NOT NULL,
+    created_at {{.DateTime}} NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE UNIQUE INDEX idx_useridentity_issuer_subject ON user_identities (issuer, subject);
+CREATE INDEX idx_useridentity_userid ON user_identities (user_id);
+
+-- OIDC授权请求的 state，仅保存摘要；link_user_id 非0表示为已登录用户关联身份
+CREATE TABLE oidc_login_states (
+    id {{.PrimaryKey}},
+    state_hash VARCHAR(64) NOT NULL,
+    code_verifier VARCHAR(128) NOT NULL,
+    nonce VARCHAR(64) NOT NULL,
+    link_user_id INT NOT NULL DEFAULT 0,
+    device_id VARCHAR(128) NOT NULL DEFAULT '',
+    device_name VARCHAR(128) NOT NULL DEFAULT '',
+    expires_at {{.DateTime}} NOT NULL,
+    created_at {{.DateTime}} NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE UNIQUE INDEX idx_oidcstate_statehash ON oidc_login_states (state_hash);
+CREATE INDEX idx_oidcstate_expiresat ON oidc_login_states (expires_at);
@@ -0,0 +1,16 @@
+-- 个人访问令牌，仅保存摘要；scopes 为以空格分隔的权限范围
+CREATE TABLE personal_access_tokens (
+    id {{.PrimaryKey}},
+    user_id INT NOT NULL,
+    name VARCHAR(64) NOT NULL,
+    token_hash VARCHAR(64) NOT NULL,
+    token_hint VARCHAR(16) NOT NULL,
+    scopes VARCHAR(512) NOT NULL,
+    expires_at {{.DateTime}} NOT NULL,
+    last_used_at {{.DateTime}} NULL,
+    last_used_ip VARCHAR(64) NOT NULL DEFAULT '',
+    revoked_at {{.DateTime}} NULL,
+    created_at {{.DateTime}} NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE UNIQUE INDEX idx_pat_tokenhash ON personal_access_tokens (token_hash);
+CREATE INDEX idx_pat_userid ON personal_access_tokens (user_id);
@@ -0,0 +1,17 @@
+-- 登录会话，session_id 与访问令牌的 sid、刷新令牌的 family_id 一致
+CREATE TABLE user_sessions (
+    id {{.PrimaryKey}},
+    user_id INT NOT NULL,
+    session_id VARCHAR(64) NOT NULL,
+    device_id VARCHAR(128) NOT NULL DEFAULT '',
+    device_name VARCHAR(128) NOT NULL DEFAULT '',
+    user_agent VARCHAR(255) NOT NULL DEFAULT '',
+    ip VARCHAR(64) NOT NULL DEFAULT '',
+    last_seen_at {{.DateTime}} NOT NULL,
+    last_seen_ip VARCHAR(64) NOT NULL DEFAULT '',
+    expires_at {{.DateTime}} NOT NULL,
+    revoked_at {{.DateTime}} NULL,
+    created_at {{.DateTime}} NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE UNIQUE INDEX idx_session_sessionid ON user_sessions (session_id);
+CREATE INDEX idx_session_userid ON user_sessions (user_id);
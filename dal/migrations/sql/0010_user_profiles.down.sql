@@ -0,0 +1,10 @@
+ALTER TABLE users DROP COLUMN share_phone;
+ALTER TABLE users DROP COLUMN share_email;
+ALTER TABLE users DROP COLUMN share_student_number;
+ALTER TABLE users DROP COLUMN share_real_name;
+ALTER TABLE users DROP COLUMN avatar_url;
+ALTER TABLE users DROP COLUMN phone;
+ALTER TABLE users DROP COLUMN email;
+ALTER TABLE users DROP COLUMN student_number;
+ALTER TABLE users DROP COLUMN real_name;
+ALTER TABLE users DROP COLUMN display_name;
@@ -0,0 +1,11 @@
+-- 个人资料与隐私设置，share_* 表示是否向所在用户组的管理员展示对应字段
+ALTER TABLE users ADD COLUMN display_name VARCHAR(50) NOT NULL DEFAULT '';
+ALTER TABLE users ADD COLUMN real_name VARCHAR(50) NOT NULL DEFAULT '';
+ALTER TABLE users ADD COLUMN student_number VARCHAR(32) NOT NULL DEFAULT '';
+ALTER TABLE users ADD COLUMN email VARCHAR(128) NOT NULL DEFAULT '';
+ALTER TABLE users ADD COLUMN phone VARCHAR(32) NOT NULL DEFAULT '';
+ALTER TABLE users ADD COLUMN avatar_url VARCHAR(512) NOT NULL DEFAULT '';
+ALTER TABLE users ADD COLUMN share_real_name BOOLEAN NOT NULL DEFAULT TRUE;
+ALTER TABLE users ADD COLUMN share_student_number BOOLEAN NOT NULL DEFAULT TRUE;
+ALTER TABLE users ADD COLUMN share_email BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE users ADD COLUMN share_phone BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,4 @@
+DROP TABLE admin_actions;
+ALTER TABLE users DROP COLUMN disabled_reason;
+ALTER TABLE users DROP COLUMN disabled_at;
+ALTER TABLE users DROP COLUMN platform_role;
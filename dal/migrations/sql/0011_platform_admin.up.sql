@@ -0,0 +1,19 @@
+-- 平台角色与账号停用
+ALTER TABLE users ADD COLUMN platform_role VARCHAR(20) NOT NULL DEFAULT 'user';
+ALTER TABLE users ADD COLUMN disabled_at {{.DateTime}} NULL;
+ALTER TABLE users ADD COLUMN disabled_reason VARCHAR(255) NOT NULL DEFAULT '';
+-- 平台管理操作日志，应用只追加记录，不提供修改与删除
+CREATE TABLE admin_actions (
+    id {{.PrimaryKey}},
+    actor_id INT NOT NULL,
+    actor_name VARCHAR(50) NOT NULL,
+    action VARCHAR(32) NOT NULL,
+    target_type VARCHAR(16) NOT NULL,
+    target_id INT NOT NULL DEFAULT 0,
+    target_name VARCHAR(128) NOT NULL DEFAULT '',
+    detail VARCHAR(1024) NOT NULL DEFAULT '',
+    ip VARCHAR(64) NOT NULL DEFAULT '',
+    created_at {{.DateTime}} NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX idx_adminaction_actorid ON admin_actions (actor_id);
+CREATE INDEX idx_adminaction_createdat ON admin_actions (created_at);
@@ -0,0 +1 @@
+DROP TABLE group_invites;
@@ -0,0 +1,16 @@
+-- 用户组邀请码
+CREATE TABLE group_invites (
+    id {{.PrimaryKey}},
+    group_id INT NOT NULL,
+    code VARCHAR(16) NOT NULL,
+    role VARCHAR(20) NOT NULL DEFAULT 'member',
+    created_by INT NOT NULL,
+    created_by_name VARCHAR(50) NOT NULL,
+    max_uses INT NOT NULL DEFAULT 0,
+    uses INT NOT NULL DEFAULT 0,
+    expires_at {{.DateTime}} NULL,
+    revoked_at {{.DateTime}} NULL,
+    created_at {{.DateTime}} NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE UNIQUE INDEX idx_groupinvite_code ON group_invites (code);
+CREATE INDEX idx_groupinvite_groupid ON group_invites (group_id);
@@ -0,0 +1 @@
+ALTER TABLE {{quote "groups"}} DROP COLUMN join_policy;
@@ -0,0 +1,2 @@
+-- 用户组加入方式：open、approval、invite_only、closed，已有用户组保持需审批
+ALTER TABLE {{quote "groups"}} ADD COLUMN join_policy VARCHAR(20) NOT NULL DEFAULT 'approval';
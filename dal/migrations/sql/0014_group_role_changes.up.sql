@@ -0,0 +1,14 @@
+-- 组内角色变更记录
+CREATE TABLE group_role_changes (
+    id {{.PrimaryKey}},
+    group_id INT NOT NULL,
+    user_id INT NOT NULL,
+    username VARCHAR(50) NOT NULL,
+    action VARCHAR(32) NOT NULL,
+    from_role VARCHAR(20) NOT NULL,
+    to_role VARCHAR(20) NOT NULL,
+    operator_id INT NOT NULL,
+    operator_name VARCHAR(50) NOT NULL,
+    created_at {{.DateTime}} NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX idx_grouprolechange_groupid ON group_role_changes (group_id);
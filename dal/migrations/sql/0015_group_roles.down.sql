@@ -0,0 +1,2 @@
+ALTER TABLE group_member DROP COLUMN role_id;
+DROP TABLE group_roles;
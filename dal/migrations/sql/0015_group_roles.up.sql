@@ -0,0 +1,13 @@
+-- 用户组自定义角色，权限以空格分隔
+CREATE TABLE group_roles (
+    id {{.PrimaryKey}},
+    group_id INT NOT NULL,
+    name VARCHAR(20) NOT NULL,
+    description VARCHAR(255) NOT NULL DEFAULT '',
+    permissions VARCHAR(512) NOT NULL,
+    created_at {{.DateTime}} NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at {{.DateTime}} NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE UNIQUE INDEX idx_grouprole_groupid_name ON group_roles (group_id, name);
+-- 成员的自定义角色，为0时按 role 列的 admin 或 member 处理
+ALTER TABLE group_member ADD COLUMN role_id INT NOT NULL DEFAULT 0;
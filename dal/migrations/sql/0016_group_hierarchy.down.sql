@@ -0,0 +1,2 @@
+{{dropIndex "idx_groups_parent_id" "groups"}};
+ALTER TABLE {{quote "groups"}} DROP COLUMN parent_id;
@@ -0,0 +1,3 @@
+-- 上级用户组，0为顶级用户组
+ALTER TABLE {{quote "groups"}} ADD COLUMN parent_id INT NOT NULL DEFAULT 0;
+CREATE INDEX idx_groups_parent_id ON {{quote "groups"}} (parent_id);
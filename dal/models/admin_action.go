@@ -0,0 +1,68 @@
+package models
+
+import (
+	"time"
+)
+
+// 平台管理操作类型
+const (
+	AdminActionDisableUser        = "disable_user"
+	AdminActionEnableUser         = "enable_user"
+	AdminActionSetPlatformRole    = "set_platform_role"
+	AdminActionDeleteGroup        = "delete_group"
+	AdminActionUnlockLogin        = "unlock_login"
+	AdminActionResetPassword      = "reset_password"
+	AdminActionRevokeSessions     = "revoke_sessions"
+	AdminActionTransferGroupOwner = "transfer_group_owner"
+	AdminActionCloseTask          = "close_task"
+	AdminActionRecountMembers     = "recount_members"
+)
+
+// 管理操作的对象类型
+const (
+	AdminTargetUser  = "user"
+	AdminTargetGroup = "group"
+	AdminTargetLogin = "login"
+	AdminTargetTask  = "task"
+)
+
+// AdminAction 平台管理操作日志，只追加不修改、不删除
+type AdminAction struct {
+	ID         int    `gorm:"primaryKey;column:id;type:int;not null;autoIncrement" json:"id"`
+	ActorID    int    `gorm:"column:actor_id;type:int;not null;index:idx_adminaction_actorid;comment:操作者用户ID，运维命令为0" json:"actor_id"`
+	ActorName  string `gorm:"column:actor_name;type:varchar(50);not null;comment:操作者用户名" json:"actor_name"`
+	Action     string `gorm:"column:action;type:varchar(32);not null;comment:操作类型" json:"action"`
+	TargetType string `gorm:"column:target_type;type:varchar(16);not null;comment:对象类型" json:"target_type"`
+	TargetID   int    `gorm:"column:target_id;type:int;not null;default:0;comment:对象ID" json:"target_id"`
+	// TargetName 操作时对象的名称，对象被删除或改名后仍可追溯
+	TargetName string    `gorm:"column:target_name;type:varchar(128);not null;default:'';comment:对象名称" json:"target_name"`
+	Detail     string    `gorm:"column:detail;type:varchar(1024);not null;default:'';comment:操作说明" json:"detail"`
+	IP         string    `gorm:"column:ip;type:varchar(64);not null;default:'';comment:操作者IP" json:"ip"`
+	CreatedAt  time.Time `gorm:"column:created_at;not null;default:CURRENT_TIMESTAMP;index:idx_adminaction_createdat;comment:操作时间" json:"created_at"`
+}
+
+func (AdminAction) TableName() string {
+	return "admin_actions"
+}
+
+// PlatformStats 平台概况，不对应数据库表
+type PlatformStats struct {
+	Users               int64
+	DisabledUsers       int64
+	SuperAdmins         int64
+	Groups              int64
+	Tasks               int64
+	ActiveTasks         int64
+	CheckIns            int64
+	CheckInsLast24h     int64
+	PendingAudits       int64
+	PendingJoinRequests int64
+}
+
+// AdminActionFilter 查询管理操作日志的条件，零值表示不过滤
+type AdminActionFilter struct {
+	ActorID    int
+	Action     string
+	TargetType string
+	TargetID   int
+}
@@ -0,0 +1,19 @@
+package models
+
+// 冗余列的来源数据
+const (
+	// DenormSourceUser 用户名，来源于 users.username
+	DenormSourceUser = "user"
+	// DenormSourceGroup 用户组名称，来源于 groups.group_name
+	DenormSourceGroup = "group"
+	// DenormSourceTask 任务名称，来源于 tasks.task_name
+	DenormSourceTask = "task"
+)
+
+// ColumnDrift 一个冗余列中与来源数据不一致的行数，不对应数据库表
+type ColumnDrift struct {
+	Table  string
+	Column string
+	Source string
+	Rows   int64
+}
@@ -0,0 +1,37 @@
+package models
+
+import (
+	"time"
+)
+
+// GroupInvite 用户组邀请码，持有邀请码的用户无需审批即可加入用户组
+type GroupInvite struct {
+	ID      int    `gorm:"primaryKey;column:id;type:int;not null;autoIncrement" json:"id"`
+	GroupID int    `gorm:"column:group_id;type:int;not null;index:idx_groupinvite_groupid;comment:用户组ID" json:"group_id"`
+	Code    string `gorm:"column:code;type:varchar(16);not null;uniqueIndex:idx_groupinvite_code;comment:邀请码" json:"code"`
+	// Role 通过邀请码加入后的组内角色
+	Role          string `gorm:"column:role;type:varchar(20);not null;default:member;comment:加入后的角色" json:"role"`
+	CreatedBy     int    `gorm:"column:created_by;type:int;not null;comment:创建者ID" json:"created_by"`
+	CreatedByName string `gorm:"column:created_by_name;type:varchar(50);not null;comment:创建者用户名" json:"created_by_name"`
+	// MaxUses 可使用次数上限，为0时不限次数
+	MaxUses   int        `gorm:"column:max_uses;type:int;not null;default:0;comment:使用次数上限" json:"max_uses"`
+	Uses      int        `gorm:"column:uses;type:int;not null;default:0;comment:已使用次数" json:"uses"`
+	ExpiresAt *time.Time `gorm:"column:expires_at;comment:过期时间，为空时长期有效" json:"expires_at"`
+	RevokedAt *time.Time `gorm:"column:revoked_at;comment:撤销时间" json:"revoked_at"`
+	CreatedAt time.Time  `gorm:"column:created_at;not null;default:CURRENT_TIMESTAMP;comment:创建时间" json:"created_at"`
+}
+
+func (GroupInvite) TableName() string {
+	return "group_invites"
+}
+
+// Usable 邀请码未撤销、未过期且未用完
+func (i *GroupInvite) Usable(now time.Time) bool {
+	if i.RevokedAt != nil {
+		return false
+	}
+	if i.ExpiresAt != nil && !i.ExpiresAt.After(now) {
+		return false
+	}
+	return i.MaxUses == 0 || i.Uses < i.MaxUses
+}
@@ -0,0 +1,86 @@
+package models
+
+import (
+	"slices"
+	"strings"
+	"time"
+)
+
+// 组内权限，组管理员与自定义角色按权限校验各项管理操作
+const (
+	PermissionGroupUpdate   = "group.update"
+	PermissionGroupDelete   = "group.delete"
+	PermissionSubgroups     = "subgroup.manage"
+	PermissionMemberReview  = "member.review"
+	PermissionMemberInvite  = "member.invite"
+	PermissionMemberRemove  = "member.remove"
+	PermissionMemberRole    = "member.role"
+	PermissionMemberProfile = "member.profile"
+	PermissionRoleManage    = "role.manage"
+	PermissionTaskView      = "task.view"
+	PermissionTaskCreate    = "task.create"
+	PermissionTaskUpdate    = "task.update"
+	PermissionTaskDelete    = "task.delete"
+	PermissionRecordsView   = "records.view"
+	PermissionRecordsExport = "records.export"
+	PermissionAuditView     = "audit.view"
+	PermissionAuditReview   = "audit.review"
+)
+
+// GroupPermissions 全部组内权限，用户组所有者具有全部权限
+var GroupPermissions = []string{
+	PermissionGroupUpdate,
+	PermissionGroupDelete,
+	PermissionSubgroups,
+	PermissionMemberReview,
+	PermissionMemberInvite,
+	PermissionMemberRemove,
+	PermissionMemberRole,
+	PermissionMemberProfile,
+	PermissionRoleManage,
+	PermissionTaskView,
+	PermissionTaskCreate,
+	PermissionTaskUpdate,
+	PermissionTaskDelete,
+	PermissionRecordsView,
+	PermissionRecordsExport,
+	PermissionAuditView,
+	PermissionAuditReview,
+}
+
+// AdminPermissions 组管理员的权限：除删除用户组外的全部权限
+var AdminPermissions = slices.DeleteFunc(slices.Clone(GroupPermissions), func(p string) bool {
+	return p == PermissionGroupDelete
+})
+
+// ValidGroupPermission 判断是否为已定义的组内权限
+func ValidGroupPermission(permission string) bool {
+	return slices.Contains(GroupPermissions, permission)
+}
+
+// GroupRole 用户组自定义角色，如助教、审核员；持有自定义角色的成员在成员表中的角色仍为 member
+type GroupRole struct {
+	ID      int    `gorm:"primaryKey;column:id;type:int;not null;autoIncrement" json:"id"`
+	GroupID int    `gorm:"column:group_id;type:int;not null;uniqueIndex:idx_grouprole_groupid_name,priority:1;comment:用户组ID" json:"group_id"`
+	Name    string `gorm:"column:name;type:varchar(20);not null;uniqueIndex:idx_grouprole_groupid_name,priority:2;comment:角色名称" json:"name"`
+	// Description 角色说明
+	Description string `gorm:"column:description;type:varchar(255);not null;default:'';comment:角色说明" json:"description"`
+	// Permissions 以空格分隔的组内权限
+	Permissions string    `gorm:"column:permissions;type:varchar(512);not null;comment:角色权限" json:"permissions"`
+	CreatedAt   time.Time `gorm:"column:created_at;not null;default:CURRENT_TIMESTAMP;comment:创建时间" json:"created_at"`
+	UpdatedAt   time.Time `gorm:"column:updated_at;not null;default:CURRENT_TIMESTAMP;comment:更新时间" json:"updated_at"`
+}
+
+func (GroupRole) TableName() string {
+	return "group_roles"
+}
+
+// PermissionList 角色的权限列表
+func (r *GroupRole) PermissionList() []string {
+	return strings.Fields(r.Permissions)
+}
+
+// HasPermission 角色是否具有指定权限
+func (r *GroupRole) HasPermission(permission string) bool {
+	return slices.Contains(r.PermissionList(), permission)
+}
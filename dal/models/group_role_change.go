@@ -0,0 +1,36 @@
+package models
+
+import (
+	"time"
+)
+
+// 组内角色变更类型
+const (
+	GroupRoleChangePromote  = "promote"
+	GroupRoleChangeDemote   = "demote"
+	GroupRoleChangeTransfer = "transfer_ownership"
+	// GroupRoleChangeAssign 设置自定义角色，记录中的角色为角色名称
+	GroupRoleChangeAssign = "assign"
+)
+
+// GroupRoleOwner 用户组所有者，只用于角色变更记录，成员表中所有者的角色为 admin
+const GroupRoleOwner = "owner"
+
+// GroupRoleChange 组内角色变更记录，只追加不修改；转让所有权时为新旧所有者各写一条
+type GroupRoleChange struct {
+	ID       int    `gorm:"primaryKey;column:id;type:int;not null;autoIncrement" json:"id"`
+	GroupID  int    `gorm:"column:group_id;type:int;not null;index:idx_grouprolechange_groupid;comment:用户组ID" json:"group_id"`
+	UserID   int    `gorm:"column:user_id;type:int;not null;comment:角色变更的成员ID" json:"user_id"`
+	Username string `gorm:"column:username;type:varchar(50);not null;comment:角色变更的成员用户名" json:"username"`
+	Action   string `gorm:"column:action;type:varchar(32);not null;comment:变更类型" json:"action"`
+	FromRole string `gorm:"column:from_role;type:varchar(20);not null;comment:变更前角色" json:"from_role"`
+	ToRole   string `gorm:"column:to_role;type:varchar(20);not null;comment:变更后角色" json:"to_role"`
+	// OperatorID 执行变更的用户，运维命令为0
+	OperatorID   int       `gorm:"column:operator_id;type:int;not null;comment:操作者用户ID" json:"operator_id"`
+	OperatorName string    `gorm:"column:operator_name;type:varchar(50);not null;comment:操作者用户名" json:"operator_name"`
+	CreatedAt    time.Time `gorm:"column:created_at;not null;default:CURRENT_TIMESTAMP;comment:变更时间" json:"created_at"`
+}
+
+func (GroupRoleChange) TableName() string {
+	return "group_role_changes"
+}
@@ -0,0 +1,22 @@
+package models
+
+// GroupActivity 单个用户组自身的签到数据，不含下级用户组，不对应数据库表
+type GroupActivity struct {
+	Tasks       int64
+	ActiveTasks int64
+	CheckIns    int64
+}
+
+// GroupStats 用户组及其全部下级用户组汇总的统计数据，不对应数据库表
+type GroupStats struct {
+	GroupID   int
+	GroupName string
+	ParentID  int
+	// Subgroups 全部下级用户组的数量
+	Subgroups int
+	// Members 去重后的成员人数，同时加入多个下级用户组的用户只计一次
+	Members     int
+	Tasks       int64
+	ActiveTasks int64
+	CheckIns    int64
+}
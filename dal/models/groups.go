@@ -11,10 +11,55 @@ type Group struct {
 	CreatorID   int       `gorm:"column:creator_id;type:int;not null;index:idx_creatorid;comment:创建者用户ID" json:"creator_id"`
 	CreatorName string    `gorm:"column:creator_name;type:varchar(50);not null;comment:创建者用户名" json:"creator_name"`
 	MemberNum   int       `gorm:"column:member_num;type:int;not null;default:1;comment:成员数量" json:"member_num"`
-	CreatedAt   time.Time `gorm:"column:created_at;type:datetime;not null;default:CURRENT_TIMESTAMP;comment:创建时间" json:"created_at"`
-	UpdatedAt   time.Time `gorm:"column:updated_at;type:datetime;not null;default:CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP;comment:更新时间" json:"updated_at"`
+	CreatedAt   time.Time `gorm:"column:created_at;not null;default:CURRENT_TIMESTAMP;comment:创建时间" json:"created_at"`
+	UpdatedAt   time.Time `gorm:"column:updated_at;not null;default:CURRENT_TIMESTAMP;comment:更新时间" json:"updated_at"`
+
+	// RequireAdmin2FA 组管理员必须开启两步验证才能执行管理操作
+	RequireAdmin2FA bool `gorm:"column:require_admin_2fa;not null;default:false;comment:是否要求管理员开启两步验证" json:"require_admin_2fa"`
+	// JoinPolicy 加入方式，见 JoinPolicy* 常量
+	JoinPolicy string `gorm:"column:join_policy;type:varchar(20);not null;default:approval;comment:加入方式" json:"join_policy"`
+	// ParentID 上级用户组ID，0为顶级用户组
+	ParentID int `gorm:"column:parent_id;type:int;not null;default:0;index:idx_groups_parent_id;comment:上级用户组ID" json:"parent_id"`
 }
 
+// GroupMaxDepth 用户组层级的最大深度，顶级用户组为第1层
+const GroupMaxDepth = 5
+
+// 用户组的加入方式
+const (
+	// JoinPolicyOpen 提交申请后自动通过
+	JoinPolicyOpen = "open"
+	// JoinPolicyApproval 提交申请后由组管理员审批
+	JoinPolicyApproval = "approval"
+	// JoinPolicyInviteOnly 不接受申请，只能凭邀请码加入
+	JoinPolicyInviteOnly = "invite_only"
+	// JoinPolicyClosed 不接受新成员
+	JoinPolicyClosed = "closed"
+)
+
 func (Group) TableName() string {
 	return "groups"
 }
+
+// ValidJoinPolicy 是否为支持的加入方式
+func ValidJoinPolicy(policy string) bool {
+	switch policy {
+	case JoinPolicyOpen, JoinPolicyApproval, JoinPolicyInviteOnly, JoinPolicyClosed:
+		return true
+	}
+	return false
+}
+
+// EffectiveJoinPolicy 加入方式，未设置时为需审批
+func (g *Group) EffectiveJoinPolicy() string {
+	if g.JoinPolicy == "" {
+		return JoinPolicyApproval
+	}
+	return g.JoinPolicy
+}
+
+// AcceptsApplications 是否接受加入申请
+func (g *Group) AcceptsApplications() bool {
+	policy := g.EffectiveJoinPolicy()
+	return policy == JoinPolicyOpen || policy == JoinPolicyApproval
+}
@@ -11,9 +11,9 @@ type JoinApplication struct {
 	Username     string    `gorm:"column:username;type:varchar(50);not null;comment:申请用户名" json:"username"`
 	Reason       string    `gorm:"column:reason;type:varchar(512);not null;comment:申请理由" json:"reason"`
 	RejectReason string    `gorm:"column:reject_reason;type:varchar(512);comment:拒绝理由" json:"reject_reason"`
-	Status       string    `gorm:"column:status;type:enum('pending','accepted','rejected');default:pending;index:idx_groupid_status;comment:审核状态" json:"status"`
-	CreatedAt    time.Time `gorm:"column:created_at;type:datetime;not null;default:CURRENT_TIMESTAMP;comment:申请时间" json:"created_at"`
-	UpdatedAt    time.Time `gorm:"column:updated_at;type:datetime;not null;default:CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP;comment:更新时间" json:"updated_at"`
+	Status       string    `gorm:"column:status;type:varchar(20);check:status IN ('pending','accepted','rejected');default:pending;index:idx_groupid_status;comment:审核状态" json:"status"`
+	CreatedAt    time.Time `gorm:"column:created_at;not null;default:CURRENT_TIMESTAMP;comment:申请时间" json:"created_at"`
+	UpdatedAt    time.Time `gorm:"column:updated_at;not null;default:CURRENT_TIMESTAMP;comment:更新时间" json:"updated_at"`
 }
 
 func (JoinApplication) TableName() string {
@@ -0,0 +1,24 @@
+package models
+
+import (
+	"time"
+)
+
+const (
+	LoginAttemptScopeUsername = "username"
+	LoginAttemptScopeIP       = "ip"
+)
+
+// LoginAttempt 登录失败计数，按用户名与客户端IP分别记录，保存在数据库中供多个实例共享
+type LoginAttempt struct {
+	ID            int        `gorm:"primaryKey;column:id;type:int;not null;autoIncrement" json:"id"`
+	Scope         string     `gorm:"column:scope;type:varchar(16);not null;uniqueIndex:idx_loginattempt_scope_subject,priority:1;comment:计数维度，username或ip" json:"scope"`
+	Subject       string     `gorm:"column:subject;type:varchar(64);not null;uniqueIndex:idx_loginattempt_scope_subject,priority:2;comment:用户名或IP" json:"subject"`
+	Failures      int        `gorm:"column:failures;type:int;not null;default:0;comment:连续失败次数" json:"failures"`
+	LastFailureAt time.Time  `gorm:"column:last_failure_at;not null;index:idx_loginattempt_lastfailureat;comment:最近一次失败时间" json:"last_failure_at"`
+	BlockedUntil  *time.Time `gorm:"column:blocked_until;comment:在此之前拒绝登录" json:"blocked_until"`
+}
+
+func (LoginAttempt) TableName() string {
+	return "login_attempts"
+}
@@ -0,0 +1,35 @@
+package models
+
+import (
+	"time"
+)
+
+// UserIdentity 外部身份提供方的账号（issuer + subject）与本地用户的关联
+type UserIdentity struct {
+	ID        int       `gorm:"primaryKey;column:id;type:int;not null;autoIncrement" json:"id"`
+	UserID    int       `gorm:"column:user_id;type:int;not null;index:idx_useridentity_userid;comment:用户ID" json:"user_id"`
+	Issuer    string    `gorm:"column:issuer;type:varchar(255);not null;uniqueIndex:idx_useridentity_issuer_subject,priority:1;comment:身份提供方" json:"issuer"`
+	Subject   string    `gorm:"column:subject;type:varchar(255);not null;uniqueIndex:idx_useridentity_issuer_subject,priority:2;comment:身份提供方中的用户标识" json:"subject"`
+	CreatedAt time.Time `gorm:"column:created_at;not null;default:CURRENT_TIMESTAMP;comment:创建时间" json:"created_at"`
+}
+
+func (UserIdentity) TableName() string {
+	return "user_identities"
+}
+
+// OIDCLoginState 发起授权时保存的 state，回调时取出 PKCE code_verifier 和 nonce，只能使用一次
+type OIDCLoginState struct {
+	ID           int       `gorm:"primaryKey;column:id;type:int;not null;autoIncrement" json:"id"`
+	StateHash    string    `gorm:"column:state_hash;type:varchar(64);not null;uniqueIndex:idx_oidcstate_statehash;comment:state摘要" json:"-"`
+	CodeVerifier string    `gorm:"column:code_verifier;type:varchar(128);not null;comment:PKCE code_verifier" json:"-"`
+	Nonce        string    `gorm:"column:nonce;type:varchar(64);not null;comment:ID Token中应携带的nonce" json:"-"`
+	LinkUserID   int       `gorm:"column:link_user_id;type:int;not null;default:0;comment:非0时为该用户关联身份" json:"link_user_id"`
+	DeviceID     string    `gorm:"column:device_id;type:varchar(128);not null;default:'';comment:登录时提交的设备ID" json:"device_id"`
+	DeviceName   string    `gorm:"column:device_name;type:varchar(128);not null;default:'';comment:设备名称" json:"device_name"`
+	ExpiresAt    time.Time `gorm:"column:expires_at;not null;index:idx_oidcstate_expiresat;comment:过期时间" json:"expires_at"`
+	CreatedAt    time.Time `gorm:"column:created_at;not null;default:CURRENT_TIMESTAMP;comment:创建时间" json:"created_at"`
+}
+
+func (OIDCLoginState) TableName() string {
+	return "oidc_login_states"
+}
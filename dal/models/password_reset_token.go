@@ -0,0 +1,19 @@
+package models
+
+import (
+	"time"
+)
+
+// PasswordResetToken 自助重置密码令牌，仅保存令牌的SHA-256摘要，使用一次后失效
+type PasswordResetToken struct {
+	ID        int        `gorm:"primaryKey;column:id;type:int;not null;autoIncrement" json:"id"`
+	UserID    int        `gorm:"column:user_id;type:int;not null;index:idx_pwreset_userid;comment:用户ID" json:"user_id"`
+	TokenHash string     `gorm:"column:token_hash;type:varchar(64);not null;uniqueIndex:idx_pwreset_tokenhash;comment:令牌摘要" json:"-"`
+	ExpiresAt time.Time  `gorm:"column:expires_at;not null;comment:过期时间" json:"expires_at"`
+	UsedAt    *time.Time `gorm:"column:used_at;comment:使用或作废时间，非空表示已失效" json:"used_at"`
+	CreatedAt time.Time  `gorm:"column:created_at;not null;default:CURRENT_TIMESTAMP;comment:创建时间" json:"created_at"`
+}
+
+func (PasswordResetToken) TableName() string {
+	return "password_reset_tokens"
+}
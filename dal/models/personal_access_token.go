@@ -0,0 +1,27 @@
+package models
+
+import (
+	"time"
+)
+
+// PersonalAccessToken 个人访问令牌，用于脚本等自动化调用，仅保存令牌摘要
+type PersonalAccessToken struct {
+	ID     int    `gorm:"primaryKey;column:id;type:int;not null;autoIncrement" json:"id"`
+	UserID int    `gorm:"column:user_id;type:int;not null;index:idx_pat_userid;comment:用户ID" json:"user_id"`
+	Name   string `gorm:"column:name;type:varchar(64);not null;comment:令牌名称" json:"name"`
+	// TokenHash 令牌的SHA-256摘要
+	TokenHash string `gorm:"column:token_hash;type:varchar(64);not null;uniqueIndex:idx_pat_tokenhash;comment:令牌摘要" json:"-"`
+	// TokenHint 令牌的末尾几位，便于用户辨认
+	TokenHint string `gorm:"column:token_hint;type:varchar(16);not null;comment:令牌末尾字符" json:"token_hint"`
+	// Scopes 以空格分隔的权限范围
+	Scopes     string     `gorm:"column:scopes;type:varchar(512);not null;comment:权限范围" json:"scopes"`
+	ExpiresAt  time.Time  `gorm:"column:expires_at;not null;comment:过期时间" json:"expires_at"`
+	LastUsedAt *time.Time `gorm:"column:last_used_at;comment:最近使用时间" json:"last_used_at"`
+	LastUsedIP string     `gorm:"column:last_used_ip;type:varchar(64);not null;default:'';comment:最近使用的IP" json:"last_used_ip"`
+	RevokedAt  *time.Time `gorm:"column:revoked_at;comment:吊销时间" json:"revoked_at"`
+	CreatedAt  time.Time  `gorm:"column:created_at;not null;default:CURRENT_TIMESTAMP;comment:创建时间" json:"created_at"`
+}
+
+func (PersonalAccessToken) TableName() string {
+	return "personal_access_tokens"
+}
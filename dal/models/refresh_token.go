@@ -0,0 +1,24 @@
+package models
+
+import (
+	"time"
+)
+
+// RefreshToken 刷新令牌，仅保存令牌的SHA-256摘要
+// 同一次登录轮换产生的令牌属于同一个家族（FamilyID），检测到重复使用时整个家族被吊销
+type RefreshToken struct {
+	ID         int        `gorm:"primaryKey;column:id;type:int;not null;autoIncrement" json:"id"`
+	UserID     int        `gorm:"column:user_id;type:int;not null;index:idx_refresh_userid;comment:用户ID" json:"user_id"`
+	FamilyID   string     `gorm:"column:family_id;type:varchar(64);not null;index:idx_refresh_familyid;comment:令牌家族ID" json:"family_id"`
+	TokenHash  string     `gorm:"column:token_hash;type:varchar(64);not null;uniqueIndex:idx_refresh_tokenhash;comment:令牌摘要" json:"-"`
+	DeviceID   string     `gorm:"column:device_id;type:varchar(128);not null;default:'';comment:绑定的设备ID" json:"device_id"`
+	DeviceName string     `gorm:"column:device_name;type:varchar(128);not null;default:'';comment:设备名称" json:"device_name"`
+	ExpiresAt  time.Time  `gorm:"column:expires_at;not null;comment:过期时间" json:"expires_at"`
+	UsedAt     *time.Time `gorm:"column:used_at;comment:轮换时间，非空表示已使用" json:"used_at"`
+	RevokedAt  *time.Time `gorm:"column:revoked_at;comment:吊销时间" json:"revoked_at"`
+	CreatedAt  time.Time  `gorm:"column:created_at;not null;default:CURRENT_TIMESTAMP;comment:创建时间" json:"created_at"`
+}
+
+func (RefreshToken) TableName() string {
+	return "refresh_tokens"
+}
@@ -0,0 +1,37 @@
+package models
+
+import (
+	"time"
+)
+
+// 吊销记录的类型
+const (
+	RevocationKindToken   = "token"
+	RevocationKindSession = "session"
+)
+
+// TokenRevocation 被吊销的访问令牌（按jti）或登录会话（按sid）
+// 访问令牌过期后记录不再有意义，ExpiresAt 之后可被清理
+type TokenRevocation struct {
+	ID        int       `gorm:"primaryKey;column:id;type:int;not null;autoIncrement" json:"id"`
+	Kind      string    `gorm:"column:kind;type:varchar(16);not null;uniqueIndex:idx_revocation_kind_value,priority:1;comment:吊销类型token或session" json:"kind"`
+	Value     string    `gorm:"column:value;type:varchar(64);not null;uniqueIndex:idx_revocation_kind_value,priority:2;comment:jti或sid" json:"value"`
+	UserID    int       `gorm:"column:user_id;type:int;not null;comment:用户ID" json:"user_id"`
+	ExpiresAt time.Time `gorm:"column:expires_at;not null;index:idx_revocation_expiresat;comment:记录过期时间" json:"expires_at"`
+	RevokedAt time.Time `gorm:"column:revoked_at;not null;index:idx_revocation_revokedat;comment:吊销时间" json:"revoked_at"`
+}
+
+func (TokenRevocation) TableName() string {
+	return "token_revocations"
+}
+
+// UserTokenCutoff 用户级吊销：签发时间早于 RevokedBefore 的访问令牌全部失效
+// 以毫秒时间戳保存，MySQL 的 DATETIME 只精确到秒，无法区分同一秒内签发的新旧令牌
+type UserTokenCutoff struct {
+	UserID        int   `gorm:"primaryKey;column:user_id;type:int;not null;autoIncrement:false" json:"user_id"`
+	RevokedBefore int64 `gorm:"column:revoked_before;type:bigint;not null;index:idx_cutoff_revokedbefore;comment:毫秒时间戳" json:"revoked_before"`
+}
+
+func (UserTokenCutoff) TableName() string {
+	return "user_token_cutoffs"
+}
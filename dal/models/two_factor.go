@@ -0,0 +1,48 @@
+package models
+
+import (
+	"time"
+)
+
+// TOTPSecret 用户的TOTP密钥，ConfirmedAt 为空表示已生成但尚未用验证码确认绑定
+type TOTPSecret struct {
+	UserID       int        `gorm:"primaryKey;column:user_id;type:int;not null;autoIncrement:false" json:"user_id"`
+	Secret       string     `gorm:"column:secret;type:varchar(64);not null;comment:Base32编码的密钥" json:"-"`
+	ConfirmedAt  *time.Time `gorm:"column:confirmed_at;comment:绑定确认时间" json:"confirmed_at"`
+	LastUsedStep int64      `gorm:"column:last_used_step;type:bigint;not null;default:0;comment:最近一次使用的时间步，防止重放" json:"-"`
+	CreatedAt    time.Time  `gorm:"column:created_at;not null;default:CURRENT_TIMESTAMP;comment:创建时间" json:"created_at"`
+}
+
+func (TOTPSecret) TableName() string {
+	return "user_totp_secrets"
+}
+
+// RecoveryCode 两步验证恢复码，仅保存SHA-256摘要，使用一次后失效
+type RecoveryCode struct {
+	ID        int        `gorm:"primaryKey;column:id;type:int;not null;autoIncrement" json:"id"`
+	UserID    int        `gorm:"column:user_id;type:int;not null;index:idx_recoverycode_userid;comment:用户ID" json:"user_id"`
+	CodeHash  string     `gorm:"column:code_hash;type:varchar(64);not null;comment:恢复码摘要" json:"-"`
+	UsedAt    *time.Time `gorm:"column:used_at;comment:使用时间" json:"used_at"`
+	CreatedAt time.Time  `gorm:"column:created_at;not null;default:CURRENT_TIMESTAMP;comment:创建时间" json:"created_at"`
+}
+
+func (RecoveryCode) TableName() string {
+	return "totp_recovery_codes"
+}
+
+// MFAChallenge 两步登录的挑战，密码校验通过后签发，提交验证码时换取令牌
+type MFAChallenge struct {
+	ID         int        `gorm:"primaryKey;column:id;type:int;not null;autoIncrement" json:"id"`
+	UserID     int        `gorm:"column:user_id;type:int;not null;comment:用户ID" json:"user_id"`
+	TokenHash  string     `gorm:"column:token_hash;type:varchar(64);not null;uniqueIndex:idx_mfachallenge_tokenhash;comment:挑战令牌摘要" json:"-"`
+	DeviceID   string     `gorm:"column:device_id;type:varchar(128);not null;default:'';comment:登录时提交的设备ID" json:"device_id"`
+	DeviceName string     `gorm:"column:device_name;type:varchar(128);not null;default:'';comment:设备名称" json:"device_name"`
+	Attempts   int        `gorm:"column:attempts;type:int;not null;default:0;comment:验证码错误次数" json:"attempts"`
+	ExpiresAt  time.Time  `gorm:"column:expires_at;not null;index:idx_mfachallenge_expiresat;comment:过期时间" json:"expires_at"`
+	UsedAt     *time.Time `gorm:"column:used_at;comment:完成时间" json:"used_at"`
+	CreatedAt  time.Time  `gorm:"column:created_at;not null;default:CURRENT_TIMESTAMP;comment:创建时间" json:"created_at"`
+}
+
+func (MFAChallenge) TableName() string {
+	return "mfa_challenges"
+}
@@ -0,0 +1,27 @@
+package models
+
+import (
+	"time"
+)
+
+// UserSession 登录会话，一次登录对应一个会话，SessionID 即访问令牌中的 sid 与刷新令牌家族ID
+type UserSession struct {
+	ID         int    `gorm:"primaryKey;column:id;type:int;not null;autoIncrement" json:"id"`
+	UserID     int    `gorm:"column:user_id;type:int;not null;index:idx_session_userid;comment:用户ID" json:"user_id"`
+	SessionID  string `gorm:"column:session_id;type:varchar(64);not null;uniqueIndex:idx_session_sessionid;comment:会话ID" json:"-"`
+	DeviceID   string `gorm:"column:device_id;type:varchar(128);not null;default:'';comment:设备ID" json:"device_id"`
+	DeviceName string `gorm:"column:device_name;type:varchar(128);not null;default:'';comment:设备名称" json:"device_name"`
+	UserAgent  string `gorm:"column:user_agent;type:varchar(255);not null;default:'';comment:登录时的User-Agent" json:"user_agent"`
+	// IP 登录时的客户端IP
+	IP         string    `gorm:"column:ip;type:varchar(64);not null;default:'';comment:登录IP" json:"ip"`
+	LastSeenAt time.Time `gorm:"column:last_seen_at;not null;comment:最近活跃时间" json:"last_seen_at"`
+	LastSeenIP string    `gorm:"column:last_seen_ip;type:varchar(64);not null;default:'';comment:最近活跃IP" json:"last_seen_ip"`
+	// ExpiresAt 会话内最新刷新令牌的过期时间，每次刷新后顺延
+	ExpiresAt time.Time  `gorm:"column:expires_at;not null;comment:过期时间" json:"expires_at"`
+	RevokedAt *time.Time `gorm:"column:revoked_at;comment:吊销时间" json:"revoked_at"`
+	CreatedAt time.Time  `gorm:"column:created_at;not null;default:CURRENT_TIMESTAMP;comment:创建时间" json:"created_at"`
+}
+
+func (UserSession) TableName() string {
+	return "user_sessions"
+}
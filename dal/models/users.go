@@ -4,12 +4,40 @@ import (
 	"time"
 )
 
+const (
+	PlatformRoleUser       = "user"
+	PlatformRoleSuperAdmin = "super_admin"
+)
+
 type User struct {
 	UserID    int       `gorm:"primaryKey;column:user_id;type:int;not null;autoIncrement" json:"user_id"`
 	Username  string    `gorm:"column:username;type:varchar(50);not null;uniqueIndex:idx_username;comment:用户名" json:"username"`
 	Password  string    `gorm:"column:password;type:varchar(128);not null;comment:密码，加密存储" json:"-"`
-	CreatedAt time.Time `gorm:"column:created_at;type:datetime;not null;default:CURRENT_TIMESTAMP;comment:创建时间" json:"created_at"`
-	UpdatedAt time.Time `gorm:"column:updated_at;type:datetime;not null;default:CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP;comment:更新时间" json:"updated_at"`
+	CreatedAt time.Time `gorm:"column:created_at;not null;default:CURRENT_TIMESTAMP;comment:创建时间" json:"created_at"`
+	UpdatedAt time.Time `gorm:"column:updated_at;not null;default:CURRENT_TIMESTAMP;comment:更新时间" json:"updated_at"`
+
+	// TwoFactorEnabled 已完成TOTP绑定，登录需要第二步验证
+	TwoFactorEnabled bool `gorm:"column:two_factor_enabled;not null;default:false;comment:是否开启两步验证" json:"two_factor_enabled"`
+
+	// 个人资料，均可为空；DisplayName 与 AvatarURL 对同组成员公开
+	DisplayName   string `gorm:"column:display_name;type:varchar(50);not null;default:'';comment:昵称" json:"display_name"`
+	RealName      string `gorm:"column:real_name;type:varchar(50);not null;default:'';comment:真实姓名" json:"real_name"`
+	StudentNumber string `gorm:"column:student_number;type:varchar(32);not null;default:'';comment:学号或工号" json:"student_number"`
+	Email         string `gorm:"column:email;type:varchar(128);not null;default:'';comment:邮箱" json:"email"`
+	Phone         string `gorm:"column:phone;type:varchar(32);not null;default:'';comment:手机号" json:"phone"`
+	AvatarURL     string `gorm:"column:avatar_url;type:varchar(512);not null;default:'';comment:头像地址" json:"avatar_url"`
+
+	// 隐私设置：是否向所在用户组的管理员展示对应字段
+	ShareRealName      bool `gorm:"column:share_real_name;not null;default:true;comment:向组管理员展示真实姓名" json:"share_real_name"`
+	ShareStudentNumber bool `gorm:"column:share_student_number;not null;default:true;comment:向组管理员展示学号或工号" json:"share_student_number"`
+	ShareEmail         bool `gorm:"column:share_email;not null;default:false;comment:向组管理员展示邮箱" json:"share_email"`
+	SharePhone         bool `gorm:"column:share_phone;not null;default:false;comment:向组管理员展示手机号" json:"share_phone"`
+
+	// PlatformRole 平台级角色，与用户组内的角色无关
+	PlatformRole string `gorm:"column:platform_role;type:varchar(20);not null;default:'user';comment:平台角色，user或super_admin" json:"platform_role"`
+	// DisabledAt 被平台管理员停用的时间，停用的账号不能登录，已签发的令牌全部失效
+	DisabledAt     *time.Time `gorm:"column:disabled_at;comment:停用时间" json:"disabled_at"`
+	DisabledReason string     `gorm:"column:disabled_reason;type:varchar(255);not null;default:'';comment:停用原因" json:"disabled_reason"`
 }
 
 func (User) TableName() string {
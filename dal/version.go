@@ -0,0 +1,55 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// 用户组层级查询使用 WITH RECURSIVE，MySQL 自 8.0 起支持，MariaDB 自 10.2.2 起支持
+var (
+	minMySQLVersion   = [3]int{8, 0, 0}
+	minMariaDBVersion = [3]int{10, 2, 2}
+)
+
+var serverVersionPattern = regexp.MustCompile(`^(\d+)\.(\d+)\.(\d+)`)
+
+// checkServerVersion 校验MySQL服务器版本支持递归CTE，其它驱动不做检查
+func checkServerVersion(ctx context.Context, db *gorm.DB, driver string) error {
+	if driver != DriverMySQL {
+		return nil
+	}
+	var version string
+	if err := db.WithContext(ctx).Raw("SELECT VERSION()").Scan(&version).Error; err != nil {
+		return fmt.Errorf("query server version: %w", err)
+	}
+	matches := serverVersionPattern.FindStringSubmatch(version)
+	if matches == nil {
+		return fmt.Errorf("unrecognized server version %q", version)
+	}
+	var current [3]int
+	for i := range current {
+		current[i], _ = strconv.Atoi(matches[i+1])
+	}
+	minimum, product := minMySQLVersion, "MySQL"
+	if strings.Contains(strings.ToLower(version), "mariadb") {
+		minimum, product = minMariaDBVersion, "MariaDB"
+	}
+	if compareVersions(current, minimum) < 0 {
+		return fmt.Errorf("%s %s is not supported, version %d.%d.%d or later is required", product, version, minimum[0], minimum[1], minimum[2])
+	}
+	return nil
+}
+
+func compareVersions(a, b [3]int) int {
+	for i := range a {
+		if a[i] != b[i] {
+			return a[i] - b[i]
+		}
+	}
+	return 0
+}
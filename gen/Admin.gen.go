@@ -0,0 +1,1260 @@
+// Package gen provides primitives to interact with the openapi HTTP API.
+//
+// Code generated by github.com/oapi-codegen/oapi-codegen/v2 version v2.4.1 DO NOT EDIT.
+package gen
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/oapi-codegen/runtime"
+	strictgin "github.com/oapi-codegen/runtime/strictmiddleware/gin"
+)
+
+// AdminServerInterface 代表所有服务器处理程序。
+type AdminServerInterface interface {
+	// 查询管理操作日志
+	// (GET /admin/actions)
+	GetAdminActions(c *gin.Context, params GetAdminActionsParams)
+	// 查询用户组
+	// (GET /admin/groups)
+	GetAdminGroups(c *gin.Context, params GetAdminGroupsParams)
+	// 强制删除用户组
+	// (DELETE /admin/groups/{groupId})
+	DeleteAdminGroupsGroupId(c *gin.Context, groupId int, params DeleteAdminGroupsGroupIdParams)
+	// 解除登录锁定
+	// (POST /admin/login-lockouts/unlock)
+	PostAdminLoginLockoutsUnlock(c *gin.Context)
+	// 平台概况
+	// (GET /admin/stats)
+	GetAdminStats(c *gin.Context)
+	// 查询用户
+	// (GET /admin/users)
+	GetAdminUsers(c *gin.Context, params GetAdminUsersParams)
+	// 停用账号
+	// (POST /admin/users/{userId}/disable)
+	PostAdminUsersUserIdDisable(c *gin.Context, userId int)
+	// 恢复账号
+	// (POST /admin/users/{userId}/enable)
+	PostAdminUsersUserIdEnable(c *gin.Context, userId int)
+	// 设置平台角色
+	// (PUT /admin/users/{userId}/platform-role)
+	PutAdminUsersUserIdPlatformRole(c *gin.Context, userId int)
+}
+
+// AdminServerInterfaceWrapper 将上下文转换为参数。
+type AdminServerInterfaceWrapper struct {
+	Handler            AdminServerInterface
+	HandlerMiddlewares []AdminMiddlewareFunc
+	ErrorHandler       func(*gin.Context, error, int)
+}
+
+type AdminMiddlewareFunc func(c *gin.Context)
+
+// GetAdminActions 操作中间件
+func (siw *AdminServerInterfaceWrapper) GetAdminActions(c *gin.Context) {
+
+	var err error
+
+	c.Set(JWT鉴权Scopes, []string{})
+
+	// 参数对象，我们将从上下文中解析所有参数到此对象
+	var params GetAdminActionsParams
+
+	// ------------- 可选查询参数 "actorId" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "actorId", c.Request.URL.Query(), &params.ActorId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("参数 actorId 格式无效: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- 可选查询参数 "action" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "action", c.Request.URL.Query(), &params.Action)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("参数 action 格式无效: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- 可选查询参数 "targetType" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "targetType", c.Request.URL.Query(), &params.TargetType)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("参数 targetType 格式无效: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- 可选查询参数 "targetId" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "targetId", c.Request.URL.Query(), &params.TargetId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("参数 targetId 格式无效: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- 可选查询参数 "page" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "page", c.Request.URL.Query(), &params.Page)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("参数 page 格式无效: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- 可选查询参数 "pageSize" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "pageSize", c.Request.URL.Query(), &params.PageSize)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("参数 pageSize 格式无效: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetAdminActions(c, params)
+}
+
+// GetAdminGroups 操作中间件
+func (siw *AdminServerInterfaceWrapper) GetAdminGroups(c *gin.Context) {
+
+	var err error
+
+	c.Set(JWT鉴权Scopes, []string{})
+
+	// 参数对象，我们将从上下文中解析所有参数到此对象
+	var params GetAdminGroupsParams
+
+	// ------------- 可选查询参数 "q" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "q", c.Request.URL.Query(), &params.Q)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("参数 q 格式无效: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- 可选查询参数 "page" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "page", c.Request.URL.Query(), &params.Page)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("参数 page 格式无效: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- 可选查询参数 "pageSize" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "pageSize", c.Request.URL.Query(), &params.PageSize)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("参数 pageSize 格式无效: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetAdminGroups(c, params)
+}
+
+// DeleteAdminGroupsGroupId 操作中间件
+func (siw *AdminServerInterfaceWrapper) DeleteAdminGroupsGroupId(c *gin.Context) {
+
+	var err error
+
+	// ------------- 路径参数 "groupId" -------------
+	var groupId int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "groupId", c.Param("groupId"), &groupId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("参数 groupId 格式无效: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(JWT鉴权Scopes, []string{})
+
+	// 参数对象，我们将从上下文中解析所有参数到此对象
+	var params DeleteAdminGroupsGroupIdParams
+
+	// ------------- 可选查询参数 "reason" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "reason", c.Request.URL.Query(), &params.Reason)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("参数 reason 格式无效: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.DeleteAdminGroupsGroupId(c, groupId, params)
+}
+
+// PostAdminLoginLockoutsUnlock 操作中间件
+func (siw *AdminServerInterfaceWrapper) PostAdminLoginLockoutsUnlock(c *gin.Context) {
+
+	c.Set(JWT鉴权Scopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostAdminLoginLockoutsUnlock(c)
+}
+
+// GetAdminStats 操作中间件
+func (siw *AdminServerInterfaceWrapper) GetAdminStats(c *gin.Context) {
+
+	c.Set(JWT鉴权Scopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetAdminStats(c)
+}
+
+// GetAdminUsers 操作中间件
+func (siw *AdminServerInterfaceWrapper) GetAdminUsers(c *gin.Context) {
+
+	var err error
+
+	c.Set(JWT鉴权Scopes, []string{})
+
+	// 参数对象，我们将从上下文中解析所有参数到此对象
+	var params GetAdminUsersParams
+
+	// ------------- 可选查询参数 "q" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "q", c.Request.URL.Query(), &params.Q)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("参数 q 格式无效: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- 可选查询参数 "disabled" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "disabled", c.Request.URL.Query(), &params.Disabled)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("参数 disabled 格式无效: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- 可选查询参数 "page" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "page", c.Request.URL.Query(), &params.Page)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("参数 page 格式无效: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- 可选查询参数 "pageSize" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "pageSize", c.Request.URL.Query(), &params.PageSize)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("参数 pageSize 格式无效: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetAdminUsers(c, params)
+}
+
+// PostAdminUsersUserIdDisable 操作中间件
+func (siw *AdminServerInterfaceWrapper) PostAdminUsersUserIdDisable(c *gin.Context) {
+
+	var err error
+
+	// ------------- 路径参数 "userId" -------------
+	var userId int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "userId", c.Param("userId"), &userId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("参数 userId 格式无效: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(JWT鉴权Scopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostAdminUsersUserIdDisable(c, userId)
+}
+
+// PostAdminUsersUserIdEnable 操作中间件
+func (siw *AdminServerInterfaceWrapper) PostAdminUsersUserIdEnable(c *gin.Context) {
+
+	var err error
+
+	// ------------- 路径参数 "userId" -------------
+	var userId int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "userId", c.Param("userId"), &userId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("参数 userId 格式无效: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(JWT鉴权Scopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostAdminUsersUserIdEnable(c, userId)
+}
+
+// PutAdminUsersUserIdPlatformRole 操作中间件
+func (siw *AdminServerInterfaceWrapper) PutAdminUsersUserIdPlatformRole(c *gin.Context) {
+
+	var err error
+
+	// ------------- 路径参数 "userId" -------------
+	var userId int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "userId", c.Param("userId"), &userId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("参数 userId 格式无效: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(JWT鉴权Scopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PutAdminUsersUserIdPlatformRole(c, userId)
+}
+
+// AdminGinServerOptions 提供 Gin 服务器的选项。
+type AdminGinServerOptions struct {
+	BaseURL      string
+	Middlewares  []AdminMiddlewareFunc
+	ErrorHandler func(*gin.Context, error, int)
+}
+
+// RegisterAdminHandlers 创建与 OpenAPI 规范匹配的 http.Handler 路由。
+func RegisterAdminHandlers(router gin.IRouter, si AdminServerInterface) {
+	RegisterAdminHandlersWithOptions(router, si, AdminGinServerOptions{})
+}
+
+// RegisterAdminHandlersWithOptions 创建带有附加选项的 http.Handler
+func RegisterAdminHandlersWithOptions(router gin.IRouter, si AdminServerInterface, options AdminGinServerOptions) {
+	errorHandler := options.ErrorHandler
+	if errorHandler == nil {
+		errorHandler = func(c *gin.Context, err error, statusCode int) {
+			c.JSON(statusCode, gin.H{"msg": err.Error()})
+		}
+	}
+
+	wrapper := AdminServerInterfaceWrapper{
+		Handler:            si,
+		HandlerMiddlewares: options.Middlewares,
+		ErrorHandler:       errorHandler,
+	}
+
+	router.GET(options.BaseURL+"/admin/actions", wrapper.GetAdminActions)
+	router.GET(options.BaseURL+"/admin/groups", wrapper.GetAdminGroups)
+	router.DELETE(options.BaseURL+"/admin/groups/:groupId", wrapper.DeleteAdminGroupsGroupId)
+	router.POST(options.BaseURL+"/admin/login-lockouts/unlock", wrapper.PostAdminLoginLockoutsUnlock)
+	router.GET(options.BaseURL+"/admin/stats", wrapper.GetAdminStats)
+	router.GET(options.BaseURL+"/admin/users", wrapper.GetAdminUsers)
+	router.POST(options.BaseURL+"/admin/users/:userId/disable", wrapper.PostAdminUsersUserIdDisable)
+	router.POST(options.BaseURL+"/admin/users/:userId/enable", wrapper.PostAdminUsersUserIdEnable)
+	router.PUT(options.BaseURL+"/admin/users/:userId/platform-role", wrapper.PutAdminUsersUserIdPlatformRole)
+}
+
+type GetAdminActionsRequestObject struct {
+	Params GetAdminActionsParams
+}
+
+type GetAdminActionsResponseObject interface {
+	VisitGetAdminActionsResponse(w http.ResponseWriter) error
+}
+
+type GetAdminActions200JSONResponse struct {
+	Code string `json:"code"`
+	Data struct {
+		Items []AdminAction `json:"items"`
+
+		// Total 符合条件的总数
+		Total int `json:"total"`
+	} `json:"data"`
+}
+
+func (response GetAdminActions200JSONResponse) VisitGetAdminActionsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAdminActions401JSONResponse Unauthorized
+
+func (response GetAdminActions401JSONResponse) VisitGetAdminActionsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAdminActions403JSONResponse Forbidden
+
+func (response GetAdminActions403JSONResponse) VisitGetAdminActionsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAdminActions500JSONResponse InternalServerError
+
+func (response GetAdminActions500JSONResponse) VisitGetAdminActionsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAdminGroupsRequestObject struct {
+	Params GetAdminGroupsParams
+}
+
+type GetAdminGroupsResponseObject interface {
+	VisitGetAdminGroupsResponse(w http.ResponseWriter) error
+}
+
+type GetAdminGroups200JSONResponse struct {
+	Code string `json:"code"`
+	Data struct {
+		Items []Group `json:"items"`
+
+		// Total 符合条件的总数
+		Total int `json:"total"`
+	} `json:"data"`
+}
+
+func (response GetAdminGroups200JSONResponse) VisitGetAdminGroupsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAdminGroups401JSONResponse Unauthorized
+
+func (response GetAdminGroups401JSONResponse) VisitGetAdminGroupsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAdminGroups403JSONResponse Forbidden
+
+func (response GetAdminGroups403JSONResponse) VisitGetAdminGroupsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAdminGroups500JSONResponse InternalServerError
+
+func (response GetAdminGroups500JSONResponse) VisitGetAdminGroupsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteAdminGroupsGroupIdRequestObject struct {
+	GroupId int `json:"groupId"`
+	Params  DeleteAdminGroupsGroupIdParams
+}
+
+type DeleteAdminGroupsGroupIdResponseObject interface {
+	VisitDeleteAdminGroupsGroupIdResponse(w http.ResponseWriter) error
+}
+
+type DeleteAdminGroupsGroupId200JSONResponse Success
+
+func (response DeleteAdminGroupsGroupId200JSONResponse) VisitDeleteAdminGroupsGroupIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteAdminGroupsGroupId401JSONResponse Unauthorized
+
+func (response DeleteAdminGroupsGroupId401JSONResponse) VisitDeleteAdminGroupsGroupIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteAdminGroupsGroupId403JSONResponse Forbidden
+
+func (response DeleteAdminGroupsGroupId403JSONResponse) VisitDeleteAdminGroupsGroupIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteAdminGroupsGroupId404JSONResponse NotFound
+
+func (response DeleteAdminGroupsGroupId404JSONResponse) VisitDeleteAdminGroupsGroupIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteAdminGroupsGroupId500JSONResponse InternalServerError
+
+func (response DeleteAdminGroupsGroupId500JSONResponse) VisitDeleteAdminGroupsGroupIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostAdminLoginLockoutsUnlockRequestObject struct {
+	Body *PostAdminLoginLockoutsUnlockJSONRequestBody
+}
+
+type PostAdminLoginLockoutsUnlockResponseObject interface {
+	VisitPostAdminLoginLockoutsUnlockResponse(w http.ResponseWriter) error
+}
+
+type PostAdminLoginLockoutsUnlock200JSONResponse struct {
+	Code string `json:"code"`
+	Data struct {
+		// Unlocked 是否存在被清除的失败记录
+		Unlocked bool `json:"unlocked"`
+	} `json:"data"`
+}
+
+func (response PostAdminLoginLockoutsUnlock200JSONResponse) VisitPostAdminLoginLockoutsUnlockResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostAdminLoginLockoutsUnlock400JSONResponse BadRequest
+
+func (response PostAdminLoginLockoutsUnlock400JSONResponse) VisitPostAdminLoginLockoutsUnlockResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostAdminLoginLockoutsUnlock401JSONResponse Unauthorized
+
+func (response PostAdminLoginLockoutsUnlock401JSONResponse) VisitPostAdminLoginLockoutsUnlockResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostAdminLoginLockoutsUnlock403JSONResponse Forbidden
+
+func (response PostAdminLoginLockoutsUnlock403JSONResponse) VisitPostAdminLoginLockoutsUnlockResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostAdminLoginLockoutsUnlock500JSONResponse InternalServerError
+
+func (response PostAdminLoginLockoutsUnlock500JSONResponse) VisitPostAdminLoginLockoutsUnlockResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAdminStatsRequestObject struct {
+}
+
+type GetAdminStatsResponseObject interface {
+	VisitGetAdminStatsResponse(w http.ResponseWriter) error
+}
+
+type GetAdminStats200JSONResponse struct {
+	Code string `json:"code"`
+	Data struct {
+		// ActiveTasks 正在进行的签到任务数
+		ActiveTasks int `json:"activeTasks"`
+
+		// Checkins 签到记录总数
+		Checkins int `json:"checkins"`
+
+		// CheckinsLast24h 近24小时的签到记录数
+		CheckinsLast24h int `json:"checkinsLast24h"`
+
+		// DisabledUsers 已停用的用户数
+		DisabledUsers int `json:"disabledUsers"`
+
+		// Groups 用户组总数
+		Groups int `json:"groups"`
+
+		// PendingAudits 待审核的签到申请数
+		PendingAudits int `json:"pendingAudits"`
+
+		// PendingJoinRequests 待审核的入组申请数
+		PendingJoinRequests int `json:"pendingJoinRequests"`
+
+		// SuperAdmins 平台角色为 super_admin 的用户数
+		SuperAdmins int `json:"superAdmins"`
+
+		// Tasks 签到任务总数
+		Tasks int `json:"tasks"`
+
+		// Users 用户总数
+		Users int `json:"users"`
+	} `json:"data"`
+}
+
+func (response GetAdminStats200JSONResponse) VisitGetAdminStatsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAdminStats401JSONResponse Unauthorized
+
+func (response GetAdminStats401JSONResponse) VisitGetAdminStatsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAdminStats403JSONResponse Forbidden
+
+func (response GetAdminStats403JSONResponse) VisitGetAdminStatsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAdminStats500JSONResponse InternalServerError
+
+func (response GetAdminStats500JSONResponse) VisitGetAdminStatsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAdminUsersRequestObject struct {
+	Params GetAdminUsersParams
+}
+
+type GetAdminUsersResponseObject interface {
+	VisitGetAdminUsersResponse(w http.ResponseWriter) error
+}
+
+type GetAdminUsers200JSONResponse struct {
+	Code string `json:"code"`
+	Data struct {
+		Items []AdminUser `json:"items"`
+
+		// Total 符合条件的总数
+		Total int `json:"total"`
+	} `json:"data"`
+}
+
+func (response GetAdminUsers200JSONResponse) VisitGetAdminUsersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAdminUsers401JSONResponse Unauthorized
+
+func (response GetAdminUsers401JSONResponse) VisitGetAdminUsersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAdminUsers403JSONResponse Forbidden
+
+func (response GetAdminUsers403JSONResponse) VisitGetAdminUsersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAdminUsers500JSONResponse InternalServerError
+
+func (response GetAdminUsers500JSONResponse) VisitGetAdminUsersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostAdminUsersUserIdDisableRequestObject struct {
+	UserId int `json:"userId"`
+	Body   *PostAdminUsersUserIdDisableJSONRequestBody
+}
+
+type PostAdminUsersUserIdDisableResponseObject interface {
+	VisitPostAdminUsersUserIdDisableResponse(w http.ResponseWriter) error
+}
+
+type PostAdminUsersUserIdDisable200JSONResponse struct {
+	Code string    `json:"code"`
+	Data AdminUser `json:"data"`
+}
+
+func (response PostAdminUsersUserIdDisable200JSONResponse) VisitPostAdminUsersUserIdDisableResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostAdminUsersUserIdDisable400JSONResponse BadRequest
+
+func (response PostAdminUsersUserIdDisable400JSONResponse) VisitPostAdminUsersUserIdDisableResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostAdminUsersUserIdDisable401JSONResponse Unauthorized
+
+func (response PostAdminUsersUserIdDisable401JSONResponse) VisitPostAdminUsersUserIdDisableResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostAdminUsersUserIdDisable403JSONResponse Forbidden
+
+func (response PostAdminUsersUserIdDisable403JSONResponse) VisitPostAdminUsersUserIdDisableResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostAdminUsersUserIdDisable404JSONResponse NotFound
+
+func (response PostAdminUsersUserIdDisable404JSONResponse) VisitPostAdminUsersUserIdDisableResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostAdminUsersUserIdDisable409JSONResponse Conflict
+
+func (response PostAdminUsersUserIdDisable409JSONResponse) VisitPostAdminUsersUserIdDisableResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostAdminUsersUserIdDisable500JSONResponse InternalServerError
+
+func (response PostAdminUsersUserIdDisable500JSONResponse) VisitPostAdminUsersUserIdDisableResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostAdminUsersUserIdEnableRequestObject struct {
+	UserId int `json:"userId"`
+}
+
+type PostAdminUsersUserIdEnableResponseObject interface {
+	VisitPostAdminUsersUserIdEnableResponse(w http.ResponseWriter) error
+}
+
+type PostAdminUsersUserIdEnable200JSONResponse struct {
+	Code string    `json:"code"`
+	Data AdminUser `json:"data"`
+}
+
+func (response PostAdminUsersUserIdEnable200JSONResponse) VisitPostAdminUsersUserIdEnableResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostAdminUsersUserIdEnable401JSONResponse Unauthorized
+
+func (response PostAdminUsersUserIdEnable401JSONResponse) VisitPostAdminUsersUserIdEnableResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostAdminUsersUserIdEnable403JSONResponse Forbidden
+
+func (response PostAdminUsersUserIdEnable403JSONResponse) VisitPostAdminUsersUserIdEnableResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostAdminUsersUserIdEnable404JSONResponse NotFound
+
+func (response PostAdminUsersUserIdEnable404JSONResponse) VisitPostAdminUsersUserIdEnableResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostAdminUsersUserIdEnable409JSONResponse Conflict
+
+func (response PostAdminUsersUserIdEnable409JSONResponse) VisitPostAdminUsersUserIdEnableResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostAdminUsersUserIdEnable500JSONResponse InternalServerError
+
+func (response PostAdminUsersUserIdEnable500JSONResponse) VisitPostAdminUsersUserIdEnableResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutAdminUsersUserIdPlatformRoleRequestObject struct {
+	UserId int `json:"userId"`
+	Body   *PutAdminUsersUserIdPlatformRoleJSONRequestBody
+}
+
+type PutAdminUsersUserIdPlatformRoleResponseObject interface {
+	VisitPutAdminUsersUserIdPlatformRoleResponse(w http.ResponseWriter) error
+}
+
+type PutAdminUsersUserIdPlatformRole200JSONResponse struct {
+	Code string    `json:"code"`
+	Data AdminUser `json:"data"`
+}
+
+func (response PutAdminUsersUserIdPlatformRole200JSONResponse) VisitPutAdminUsersUserIdPlatformRoleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutAdminUsersUserIdPlatformRole400JSONResponse BadRequest
+
+func (response PutAdminUsersUserIdPlatformRole400JSONResponse) VisitPutAdminUsersUserIdPlatformRoleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutAdminUsersUserIdPlatformRole401JSONResponse Unauthorized
+
+func (response PutAdminUsersUserIdPlatformRole401JSONResponse) VisitPutAdminUsersUserIdPlatformRoleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutAdminUsersUserIdPlatformRole403JSONResponse Forbidden
+
+func (response PutAdminUsersUserIdPlatformRole403JSONResponse) VisitPutAdminUsersUserIdPlatformRoleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutAdminUsersUserIdPlatformRole404JSONResponse NotFound
+
+func (response PutAdminUsersUserIdPlatformRole404JSONResponse) VisitPutAdminUsersUserIdPlatformRoleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutAdminUsersUserIdPlatformRole500JSONResponse InternalServerError
+
+func (response PutAdminUsersUserIdPlatformRole500JSONResponse) VisitPutAdminUsersUserIdPlatformRoleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+// AdminStrictServerInterface represents all server handlers.
+type AdminStrictServerInterface interface {
+	// 查询管理操作日志
+	// (GET /admin/actions)
+	GetAdminActions(ctx context.Context, request GetAdminActionsRequestObject) (GetAdminActionsResponseObject, error)
+	// 查询用户组
+	// (GET /admin/groups)
+	GetAdminGroups(ctx context.Context, request GetAdminGroupsRequestObject) (GetAdminGroupsResponseObject, error)
+	// 强制删除用户组
+	// (DELETE /admin/groups/{groupId})
+	DeleteAdminGroupsGroupId(ctx context.Context, request DeleteAdminGroupsGroupIdRequestObject) (DeleteAdminGroupsGroupIdResponseObject, error)
+	// 解除登录锁定
+	// (POST /admin/login-lockouts/unlock)
+	PostAdminLoginLockoutsUnlock(ctx context.Context, request PostAdminLoginLockoutsUnlockRequestObject) (PostAdminLoginLockoutsUnlockResponseObject, error)
+	// 平台概况
+	// (GET /admin/stats)
+	GetAdminStats(ctx context.Context, request GetAdminStatsRequestObject) (GetAdminStatsResponseObject, error)
+	// 查询用户
+	// (GET /admin/users)
+	GetAdminUsers(ctx context.Context, request GetAdminUsersRequestObject) (GetAdminUsersResponseObject, error)
+	// 停用账号
+	// (POST /admin/users/{userId}/disable)
+	PostAdminUsersUserIdDisable(ctx context.Context, request PostAdminUsersUserIdDisableRequestObject) (PostAdminUsersUserIdDisableResponseObject, error)
+	// 恢复账号
+	// (POST /admin/users/{userId}/enable)
+	PostAdminUsersUserIdEnable(ctx context.Context, request PostAdminUsersUserIdEnableRequestObject) (PostAdminUsersUserIdEnableResponseObject, error)
+	// 设置平台角色
+	// (PUT /admin/users/{userId}/platform-role)
+	PutAdminUsersUserIdPlatformRole(ctx context.Context, request PutAdminUsersUserIdPlatformRoleRequestObject) (PutAdminUsersUserIdPlatformRoleResponseObject, error)
+}
+
+type AdminStrictHandlerFunc = strictgin.StrictGinHandlerFunc
+type AdminStrictMiddlewareFunc = strictgin.StrictGinMiddlewareFunc
+
+func NewAdminStrictHandler(ssi AdminStrictServerInterface, middlewares []AdminStrictMiddlewareFunc) AdminServerInterface {
+	return &AdminstrictHandler{ssi: ssi, middlewares: middlewares}
+}
+
+type AdminstrictHandler struct {
+	ssi         AdminStrictServerInterface
+	middlewares []AdminStrictMiddlewareFunc
+}
+
+// GetAdminActions 操作中间件
+func (sh *AdminstrictHandler) GetAdminActions(ctx *gin.Context, params GetAdminActionsParams) {
+	var request GetAdminActionsRequestObject
+
+	request.Params = params
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetAdminActions(ctx, request.(GetAdminActionsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetAdminActions")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(GetAdminActionsResponseObject); ok {
+		if err := validResponse.VisitGetAdminActionsResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetAdminGroups 操作中间件
+func (sh *AdminstrictHandler) GetAdminGroups(ctx *gin.Context, params GetAdminGroupsParams) {
+	var request GetAdminGroupsRequestObject
+
+	request.Params = params
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetAdminGroups(ctx, request.(GetAdminGroupsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetAdminGroups")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(GetAdminGroupsResponseObject); ok {
+		if err := validResponse.VisitGetAdminGroupsResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// DeleteAdminGroupsGroupId 操作中间件
+func (sh *AdminstrictHandler) DeleteAdminGroupsGroupId(ctx *gin.Context, groupId int, params DeleteAdminGroupsGroupIdParams) {
+	var request DeleteAdminGroupsGroupIdRequestObject
+
+	request.GroupId = groupId
+	request.Params = params
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteAdminGroupsGroupId(ctx, request.(DeleteAdminGroupsGroupIdRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteAdminGroupsGroupId")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(DeleteAdminGroupsGroupIdResponseObject); ok {
+		if err := validResponse.VisitDeleteAdminGroupsGroupIdResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PostAdminLoginLockoutsUnlock 操作中间件
+func (sh *AdminstrictHandler) PostAdminLoginLockoutsUnlock(ctx *gin.Context) {
+	var request PostAdminLoginLockoutsUnlockRequestObject
+
+	var body PostAdminLoginLockoutsUnlockJSONRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.Status(http.StatusBadRequest)
+		ctx.Error(err)
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PostAdminLoginLockoutsUnlock(ctx, request.(PostAdminLoginLockoutsUnlockRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostAdminLoginLockoutsUnlock")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(PostAdminLoginLockoutsUnlockResponseObject); ok {
+		if err := validResponse.VisitPostAdminLoginLockoutsUnlockResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetAdminStats 操作中间件
+func (sh *AdminstrictHandler) GetAdminStats(ctx *gin.Context) {
+	var request GetAdminStatsRequestObject
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetAdminStats(ctx, request.(GetAdminStatsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetAdminStats")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(GetAdminStatsResponseObject); ok {
+		if err := validResponse.VisitGetAdminStatsResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetAdminUsers 操作中间件
+func (sh *AdminstrictHandler) GetAdminUsers(ctx *gin.Context, params GetAdminUsersParams) {
+	var request GetAdminUsersRequestObject
+
+	request.Params = params
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetAdminUsers(ctx, request.(GetAdminUsersRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetAdminUsers")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(GetAdminUsersResponseObject); ok {
+		if err := validResponse.VisitGetAdminUsersResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PostAdminUsersUserIdDisable 操作中间件
+func (sh *AdminstrictHandler) PostAdminUsersUserIdDisable(ctx *gin.Context, userId int) {
+	var request PostAdminUsersUserIdDisableRequestObject
+
+	request.UserId = userId
+
+	var body PostAdminUsersUserIdDisableJSONRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.Status(http.StatusBadRequest)
+		ctx.Error(err)
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PostAdminUsersUserIdDisable(ctx, request.(PostAdminUsersUserIdDisableRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostAdminUsersUserIdDisable")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(PostAdminUsersUserIdDisableResponseObject); ok {
+		if err := validResponse.VisitPostAdminUsersUserIdDisableResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PostAdminUsersUserIdEnable 操作中间件
+func (sh *AdminstrictHandler) PostAdminUsersUserIdEnable(ctx *gin.Context, userId int) {
+	var request PostAdminUsersUserIdEnableRequestObject
+
+	request.UserId = userId
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PostAdminUsersUserIdEnable(ctx, request.(PostAdminUsersUserIdEnableRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostAdminUsersUserIdEnable")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(PostAdminUsersUserIdEnableResponseObject); ok {
+		if err := validResponse.VisitPostAdminUsersUserIdEnableResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PutAdminUsersUserIdPlatformRole 操作中间件
+func (sh *AdminstrictHandler) PutAdminUsersUserIdPlatformRole(ctx *gin.Context, userId int) {
+	var request PutAdminUsersUserIdPlatformRoleRequestObject
+
+	request.UserId = userId
+
+	var body PutAdminUsersUserIdPlatformRoleJSONRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.Status(http.StatusBadRequest)
+		ctx.Error(err)
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PutAdminUsersUserIdPlatformRole(ctx, request.(PutAdminUsersUserIdPlatformRoleRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PutAdminUsersUserIdPlatformRole")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(PutAdminUsersUserIdPlatformRoleResponseObject); ok {
+		if err := validResponse.VisitPutAdminUsersUserIdPlatformRoleResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
@@ -18,6 +18,27 @@ type AuthServerInterface interface {
 	// 用户登录
 	// (POST /auth/login)
 	PostAuthLogin(c *gin.Context)
+	// 两步登录
+	// (POST /auth/login/2fa)
+	PostAuthLogin2fa(c *gin.Context)
+	// 退出登录
+	// (POST /auth/logout)
+	PostAuthLogout(c *gin.Context)
+	// 发起统一身份认证登录
+	// (POST /auth/oidc/authorize)
+	PostAuthOidcAuthorize(c *gin.Context)
+	// 完成统一身份认证登录
+	// (POST /auth/oidc/callback)
+	PostAuthOidcCallback(c *gin.Context)
+	// 申请重置密码
+	// (POST /auth/password-reset)
+	PostAuthPasswordReset(c *gin.Context)
+	// 使用重置令牌设置新密码
+	// (POST /auth/password-reset/confirm)
+	PostAuthPasswordResetConfirm(c *gin.Context)
+	// 刷新访问令牌
+	// (POST /auth/refresh)
+	PostAuthRefresh(c *gin.Context)
 	// 用户注册
 	// (POST /auth/register)
 	PostAuthRegister(c *gin.Context)
@@ -45,100 +66,652 @@ func (siw *AuthServerInterfaceWrapper) PostAuthLogin(c *gin.Context) {
 	siw.Handler.PostAuthLogin(c)
 }
 
+// PostAuthLogin2fa 操作中间件
+func (siw *AuthServerInterfaceWrapper) PostAuthLogin2fa(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostAuthLogin2fa(c)
+}
+
+// PostAuthLogout 操作中间件
+func (siw *AuthServerInterfaceWrapper) PostAuthLogout(c *gin.Context) {
+
+	c.Set(JWT鉴权Scopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostAuthLogout(c)
+}
+
+// PostAuthOidcAuthorize 操作中间件
+func (siw *AuthServerInterfaceWrapper) PostAuthOidcAuthorize(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostAuthOidcAuthorize(c)
+}
+
+// PostAuthOidcCallback 操作中间件
+func (siw *AuthServerInterfaceWrapper) PostAuthOidcCallback(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostAuthOidcCallback(c)
+}
+
+// PostAuthPasswordReset 操作中间件
+func (siw *AuthServerInterfaceWrapper) PostAuthPasswordReset(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostAuthPasswordReset(c)
+}
+
+// PostAuthPasswordResetConfirm 操作中间件
+func (siw *AuthServerInterfaceWrapper) PostAuthPasswordResetConfirm(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostAuthPasswordResetConfirm(c)
+}
+
+// PostAuthRefresh 操作中间件
+func (siw *AuthServerInterfaceWrapper) PostAuthRefresh(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostAuthRefresh(c)
+}
+
 // PostAuthRegister 操作中间件
 func (siw *AuthServerInterfaceWrapper) PostAuthRegister(c *gin.Context) {
 
-	for _, middleware := range siw.HandlerMiddlewares {
-		middleware(c)
-		if c.IsAborted() {
-			return
-		}
-	}
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostAuthRegister(c)
+}
+
+// AuthGinServerOptions 提供 Gin 服务器的选项。
+type AuthGinServerOptions struct {
+	BaseURL      string
+	Middlewares  []AuthMiddlewareFunc
+	ErrorHandler func(*gin.Context, error, int)
+}
+
+// RegisterAuthHandlers 创建与 OpenAPI 规范匹配的 http.Handler 路由。
+func RegisterAuthHandlers(router gin.IRouter, si AuthServerInterface) {
+	RegisterAuthHandlersWithOptions(router, si, AuthGinServerOptions{})
+}
+
+// RegisterAuthHandlersWithOptions 创建带有附加选项的 http.Handler
+func RegisterAuthHandlersWithOptions(router gin.IRouter, si AuthServerInterface, options AuthGinServerOptions) {
+	errorHandler := options.ErrorHandler
+	if errorHandler == nil {
+		errorHandler = func(c *gin.Context, err error, statusCode int) {
+			c.JSON(statusCode, gin.H{"msg": err.Error()})
+		}
+	}
+
+	wrapper := AuthServerInterfaceWrapper{
+		Handler:            si,
+		HandlerMiddlewares: options.Middlewares,
+		ErrorHandler:       errorHandler,
+	}
+
+	router.POST(options.BaseURL+"/auth/login", wrapper.PostAuthLogin)
+	router.POST(options.BaseURL+"/auth/login/2fa", wrapper.PostAuthLogin2fa)
+	router.POST(options.BaseURL+"/auth/logout", wrapper.PostAuthLogout)
+	router.POST(options.BaseURL+"/auth/oidc/authorize", wrapper.PostAuthOidcAuthorize)
+	router.POST(options.BaseURL+"/auth/oidc/callback", wrapper.PostAuthOidcCallback)
+	router.POST(options.BaseURL+"/auth/password-reset", wrapper.PostAuthPasswordReset)
+	router.POST(options.BaseURL+"/auth/password-reset/confirm", wrapper.PostAuthPasswordResetConfirm)
+	router.POST(options.BaseURL+"/auth/refresh", wrapper.PostAuthRefresh)
+	router.POST(options.BaseURL+"/auth/register", wrapper.PostAuthRegister)
+}
+
+type PostAuthLoginRequestObject struct {
+	Body *PostAuthLoginJSONRequestBody
+}
+
+type PostAuthLoginResponseObject interface {
+	VisitPostAuthLoginResponse(w http.ResponseWriter) error
+}
+
+type PostAuthLogin200JSONResponse struct {
+	Code string `json:"code"`
+	Data struct {
+		// ExpiresIn 访问令牌有效期（秒）
+		ExpiresIn int `json:"expiresIn,omitempty"`
+
+		// MfaRequired 为true时需调用 /auth/login/2fa 完成第二步登录，此时不返回令牌
+		MfaRequired bool `json:"mfaRequired,omitempty"`
+
+		// MfaToken 两步登录挑战令牌，仅在 mfaRequired 为true时返回，短时间内有效且只能使用一次
+		MfaToken string `json:"mfaToken,omitempty"`
+
+		// RefreshToken 刷新令牌，用于调用 /auth/refresh 换取新的访问令牌，每次使用后轮换
+		RefreshToken string `json:"refreshToken,omitempty"`
+
+		// Token JWT 令牌
+		Token string `json:"token,omitempty"`
+
+		// UserId 用户ID
+		UserId int `json:"userId,omitempty"`
+
+		// Username 用户名
+		Username string `json:"username,omitempty"`
+	} `json:"data"`
+}
+
+func (response PostAuthLogin200JSONResponse) VisitPostAuthLoginResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostAuthLogin400JSONResponse BadRequest
+
+func (response PostAuthLogin400JSONResponse) VisitPostAuthLoginResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostAuthLogin401JSONResponse Unauthorized
+
+func (response PostAuthLogin401JSONResponse) VisitPostAuthLoginResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostAuthLogin403JSONResponse Forbidden
+
+func (response PostAuthLogin403JSONResponse) VisitPostAuthLoginResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostAuthLogin429ResponseHeaders struct {
+	RetryAfter int
+}
+
+type PostAuthLogin429JSONResponse struct {
+	Body    Error
+	Headers PostAuthLogin429ResponseHeaders
+}
+
+func (response PostAuthLogin429JSONResponse) VisitPostAuthLoginResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type PostAuthLogin500JSONResponse InternalServerError
+
+func (response PostAuthLogin500JSONResponse) VisitPostAuthLoginResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostAuthLogin2faRequestObject struct {
+	Body *PostAuthLogin2faJSONRequestBody
+}
+
+type PostAuthLogin2faResponseObject interface {
+	VisitPostAuthLogin2faResponse(w http.ResponseWriter) error
+}
+
+type PostAuthLogin2fa200JSONResponse struct {
+	Code string `json:"code"`
+	Data struct {
+		// ExpiresIn 访问令牌有效期（秒）
+		ExpiresIn int `json:"expiresIn,omitempty"`
+
+		// RefreshToken 刷新令牌，用于调用 /auth/refresh 换取新的访问令牌，每次使用后轮换
+		RefreshToken string `json:"refreshToken,omitempty"`
+
+		// Token JWT 令牌
+		Token string `json:"token,omitempty"`
+
+		// UserId 用户ID
+		UserId int `json:"userId,omitempty"`
+
+		// Username 用户名
+		Username string `json:"username,omitempty"`
+	} `json:"data"`
+}
+
+func (response PostAuthLogin2fa200JSONResponse) VisitPostAuthLogin2faResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostAuthLogin2fa400JSONResponse BadRequest
+
+func (response PostAuthLogin2fa400JSONResponse) VisitPostAuthLogin2faResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostAuthLogin2fa401JSONResponse Unauthorized
+
+func (response PostAuthLogin2fa401JSONResponse) VisitPostAuthLogin2faResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostAuthLogin2fa429ResponseHeaders struct {
+	RetryAfter int
+}
+
+type PostAuthLogin2fa429JSONResponse struct {
+	Body    Error
+	Headers PostAuthLogin2fa429ResponseHeaders
+}
+
+func (response PostAuthLogin2fa429JSONResponse) VisitPostAuthLogin2faResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type PostAuthLogin2fa500JSONResponse InternalServerError
+
+func (response PostAuthLogin2fa500JSONResponse) VisitPostAuthLogin2faResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostAuthLogoutRequestObject struct {
+}
+
+type PostAuthLogoutResponseObject interface {
+	VisitPostAuthLogoutResponse(w http.ResponseWriter) error
+}
+
+type PostAuthLogout200JSONResponse Success
+
+func (response PostAuthLogout200JSONResponse) VisitPostAuthLogoutResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostAuthLogout401JSONResponse Unauthorized
+
+func (response PostAuthLogout401JSONResponse) VisitPostAuthLogoutResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostAuthLogout500JSONResponse InternalServerError
+
+func (response PostAuthLogout500JSONResponse) VisitPostAuthLogoutResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostAuthOidcAuthorizeRequestObject struct {
+	Body *PostAuthOidcAuthorizeJSONRequestBody
+}
+
+type PostAuthOidcAuthorizeResponseObject interface {
+	VisitPostAuthOidcAuthorizeResponse(w http.ResponseWriter) error
+}
+
+type PostAuthOidcAuthorize200JSONResponse struct {
+	Code string `json:"code"`
+	Data struct {
+		// AuthorizationUrl 身份提供方的授权地址，前端将浏览器跳转到该地址
+		AuthorizationUrl string `json:"authorizationUrl"`
+
+		// State 本次授权请求的state，回调页面应校验身份提供方返回的state与之一致
+		State string `json:"state"`
+	} `json:"data"`
+}
+
+func (response PostAuthOidcAuthorize200JSONResponse) VisitPostAuthOidcAuthorizeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostAuthOidcAuthorize400JSONResponse BadRequest
+
+func (response PostAuthOidcAuthorize400JSONResponse) VisitPostAuthOidcAuthorizeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostAuthOidcAuthorize403JSONResponse Forbidden
+
+func (response PostAuthOidcAuthorize403JSONResponse) VisitPostAuthOidcAuthorizeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostAuthOidcAuthorize500JSONResponse InternalServerError
+
+func (response PostAuthOidcAuthorize500JSONResponse) VisitPostAuthOidcAuthorizeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostAuthOidcAuthorize502JSONResponse Error
+
+func (response PostAuthOidcAuthorize502JSONResponse) VisitPostAuthOidcAuthorizeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(502)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostAuthOidcCallbackRequestObject struct {
+	Body *PostAuthOidcCallbackJSONRequestBody
+}
+
+type PostAuthOidcCallbackResponseObject interface {
+	VisitPostAuthOidcCallbackResponse(w http.ResponseWriter) error
+}
+
+type PostAuthOidcCallback200JSONResponse struct {
+	Code string `json:"code"`
+	Data struct {
+		// ExpiresIn 访问令牌有效期（秒）
+		ExpiresIn int `json:"expiresIn,omitempty"`
+
+		// MfaRequired 为true时需调用 /auth/login/2fa 完成第二步登录，此时不返回令牌
+		MfaRequired bool `json:"mfaRequired,omitempty"`
+
+		// MfaToken 两步登录挑战令牌，仅在 mfaRequired 为true时返回，短时间内有效且只能使用一次
+		MfaToken string `json:"mfaToken,omitempty"`
+
+		// RefreshToken 刷新令牌，用于调用 /auth/refresh 换取新的访问令牌，每次使用后轮换
+		RefreshToken string `json:"refreshToken,omitempty"`
+
+		// Token JWT 令牌
+		Token string `json:"token,omitempty"`
+
+		// UserId 用户ID
+		UserId int `json:"userId,omitempty"`
+
+		// Username 用户名
+		Username string `json:"username,omitempty"`
+	} `json:"data"`
+}
+
+func (response PostAuthOidcCallback200JSONResponse) VisitPostAuthOidcCallbackResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostAuthOidcCallback400JSONResponse BadRequest
+
+func (response PostAuthOidcCallback400JSONResponse) VisitPostAuthOidcCallbackResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostAuthOidcCallback401JSONResponse Unauthorized
+
+func (response PostAuthOidcCallback401JSONResponse) VisitPostAuthOidcCallbackResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostAuthOidcCallback403JSONResponse Forbidden
+
+func (response PostAuthOidcCallback403JSONResponse) VisitPostAuthOidcCallbackResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostAuthOidcCallback409JSONResponse Conflict
+
+func (response PostAuthOidcCallback409JSONResponse) VisitPostAuthOidcCallbackResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostAuthOidcCallback500JSONResponse InternalServerError
+
+func (response PostAuthOidcCallback500JSONResponse) VisitPostAuthOidcCallbackResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostAuthOidcCallback502JSONResponse Error
+
+func (response PostAuthOidcCallback502JSONResponse) VisitPostAuthOidcCallbackResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(502)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostAuthPasswordResetRequestObject struct {
+	Body *PostAuthPasswordResetJSONRequestBody
+}
+
+type PostAuthPasswordResetResponseObject interface {
+	VisitPostAuthPasswordResetResponse(w http.ResponseWriter) error
+}
+
+type PostAuthPasswordReset200JSONResponse Success
+
+func (response PostAuthPasswordReset200JSONResponse) VisitPostAuthPasswordResetResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostAuthPasswordReset400JSONResponse BadRequest
+
+func (response PostAuthPasswordReset400JSONResponse) VisitPostAuthPasswordResetResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
 
-	siw.Handler.PostAuthRegister(c)
+	return json.NewEncoder(w).Encode(response)
 }
 
-// AuthGinServerOptions 提供 Gin 服务器的选项。
-type AuthGinServerOptions struct {
-	BaseURL      string
-	Middlewares  []AuthMiddlewareFunc
-	ErrorHandler func(*gin.Context, error, int)
+type PostAuthPasswordReset403JSONResponse Forbidden
+
+func (response PostAuthPasswordReset403JSONResponse) VisitPostAuthPasswordResetResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-// RegisterAuthHandlers 创建与 OpenAPI 规范匹配的 http.Handler 路由。
-func RegisterAuthHandlers(router gin.IRouter, si AuthServerInterface) {
-	RegisterAuthHandlersWithOptions(router, si, AuthGinServerOptions{})
+type PostAuthPasswordReset500JSONResponse InternalServerError
+
+func (response PostAuthPasswordReset500JSONResponse) VisitPostAuthPasswordResetResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-// RegisterAuthHandlersWithOptions 创建带有附加选项的 http.Handler
-func RegisterAuthHandlersWithOptions(router gin.IRouter, si AuthServerInterface, options AuthGinServerOptions) {
-	errorHandler := options.ErrorHandler
-	if errorHandler == nil {
-		errorHandler = func(c *gin.Context, err error, statusCode int) {
-			c.JSON(statusCode, gin.H{"msg": err.Error()})
-		}
-	}
+type PostAuthPasswordResetConfirmRequestObject struct {
+	Body *PostAuthPasswordResetConfirmJSONRequestBody
+}
 
-	wrapper := AuthServerInterfaceWrapper{
-		Handler:            si,
-		HandlerMiddlewares: options.Middlewares,
-		ErrorHandler:       errorHandler,
-	}
+type PostAuthPasswordResetConfirmResponseObject interface {
+	VisitPostAuthPasswordResetConfirmResponse(w http.ResponseWriter) error
+}
 
-	router.POST(options.BaseURL+"/auth/login", wrapper.PostAuthLogin)
-	router.POST(options.BaseURL+"/auth/register", wrapper.PostAuthRegister)
+type PostAuthPasswordResetConfirm200JSONResponse Success
+
+func (response PostAuthPasswordResetConfirm200JSONResponse) VisitPostAuthPasswordResetConfirmResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-type PostAuthLoginRequestObject struct {
-	Body *PostAuthLoginJSONRequestBody
+type PostAuthPasswordResetConfirm400JSONResponse BadRequest
+
+func (response PostAuthPasswordResetConfirm400JSONResponse) VisitPostAuthPasswordResetConfirmResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-type PostAuthLoginResponseObject interface {
-	VisitPostAuthLoginResponse(w http.ResponseWriter) error
+type PostAuthPasswordResetConfirm403JSONResponse Forbidden
+
+func (response PostAuthPasswordResetConfirm403JSONResponse) VisitPostAuthPasswordResetConfirmResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-type PostAuthLogin200JSONResponse struct {
+type PostAuthPasswordResetConfirm500JSONResponse InternalServerError
+
+func (response PostAuthPasswordResetConfirm500JSONResponse) VisitPostAuthPasswordResetConfirmResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostAuthRefreshRequestObject struct {
+	Body *PostAuthRefreshJSONRequestBody
+}
+
+type PostAuthRefreshResponseObject interface {
+	VisitPostAuthRefreshResponse(w http.ResponseWriter) error
+}
+
+type PostAuthRefresh200JSONResponse struct {
 	Code string `json:"code"`
 	Data struct {
-		// Token JWT 令牌
-		Token string `json:"token,omitempty"`
+		// ExpiresIn 访问令牌有效期（秒）
+		ExpiresIn int `json:"expiresIn,omitempty"`
 
-		// UserId 用户ID
-		UserId int `json:"userId,omitempty"`
+		// RefreshToken 新的刷新令牌，旧的刷新令牌立即失效
+		RefreshToken string `json:"refreshToken,omitempty"`
 
-		// Username 用户名
-		Username string `json:"username,omitempty"`
+		// Token 新的 JWT 访问令牌
+		Token string `json:"token,omitempty"`
 	} `json:"data"`
 }
 
-func (response PostAuthLogin200JSONResponse) VisitPostAuthLoginResponse(w http.ResponseWriter) error {
+func (response PostAuthRefresh200JSONResponse) VisitPostAuthRefreshResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type PostAuthLogin400JSONResponse BadRequest
+type PostAuthRefresh400JSONResponse BadRequest
 
-func (response PostAuthLogin400JSONResponse) VisitPostAuthLoginResponse(w http.ResponseWriter) error {
+func (response PostAuthRefresh400JSONResponse) VisitPostAuthRefreshResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(400)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type PostAuthLogin401JSONResponse Unauthorized
+type PostAuthRefresh401JSONResponse Unauthorized
 
-func (response PostAuthLogin401JSONResponse) VisitPostAuthLoginResponse(w http.ResponseWriter) error {
+func (response PostAuthRefresh401JSONResponse) VisitPostAuthRefreshResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(401)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type PostAuthLogin500JSONResponse InternalServerError
+type PostAuthRefresh500JSONResponse InternalServerError
 
-func (response PostAuthLogin500JSONResponse) VisitPostAuthLoginResponse(w http.ResponseWriter) error {
+func (response PostAuthRefresh500JSONResponse) VisitPostAuthRefreshResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(500)
 
@@ -197,6 +770,27 @@ type AuthStrictServerInterface interface {
 	// 用户登录
 	// (POST /auth/login)
 	PostAuthLogin(ctx context.Context, request PostAuthLoginRequestObject) (PostAuthLoginResponseObject, error)
+	// 两步登录
+	// (POST /auth/login/2fa)
+	PostAuthLogin2fa(ctx context.Context, request PostAuthLogin2faRequestObject) (PostAuthLogin2faResponseObject, error)
+	// 退出登录
+	// (POST /auth/logout)
+	PostAuthLogout(ctx context.Context, request PostAuthLogoutRequestObject) (PostAuthLogoutResponseObject, error)
+	// 发起统一身份认证登录
+	// (POST /auth/oidc/authorize)
+	PostAuthOidcAuthorize(ctx context.Context, request PostAuthOidcAuthorizeRequestObject) (PostAuthOidcAuthorizeResponseObject, error)
+	// 完成统一身份认证登录
+	// (POST /auth/oidc/callback)
+	PostAuthOidcCallback(ctx context.Context, request PostAuthOidcCallbackRequestObject) (PostAuthOidcCallbackResponseObject, error)
+	// 申请重置密码
+	// (POST /auth/password-reset)
+	PostAuthPasswordReset(ctx context.Context, request PostAuthPasswordResetRequestObject) (PostAuthPasswordResetResponseObject, error)
+	// 使用重置令牌设置新密码
+	// (POST /auth/password-reset/confirm)
+	PostAuthPasswordResetConfirm(ctx context.Context, request PostAuthPasswordResetConfirmRequestObject) (PostAuthPasswordResetConfirmResponseObject, error)
+	// 刷新访问令牌
+	// (POST /auth/refresh)
+	PostAuthRefresh(ctx context.Context, request PostAuthRefreshRequestObject) (PostAuthRefreshResponseObject, error)
 	// 用户注册
 	// (POST /auth/register)
 	PostAuthRegister(ctx context.Context, request PostAuthRegisterRequestObject) (PostAuthRegisterResponseObject, error)
@@ -247,6 +841,229 @@ func (sh *AuthstrictHandler) PostAuthLogin(ctx *gin.Context) {
 	}
 }
 
+// PostAuthLogin2fa 操作中间件
+func (sh *AuthstrictHandler) PostAuthLogin2fa(ctx *gin.Context) {
+	var request PostAuthLogin2faRequestObject
+
+	var body PostAuthLogin2faJSONRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.Status(http.StatusBadRequest)
+		ctx.Error(err)
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PostAuthLogin2fa(ctx, request.(PostAuthLogin2faRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostAuthLogin2fa")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(PostAuthLogin2faResponseObject); ok {
+		if err := validResponse.VisitPostAuthLogin2faResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PostAuthLogout 操作中间件
+func (sh *AuthstrictHandler) PostAuthLogout(ctx *gin.Context) {
+	var request PostAuthLogoutRequestObject
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PostAuthLogout(ctx, request.(PostAuthLogoutRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostAuthLogout")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(PostAuthLogoutResponseObject); ok {
+		if err := validResponse.VisitPostAuthLogoutResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PostAuthOidcAuthorize 操作中间件
+func (sh *AuthstrictHandler) PostAuthOidcAuthorize(ctx *gin.Context) {
+	var request PostAuthOidcAuthorizeRequestObject
+
+	var body PostAuthOidcAuthorizeJSONRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.Status(http.StatusBadRequest)
+		ctx.Error(err)
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PostAuthOidcAuthorize(ctx, request.(PostAuthOidcAuthorizeRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostAuthOidcAuthorize")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(PostAuthOidcAuthorizeResponseObject); ok {
+		if err := validResponse.VisitPostAuthOidcAuthorizeResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PostAuthOidcCallback 操作中间件
+func (sh *AuthstrictHandler) PostAuthOidcCallback(ctx *gin.Context) {
+	var request PostAuthOidcCallbackRequestObject
+
+	var body PostAuthOidcCallbackJSONRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.Status(http.StatusBadRequest)
+		ctx.Error(err)
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PostAuthOidcCallback(ctx, request.(PostAuthOidcCallbackRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostAuthOidcCallback")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(PostAuthOidcCallbackResponseObject); ok {
+		if err := validResponse.VisitPostAuthOidcCallbackResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PostAuthPasswordReset 操作中间件
+func (sh *AuthstrictHandler) PostAuthPasswordReset(ctx *gin.Context) {
+	var request PostAuthPasswordResetRequestObject
+
+	var body PostAuthPasswordResetJSONRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.Status(http.StatusBadRequest)
+		ctx.Error(err)
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PostAuthPasswordReset(ctx, request.(PostAuthPasswordResetRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostAuthPasswordReset")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(PostAuthPasswordResetResponseObject); ok {
+		if err := validResponse.VisitPostAuthPasswordResetResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PostAuthPasswordResetConfirm 操作中间件
+func (sh *AuthstrictHandler) PostAuthPasswordResetConfirm(ctx *gin.Context) {
+	var request PostAuthPasswordResetConfirmRequestObject
+
+	var body PostAuthPasswordResetConfirmJSONRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.Status(http.StatusBadRequest)
+		ctx.Error(err)
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PostAuthPasswordResetConfirm(ctx, request.(PostAuthPasswordResetConfirmRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostAuthPasswordResetConfirm")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(PostAuthPasswordResetConfirmResponseObject); ok {
+		if err := validResponse.VisitPostAuthPasswordResetConfirmResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PostAuthRefresh 操作中间件
+func (sh *AuthstrictHandler) PostAuthRefresh(ctx *gin.Context) {
+	var request PostAuthRefreshRequestObject
+
+	var body PostAuthRefreshJSONRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.Status(http.StatusBadRequest)
+		ctx.Error(err)
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PostAuthRefresh(ctx, request.(PostAuthRefreshRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostAuthRefresh")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(PostAuthRefreshResponseObject); ok {
+		if err := validResponse.VisitPostAuthRefreshResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
 // PostAuthRegister 操作中间件
 func (sh *AuthstrictHandler) PostAuthRegister(ctx *gin.Context) {
 	var request PostAuthRegisterRequestObject
@@ -7,6 +7,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -22,6 +23,9 @@ type CheckinRecordsServerInterface interface {
 	// 获取签到任务的签到记录列表 (管理员视角)
 	// (GET /checkin-tasks/{taskId}/records)
 	GetCheckinTasksTaskIdRecords(c *gin.Context, taskId int)
+	// 导出签到任务的签到记录
+	// (GET /checkin-tasks/{taskId}/records/export)
+	GetCheckinTasksTaskIdRecordsExport(c *gin.Context, taskId int)
 	// 获取当前用户签到记录
 	// (GET /users/me/checkin-records)
 	GetUsersMeCheckinRecords(c *gin.Context)
@@ -84,6 +88,32 @@ func (siw *CheckinRecordsServerInterfaceWrapper) GetCheckinTasksTaskIdRecords(c
 	siw.Handler.GetCheckinTasksTaskIdRecords(c, taskId)
 }
 
+// GetCheckinTasksTaskIdRecordsExport 操作中间件
+func (siw *CheckinRecordsServerInterfaceWrapper) GetCheckinTasksTaskIdRecordsExport(c *gin.Context) {
+
+	var err error
+
+	// ------------- 路径参数 "taskId" -------------
+	var taskId int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "taskId", c.Param("taskId"), &taskId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("参数 taskId 格式无效: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(JWT鉴权Scopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetCheckinTasksTaskIdRecordsExport(c, taskId)
+}
+
 // GetUsersMeCheckinRecords 操作中间件
 func (siw *CheckinRecordsServerInterfaceWrapper) GetUsersMeCheckinRecords(c *gin.Context) {
 
@@ -126,6 +156,7 @@ func RegisterCheckinRecordsHandlersWithOptions(router gin.IRouter, si CheckinRec
 
 	router.POST(options.BaseURL+"/checkin-tasks/:taskId/checkin", wrapper.PostCheckinTasksTaskIdCheckin)
 	router.GET(options.BaseURL+"/checkin-tasks/:taskId/records", wrapper.GetCheckinTasksTaskIdRecords)
+	router.GET(options.BaseURL+"/checkin-tasks/:taskId/records/export", wrapper.GetCheckinTasksTaskIdRecordsExport)
 	router.GET(options.BaseURL+"/users/me/checkin-records", wrapper.GetUsersMeCheckinRecords)
 }
 
@@ -271,6 +302,75 @@ func (response GetCheckinTasksTaskIdRecords500JSONResponse) VisitGetCheckinTasks
 	return json.NewEncoder(w).Encode(response)
 }
 
+type GetCheckinTasksTaskIdRecordsExportRequestObject struct {
+	TaskId int `json:"taskId"`
+}
+
+type GetCheckinTasksTaskIdRecordsExportResponseObject interface {
+	VisitGetCheckinTasksTaskIdRecordsExportResponse(w http.ResponseWriter) error
+}
+
+type GetCheckinTasksTaskIdRecordsExport200ResponseHeaders struct {
+	ContentDisposition string
+}
+
+type GetCheckinTasksTaskIdRecordsExport200TextcsvResponse struct {
+	Body          io.Reader
+	Headers       GetCheckinTasksTaskIdRecordsExport200ResponseHeaders
+	ContentLength int64
+}
+
+func (response GetCheckinTasksTaskIdRecordsExport200TextcsvResponse) VisitGetCheckinTasksTaskIdRecordsExportResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "text/csv")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.Header().Set("Content-Disposition", fmt.Sprint(response.Headers.ContentDisposition))
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type GetCheckinTasksTaskIdRecordsExport401JSONResponse Unauthorized
+
+func (response GetCheckinTasksTaskIdRecordsExport401JSONResponse) VisitGetCheckinTasksTaskIdRecordsExportResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetCheckinTasksTaskIdRecordsExport403JSONResponse Forbidden
+
+func (response GetCheckinTasksTaskIdRecordsExport403JSONResponse) VisitGetCheckinTasksTaskIdRecordsExportResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetCheckinTasksTaskIdRecordsExport404JSONResponse NotFound
+
+func (response GetCheckinTasksTaskIdRecordsExport404JSONResponse) VisitGetCheckinTasksTaskIdRecordsExportResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetCheckinTasksTaskIdRecordsExport500JSONResponse InternalServerError
+
+func (response GetCheckinTasksTaskIdRecordsExport500JSONResponse) VisitGetCheckinTasksTaskIdRecordsExportResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
 type GetUsersMeCheckinRecordsRequestObject struct {
 }
 
@@ -316,6 +416,9 @@ type CheckinRecordsStrictServerInterface interface {
 	// 获取签到任务的签到记录列表 (管理员视角)
 	// (GET /checkin-tasks/{taskId}/records)
 	GetCheckinTasksTaskIdRecords(ctx context.Context, request GetCheckinTasksTaskIdRecordsRequestObject) (GetCheckinTasksTaskIdRecordsResponseObject, error)
+	// 导出签到任务的签到记录
+	// (GET /checkin-tasks/{taskId}/records/export)
+	GetCheckinTasksTaskIdRecordsExport(ctx context.Context, request GetCheckinTasksTaskIdRecordsExportRequestObject) (GetCheckinTasksTaskIdRecordsExportResponseObject, error)
 	// 获取当前用户签到记录
 	// (GET /users/me/checkin-records)
 	GetUsersMeCheckinRecords(ctx context.Context, request GetUsersMeCheckinRecordsRequestObject) (GetUsersMeCheckinRecordsResponseObject, error)
@@ -395,6 +498,33 @@ func (sh *CheckinRecordsstrictHandler) GetCheckinTasksTaskIdRecords(ctx *gin.Con
 	}
 }
 
+// GetCheckinTasksTaskIdRecordsExport 操作中间件
+func (sh *CheckinRecordsstrictHandler) GetCheckinTasksTaskIdRecordsExport(ctx *gin.Context, taskId int) {
+	var request GetCheckinTasksTaskIdRecordsExportRequestObject
+
+	request.TaskId = taskId
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetCheckinTasksTaskIdRecordsExport(ctx, request.(GetCheckinTasksTaskIdRecordsExportRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetCheckinTasksTaskIdRecordsExport")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(GetCheckinTasksTaskIdRecordsExportResponseObject); ok {
+		if err := validResponse.VisitGetCheckinTasksTaskIdRecordsExportResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
 // GetUsersMeCheckinRecords 操作中间件
 func (sh *CheckinRecordsstrictHandler) GetUsersMeCheckinRecords(ctx *gin.Context) {
 	var request GetUsersMeCheckinRecordsRequestObject
@@ -22,6 +22,9 @@ type GroupsServerInterface interface {
 	// 创建用户组
 	// (POST /groups)
 	PostGroups(c *gin.Context)
+	// 凭邀请码加入用户组
+	// (POST /groups/join-by-code)
+	PostGroupsJoinByCode(c *gin.Context)
 	// 删除用户组
 	// (DELETE /groups/{groupId})
 	DeleteGroupsGroupId(c *gin.Context, groupId int)
@@ -31,6 +34,15 @@ type GroupsServerInterface interface {
 	// 修改用户组信息
 	// (PUT /groups/{groupId})
 	PutGroupsGroupId(c *gin.Context, groupId int)
+	// 列出邀请码
+	// (GET /groups/{groupId}/invites)
+	GetGroupsGroupIdInvites(c *gin.Context, groupId int)
+	// 创建邀请码
+	// (POST /groups/{groupId}/invites)
+	PostGroupsGroupIdInvites(c *gin.Context, groupId int)
+	// 撤销邀请码
+	// (DELETE /groups/{groupId}/invites/{inviteId})
+	DeleteGroupsGroupIdInvitesInviteId(c *gin.Context, groupId int, inviteId int)
 	// 查看用户组的加入申请列表
 	// (GET /groups/{groupId}/join-requests)
 	GetGroupsGroupIdJoinRequests(c *gin.Context, groupId int, params GetGroupsGroupIdJoinRequestsParams)
@@ -46,9 +58,36 @@ type GroupsServerInterface interface {
 	// 移除用户组成员
 	// (DELETE /groups/{groupId}/members/{userId})
 	DeleteGroupsGroupIdMembersUserId(c *gin.Context, groupId int, userId int)
+	// 设置成员角色
+	// (PUT /groups/{groupId}/members/{userId}/role)
+	PutGroupsGroupIdMembersUserIdRole(c *gin.Context, groupId int, userId int)
 	// 查询当前用户在用户组中的状态
 	// (GET /groups/{groupId}/my-status)
 	GetGroupsGroupIdMyStatus(c *gin.Context, groupId int)
+	// 调整上级用户组
+	// (PUT /groups/{groupId}/parent)
+	PutGroupsGroupIdParent(c *gin.Context, groupId int)
+	// 查询角色变更记录
+	// (GET /groups/{groupId}/role-changes)
+	GetGroupsGroupIdRoleChanges(c *gin.Context, groupId int, params GetGroupsGroupIdRoleChangesParams)
+	// 查询自定义角色
+	// (GET /groups/{groupId}/roles)
+	GetGroupsGroupIdRoles(c *gin.Context, groupId int)
+	// 创建自定义角色
+	// (POST /groups/{groupId}/roles)
+	PostGroupsGroupIdRoles(c *gin.Context, groupId int)
+	// 删除自定义角色
+	// (DELETE /groups/{groupId}/roles/{roleId})
+	DeleteGroupsGroupIdRolesRoleId(c *gin.Context, groupId int, roleId int)
+	// 修改自定义角色
+	// (PUT /groups/{groupId}/roles/{roleId})
+	PutGroupsGroupIdRolesRoleId(c *gin.Context, groupId int, roleId int)
+	// 查询用户组统计
+	// (GET /groups/{groupId}/stats)
+	GetGroupsGroupIdStats(c *gin.Context, groupId int)
+	// 转让用户组所有权
+	// (POST /groups/{groupId}/transfer-ownership)
+	PostGroupsGroupIdTransferOwnership(c *gin.Context, groupId int)
 }
 
 // GroupsServerInterfaceWrapper 将上下文转换为参数。
@@ -99,6 +138,21 @@ func (siw *GroupsServerInterfaceWrapper) PostGroups(c *gin.Context) {
 	siw.Handler.PostGroups(c)
 }
 
+// PostGroupsJoinByCode 操作中间件
+func (siw *GroupsServerInterfaceWrapper) PostGroupsJoinByCode(c *gin.Context) {
+
+	c.Set(JWT鉴权Scopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostGroupsJoinByCode(c)
+}
+
 // DeleteGroupsGroupId 操作中间件
 func (siw *GroupsServerInterfaceWrapper) DeleteGroupsGroupId(c *gin.Context) {
 
@@ -171,6 +225,93 @@ func (siw *GroupsServerInterfaceWrapper) PutGroupsGroupId(c *gin.Context) {
 	siw.Handler.PutGroupsGroupId(c, groupId)
 }
 
+// GetGroupsGroupIdInvites 操作中间件
+func (siw *GroupsServerInterfaceWrapper) GetGroupsGroupIdInvites(c *gin.Context) {
+
+	var err error
+
+	// ------------- 路径参数 "groupId" -------------
+	var groupId int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "groupId", c.Param("groupId"), &groupId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("参数 groupId 格式无效: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(JWT鉴权Scopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetGroupsGroupIdInvites(c, groupId)
+}
+
+// PostGroupsGroupIdInvites 操作中间件
+func (siw *GroupsServerInterfaceWrapper) PostGroupsGroupIdInvites(c *gin.Context) {
+
+	var err error
+
+	// ------------- 路径参数 "groupId" -------------
+	var groupId int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "groupId", c.Param("groupId"), &groupId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("参数 groupId 格式无效: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(JWT鉴权Scopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostGroupsGroupIdInvites(c, groupId)
+}
+
+// DeleteGroupsGroupIdInvitesInviteId 操作中间件
+func (siw *GroupsServerInterfaceWrapper) DeleteGroupsGroupIdInvitesInviteId(c *gin.Context) {
+
+	var err error
+
+	// ------------- 路径参数 "groupId" -------------
+	var groupId int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "groupId", c.Param("groupId"), &groupId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("参数 groupId 格式无效: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- 路径参数 "inviteId" -------------
+	var inviteId int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "inviteId", c.Param("inviteId"), &inviteId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("参数 inviteId 格式无效: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(JWT鉴权Scopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.DeleteGroupsGroupIdInvitesInviteId(c, groupId, inviteId)
+}
+
 // GetGroupsGroupIdJoinRequests 操作中间件
 func (siw *GroupsServerInterfaceWrapper) GetGroupsGroupIdJoinRequests(c *gin.Context) {
 
@@ -320,8 +461,8 @@ func (siw *GroupsServerInterfaceWrapper) DeleteGroupsGroupIdMembersUserId(c *gin
 	siw.Handler.DeleteGroupsGroupIdMembersUserId(c, groupId, userId)
 }
 
-// GetGroupsGroupIdMyStatus 操作中间件
-func (siw *GroupsServerInterfaceWrapper) GetGroupsGroupIdMyStatus(c *gin.Context) {
+// PutGroupsGroupIdMembersUserIdRole 操作中间件
+func (siw *GroupsServerInterfaceWrapper) PutGroupsGroupIdMembersUserIdRole(c *gin.Context) {
 
 	var err error
 
@@ -334,6 +475,17 @@ func (siw *GroupsServerInterfaceWrapper) GetGroupsGroupIdMyStatus(c *gin.Context
 		return
 	}
 
+	// ------------- 路径参数 "userId" -------------
+	var userId int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "userId", c.Param("userId"), &userId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("参数 userId 格式无效: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(JWT鉴权Scopes, []string{})
+
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
 		if c.IsAborted() {
@@ -341,689 +493,1891 @@ func (siw *GroupsServerInterfaceWrapper) GetGroupsGroupIdMyStatus(c *gin.Context
 		}
 	}
 
-	siw.Handler.GetGroupsGroupIdMyStatus(c, groupId)
+	siw.Handler.PutGroupsGroupIdMembersUserIdRole(c, groupId, userId)
 }
 
-// GroupsGinServerOptions 提供 Gin 服务器的选项。
-type GroupsGinServerOptions struct {
-	BaseURL      string
-	Middlewares  []GroupsMiddlewareFunc
-	ErrorHandler func(*gin.Context, error, int)
-}
+// GetGroupsGroupIdMyStatus 操作中间件
+func (siw *GroupsServerInterfaceWrapper) GetGroupsGroupIdMyStatus(c *gin.Context) {
 
-// RegisterGroupsHandlers 创建与 OpenAPI 规范匹配的 http.Handler 路由。
-func RegisterGroupsHandlers(router gin.IRouter, si GroupsServerInterface) {
-	RegisterGroupsHandlersWithOptions(router, si, GroupsGinServerOptions{})
-}
+	var err error
 
-// RegisterGroupsHandlersWithOptions 创建带有附加选项的 http.Handler
-func RegisterGroupsHandlersWithOptions(router gin.IRouter, si GroupsServerInterface, options GroupsGinServerOptions) {
-	errorHandler := options.ErrorHandler
-	if errorHandler == nil {
-		errorHandler = func(c *gin.Context, err error, statusCode int) {
-			c.JSON(statusCode, gin.H{"msg": err.Error()})
-		}
+	// ------------- 路径参数 "groupId" -------------
+	var groupId int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "groupId", c.Param("groupId"), &groupId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("参数 groupId 格式无效: %w", err), http.StatusBadRequest)
+		return
 	}
 
-	wrapper := GroupsServerInterfaceWrapper{
-		Handler:            si,
-		HandlerMiddlewares: options.Middlewares,
-		ErrorHandler:       errorHandler,
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
 	}
 
-	router.GET(options.BaseURL+"/groups", wrapper.GetGroups)
-	router.POST(options.BaseURL+"/groups", wrapper.PostGroups)
-	router.DELETE(options.BaseURL+"/groups/:groupId", wrapper.DeleteGroupsGroupId)
-	router.GET(options.BaseURL+"/groups/:groupId", wrapper.GetGroupsGroupId)
-	router.PUT(options.BaseURL+"/groups/:groupId", wrapper.PutGroupsGroupId)
-	router.GET(options.BaseURL+"/groups/:groupId/join-requests", wrapper.GetGroupsGroupIdJoinRequests)
-	router.POST(options.BaseURL+"/groups/:groupId/join-requests", wrapper.PostGroupsGroupIdJoinRequests)
-	router.PUT(options.BaseURL+"/groups/:groupId/join-requests/:requestId", wrapper.PutGroupsGroupIdJoinRequestsRequestId)
-	router.GET(options.BaseURL+"/groups/:groupId/members", wrapper.GetGroupsGroupIdMembers)
-	router.DELETE(options.BaseURL+"/groups/:groupId/members/:userId", wrapper.DeleteGroupsGroupIdMembersUserId)
-	router.GET(options.BaseURL+"/groups/:groupId/my-status", wrapper.GetGroupsGroupIdMyStatus)
+	siw.Handler.GetGroupsGroupIdMyStatus(c, groupId)
 }
 
-type GetGroupsRequestObject struct {
-	Params GetGroupsParams
-}
+// PutGroupsGroupIdParent 操作中间件
+func (siw *GroupsServerInterfaceWrapper) PutGroupsGroupIdParent(c *gin.Context) {
 
-type GetGroupsResponseObject interface {
-	VisitGetGroupsResponse(w http.ResponseWriter) error
-}
+	var err error
 
-type GetGroups200JSONResponse struct {
-	Code string `json:"code"`
-	Data []struct {
-		// CreatedAt 创建时间（Unix时间戳，单位：秒）
-		CreatedAt int `json:"createdAt,omitempty"`
+	// ------------- 路径参数 "groupId" -------------
+	var groupId int
 
-		// CreatorId 创建者用户ID
-		CreatorId int `json:"creatorId,omitempty"`
+	err = runtime.BindStyledParameterWithOptions("simple", "groupId", c.Param("groupId"), &groupId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("参数 groupId 格式无效: %w", err), http.StatusBadRequest)
+		return
+	}
 
-		// CreatorName 创建者用户名
-		CreatorName string `json:"creatorName,omitempty"`
+	c.Set(JWT鉴权Scopes, []string{})
 
-		// Description 用户组描述
-		Description string `json:"description,omitempty"`
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
 
-		// GroupId 用户组ID
-		GroupId int `json:"groupId,omitempty"`
+	siw.Handler.PutGroupsGroupIdParent(c, groupId)
+}
 
-		// GroupName 用户组名称
-		GroupName string `json:"groupName,omitempty"`
+// GetGroupsGroupIdRoleChanges 操作中间件
+func (siw *GroupsServerInterfaceWrapper) GetGroupsGroupIdRoleChanges(c *gin.Context) {
 
-		// MemberCount 成员数量
-		MemberCount int       `json:"memberCount,omitempty"`
-		RoleInGroup GroupRole `json:"roleInGroup,omitempty"`
-	} `json:"data"`
-}
+	var err error
 
-func (response GetGroups200JSONResponse) VisitGetGroupsResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	// ------------- 路径参数 "groupId" -------------
+	var groupId int
 
-	return json.NewEncoder(w).Encode(response)
-}
+	err = runtime.BindStyledParameterWithOptions("simple", "groupId", c.Param("groupId"), &groupId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("参数 groupId 格式无效: %w", err), http.StatusBadRequest)
+		return
+	}
 
-type GetGroups401JSONResponse Unauthorized
+	c.Set(JWT鉴权Scopes, []string{})
 
-func (response GetGroups401JSONResponse) VisitGetGroupsResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(401)
+	// 参数对象，我们将从上下文中解析所有参数到此对象
+	var params GetGroupsGroupIdRoleChangesParams
 
-	return json.NewEncoder(w).Encode(response)
-}
+	// ------------- 可选查询参数 "page" -------------
 
-type PostGroupsRequestObject struct {
-	Body *PostGroupsJSONRequestBody
-}
+	err = runtime.BindQueryParameter("form", true, false, "page", c.Request.URL.Query(), &params.Page)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("参数 page 格式无效: %w", err), http.StatusBadRequest)
+		return
+	}
 
-type PostGroupsResponseObject interface {
-	VisitPostGroupsResponse(w http.ResponseWriter) error
-}
+	// ------------- 可选查询参数 "pageSize" -------------
 
-type PostGroups201JSONResponse struct {
-	Code string `json:"code"`
-	Data Group  `json:"data"`
-}
+	err = runtime.BindQueryParameter("form", true, false, "pageSize", c.Request.URL.Query(), &params.PageSize)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("参数 pageSize 格式无效: %w", err), http.StatusBadRequest)
+		return
+	}
 
-func (response PostGroups201JSONResponse) VisitPostGroupsResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(201)
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
 
-	return json.NewEncoder(w).Encode(response)
+	siw.Handler.GetGroupsGroupIdRoleChanges(c, groupId, params)
 }
 
-type PostGroups400JSONResponse BadRequest
+// GetGroupsGroupIdRoles 操作中间件
+func (siw *GroupsServerInterfaceWrapper) GetGroupsGroupIdRoles(c *gin.Context) {
 
-func (response PostGroups400JSONResponse) VisitPostGroupsResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	var err error
 
-	return json.NewEncoder(w).Encode(response)
-}
+	// ------------- 路径参数 "groupId" -------------
+	var groupId int
 
-type PostGroups401JSONResponse Unauthorized
+	err = runtime.BindStyledParameterWithOptions("simple", "groupId", c.Param("groupId"), &groupId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("参数 groupId 格式无效: %w", err), http.StatusBadRequest)
+		return
+	}
 
-func (response PostGroups401JSONResponse) VisitPostGroupsResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(401)
+	c.Set(JWT鉴权Scopes, []string{})
 
-	return json.NewEncoder(w).Encode(response)
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetGroupsGroupIdRoles(c, groupId)
 }
 
-type PostGroups500JSONResponse InternalServerError
+// PostGroupsGroupIdRoles 操作中间件
+func (siw *GroupsServerInterfaceWrapper) PostGroupsGroupIdRoles(c *gin.Context) {
 
-func (response PostGroups500JSONResponse) VisitPostGroupsResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+	var err error
 
-	return json.NewEncoder(w).Encode(response)
-}
+	// ------------- 路径参数 "groupId" -------------
+	var groupId int
 
-type DeleteGroupsGroupIdRequestObject struct {
-	GroupId int `json:"groupId"`
-}
+	err = runtime.BindStyledParameterWithOptions("simple", "groupId", c.Param("groupId"), &groupId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("参数 groupId 格式无效: %w", err), http.StatusBadRequest)
+		return
+	}
 
-type DeleteGroupsGroupIdResponseObject interface {
-	VisitDeleteGroupsGroupIdResponse(w http.ResponseWriter) error
+	c.Set(JWT鉴权Scopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostGroupsGroupIdRoles(c, groupId)
 }
 
-type DeleteGroupsGroupId200JSONResponse Success
+// DeleteGroupsGroupIdRolesRoleId 操作中间件
+func (siw *GroupsServerInterfaceWrapper) DeleteGroupsGroupIdRolesRoleId(c *gin.Context) {
 
-func (response DeleteGroupsGroupId200JSONResponse) VisitDeleteGroupsGroupIdResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	var err error
 
-	return json.NewEncoder(w).Encode(response)
-}
+	// ------------- 路径参数 "groupId" -------------
+	var groupId int
 
-type DeleteGroupsGroupId401JSONResponse Unauthorized
+	err = runtime.BindStyledParameterWithOptions("simple", "groupId", c.Param("groupId"), &groupId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("参数 groupId 格式无效: %w", err), http.StatusBadRequest)
+		return
+	}
 
-func (response DeleteGroupsGroupId401JSONResponse) VisitDeleteGroupsGroupIdResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(401)
+	// ------------- 路径参数 "roleId" -------------
+	var roleId int
 
-	return json.NewEncoder(w).Encode(response)
+	err = runtime.BindStyledParameterWithOptions("simple", "roleId", c.Param("roleId"), &roleId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("参数 roleId 格式无效: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(JWT鉴权Scopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.DeleteGroupsGroupIdRolesRoleId(c, groupId, roleId)
 }
 
-type DeleteGroupsGroupId403JSONResponse Forbidden
+// PutGroupsGroupIdRolesRoleId 操作中间件
+func (siw *GroupsServerInterfaceWrapper) PutGroupsGroupIdRolesRoleId(c *gin.Context) {
 
-func (response DeleteGroupsGroupId403JSONResponse) VisitDeleteGroupsGroupIdResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(403)
+	var err error
 
-	return json.NewEncoder(w).Encode(response)
+	// ------------- 路径参数 "groupId" -------------
+	var groupId int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "groupId", c.Param("groupId"), &groupId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("参数 groupId 格式无效: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- 路径参数 "roleId" -------------
+	var roleId int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "roleId", c.Param("roleId"), &roleId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("参数 roleId 格式无效: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(JWT鉴权Scopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PutGroupsGroupIdRolesRoleId(c, groupId, roleId)
 }
 
-type DeleteGroupsGroupId404JSONResponse NotFound
+// GetGroupsGroupIdStats 操作中间件
+func (siw *GroupsServerInterfaceWrapper) GetGroupsGroupIdStats(c *gin.Context) {
 
-func (response DeleteGroupsGroupId404JSONResponse) VisitDeleteGroupsGroupIdResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	var err error
 
-	return json.NewEncoder(w).Encode(response)
+	// ------------- 路径参数 "groupId" -------------
+	var groupId int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "groupId", c.Param("groupId"), &groupId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("参数 groupId 格式无效: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(JWT鉴权Scopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetGroupsGroupIdStats(c, groupId)
 }
 
-type DeleteGroupsGroupId409JSONResponse Conflict
+// PostGroupsGroupIdTransferOwnership 操作中间件
+func (siw *GroupsServerInterfaceWrapper) PostGroupsGroupIdTransferOwnership(c *gin.Context) {
 
-func (response DeleteGroupsGroupId409JSONResponse) VisitDeleteGroupsGroupIdResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(409)
+	var err error
 
-	return json.NewEncoder(w).Encode(response)
+	// ------------- 路径参数 "groupId" -------------
+	var groupId int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "groupId", c.Param("groupId"), &groupId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("参数 groupId 格式无效: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(JWT鉴权Scopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostGroupsGroupIdTransferOwnership(c, groupId)
 }
 
-type GetGroupsGroupIdRequestObject struct {
-	GroupId int `json:"groupId"`
+// GroupsGinServerOptions 提供 Gin 服务器的选项。
+type GroupsGinServerOptions struct {
+	BaseURL      string
+	Middlewares  []GroupsMiddlewareFunc
+	ErrorHandler func(*gin.Context, error, int)
 }
 
-type GetGroupsGroupIdResponseObject interface {
-	VisitGetGroupsGroupIdResponse(w http.ResponseWriter) error
+// RegisterGroupsHandlers 创建与 OpenAPI 规范匹配的 http.Handler 路由。
+func RegisterGroupsHandlers(router gin.IRouter, si GroupsServerInterface) {
+	RegisterGroupsHandlersWithOptions(router, si, GroupsGinServerOptions{})
 }
 
-type GetGroupsGroupId200JSONResponse struct {
-	Code string `json:"code"`
-	Data Group  `json:"data"`
+// RegisterGroupsHandlersWithOptions 创建带有附加选项的 http.Handler
+func RegisterGroupsHandlersWithOptions(router gin.IRouter, si GroupsServerInterface, options GroupsGinServerOptions) {
+	errorHandler := options.ErrorHandler
+	if errorHandler == nil {
+		errorHandler = func(c *gin.Context, err error, statusCode int) {
+			c.JSON(statusCode, gin.H{"msg": err.Error()})
+		}
+	}
+
+	wrapper := GroupsServerInterfaceWrapper{
+		Handler:            si,
+		HandlerMiddlewares: options.Middlewares,
+		ErrorHandler:       errorHandler,
+	}
+
+	router.GET(options.BaseURL+"/groups", wrapper.GetGroups)
+	router.POST(options.BaseURL+"/groups", wrapper.PostGroups)
+	router.POST(options.BaseURL+"/groups/join-by-code", wrapper.PostGroupsJoinByCode)
+	router.DELETE(options.BaseURL+"/groups/:groupId", wrapper.DeleteGroupsGroupId)
+	router.GET(options.BaseURL+"/groups/:groupId", wrapper.GetGroupsGroupId)
+	router.PUT(options.BaseURL+"/groups/:groupId", wrapper.PutGroupsGroupId)
+	router.GET(options.BaseURL+"/groups/:groupId/invites", wrapper.GetGroupsGroupIdInvites)
+	router.POST(options.BaseURL+"/groups/:groupId/invites", wrapper.PostGroupsGroupIdInvites)
+	router.DELETE(options.BaseURL+"/groups/:groupId/invites/:inviteId", wrapper.DeleteGroupsGroupIdInvitesInviteId)
+	router.GET(options.BaseURL+"/groups/:groupId/join-requests", wrapper.GetGroupsGroupIdJoinRequests)
+	router.POST(options.BaseURL+"/groups/:groupId/join-requests", wrapper.PostGroupsGroupIdJoinRequests)
+	router.PUT(options.BaseURL+"/groups/:groupId/join-requests/:requestId", wrapper.PutGroupsGroupIdJoinRequestsRequestId)
+	router.GET(options.BaseURL+"/groups/:groupId/members", wrapper.GetGroupsGroupIdMembers)
+	router.DELETE(options.BaseURL+"/groups/:groupId/members/:userId", wrapper.DeleteGroupsGroupIdMembersUserId)
+	router.PUT(options.BaseURL+"/groups/:groupId/members/:userId/role", wrapper.PutGroupsGroupIdMembersUserIdRole)
+	router.GET(options.BaseURL+"/groups/:groupId/my-status", wrapper.GetGroupsGroupIdMyStatus)
+	router.PUT(options.BaseURL+"/groups/:groupId/parent", wrapper.PutGroupsGroupIdParent)
+	router.GET(options.BaseURL+"/groups/:groupId/role-changes", wrapper.GetGroupsGroupIdRoleChanges)
+	router.GET(options.BaseURL+"/groups/:groupId/roles", wrapper.GetGroupsGroupIdRoles)
+	router.POST(options.BaseURL+"/groups/:groupId/roles", wrapper.PostGroupsGroupIdRoles)
+	router.DELETE(options.BaseURL+"/groups/:groupId/roles/:roleId", wrapper.DeleteGroupsGroupIdRolesRoleId)
+	router.PUT(options.BaseURL+"/groups/:groupId/roles/:roleId", wrapper.PutGroupsGroupIdRolesRoleId)
+	router.GET(options.BaseURL+"/groups/:groupId/stats", wrapper.GetGroupsGroupIdStats)
+	router.POST(options.BaseURL+"/groups/:groupId/transfer-ownership", wrapper.PostGroupsGroupIdTransferOwnership)
 }
 
-func (response GetGroupsGroupId200JSONResponse) VisitGetGroupsGroupIdResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+type GetGroupsRequestObject struct {
+	Params GetGroupsParams
+}
 
-	return json.NewEncoder(w).Encode(response)
+type GetGroupsResponseObject interface {
+	VisitGetGroupsResponse(w http.ResponseWriter) error
 }
 
-type GetGroupsGroupId401JSONResponse Unauthorized
+type GetGroups200JSONResponse struct {
+	Code string `json:"code"`
+	Data []struct {
+		// CreatedAt 创建时间（Unix时间戳，单位：秒）
+		CreatedAt int `json:"createdAt,omitempty"`
 
-func (response GetGroupsGroupId401JSONResponse) VisitGetGroupsGroupIdResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(401)
+		// CreatorId 创建者用户ID
+		CreatorId int `json:"creatorId,omitempty"`
 
-	return json.NewEncoder(w).Encode(response)
-}
+		// CreatorName 创建者用户名
+		CreatorName string `json:"creatorName,omitempty"`
 
-type GetGroupsGroupId403JSONResponse Forbidden
+		// Description 用户组描述
+		Description string `json:"description,omitempty"`
 
-func (response GetGroupsGroupId403JSONResponse) VisitGetGroupsGroupIdResponse(w http.ResponseWriter) error {
+		// GroupId 用户组ID
+		GroupId int `json:"groupId,omitempty"`
+
+		// GroupName 用户组名称
+		GroupName string `json:"groupName,omitempty"`
+
+		// JoinPolicy 加入方式：open(提交申请后自动通过)、approval(组管理员审批)、invite_only(只能凭邀请码加入)、closed(不接受新成员)
+		JoinPolicy GroupJoinPolicy `json:"joinPolicy"`
+
+		// MemberCount 成员数量
+		MemberCount int `json:"memberCount,omitempty"`
+
+		// ParentId 上级用户组ID，0为顶级用户组
+		ParentId int `json:"parentId,omitempty"`
+
+		// RequireAdmin2fa 是否要求该组管理员开启两步验证
+		RequireAdmin2fa bool      `json:"requireAdmin2fa"`
+		RoleInGroup     GroupRole `json:"roleInGroup,omitempty"`
+	} `json:"data"`
+}
+
+func (response GetGroups200JSONResponse) VisitGetGroupsResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(403)
+	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetGroupsGroupId404JSONResponse NotFound
+type GetGroups401JSONResponse Unauthorized
 
-func (response GetGroupsGroupId404JSONResponse) VisitGetGroupsGroupIdResponse(w http.ResponseWriter) error {
+func (response GetGroups401JSONResponse) VisitGetGroupsResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	w.WriteHeader(401)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type PutGroupsGroupIdRequestObject struct {
-	GroupId int `json:"groupId"`
-	Body    *PutGroupsGroupIdJSONRequestBody
+type PostGroupsRequestObject struct {
+	Body *PostGroupsJSONRequestBody
 }
 
-type PutGroupsGroupIdResponseObject interface {
-	VisitPutGroupsGroupIdResponse(w http.ResponseWriter) error
+type PostGroupsResponseObject interface {
+	VisitPostGroupsResponse(w http.ResponseWriter) error
 }
 
-type PutGroupsGroupId200JSONResponse struct {
+type PostGroups201JSONResponse struct {
 	Code string `json:"code"`
 	Data Group  `json:"data"`
 }
 
-func (response PutGroupsGroupId200JSONResponse) VisitPutGroupsGroupIdResponse(w http.ResponseWriter) error {
+func (response PostGroups201JSONResponse) VisitPostGroupsResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	w.WriteHeader(201)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type PutGroupsGroupId400JSONResponse BadRequest
+type PostGroups400JSONResponse BadRequest
 
-func (response PutGroupsGroupId400JSONResponse) VisitPutGroupsGroupIdResponse(w http.ResponseWriter) error {
+func (response PostGroups400JSONResponse) VisitPostGroupsResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(400)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type PutGroupsGroupId401JSONResponse Unauthorized
+type PostGroups401JSONResponse Unauthorized
 
-func (response PutGroupsGroupId401JSONResponse) VisitPutGroupsGroupIdResponse(w http.ResponseWriter) error {
+func (response PostGroups401JSONResponse) VisitPostGroupsResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(401)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type PutGroupsGroupId403JSONResponse Forbidden
+type PostGroups403JSONResponse Forbidden
 
-func (response PutGroupsGroupId403JSONResponse) VisitPutGroupsGroupIdResponse(w http.ResponseWriter) error {
+func (response PostGroups403JSONResponse) VisitPostGroupsResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(403)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type PutGroupsGroupId404JSONResponse NotFound
+type PostGroups404JSONResponse NotFound
 
-func (response PutGroupsGroupId404JSONResponse) VisitPutGroupsGroupIdResponse(w http.ResponseWriter) error {
+func (response PostGroups404JSONResponse) VisitPostGroupsResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(404)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetGroupsGroupIdJoinRequestsRequestObject struct {
-	GroupId int `json:"groupId"`
-	Params  GetGroupsGroupIdJoinRequestsParams
-}
+type PostGroups500JSONResponse InternalServerError
 
-type GetGroupsGroupIdJoinRequestsResponseObject interface {
-	VisitGetGroupsGroupIdJoinRequestsResponse(w http.ResponseWriter) error
-}
+func (response PostGroups500JSONResponse) VisitPostGroupsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
 
-type GetGroupsGroupIdJoinRequests200JSONResponse struct {
-	Code string        `json:"code"`
-	Data []JoinRequest `json:"data"`
+	return json.NewEncoder(w).Encode(response)
 }
 
-func (response GetGroupsGroupIdJoinRequests200JSONResponse) VisitGetGroupsGroupIdJoinRequestsResponse(w http.ResponseWriter) error {
+type PostGroupsJoinByCodeRequestObject struct {
+	Body *PostGroupsJoinByCodeJSONRequestBody
+}
+
+type PostGroupsJoinByCodeResponseObject interface {
+	VisitPostGroupsJoinByCodeResponse(w http.ResponseWriter) error
+}
+
+type PostGroupsJoinByCode200JSONResponse struct {
+	Code string `json:"code"`
+	Data struct {
+		// GroupId 用户组ID
+		GroupId int `json:"groupId"`
+
+		// GroupName 用户组名称
+		GroupName string `json:"groupName"`
+
+		// Role 加入后的组内角色
+		Role string `json:"role"`
+	} `json:"data"`
+}
+
+func (response PostGroupsJoinByCode200JSONResponse) VisitPostGroupsJoinByCodeResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetGroupsGroupIdJoinRequests401JSONResponse Unauthorized
+type PostGroupsJoinByCode401JSONResponse Unauthorized
 
-func (response GetGroupsGroupIdJoinRequests401JSONResponse) VisitGetGroupsGroupIdJoinRequestsResponse(w http.ResponseWriter) error {
+func (response PostGroupsJoinByCode401JSONResponse) VisitPostGroupsJoinByCodeResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(401)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetGroupsGroupIdJoinRequests403JSONResponse Forbidden
+type PostGroupsJoinByCode403JSONResponse Forbidden
 
-func (response GetGroupsGroupIdJoinRequests403JSONResponse) VisitGetGroupsGroupIdJoinRequestsResponse(w http.ResponseWriter) error {
+func (response PostGroupsJoinByCode403JSONResponse) VisitPostGroupsJoinByCodeResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(403)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetGroupsGroupIdJoinRequests404JSONResponse NotFound
+type PostGroupsJoinByCode404JSONResponse NotFound
 
-func (response GetGroupsGroupIdJoinRequests404JSONResponse) VisitGetGroupsGroupIdJoinRequestsResponse(w http.ResponseWriter) error {
+func (response PostGroupsJoinByCode404JSONResponse) VisitPostGroupsJoinByCodeResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(404)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetGroupsGroupIdJoinRequests500JSONResponse InternalServerError
+type PostGroupsJoinByCode409JSONResponse Conflict
 
-func (response GetGroupsGroupIdJoinRequests500JSONResponse) VisitGetGroupsGroupIdJoinRequestsResponse(w http.ResponseWriter) error {
+func (response PostGroupsJoinByCode409JSONResponse) VisitPostGroupsJoinByCodeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostGroupsJoinByCode410JSONResponse BadRequest
+
+func (response PostGroupsJoinByCode410JSONResponse) VisitPostGroupsJoinByCodeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(410)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostGroupsJoinByCode500JSONResponse InternalServerError
+
+func (response PostGroupsJoinByCode500JSONResponse) VisitPostGroupsJoinByCodeResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(500)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type PostGroupsGroupIdJoinRequestsRequestObject struct {
+type DeleteGroupsGroupIdRequestObject struct {
 	GroupId int `json:"groupId"`
-	Body    *PostGroupsGroupIdJoinRequestsJSONRequestBody
 }
 
-type PostGroupsGroupIdJoinRequestsResponseObject interface {
-	VisitPostGroupsGroupIdJoinRequestsResponse(w http.ResponseWriter) error
+type DeleteGroupsGroupIdResponseObject interface {
+	VisitDeleteGroupsGroupIdResponse(w http.ResponseWriter) error
 }
 
-type PostGroupsGroupIdJoinRequests201JSONResponse struct {
-	Code string      `json:"code"`
-	Data JoinRequest `json:"data"`
-}
+type DeleteGroupsGroupId200JSONResponse Success
 
-func (response PostGroupsGroupIdJoinRequests201JSONResponse) VisitPostGroupsGroupIdJoinRequestsResponse(w http.ResponseWriter) error {
+func (response DeleteGroupsGroupId200JSONResponse) VisitDeleteGroupsGroupIdResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(201)
+	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type PostGroupsGroupIdJoinRequests401JSONResponse Unauthorized
+type DeleteGroupsGroupId401JSONResponse Unauthorized
 
-func (response PostGroupsGroupIdJoinRequests401JSONResponse) VisitPostGroupsGroupIdJoinRequestsResponse(w http.ResponseWriter) error {
+func (response DeleteGroupsGroupId401JSONResponse) VisitDeleteGroupsGroupIdResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(401)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type PostGroupsGroupIdJoinRequests403JSONResponse Forbidden
+type DeleteGroupsGroupId403JSONResponse Forbidden
 
-func (response PostGroupsGroupIdJoinRequests403JSONResponse) VisitPostGroupsGroupIdJoinRequestsResponse(w http.ResponseWriter) error {
+func (response DeleteGroupsGroupId403JSONResponse) VisitDeleteGroupsGroupIdResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(403)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type PostGroupsGroupIdJoinRequests404JSONResponse NotFound
+type DeleteGroupsGroupId404JSONResponse NotFound
 
-func (response PostGroupsGroupIdJoinRequests404JSONResponse) VisitPostGroupsGroupIdJoinRequestsResponse(w http.ResponseWriter) error {
+func (response DeleteGroupsGroupId404JSONResponse) VisitDeleteGroupsGroupIdResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(404)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type PostGroupsGroupIdJoinRequests409JSONResponse Conflict
+type DeleteGroupsGroupId409JSONResponse Conflict
 
-func (response PostGroupsGroupIdJoinRequests409JSONResponse) VisitPostGroupsGroupIdJoinRequestsResponse(w http.ResponseWriter) error {
+func (response DeleteGroupsGroupId409JSONResponse) VisitDeleteGroupsGroupIdResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(409)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type PostGroupsGroupIdJoinRequests500JSONResponse InternalServerError
+type GetGroupsGroupIdRequestObject struct {
+	GroupId int `json:"groupId"`
+}
 
-func (response PostGroupsGroupIdJoinRequests500JSONResponse) VisitPostGroupsGroupIdJoinRequestsResponse(w http.ResponseWriter) error {
+type GetGroupsGroupIdResponseObject interface {
+	VisitGetGroupsGroupIdResponse(w http.ResponseWriter) error
+}
+
+type GetGroupsGroupId200JSONResponse struct {
+	Code string `json:"code"`
+	Data Group  `json:"data"`
+}
+
+func (response GetGroupsGroupId200JSONResponse) VisitGetGroupsGroupIdResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type PutGroupsGroupIdJoinRequestsRequestIdRequestObject struct {
-	GroupId   int `json:"groupId"`
-	RequestId int `json:"requestId"`
-	Body      *PutGroupsGroupIdJoinRequestsRequestIdJSONRequestBody
-}
+type GetGroupsGroupId401JSONResponse Unauthorized
 
-type PutGroupsGroupIdJoinRequestsRequestIdResponseObject interface {
-	VisitPutGroupsGroupIdJoinRequestsRequestIdResponse(w http.ResponseWriter) error
+func (response GetGroupsGroupId401JSONResponse) VisitGetGroupsGroupIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-type PutGroupsGroupIdJoinRequestsRequestId200JSONResponse Success
+type GetGroupsGroupId403JSONResponse Forbidden
 
-func (response PutGroupsGroupIdJoinRequestsRequestId200JSONResponse) VisitPutGroupsGroupIdJoinRequestsRequestIdResponse(w http.ResponseWriter) error {
+func (response GetGroupsGroupId403JSONResponse) VisitGetGroupsGroupIdResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	w.WriteHeader(403)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type PutGroupsGroupIdJoinRequestsRequestId400JSONResponse BadRequest
+type GetGroupsGroupId404JSONResponse NotFound
 
-func (response PutGroupsGroupIdJoinRequestsRequestId400JSONResponse) VisitPutGroupsGroupIdJoinRequestsRequestIdResponse(w http.ResponseWriter) error {
+func (response GetGroupsGroupId404JSONResponse) VisitGetGroupsGroupIdResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	w.WriteHeader(404)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type PutGroupsGroupIdJoinRequestsRequestId401JSONResponse Unauthorized
+type PutGroupsGroupIdRequestObject struct {
+	GroupId int `json:"groupId"`
+	Body    *PutGroupsGroupIdJSONRequestBody
+}
 
-func (response PutGroupsGroupIdJoinRequestsRequestId401JSONResponse) VisitPutGroupsGroupIdJoinRequestsRequestIdResponse(w http.ResponseWriter) error {
+type PutGroupsGroupIdResponseObject interface {
+	VisitPutGroupsGroupIdResponse(w http.ResponseWriter) error
+}
+
+type PutGroupsGroupId200JSONResponse struct {
+	Code string `json:"code"`
+	Data Group  `json:"data"`
+}
+
+func (response PutGroupsGroupId200JSONResponse) VisitPutGroupsGroupIdResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(401)
+	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type PutGroupsGroupIdJoinRequestsRequestId403JSONResponse Forbidden
+type PutGroupsGroupId400JSONResponse BadRequest
 
-func (response PutGroupsGroupIdJoinRequestsRequestId403JSONResponse) VisitPutGroupsGroupIdJoinRequestsRequestIdResponse(w http.ResponseWriter) error {
+func (response PutGroupsGroupId400JSONResponse) VisitPutGroupsGroupIdResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(403)
+	w.WriteHeader(400)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type PutGroupsGroupIdJoinRequestsRequestId404JSONResponse NotFound
+type PutGroupsGroupId401JSONResponse Unauthorized
 
-func (response PutGroupsGroupIdJoinRequestsRequestId404JSONResponse) VisitPutGroupsGroupIdJoinRequestsRequestIdResponse(w http.ResponseWriter) error {
+func (response PutGroupsGroupId401JSONResponse) VisitPutGroupsGroupIdResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	w.WriteHeader(401)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type PutGroupsGroupIdJoinRequestsRequestId409JSONResponse Conflict
+type PutGroupsGroupId403JSONResponse Forbidden
 
-func (response PutGroupsGroupIdJoinRequestsRequestId409JSONResponse) VisitPutGroupsGroupIdJoinRequestsRequestIdResponse(w http.ResponseWriter) error {
+func (response PutGroupsGroupId403JSONResponse) VisitPutGroupsGroupIdResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(409)
+	w.WriteHeader(403)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type PutGroupsGroupIdJoinRequestsRequestId500JSONResponse InternalServerError
+type PutGroupsGroupId404JSONResponse NotFound
 
-func (response PutGroupsGroupIdJoinRequestsRequestId500JSONResponse) VisitPutGroupsGroupIdJoinRequestsRequestIdResponse(w http.ResponseWriter) error {
+func (response PutGroupsGroupId404JSONResponse) VisitPutGroupsGroupIdResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+	w.WriteHeader(404)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetGroupsGroupIdMembersRequestObject struct {
+type GetGroupsGroupIdInvitesRequestObject struct {
 	GroupId int `json:"groupId"`
 }
 
-type GetGroupsGroupIdMembersResponseObject interface {
-	VisitGetGroupsGroupIdMembersResponse(w http.ResponseWriter) error
+type GetGroupsGroupIdInvitesResponseObject interface {
+	VisitGetGroupsGroupIdInvitesResponse(w http.ResponseWriter) error
 }
 
-type GetGroupsGroupIdMembers200JSONResponse struct {
+type GetGroupsGroupIdInvites200JSONResponse struct {
 	Code string        `json:"code"`
-	Data []GroupMember `json:"data"`
+	Data []GroupInvite `json:"data"`
 }
 
-func (response GetGroupsGroupIdMembers200JSONResponse) VisitGetGroupsGroupIdMembersResponse(w http.ResponseWriter) error {
+func (response GetGroupsGroupIdInvites200JSONResponse) VisitGetGroupsGroupIdInvitesResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetGroupsGroupIdMembers401JSONResponse Unauthorized
+type GetGroupsGroupIdInvites401JSONResponse Unauthorized
 
-func (response GetGroupsGroupIdMembers401JSONResponse) VisitGetGroupsGroupIdMembersResponse(w http.ResponseWriter) error {
+func (response GetGroupsGroupIdInvites401JSONResponse) VisitGetGroupsGroupIdInvitesResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(401)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetGroupsGroupIdMembers403JSONResponse Forbidden
+type GetGroupsGroupIdInvites403JSONResponse Forbidden
 
-func (response GetGroupsGroupIdMembers403JSONResponse) VisitGetGroupsGroupIdMembersResponse(w http.ResponseWriter) error {
+func (response GetGroupsGroupIdInvites403JSONResponse) VisitGetGroupsGroupIdInvitesResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(403)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetGroupsGroupIdMembers404JSONResponse NotFound
+type GetGroupsGroupIdInvites404JSONResponse NotFound
 
-func (response GetGroupsGroupIdMembers404JSONResponse) VisitGetGroupsGroupIdMembersResponse(w http.ResponseWriter) error {
+func (response GetGroupsGroupIdInvites404JSONResponse) VisitGetGroupsGroupIdInvitesResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(404)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetGroupsGroupIdMembers500JSONResponse InternalServerError
+type GetGroupsGroupIdInvites500JSONResponse InternalServerError
 
-func (response GetGroupsGroupIdMembers500JSONResponse) VisitGetGroupsGroupIdMembersResponse(w http.ResponseWriter) error {
+func (response GetGroupsGroupIdInvites500JSONResponse) VisitGetGroupsGroupIdInvitesResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(500)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type DeleteGroupsGroupIdMembersUserIdRequestObject struct {
+type PostGroupsGroupIdInvitesRequestObject struct {
 	GroupId int `json:"groupId"`
-	UserId  int `json:"userId"`
+	Body    *PostGroupsGroupIdInvitesJSONRequestBody
 }
 
-type DeleteGroupsGroupIdMembersUserIdResponseObject interface {
-	VisitDeleteGroupsGroupIdMembersUserIdResponse(w http.ResponseWriter) error
+type PostGroupsGroupIdInvitesResponseObject interface {
+	VisitPostGroupsGroupIdInvitesResponse(w http.ResponseWriter) error
 }
 
-type DeleteGroupsGroupIdMembersUserId200JSONResponse Success
+type PostGroupsGroupIdInvites201JSONResponse struct {
+	Code string      `json:"code"`
+	Data GroupInvite `json:"data"`
+}
 
-func (response DeleteGroupsGroupIdMembersUserId200JSONResponse) VisitDeleteGroupsGroupIdMembersUserIdResponse(w http.ResponseWriter) error {
+func (response PostGroupsGroupIdInvites201JSONResponse) VisitPostGroupsGroupIdInvitesResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	w.WriteHeader(201)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type DeleteGroupsGroupIdMembersUserId401JSONResponse Unauthorized
+type PostGroupsGroupIdInvites400JSONResponse BadRequest
 
-func (response DeleteGroupsGroupIdMembersUserId401JSONResponse) VisitDeleteGroupsGroupIdMembersUserIdResponse(w http.ResponseWriter) error {
+func (response PostGroupsGroupIdInvites400JSONResponse) VisitPostGroupsGroupIdInvitesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostGroupsGroupIdInvites401JSONResponse Unauthorized
+
+func (response PostGroupsGroupIdInvites401JSONResponse) VisitPostGroupsGroupIdInvitesResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(401)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type DeleteGroupsGroupIdMembersUserId403JSONResponse Forbidden
+type PostGroupsGroupIdInvites403JSONResponse Forbidden
 
-func (response DeleteGroupsGroupIdMembersUserId403JSONResponse) VisitDeleteGroupsGroupIdMembersUserIdResponse(w http.ResponseWriter) error {
+func (response PostGroupsGroupIdInvites403JSONResponse) VisitPostGroupsGroupIdInvitesResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(403)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type DeleteGroupsGroupIdMembersUserId404JSONResponse NotFound
+type PostGroupsGroupIdInvites404JSONResponse NotFound
 
-func (response DeleteGroupsGroupIdMembersUserId404JSONResponse) VisitDeleteGroupsGroupIdMembersUserIdResponse(w http.ResponseWriter) error {
+func (response PostGroupsGroupIdInvites404JSONResponse) VisitPostGroupsGroupIdInvitesResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(404)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type DeleteGroupsGroupIdMembersUserId500JSONResponse InternalServerError
+type PostGroupsGroupIdInvites409JSONResponse Conflict
 
-func (response DeleteGroupsGroupIdMembersUserId500JSONResponse) VisitDeleteGroupsGroupIdMembersUserIdResponse(w http.ResponseWriter) error {
+func (response PostGroupsGroupIdInvites409JSONResponse) VisitPostGroupsGroupIdInvitesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostGroupsGroupIdInvites500JSONResponse InternalServerError
+
+func (response PostGroupsGroupIdInvites500JSONResponse) VisitPostGroupsGroupIdInvitesResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(500)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetGroupsGroupIdMyStatusRequestObject struct {
-	GroupId int `json:"groupId"`
+type DeleteGroupsGroupIdInvitesInviteIdRequestObject struct {
+	GroupId  int `json:"groupId"`
+	InviteId int `json:"inviteId"`
 }
 
-type GetGroupsGroupIdMyStatusResponseObject interface {
-	VisitGetGroupsGroupIdMyStatusResponse(w http.ResponseWriter) error
+type DeleteGroupsGroupIdInvitesInviteIdResponseObject interface {
+	VisitDeleteGroupsGroupIdInvitesInviteIdResponse(w http.ResponseWriter) error
 }
 
-type GetGroupsGroupIdMyStatus200JSONResponse struct {
-	Code string `json:"code"`
-	Data struct {
-		// JoinRequestId 加入申请ID (仅当status为pending或rejected时有值)
-		JoinRequestId int `json:"joinRequestId,omitempty"`
+type DeleteGroupsGroupIdInvitesInviteId200JSONResponse Success
 
-		// Message 附加信息说明 (可选)
-		Message string `json:"message,omitempty"`
+func (response DeleteGroupsGroupIdInvitesInviteId200JSONResponse) VisitDeleteGroupsGroupIdInvitesInviteIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
 
-		// Status 用户在组中的状态：none(未关联)、pending(申请中)、member(普通成员)、rejected(申请被拒绝)
-		Status GroupMembershipStatus `json:"status"`
-	} `json:"data"`
+	return json.NewEncoder(w).Encode(response)
 }
 
-func (response GetGroupsGroupIdMyStatus200JSONResponse) VisitGetGroupsGroupIdMyStatusResponse(w http.ResponseWriter) error {
+type DeleteGroupsGroupIdInvitesInviteId401JSONResponse Unauthorized
+
+func (response DeleteGroupsGroupIdInvitesInviteId401JSONResponse) VisitDeleteGroupsGroupIdInvitesInviteIdResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	w.WriteHeader(401)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetGroupsGroupIdMyStatus401JSONResponse Unauthorized
+type DeleteGroupsGroupIdInvitesInviteId403JSONResponse Forbidden
 
-func (response GetGroupsGroupIdMyStatus401JSONResponse) VisitGetGroupsGroupIdMyStatusResponse(w http.ResponseWriter) error {
+func (response DeleteGroupsGroupIdInvitesInviteId403JSONResponse) VisitDeleteGroupsGroupIdInvitesInviteIdResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(401)
+	w.WriteHeader(403)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetGroupsGroupIdMyStatus404JSONResponse NotFound
+type DeleteGroupsGroupIdInvitesInviteId404JSONResponse NotFound
 
-func (response GetGroupsGroupIdMyStatus404JSONResponse) VisitGetGroupsGroupIdMyStatusResponse(w http.ResponseWriter) error {
+func (response DeleteGroupsGroupIdInvitesInviteId404JSONResponse) VisitDeleteGroupsGroupIdInvitesInviteIdResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(404)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetGroupsGroupIdMyStatus500JSONResponse InternalServerError
+type DeleteGroupsGroupIdInvitesInviteId500JSONResponse InternalServerError
 
-func (response GetGroupsGroupIdMyStatus500JSONResponse) VisitGetGroupsGroupIdMyStatusResponse(w http.ResponseWriter) error {
+func (response DeleteGroupsGroupIdInvitesInviteId500JSONResponse) VisitDeleteGroupsGroupIdInvitesInviteIdResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(500)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-// GroupsStrictServerInterface represents all server handlers.
-type GroupsStrictServerInterface interface {
-	// 获取用户相关的用户组列表
-	// (GET /groups)
-	GetGroups(ctx context.Context, request GetGroupsRequestObject) (GetGroupsResponseObject, error)
-	// 创建用户组
-	// (POST /groups)
-	PostGroups(ctx context.Context, request PostGroupsRequestObject) (PostGroupsResponseObject, error)
-	// 删除用户组
-	// (DELETE /groups/{groupId})
-	DeleteGroupsGroupId(ctx context.Context, request DeleteGroupsGroupIdRequestObject) (DeleteGroupsGroupIdResponseObject, error)
-	// 获取用户组详细信息
-	// (GET /groups/{groupId})
-	GetGroupsGroupId(ctx context.Context, request GetGroupsGroupIdRequestObject) (GetGroupsGroupIdResponseObject, error)
-	// 修改用户组信息
-	// (PUT /groups/{groupId})
-	PutGroupsGroupId(ctx context.Context, request PutGroupsGroupIdRequestObject) (PutGroupsGroupIdResponseObject, error)
-	// 查看用户组的加入申请列表
-	// (GET /groups/{groupId}/join-requests)
-	GetGroupsGroupIdJoinRequests(ctx context.Context, request GetGroupsGroupIdJoinRequestsRequestObject) (GetGroupsGroupIdJoinRequestsResponseObject, error)
-	// 申请加入用户组
-	// (POST /groups/{groupId}/join-requests)
-	PostGroupsGroupIdJoinRequests(ctx context.Context, request PostGroupsGroupIdJoinRequestsRequestObject) (PostGroupsGroupIdJoinRequestsResponseObject, error)
+type GetGroupsGroupIdJoinRequestsRequestObject struct {
+	GroupId int `json:"groupId"`
+	Params  GetGroupsGroupIdJoinRequestsParams
+}
+
+type GetGroupsGroupIdJoinRequestsResponseObject interface {
+	VisitGetGroupsGroupIdJoinRequestsResponse(w http.ResponseWriter) error
+}
+
+type GetGroupsGroupIdJoinRequests200JSONResponse struct {
+	Code string        `json:"code"`
+	Data []JoinRequest `json:"data"`
+}
+
+func (response GetGroupsGroupIdJoinRequests200JSONResponse) VisitGetGroupsGroupIdJoinRequestsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetGroupsGroupIdJoinRequests401JSONResponse Unauthorized
+
+func (response GetGroupsGroupIdJoinRequests401JSONResponse) VisitGetGroupsGroupIdJoinRequestsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetGroupsGroupIdJoinRequests403JSONResponse Forbidden
+
+func (response GetGroupsGroupIdJoinRequests403JSONResponse) VisitGetGroupsGroupIdJoinRequestsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetGroupsGroupIdJoinRequests404JSONResponse NotFound
+
+func (response GetGroupsGroupIdJoinRequests404JSONResponse) VisitGetGroupsGroupIdJoinRequestsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetGroupsGroupIdJoinRequests500JSONResponse InternalServerError
+
+func (response GetGroupsGroupIdJoinRequests500JSONResponse) VisitGetGroupsGroupIdJoinRequestsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostGroupsGroupIdJoinRequestsRequestObject struct {
+	GroupId int `json:"groupId"`
+	Body    *PostGroupsGroupIdJoinRequestsJSONRequestBody
+}
+
+type PostGroupsGroupIdJoinRequestsResponseObject interface {
+	VisitPostGroupsGroupIdJoinRequestsResponse(w http.ResponseWriter) error
+}
+
+type PostGroupsGroupIdJoinRequests201JSONResponse struct {
+	Code string      `json:"code"`
+	Data JoinRequest `json:"data"`
+}
+
+func (response PostGroupsGroupIdJoinRequests201JSONResponse) VisitPostGroupsGroupIdJoinRequestsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostGroupsGroupIdJoinRequests401JSONResponse Unauthorized
+
+func (response PostGroupsGroupIdJoinRequests401JSONResponse) VisitPostGroupsGroupIdJoinRequestsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostGroupsGroupIdJoinRequests403JSONResponse Forbidden
+
+func (response PostGroupsGroupIdJoinRequests403JSONResponse) VisitPostGroupsGroupIdJoinRequestsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostGroupsGroupIdJoinRequests404JSONResponse NotFound
+
+func (response PostGroupsGroupIdJoinRequests404JSONResponse) VisitPostGroupsGroupIdJoinRequestsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostGroupsGroupIdJoinRequests409JSONResponse Conflict
+
+func (response PostGroupsGroupIdJoinRequests409JSONResponse) VisitPostGroupsGroupIdJoinRequestsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostGroupsGroupIdJoinRequests500JSONResponse InternalServerError
+
+func (response PostGroupsGroupIdJoinRequests500JSONResponse) VisitPostGroupsGroupIdJoinRequestsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutGroupsGroupIdJoinRequestsRequestIdRequestObject struct {
+	GroupId   int `json:"groupId"`
+	RequestId int `json:"requestId"`
+	Body      *PutGroupsGroupIdJoinRequestsRequestIdJSONRequestBody
+}
+
+type PutGroupsGroupIdJoinRequestsRequestIdResponseObject interface {
+	VisitPutGroupsGroupIdJoinRequestsRequestIdResponse(w http.ResponseWriter) error
+}
+
+type PutGroupsGroupIdJoinRequestsRequestId200JSONResponse Success
+
+func (response PutGroupsGroupIdJoinRequestsRequestId200JSONResponse) VisitPutGroupsGroupIdJoinRequestsRequestIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutGroupsGroupIdJoinRequestsRequestId400JSONResponse BadRequest
+
+func (response PutGroupsGroupIdJoinRequestsRequestId400JSONResponse) VisitPutGroupsGroupIdJoinRequestsRequestIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutGroupsGroupIdJoinRequestsRequestId401JSONResponse Unauthorized
+
+func (response PutGroupsGroupIdJoinRequestsRequestId401JSONResponse) VisitPutGroupsGroupIdJoinRequestsRequestIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutGroupsGroupIdJoinRequestsRequestId403JSONResponse Forbidden
+
+func (response PutGroupsGroupIdJoinRequestsRequestId403JSONResponse) VisitPutGroupsGroupIdJoinRequestsRequestIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutGroupsGroupIdJoinRequestsRequestId404JSONResponse NotFound
+
+func (response PutGroupsGroupIdJoinRequestsRequestId404JSONResponse) VisitPutGroupsGroupIdJoinRequestsRequestIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutGroupsGroupIdJoinRequestsRequestId409JSONResponse Conflict
+
+func (response PutGroupsGroupIdJoinRequestsRequestId409JSONResponse) VisitPutGroupsGroupIdJoinRequestsRequestIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutGroupsGroupIdJoinRequestsRequestId500JSONResponse InternalServerError
+
+func (response PutGroupsGroupIdJoinRequestsRequestId500JSONResponse) VisitPutGroupsGroupIdJoinRequestsRequestIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetGroupsGroupIdMembersRequestObject struct {
+	GroupId int `json:"groupId"`
+}
+
+type GetGroupsGroupIdMembersResponseObject interface {
+	VisitGetGroupsGroupIdMembersResponse(w http.ResponseWriter) error
+}
+
+type GetGroupsGroupIdMembers200JSONResponse struct {
+	Code string        `json:"code"`
+	Data []GroupMember `json:"data"`
+}
+
+func (response GetGroupsGroupIdMembers200JSONResponse) VisitGetGroupsGroupIdMembersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetGroupsGroupIdMembers401JSONResponse Unauthorized
+
+func (response GetGroupsGroupIdMembers401JSONResponse) VisitGetGroupsGroupIdMembersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetGroupsGroupIdMembers403JSONResponse Forbidden
+
+func (response GetGroupsGroupIdMembers403JSONResponse) VisitGetGroupsGroupIdMembersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetGroupsGroupIdMembers404JSONResponse NotFound
+
+func (response GetGroupsGroupIdMembers404JSONResponse) VisitGetGroupsGroupIdMembersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetGroupsGroupIdMembers500JSONResponse InternalServerError
+
+func (response GetGroupsGroupIdMembers500JSONResponse) VisitGetGroupsGroupIdMembersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteGroupsGroupIdMembersUserIdRequestObject struct {
+	GroupId int `json:"groupId"`
+	UserId  int `json:"userId"`
+}
+
+type DeleteGroupsGroupIdMembersUserIdResponseObject interface {
+	VisitDeleteGroupsGroupIdMembersUserIdResponse(w http.ResponseWriter) error
+}
+
+type DeleteGroupsGroupIdMembersUserId200JSONResponse Success
+
+func (response DeleteGroupsGroupIdMembersUserId200JSONResponse) VisitDeleteGroupsGroupIdMembersUserIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteGroupsGroupIdMembersUserId401JSONResponse Unauthorized
+
+func (response DeleteGroupsGroupIdMembersUserId401JSONResponse) VisitDeleteGroupsGroupIdMembersUserIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteGroupsGroupIdMembersUserId403JSONResponse Forbidden
+
+func (response DeleteGroupsGroupIdMembersUserId403JSONResponse) VisitDeleteGroupsGroupIdMembersUserIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteGroupsGroupIdMembersUserId404JSONResponse NotFound
+
+func (response DeleteGroupsGroupIdMembersUserId404JSONResponse) VisitDeleteGroupsGroupIdMembersUserIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteGroupsGroupIdMembersUserId500JSONResponse InternalServerError
+
+func (response DeleteGroupsGroupIdMembersUserId500JSONResponse) VisitDeleteGroupsGroupIdMembersUserIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutGroupsGroupIdMembersUserIdRoleRequestObject struct {
+	GroupId int `json:"groupId"`
+	UserId  int `json:"userId"`
+	Body    *PutGroupsGroupIdMembersUserIdRoleJSONRequestBody
+}
+
+type PutGroupsGroupIdMembersUserIdRoleResponseObject interface {
+	VisitPutGroupsGroupIdMembersUserIdRoleResponse(w http.ResponseWriter) error
+}
+
+type PutGroupsGroupIdMembersUserIdRole200JSONResponse struct {
+	Code string      `json:"code"`
+	Data GroupMember `json:"data"`
+}
+
+func (response PutGroupsGroupIdMembersUserIdRole200JSONResponse) VisitPutGroupsGroupIdMembersUserIdRoleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutGroupsGroupIdMembersUserIdRole400JSONResponse BadRequest
+
+func (response PutGroupsGroupIdMembersUserIdRole400JSONResponse) VisitPutGroupsGroupIdMembersUserIdRoleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutGroupsGroupIdMembersUserIdRole401JSONResponse Unauthorized
+
+func (response PutGroupsGroupIdMembersUserIdRole401JSONResponse) VisitPutGroupsGroupIdMembersUserIdRoleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutGroupsGroupIdMembersUserIdRole403JSONResponse Forbidden
+
+func (response PutGroupsGroupIdMembersUserIdRole403JSONResponse) VisitPutGroupsGroupIdMembersUserIdRoleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutGroupsGroupIdMembersUserIdRole404JSONResponse NotFound
+
+func (response PutGroupsGroupIdMembersUserIdRole404JSONResponse) VisitPutGroupsGroupIdMembersUserIdRoleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutGroupsGroupIdMembersUserIdRole409JSONResponse Conflict
+
+func (response PutGroupsGroupIdMembersUserIdRole409JSONResponse) VisitPutGroupsGroupIdMembersUserIdRoleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutGroupsGroupIdMembersUserIdRole500JSONResponse InternalServerError
+
+func (response PutGroupsGroupIdMembersUserIdRole500JSONResponse) VisitPutGroupsGroupIdMembersUserIdRoleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetGroupsGroupIdMyStatusRequestObject struct {
+	GroupId int `json:"groupId"`
+}
+
+type GetGroupsGroupIdMyStatusResponseObject interface {
+	VisitGetGroupsGroupIdMyStatusResponse(w http.ResponseWriter) error
+}
+
+type GetGroupsGroupIdMyStatus200JSONResponse struct {
+	Code string `json:"code"`
+	Data struct {
+		// CanApply 当前用户是否可以提交加入申请：不是组成员、没有待审批的申请且用户组接受申请
+		CanApply bool `json:"canApply"`
+
+		// JoinPolicy 加入方式：open(提交申请后自动通过)、approval(组管理员审批)、invite_only(只能凭邀请码加入)、closed(不接受新成员)
+		JoinPolicy GroupJoinPolicy `json:"joinPolicy"`
+
+		// JoinRequestId 加入申请ID (仅当status为pending或rejected时有值)
+		JoinRequestId int `json:"joinRequestId,omitempty"`
+
+		// Message 附加信息说明 (可选)
+		Message string `json:"message,omitempty"`
+
+		// Permissions 当前用户在该组具有的组内权限，非组成员时为空
+		Permissions []GroupPermission `json:"permissions,omitempty"`
+
+		// Role 组内角色，担任自定义角色时为角色名称，非组成员时为空字符串
+		Role string `json:"role,omitempty"`
+
+		// Status 用户在组中的状态：none(未关联)、pending(申请中)、member(普通成员)、rejected(申请被拒绝)
+		Status GroupMembershipStatus `json:"status"`
+	} `json:"data"`
+}
+
+func (response GetGroupsGroupIdMyStatus200JSONResponse) VisitGetGroupsGroupIdMyStatusResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetGroupsGroupIdMyStatus401JSONResponse Unauthorized
+
+func (response GetGroupsGroupIdMyStatus401JSONResponse) VisitGetGroupsGroupIdMyStatusResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetGroupsGroupIdMyStatus404JSONResponse NotFound
+
+func (response GetGroupsGroupIdMyStatus404JSONResponse) VisitGetGroupsGroupIdMyStatusResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetGroupsGroupIdMyStatus500JSONResponse InternalServerError
+
+func (response GetGroupsGroupIdMyStatus500JSONResponse) VisitGetGroupsGroupIdMyStatusResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutGroupsGroupIdParentRequestObject struct {
+	GroupId int `json:"groupId"`
+	Body    *PutGroupsGroupIdParentJSONRequestBody
+}
+
+type PutGroupsGroupIdParentResponseObject interface {
+	VisitPutGroupsGroupIdParentResponse(w http.ResponseWriter) error
+}
+
+type PutGroupsGroupIdParent200JSONResponse struct {
+	Code string `json:"code"`
+	Data Group  `json:"data"`
+}
+
+func (response PutGroupsGroupIdParent200JSONResponse) VisitPutGroupsGroupIdParentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutGroupsGroupIdParent400JSONResponse BadRequest
+
+func (response PutGroupsGroupIdParent400JSONResponse) VisitPutGroupsGroupIdParentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutGroupsGroupIdParent401JSONResponse Unauthorized
+
+func (response PutGroupsGroupIdParent401JSONResponse) VisitPutGroupsGroupIdParentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutGroupsGroupIdParent403JSONResponse Forbidden
+
+func (response PutGroupsGroupIdParent403JSONResponse) VisitPutGroupsGroupIdParentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutGroupsGroupIdParent404JSONResponse NotFound
+
+func (response PutGroupsGroupIdParent404JSONResponse) VisitPutGroupsGroupIdParentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutGroupsGroupIdParent500JSONResponse InternalServerError
+
+func (response PutGroupsGroupIdParent500JSONResponse) VisitPutGroupsGroupIdParentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetGroupsGroupIdRoleChangesRequestObject struct {
+	GroupId int `json:"groupId"`
+	Params  GetGroupsGroupIdRoleChangesParams
+}
+
+type GetGroupsGroupIdRoleChangesResponseObject interface {
+	VisitGetGroupsGroupIdRoleChangesResponse(w http.ResponseWriter) error
+}
+
+type GetGroupsGroupIdRoleChanges200JSONResponse struct {
+	Code string `json:"code"`
+	Data struct {
+		Items []GroupRoleChange `json:"items"`
+
+		// Total 记录总数
+		Total int `json:"total"`
+	} `json:"data"`
+}
+
+func (response GetGroupsGroupIdRoleChanges200JSONResponse) VisitGetGroupsGroupIdRoleChangesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetGroupsGroupIdRoleChanges401JSONResponse Unauthorized
+
+func (response GetGroupsGroupIdRoleChanges401JSONResponse) VisitGetGroupsGroupIdRoleChangesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetGroupsGroupIdRoleChanges403JSONResponse Forbidden
+
+func (response GetGroupsGroupIdRoleChanges403JSONResponse) VisitGetGroupsGroupIdRoleChangesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetGroupsGroupIdRoleChanges404JSONResponse NotFound
+
+func (response GetGroupsGroupIdRoleChanges404JSONResponse) VisitGetGroupsGroupIdRoleChangesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetGroupsGroupIdRoleChanges500JSONResponse InternalServerError
+
+func (response GetGroupsGroupIdRoleChanges500JSONResponse) VisitGetGroupsGroupIdRoleChangesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetGroupsGroupIdRolesRequestObject struct {
+	GroupId int `json:"groupId"`
+}
+
+type GetGroupsGroupIdRolesResponseObject interface {
+	VisitGetGroupsGroupIdRolesResponse(w http.ResponseWriter) error
+}
+
+type GetGroupsGroupIdRoles200JSONResponse struct {
+	Code string                `json:"code"`
+	Data []GroupRoleDefinition `json:"data"`
+}
+
+func (response GetGroupsGroupIdRoles200JSONResponse) VisitGetGroupsGroupIdRolesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetGroupsGroupIdRoles401JSONResponse Unauthorized
+
+func (response GetGroupsGroupIdRoles401JSONResponse) VisitGetGroupsGroupIdRolesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetGroupsGroupIdRoles403JSONResponse Forbidden
+
+func (response GetGroupsGroupIdRoles403JSONResponse) VisitGetGroupsGroupIdRolesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetGroupsGroupIdRoles404JSONResponse NotFound
+
+func (response GetGroupsGroupIdRoles404JSONResponse) VisitGetGroupsGroupIdRolesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetGroupsGroupIdRoles500JSONResponse InternalServerError
+
+func (response GetGroupsGroupIdRoles500JSONResponse) VisitGetGroupsGroupIdRolesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostGroupsGroupIdRolesRequestObject struct {
+	GroupId int `json:"groupId"`
+	Body    *PostGroupsGroupIdRolesJSONRequestBody
+}
+
+type PostGroupsGroupIdRolesResponseObject interface {
+	VisitPostGroupsGroupIdRolesResponse(w http.ResponseWriter) error
+}
+
+type PostGroupsGroupIdRoles201JSONResponse struct {
+	Code string              `json:"code"`
+	Data GroupRoleDefinition `json:"data"`
+}
+
+func (response PostGroupsGroupIdRoles201JSONResponse) VisitPostGroupsGroupIdRolesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostGroupsGroupIdRoles400JSONResponse BadRequest
+
+func (response PostGroupsGroupIdRoles400JSONResponse) VisitPostGroupsGroupIdRolesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostGroupsGroupIdRoles401JSONResponse Unauthorized
+
+func (response PostGroupsGroupIdRoles401JSONResponse) VisitPostGroupsGroupIdRolesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostGroupsGroupIdRoles403JSONResponse Forbidden
+
+func (response PostGroupsGroupIdRoles403JSONResponse) VisitPostGroupsGroupIdRolesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostGroupsGroupIdRoles404JSONResponse NotFound
+
+func (response PostGroupsGroupIdRoles404JSONResponse) VisitPostGroupsGroupIdRolesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostGroupsGroupIdRoles409JSONResponse Conflict
+
+func (response PostGroupsGroupIdRoles409JSONResponse) VisitPostGroupsGroupIdRolesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostGroupsGroupIdRoles500JSONResponse InternalServerError
+
+func (response PostGroupsGroupIdRoles500JSONResponse) VisitPostGroupsGroupIdRolesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteGroupsGroupIdRolesRoleIdRequestObject struct {
+	GroupId int `json:"groupId"`
+	RoleId  int `json:"roleId"`
+}
+
+type DeleteGroupsGroupIdRolesRoleIdResponseObject interface {
+	VisitDeleteGroupsGroupIdRolesRoleIdResponse(w http.ResponseWriter) error
+}
+
+type DeleteGroupsGroupIdRolesRoleId200JSONResponse Success
+
+func (response DeleteGroupsGroupIdRolesRoleId200JSONResponse) VisitDeleteGroupsGroupIdRolesRoleIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteGroupsGroupIdRolesRoleId401JSONResponse Unauthorized
+
+func (response DeleteGroupsGroupIdRolesRoleId401JSONResponse) VisitDeleteGroupsGroupIdRolesRoleIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteGroupsGroupIdRolesRoleId403JSONResponse Forbidden
+
+func (response DeleteGroupsGroupIdRolesRoleId403JSONResponse) VisitDeleteGroupsGroupIdRolesRoleIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteGroupsGroupIdRolesRoleId404JSONResponse NotFound
+
+func (response DeleteGroupsGroupIdRolesRoleId404JSONResponse) VisitDeleteGroupsGroupIdRolesRoleIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteGroupsGroupIdRolesRoleId409JSONResponse Conflict
+
+func (response DeleteGroupsGroupIdRolesRoleId409JSONResponse) VisitDeleteGroupsGroupIdRolesRoleIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteGroupsGroupIdRolesRoleId500JSONResponse InternalServerError
+
+func (response DeleteGroupsGroupIdRolesRoleId500JSONResponse) VisitDeleteGroupsGroupIdRolesRoleIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutGroupsGroupIdRolesRoleIdRequestObject struct {
+	GroupId int `json:"groupId"`
+	RoleId  int `json:"roleId"`
+	Body    *PutGroupsGroupIdRolesRoleIdJSONRequestBody
+}
+
+type PutGroupsGroupIdRolesRoleIdResponseObject interface {
+	VisitPutGroupsGroupIdRolesRoleIdResponse(w http.ResponseWriter) error
+}
+
+type PutGroupsGroupIdRolesRoleId200JSONResponse struct {
+	Code string              `json:"code"`
+	Data GroupRoleDefinition `json:"data"`
+}
+
+func (response PutGroupsGroupIdRolesRoleId200JSONResponse) VisitPutGroupsGroupIdRolesRoleIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutGroupsGroupIdRolesRoleId400JSONResponse BadRequest
+
+func (response PutGroupsGroupIdRolesRoleId400JSONResponse) VisitPutGroupsGroupIdRolesRoleIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutGroupsGroupIdRolesRoleId401JSONResponse Unauthorized
+
+func (response PutGroupsGroupIdRolesRoleId401JSONResponse) VisitPutGroupsGroupIdRolesRoleIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutGroupsGroupIdRolesRoleId403JSONResponse Forbidden
+
+func (response PutGroupsGroupIdRolesRoleId403JSONResponse) VisitPutGroupsGroupIdRolesRoleIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutGroupsGroupIdRolesRoleId404JSONResponse NotFound
+
+func (response PutGroupsGroupIdRolesRoleId404JSONResponse) VisitPutGroupsGroupIdRolesRoleIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutGroupsGroupIdRolesRoleId409JSONResponse Conflict
+
+func (response PutGroupsGroupIdRolesRoleId409JSONResponse) VisitPutGroupsGroupIdRolesRoleIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutGroupsGroupIdRolesRoleId500JSONResponse InternalServerError
+
+func (response PutGroupsGroupIdRolesRoleId500JSONResponse) VisitPutGroupsGroupIdRolesRoleIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetGroupsGroupIdStatsRequestObject struct {
+	GroupId int `json:"groupId"`
+}
+
+type GetGroupsGroupIdStatsResponseObject interface {
+	VisitGetGroupsGroupIdStatsResponse(w http.ResponseWriter) error
+}
+
+type GetGroupsGroupIdStats200JSONResponse struct {
+	Code string `json:"code"`
+	Data struct {
+		Children []GroupStats `json:"children"`
+
+		// Total 用户组连同全部下级用户组汇总的统计数据
+		Total GroupStats `json:"total"`
+	} `json:"data"`
+}
+
+func (response GetGroupsGroupIdStats200JSONResponse) VisitGetGroupsGroupIdStatsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetGroupsGroupIdStats401JSONResponse Unauthorized
+
+func (response GetGroupsGroupIdStats401JSONResponse) VisitGetGroupsGroupIdStatsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetGroupsGroupIdStats403JSONResponse Forbidden
+
+func (response GetGroupsGroupIdStats403JSONResponse) VisitGetGroupsGroupIdStatsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetGroupsGroupIdStats404JSONResponse NotFound
+
+func (response GetGroupsGroupIdStats404JSONResponse) VisitGetGroupsGroupIdStatsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetGroupsGroupIdStats500JSONResponse InternalServerError
+
+func (response GetGroupsGroupIdStats500JSONResponse) VisitGetGroupsGroupIdStatsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostGroupsGroupIdTransferOwnershipRequestObject struct {
+	GroupId int `json:"groupId"`
+	Body    *PostGroupsGroupIdTransferOwnershipJSONRequestBody
+}
+
+type PostGroupsGroupIdTransferOwnershipResponseObject interface {
+	VisitPostGroupsGroupIdTransferOwnershipResponse(w http.ResponseWriter) error
+}
+
+type PostGroupsGroupIdTransferOwnership200JSONResponse struct {
+	Code string `json:"code"`
+	Data Group  `json:"data"`
+}
+
+func (response PostGroupsGroupIdTransferOwnership200JSONResponse) VisitPostGroupsGroupIdTransferOwnershipResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostGroupsGroupIdTransferOwnership401JSONResponse Unauthorized
+
+func (response PostGroupsGroupIdTransferOwnership401JSONResponse) VisitPostGroupsGroupIdTransferOwnershipResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostGroupsGroupIdTransferOwnership403JSONResponse Forbidden
+
+func (response PostGroupsGroupIdTransferOwnership403JSONResponse) VisitPostGroupsGroupIdTransferOwnershipResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostGroupsGroupIdTransferOwnership404JSONResponse NotFound
+
+func (response PostGroupsGroupIdTransferOwnership404JSONResponse) VisitPostGroupsGroupIdTransferOwnershipResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostGroupsGroupIdTransferOwnership409JSONResponse Conflict
+
+func (response PostGroupsGroupIdTransferOwnership409JSONResponse) VisitPostGroupsGroupIdTransferOwnershipResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostGroupsGroupIdTransferOwnership500JSONResponse InternalServerError
+
+func (response PostGroupsGroupIdTransferOwnership500JSONResponse) VisitPostGroupsGroupIdTransferOwnershipResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+// GroupsStrictServerInterface represents all server handlers.
+type GroupsStrictServerInterface interface {
+	// 获取用户相关的用户组列表
+	// (GET /groups)
+	GetGroups(ctx context.Context, request GetGroupsRequestObject) (GetGroupsResponseObject, error)
+	// 创建用户组
+	// (POST /groups)
+	PostGroups(ctx context.Context, request PostGroupsRequestObject) (PostGroupsResponseObject, error)
+	// 凭邀请码加入用户组
+	// (POST /groups/join-by-code)
+	PostGroupsJoinByCode(ctx context.Context, request PostGroupsJoinByCodeRequestObject) (PostGroupsJoinByCodeResponseObject, error)
+	// 删除用户组
+	// (DELETE /groups/{groupId})
+	DeleteGroupsGroupId(ctx context.Context, request DeleteGroupsGroupIdRequestObject) (DeleteGroupsGroupIdResponseObject, error)
+	// 获取用户组详细信息
+	// (GET /groups/{groupId})
+	GetGroupsGroupId(ctx context.Context, request GetGroupsGroupIdRequestObject) (GetGroupsGroupIdResponseObject, error)
+	// 修改用户组信息
+	// (PUT /groups/{groupId})
+	PutGroupsGroupId(ctx context.Context, request PutGroupsGroupIdRequestObject) (PutGroupsGroupIdResponseObject, error)
+	// 列出邀请码
+	// (GET /groups/{groupId}/invites)
+	GetGroupsGroupIdInvites(ctx context.Context, request GetGroupsGroupIdInvitesRequestObject) (GetGroupsGroupIdInvitesResponseObject, error)
+	// 创建邀请码
+	// (POST /groups/{groupId}/invites)
+	PostGroupsGroupIdInvites(ctx context.Context, request PostGroupsGroupIdInvitesRequestObject) (PostGroupsGroupIdInvitesResponseObject, error)
+	// 撤销邀请码
+	// (DELETE /groups/{groupId}/invites/{inviteId})
+	DeleteGroupsGroupIdInvitesInviteId(ctx context.Context, request DeleteGroupsGroupIdInvitesInviteIdRequestObject) (DeleteGroupsGroupIdInvitesInviteIdResponseObject, error)
+	// 查看用户组的加入申请列表
+	// (GET /groups/{groupId}/join-requests)
+	GetGroupsGroupIdJoinRequests(ctx context.Context, request GetGroupsGroupIdJoinRequestsRequestObject) (GetGroupsGroupIdJoinRequestsResponseObject, error)
+	// 申请加入用户组
+	// (POST /groups/{groupId}/join-requests)
+	PostGroupsGroupIdJoinRequests(ctx context.Context, request PostGroupsGroupIdJoinRequestsRequestObject) (PostGroupsGroupIdJoinRequestsResponseObject, error)
 	// 处理用户组加入申请
 	// (PUT /groups/{groupId}/join-requests/{requestId})
 	PutGroupsGroupIdJoinRequestsRequestId(ctx context.Context, request PutGroupsGroupIdJoinRequestsRequestIdRequestObject) (PutGroupsGroupIdJoinRequestsRequestIdResponseObject, error)
@@ -1033,34 +2387,369 @@ type GroupsStrictServerInterface interface {
 	// 移除用户组成员
 	// (DELETE /groups/{groupId}/members/{userId})
 	DeleteGroupsGroupIdMembersUserId(ctx context.Context, request DeleteGroupsGroupIdMembersUserIdRequestObject) (DeleteGroupsGroupIdMembersUserIdResponseObject, error)
+	// 设置成员角色
+	// (PUT /groups/{groupId}/members/{userId}/role)
+	PutGroupsGroupIdMembersUserIdRole(ctx context.Context, request PutGroupsGroupIdMembersUserIdRoleRequestObject) (PutGroupsGroupIdMembersUserIdRoleResponseObject, error)
 	// 查询当前用户在用户组中的状态
 	// (GET /groups/{groupId}/my-status)
 	GetGroupsGroupIdMyStatus(ctx context.Context, request GetGroupsGroupIdMyStatusRequestObject) (GetGroupsGroupIdMyStatusResponseObject, error)
+	// 调整上级用户组
+	// (PUT /groups/{groupId}/parent)
+	PutGroupsGroupIdParent(ctx context.Context, request PutGroupsGroupIdParentRequestObject) (PutGroupsGroupIdParentResponseObject, error)
+	// 查询角色变更记录
+	// (GET /groups/{groupId}/role-changes)
+	GetGroupsGroupIdRoleChanges(ctx context.Context, request GetGroupsGroupIdRoleChangesRequestObject) (GetGroupsGroupIdRoleChangesResponseObject, error)
+	// 查询自定义角色
+	// (GET /groups/{groupId}/roles)
+	GetGroupsGroupIdRoles(ctx context.Context, request GetGroupsGroupIdRolesRequestObject) (GetGroupsGroupIdRolesResponseObject, error)
+	// 创建自定义角色
+	// (POST /groups/{groupId}/roles)
+	PostGroupsGroupIdRoles(ctx context.Context, request PostGroupsGroupIdRolesRequestObject) (PostGroupsGroupIdRolesResponseObject, error)
+	// 删除自定义角色
+	// (DELETE /groups/{groupId}/roles/{roleId})
+	DeleteGroupsGroupIdRolesRoleId(ctx context.Context, request DeleteGroupsGroupIdRolesRoleIdRequestObject) (DeleteGroupsGroupIdRolesRoleIdResponseObject, error)
+	// 修改自定义角色
+	// (PUT /groups/{groupId}/roles/{roleId})
+	PutGroupsGroupIdRolesRoleId(ctx context.Context, request PutGroupsGroupIdRolesRoleIdRequestObject) (PutGroupsGroupIdRolesRoleIdResponseObject, error)
+	// 查询用户组统计
+	// (GET /groups/{groupId}/stats)
+	GetGroupsGroupIdStats(ctx context.Context, request GetGroupsGroupIdStatsRequestObject) (GetGroupsGroupIdStatsResponseObject, error)
+	// 转让用户组所有权
+	// (POST /groups/{groupId}/transfer-ownership)
+	PostGroupsGroupIdTransferOwnership(ctx context.Context, request PostGroupsGroupIdTransferOwnershipRequestObject) (PostGroupsGroupIdTransferOwnershipResponseObject, error)
+}
+
+type GroupsStrictHandlerFunc = strictgin.StrictGinHandlerFunc
+type GroupsStrictMiddlewareFunc = strictgin.StrictGinMiddlewareFunc
+
+func NewGroupsStrictHandler(ssi GroupsStrictServerInterface, middlewares []GroupsStrictMiddlewareFunc) GroupsServerInterface {
+	return &GroupsstrictHandler{ssi: ssi, middlewares: middlewares}
+}
+
+type GroupsstrictHandler struct {
+	ssi         GroupsStrictServerInterface
+	middlewares []GroupsStrictMiddlewareFunc
+}
+
+// GetGroups 操作中间件
+func (sh *GroupsstrictHandler) GetGroups(ctx *gin.Context, params GetGroupsParams) {
+	var request GetGroupsRequestObject
+
+	request.Params = params
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetGroups(ctx, request.(GetGroupsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetGroups")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(GetGroupsResponseObject); ok {
+		if err := validResponse.VisitGetGroupsResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PostGroups 操作中间件
+func (sh *GroupsstrictHandler) PostGroups(ctx *gin.Context) {
+	var request PostGroupsRequestObject
+
+	var body PostGroupsJSONRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.Status(http.StatusBadRequest)
+		ctx.Error(err)
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PostGroups(ctx, request.(PostGroupsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostGroups")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(PostGroupsResponseObject); ok {
+		if err := validResponse.VisitPostGroupsResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PostGroupsJoinByCode 操作中间件
+func (sh *GroupsstrictHandler) PostGroupsJoinByCode(ctx *gin.Context) {
+	var request PostGroupsJoinByCodeRequestObject
+
+	var body PostGroupsJoinByCodeJSONRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.Status(http.StatusBadRequest)
+		ctx.Error(err)
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PostGroupsJoinByCode(ctx, request.(PostGroupsJoinByCodeRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostGroupsJoinByCode")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(PostGroupsJoinByCodeResponseObject); ok {
+		if err := validResponse.VisitPostGroupsJoinByCodeResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// DeleteGroupsGroupId 操作中间件
+func (sh *GroupsstrictHandler) DeleteGroupsGroupId(ctx *gin.Context, groupId int) {
+	var request DeleteGroupsGroupIdRequestObject
+
+	request.GroupId = groupId
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteGroupsGroupId(ctx, request.(DeleteGroupsGroupIdRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteGroupsGroupId")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(DeleteGroupsGroupIdResponseObject); ok {
+		if err := validResponse.VisitDeleteGroupsGroupIdResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetGroupsGroupId 操作中间件
+func (sh *GroupsstrictHandler) GetGroupsGroupId(ctx *gin.Context, groupId int) {
+	var request GetGroupsGroupIdRequestObject
+
+	request.GroupId = groupId
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetGroupsGroupId(ctx, request.(GetGroupsGroupIdRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetGroupsGroupId")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(GetGroupsGroupIdResponseObject); ok {
+		if err := validResponse.VisitGetGroupsGroupIdResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PutGroupsGroupId 操作中间件
+func (sh *GroupsstrictHandler) PutGroupsGroupId(ctx *gin.Context, groupId int) {
+	var request PutGroupsGroupIdRequestObject
+
+	request.GroupId = groupId
+
+	var body PutGroupsGroupIdJSONRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.Status(http.StatusBadRequest)
+		ctx.Error(err)
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PutGroupsGroupId(ctx, request.(PutGroupsGroupIdRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PutGroupsGroupId")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(PutGroupsGroupIdResponseObject); ok {
+		if err := validResponse.VisitPutGroupsGroupIdResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetGroupsGroupIdInvites 操作中间件
+func (sh *GroupsstrictHandler) GetGroupsGroupIdInvites(ctx *gin.Context, groupId int) {
+	var request GetGroupsGroupIdInvitesRequestObject
+
+	request.GroupId = groupId
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetGroupsGroupIdInvites(ctx, request.(GetGroupsGroupIdInvitesRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetGroupsGroupIdInvites")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(GetGroupsGroupIdInvitesResponseObject); ok {
+		if err := validResponse.VisitGetGroupsGroupIdInvitesResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PostGroupsGroupIdInvites 操作中间件
+func (sh *GroupsstrictHandler) PostGroupsGroupIdInvites(ctx *gin.Context, groupId int) {
+	var request PostGroupsGroupIdInvitesRequestObject
+
+	request.GroupId = groupId
+
+	var body PostGroupsGroupIdInvitesJSONRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.Status(http.StatusBadRequest)
+		ctx.Error(err)
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PostGroupsGroupIdInvites(ctx, request.(PostGroupsGroupIdInvitesRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostGroupsGroupIdInvites")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(PostGroupsGroupIdInvitesResponseObject); ok {
+		if err := validResponse.VisitPostGroupsGroupIdInvitesResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type GroupsStrictHandlerFunc = strictgin.StrictGinHandlerFunc
-type GroupsStrictMiddlewareFunc = strictgin.StrictGinMiddlewareFunc
+// DeleteGroupsGroupIdInvitesInviteId 操作中间件
+func (sh *GroupsstrictHandler) DeleteGroupsGroupIdInvitesInviteId(ctx *gin.Context, groupId int, inviteId int) {
+	var request DeleteGroupsGroupIdInvitesInviteIdRequestObject
+
+	request.GroupId = groupId
+	request.InviteId = inviteId
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteGroupsGroupIdInvitesInviteId(ctx, request.(DeleteGroupsGroupIdInvitesInviteIdRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteGroupsGroupIdInvitesInviteId")
+	}
+
+	response, err := handler(ctx, request)
 
-func NewGroupsStrictHandler(ssi GroupsStrictServerInterface, middlewares []GroupsStrictMiddlewareFunc) GroupsServerInterface {
-	return &GroupsstrictHandler{ssi: ssi, middlewares: middlewares}
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(DeleteGroupsGroupIdInvitesInviteIdResponseObject); ok {
+		if err := validResponse.VisitDeleteGroupsGroupIdInvitesInviteIdResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type GroupsstrictHandler struct {
-	ssi         GroupsStrictServerInterface
-	middlewares []GroupsStrictMiddlewareFunc
+// GetGroupsGroupIdJoinRequests 操作中间件
+func (sh *GroupsstrictHandler) GetGroupsGroupIdJoinRequests(ctx *gin.Context, groupId int, params GetGroupsGroupIdJoinRequestsParams) {
+	var request GetGroupsGroupIdJoinRequestsRequestObject
+
+	request.GroupId = groupId
+	request.Params = params
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetGroupsGroupIdJoinRequests(ctx, request.(GetGroupsGroupIdJoinRequestsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetGroupsGroupIdJoinRequests")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(GetGroupsGroupIdJoinRequestsResponseObject); ok {
+		if err := validResponse.VisitGetGroupsGroupIdJoinRequestsResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-// GetGroups 操作中间件
-func (sh *GroupsstrictHandler) GetGroups(ctx *gin.Context, params GetGroupsParams) {
-	var request GetGroupsRequestObject
+// PostGroupsGroupIdJoinRequests 操作中间件
+func (sh *GroupsstrictHandler) PostGroupsGroupIdJoinRequests(ctx *gin.Context, groupId int) {
+	var request PostGroupsGroupIdJoinRequestsRequestObject
 
-	request.Params = params
+	request.GroupId = groupId
+
+	var body PostGroupsGroupIdJoinRequestsJSONRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.Status(http.StatusBadRequest)
+		ctx.Error(err)
+		return
+	}
+	request.Body = &body
 
 	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
-		return sh.ssi.GetGroups(ctx, request.(GetGroupsRequestObject))
+		return sh.ssi.PostGroupsGroupIdJoinRequests(ctx, request.(PostGroupsGroupIdJoinRequestsRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "GetGroups")
+		handler = middleware(handler, "PostGroupsGroupIdJoinRequests")
 	}
 
 	response, err := handler(ctx, request)
@@ -1068,8 +2757,8 @@ func (sh *GroupsstrictHandler) GetGroups(ctx *gin.Context, params GetGroupsParam
 	if err != nil {
 		ctx.Error(err)
 		ctx.Status(http.StatusInternalServerError)
-	} else if validResponse, ok := response.(GetGroupsResponseObject); ok {
-		if err := validResponse.VisitGetGroupsResponse(ctx.Writer); err != nil {
+	} else if validResponse, ok := response.(PostGroupsGroupIdJoinRequestsResponseObject); ok {
+		if err := validResponse.VisitPostGroupsGroupIdJoinRequestsResponse(ctx.Writer); err != nil {
 			ctx.Error(err)
 		}
 	} else if response != nil {
@@ -1077,11 +2766,14 @@ func (sh *GroupsstrictHandler) GetGroups(ctx *gin.Context, params GetGroupsParam
 	}
 }
 
-// PostGroups 操作中间件
-func (sh *GroupsstrictHandler) PostGroups(ctx *gin.Context) {
-	var request PostGroupsRequestObject
+// PutGroupsGroupIdJoinRequestsRequestId 操作中间件
+func (sh *GroupsstrictHandler) PutGroupsGroupIdJoinRequestsRequestId(ctx *gin.Context, groupId int, requestId int) {
+	var request PutGroupsGroupIdJoinRequestsRequestIdRequestObject
 
-	var body PostGroupsJSONRequestBody
+	request.GroupId = groupId
+	request.RequestId = requestId
+
+	var body PutGroupsGroupIdJoinRequestsRequestIdJSONRequestBody
 	if err := ctx.ShouldBindJSON(&body); err != nil {
 		ctx.Status(http.StatusBadRequest)
 		ctx.Error(err)
@@ -1090,10 +2782,10 @@ func (sh *GroupsstrictHandler) PostGroups(ctx *gin.Context) {
 	request.Body = &body
 
 	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
-		return sh.ssi.PostGroups(ctx, request.(PostGroupsRequestObject))
+		return sh.ssi.PutGroupsGroupIdJoinRequestsRequestId(ctx, request.(PutGroupsGroupIdJoinRequestsRequestIdRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "PostGroups")
+		handler = middleware(handler, "PutGroupsGroupIdJoinRequestsRequestId")
 	}
 
 	response, err := handler(ctx, request)
@@ -1101,8 +2793,8 @@ func (sh *GroupsstrictHandler) PostGroups(ctx *gin.Context) {
 	if err != nil {
 		ctx.Error(err)
 		ctx.Status(http.StatusInternalServerError)
-	} else if validResponse, ok := response.(PostGroupsResponseObject); ok {
-		if err := validResponse.VisitPostGroupsResponse(ctx.Writer); err != nil {
+	} else if validResponse, ok := response.(PutGroupsGroupIdJoinRequestsRequestIdResponseObject); ok {
+		if err := validResponse.VisitPutGroupsGroupIdJoinRequestsRequestIdResponse(ctx.Writer); err != nil {
 			ctx.Error(err)
 		}
 	} else if response != nil {
@@ -1110,17 +2802,17 @@ func (sh *GroupsstrictHandler) PostGroups(ctx *gin.Context) {
 	}
 }
 
-// DeleteGroupsGroupId 操作中间件
-func (sh *GroupsstrictHandler) DeleteGroupsGroupId(ctx *gin.Context, groupId int) {
-	var request DeleteGroupsGroupIdRequestObject
+// GetGroupsGroupIdMembers 操作中间件
+func (sh *GroupsstrictHandler) GetGroupsGroupIdMembers(ctx *gin.Context, groupId int) {
+	var request GetGroupsGroupIdMembersRequestObject
 
 	request.GroupId = groupId
 
 	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
-		return sh.ssi.DeleteGroupsGroupId(ctx, request.(DeleteGroupsGroupIdRequestObject))
+		return sh.ssi.GetGroupsGroupIdMembers(ctx, request.(GetGroupsGroupIdMembersRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "DeleteGroupsGroupId")
+		handler = middleware(handler, "GetGroupsGroupIdMembers")
 	}
 
 	response, err := handler(ctx, request)
@@ -1128,8 +2820,8 @@ func (sh *GroupsstrictHandler) DeleteGroupsGroupId(ctx *gin.Context, groupId int
 	if err != nil {
 		ctx.Error(err)
 		ctx.Status(http.StatusInternalServerError)
-	} else if validResponse, ok := response.(DeleteGroupsGroupIdResponseObject); ok {
-		if err := validResponse.VisitDeleteGroupsGroupIdResponse(ctx.Writer); err != nil {
+	} else if validResponse, ok := response.(GetGroupsGroupIdMembersResponseObject); ok {
+		if err := validResponse.VisitGetGroupsGroupIdMembersResponse(ctx.Writer); err != nil {
 			ctx.Error(err)
 		}
 	} else if response != nil {
@@ -1137,17 +2829,18 @@ func (sh *GroupsstrictHandler) DeleteGroupsGroupId(ctx *gin.Context, groupId int
 	}
 }
 
-// GetGroupsGroupId 操作中间件
-func (sh *GroupsstrictHandler) GetGroupsGroupId(ctx *gin.Context, groupId int) {
-	var request GetGroupsGroupIdRequestObject
+// DeleteGroupsGroupIdMembersUserId 操作中间件
+func (sh *GroupsstrictHandler) DeleteGroupsGroupIdMembersUserId(ctx *gin.Context, groupId int, userId int) {
+	var request DeleteGroupsGroupIdMembersUserIdRequestObject
 
 	request.GroupId = groupId
+	request.UserId = userId
 
 	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
-		return sh.ssi.GetGroupsGroupId(ctx, request.(GetGroupsGroupIdRequestObject))
+		return sh.ssi.DeleteGroupsGroupIdMembersUserId(ctx, request.(DeleteGroupsGroupIdMembersUserIdRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "GetGroupsGroupId")
+		handler = middleware(handler, "DeleteGroupsGroupIdMembersUserId")
 	}
 
 	response, err := handler(ctx, request)
@@ -1155,8 +2848,8 @@ func (sh *GroupsstrictHandler) GetGroupsGroupId(ctx *gin.Context, groupId int) {
 	if err != nil {
 		ctx.Error(err)
 		ctx.Status(http.StatusInternalServerError)
-	} else if validResponse, ok := response.(GetGroupsGroupIdResponseObject); ok {
-		if err := validResponse.VisitGetGroupsGroupIdResponse(ctx.Writer); err != nil {
+	} else if validResponse, ok := response.(DeleteGroupsGroupIdMembersUserIdResponseObject); ok {
+		if err := validResponse.VisitDeleteGroupsGroupIdMembersUserIdResponse(ctx.Writer); err != nil {
 			ctx.Error(err)
 		}
 	} else if response != nil {
@@ -1164,13 +2857,14 @@ func (sh *GroupsstrictHandler) GetGroupsGroupId(ctx *gin.Context, groupId int) {
 	}
 }
 
-// PutGroupsGroupId 操作中间件
-func (sh *GroupsstrictHandler) PutGroupsGroupId(ctx *gin.Context, groupId int) {
-	var request PutGroupsGroupIdRequestObject
+// PutGroupsGroupIdMembersUserIdRole 操作中间件
+func (sh *GroupsstrictHandler) PutGroupsGroupIdMembersUserIdRole(ctx *gin.Context, groupId int, userId int) {
+	var request PutGroupsGroupIdMembersUserIdRoleRequestObject
 
 	request.GroupId = groupId
+	request.UserId = userId
 
-	var body PutGroupsGroupIdJSONRequestBody
+	var body PutGroupsGroupIdMembersUserIdRoleJSONRequestBody
 	if err := ctx.ShouldBindJSON(&body); err != nil {
 		ctx.Status(http.StatusBadRequest)
 		ctx.Error(err)
@@ -1179,10 +2873,10 @@ func (sh *GroupsstrictHandler) PutGroupsGroupId(ctx *gin.Context, groupId int) {
 	request.Body = &body
 
 	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
-		return sh.ssi.PutGroupsGroupId(ctx, request.(PutGroupsGroupIdRequestObject))
+		return sh.ssi.PutGroupsGroupIdMembersUserIdRole(ctx, request.(PutGroupsGroupIdMembersUserIdRoleRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "PutGroupsGroupId")
+		handler = middleware(handler, "PutGroupsGroupIdMembersUserIdRole")
 	}
 
 	response, err := handler(ctx, request)
@@ -1190,8 +2884,8 @@ func (sh *GroupsstrictHandler) PutGroupsGroupId(ctx *gin.Context, groupId int) {
 	if err != nil {
 		ctx.Error(err)
 		ctx.Status(http.StatusInternalServerError)
-	} else if validResponse, ok := response.(PutGroupsGroupIdResponseObject); ok {
-		if err := validResponse.VisitPutGroupsGroupIdResponse(ctx.Writer); err != nil {
+	} else if validResponse, ok := response.(PutGroupsGroupIdMembersUserIdRoleResponseObject); ok {
+		if err := validResponse.VisitPutGroupsGroupIdMembersUserIdRoleResponse(ctx.Writer); err != nil {
 			ctx.Error(err)
 		}
 	} else if response != nil {
@@ -1199,18 +2893,17 @@ func (sh *GroupsstrictHandler) PutGroupsGroupId(ctx *gin.Context, groupId int) {
 	}
 }
 
-// GetGroupsGroupIdJoinRequests 操作中间件
-func (sh *GroupsstrictHandler) GetGroupsGroupIdJoinRequests(ctx *gin.Context, groupId int, params GetGroupsGroupIdJoinRequestsParams) {
-	var request GetGroupsGroupIdJoinRequestsRequestObject
+// GetGroupsGroupIdMyStatus 操作中间件
+func (sh *GroupsstrictHandler) GetGroupsGroupIdMyStatus(ctx *gin.Context, groupId int) {
+	var request GetGroupsGroupIdMyStatusRequestObject
 
 	request.GroupId = groupId
-	request.Params = params
 
 	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
-		return sh.ssi.GetGroupsGroupIdJoinRequests(ctx, request.(GetGroupsGroupIdJoinRequestsRequestObject))
+		return sh.ssi.GetGroupsGroupIdMyStatus(ctx, request.(GetGroupsGroupIdMyStatusRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "GetGroupsGroupIdJoinRequests")
+		handler = middleware(handler, "GetGroupsGroupIdMyStatus")
 	}
 
 	response, err := handler(ctx, request)
@@ -1218,8 +2911,8 @@ func (sh *GroupsstrictHandler) GetGroupsGroupIdJoinRequests(ctx *gin.Context, gr
 	if err != nil {
 		ctx.Error(err)
 		ctx.Status(http.StatusInternalServerError)
-	} else if validResponse, ok := response.(GetGroupsGroupIdJoinRequestsResponseObject); ok {
-		if err := validResponse.VisitGetGroupsGroupIdJoinRequestsResponse(ctx.Writer); err != nil {
+	} else if validResponse, ok := response.(GetGroupsGroupIdMyStatusResponseObject); ok {
+		if err := validResponse.VisitGetGroupsGroupIdMyStatusResponse(ctx.Writer); err != nil {
 			ctx.Error(err)
 		}
 	} else if response != nil {
@@ -1227,13 +2920,13 @@ func (sh *GroupsstrictHandler) GetGroupsGroupIdJoinRequests(ctx *gin.Context, gr
 	}
 }
 
-// PostGroupsGroupIdJoinRequests 操作中间件
-func (sh *GroupsstrictHandler) PostGroupsGroupIdJoinRequests(ctx *gin.Context, groupId int) {
-	var request PostGroupsGroupIdJoinRequestsRequestObject
+// PutGroupsGroupIdParent 操作中间件
+func (sh *GroupsstrictHandler) PutGroupsGroupIdParent(ctx *gin.Context, groupId int) {
+	var request PutGroupsGroupIdParentRequestObject
 
 	request.GroupId = groupId
 
-	var body PostGroupsGroupIdJoinRequestsJSONRequestBody
+	var body PutGroupsGroupIdParentJSONRequestBody
 	if err := ctx.ShouldBindJSON(&body); err != nil {
 		ctx.Status(http.StatusBadRequest)
 		ctx.Error(err)
@@ -1242,10 +2935,10 @@ func (sh *GroupsstrictHandler) PostGroupsGroupIdJoinRequests(ctx *gin.Context, g
 	request.Body = &body
 
 	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
-		return sh.ssi.PostGroupsGroupIdJoinRequests(ctx, request.(PostGroupsGroupIdJoinRequestsRequestObject))
+		return sh.ssi.PutGroupsGroupIdParent(ctx, request.(PutGroupsGroupIdParentRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "PostGroupsGroupIdJoinRequests")
+		handler = middleware(handler, "PutGroupsGroupIdParent")
 	}
 
 	response, err := handler(ctx, request)
@@ -1253,8 +2946,8 @@ func (sh *GroupsstrictHandler) PostGroupsGroupIdJoinRequests(ctx *gin.Context, g
 	if err != nil {
 		ctx.Error(err)
 		ctx.Status(http.StatusInternalServerError)
-	} else if validResponse, ok := response.(PostGroupsGroupIdJoinRequestsResponseObject); ok {
-		if err := validResponse.VisitPostGroupsGroupIdJoinRequestsResponse(ctx.Writer); err != nil {
+	} else if validResponse, ok := response.(PutGroupsGroupIdParentResponseObject); ok {
+		if err := validResponse.VisitPutGroupsGroupIdParentResponse(ctx.Writer); err != nil {
 			ctx.Error(err)
 		}
 	} else if response != nil {
@@ -1262,14 +2955,68 @@ func (sh *GroupsstrictHandler) PostGroupsGroupIdJoinRequests(ctx *gin.Context, g
 	}
 }
 
-// PutGroupsGroupIdJoinRequestsRequestId 操作中间件
-func (sh *GroupsstrictHandler) PutGroupsGroupIdJoinRequestsRequestId(ctx *gin.Context, groupId int, requestId int) {
-	var request PutGroupsGroupIdJoinRequestsRequestIdRequestObject
+// GetGroupsGroupIdRoleChanges 操作中间件
+func (sh *GroupsstrictHandler) GetGroupsGroupIdRoleChanges(ctx *gin.Context, groupId int, params GetGroupsGroupIdRoleChangesParams) {
+	var request GetGroupsGroupIdRoleChangesRequestObject
 
 	request.GroupId = groupId
-	request.RequestId = requestId
+	request.Params = params
 
-	var body PutGroupsGroupIdJoinRequestsRequestIdJSONRequestBody
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetGroupsGroupIdRoleChanges(ctx, request.(GetGroupsGroupIdRoleChangesRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetGroupsGroupIdRoleChanges")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(GetGroupsGroupIdRoleChangesResponseObject); ok {
+		if err := validResponse.VisitGetGroupsGroupIdRoleChangesResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetGroupsGroupIdRoles 操作中间件
+func (sh *GroupsstrictHandler) GetGroupsGroupIdRoles(ctx *gin.Context, groupId int) {
+	var request GetGroupsGroupIdRolesRequestObject
+
+	request.GroupId = groupId
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetGroupsGroupIdRoles(ctx, request.(GetGroupsGroupIdRolesRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetGroupsGroupIdRoles")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(GetGroupsGroupIdRolesResponseObject); ok {
+		if err := validResponse.VisitGetGroupsGroupIdRolesResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PostGroupsGroupIdRoles 操作中间件
+func (sh *GroupsstrictHandler) PostGroupsGroupIdRoles(ctx *gin.Context, groupId int) {
+	var request PostGroupsGroupIdRolesRequestObject
+
+	request.GroupId = groupId
+
+	var body PostGroupsGroupIdRolesJSONRequestBody
 	if err := ctx.ShouldBindJSON(&body); err != nil {
 		ctx.Status(http.StatusBadRequest)
 		ctx.Error(err)
@@ -1278,10 +3025,10 @@ func (sh *GroupsstrictHandler) PutGroupsGroupIdJoinRequestsRequestId(ctx *gin.Co
 	request.Body = &body
 
 	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
-		return sh.ssi.PutGroupsGroupIdJoinRequestsRequestId(ctx, request.(PutGroupsGroupIdJoinRequestsRequestIdRequestObject))
+		return sh.ssi.PostGroupsGroupIdRoles(ctx, request.(PostGroupsGroupIdRolesRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "PutGroupsGroupIdJoinRequestsRequestId")
+		handler = middleware(handler, "PostGroupsGroupIdRoles")
 	}
 
 	response, err := handler(ctx, request)
@@ -1289,8 +3036,8 @@ func (sh *GroupsstrictHandler) PutGroupsGroupIdJoinRequestsRequestId(ctx *gin.Co
 	if err != nil {
 		ctx.Error(err)
 		ctx.Status(http.StatusInternalServerError)
-	} else if validResponse, ok := response.(PutGroupsGroupIdJoinRequestsRequestIdResponseObject); ok {
-		if err := validResponse.VisitPutGroupsGroupIdJoinRequestsRequestIdResponse(ctx.Writer); err != nil {
+	} else if validResponse, ok := response.(PostGroupsGroupIdRolesResponseObject); ok {
+		if err := validResponse.VisitPostGroupsGroupIdRolesResponse(ctx.Writer); err != nil {
 			ctx.Error(err)
 		}
 	} else if response != nil {
@@ -1298,17 +3045,18 @@ func (sh *GroupsstrictHandler) PutGroupsGroupIdJoinRequestsRequestId(ctx *gin.Co
 	}
 }
 
-// GetGroupsGroupIdMembers 操作中间件
-func (sh *GroupsstrictHandler) GetGroupsGroupIdMembers(ctx *gin.Context, groupId int) {
-	var request GetGroupsGroupIdMembersRequestObject
+// DeleteGroupsGroupIdRolesRoleId 操作中间件
+func (sh *GroupsstrictHandler) DeleteGroupsGroupIdRolesRoleId(ctx *gin.Context, groupId int, roleId int) {
+	var request DeleteGroupsGroupIdRolesRoleIdRequestObject
 
 	request.GroupId = groupId
+	request.RoleId = roleId
 
 	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
-		return sh.ssi.GetGroupsGroupIdMembers(ctx, request.(GetGroupsGroupIdMembersRequestObject))
+		return sh.ssi.DeleteGroupsGroupIdRolesRoleId(ctx, request.(DeleteGroupsGroupIdRolesRoleIdRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "GetGroupsGroupIdMembers")
+		handler = middleware(handler, "DeleteGroupsGroupIdRolesRoleId")
 	}
 
 	response, err := handler(ctx, request)
@@ -1316,8 +3064,8 @@ func (sh *GroupsstrictHandler) GetGroupsGroupIdMembers(ctx *gin.Context, groupId
 	if err != nil {
 		ctx.Error(err)
 		ctx.Status(http.StatusInternalServerError)
-	} else if validResponse, ok := response.(GetGroupsGroupIdMembersResponseObject); ok {
-		if err := validResponse.VisitGetGroupsGroupIdMembersResponse(ctx.Writer); err != nil {
+	} else if validResponse, ok := response.(DeleteGroupsGroupIdRolesRoleIdResponseObject); ok {
+		if err := validResponse.VisitDeleteGroupsGroupIdRolesRoleIdResponse(ctx.Writer); err != nil {
 			ctx.Error(err)
 		}
 	} else if response != nil {
@@ -1325,18 +3073,26 @@ func (sh *GroupsstrictHandler) GetGroupsGroupIdMembers(ctx *gin.Context, groupId
 	}
 }
 
-// DeleteGroupsGroupIdMembersUserId 操作中间件
-func (sh *GroupsstrictHandler) DeleteGroupsGroupIdMembersUserId(ctx *gin.Context, groupId int, userId int) {
-	var request DeleteGroupsGroupIdMembersUserIdRequestObject
+// PutGroupsGroupIdRolesRoleId 操作中间件
+func (sh *GroupsstrictHandler) PutGroupsGroupIdRolesRoleId(ctx *gin.Context, groupId int, roleId int) {
+	var request PutGroupsGroupIdRolesRoleIdRequestObject
 
 	request.GroupId = groupId
-	request.UserId = userId
+	request.RoleId = roleId
+
+	var body PutGroupsGroupIdRolesRoleIdJSONRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.Status(http.StatusBadRequest)
+		ctx.Error(err)
+		return
+	}
+	request.Body = &body
 
 	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
-		return sh.ssi.DeleteGroupsGroupIdMembersUserId(ctx, request.(DeleteGroupsGroupIdMembersUserIdRequestObject))
+		return sh.ssi.PutGroupsGroupIdRolesRoleId(ctx, request.(PutGroupsGroupIdRolesRoleIdRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "DeleteGroupsGroupIdMembersUserId")
+		handler = middleware(handler, "PutGroupsGroupIdRolesRoleId")
 	}
 
 	response, err := handler(ctx, request)
@@ -1344,8 +3100,8 @@ func (sh *GroupsstrictHandler) DeleteGroupsGroupIdMembersUserId(ctx *gin.Context
 	if err != nil {
 		ctx.Error(err)
 		ctx.Status(http.StatusInternalServerError)
-	} else if validResponse, ok := response.(DeleteGroupsGroupIdMembersUserIdResponseObject); ok {
-		if err := validResponse.VisitDeleteGroupsGroupIdMembersUserIdResponse(ctx.Writer); err != nil {
+	} else if validResponse, ok := response.(PutGroupsGroupIdRolesRoleIdResponseObject); ok {
+		if err := validResponse.VisitPutGroupsGroupIdRolesRoleIdResponse(ctx.Writer); err != nil {
 			ctx.Error(err)
 		}
 	} else if response != nil {
@@ -1353,17 +3109,17 @@ func (sh *GroupsstrictHandler) DeleteGroupsGroupIdMembersUserId(ctx *gin.Context
 	}
 }
 
-// GetGroupsGroupIdMyStatus 操作中间件
-func (sh *GroupsstrictHandler) GetGroupsGroupIdMyStatus(ctx *gin.Context, groupId int) {
-	var request GetGroupsGroupIdMyStatusRequestObject
+// GetGroupsGroupIdStats 操作中间件
+func (sh *GroupsstrictHandler) GetGroupsGroupIdStats(ctx *gin.Context, groupId int) {
+	var request GetGroupsGroupIdStatsRequestObject
 
 	request.GroupId = groupId
 
 	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
-		return sh.ssi.GetGroupsGroupIdMyStatus(ctx, request.(GetGroupsGroupIdMyStatusRequestObject))
+		return sh.ssi.GetGroupsGroupIdStats(ctx, request.(GetGroupsGroupIdStatsRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "GetGroupsGroupIdMyStatus")
+		handler = middleware(handler, "GetGroupsGroupIdStats")
 	}
 
 	response, err := handler(ctx, request)
@@ -1371,8 +3127,43 @@ func (sh *GroupsstrictHandler) GetGroupsGroupIdMyStatus(ctx *gin.Context, groupI
 	if err != nil {
 		ctx.Error(err)
 		ctx.Status(http.StatusInternalServerError)
-	} else if validResponse, ok := response.(GetGroupsGroupIdMyStatusResponseObject); ok {
-		if err := validResponse.VisitGetGroupsGroupIdMyStatusResponse(ctx.Writer); err != nil {
+	} else if validResponse, ok := response.(GetGroupsGroupIdStatsResponseObject); ok {
+		if err := validResponse.VisitGetGroupsGroupIdStatsResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PostGroupsGroupIdTransferOwnership 操作中间件
+func (sh *GroupsstrictHandler) PostGroupsGroupIdTransferOwnership(ctx *gin.Context, groupId int) {
+	var request PostGroupsGroupIdTransferOwnershipRequestObject
+
+	request.GroupId = groupId
+
+	var body PostGroupsGroupIdTransferOwnershipJSONRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.Status(http.StatusBadRequest)
+		ctx.Error(err)
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PostGroupsGroupIdTransferOwnership(ctx, request.(PostGroupsGroupIdTransferOwnershipRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostGroupsGroupIdTransferOwnership")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(PostGroupsGroupIdTransferOwnershipResponseObject); ok {
+		if err := validResponse.VisitPostGroupsGroupIdTransferOwnershipResponse(ctx.Writer); err != nil {
 			ctx.Error(err)
 		}
 	} else if response != nil {
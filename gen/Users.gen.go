@@ -10,6 +10,7 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/oapi-codegen/runtime"
 	strictgin "github.com/oapi-codegen/runtime/strictmiddleware/gin"
 )
 
@@ -18,6 +19,48 @@ type UsersServerInterface interface {
 	// 获取当前用户信息
 	// (GET /users/me)
 	GetUsersMe(c *gin.Context)
+	// 修改个人资料
+	// (PUT /users/me)
+	PutUsersMe(c *gin.Context)
+	// 查询两步验证状态
+	// (GET /users/me/2fa)
+	GetUsersMe2fa(c *gin.Context)
+	// 确认绑定两步验证
+	// (POST /users/me/2fa/confirm)
+	PostUsersMe2faConfirm(c *gin.Context)
+	// 关闭两步验证
+	// (POST /users/me/2fa/disable)
+	PostUsersMe2faDisable(c *gin.Context)
+	// 开始绑定两步验证
+	// (POST /users/me/2fa/enroll)
+	PostUsersMe2faEnroll(c *gin.Context)
+	// 重新生成恢复码
+	// (POST /users/me/2fa/recovery-codes)
+	PostUsersMe2faRecoveryCodes(c *gin.Context)
+	// 关联统一身份认证账号
+	// (POST /users/me/oidc/link)
+	PostUsersMeOidcLink(c *gin.Context)
+	// 修改密码
+	// (PUT /users/me/password)
+	PutUsersMePassword(c *gin.Context)
+	// 列出登录会话
+	// (GET /users/me/sessions)
+	GetUsersMeSessions(c *gin.Context)
+	// 退出指定会话
+	// (DELETE /users/me/sessions/{sessionId})
+	DeleteUsersMeSessionsSessionId(c *gin.Context, sessionId int)
+	// 列出个人访问令牌
+	// (GET /users/me/tokens)
+	GetUsersMeTokens(c *gin.Context)
+	// 创建个人访问令牌
+	// (POST /users/me/tokens)
+	PostUsersMeTokens(c *gin.Context)
+	// 吊销个人访问令牌
+	// (DELETE /users/me/tokens/{tokenId})
+	DeleteUsersMeTokensTokenId(c *gin.Context, tokenId int)
+	// 修改用户名
+	// (PUT /users/me/username)
+	PutUsersMeUsername(c *gin.Context)
 }
 
 // UsersServerInterfaceWrapper 将上下文转换为参数。
@@ -42,6 +85,212 @@ func (siw *UsersServerInterfaceWrapper) GetUsersMe(c *gin.Context) {
 	siw.Handler.GetUsersMe(c)
 }
 
+// PutUsersMe 操作中间件
+func (siw *UsersServerInterfaceWrapper) PutUsersMe(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PutUsersMe(c)
+}
+
+// GetUsersMe2fa 操作中间件
+func (siw *UsersServerInterfaceWrapper) GetUsersMe2fa(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetUsersMe2fa(c)
+}
+
+// PostUsersMe2faConfirm 操作中间件
+func (siw *UsersServerInterfaceWrapper) PostUsersMe2faConfirm(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostUsersMe2faConfirm(c)
+}
+
+// PostUsersMe2faDisable 操作中间件
+func (siw *UsersServerInterfaceWrapper) PostUsersMe2faDisable(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostUsersMe2faDisable(c)
+}
+
+// PostUsersMe2faEnroll 操作中间件
+func (siw *UsersServerInterfaceWrapper) PostUsersMe2faEnroll(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostUsersMe2faEnroll(c)
+}
+
+// PostUsersMe2faRecoveryCodes 操作中间件
+func (siw *UsersServerInterfaceWrapper) PostUsersMe2faRecoveryCodes(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostUsersMe2faRecoveryCodes(c)
+}
+
+// PostUsersMeOidcLink 操作中间件
+func (siw *UsersServerInterfaceWrapper) PostUsersMeOidcLink(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostUsersMeOidcLink(c)
+}
+
+// PutUsersMePassword 操作中间件
+func (siw *UsersServerInterfaceWrapper) PutUsersMePassword(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PutUsersMePassword(c)
+}
+
+// GetUsersMeSessions 操作中间件
+func (siw *UsersServerInterfaceWrapper) GetUsersMeSessions(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetUsersMeSessions(c)
+}
+
+// DeleteUsersMeSessionsSessionId 操作中间件
+func (siw *UsersServerInterfaceWrapper) DeleteUsersMeSessionsSessionId(c *gin.Context) {
+
+	var err error
+
+	// ------------- 路径参数 "sessionId" -------------
+	var sessionId int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "sessionId", c.Param("sessionId"), &sessionId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("参数 sessionId 格式无效: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.DeleteUsersMeSessionsSessionId(c, sessionId)
+}
+
+// GetUsersMeTokens 操作中间件
+func (siw *UsersServerInterfaceWrapper) GetUsersMeTokens(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetUsersMeTokens(c)
+}
+
+// PostUsersMeTokens 操作中间件
+func (siw *UsersServerInterfaceWrapper) PostUsersMeTokens(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostUsersMeTokens(c)
+}
+
+// DeleteUsersMeTokensTokenId 操作中间件
+func (siw *UsersServerInterfaceWrapper) DeleteUsersMeTokensTokenId(c *gin.Context) {
+
+	var err error
+
+	// ------------- 路径参数 "tokenId" -------------
+	var tokenId int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "tokenId", c.Param("tokenId"), &tokenId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("参数 tokenId 格式无效: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.DeleteUsersMeTokensTokenId(c, tokenId)
+}
+
+// PutUsersMeUsername 操作中间件
+func (siw *UsersServerInterfaceWrapper) PutUsersMeUsername(c *gin.Context) {
+
+	c.Set(JWT鉴权Scopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PutUsersMeUsername(c)
+}
+
 // UsersGinServerOptions 提供 Gin 服务器的选项。
 type UsersGinServerOptions struct {
 	BaseURL      string
@@ -70,6 +319,20 @@ func RegisterUsersHandlersWithOptions(router gin.IRouter, si UsersServerInterfac
 	}
 
 	router.GET(options.BaseURL+"/users/me", wrapper.GetUsersMe)
+	router.PUT(options.BaseURL+"/users/me", wrapper.PutUsersMe)
+	router.GET(options.BaseURL+"/users/me/2fa", wrapper.GetUsersMe2fa)
+	router.POST(options.BaseURL+"/users/me/2fa/confirm", wrapper.PostUsersMe2faConfirm)
+	router.POST(options.BaseURL+"/users/me/2fa/disable", wrapper.PostUsersMe2faDisable)
+	router.POST(options.BaseURL+"/users/me/2fa/enroll", wrapper.PostUsersMe2faEnroll)
+	router.POST(options.BaseURL+"/users/me/2fa/recovery-codes", wrapper.PostUsersMe2faRecoveryCodes)
+	router.POST(options.BaseURL+"/users/me/oidc/link", wrapper.PostUsersMeOidcLink)
+	router.PUT(options.BaseURL+"/users/me/password", wrapper.PutUsersMePassword)
+	router.GET(options.BaseURL+"/users/me/sessions", wrapper.GetUsersMeSessions)
+	router.DELETE(options.BaseURL+"/users/me/sessions/:sessionId", wrapper.DeleteUsersMeSessionsSessionId)
+	router.GET(options.BaseURL+"/users/me/tokens", wrapper.GetUsersMeTokens)
+	router.POST(options.BaseURL+"/users/me/tokens", wrapper.PostUsersMeTokens)
+	router.DELETE(options.BaseURL+"/users/me/tokens/:tokenId", wrapper.DeleteUsersMeTokensTokenId)
+	router.PUT(options.BaseURL+"/users/me/username", wrapper.PutUsersMeUsername)
 }
 
 type GetUsersMeRequestObject struct {
@@ -109,43 +372,1192 @@ func (response GetUsersMe500JSONResponse) VisitGetUsersMeResponse(w http.Respons
 	return json.NewEncoder(w).Encode(response)
 }
 
-// UsersStrictServerInterface represents all server handlers.
-type UsersStrictServerInterface interface {
-	// 获取当前用户信息
-	// (GET /users/me)
-	GetUsersMe(ctx context.Context, request GetUsersMeRequestObject) (GetUsersMeResponseObject, error)
+type PutUsersMeRequestObject struct {
+	Body *PutUsersMeJSONRequestBody
 }
 
-type UsersStrictHandlerFunc = strictgin.StrictGinHandlerFunc
-type UsersStrictMiddlewareFunc = strictgin.StrictGinMiddlewareFunc
+type PutUsersMeResponseObject interface {
+	VisitPutUsersMeResponse(w http.ResponseWriter) error
+}
 
-func NewUsersStrictHandler(ssi UsersStrictServerInterface, middlewares []UsersStrictMiddlewareFunc) UsersServerInterface {
-	return &UsersstrictHandler{ssi: ssi, middlewares: middlewares}
+type PutUsersMe200JSONResponse struct {
+	Code string `json:"code"`
+	Data User   `json:"data"`
 }
 
-type UsersstrictHandler struct {
-	ssi         UsersStrictServerInterface
-	middlewares []UsersStrictMiddlewareFunc
+func (response PutUsersMe200JSONResponse) VisitPutUsersMeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-// GetUsersMe 操作中间件
-func (sh *UsersstrictHandler) GetUsersMe(ctx *gin.Context) {
-	var request GetUsersMeRequestObject
+type PutUsersMe400JSONResponse BadRequest
 
-	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
-		return sh.ssi.GetUsersMe(ctx, request.(GetUsersMeRequestObject))
-	}
-	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "GetUsersMe")
-	}
+func (response PutUsersMe400JSONResponse) VisitPutUsersMeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
 
-	response, err := handler(ctx, request)
+	return json.NewEncoder(w).Encode(response)
+}
 
-	if err != nil {
-		ctx.Error(err)
-		ctx.Status(http.StatusInternalServerError)
-	} else if validResponse, ok := response.(GetUsersMeResponseObject); ok {
-		if err := validResponse.VisitGetUsersMeResponse(ctx.Writer); err != nil {
+type PutUsersMe401JSONResponse Unauthorized
+
+func (response PutUsersMe401JSONResponse) VisitPutUsersMeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutUsersMe500JSONResponse InternalServerError
+
+func (response PutUsersMe500JSONResponse) VisitPutUsersMeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetUsersMe2faRequestObject struct {
+}
+
+type GetUsersMe2faResponseObject interface {
+	VisitGetUsersMe2faResponse(w http.ResponseWriter) error
+}
+
+type GetUsersMe2fa200JSONResponse struct {
+	Code string `json:"code"`
+	Data struct {
+		// Enabled 是否已开启两步验证
+		Enabled bool `json:"enabled"`
+
+		// RecoveryCodesRemaining 剩余可用的恢复码数量
+		RecoveryCodesRemaining int `json:"recoveryCodesRemaining"`
+	} `json:"data"`
+}
+
+func (response GetUsersMe2fa200JSONResponse) VisitGetUsersMe2faResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetUsersMe2fa401JSONResponse Unauthorized
+
+func (response GetUsersMe2fa401JSONResponse) VisitGetUsersMe2faResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetUsersMe2fa500JSONResponse InternalServerError
+
+func (response GetUsersMe2fa500JSONResponse) VisitGetUsersMe2faResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostUsersMe2faConfirmRequestObject struct {
+	Body *PostUsersMe2faConfirmJSONRequestBody
+}
+
+type PostUsersMe2faConfirmResponseObject interface {
+	VisitPostUsersMe2faConfirmResponse(w http.ResponseWriter) error
+}
+
+type PostUsersMe2faConfirm200JSONResponse struct {
+	Code string `json:"code"`
+	Data struct {
+		// RecoveryCodes 恢复码，手机丢失时可代替验证码使用
+		RecoveryCodes []string `json:"recoveryCodes"`
+	} `json:"data"`
+}
+
+func (response PostUsersMe2faConfirm200JSONResponse) VisitPostUsersMe2faConfirmResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostUsersMe2faConfirm400JSONResponse BadRequest
+
+func (response PostUsersMe2faConfirm400JSONResponse) VisitPostUsersMe2faConfirmResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostUsersMe2faConfirm401JSONResponse Unauthorized
+
+func (response PostUsersMe2faConfirm401JSONResponse) VisitPostUsersMe2faConfirmResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostUsersMe2faConfirm409JSONResponse Conflict
+
+func (response PostUsersMe2faConfirm409JSONResponse) VisitPostUsersMe2faConfirmResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostUsersMe2faConfirm500JSONResponse InternalServerError
+
+func (response PostUsersMe2faConfirm500JSONResponse) VisitPostUsersMe2faConfirmResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostUsersMe2faDisableRequestObject struct {
+	Body *PostUsersMe2faDisableJSONRequestBody
+}
+
+type PostUsersMe2faDisableResponseObject interface {
+	VisitPostUsersMe2faDisableResponse(w http.ResponseWriter) error
+}
+
+type PostUsersMe2faDisable200JSONResponse Success
+
+func (response PostUsersMe2faDisable200JSONResponse) VisitPostUsersMe2faDisableResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostUsersMe2faDisable400JSONResponse BadRequest
+
+func (response PostUsersMe2faDisable400JSONResponse) VisitPostUsersMe2faDisableResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostUsersMe2faDisable401JSONResponse Unauthorized
+
+func (response PostUsersMe2faDisable401JSONResponse) VisitPostUsersMe2faDisableResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostUsersMe2faDisable409JSONResponse Conflict
+
+func (response PostUsersMe2faDisable409JSONResponse) VisitPostUsersMe2faDisableResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostUsersMe2faDisable500JSONResponse InternalServerError
+
+func (response PostUsersMe2faDisable500JSONResponse) VisitPostUsersMe2faDisableResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostUsersMe2faEnrollRequestObject struct {
+}
+
+type PostUsersMe2faEnrollResponseObject interface {
+	VisitPostUsersMe2faEnrollResponse(w http.ResponseWriter) error
+}
+
+type PostUsersMe2faEnroll200JSONResponse struct {
+	Code string `json:"code"`
+	Data struct {
+		// OtpauthUri otpauth://totp/ URI
+		OtpauthUri string `json:"otpauthUri"`
+
+		// Secret Base32编码的密钥，供无法扫码时手动输入
+		Secret string `json:"secret"`
+	} `json:"data"`
+}
+
+func (response PostUsersMe2faEnroll200JSONResponse) VisitPostUsersMe2faEnrollResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostUsersMe2faEnroll401JSONResponse Unauthorized
+
+func (response PostUsersMe2faEnroll401JSONResponse) VisitPostUsersMe2faEnrollResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostUsersMe2faEnroll409JSONResponse Conflict
+
+func (response PostUsersMe2faEnroll409JSONResponse) VisitPostUsersMe2faEnrollResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostUsersMe2faEnroll500JSONResponse InternalServerError
+
+func (response PostUsersMe2faEnroll500JSONResponse) VisitPostUsersMe2faEnrollResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostUsersMe2faRecoveryCodesRequestObject struct {
+	Body *PostUsersMe2faRecoveryCodesJSONRequestBody
+}
+
+type PostUsersMe2faRecoveryCodesResponseObject interface {
+	VisitPostUsersMe2faRecoveryCodesResponse(w http.ResponseWriter) error
+}
+
+type PostUsersMe2faRecoveryCodes200JSONResponse struct {
+	Code string `json:"code"`
+	Data struct {
+		// RecoveryCodes 新的恢复码
+		RecoveryCodes []string `json:"recoveryCodes"`
+	} `json:"data"`
+}
+
+func (response PostUsersMe2faRecoveryCodes200JSONResponse) VisitPostUsersMe2faRecoveryCodesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostUsersMe2faRecoveryCodes400JSONResponse BadRequest
+
+func (response PostUsersMe2faRecoveryCodes400JSONResponse) VisitPostUsersMe2faRecoveryCodesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostUsersMe2faRecoveryCodes401JSONResponse Unauthorized
+
+func (response PostUsersMe2faRecoveryCodes401JSONResponse) VisitPostUsersMe2faRecoveryCodesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostUsersMe2faRecoveryCodes500JSONResponse InternalServerError
+
+func (response PostUsersMe2faRecoveryCodes500JSONResponse) VisitPostUsersMe2faRecoveryCodesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostUsersMeOidcLinkRequestObject struct {
+}
+
+type PostUsersMeOidcLinkResponseObject interface {
+	VisitPostUsersMeOidcLinkResponse(w http.ResponseWriter) error
+}
+
+type PostUsersMeOidcLink200JSONResponse struct {
+	Code string `json:"code"`
+	Data struct {
+		// AuthorizationUrl 身份提供方的授权地址，前端将浏览器跳转到该地址
+		AuthorizationUrl string `json:"authorizationUrl"`
+
+		// State 本次授权请求的state，回调页面应校验身份提供方返回的state与之一致
+		State string `json:"state"`
+	} `json:"data"`
+}
+
+func (response PostUsersMeOidcLink200JSONResponse) VisitPostUsersMeOidcLinkResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostUsersMeOidcLink401JSONResponse Unauthorized
+
+func (response PostUsersMeOidcLink401JSONResponse) VisitPostUsersMeOidcLinkResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostUsersMeOidcLink403JSONResponse Forbidden
+
+func (response PostUsersMeOidcLink403JSONResponse) VisitPostUsersMeOidcLinkResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostUsersMeOidcLink500JSONResponse InternalServerError
+
+func (response PostUsersMeOidcLink500JSONResponse) VisitPostUsersMeOidcLinkResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostUsersMeOidcLink502JSONResponse Error
+
+func (response PostUsersMeOidcLink502JSONResponse) VisitPostUsersMeOidcLinkResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(502)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutUsersMePasswordRequestObject struct {
+	Body *PutUsersMePasswordJSONRequestBody
+}
+
+type PutUsersMePasswordResponseObject interface {
+	VisitPutUsersMePasswordResponse(w http.ResponseWriter) error
+}
+
+type PutUsersMePassword200JSONResponse Success
+
+func (response PutUsersMePassword200JSONResponse) VisitPutUsersMePasswordResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutUsersMePassword400JSONResponse BadRequest
+
+func (response PutUsersMePassword400JSONResponse) VisitPutUsersMePasswordResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutUsersMePassword401JSONResponse Unauthorized
+
+func (response PutUsersMePassword401JSONResponse) VisitPutUsersMePasswordResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutUsersMePassword500JSONResponse InternalServerError
+
+func (response PutUsersMePassword500JSONResponse) VisitPutUsersMePasswordResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetUsersMeSessionsRequestObject struct {
+}
+
+type GetUsersMeSessionsResponseObject interface {
+	VisitGetUsersMeSessionsResponse(w http.ResponseWriter) error
+}
+
+type GetUsersMeSessions200JSONResponse struct {
+	Code string    `json:"code"`
+	Data []Session `json:"data"`
+}
+
+func (response GetUsersMeSessions200JSONResponse) VisitGetUsersMeSessionsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetUsersMeSessions401JSONResponse Unauthorized
+
+func (response GetUsersMeSessions401JSONResponse) VisitGetUsersMeSessionsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetUsersMeSessions500JSONResponse InternalServerError
+
+func (response GetUsersMeSessions500JSONResponse) VisitGetUsersMeSessionsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteUsersMeSessionsSessionIdRequestObject struct {
+	SessionId int `json:"sessionId"`
+}
+
+type DeleteUsersMeSessionsSessionIdResponseObject interface {
+	VisitDeleteUsersMeSessionsSessionIdResponse(w http.ResponseWriter) error
+}
+
+type DeleteUsersMeSessionsSessionId200JSONResponse Success
+
+func (response DeleteUsersMeSessionsSessionId200JSONResponse) VisitDeleteUsersMeSessionsSessionIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteUsersMeSessionsSessionId401JSONResponse Unauthorized
+
+func (response DeleteUsersMeSessionsSessionId401JSONResponse) VisitDeleteUsersMeSessionsSessionIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteUsersMeSessionsSessionId404JSONResponse NotFound
+
+func (response DeleteUsersMeSessionsSessionId404JSONResponse) VisitDeleteUsersMeSessionsSessionIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteUsersMeSessionsSessionId500JSONResponse InternalServerError
+
+func (response DeleteUsersMeSessionsSessionId500JSONResponse) VisitDeleteUsersMeSessionsSessionIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetUsersMeTokensRequestObject struct {
+}
+
+type GetUsersMeTokensResponseObject interface {
+	VisitGetUsersMeTokensResponse(w http.ResponseWriter) error
+}
+
+type GetUsersMeTokens200JSONResponse struct {
+	Code string        `json:"code"`
+	Data []AccessToken `json:"data"`
+}
+
+func (response GetUsersMeTokens200JSONResponse) VisitGetUsersMeTokensResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetUsersMeTokens401JSONResponse Unauthorized
+
+func (response GetUsersMeTokens401JSONResponse) VisitGetUsersMeTokensResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetUsersMeTokens500JSONResponse InternalServerError
+
+func (response GetUsersMeTokens500JSONResponse) VisitGetUsersMeTokensResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostUsersMeTokensRequestObject struct {
+	Body *PostUsersMeTokensJSONRequestBody
+}
+
+type PostUsersMeTokensResponseObject interface {
+	VisitPostUsersMeTokensResponse(w http.ResponseWriter) error
+}
+
+type PostUsersMeTokens201JSONResponse struct {
+	Code string `json:"code"`
+	Data struct {
+		AccessToken AccessToken `json:"accessToken"`
+
+		// Token 令牌明文，只返回这一次
+		Token string `json:"token"`
+	} `json:"data"`
+}
+
+func (response PostUsersMeTokens201JSONResponse) VisitPostUsersMeTokensResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostUsersMeTokens400JSONResponse BadRequest
+
+func (response PostUsersMeTokens400JSONResponse) VisitPostUsersMeTokensResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostUsersMeTokens401JSONResponse Unauthorized
+
+func (response PostUsersMeTokens401JSONResponse) VisitPostUsersMeTokensResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostUsersMeTokens403JSONResponse Forbidden
+
+func (response PostUsersMeTokens403JSONResponse) VisitPostUsersMeTokensResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostUsersMeTokens409JSONResponse Conflict
+
+func (response PostUsersMeTokens409JSONResponse) VisitPostUsersMeTokensResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostUsersMeTokens500JSONResponse InternalServerError
+
+func (response PostUsersMeTokens500JSONResponse) VisitPostUsersMeTokensResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteUsersMeTokensTokenIdRequestObject struct {
+	TokenId int `json:"tokenId"`
+}
+
+type DeleteUsersMeTokensTokenIdResponseObject interface {
+	VisitDeleteUsersMeTokensTokenIdResponse(w http.ResponseWriter) error
+}
+
+type DeleteUsersMeTokensTokenId200JSONResponse Success
+
+func (response DeleteUsersMeTokensTokenId200JSONResponse) VisitDeleteUsersMeTokensTokenIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteUsersMeTokensTokenId401JSONResponse Unauthorized
+
+func (response DeleteUsersMeTokensTokenId401JSONResponse) VisitDeleteUsersMeTokensTokenIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteUsersMeTokensTokenId404JSONResponse NotFound
+
+func (response DeleteUsersMeTokensTokenId404JSONResponse) VisitDeleteUsersMeTokensTokenIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteUsersMeTokensTokenId500JSONResponse InternalServerError
+
+func (response DeleteUsersMeTokensTokenId500JSONResponse) VisitDeleteUsersMeTokensTokenIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutUsersMeUsernameRequestObject struct {
+	Body *PutUsersMeUsernameJSONRequestBody
+}
+
+type PutUsersMeUsernameResponseObject interface {
+	VisitPutUsersMeUsernameResponse(w http.ResponseWriter) error
+}
+
+type PutUsersMeUsername200JSONResponse struct {
+	Code string `json:"code"`
+	Data User   `json:"data"`
+}
+
+func (response PutUsersMeUsername200JSONResponse) VisitPutUsersMeUsernameResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutUsersMeUsername400JSONResponse BadRequest
+
+func (response PutUsersMeUsername400JSONResponse) VisitPutUsersMeUsernameResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutUsersMeUsername401JSONResponse Unauthorized
+
+func (response PutUsersMeUsername401JSONResponse) VisitPutUsersMeUsernameResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutUsersMeUsername409JSONResponse Conflict
+
+func (response PutUsersMeUsername409JSONResponse) VisitPutUsersMeUsernameResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutUsersMeUsername500JSONResponse InternalServerError
+
+func (response PutUsersMeUsername500JSONResponse) VisitPutUsersMeUsernameResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+// UsersStrictServerInterface represents all server handlers.
+type UsersStrictServerInterface interface {
+	// 获取当前用户信息
+	// (GET /users/me)
+	GetUsersMe(ctx context.Context, request GetUsersMeRequestObject) (GetUsersMeResponseObject, error)
+	// 修改个人资料
+	// (PUT /users/me)
+	PutUsersMe(ctx context.Context, request PutUsersMeRequestObject) (PutUsersMeResponseObject, error)
+	// 查询两步验证状态
+	// (GET /users/me/2fa)
+	GetUsersMe2fa(ctx context.Context, request GetUsersMe2faRequestObject) (GetUsersMe2faResponseObject, error)
+	// 确认绑定两步验证
+	// (POST /users/me/2fa/confirm)
+	PostUsersMe2faConfirm(ctx context.Context, request PostUsersMe2faConfirmRequestObject) (PostUsersMe2faConfirmResponseObject, error)
+	// 关闭两步验证
+	// (POST /users/me/2fa/disable)
+	PostUsersMe2faDisable(ctx context.Context, request PostUsersMe2faDisableRequestObject) (PostUsersMe2faDisableResponseObject, error)
+	// 开始绑定两步验证
+	// (POST /users/me/2fa/enroll)
+	PostUsersMe2faEnroll(ctx context.Context, request PostUsersMe2faEnrollRequestObject) (PostUsersMe2faEnrollResponseObject, error)
+	// 重新生成恢复码
+	// (POST /users/me/2fa/recovery-codes)
+	PostUsersMe2faRecoveryCodes(ctx context.Context, request PostUsersMe2faRecoveryCodesRequestObject) (PostUsersMe2faRecoveryCodesResponseObject, error)
+	// 关联统一身份认证账号
+	// (POST /users/me/oidc/link)
+	PostUsersMeOidcLink(ctx context.Context, request PostUsersMeOidcLinkRequestObject) (PostUsersMeOidcLinkResponseObject, error)
+	// 修改密码
+	// (PUT /users/me/password)
+	PutUsersMePassword(ctx context.Context, request PutUsersMePasswordRequestObject) (PutUsersMePasswordResponseObject, error)
+	// 列出登录会话
+	// (GET /users/me/sessions)
+	GetUsersMeSessions(ctx context.Context, request GetUsersMeSessionsRequestObject) (GetUsersMeSessionsResponseObject, error)
+	// 退出指定会话
+	// (DELETE /users/me/sessions/{sessionId})
+	DeleteUsersMeSessionsSessionId(ctx context.Context, request DeleteUsersMeSessionsSessionIdRequestObject) (DeleteUsersMeSessionsSessionIdResponseObject, error)
+	// 列出个人访问令牌
+	// (GET /users/me/tokens)
+	GetUsersMeTokens(ctx context.Context, request GetUsersMeTokensRequestObject) (GetUsersMeTokensResponseObject, error)
+	// 创建个人访问令牌
+	// (POST /users/me/tokens)
+	PostUsersMeTokens(ctx context.Context, request PostUsersMeTokensRequestObject) (PostUsersMeTokensResponseObject, error)
+	// 吊销个人访问令牌
+	// (DELETE /users/me/tokens/{tokenId})
+	DeleteUsersMeTokensTokenId(ctx context.Context, request DeleteUsersMeTokensTokenIdRequestObject) (DeleteUsersMeTokensTokenIdResponseObject, error)
+	// 修改用户名
+	// (PUT /users/me/username)
+	PutUsersMeUsername(ctx context.Context, request PutUsersMeUsernameRequestObject) (PutUsersMeUsernameResponseObject, error)
+}
+
+type UsersStrictHandlerFunc = strictgin.StrictGinHandlerFunc
+type UsersStrictMiddlewareFunc = strictgin.StrictGinMiddlewareFunc
+
+func NewUsersStrictHandler(ssi UsersStrictServerInterface, middlewares []UsersStrictMiddlewareFunc) UsersServerInterface {
+	return &UsersstrictHandler{ssi: ssi, middlewares: middlewares}
+}
+
+type UsersstrictHandler struct {
+	ssi         UsersStrictServerInterface
+	middlewares []UsersStrictMiddlewareFunc
+}
+
+// GetUsersMe 操作中间件
+func (sh *UsersstrictHandler) GetUsersMe(ctx *gin.Context) {
+	var request GetUsersMeRequestObject
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetUsersMe(ctx, request.(GetUsersMeRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetUsersMe")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(GetUsersMeResponseObject); ok {
+		if err := validResponse.VisitGetUsersMeResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PutUsersMe 操作中间件
+func (sh *UsersstrictHandler) PutUsersMe(ctx *gin.Context) {
+	var request PutUsersMeRequestObject
+
+	var body PutUsersMeJSONRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.Status(http.StatusBadRequest)
+		ctx.Error(err)
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PutUsersMe(ctx, request.(PutUsersMeRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PutUsersMe")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(PutUsersMeResponseObject); ok {
+		if err := validResponse.VisitPutUsersMeResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetUsersMe2fa 操作中间件
+func (sh *UsersstrictHandler) GetUsersMe2fa(ctx *gin.Context) {
+	var request GetUsersMe2faRequestObject
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetUsersMe2fa(ctx, request.(GetUsersMe2faRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetUsersMe2fa")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(GetUsersMe2faResponseObject); ok {
+		if err := validResponse.VisitGetUsersMe2faResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PostUsersMe2faConfirm 操作中间件
+func (sh *UsersstrictHandler) PostUsersMe2faConfirm(ctx *gin.Context) {
+	var request PostUsersMe2faConfirmRequestObject
+
+	var body PostUsersMe2faConfirmJSONRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.Status(http.StatusBadRequest)
+		ctx.Error(err)
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PostUsersMe2faConfirm(ctx, request.(PostUsersMe2faConfirmRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostUsersMe2faConfirm")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(PostUsersMe2faConfirmResponseObject); ok {
+		if err := validResponse.VisitPostUsersMe2faConfirmResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PostUsersMe2faDisable 操作中间件
+func (sh *UsersstrictHandler) PostUsersMe2faDisable(ctx *gin.Context) {
+	var request PostUsersMe2faDisableRequestObject
+
+	var body PostUsersMe2faDisableJSONRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.Status(http.StatusBadRequest)
+		ctx.Error(err)
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PostUsersMe2faDisable(ctx, request.(PostUsersMe2faDisableRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostUsersMe2faDisable")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(PostUsersMe2faDisableResponseObject); ok {
+		if err := validResponse.VisitPostUsersMe2faDisableResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PostUsersMe2faEnroll 操作中间件
+func (sh *UsersstrictHandler) PostUsersMe2faEnroll(ctx *gin.Context) {
+	var request PostUsersMe2faEnrollRequestObject
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PostUsersMe2faEnroll(ctx, request.(PostUsersMe2faEnrollRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostUsersMe2faEnroll")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(PostUsersMe2faEnrollResponseObject); ok {
+		if err := validResponse.VisitPostUsersMe2faEnrollResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PostUsersMe2faRecoveryCodes 操作中间件
+func (sh *UsersstrictHandler) PostUsersMe2faRecoveryCodes(ctx *gin.Context) {
+	var request PostUsersMe2faRecoveryCodesRequestObject
+
+	var body PostUsersMe2faRecoveryCodesJSONRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.Status(http.StatusBadRequest)
+		ctx.Error(err)
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PostUsersMe2faRecoveryCodes(ctx, request.(PostUsersMe2faRecoveryCodesRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostUsersMe2faRecoveryCodes")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(PostUsersMe2faRecoveryCodesResponseObject); ok {
+		if err := validResponse.VisitPostUsersMe2faRecoveryCodesResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PostUsersMeOidcLink 操作中间件
+func (sh *UsersstrictHandler) PostUsersMeOidcLink(ctx *gin.Context) {
+	var request PostUsersMeOidcLinkRequestObject
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PostUsersMeOidcLink(ctx, request.(PostUsersMeOidcLinkRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostUsersMeOidcLink")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(PostUsersMeOidcLinkResponseObject); ok {
+		if err := validResponse.VisitPostUsersMeOidcLinkResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PutUsersMePassword 操作中间件
+func (sh *UsersstrictHandler) PutUsersMePassword(ctx *gin.Context) {
+	var request PutUsersMePasswordRequestObject
+
+	var body PutUsersMePasswordJSONRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.Status(http.StatusBadRequest)
+		ctx.Error(err)
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PutUsersMePassword(ctx, request.(PutUsersMePasswordRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PutUsersMePassword")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(PutUsersMePasswordResponseObject); ok {
+		if err := validResponse.VisitPutUsersMePasswordResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetUsersMeSessions 操作中间件
+func (sh *UsersstrictHandler) GetUsersMeSessions(ctx *gin.Context) {
+	var request GetUsersMeSessionsRequestObject
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetUsersMeSessions(ctx, request.(GetUsersMeSessionsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetUsersMeSessions")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(GetUsersMeSessionsResponseObject); ok {
+		if err := validResponse.VisitGetUsersMeSessionsResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// DeleteUsersMeSessionsSessionId 操作中间件
+func (sh *UsersstrictHandler) DeleteUsersMeSessionsSessionId(ctx *gin.Context, sessionId int) {
+	var request DeleteUsersMeSessionsSessionIdRequestObject
+
+	request.SessionId = sessionId
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteUsersMeSessionsSessionId(ctx, request.(DeleteUsersMeSessionsSessionIdRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteUsersMeSessionsSessionId")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(DeleteUsersMeSessionsSessionIdResponseObject); ok {
+		if err := validResponse.VisitDeleteUsersMeSessionsSessionIdResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetUsersMeTokens 操作中间件
+func (sh *UsersstrictHandler) GetUsersMeTokens(ctx *gin.Context) {
+	var request GetUsersMeTokensRequestObject
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetUsersMeTokens(ctx, request.(GetUsersMeTokensRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetUsersMeTokens")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(GetUsersMeTokensResponseObject); ok {
+		if err := validResponse.VisitGetUsersMeTokensResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PostUsersMeTokens 操作中间件
+func (sh *UsersstrictHandler) PostUsersMeTokens(ctx *gin.Context) {
+	var request PostUsersMeTokensRequestObject
+
+	var body PostUsersMeTokensJSONRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.Status(http.StatusBadRequest)
+		ctx.Error(err)
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PostUsersMeTokens(ctx, request.(PostUsersMeTokensRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostUsersMeTokens")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(PostUsersMeTokensResponseObject); ok {
+		if err := validResponse.VisitPostUsersMeTokensResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// DeleteUsersMeTokensTokenId 操作中间件
+func (sh *UsersstrictHandler) DeleteUsersMeTokensTokenId(ctx *gin.Context, tokenId int) {
+	var request DeleteUsersMeTokensTokenIdRequestObject
+
+	request.TokenId = tokenId
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteUsersMeTokensTokenId(ctx, request.(DeleteUsersMeTokensTokenIdRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteUsersMeTokensTokenId")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(DeleteUsersMeTokensTokenIdResponseObject); ok {
+		if err := validResponse.VisitDeleteUsersMeTokensTokenIdResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PutUsersMeUsername 操作中间件
+func (sh *UsersstrictHandler) PutUsersMeUsername(ctx *gin.Context) {
+	var request PutUsersMeUsernameRequestObject
+
+	var body PutUsersMeUsernameJSONRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.Status(http.StatusBadRequest)
+		ctx.Error(err)
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PutUsersMeUsername(ctx, request.(PutUsersMeUsernameRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PutUsersMeUsername")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(PutUsersMeUsernameResponseObject); ok {
+		if err := validResponse.VisitPutUsersMeUsernameResponse(ctx.Writer); err != nil {
 			ctx.Error(err)
 		}
 	} else if response != nil {
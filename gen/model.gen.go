@@ -5,10 +5,34 @@ package gen
 
 import (
 	"encoding/json"
+	"time"
 
 	"github.com/oapi-codegen/runtime"
 )
 
+const (
+	JWT鉴权Scopes = "JWT鉴权.Scopes"
+)
+
+// Defines values for AccessTokenScopes.
+const (
+	AccessTokenScopesAuditsRead  AccessTokenScopes = "audits:read"
+	AccessTokenScopesAuditsWrite AccessTokenScopes = "audits:write"
+	AccessTokenScopesGroupsAdmin AccessTokenScopes = "groups:admin"
+	AccessTokenScopesGroupsRead  AccessTokenScopes = "groups:read"
+	AccessTokenScopesGroupsWrite AccessTokenScopes = "groups:write"
+	AccessTokenScopesProfileRead AccessTokenScopes = "profile:read"
+	AccessTokenScopesRecordsRead AccessTokenScopes = "records:read"
+	AccessTokenScopesTasksRead   AccessTokenScopes = "tasks:read"
+	AccessTokenScopesTasksWrite  AccessTokenScopes = "tasks:write"
+)
+
+// Defines values for AdminUserPlatformRole.
+const (
+	AdminUserPlatformRoleSuperAdmin AdminUserPlatformRole = "super_admin"
+	AdminUserPlatformRoleUser       AdminUserPlatformRole = "user"
+)
+
 // Defines values for AuditRequestStatus.
 const (
 	AuditRequestStatusApproved AuditRequestStatus = "approved"
@@ -29,6 +53,20 @@ const (
 	Upcoming CheckinTaskStatus = "upcoming"
 )
 
+// Defines values for GroupInviteRole.
+const (
+	GroupInviteRoleAdmin  GroupInviteRole = "admin"
+	GroupInviteRoleMember GroupInviteRole = "member"
+)
+
+// Defines values for GroupJoinPolicy.
+const (
+	Approval   GroupJoinPolicy = "approval"
+	Closed     GroupJoinPolicy = "closed"
+	InviteOnly GroupJoinPolicy = "invite_only"
+	Open       GroupJoinPolicy = "open"
+)
+
 // Defines values for GroupMembershipStatus.
 const (
 	GroupMembershipStatusMember   GroupMembershipStatus = "member"
@@ -37,10 +75,39 @@ const (
 	GroupMembershipStatusRejected GroupMembershipStatus = "rejected"
 )
 
+// Defines values for GroupPermission.
+const (
+	AuditReview    GroupPermission = "audit.review"
+	AuditView      GroupPermission = "audit.view"
+	GroupDelete    GroupPermission = "group.delete"
+	GroupUpdate    GroupPermission = "group.update"
+	MemberInvite   GroupPermission = "member.invite"
+	MemberProfile  GroupPermission = "member.profile"
+	MemberRemove   GroupPermission = "member.remove"
+	MemberReview   GroupPermission = "member.review"
+	MemberRole     GroupPermission = "member.role"
+	RecordsExport  GroupPermission = "records.export"
+	RecordsView    GroupPermission = "records.view"
+	RoleManage     GroupPermission = "role.manage"
+	SubgroupManage GroupPermission = "subgroup.manage"
+	TaskCreate     GroupPermission = "task.create"
+	TaskDelete     GroupPermission = "task.delete"
+	TaskUpdate     GroupPermission = "task.update"
+	TaskView       GroupPermission = "task.view"
+)
+
 // Defines values for GroupRole.
 const (
-	Admin  GroupRole = "admin"
-	Member GroupRole = "member"
+	GroupRoleAdmin  GroupRole = "admin"
+	GroupRoleMember GroupRole = "member"
+)
+
+// Defines values for GroupRoleChangeAction.
+const (
+	Assign            GroupRoleChangeAction = "assign"
+	Demote            GroupRoleChangeAction = "demote"
+	Promote           GroupRoleChangeAction = "promote"
+	TransferOwnership GroupRoleChangeAction = "transfer_ownership"
 )
 
 // Defines values for JoinRequestStatus.
@@ -66,6 +133,12 @@ const (
 	UserCheckinStatusUnchecked     UserCheckinStatus = "unchecked"
 )
 
+// Defines values for PutAdminUsersUserIdPlatformRoleJSONBodyRole.
+const (
+	PutAdminUsersUserIdPlatformRoleJSONBodyRoleSuperAdmin PutAdminUsersUserIdPlatformRoleJSONBodyRole = "super_admin"
+	PutAdminUsersUserIdPlatformRoleJSONBodyRoleUser       PutAdminUsersUserIdPlatformRoleJSONBodyRole = "user"
+)
+
 // Defines values for PutAuditRequestsAuditRequestIdJSONBodyAction.
 const (
 	PutAuditRequestsAuditRequestIdJSONBodyActionApprove PutAuditRequestsAuditRequestIdJSONBodyAction = "approve"
@@ -100,12 +173,133 @@ const (
 	Joined  GetGroupsParamsFilter = "joined"
 )
 
+// Defines values for PostGroupsGroupIdInvitesJSONBodyRole.
+const (
+	Admin  PostGroupsGroupIdInvitesJSONBodyRole = "admin"
+	Member PostGroupsGroupIdInvitesJSONBodyRole = "member"
+)
+
 // Defines values for PutGroupsGroupIdJoinRequestsRequestIdJSONBodyAction.
 const (
 	PutGroupsGroupIdJoinRequestsRequestIdJSONBodyActionApprove PutGroupsGroupIdJoinRequestsRequestIdJSONBodyAction = "approve"
 	PutGroupsGroupIdJoinRequestsRequestIdJSONBodyActionReject  PutGroupsGroupIdJoinRequestsRequestIdJSONBodyAction = "reject"
 )
 
+// Defines values for PostUsersMeTokensJSONBodyScopes.
+const (
+	PostUsersMeTokensJSONBodyScopesAuditsRead  PostUsersMeTokensJSONBodyScopes = "audits:read"
+	PostUsersMeTokensJSONBodyScopesAuditsWrite PostUsersMeTokensJSONBodyScopes = "audits:write"
+	PostUsersMeTokensJSONBodyScopesGroupsAdmin PostUsersMeTokensJSONBodyScopes = "groups:admin"
+	PostUsersMeTokensJSONBodyScopesGroupsRead  PostUsersMeTokensJSONBodyScopes = "groups:read"
+	PostUsersMeTokensJSONBodyScopesGroupsWrite PostUsersMeTokensJSONBodyScopes = "groups:write"
+	PostUsersMeTokensJSONBodyScopesProfileRead PostUsersMeTokensJSONBodyScopes = "profile:read"
+	PostUsersMeTokensJSONBodyScopesRecordsRead PostUsersMeTokensJSONBodyScopes = "records:read"
+	PostUsersMeTokensJSONBodyScopesTasksRead   PostUsersMeTokensJSONBodyScopes = "tasks:read"
+	PostUsersMeTokensJSONBodyScopesTasksWrite  PostUsersMeTokensJSONBodyScopes = "tasks:write"
+)
+
+// AccessToken defines model for AccessToken.
+type AccessToken struct {
+	// CreatedAt 创建时间
+	CreatedAt time.Time `json:"createdAt"`
+
+	// ExpiresAt 过期时间
+	ExpiresAt time.Time `json:"expiresAt"`
+
+	// Id 令牌ID
+	Id int `json:"id,omitempty"`
+
+	// LastUsedAt 最近使用时间，从未使用时为空
+	LastUsedAt *time.Time `json:"lastUsedAt,omitempty"`
+
+	// LastUsedIp 最近使用的IP
+	LastUsedIp string `json:"lastUsedIp"`
+
+	// Name 令牌名称
+	Name string `json:"name"`
+
+	// Scopes 权限范围
+	Scopes []AccessTokenScopes `json:"scopes"`
+
+	// TokenHint 令牌末尾4位，便于辨认
+	TokenHint string `json:"tokenHint"`
+}
+
+// AccessTokenScopes defines model for AccessToken.Scopes.
+type AccessTokenScopes string
+
+// AdminAction defines model for AdminAction.
+type AdminAction struct {
+	// Action 操作类型：`disable_user`、`enable_user`、`set_platform_role`、`delete_group`、`unlock_login`、`reset_password`、`revoke_sessions`、`transfer_group_owner`、`close_task`、`recount_members`
+	Action string `json:"action"`
+
+	// ActorId 操作者用户ID，运维命令为0
+	ActorId int `json:"actorId"`
+
+	// ActorName 操作者用户名
+	ActorName string `json:"actorName"`
+
+	// CreatedAt 操作时间
+	CreatedAt time.Time `json:"createdAt"`
+
+	// Detail 操作说明，如停用原因、角色变更
+	Detail string `json:"detail"`
+
+	// Id 日志ID
+	Id int `json:"id,omitempty"`
+
+	// Ip 操作者IP
+	Ip string `json:"ip"`
+
+	// TargetId 对象ID，解除登录锁定时为0
+	TargetId int `json:"targetId"`
+
+	// TargetName 操作时对象的名称
+	TargetName string `json:"targetName"`
+
+	// TargetType 对象类型：`user`、`group`、`login`、`task`
+	TargetType string `json:"targetType"`
+}
+
+// AdminUser defines model for AdminUser.
+type AdminUser struct {
+	// CreatedAt 注册时间
+	CreatedAt time.Time `json:"createdAt"`
+
+	// Disabled 是否已停用
+	Disabled bool `json:"disabled"`
+
+	// DisabledAt 停用时间，未停用时为空
+	DisabledAt *time.Time `json:"disabledAt"`
+
+	// DisabledReason 停用原因
+	DisabledReason string `json:"disabledReason"`
+
+	// DisplayName 昵称
+	DisplayName string `json:"displayName"`
+
+	// Email 邮箱
+	Email string `json:"email"`
+
+	// PlatformRole 平台角色：`user` 普通用户，`super_admin` 超级管理员
+	PlatformRole AdminUserPlatformRole `json:"platformRole"`
+
+	// RealName 真实姓名
+	RealName string `json:"realName"`
+
+	// StudentNumber 学号或工号
+	StudentNumber string `json:"studentNumber"`
+
+	// UserId 用户ID
+	UserId int `json:"userId,omitempty"`
+
+	// Username 用户名
+	Username string `json:"username"`
+}
+
+// AdminUserPlatformRole 平台角色：`user` 普通用户，`super_admin` 超级管理员
+type AdminUserPlatformRole string
+
 // AuditRequest defines model for AuditRequest.
 type AuditRequest struct {
 	// AdminId 处理管理员ID
@@ -258,6 +452,12 @@ type Conflict struct {
 	Message string `json:"message"`
 }
 
+// Error defines model for Error.
+type Error struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
 // Forbidden defines model for Forbidden.
 type Forbidden struct {
 	Code    string `json:"code"`
@@ -284,18 +484,90 @@ type Group struct {
 	// GroupName 用户组名称
 	GroupName string `json:"groupName,omitempty"`
 
+	// JoinPolicy 加入方式：open(提交申请后自动通过)、approval(组管理员审批)、invite_only(只能凭邀请码加入)、closed(不接受新成员)
+	JoinPolicy GroupJoinPolicy `json:"joinPolicy"`
+
 	// MemberCount 成员数量
 	MemberCount int `json:"memberCount,omitempty"`
+
+	// ParentId 上级用户组ID，0为顶级用户组
+	ParentId int `json:"parentId,omitempty"`
+
+	// RequireAdmin2fa 是否要求该组管理员开启两步验证
+	RequireAdmin2fa bool `json:"requireAdmin2fa"`
+}
+
+// GroupInvite defines model for GroupInvite.
+type GroupInvite struct {
+	// Code 邀请码
+	Code string `json:"code"`
+
+	// CreatedAt 创建时间
+	CreatedAt time.Time `json:"createdAt"`
+
+	// CreatedBy 创建者ID
+	CreatedBy int `json:"createdBy"`
+
+	// CreatedByName 创建者用户名
+	CreatedByName string `json:"createdByName"`
+
+	// ExpiresAt 过期时间，长期有效时为空
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+
+	// GroupId 用户组ID
+	GroupId int `json:"groupId"`
+
+	// Id 邀请码ID
+	Id int `json:"id,omitempty"`
+
+	// Link 邀请链接，服务端未配置前端加入页面地址时为空字符串
+	Link string `json:"link"`
+
+	// MaxUses 可使用次数上限，为0时不限次数
+	MaxUses int `json:"maxUses"`
+
+	// Role 通过该邀请码加入后的组内角色
+	Role GroupInviteRole `json:"role"`
+
+	// Usable 当前是否可用，已过期或已用完时为false
+	Usable bool `json:"usable"`
+
+	// Uses 已使用次数
+	Uses int `json:"uses"`
 }
 
+// GroupInviteRole 通过该邀请码加入后的组内角色
+type GroupInviteRole string
+
+// GroupJoinPolicy 加入方式：open(提交申请后自动通过)、approval(组管理员审批)、invite_only(只能凭邀请码加入)、closed(不接受新成员)
+type GroupJoinPolicy string
+
 // GroupMember defines model for GroupMember.
 type GroupMember struct {
+	// AvatarUrl 头像地址
+	AvatarUrl string `json:"avatarUrl,omitempty"`
+
+	// DisplayName 昵称
+	DisplayName string `json:"displayName,omitempty"`
+
+	// Email 邮箱，仅组管理员可见且成员允许展示时返回
+	Email string `json:"email,omitempty"`
+
 	// JoinedAt 加入时间（Unix时间戳，单位：秒）
 	JoinedAt int `json:"joinedAt,omitempty"`
 
-	// Role 用户在组中的角色，如'admin'或'member'
+	// Phone 手机号，仅组管理员可见且成员允许展示时返回
+	Phone string `json:"phone,omitempty"`
+
+	// RealName 真实姓名，仅组管理员可见且成员允许展示时返回
+	RealName string `json:"realName,omitempty"`
+
+	// Role 用户在组中的角色：'admin'、'member'，担任自定义角色时为角色名称
 	Role string `json:"role,omitempty"`
 
+	// StudentNumber 学号或工号，仅组管理员可见且成员允许展示时返回
+	StudentNumber string `json:"studentNumber,omitempty"`
+
 	// UserId 用户ID
 	UserId int `json:"userId,omitempty"`
 
@@ -306,9 +578,93 @@ type GroupMember struct {
 // GroupMembershipStatus 用户在组中的状态：none(未关联)、pending(申请中)、member(普通成员)、rejected(申请被拒绝)
 type GroupMembershipStatus string
 
+// GroupPermission 组内权限
+type GroupPermission string
+
 // GroupRole defines model for GroupRole.
 type GroupRole string
 
+// GroupRoleChange defines model for GroupRoleChange.
+type GroupRoleChange struct {
+	// Action 变更类型：设置管理员、撤销为普通成员、设置自定义角色、转让所有权
+	Action GroupRoleChangeAction `json:"action"`
+
+	// CreatedAt 变更时间
+	CreatedAt time.Time `json:"createdAt"`
+
+	// FromRole 变更前角色：`admin`、`member` 或自定义角色名称，所有者为 `owner`
+	FromRole string `json:"fromRole"`
+
+	// GroupId 用户组ID
+	GroupId int `json:"groupId"`
+
+	// Id 记录ID
+	Id int `json:"id,omitempty"`
+
+	// OperatorId 操作者用户ID，运维命令为0
+	OperatorId int `json:"operatorId"`
+
+	// OperatorName 操作者用户名
+	OperatorName string `json:"operatorName"`
+
+	// ToRole 变更后角色：`admin`、`member` 或自定义角色名称，所有者为 `owner`
+	ToRole string `json:"toRole"`
+
+	// UserId 角色变更的成员ID
+	UserId int `json:"userId"`
+
+	// Username 角色变更的成员用户名
+	Username string `json:"username"`
+}
+
+// GroupRoleChangeAction 变更类型：设置管理员、撤销为普通成员、设置自定义角色、转让所有权
+type GroupRoleChangeAction string
+
+// GroupRoleDefinition defines model for GroupRoleDefinition.
+type GroupRoleDefinition struct {
+	// Builtin 是否为内置角色，内置角色不能修改或删除
+	Builtin bool `json:"builtin"`
+
+	// Description 角色说明
+	Description string `json:"description"`
+
+	// Id 角色ID，内置角色为0
+	Id int `json:"id,omitempty"`
+
+	// Name 角色名称，内置角色为 `admin`、`member`
+	Name string `json:"name"`
+
+	// Permissions 角色具有的组内权限
+	Permissions []GroupPermission `json:"permissions"`
+}
+
+// GroupStats 用户组连同全部下级用户组汇总的统计数据
+type GroupStats struct {
+	// ActiveTaskCount 进行中的签到任务数
+	ActiveTaskCount int `json:"activeTaskCount"`
+
+	// CheckinCount 签到次数
+	CheckinCount int `json:"checkinCount"`
+
+	// GroupId 用户组ID
+	GroupId int `json:"groupId"`
+
+	// GroupName 用户组名称
+	GroupName string `json:"groupName"`
+
+	// MemberCount 成员人数，同时加入多个下级用户组的用户只计一次
+	MemberCount int `json:"memberCount"`
+
+	// ParentId 上级用户组ID，0为顶级用户组
+	ParentId int `json:"parentId"`
+
+	// SubgroupCount 全部下级用户组的数量
+	SubgroupCount int `json:"subgroupCount"`
+
+	// TaskCount 签到任务数
+	TaskCount int `json:"taskCount"`
+}
+
 // InternalServerError defines model for InternalServerError.
 type InternalServerError struct {
 	Code    string `json:"code"`
@@ -363,9 +719,57 @@ type NotFound struct {
 	Message string `json:"message"`
 }
 
+// ProfilePrivacy 隐私设置：是否向所在用户组的管理员展示对应字段，昵称与头像对同组成员始终可见
+type ProfilePrivacy struct {
+	// Email 展示邮箱，默认不展示
+	Email bool `json:"email"`
+
+	// Phone 展示手机号，默认不展示
+	Phone bool `json:"phone"`
+
+	// RealName 展示真实姓名，默认展示
+	RealName bool `json:"realName"`
+
+	// StudentNumber 展示学号或工号，默认展示
+	StudentNumber bool `json:"studentNumber"`
+}
+
 // RequestQueryStatus defines model for RequestQueryStatus.
 type RequestQueryStatus string
 
+// Session defines model for Session.
+type Session struct {
+	// CreatedAt 登录时间
+	CreatedAt time.Time `json:"createdAt"`
+
+	// Current 是否为发起本次请求的会话
+	Current bool `json:"current"`
+
+	// DeviceId 登录时上报的设备ID
+	DeviceId string `json:"deviceId"`
+
+	// DeviceName 登录时上报的设备名称
+	DeviceName string `json:"deviceName"`
+
+	// ExpiresAt 会话过期时间，每次刷新令牌后顺延
+	ExpiresAt time.Time `json:"expiresAt"`
+
+	// Id 会话ID
+	Id int `json:"id,omitempty"`
+
+	// Ip 登录时的客户端IP
+	Ip string `json:"ip"`
+
+	// LastSeenAt 最近活跃时间
+	LastSeenAt time.Time `json:"lastSeenAt"`
+
+	// LastSeenIp 最近活跃时的客户端IP
+	LastSeenIp string `json:"lastSeenIp"`
+
+	// UserAgent 登录时的User-Agent
+	UserAgent string `json:"userAgent"`
+}
+
 // Success defines model for Success.
 type Success struct {
 	Code string                  `json:"code"`
@@ -417,6 +821,27 @@ type Unauthorized struct {
 
 // User defines model for User.
 type User struct {
+	// AvatarUrl 头像地址
+	AvatarUrl string `json:"avatarUrl,omitempty"`
+
+	// DisplayName 昵称
+	DisplayName string `json:"displayName,omitempty"`
+
+	// Email 邮箱
+	Email string `json:"email,omitempty"`
+
+	// Phone 手机号
+	Phone string `json:"phone,omitempty"`
+
+	// Privacy 隐私设置：是否向所在用户组的管理员展示对应字段，昵称与头像对同组成员始终可见
+	Privacy *ProfilePrivacy `json:"privacy,omitempty"`
+
+	// RealName 真实姓名
+	RealName string `json:"realName,omitempty"`
+
+	// StudentNumber 学号或工号
+	StudentNumber string `json:"studentNumber,omitempty"`
+
 	// UserId 用户ID
 	UserId int `json:"userId,omitempty"`
 
@@ -453,6 +878,84 @@ type WifiInfo struct {
 	Ssid string `json:"ssid"`
 }
 
+// GetAdminActionsParams defines parameters for GetAdminActions.
+type GetAdminActionsParams struct {
+	// ActorId 按操作者用户ID筛选
+	ActorId *int `form:"actorId,omitempty" json:"actorId,omitempty"`
+
+	// Action 按操作类型筛选
+	Action *string `form:"action,omitempty" json:"action,omitempty"`
+
+	// TargetType 按对象类型筛选
+	TargetType *string `form:"targetType,omitempty" json:"targetType,omitempty"`
+
+	// TargetId 按对象ID筛选
+	TargetId *int `form:"targetId,omitempty" json:"targetId,omitempty"`
+
+	// Page 页码，从1开始，默认1
+	Page *int `form:"page,omitempty" json:"page,omitempty"`
+
+	// PageSize 每页数量，默认20，最大100
+	PageSize *int `form:"pageSize,omitempty" json:"pageSize,omitempty"`
+}
+
+// GetAdminGroupsParams defines parameters for GetAdminGroups.
+type GetAdminGroupsParams struct {
+	// Q 关键字
+	Q *string `form:"q,omitempty" json:"q,omitempty"`
+
+	// Page 页码，从1开始，默认1
+	Page *int `form:"page,omitempty" json:"page,omitempty"`
+
+	// PageSize 每页数量，默认20，最大100
+	PageSize *int `form:"pageSize,omitempty" json:"pageSize,omitempty"`
+}
+
+// DeleteAdminGroupsGroupIdParams defines parameters for DeleteAdminGroupsGroupId.
+type DeleteAdminGroupsGroupIdParams struct {
+	// Reason 删除原因，记录在操作日志中
+	Reason *string `form:"reason,omitempty" json:"reason,omitempty"`
+}
+
+// PostAdminLoginLockoutsUnlockJSONBody defines parameters for PostAdminLoginLockoutsUnlock.
+type PostAdminLoginLockoutsUnlockJSONBody struct {
+	// Ip 要解锁的客户端IP
+	Ip string `binding:"omitempty,ip" json:"ip,omitempty"`
+
+	// Username 要解锁的用户名
+	Username string `binding:"max=64" json:"username,omitempty"`
+}
+
+// GetAdminUsersParams defines parameters for GetAdminUsers.
+type GetAdminUsersParams struct {
+	// Q 关键字
+	Q *string `form:"q,omitempty" json:"q,omitempty"`
+
+	// Disabled 按是否停用筛选，不传则不筛选
+	Disabled *bool `form:"disabled,omitempty" json:"disabled,omitempty"`
+
+	// Page 页码，从1开始，默认1
+	Page *int `form:"page,omitempty" json:"page,omitempty"`
+
+	// PageSize 每页数量，默认20，最大100
+	PageSize *int `form:"pageSize,omitempty" json:"pageSize,omitempty"`
+}
+
+// PostAdminUsersUserIdDisableJSONBody defines parameters for PostAdminUsersUserIdDisable.
+type PostAdminUsersUserIdDisableJSONBody struct {
+	// Reason 停用原因，记录在操作日志中
+	Reason string `binding:"max=255" json:"reason,omitempty"`
+}
+
+// PutAdminUsersUserIdPlatformRoleJSONBody defines parameters for PutAdminUsersUserIdPlatformRole.
+type PutAdminUsersUserIdPlatformRoleJSONBody struct {
+	// Role 平台角色
+	Role PutAdminUsersUserIdPlatformRoleJSONBodyRole `binding:"required,oneof=user super_admin" json:"role"`
+}
+
+// PutAdminUsersUserIdPlatformRoleJSONBodyRole defines parameters for PutAdminUsersUserIdPlatformRole.
+type PutAdminUsersUserIdPlatformRoleJSONBodyRole string
+
 // PutAuditRequestsAuditRequestIdJSONBody defines parameters for PutAuditRequestsAuditRequestId.
 type PutAuditRequestsAuditRequestIdJSONBody struct {
 	// Action 处理动作
@@ -464,6 +967,12 @@ type PutAuditRequestsAuditRequestIdJSONBodyAction string
 
 // PostAuthLoginJSONBody defines parameters for PostAuthLogin.
 type PostAuthLoginJSONBody struct {
+	// DeviceId 客户端设备ID，刷新令牌与该设备绑定，刷新时需提供相同的设备ID
+	DeviceId string `binding:"max=128" json:"deviceId,omitempty"`
+
+	// DeviceName 设备名称，例如 iPhone 15
+	DeviceName string `binding:"max=128" json:"deviceName,omitempty"`
+
 	// Password 密码
 	Password string `binding:"required" json:"password,omitempty"`
 
@@ -471,6 +980,57 @@ type PostAuthLoginJSONBody struct {
 	Username string `binding:"required" json:"username"`
 }
 
+// PostAuthLogin2faJSONBody defines parameters for PostAuthLogin2fa.
+type PostAuthLogin2faJSONBody struct {
+	// Code 6位验证码或恢复码
+	Code string `binding:"required,max=32" json:"code"`
+
+	// MfaToken /auth/login 返回的两步登录挑战令牌
+	MfaToken string `binding:"required,max=128" json:"mfaToken"`
+}
+
+// PostAuthOidcAuthorizeJSONBody defines parameters for PostAuthOidcAuthorize.
+type PostAuthOidcAuthorizeJSONBody struct {
+	// DeviceId 客户端设备ID，刷新令牌与该设备绑定，刷新时需提供相同的设备ID
+	DeviceId string `binding:"max=128" json:"deviceId,omitempty"`
+
+	// DeviceName 设备名称，例如 iPhone 15
+	DeviceName string `binding:"max=128" json:"deviceName,omitempty"`
+}
+
+// PostAuthOidcCallbackJSONBody defines parameters for PostAuthOidcCallback.
+type PostAuthOidcCallbackJSONBody struct {
+	// Code 身份提供方返回的授权码
+	Code string `binding:"required,max=2048" json:"code"`
+
+	// State 身份提供方原样返回的state
+	State string `binding:"required,max=128" json:"state"`
+}
+
+// PostAuthPasswordResetJSONBody defines parameters for PostAuthPasswordReset.
+type PostAuthPasswordResetJSONBody struct {
+	// Username 用户名
+	Username string `binding:"required,max=50" json:"username"`
+}
+
+// PostAuthPasswordResetConfirmJSONBody defines parameters for PostAuthPasswordResetConfirm.
+type PostAuthPasswordResetConfirmJSONBody struct {
+	// NewPassword 新密码
+	NewPassword string `binding:"required,min=6,max=128" json:"newPassword,omitempty"`
+
+	// Token 投递的重置令牌
+	Token string `binding:"required" json:"token,omitempty"`
+}
+
+// PostAuthRefreshJSONBody defines parameters for PostAuthRefresh.
+type PostAuthRefreshJSONBody struct {
+	// DeviceId 登录时提供的设备ID
+	DeviceId string `binding:"max=128" json:"deviceId,omitempty"`
+
+	// RefreshToken 登录或上次刷新时获得的刷新令牌
+	RefreshToken string `binding:"required" json:"refreshToken,omitempty"`
+}
+
 // PostAuthRegisterJSONBody defines parameters for PostAuthRegister.
 type PostAuthRegisterJSONBody struct {
 	// Password 密码
@@ -567,6 +1127,15 @@ type PostGroupsJSONBody struct {
 
 	// GroupName 用户组名称
 	GroupName string `binding:"required,min=1,max=50" json:"groupName"`
+
+	// ParentId 上级用户组ID，指定时在该用户组下创建下级用户组，需要具有上级用户组的 subgroup.manage 权限
+	ParentId int `binding:"omitempty,gt=0" json:"parentId,omitempty"`
+}
+
+// PostGroupsJoinByCodeJSONBody defines parameters for PostGroupsJoinByCode.
+type PostGroupsJoinByCodeJSONBody struct {
+	// Code 邀请码，忽略大小写、空格与连字符
+	Code string `binding:"required,max=32" json:"code"`
 }
 
 // PutGroupsGroupIdJSONBody defines parameters for PutGroupsGroupId.
@@ -576,6 +1145,12 @@ type PutGroupsGroupIdJSONBody struct {
 
 	// GroupName 新的用户组名称
 	GroupName string `binding:"required,min=1,max=50" json:"groupName"`
+
+	// JoinPolicy 加入方式：open(提交申请后自动通过)、approval(组管理员审批)、invite_only(只能凭邀请码加入)、closed(不接受新成员)
+	JoinPolicy GroupJoinPolicy `json:"joinPolicy,omitempty"`
+
+	// RequireAdmin2fa 是否要求该组管理员开启两步验证，不传则保持不变
+	RequireAdmin2fa *bool `json:"requireAdmin2fa,omitempty"`
 }
 
 // GetGroupsGroupIdAuditRequestsParams defines parameters for GetGroupsGroupIdAuditRequests.
@@ -602,6 +1177,21 @@ type PostGroupsGroupIdCheckinTasksJSONBody struct {
 	VerificationConfig TaskVerificationConfig `json:"verificationConfig"`
 }
 
+// PostGroupsGroupIdInvitesJSONBody defines parameters for PostGroupsGroupIdInvites.
+type PostGroupsGroupIdInvitesJSONBody struct {
+	// ExpiresInHours 有效小时数，不填或为0时长期有效
+	ExpiresInHours int `binding:"min=0" json:"expiresInHours,omitempty"`
+
+	// MaxUses 可使用次数上限，不填或为0时不限次数
+	MaxUses int `binding:"min=0" json:"maxUses,omitempty"`
+
+	// Role 加入后的组内角色，默认为普通成员
+	Role PostGroupsGroupIdInvitesJSONBodyRole `json:"role,omitempty"`
+}
+
+// PostGroupsGroupIdInvitesJSONBodyRole defines parameters for PostGroupsGroupIdInvites.
+type PostGroupsGroupIdInvitesJSONBodyRole string
+
 // GetGroupsGroupIdJoinRequestsParams defines parameters for GetGroupsGroupIdJoinRequests.
 type GetGroupsGroupIdJoinRequestsParams struct {
 	// Status 筛选状态: `pending` (默认), `approved`, `rejected`, `all`。
@@ -626,6 +1216,57 @@ type PutGroupsGroupIdJoinRequestsRequestIdJSONBody struct {
 // PutGroupsGroupIdJoinRequestsRequestIdJSONBodyAction defines parameters for PutGroupsGroupIdJoinRequestsRequestId.
 type PutGroupsGroupIdJoinRequestsRequestIdJSONBodyAction string
 
+// PutGroupsGroupIdMembersUserIdRoleJSONBody defines parameters for PutGroupsGroupIdMembersUserIdRole.
+type PutGroupsGroupIdMembersUserIdRoleJSONBody struct {
+	// Role 新的组内角色：admin、member 或本组自定义角色的名称
+	Role string `binding:"required" json:"role"`
+}
+
+// PutGroupsGroupIdParentJSONBody defines parameters for PutGroupsGroupIdParent.
+type PutGroupsGroupIdParentJSONBody struct {
+	// ParentId 新的上级用户组ID，为0时成为顶级用户组
+	ParentId int `binding:"gte=0" json:"parentId"`
+}
+
+// GetGroupsGroupIdRoleChangesParams defines parameters for GetGroupsGroupIdRoleChanges.
+type GetGroupsGroupIdRoleChangesParams struct {
+	// Page 页码，从1开始，默认1
+	Page *int `form:"page,omitempty" json:"page,omitempty"`
+
+	// PageSize 每页数量，默认20，最大100
+	PageSize *int `form:"pageSize,omitempty" json:"pageSize,omitempty"`
+}
+
+// PostGroupsGroupIdRolesJSONBody defines parameters for PostGroupsGroupIdRoles.
+type PostGroupsGroupIdRolesJSONBody struct {
+	// Description 角色说明
+	Description string `json:"description,omitempty"`
+
+	// Name 角色名称，不能为 admin、member 或 owner
+	Name string `binding:"required" json:"name"`
+
+	// Permissions 角色具有的组内权限，至少一项
+	Permissions []GroupPermission `binding:"required" json:"permissions"`
+}
+
+// PutGroupsGroupIdRolesRoleIdJSONBody defines parameters for PutGroupsGroupIdRolesRoleId.
+type PutGroupsGroupIdRolesRoleIdJSONBody struct {
+	// Description 角色说明
+	Description string `json:"description,omitempty"`
+
+	// Name 角色名称，不能为 admin、member 或 owner
+	Name string `binding:"required" json:"name"`
+
+	// Permissions 角色具有的组内权限，至少一项
+	Permissions []GroupPermission `binding:"required" json:"permissions"`
+}
+
+// PostGroupsGroupIdTransferOwnershipJSONBody defines parameters for PostGroupsGroupIdTransferOwnership.
+type PostGroupsGroupIdTransferOwnershipJSONBody struct {
+	// UserId 新所有者的用户ID，必须是该组成员
+	UserId int `binding:"required,gt=0" json:"userId"`
+}
+
 // GetStatisticsDailyParams defines parameters for GetStatisticsDaily.
 type GetStatisticsDailyParams struct {
 	// GroupId 用户组ID（可选，筛选特定用户组的统计数据）
@@ -659,6 +1300,53 @@ type GetStatisticsUsersParams struct {
 	EndDate *int `form:"endDate,omitempty" json:"endDate,omitempty"`
 }
 
+// PutUsersMeJSONBody defines parameters for PutUsersMe.
+type PutUsersMeJSONBody struct {
+	// AvatarUrl 头像地址，http或https
+	AvatarUrl *string `binding:"omitempty,max=512" json:"avatarUrl,omitempty"`
+
+	// DisplayName 昵称，空字符串表示清除
+	DisplayName *string `binding:"omitempty,max=50" json:"displayName,omitempty"`
+
+	// Email 邮箱
+	Email *string `binding:"omitempty,max=128" json:"email,omitempty"`
+
+	// Phone 手机号，数字，可带+国家码与连字符
+	Phone *string `binding:"omitempty,max=32" json:"phone,omitempty"`
+
+	// Privacy 隐私设置，未提交的项保持不变
+	Privacy *struct {
+		Email         *bool `json:"email,omitempty"`
+		Phone         *bool `json:"phone,omitempty"`
+		RealName      *bool `json:"realName,omitempty"`
+		StudentNumber *bool `json:"studentNumber,omitempty"`
+	} `json:"privacy,omitempty"`
+
+	// RealName 真实姓名
+	RealName *string `binding:"omitempty,max=50" json:"realName,omitempty"`
+
+	// StudentNumber 学号或工号，字母、数字或连字符
+	StudentNumber *string `binding:"omitempty,max=32" json:"studentNumber,omitempty"`
+}
+
+// PostUsersMe2faConfirmJSONBody defines parameters for PostUsersMe2faConfirm.
+type PostUsersMe2faConfirmJSONBody struct {
+	// Code 验证器App生成的6位验证码
+	Code string `binding:"required,max=32" json:"code"`
+}
+
+// PostUsersMe2faDisableJSONBody defines parameters for PostUsersMe2faDisable.
+type PostUsersMe2faDisableJSONBody struct {
+	// Code 6位验证码或恢复码
+	Code string `binding:"required,max=32" json:"code"`
+}
+
+// PostUsersMe2faRecoveryCodesJSONBody defines parameters for PostUsersMe2faRecoveryCodes.
+type PostUsersMe2faRecoveryCodesJSONBody struct {
+	// Code 6位验证码或恢复码
+	Code string `binding:"required,max=32" json:"code"`
+}
+
 // GetUsersMeFaceParams defines parameters for GetUsersMeFace.
 type GetUsersMeFaceParams struct {
 	// UserId 用户ID
@@ -683,12 +1371,69 @@ type PostUsersMeFaceVerifyJSONBody struct {
 	UserId int `json:"userId,omitempty"`
 }
 
+// PutUsersMePasswordJSONBody defines parameters for PutUsersMePassword.
+type PutUsersMePasswordJSONBody struct {
+	// NewPassword 新密码
+	NewPassword string `binding:"required,min=6,max=128" json:"newPassword,omitempty"`
+
+	// OldPassword 原密码
+	OldPassword string `binding:"required,max=128" json:"oldPassword,omitempty"`
+}
+
+// PostUsersMeTokensJSONBody defines parameters for PostUsersMeTokens.
+type PostUsersMeTokensJSONBody struct {
+	// ExpiresInDays 有效天数，不填时使用服务端默认有效期，不能超过服务端允许的最长有效期
+	ExpiresInDays *int `json:"expiresInDays,omitempty"`
+
+	// Name 令牌名称，例如用途说明
+	Name string `binding:"required,max=64" json:"name"`
+
+	// Scopes 权限范围，至少一项
+	Scopes []PostUsersMeTokensJSONBodyScopes `binding:"required,min=1" json:"scopes"`
+}
+
+// PostUsersMeTokensJSONBodyScopes defines parameters for PostUsersMeTokens.
+type PostUsersMeTokensJSONBodyScopes string
+
+// PutUsersMeUsernameJSONBody defines parameters for PutUsersMeUsername.
+type PutUsersMeUsernameJSONBody struct {
+	// Username 新用户名
+	Username string `binding:"required,min=3,max=50" json:"username"`
+}
+
+// PostAdminLoginLockoutsUnlockJSONRequestBody defines body for PostAdminLoginLockoutsUnlock for application/json ContentType.
+type PostAdminLoginLockoutsUnlockJSONRequestBody PostAdminLoginLockoutsUnlockJSONBody
+
+// PostAdminUsersUserIdDisableJSONRequestBody defines body for PostAdminUsersUserIdDisable for application/json ContentType.
+type PostAdminUsersUserIdDisableJSONRequestBody PostAdminUsersUserIdDisableJSONBody
+
+// PutAdminUsersUserIdPlatformRoleJSONRequestBody defines body for PutAdminUsersUserIdPlatformRole for application/json ContentType.
+type PutAdminUsersUserIdPlatformRoleJSONRequestBody PutAdminUsersUserIdPlatformRoleJSONBody
+
 // PutAuditRequestsAuditRequestIdJSONRequestBody defines body for PutAuditRequestsAuditRequestId for application/json ContentType.
 type PutAuditRequestsAuditRequestIdJSONRequestBody PutAuditRequestsAuditRequestIdJSONBody
 
 // PostAuthLoginJSONRequestBody defines body for PostAuthLogin for application/json ContentType.
 type PostAuthLoginJSONRequestBody PostAuthLoginJSONBody
 
+// PostAuthLogin2faJSONRequestBody defines body for PostAuthLogin2fa for application/json ContentType.
+type PostAuthLogin2faJSONRequestBody PostAuthLogin2faJSONBody
+
+// PostAuthOidcAuthorizeJSONRequestBody defines body for PostAuthOidcAuthorize for application/json ContentType.
+type PostAuthOidcAuthorizeJSONRequestBody PostAuthOidcAuthorizeJSONBody
+
+// PostAuthOidcCallbackJSONRequestBody defines body for PostAuthOidcCallback for application/json ContentType.
+type PostAuthOidcCallbackJSONRequestBody PostAuthOidcCallbackJSONBody
+
+// PostAuthPasswordResetJSONRequestBody defines body for PostAuthPasswordReset for application/json ContentType.
+type PostAuthPasswordResetJSONRequestBody PostAuthPasswordResetJSONBody
+
+// PostAuthPasswordResetConfirmJSONRequestBody defines body for PostAuthPasswordResetConfirm for application/json ContentType.
+type PostAuthPasswordResetConfirmJSONRequestBody PostAuthPasswordResetConfirmJSONBody
+
+// PostAuthRefreshJSONRequestBody defines body for PostAuthRefresh for application/json ContentType.
+type PostAuthRefreshJSONRequestBody PostAuthRefreshJSONBody
+
 // PostAuthRegisterJSONRequestBody defines body for PostAuthRegister for application/json ContentType.
 type PostAuthRegisterJSONRequestBody PostAuthRegisterJSONBody
 
@@ -710,24 +1455,66 @@ type PostExportTasksJSONRequestBody PostExportTasksJSONBody
 // PostGroupsJSONRequestBody defines body for PostGroups for application/json ContentType.
 type PostGroupsJSONRequestBody PostGroupsJSONBody
 
+// PostGroupsJoinByCodeJSONRequestBody defines body for PostGroupsJoinByCode for application/json ContentType.
+type PostGroupsJoinByCodeJSONRequestBody PostGroupsJoinByCodeJSONBody
+
 // PutGroupsGroupIdJSONRequestBody defines body for PutGroupsGroupId for application/json ContentType.
 type PutGroupsGroupIdJSONRequestBody PutGroupsGroupIdJSONBody
 
 // PostGroupsGroupIdCheckinTasksJSONRequestBody defines body for PostGroupsGroupIdCheckinTasks for application/json ContentType.
 type PostGroupsGroupIdCheckinTasksJSONRequestBody PostGroupsGroupIdCheckinTasksJSONBody
 
+// PostGroupsGroupIdInvitesJSONRequestBody defines body for PostGroupsGroupIdInvites for application/json ContentType.
+type PostGroupsGroupIdInvitesJSONRequestBody PostGroupsGroupIdInvitesJSONBody
+
 // PostGroupsGroupIdJoinRequestsJSONRequestBody defines body for PostGroupsGroupIdJoinRequests for application/json ContentType.
 type PostGroupsGroupIdJoinRequestsJSONRequestBody PostGroupsGroupIdJoinRequestsJSONBody
 
 // PutGroupsGroupIdJoinRequestsRequestIdJSONRequestBody defines body for PutGroupsGroupIdJoinRequestsRequestId for application/json ContentType.
 type PutGroupsGroupIdJoinRequestsRequestIdJSONRequestBody PutGroupsGroupIdJoinRequestsRequestIdJSONBody
 
+// PutGroupsGroupIdMembersUserIdRoleJSONRequestBody defines body for PutGroupsGroupIdMembersUserIdRole for application/json ContentType.
+type PutGroupsGroupIdMembersUserIdRoleJSONRequestBody PutGroupsGroupIdMembersUserIdRoleJSONBody
+
+// PutGroupsGroupIdParentJSONRequestBody defines body for PutGroupsGroupIdParent for application/json ContentType.
+type PutGroupsGroupIdParentJSONRequestBody PutGroupsGroupIdParentJSONBody
+
+// PostGroupsGroupIdRolesJSONRequestBody defines body for PostGroupsGroupIdRoles for application/json ContentType.
+type PostGroupsGroupIdRolesJSONRequestBody PostGroupsGroupIdRolesJSONBody
+
+// PutGroupsGroupIdRolesRoleIdJSONRequestBody defines body for PutGroupsGroupIdRolesRoleId for application/json ContentType.
+type PutGroupsGroupIdRolesRoleIdJSONRequestBody PutGroupsGroupIdRolesRoleIdJSONBody
+
+// PostGroupsGroupIdTransferOwnershipJSONRequestBody defines body for PostGroupsGroupIdTransferOwnership for application/json ContentType.
+type PostGroupsGroupIdTransferOwnershipJSONRequestBody PostGroupsGroupIdTransferOwnershipJSONBody
+
+// PutUsersMeJSONRequestBody defines body for PutUsersMe for application/json ContentType.
+type PutUsersMeJSONRequestBody PutUsersMeJSONBody
+
+// PostUsersMe2faConfirmJSONRequestBody defines body for PostUsersMe2faConfirm for application/json ContentType.
+type PostUsersMe2faConfirmJSONRequestBody PostUsersMe2faConfirmJSONBody
+
+// PostUsersMe2faDisableJSONRequestBody defines body for PostUsersMe2faDisable for application/json ContentType.
+type PostUsersMe2faDisableJSONRequestBody PostUsersMe2faDisableJSONBody
+
+// PostUsersMe2faRecoveryCodesJSONRequestBody defines body for PostUsersMe2faRecoveryCodes for application/json ContentType.
+type PostUsersMe2faRecoveryCodesJSONRequestBody PostUsersMe2faRecoveryCodesJSONBody
+
 // PutUsersMeFaceJSONRequestBody defines body for PutUsersMeFace for application/json ContentType.
 type PutUsersMeFaceJSONRequestBody PutUsersMeFaceJSONBody
 
 // PostUsersMeFaceVerifyJSONRequestBody defines body for PostUsersMeFaceVerify for application/json ContentType.
 type PostUsersMeFaceVerifyJSONRequestBody PostUsersMeFaceVerifyJSONBody
 
+// PutUsersMePasswordJSONRequestBody defines body for PutUsersMePassword for application/json ContentType.
+type PutUsersMePasswordJSONRequestBody PutUsersMePasswordJSONBody
+
+// PostUsersMeTokensJSONRequestBody defines body for PostUsersMeTokens for application/json ContentType.
+type PostUsersMeTokensJSONRequestBody PostUsersMeTokensJSONBody
+
+// PutUsersMeUsernameJSONRequestBody defines body for PutUsersMeUsername for application/json ContentType.
+type PutUsersMeUsernameJSONRequestBody PutUsersMeUsernameJSONBody
+
 // AsSuccessWithDataData0 returns the union data inside the SuccessWithData_Data as a SuccessWithDataData0
 func (t SuccessWithData_Data) AsSuccessWithDataData0() (SuccessWithDataData0, error) {
 	var body SuccessWithDataData0
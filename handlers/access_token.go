@@ -0,0 +1,113 @@
+package handlers
+
+import (
+	"TeamTickBackend/app"
+	"TeamTickBackend/dal/models"
+	"TeamTickBackend/gen"
+	"TeamTickBackend/middlewares"
+	appErrors "TeamTickBackend/pkg/errors"
+	service "TeamTickBackend/services"
+	"context"
+	"errors"
+	"strings"
+	"time"
+)
+
+func newAccessTokenService(container *app.AppContainer) *service.AccessTokenService {
+	return service.NewAccessTokenService(
+		container.DaoFactory.PersonalAccessTokenDAO,
+		container.DaoFactory.UserDAO,
+		container.Config.AccessTokens,
+		container.ServiceLogger,
+	)
+}
+
+// NewAccessTokenAuthenticator 供 AuthMiddleware 校验个人访问令牌
+func NewAccessTokenAuthenticator(container *app.AppContainer) middlewares.AccessTokenAuthenticator {
+	return newAccessTokenService(container)
+}
+
+// 列出个人访问令牌
+func (h *UserHandler) GetUsersMeTokens(ctx context.Context, request gen.GetUsersMeTokensRequestObject) (gen.GetUsersMeTokensResponseObject, error) {
+	userID, ok := ctx.Value("userID").(int)
+	if !ok {
+		return nil, appErrors.ErrJwtParseFailed
+	}
+	tokens, err := h.accessTokenService.List(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	data := make([]gen.AccessToken, 0, len(tokens))
+	for _, token := range tokens {
+		data = append(data, toGenAccessToken(token))
+	}
+	return gen.GetUsersMeTokens200JSONResponse{Code: "0", Data: data}, nil
+}
+
+// 创建个人访问令牌，令牌明文只在本次响应中返回
+func (h *UserHandler) PostUsersMeTokens(ctx context.Context, request gen.PostUsersMeTokensRequestObject) (gen.PostUsersMeTokensResponseObject, error) {
+	userID, ok := ctx.Value("userID").(int)
+	if !ok {
+		return nil, appErrors.ErrJwtParseFailed
+	}
+	scopes := make([]string, 0, len(request.Body.Scopes))
+	for _, scope := range request.Body.Scopes {
+		scopes = append(scopes, string(scope))
+	}
+	var expiresIn time.Duration
+	if request.Body.ExpiresInDays != nil {
+		if *request.Body.ExpiresInDays <= 0 {
+			return &gen.PostUsersMeTokens400JSONResponse{Code: "1", Message: appErrors.ErrAccessTokenExpiryInvalid.Error()}, nil
+		}
+		expiresIn = time.Duration(*request.Body.ExpiresInDays) * 24 * time.Hour
+	}
+
+	token, plaintext, err := h.accessTokenService.Create(ctx, userID, strings.TrimSpace(request.Body.Name), scopes, expiresIn)
+	if err != nil {
+		switch {
+		case errors.Is(err, appErrors.ErrAccessTokenScopeInvalid), errors.Is(err, appErrors.ErrAccessTokenExpiryInvalid):
+			return &gen.PostUsersMeTokens400JSONResponse{Code: "1", Message: err.Error()}, nil
+		case errors.Is(err, appErrors.ErrAccessTokensDisabled):
+			return &gen.PostUsersMeTokens403JSONResponse{Code: "1", Message: err.Error()}, nil
+		case errors.Is(err, appErrors.ErrAccessTokenLimitReached):
+			return &gen.PostUsersMeTokens409JSONResponse{Code: "1", Message: err.Error()}, nil
+		}
+		return nil, err
+	}
+	response := gen.PostUsersMeTokens201JSONResponse{Code: "0"}
+	response.Data.Token = plaintext
+	response.Data.AccessToken = toGenAccessToken(token)
+	return response, nil
+}
+
+// 吊销个人访问令牌
+func (h *UserHandler) DeleteUsersMeTokensTokenId(ctx context.Context, request gen.DeleteUsersMeTokensTokenIdRequestObject) (gen.DeleteUsersMeTokensTokenIdResponseObject, error) {
+	userID, ok := ctx.Value("userID").(int)
+	if !ok {
+		return nil, appErrors.ErrJwtParseFailed
+	}
+	if err := h.accessTokenService.Revoke(ctx, userID, request.TokenId); err != nil {
+		if errors.Is(err, appErrors.ErrAccessTokenNotFound) {
+			return &gen.DeleteUsersMeTokensTokenId404JSONResponse{Code: "1", Message: err.Error()}, nil
+		}
+		return nil, err
+	}
+	return gen.DeleteUsersMeTokensTokenId200JSONResponse{Code: "0"}, nil
+}
+
+func toGenAccessToken(token *models.PersonalAccessToken) gen.AccessToken {
+	scopes := make([]gen.AccessTokenScopes, 0)
+	for _, scope := range strings.Fields(token.Scopes) {
+		scopes = append(scopes, gen.AccessTokenScopes(scope))
+	}
+	return gen.AccessToken{
+		Id:         token.ID,
+		Name:       token.Name,
+		TokenHint:  token.TokenHint,
+		Scopes:     scopes,
+		ExpiresAt:  token.ExpiresAt,
+		LastUsedAt: token.LastUsedAt,
+		LastUsedIp: token.LastUsedIP,
+		CreatedAt:  token.CreatedAt,
+	}
+}
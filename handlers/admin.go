@@ -0,0 +1,299 @@
+package handlers
+
+import (
+	"TeamTickBackend/app"
+	"TeamTickBackend/dal/models"
+	"TeamTickBackend/gen"
+	"TeamTickBackend/middlewares"
+	appErrors "TeamTickBackend/pkg/errors"
+	service "TeamTickBackend/services"
+	"context"
+	"errors"
+)
+
+// AdminHandler 平台管理接口，路由上已校验平台管理员身份
+type AdminHandler struct {
+	adminService *service.AdminService
+}
+
+func NewAdminHandler(container *app.AppContainer) gen.AdminServerInterface {
+	handler := &AdminHandler{
+		adminService: newAdminService(container),
+	}
+	return gen.NewAdminStrictHandler(handler, []gen.AdminStrictMiddlewareFunc{middlewares.RequireAccessTokenScope})
+}
+
+// NewPlatformAdminChecker 供 PlatformAdminMiddleware 判断平台管理员身份
+func NewPlatformAdminChecker(container *app.AppContainer) middlewares.PlatformAdminChecker {
+	return newAdminService(container)
+}
+
+func newAdminService(container *app.AppContainer) *service.AdminService {
+	factory := container.DaoFactory
+	tokenService := newTokenService(container)
+	return service.NewAdminService(
+		factory.UserDAO,
+		factory.GroupDAO,
+		factory.AdminActionDAO,
+		factory.PlatformStatsDAO,
+		factory.TransactionManager,
+		service.NewGroupsService(
+			factory.GroupDAO,
+			factory.GroupMemberDAO,
+			factory.JoinApplicationDAO,
+			factory.UserDAO,
+			factory.DenormalizationDAO,
+			factory.GroupRoleChangeDAO,
+			factory.GroupRoleDAO,
+			factory.TransactionManager,
+			container.ServiceLogger,
+		),
+		tokenService,
+		newPasswordService(container, tokenService),
+		service.NewTaskService(
+			factory.TaskDAO,
+			factory.TaskRecordDAO,
+			factory.TransactionManager,
+			factory.GroupDAO,
+			factory.DenormalizationDAO,
+			factory.UserDAO,
+			container.ServiceLogger,
+		),
+		// 登录保护关闭时同样可以清除此前遗留的锁定
+		service.NewLoginGuard(factory.LoginAttemptDAO, container.Config.LoginProtection, container.ServiceLogger),
+		container.Config.Admin.UserIDs,
+		container.ServiceLogger,
+	)
+}
+
+// adminActor 当前请求的平台管理员，写入操作日志
+func adminActor(ctx context.Context) (service.AdminActor, error) {
+	userID, ok := ctx.Value("userID").(int)
+	if !ok {
+		return service.AdminActor{}, appErrors.ErrJwtParseFailed
+	}
+	username, _ := ctx.Value("username").(string)
+	return service.AdminActor{UserID: userID, Username: username, IP: clientIP(ctx)}, nil
+}
+
+// 解除用户名和（或）IP的登录锁定
+func (h *AdminHandler) PostAdminLoginLockoutsUnlock(ctx context.Context, request gen.PostAdminLoginLockoutsUnlockRequestObject) (gen.PostAdminLoginLockoutsUnlockResponseObject, error) {
+	if request.Body.Username == "" && request.Body.Ip == "" {
+		return &gen.PostAdminLoginLockoutsUnlock400JSONResponse{
+			Code:    "1",
+			Message: "用户名与IP至少提供一个",
+		}, nil
+	}
+	actor, err := adminActor(ctx)
+	if err != nil {
+		return nil, err
+	}
+	unlocked, err := h.adminService.UnlockLogin(ctx, actor, request.Body.Username, request.Body.Ip)
+	if err != nil {
+		return nil, err
+	}
+	response := gen.PostAdminLoginLockoutsUnlock200JSONResponse{Code: "0"}
+	response.Data.Unlocked = unlocked
+	return response, nil
+}
+
+// 查询用户
+func (h *AdminHandler) GetAdminUsers(ctx context.Context, request gen.GetAdminUsersRequestObject) (gen.GetAdminUsersResponseObject, error) {
+	params := request.Params
+	users, total, err := h.adminService.ListUsers(ctx, stringParam(params.Q), params.Disabled, intParam(params.Page), intParam(params.PageSize))
+	if err != nil {
+		return nil, err
+	}
+	response := gen.GetAdminUsers200JSONResponse{Code: "0"}
+	response.Data.Total = int(total)
+	response.Data.Items = make([]gen.AdminUser, 0, len(users))
+	for _, user := range users {
+		response.Data.Items = append(response.Data.Items, toGenAdminUser(user))
+	}
+	return response, nil
+}
+
+// 停用账号
+func (h *AdminHandler) PostAdminUsersUserIdDisable(ctx context.Context, request gen.PostAdminUsersUserIdDisableRequestObject) (gen.PostAdminUsersUserIdDisableResponseObject, error) {
+	actor, err := adminActor(ctx)
+	if err != nil {
+		return nil, err
+	}
+	user, err := h.adminService.DisableUser(ctx, actor, request.UserId, request.Body.Reason)
+	if err != nil {
+		switch {
+		case errors.Is(err, appErrors.ErrAdminSelfAction):
+			return &gen.PostAdminUsersUserIdDisable400JSONResponse{Code: "1", Message: err.Error()}, nil
+		case errors.Is(err, appErrors.ErrUserNotFound):
+			return &gen.PostAdminUsersUserIdDisable404JSONResponse{Code: "1", Message: err.Error()}, nil
+		case errors.Is(err, appErrors.ErrUserAlreadyDisabled):
+			return &gen.PostAdminUsersUserIdDisable409JSONResponse{Code: "1", Message: err.Error()}, nil
+		}
+		return nil, err
+	}
+	return gen.PostAdminUsersUserIdDisable200JSONResponse{Code: "0", Data: toGenAdminUser(user)}, nil
+}
+
+// 恢复账号
+func (h *AdminHandler) PostAdminUsersUserIdEnable(ctx context.Context, request gen.PostAdminUsersUserIdEnableRequestObject) (gen.PostAdminUsersUserIdEnableResponseObject, error) {
+	actor, err := adminActor(ctx)
+	if err != nil {
+		return nil, err
+	}
+	user, err := h.adminService.EnableUser(ctx, actor, request.UserId)
+	if err != nil {
+		switch {
+		case errors.Is(err, appErrors.ErrUserNotFound):
+			return &gen.PostAdminUsersUserIdEnable404JSONResponse{Code: "1", Message: err.Error()}, nil
+		case errors.Is(err, appErrors.ErrUserNotDisabled):
+			return &gen.PostAdminUsersUserIdEnable409JSONResponse{Code: "1", Message: err.Error()}, nil
+		}
+		return nil, err
+	}
+	return gen.PostAdminUsersUserIdEnable200JSONResponse{Code: "0", Data: toGenAdminUser(user)}, nil
+}
+
+// 设置平台角色
+func (h *AdminHandler) PutAdminUsersUserIdPlatformRole(ctx context.Context, request gen.PutAdminUsersUserIdPlatformRoleRequestObject) (gen.PutAdminUsersUserIdPlatformRoleResponseObject, error) {
+	actor, err := adminActor(ctx)
+	if err != nil {
+		return nil, err
+	}
+	user, err := h.adminService.SetPlatformRole(ctx, actor, request.UserId, string(request.Body.Role))
+	if err != nil {
+		switch {
+		case errors.Is(err, appErrors.ErrAdminSelfAction), errors.Is(err, appErrors.ErrPlatformRoleInvalid):
+			return &gen.PutAdminUsersUserIdPlatformRole400JSONResponse{Code: "1", Message: err.Error()}, nil
+		case errors.Is(err, appErrors.ErrUserNotFound):
+			return &gen.PutAdminUsersUserIdPlatformRole404JSONResponse{Code: "1", Message: err.Error()}, nil
+		}
+		return nil, err
+	}
+	return gen.PutAdminUsersUserIdPlatformRole200JSONResponse{Code: "0", Data: toGenAdminUser(user)}, nil
+}
+
+// 查询用户组
+func (h *AdminHandler) GetAdminGroups(ctx context.Context, request gen.GetAdminGroupsRequestObject) (gen.GetAdminGroupsResponseObject, error) {
+	params := request.Params
+	groups, total, err := h.adminService.ListGroups(ctx, stringParam(params.Q), intParam(params.Page), intParam(params.PageSize))
+	if err != nil {
+		return nil, err
+	}
+	response := gen.GetAdminGroups200JSONResponse{Code: "0"}
+	response.Data.Total = int(total)
+	response.Data.Items = make([]gen.Group, 0, len(groups))
+	for _, group := range groups {
+		response.Data.Items = append(response.Data.Items, gen.Group{
+			GroupId:         group.GroupID,
+			GroupName:       group.GroupName,
+			Description:     group.Description,
+			CreatorId:       group.CreatorID,
+			CreatorName:     group.CreatorName,
+			CreatedAt:       int(group.CreatedAt.Unix()),
+			MemberCount:     group.MemberNum,
+			ParentId:        group.ParentID,
+			RequireAdmin2fa: group.RequireAdmin2FA,
+		})
+	}
+	return response, nil
+}
+
+// 强制删除用户组
+func (h *AdminHandler) DeleteAdminGroupsGroupId(ctx context.Context, request gen.DeleteAdminGroupsGroupIdRequestObject) (gen.DeleteAdminGroupsGroupIdResponseObject, error) {
+	actor, err := adminActor(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := h.adminService.ForceDeleteGroup(ctx, actor, request.GroupId, stringParam(request.Params.Reason)); err != nil {
+		if errors.Is(err, appErrors.ErrGroupNotFound) {
+			return &gen.DeleteAdminGroupsGroupId404JSONResponse{Code: "1", Message: err.Error()}, nil
+		}
+		return nil, err
+	}
+	return gen.DeleteAdminGroupsGroupId200JSONResponse{Code: "0"}, nil
+}
+
+// 平台概况
+func (h *AdminHandler) GetAdminStats(ctx context.Context, request gen.GetAdminStatsRequestObject) (gen.GetAdminStatsResponseObject, error) {
+	stats, err := h.adminService.Stats(ctx)
+	if err != nil {
+		return nil, err
+	}
+	response := gen.GetAdminStats200JSONResponse{Code: "0"}
+	response.Data.Users = int(stats.Users)
+	response.Data.DisabledUsers = int(stats.DisabledUsers)
+	response.Data.SuperAdmins = int(stats.SuperAdmins)
+	response.Data.Groups = int(stats.Groups)
+	response.Data.Tasks = int(stats.Tasks)
+	response.Data.ActiveTasks = int(stats.ActiveTasks)
+	response.Data.Checkins = int(stats.CheckIns)
+	response.Data.CheckinsLast24h = int(stats.CheckInsLast24h)
+	response.Data.PendingAudits = int(stats.PendingAudits)
+	response.Data.PendingJoinRequests = int(stats.PendingJoinRequests)
+	return response, nil
+}
+
+// 查询管理操作日志
+func (h *AdminHandler) GetAdminActions(ctx context.Context, request gen.GetAdminActionsRequestObject) (gen.GetAdminActionsResponseObject, error) {
+	params := request.Params
+	filter := models.AdminActionFilter{
+		ActorID:    intParam(params.ActorId),
+		Action:     stringParam(params.Action),
+		TargetType: stringParam(params.TargetType),
+		TargetID:   intParam(params.TargetId),
+	}
+	actions, total, err := h.adminService.ListActions(ctx, filter, intParam(params.Page), intParam(params.PageSize))
+	if err != nil {
+		return nil, err
+	}
+	response := gen.GetAdminActions200JSONResponse{Code: "0"}
+	response.Data.Total = int(total)
+	response.Data.Items = make([]gen.AdminAction, 0, len(actions))
+	for _, action := range actions {
+		response.Data.Items = append(response.Data.Items, gen.AdminAction{
+			Id:         action.ID,
+			ActorId:    action.ActorID,
+			ActorName:  action.ActorName,
+			Action:     action.Action,
+			TargetType: action.TargetType,
+			TargetId:   action.TargetID,
+			TargetName: action.TargetName,
+			Detail:     action.Detail,
+			Ip:         action.IP,
+			CreatedAt:  action.CreatedAt,
+		})
+	}
+	return response, nil
+}
+
+func toGenAdminUser(user *models.User) gen.AdminUser {
+	return gen.AdminUser{
+		UserId:         user.UserID,
+		Username:       user.Username,
+		DisplayName:    user.DisplayName,
+		RealName:       user.RealName,
+		StudentNumber:  user.StudentNumber,
+		Email:          user.Email,
+		PlatformRole:   gen.AdminUserPlatformRole(user.PlatformRole),
+		Disabled:       user.DisabledAt != nil,
+		DisabledAt:     user.DisabledAt,
+		DisabledReason: user.DisabledReason,
+		CreatedAt:      user.CreatedAt,
+	}
+}
+
+// stringParam 可选查询参数未提供时为空字符串
+func stringParam(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
+
+// intParam 可选查询参数未提供时为0
+func intParam(value *int) int {
+	if value == nil {
+		return 0
+	}
+	return *value
+}
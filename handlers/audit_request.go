@@ -2,8 +2,11 @@ package handlers
 
 import (
 	"TeamTickBackend/app"
+	"TeamTickBackend/dal/models"
 	"TeamTickBackend/gen"
+	"TeamTickBackend/middlewares"
 	appErrors "TeamTickBackend/pkg/errors"
+	"TeamTickBackend/pkg/metrics"
 	service "TeamTickBackend/services"
 	"context"
 	"errors"
@@ -12,6 +15,7 @@ import (
 type AuditRequestHandler struct {
 	auditRequestService *service.AuditRequestService
 	groupsService       *service.GroupsService
+	metrics             *metrics.Metrics
 }
 
 func NewAuditRequestHandler(container *app.AppContainer) gen.AuditRequestsServerInterface {
@@ -21,18 +25,25 @@ func NewAuditRequestHandler(container *app.AppContainer) gen.AuditRequestsServer
 		container.DaoFactory.TaskRecordDAO,
 		container.DaoFactory.TaskDAO,
 		container.DaoFactory.GroupDAO,
+		container.DaoFactory.UserDAO,
 	)
 	groupsService := service.NewGroupsService(
 		container.DaoFactory.GroupDAO,
 		container.DaoFactory.GroupMemberDAO,
 		container.DaoFactory.JoinApplicationDAO,
+		container.DaoFactory.UserDAO,
+		container.DaoFactory.DenormalizationDAO,
+		container.DaoFactory.GroupRoleChangeDAO,
+		container.DaoFactory.GroupRoleDAO,
 		container.DaoFactory.TransactionManager,
+		container.ServiceLogger,
 	)
 	handler := &AuditRequestHandler{
 		auditRequestService: auditRequestService,
 		groupsService:       groupsService,
+		metrics:             container.Metrics,
 	}
-	return gen.NewAuditRequestsStrictHandler(handler, nil)
+	return gen.NewAuditRequestsStrictHandler(handler, []gen.AuditRequestsStrictMiddlewareFunc{middlewares.RequireAccessTokenScope})
 }
 
 // 获取当前用户提交的所有审核请求列表
@@ -95,12 +106,12 @@ func (h *AuditRequestHandler) GetGroupsGroupIdAuditRequests(ctx context.Context,
 		return nil, appErrors.ErrJwtParseFailed
 	}
 
-	// 检查用户是否是组的管理员
-	if err := h.groupsService.CheckMemberPermission(ctx, request.GroupId, userID); err != nil {
+	// 检查用户是否具有查看审核请求的权限
+	if err := h.groupsService.CheckPermission(ctx, request.GroupId, userID, models.PermissionAuditView); err != nil {
 		if errors.Is(err, appErrors.ErrRolePermissionDenied) {
 			return &gen.GetGroupsGroupIdAuditRequests403JSONResponse{
 				Code:    "1",
-				Message: "权限不足",
+				Message: permissionDeniedMessage(err, "权限不足"),
 			}, nil
 		}
 		if errors.Is(err, appErrors.ErrGroupNotFound) {
@@ -158,15 +169,11 @@ func (h *AuditRequestHandler) GetGroupsGroupIdAuditRequests(ctx context.Context,
 
 // 当用户无法通过常规方式成功签到时，可以提交异常情况说明和证明，申请人工审核。审核通过后才会生成签到记录
 func (h *AuditRequestHandler) PostCheckinTasksTaskIdAuditRequests(ctx context.Context, request gen.PostCheckinTasksTaskIdAuditRequestsRequestObject) (gen.PostCheckinTasksTaskIdAuditRequestsResponseObject, error) {
-	// 从上下文中获取用户ID和用户名
+	// 从上下文中获取用户ID
 	userID, ok := ctx.Value("userID").(int)
 	if !ok {
 		return nil, appErrors.ErrJwtParseFailed
 	}
-	username, ok := ctx.Value("username").(string)
-	if !ok {
-		return nil, appErrors.ErrJwtParseFailed
-	}
 
 	// 处理可能为nil的ProofImageUrls
 	var proofImageUrls string
@@ -178,7 +185,7 @@ func (h *AuditRequestHandler) PostCheckinTasksTaskIdAuditRequests(ctx context.Co
 	}
 
 	// 调用服务创建审核请求
-	auditRequest, err := h.auditRequestService.CreateAuditRequest(ctx, request.TaskId, userID, username, request.Body.Reason, proofImageUrls)
+	auditRequest, err := h.auditRequestService.CreateAuditRequest(ctx, request.TaskId, userID, request.Body.Reason, proofImageUrls)
 	if err != nil {
 		if errors.Is(err, appErrors.ErrGroupNotFound) {
 			return &gen.PostCheckinTasksTaskIdAuditRequests404JSONResponse{
@@ -200,6 +207,7 @@ func (h *AuditRequestHandler) PostCheckinTasksTaskIdAuditRequests(ctx context.Co
 		}
 		return nil, err
 	}
+	h.metrics.AuditRequest(metrics.EventCreated)
 
 	// 转换为API响应格式
 	status := gen.AuditRequestStatus(auditRequest.Status)
@@ -239,12 +247,12 @@ func (h *AuditRequestHandler) PutAuditRequestsAuditRequestId(ctx context.Context
 		return nil, err
 	}
 
-	// 检查用户是否有权限处理审核请求
-	if err := h.groupsService.CheckMemberPermission(ctx, groupID, userID); err != nil {
+	// 检查用户是否具有处理审核请求的权限
+	if err := h.groupsService.CheckPermission(ctx, groupID, userID, models.PermissionAuditReview); err != nil {
 		if errors.Is(err, appErrors.ErrRolePermissionDenied) {
 			return &gen.PutAuditRequestsAuditRequestId403JSONResponse{
 				Code:    "1",
-				Message: "权限不足",
+				Message: permissionDeniedMessage(err, "权限不足"),
 			}, nil
 		}
 		if errors.Is(err, appErrors.ErrGroupNotFound) {
@@ -268,6 +276,11 @@ func (h *AuditRequestHandler) PutAuditRequestsAuditRequestId(ctx context.Context
 		}
 		return nil, err
 	}
+	if request.Body.Action == gen.PutAuditRequestsAuditRequestIdJSONBodyActionApprove {
+		h.metrics.AuditRequest(metrics.EventApproved)
+	} else {
+		h.metrics.AuditRequest(metrics.EventRejected)
+	}
 
 	return &gen.PutAuditRequestsAuditRequestId200JSONResponse{
 		Code: "0",
@@ -3,70 +3,319 @@ package handlers
 import (
 	"TeamTickBackend/app"
 	"TeamTickBackend/gen"
+	"TeamTickBackend/pkg"
 	appErrors "TeamTickBackend/pkg/errors"
+	"TeamTickBackend/pkg/metrics"
 	service "TeamTickBackend/services"
 	"context"
 	"errors"
+	"math"
+	"time"
+
+	"github.com/gin-gonic/gin"
 )
 
 type AuthHandler struct {
-	authService service.AuthService
+	authService       service.AuthService
+	tokenService      *service.TokenService
+	passwordService   *service.PasswordService
+	twoFactorService  *service.TwoFactorService
+	oidcService       *service.OIDCService
+	metrics           *metrics.Metrics
+	allowRegistration bool
+	tokenExpiry       time.Duration
 }
 
 func NewAuthHandler(container *app.AppContainer) gen.AuthServerInterface {
+	loginGuard := newLoginGuard(container)
+	tokenService := newTokenService(container)
 	authService := service.NewAuthService(
 		container.DaoFactory.UserDAO,
 		container.DaoFactory.TransactionManager,
 		container.JwtHandler,
+		loginGuard,
+		tokenService,
+		container.ServiceLogger,
 	)
 	handler := &AuthHandler{
-		authService: *authService,
+		authService:       *authService,
+		tokenService:      tokenService,
+		passwordService:   newPasswordService(container, tokenService),
+		twoFactorService:  newTwoFactorService(container, loginGuard),
+		oidcService:       newOIDCService(container),
+		metrics:           container.Metrics,
+		allowRegistration: container.Config.Features.AllowRegistration,
+		tokenExpiry:       container.Config.JWT.TokenExpiry,
 	}
 	return gen.NewAuthStrictHandler(handler, nil)
 }
 
+func newTokenService(container *app.AppContainer) *service.TokenService {
+	return service.NewTokenService(
+		container.DaoFactory.RefreshTokenDAO,
+		container.DaoFactory.UserSessionDAO,
+		container.DaoFactory.UserDAO,
+		container.DaoFactory.TransactionManager,
+		container.JwtHandler,
+		container.Revocations,
+		container.Config.JWT.RefreshTokenExpiry,
+		container.ServiceLogger,
+	)
+}
+
+// newLoginGuard 登录保护关闭时返回nil
+func newLoginGuard(container *app.AppContainer) *service.LoginGuard {
+	if !container.Config.LoginProtection.Enabled {
+		return nil
+	}
+	return service.NewLoginGuard(container.DaoFactory.LoginAttemptDAO, container.Config.LoginProtection, container.ServiceLogger)
+}
+
+// clientIP 严格模式处理函数收到的ctx为*gin.Context，按 server.trusted_proxies 解析客户端IP
+func clientIP(ctx context.Context) string {
+	if c, ok := ctx.(*gin.Context); ok {
+		return c.ClientIP()
+	}
+	return ""
+}
+
+// withRequestInfo 补充发起登录请求的User-Agent与客户端IP，记录在登录会话中
+func withRequestInfo(ctx context.Context, device service.DeviceInfo) service.DeviceInfo {
+	if c, ok := ctx.(*gin.Context); ok {
+		device.UserAgent = c.Request.UserAgent()
+		device.IP = c.ClientIP()
+	}
+	return device
+}
+
+func newTwoFactorService(container *app.AppContainer, loginGuard *service.LoginGuard) *service.TwoFactorService {
+	return service.NewTwoFactorService(
+		container.DaoFactory.UserDAO,
+		container.DaoFactory.TOTPSecretDAO,
+		container.DaoFactory.RecoveryCodeDAO,
+		container.DaoFactory.MFAChallengeDAO,
+		container.DaoFactory.GroupDAO,
+		container.DaoFactory.GroupMemberDAO,
+		container.DaoFactory.TransactionManager,
+		loginGuard,
+		container.Config.TwoFactor,
+		container.ServiceLogger,
+	)
+}
+
+func newPasswordService(container *app.AppContainer, tokenService *service.TokenService) *service.PasswordService {
+	return service.NewPasswordService(
+		container.DaoFactory.UserDAO,
+		container.DaoFactory.PasswordResetTokenDAO,
+		container.DaoFactory.TransactionManager,
+		tokenService,
+		container.PasswordResetSender,
+		container.Config.PasswordReset.TokenExpiry,
+		container.ServiceLogger,
+	)
+}
+
 // 用户登录
 func (h *AuthHandler) PostAuthLogin(ctx context.Context, request gen.PostAuthLoginRequestObject) (gen.PostAuthLoginResponseObject, error) {
 	username := request.Body.Username
 	password := request.Body.Password
 
-	user, token, err := h.authService.AuthLogin(ctx, username, password)
+	user, err := h.authService.VerifyCredentials(ctx, username, password, clientIP(ctx))
 	if err != nil {
-		if errors.Is(err, appErrors.ErrUserNotFound) {
+		var locked *appErrors.LoginLockedError
+		if errors.As(err, &locked) {
+			h.metrics.Login(metrics.ResultLocked)
+			return &gen.PostAuthLogin429JSONResponse{
+				Body: gen.Error{
+					Code:    "1",
+					Message: locked.Error(),
+				},
+				Headers: gen.PostAuthLogin429ResponseHeaders{
+					RetryAfter: int(math.Ceil(locked.RetryAfter.Seconds())),
+				},
+			}, nil
+		}
+		if errors.Is(err, appErrors.ErrInvalidCredentials) {
+			h.metrics.Login(metrics.ResultFailure)
 			return &gen.PostAuthLogin401JSONResponse{
 				Code:    "1",
-				Message: "用户不存在",
+				Message: err.Error(),
 			}, nil
 		}
-		if errors.Is(err, appErrors.ErrInvalidPassword) {
-			return &gen.PostAuthLogin401JSONResponse{
+		if errors.Is(err, appErrors.ErrUserDisabled) {
+			h.metrics.Login(metrics.ResultFailure)
+			return &gen.PostAuthLogin403JSONResponse{
 				Code:    "1",
-				Message: "用户名或密码错误",
+				Message: err.Error(),
 			}, nil
 		}
 		return nil, err
 	}
+	device := service.DeviceInfo{
+		ID:   request.Body.DeviceId,
+		Name: request.Body.DeviceName,
+	}
+
+	// 开启两步验证时先签发挑战令牌，提交验证码后再签发访问令牌
+	if user.TwoFactorEnabled {
+		mfaToken, err := h.twoFactorService.StartChallenge(ctx, user, device)
+		if err != nil {
+			return nil, err
+		}
+		h.metrics.Login(metrics.ResultMFARequired)
+		response := gen.PostAuthLogin200JSONResponse{Code: "0"}
+		response.Data.MfaRequired = true
+		response.Data.MfaToken = mfaToken
+		response.Data.UserId = user.UserID
+		response.Data.Username = user.Username
+		return response, nil
+	}
+	h.metrics.Login(metrics.ResultSuccess)
 
 	username = user.Username
 	userId := user.UserID
 
-	data := struct {
-		Token    string `json:"token,omitempty"`
-		UserId   int    `json:"userId,omitempty"`
-		Username string `json:"username,omitempty"`
-	}{
-		Token:    token,
-		UserId:   userId,
-		Username: username,
+	pair, err := h.tokenService.StartSession(ctx, user, withRequestInfo(ctx, device))
+	if err != nil {
+		return nil, err
 	}
 
-	return gen.PostAuthLogin200JSONResponse{
-		Code: "0",
-		Data: data,
-	}, nil
+	response := gen.PostAuthLogin200JSONResponse{Code: "0"}
+	response.Data.Token = pair.AccessToken
+	response.Data.RefreshToken = pair.RefreshToken
+	response.Data.ExpiresIn = int(h.tokenExpiry.Seconds())
+	response.Data.UserId = userId
+	response.Data.Username = username
+	return response, nil
+}
+
+// 两步登录：提交挑战令牌与验证码（或恢复码）换取访问令牌
+func (h *AuthHandler) PostAuthLogin2fa(ctx context.Context, request gen.PostAuthLogin2faRequestObject) (gen.PostAuthLogin2faResponseObject, error) {
+	user, device, err := h.twoFactorService.CompleteChallenge(ctx, request.Body.MfaToken, request.Body.Code, clientIP(ctx))
+	if err != nil {
+		var locked *appErrors.LoginLockedError
+		if errors.As(err, &locked) {
+			h.metrics.Login(metrics.ResultLocked)
+			return &gen.PostAuthLogin2fa429JSONResponse{
+				Body: gen.Error{
+					Code:    "1",
+					Message: locked.Error(),
+				},
+				Headers: gen.PostAuthLogin2fa429ResponseHeaders{
+					RetryAfter: int(math.Ceil(locked.RetryAfter.Seconds())),
+				},
+			}, nil
+		}
+		if errors.Is(err, appErrors.ErrTwoFactorCodeInvalid) {
+			h.metrics.Login(metrics.ResultFailure)
+			return &gen.PostAuthLogin2fa400JSONResponse{
+				Code:    "1",
+				Message: err.Error(),
+			}, nil
+		}
+		// 挑战签发后用户关闭了两步验证，同样需要重新登录
+		if errors.Is(err, appErrors.ErrMFAChallengeInvalid) || errors.Is(err, appErrors.ErrTwoFactorNotEnrolled) {
+			return &gen.PostAuthLogin2fa401JSONResponse{
+				Code:    "1",
+				Message: appErrors.ErrMFAChallengeInvalid.Error(),
+			}, nil
+		}
+		return nil, err
+	}
+	h.metrics.Login(metrics.ResultSuccess)
+
+	pair, err := h.tokenService.StartSession(ctx, user, withRequestInfo(ctx, device))
+	if err != nil {
+		return nil, err
+	}
+
+	response := gen.PostAuthLogin2fa200JSONResponse{Code: "0"}
+	response.Data.Token = pair.AccessToken
+	response.Data.RefreshToken = pair.RefreshToken
+	response.Data.ExpiresIn = int(h.tokenExpiry.Seconds())
+	response.Data.UserId = user.UserID
+	response.Data.Username = user.Username
+	return response, nil
+}
+
+// 使用刷新令牌换取新的访问令牌
+func (h *AuthHandler) PostAuthRefresh(ctx context.Context, request gen.PostAuthRefreshRequestObject) (gen.PostAuthRefreshResponseObject, error) {
+	pair, err := h.tokenService.Refresh(ctx, request.Body.RefreshToken, request.Body.DeviceId)
+	if err != nil {
+		if errors.Is(err, appErrors.ErrRefreshTokenInvalid) ||
+			errors.Is(err, appErrors.ErrRefreshTokenExpired) ||
+			errors.Is(err, appErrors.ErrRefreshTokenReused) {
+			return &gen.PostAuthRefresh401JSONResponse{
+				Code:    "1",
+				Message: err.Error(),
+			}, nil
+		}
+		return nil, err
+	}
+
+	response := gen.PostAuthRefresh200JSONResponse{Code: "0"}
+	response.Data.Token = pair.AccessToken
+	response.Data.RefreshToken = pair.RefreshToken
+	response.Data.ExpiresIn = int(h.tokenExpiry.Seconds())
+	return response, nil
+}
+
+// 退出登录，吊销当前访问令牌及其所属会话
+func (h *AuthHandler) PostAuthLogout(ctx context.Context, request gen.PostAuthLogoutRequestObject) (gen.PostAuthLogoutResponseObject, error) {
+	payload, ok := ctx.Value("tokenPayload").(pkg.JwtPayload)
+	if !ok {
+		return &gen.PostAuthLogout401JSONResponse{
+			Code:    "1",
+			Message: "未登录",
+		}, nil
+	}
+	if err := h.tokenService.Logout(ctx, payload); err != nil {
+		return nil, err
+	}
+	return &gen.PostAuthLogout200JSONResponse{Code: "0"}, nil
+}
+
+// 申请重置密码，用户是否存在都返回成功
+func (h *AuthHandler) PostAuthPasswordReset(ctx context.Context, request gen.PostAuthPasswordResetRequestObject) (gen.PostAuthPasswordResetResponseObject, error) {
+	if err := h.passwordService.RequestReset(ctx, request.Body.Username); err != nil {
+		if errors.Is(err, appErrors.ErrPasswordResetDisabled) {
+			return &gen.PostAuthPasswordReset403JSONResponse{
+				Code:    "1",
+				Message: err.Error(),
+			}, nil
+		}
+		return nil, err
+	}
+	return &gen.PostAuthPasswordReset200JSONResponse{Code: "0"}, nil
+}
+
+// 使用重置令牌设置新密码
+func (h *AuthHandler) PostAuthPasswordResetConfirm(ctx context.Context, request gen.PostAuthPasswordResetConfirmRequestObject) (gen.PostAuthPasswordResetConfirmResponseObject, error) {
+	if err := h.passwordService.ConfirmReset(ctx, request.Body.Token, request.Body.NewPassword); err != nil {
+		if errors.Is(err, appErrors.ErrResetTokenInvalid) {
+			return &gen.PostAuthPasswordResetConfirm400JSONResponse{
+				Code:    "1",
+				Message: err.Error(),
+			}, nil
+		}
+		if errors.Is(err, appErrors.ErrPasswordResetDisabled) {
+			return &gen.PostAuthPasswordResetConfirm403JSONResponse{
+				Code:    "1",
+				Message: err.Error(),
+			}, nil
+		}
+		return nil, err
+	}
+	return &gen.PostAuthPasswordResetConfirm200JSONResponse{Code: "0"}, nil
 }
 
 func (h *AuthHandler) PostAuthRegister(ctx context.Context, request gen.PostAuthRegisterRequestObject) (gen.PostAuthRegisterResponseObject, error) {
+	if !h.allowRegistration {
+		return &gen.PostAuthRegister400JSONResponse{
+			Code:    "1",
+			Message: "注册功能已关闭",
+		}, nil
+	}
 	username := request.Body.Username
 	password := request.Body.Password
 
@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"TeamTickBackend/dal/models"
+	"TeamTickBackend/gen"
+	appErrors "TeamTickBackend/pkg/errors"
+	"context"
+	"errors"
+)
+
+// 调整用户组的上级用户组，parentId 为0时成为顶级用户组
+func (h *GroupsHandler) PutGroupsGroupIdParent(ctx context.Context, request gen.PutGroupsGroupIdParentRequestObject) (gen.PutGroupsGroupIdParentResponseObject, error) {
+	userID, ok := ctx.Value("userID").(int)
+	if !ok {
+		return nil, appErrors.ErrJwtParseFailed
+	}
+
+	group, err := h.groupsService.SetParentGroup(ctx, request.GroupId, request.Body.ParentId, userID)
+	if err != nil {
+		switch {
+		case errors.Is(err, appErrors.ErrGroupNotFound):
+			return &gen.PutGroupsGroupIdParent404JSONResponse{Code: "1", Message: "用户组不存在"}, nil
+		case errors.Is(err, appErrors.ErrGroupParentNotFound):
+			return &gen.PutGroupsGroupIdParent404JSONResponse{Code: "1", Message: err.Error()}, nil
+		case errors.Is(err, appErrors.ErrGroupHierarchyCycle), errors.Is(err, appErrors.ErrGroupHierarchyTooDeep):
+			return &gen.PutGroupsGroupIdParent400JSONResponse{Code: "1", Message: err.Error()}, nil
+		case errors.Is(err, appErrors.ErrRolePermissionDenied), errors.Is(err, appErrors.ErrGroupMemberNotFound):
+			return &gen.PutGroupsGroupIdParent403JSONResponse{Code: "1", Message: permissionDeniedMessage(err, "权限不足")}, nil
+		}
+		return nil, err
+	}
+
+	return &gen.PutGroupsGroupIdParent200JSONResponse{
+		Code: "0",
+		Data: gen.Group{
+			GroupId:         group.GroupID,
+			GroupName:       group.GroupName,
+			Description:     group.Description,
+			CreatorId:       group.CreatorID,
+			CreatorName:     group.CreatorName,
+			CreatedAt:       int(group.CreatedAt.Unix()),
+			MemberCount:     group.MemberNum,
+			ParentId:        group.ParentID,
+			RequireAdmin2fa: group.RequireAdmin2FA,
+			JoinPolicy:      gen.GroupJoinPolicy(group.EffectiveJoinPolicy()),
+		},
+	}, nil
+}
+
+// 查询用户组连同全部下级用户组汇总的统计数据，以及每个直接下级用户组的统计数据
+func (h *GroupsHandler) GetGroupsGroupIdStats(ctx context.Context, request gen.GetGroupsGroupIdStatsRequestObject) (gen.GetGroupsGroupIdStatsResponseObject, error) {
+	userID, ok := ctx.Value("userID").(int)
+	if !ok {
+		return nil, appErrors.ErrJwtParseFailed
+	}
+
+	total, children, err := h.statsService.Stats(ctx, request.GroupId, userID)
+	if err != nil {
+		switch {
+		case errors.Is(err, appErrors.ErrGroupNotFound):
+			return &gen.GetGroupsGroupIdStats404JSONResponse{Code: "1", Message: "用户组不存在"}, nil
+		case errors.Is(err, appErrors.ErrRolePermissionDenied), errors.Is(err, appErrors.ErrGroupMemberNotFound):
+			return &gen.GetGroupsGroupIdStats403JSONResponse{Code: "1", Message: permissionDeniedMessage(err, "没有权限查看该组的统计数据")}, nil
+		}
+		return nil, err
+	}
+
+	response := &gen.GetGroupsGroupIdStats200JSONResponse{Code: "0"}
+	response.Data.Total = toGenGroupStats(total)
+	response.Data.Children = make([]gen.GroupStats, 0, len(children))
+	for _, child := range children {
+		response.Data.Children = append(response.Data.Children, toGenGroupStats(child))
+	}
+	return response, nil
+}
+
+func toGenGroupStats(stats *models.GroupStats) gen.GroupStats {
+	return gen.GroupStats{
+		GroupId:         stats.GroupID,
+		GroupName:       stats.GroupName,
+		ParentId:        stats.ParentID,
+		SubgroupCount:   stats.Subgroups,
+		MemberCount:     stats.Members,
+		TaskCount:       int(stats.Tasks),
+		ActiveTaskCount: int(stats.ActiveTasks),
+		CheckinCount:    int(stats.CheckIns),
+	}
+}
@@ -0,0 +1,139 @@
+package handlers
+
+import (
+	"TeamTickBackend/dal/models"
+	"TeamTickBackend/gen"
+	appErrors "TeamTickBackend/pkg/errors"
+	service "TeamTickBackend/services"
+	"context"
+	"errors"
+	"time"
+)
+
+// 用户组管理员创建邀请码
+func (h *GroupsHandler) PostGroupsGroupIdInvites(ctx context.Context, request gen.PostGroupsGroupIdInvitesRequestObject) (gen.PostGroupsGroupIdInvitesResponseObject, error) {
+	userID, ok := ctx.Value("userID").(int)
+	if !ok {
+		return nil, appErrors.ErrJwtParseFailed
+	}
+
+	invite, err := h.inviteService.CreateInvite(ctx, request.GroupId, userID, service.InviteOptions{
+		Role:      string(request.Body.Role),
+		MaxUses:   request.Body.MaxUses,
+		ExpiresIn: time.Duration(request.Body.ExpiresInHours) * time.Hour,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, appErrors.ErrGroupInviteInvalid):
+			return &gen.PostGroupsGroupIdInvites400JSONResponse{Code: "1", Message: err.Error()}, nil
+		case errors.Is(err, appErrors.ErrRolePermissionDenied):
+			return &gen.PostGroupsGroupIdInvites403JSONResponse{Code: "1", Message: permissionDeniedMessage(err, "权限不足")}, nil
+		case errors.Is(err, appErrors.ErrGroupPermissionExceeded):
+			return &gen.PostGroupsGroupIdInvites403JSONResponse{Code: "1", Message: err.Error()}, nil
+		case errors.Is(err, appErrors.ErrGroupNotFound):
+			return &gen.PostGroupsGroupIdInvites404JSONResponse{Code: "1", Message: "用户组不存在"}, nil
+		case errors.Is(err, appErrors.ErrGroupInviteLimitReached):
+			return &gen.PostGroupsGroupIdInvites409JSONResponse{Code: "1", Message: err.Error()}, nil
+		}
+		return nil, err
+	}
+	return &gen.PostGroupsGroupIdInvites201JSONResponse{
+		Code: "0",
+		Data: h.toGenGroupInvite(invite),
+	}, nil
+}
+
+// 用户组管理员查看邀请码
+func (h *GroupsHandler) GetGroupsGroupIdInvites(ctx context.Context, request gen.GetGroupsGroupIdInvitesRequestObject) (gen.GetGroupsGroupIdInvitesResponseObject, error) {
+	userID, ok := ctx.Value("userID").(int)
+	if !ok {
+		return nil, appErrors.ErrJwtParseFailed
+	}
+
+	invites, err := h.inviteService.ListInvites(ctx, request.GroupId, userID)
+	if err != nil {
+		switch {
+		case errors.Is(err, appErrors.ErrRolePermissionDenied):
+			return &gen.GetGroupsGroupIdInvites403JSONResponse{Code: "1", Message: permissionDeniedMessage(err, "权限不足")}, nil
+		case errors.Is(err, appErrors.ErrGroupNotFound):
+			return &gen.GetGroupsGroupIdInvites404JSONResponse{Code: "1", Message: "用户组不存在"}, nil
+		}
+		return nil, err
+	}
+	genInvites := make([]gen.GroupInvite, 0, len(invites))
+	for _, invite := range invites {
+		genInvites = append(genInvites, h.toGenGroupInvite(invite))
+	}
+	return &gen.GetGroupsGroupIdInvites200JSONResponse{
+		Code: "0",
+		Data: genInvites,
+	}, nil
+}
+
+// 用户组管理员撤销邀请码
+func (h *GroupsHandler) DeleteGroupsGroupIdInvitesInviteId(ctx context.Context, request gen.DeleteGroupsGroupIdInvitesInviteIdRequestObject) (gen.DeleteGroupsGroupIdInvitesInviteIdResponseObject, error) {
+	userID, ok := ctx.Value("userID").(int)
+	if !ok {
+		return nil, appErrors.ErrJwtParseFailed
+	}
+
+	if err := h.inviteService.RevokeInvite(ctx, request.GroupId, request.InviteId, userID); err != nil {
+		switch {
+		case errors.Is(err, appErrors.ErrRolePermissionDenied):
+			return &gen.DeleteGroupsGroupIdInvitesInviteId403JSONResponse{Code: "1", Message: permissionDeniedMessage(err, "权限不足")}, nil
+		case errors.Is(err, appErrors.ErrGroupNotFound):
+			return &gen.DeleteGroupsGroupIdInvitesInviteId404JSONResponse{Code: "1", Message: "用户组不存在"}, nil
+		case errors.Is(err, appErrors.ErrGroupInviteNotFound):
+			return &gen.DeleteGroupsGroupIdInvitesInviteId404JSONResponse{Code: "1", Message: err.Error()}, nil
+		}
+		return nil, err
+	}
+	return &gen.DeleteGroupsGroupIdInvitesInviteId200JSONResponse{Code: "0"}, nil
+}
+
+// 凭邀请码加入用户组，无需审批
+func (h *GroupsHandler) PostGroupsJoinByCode(ctx context.Context, request gen.PostGroupsJoinByCodeRequestObject) (gen.PostGroupsJoinByCodeResponseObject, error) {
+	userID, ok := ctx.Value("userID").(int)
+	if !ok {
+		return nil, appErrors.ErrJwtParseFailed
+	}
+
+	member, err := h.inviteService.JoinByCode(ctx, request.Body.Code, userID)
+	if err != nil {
+		switch {
+		case errors.Is(err, appErrors.ErrGroupInviteNotFound):
+			return &gen.PostGroupsJoinByCode404JSONResponse{Code: "1", Message: err.Error()}, nil
+		case errors.Is(err, appErrors.ErrGroupNotFound):
+			return &gen.PostGroupsJoinByCode404JSONResponse{Code: "1", Message: "用户组不存在"}, nil
+		case errors.Is(err, appErrors.ErrGroupClosed):
+			return &gen.PostGroupsJoinByCode403JSONResponse{Code: "1", Message: err.Error()}, nil
+		case errors.Is(err, appErrors.ErrGroupMemberAlreadyExists):
+			return &gen.PostGroupsJoinByCode409JSONResponse{Code: "1", Message: "您已是该组成员"}, nil
+		case errors.Is(err, appErrors.ErrGroupInviteUnavailable):
+			return &gen.PostGroupsJoinByCode410JSONResponse{Code: "1", Message: err.Error()}, nil
+		}
+		return nil, err
+	}
+	response := gen.PostGroupsJoinByCode200JSONResponse{Code: "0"}
+	response.Data.GroupId = member.GroupID
+	response.Data.GroupName = member.GroupName
+	response.Data.Role = member.Role
+	return &response, nil
+}
+
+func (h *GroupsHandler) toGenGroupInvite(invite *models.GroupInvite) gen.GroupInvite {
+	return gen.GroupInvite{
+		Id:            invite.ID,
+		GroupId:       invite.GroupID,
+		Code:          invite.Code,
+		Link:          h.inviteService.Link(invite.Code),
+		Role:          gen.GroupInviteRole(invite.Role),
+		MaxUses:       invite.MaxUses,
+		Uses:          invite.Uses,
+		Usable:        invite.Usable(time.Now()),
+		ExpiresAt:     invite.ExpiresAt,
+		CreatedBy:     invite.CreatedBy,
+		CreatedByName: invite.CreatedByName,
+		CreatedAt:     invite.CreatedAt,
+	}
+}
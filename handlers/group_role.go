@@ -0,0 +1,282 @@
+package handlers
+
+import (
+	"TeamTickBackend/dal/models"
+	"TeamTickBackend/gen"
+	appErrors "TeamTickBackend/pkg/errors"
+	service "TeamTickBackend/services"
+	"context"
+	"errors"
+	"strings"
+)
+
+// groupOperator 当前请求的用户，写入角色变更记录
+func groupOperator(ctx context.Context) (service.GroupOperator, error) {
+	userID, ok := ctx.Value("userID").(int)
+	if !ok {
+		return service.GroupOperator{}, appErrors.ErrJwtParseFailed
+	}
+	return service.GroupOperator{UserID: userID}, nil
+}
+
+// 设置成员的组内角色：管理员、普通成员或本组的自定义角色
+func (h *GroupsHandler) PutGroupsGroupIdMembersUserIdRole(ctx context.Context, request gen.PutGroupsGroupIdMembersUserIdRoleRequestObject) (gen.PutGroupsGroupIdMembersUserIdRoleResponseObject, error) {
+	operator, err := groupOperator(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	member, err := h.groupsService.SetMemberRole(ctx, request.GroupId, request.UserId, request.Body.Role, operator)
+	if err != nil {
+		switch {
+		case errors.Is(err, appErrors.ErrGroupRoleInvalid):
+			return &gen.PutGroupsGroupIdMembersUserIdRole400JSONResponse{Code: "1", Message: err.Error()}, nil
+		case errors.Is(err, appErrors.ErrRolePermissionDenied):
+			return &gen.PutGroupsGroupIdMembersUserIdRole403JSONResponse{Code: "1", Message: permissionDeniedMessage(err, "权限不足")}, nil
+		case errors.Is(err, appErrors.ErrGroupOwnerOnly):
+			return &gen.PutGroupsGroupIdMembersUserIdRole403JSONResponse{Code: "1", Message: "只有用户组所有者可以撤销其他管理员"}, nil
+		case errors.Is(err, appErrors.ErrGroupPermissionExceeded):
+			return &gen.PutGroupsGroupIdMembersUserIdRole403JSONResponse{Code: "1", Message: err.Error()}, nil
+		case errors.Is(err, appErrors.ErrGroupNotFound):
+			return &gen.PutGroupsGroupIdMembersUserIdRole404JSONResponse{Code: "1", Message: "用户组不存在"}, nil
+		case errors.Is(err, appErrors.ErrGroupRoleNotFound):
+			return &gen.PutGroupsGroupIdMembersUserIdRole404JSONResponse{Code: "1", Message: err.Error()}, nil
+		case errors.Is(err, appErrors.ErrGroupMemberNotFound):
+			return &gen.PutGroupsGroupIdMembersUserIdRole404JSONResponse{Code: "1", Message: "指定用户不是该组成员"}, nil
+		case errors.Is(err, appErrors.ErrGroupOwnerRequired):
+			return &gen.PutGroupsGroupIdMembersUserIdRole409JSONResponse{Code: "1", Message: err.Error()}, nil
+		}
+		return nil, err
+	}
+	role := member.Role
+	if member.RoleID != 0 {
+		role = strings.TrimSpace(request.Body.Role)
+	}
+	return &gen.PutGroupsGroupIdMembersUserIdRole200JSONResponse{
+		Code: "0",
+		Data: gen.GroupMember{
+			UserId:   member.UserID,
+			Username: member.Username,
+			Role:     role,
+			JoinedAt: int(member.CreatedAt.Unix()),
+		},
+	}, nil
+}
+
+// 用户组所有者将所有权转让给组内成员
+func (h *GroupsHandler) PostGroupsGroupIdTransferOwnership(ctx context.Context, request gen.PostGroupsGroupIdTransferOwnershipRequestObject) (gen.PostGroupsGroupIdTransferOwnershipResponseObject, error) {
+	operator, err := groupOperator(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	group, err := h.groupsService.TransferOwnership(ctx, request.GroupId, request.Body.UserId, operator)
+	if err != nil {
+		switch {
+		case errors.Is(err, appErrors.ErrGroupOwnerOnly):
+			return &gen.PostGroupsGroupIdTransferOwnership403JSONResponse{Code: "1", Message: err.Error()}, nil
+		case errors.Is(err, appErrors.ErrRolePermissionDenied):
+			return &gen.PostGroupsGroupIdTransferOwnership403JSONResponse{Code: "1", Message: permissionDeniedMessage(err, "权限不足")}, nil
+		case errors.Is(err, appErrors.ErrGroupNotFound):
+			return &gen.PostGroupsGroupIdTransferOwnership404JSONResponse{Code: "1", Message: "用户组不存在"}, nil
+		case errors.Is(err, appErrors.ErrGroupMemberNotFound):
+			return &gen.PostGroupsGroupIdTransferOwnership404JSONResponse{Code: "1", Message: "指定用户不是该组成员"}, nil
+		case errors.Is(err, appErrors.ErrGroupAlreadyOwner):
+			return &gen.PostGroupsGroupIdTransferOwnership409JSONResponse{Code: "1", Message: err.Error()}, nil
+		}
+		return nil, err
+	}
+	return &gen.PostGroupsGroupIdTransferOwnership200JSONResponse{
+		Code: "0",
+		Data: gen.Group{
+			GroupId:         group.GroupID,
+			GroupName:       group.GroupName,
+			Description:     group.Description,
+			CreatorId:       group.CreatorID,
+			CreatorName:     group.CreatorName,
+			CreatedAt:       int(group.CreatedAt.Unix()),
+			MemberCount:     group.MemberNum,
+			ParentId:        group.ParentID,
+			RequireAdmin2fa: group.RequireAdmin2FA,
+			JoinPolicy:      gen.GroupJoinPolicy(group.EffectiveJoinPolicy()),
+		},
+	}, nil
+}
+
+// 组管理员查看角色变更记录
+func (h *GroupsHandler) GetGroupsGroupIdRoleChanges(ctx context.Context, request gen.GetGroupsGroupIdRoleChangesRequestObject) (gen.GetGroupsGroupIdRoleChangesResponseObject, error) {
+	userID, ok := ctx.Value("userID").(int)
+	if !ok {
+		return nil, appErrors.ErrJwtParseFailed
+	}
+
+	params := request.Params
+	changes, total, err := h.groupsService.ListRoleChanges(ctx, request.GroupId, userID, intParam(params.Page), intParam(params.PageSize))
+	if err != nil {
+		switch {
+		case errors.Is(err, appErrors.ErrRolePermissionDenied):
+			return &gen.GetGroupsGroupIdRoleChanges403JSONResponse{Code: "1", Message: permissionDeniedMessage(err, "权限不足")}, nil
+		case errors.Is(err, appErrors.ErrGroupNotFound):
+			return &gen.GetGroupsGroupIdRoleChanges404JSONResponse{Code: "1", Message: "用户组不存在"}, nil
+		}
+		return nil, err
+	}
+	response := gen.GetGroupsGroupIdRoleChanges200JSONResponse{Code: "0"}
+	response.Data.Total = int(total)
+	response.Data.Items = make([]gen.GroupRoleChange, 0, len(changes))
+	for _, change := range changes {
+		response.Data.Items = append(response.Data.Items, gen.GroupRoleChange{
+			Id:           change.ID,
+			GroupId:      change.GroupID,
+			UserId:       change.UserID,
+			Username:     change.Username,
+			Action:       gen.GroupRoleChangeAction(change.Action),
+			FromRole:     change.FromRole,
+			ToRole:       change.ToRole,
+			OperatorId:   change.OperatorID,
+			OperatorName: change.OperatorName,
+			CreatedAt:    change.CreatedAt,
+		})
+	}
+	return &response, nil
+}
+
+// 组成员查看组内角色：内置的管理员、普通成员及本组的自定义角色
+func (h *GroupsHandler) GetGroupsGroupIdRoles(ctx context.Context, request gen.GetGroupsGroupIdRolesRequestObject) (gen.GetGroupsGroupIdRolesResponseObject, error) {
+	userID, ok := ctx.Value("userID").(int)
+	if !ok {
+		return nil, appErrors.ErrJwtParseFailed
+	}
+
+	roles, err := h.groupsService.ListCustomRoles(ctx, request.GroupId, userID)
+	if err != nil {
+		switch {
+		case errors.Is(err, appErrors.ErrGroupNotFound):
+			return &gen.GetGroupsGroupIdRoles404JSONResponse{Code: "1", Message: "用户组不存在"}, nil
+		case errors.Is(err, appErrors.ErrGroupMemberNotFound):
+			return &gen.GetGroupsGroupIdRoles403JSONResponse{Code: "1", Message: "您不是该组成员"}, nil
+		}
+		return nil, err
+	}
+	data := make([]gen.GroupRoleDefinition, 0, len(roles)+2)
+	data = append(data,
+		gen.GroupRoleDefinition{Name: "admin", Description: "组管理员", Builtin: true, Permissions: toGenGroupPermissions(models.AdminPermissions)},
+		gen.GroupRoleDefinition{Name: "member", Description: "普通成员", Builtin: true, Permissions: []gen.GroupPermission{}},
+	)
+	for _, role := range roles {
+		data = append(data, toGenGroupRole(role))
+	}
+	return &gen.GetGroupsGroupIdRoles200JSONResponse{Code: "0", Data: data}, nil
+}
+
+// 创建自定义角色
+func (h *GroupsHandler) PostGroupsGroupIdRoles(ctx context.Context, request gen.PostGroupsGroupIdRolesRequestObject) (gen.PostGroupsGroupIdRolesResponseObject, error) {
+	userID, ok := ctx.Value("userID").(int)
+	if !ok {
+		return nil, appErrors.ErrJwtParseFailed
+	}
+
+	role, err := h.groupsService.CreateCustomRole(ctx, request.GroupId, userID, service.GroupRoleInput{
+		Name:        request.Body.Name,
+		Description: request.Body.Description,
+		Permissions: fromGenGroupPermissions(request.Body.Permissions),
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, appErrors.ErrGroupRoleInvalid), errors.Is(err, appErrors.ErrGroupPermissionInvalid):
+			return &gen.PostGroupsGroupIdRoles400JSONResponse{Code: "1", Message: err.Error()}, nil
+		case errors.Is(err, appErrors.ErrRolePermissionDenied):
+			return &gen.PostGroupsGroupIdRoles403JSONResponse{Code: "1", Message: permissionDeniedMessage(err, "权限不足")}, nil
+		case errors.Is(err, appErrors.ErrGroupPermissionExceeded):
+			return &gen.PostGroupsGroupIdRoles403JSONResponse{Code: "1", Message: err.Error()}, nil
+		case errors.Is(err, appErrors.ErrGroupNotFound):
+			return &gen.PostGroupsGroupIdRoles404JSONResponse{Code: "1", Message: "用户组不存在"}, nil
+		case errors.Is(err, appErrors.ErrGroupRoleExists):
+			return &gen.PostGroupsGroupIdRoles409JSONResponse{Code: "1", Message: err.Error()}, nil
+		}
+		return nil, err
+	}
+	return &gen.PostGroupsGroupIdRoles201JSONResponse{Code: "0", Data: toGenGroupRole(role)}, nil
+}
+
+// 修改自定义角色
+func (h *GroupsHandler) PutGroupsGroupIdRolesRoleId(ctx context.Context, request gen.PutGroupsGroupIdRolesRoleIdRequestObject) (gen.PutGroupsGroupIdRolesRoleIdResponseObject, error) {
+	userID, ok := ctx.Value("userID").(int)
+	if !ok {
+		return nil, appErrors.ErrJwtParseFailed
+	}
+
+	role, err := h.groupsService.UpdateCustomRole(ctx, request.GroupId, request.RoleId, userID, service.GroupRoleInput{
+		Name:        request.Body.Name,
+		Description: request.Body.Description,
+		Permissions: fromGenGroupPermissions(request.Body.Permissions),
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, appErrors.ErrGroupRoleInvalid), errors.Is(err, appErrors.ErrGroupPermissionInvalid):
+			return &gen.PutGroupsGroupIdRolesRoleId400JSONResponse{Code: "1", Message: err.Error()}, nil
+		case errors.Is(err, appErrors.ErrRolePermissionDenied):
+			return &gen.PutGroupsGroupIdRolesRoleId403JSONResponse{Code: "1", Message: permissionDeniedMessage(err, "权限不足")}, nil
+		case errors.Is(err, appErrors.ErrGroupPermissionExceeded):
+			return &gen.PutGroupsGroupIdRolesRoleId403JSONResponse{Code: "1", Message: err.Error()}, nil
+		case errors.Is(err, appErrors.ErrGroupNotFound):
+			return &gen.PutGroupsGroupIdRolesRoleId404JSONResponse{Code: "1", Message: "用户组不存在"}, nil
+		case errors.Is(err, appErrors.ErrGroupRoleNotFound):
+			return &gen.PutGroupsGroupIdRolesRoleId404JSONResponse{Code: "1", Message: err.Error()}, nil
+		case errors.Is(err, appErrors.ErrGroupRoleExists):
+			return &gen.PutGroupsGroupIdRolesRoleId409JSONResponse{Code: "1", Message: err.Error()}, nil
+		}
+		return nil, err
+	}
+	return &gen.PutGroupsGroupIdRolesRoleId200JSONResponse{Code: "0", Data: toGenGroupRole(role)}, nil
+}
+
+// 删除自定义角色
+func (h *GroupsHandler) DeleteGroupsGroupIdRolesRoleId(ctx context.Context, request gen.DeleteGroupsGroupIdRolesRoleIdRequestObject) (gen.DeleteGroupsGroupIdRolesRoleIdResponseObject, error) {
+	userID, ok := ctx.Value("userID").(int)
+	if !ok {
+		return nil, appErrors.ErrJwtParseFailed
+	}
+
+	if err := h.groupsService.DeleteCustomRole(ctx, request.GroupId, request.RoleId, userID); err != nil {
+		switch {
+		case errors.Is(err, appErrors.ErrRolePermissionDenied):
+			return &gen.DeleteGroupsGroupIdRolesRoleId403JSONResponse{Code: "1", Message: permissionDeniedMessage(err, "权限不足")}, nil
+		case errors.Is(err, appErrors.ErrGroupPermissionExceeded):
+			return &gen.DeleteGroupsGroupIdRolesRoleId403JSONResponse{Code: "1", Message: err.Error()}, nil
+		case errors.Is(err, appErrors.ErrGroupNotFound):
+			return &gen.DeleteGroupsGroupIdRolesRoleId404JSONResponse{Code: "1", Message: "用户组不存在"}, nil
+		case errors.Is(err, appErrors.ErrGroupRoleNotFound):
+			return &gen.DeleteGroupsGroupIdRolesRoleId404JSONResponse{Code: "1", Message: err.Error()}, nil
+		case errors.Is(err, appErrors.ErrGroupRoleInUse):
+			return &gen.DeleteGroupsGroupIdRolesRoleId409JSONResponse{Code: "1", Message: err.Error()}, nil
+		}
+		return nil, err
+	}
+	return &gen.DeleteGroupsGroupIdRolesRoleId200JSONResponse{Code: "0"}, nil
+}
+
+func toGenGroupRole(role *models.GroupRole) gen.GroupRoleDefinition {
+	return gen.GroupRoleDefinition{
+		Id:          role.ID,
+		Name:        role.Name,
+		Description: role.Description,
+		Permissions: toGenGroupPermissions(role.PermissionList()),
+	}
+}
+
+func toGenGroupPermissions(permissions []string) []gen.GroupPermission {
+	result := make([]gen.GroupPermission, 0, len(permissions))
+	for _, permission := range permissions {
+		result = append(result, gen.GroupPermission(permission))
+	}
+	return result
+}
+
+func fromGenGroupPermissions(permissions []gen.GroupPermission) []string {
+	result := make([]string, 0, len(permissions))
+	for _, permission := range permissions {
+		result = append(result, string(permission))
+	}
+	return result
+}
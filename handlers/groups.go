@@ -4,14 +4,22 @@ import (
 	"TeamTickBackend/app"
 	"TeamTickBackend/dal/models"
 	"TeamTickBackend/gen"
+	"TeamTickBackend/middlewares"
 	appErrors "TeamTickBackend/pkg/errors"
+	"TeamTickBackend/pkg/metrics"
 	service "TeamTickBackend/services"
 	"context"
 	"errors"
 )
 
+// 用户组要求管理员开启两步验证而当前用户未开启时的提示
+const adminTwoFactorRequiredMessage = "该用户组要求管理员开启两步验证，请先开启两步验证"
+
 type GroupsHandler struct {
 	groupsService service.GroupsService
+	inviteService *service.GroupInviteService
+	statsService  *service.GroupStatsService
+	metrics       *metrics.Metrics
 }
 
 func NewGroupsHandler(container *app.AppContainer) gen.GroupsServerInterface {
@@ -19,12 +27,32 @@ func NewGroupsHandler(container *app.AppContainer) gen.GroupsServerInterface {
 		container.DaoFactory.GroupDAO,
 		container.DaoFactory.GroupMemberDAO,
 		container.DaoFactory.JoinApplicationDAO,
+		container.DaoFactory.UserDAO,
+		container.DaoFactory.DenormalizationDAO,
+		container.DaoFactory.GroupRoleChangeDAO,
+		container.DaoFactory.GroupRoleDAO,
 		container.DaoFactory.TransactionManager,
+		container.ServiceLogger,
 	)
 	handler := &GroupsHandler{
 		groupsService: *GroupsService,
-	}
-	return gen.NewGroupsStrictHandler(handler, nil)
+		inviteService: service.NewGroupInviteService(
+			container.DaoFactory.GroupInviteDAO,
+			container.DaoFactory.JoinApplicationDAO,
+			container.DaoFactory.TransactionManager,
+			GroupsService,
+			container.Config.GroupInvites,
+			container.ServiceLogger,
+		),
+		statsService: service.NewGroupStatsService(
+			container.DaoFactory.GroupDAO,
+			container.DaoFactory.GroupMemberDAO,
+			container.DaoFactory.GroupStatsDAO,
+			GroupsService,
+		),
+		metrics: container.Metrics,
+	}
+	return gen.NewGroupsStrictHandler(handler, []gen.GroupsStrictMiddlewareFunc{middlewares.RequireAccessTokenScope})
 }
 
 // 获取当前用户创建的或加入的用户组列表
@@ -53,14 +81,17 @@ func (h *GroupsHandler) GetGroups(ctx context.Context, request gen.GetGroupsRequ
 			return &gen.GetGroups200JSONResponse{
 				Code: "0",
 				Data: []struct {
-					CreatedAt   int           `json:"createdAt,omitempty"`
-					CreatorId   int           `json:"creatorId,omitempty"`
-					CreatorName string        `json:"creatorName,omitempty"`
-					Description string        `json:"description,omitempty"`
-					GroupId     int           `json:"groupId,omitempty"`
-					GroupName   string        `json:"groupName,omitempty"`
-					MemberCount int           `json:"memberCount,omitempty"`
-					RoleInGroup gen.GroupRole `json:"roleInGroup,omitempty"`
+					CreatedAt       int                 `json:"createdAt,omitempty"`
+					CreatorId       int                 `json:"creatorId,omitempty"`
+					CreatorName     string              `json:"creatorName,omitempty"`
+					Description     string              `json:"description,omitempty"`
+					GroupId         int                 `json:"groupId,omitempty"`
+					GroupName       string              `json:"groupName,omitempty"`
+					JoinPolicy      gen.GroupJoinPolicy `json:"joinPolicy"`
+					MemberCount     int                 `json:"memberCount,omitempty"`
+					ParentId        int                 `json:"parentId,omitempty"`
+					RequireAdmin2fa bool                `json:"requireAdmin2fa"`
+					RoleInGroup     gen.GroupRole       `json:"roleInGroup,omitempty"`
 				}{},
 			}, nil
 		}
@@ -68,56 +99,71 @@ func (h *GroupsHandler) GetGroups(ctx context.Context, request gen.GetGroupsRequ
 	}
 
 	genGroups := make([]struct {
-		CreatedAt   int           `json:"createdAt,omitempty"`
-		CreatorId   int           `json:"creatorId,omitempty"`
-		CreatorName string        `json:"creatorName,omitempty"`
-		Description string        `json:"description,omitempty"`
-		GroupId     int           `json:"groupId,omitempty"`
-		GroupName   string        `json:"groupName,omitempty"`
-		MemberCount int           `json:"memberCount,omitempty"`
-		RoleInGroup gen.GroupRole `json:"roleInGroup,omitempty"`
+		CreatedAt       int                 `json:"createdAt,omitempty"`
+		CreatorId       int                 `json:"creatorId,omitempty"`
+		CreatorName     string              `json:"creatorName,omitempty"`
+		Description     string              `json:"description,omitempty"`
+		GroupId         int                 `json:"groupId,omitempty"`
+		GroupName       string              `json:"groupName,omitempty"`
+		JoinPolicy      gen.GroupJoinPolicy `json:"joinPolicy"`
+		MemberCount     int                 `json:"memberCount,omitempty"`
+		ParentId        int                 `json:"parentId,omitempty"`
+		RequireAdmin2fa bool                `json:"requireAdmin2fa"`
+		RoleInGroup     gen.GroupRole       `json:"roleInGroup,omitempty"`
 	}, len(groups))
 
 	for i, group := range groups {
 		if group.CreatorID == userID {
 			genGroups[i] = struct {
-				CreatedAt   int           `json:"createdAt,omitempty"`
-				CreatorId   int           `json:"creatorId,omitempty"`
-				CreatorName string        `json:"creatorName,omitempty"`
-				Description string        `json:"description,omitempty"`
-				GroupId     int           `json:"groupId,omitempty"`
-				GroupName   string        `json:"groupName,omitempty"`
-				MemberCount int           `json:"memberCount,omitempty"`
-				RoleInGroup gen.GroupRole `json:"roleInGroup,omitempty"`
+				CreatedAt       int                 `json:"createdAt,omitempty"`
+				CreatorId       int                 `json:"creatorId,omitempty"`
+				CreatorName     string              `json:"creatorName,omitempty"`
+				Description     string              `json:"description,omitempty"`
+				GroupId         int                 `json:"groupId,omitempty"`
+				GroupName       string              `json:"groupName,omitempty"`
+				JoinPolicy      gen.GroupJoinPolicy `json:"joinPolicy"`
+				MemberCount     int                 `json:"memberCount,omitempty"`
+				ParentId        int                 `json:"parentId,omitempty"`
+				RequireAdmin2fa bool                `json:"requireAdmin2fa"`
+				RoleInGroup     gen.GroupRole       `json:"roleInGroup,omitempty"`
 			}{
-				CreatedAt:   int(group.CreatedAt.Unix()),
-				CreatorId:   group.CreatorID,
-				CreatorName: group.CreatorName,
-				Description: group.Description,
-				GroupId:     group.GroupID,
-				GroupName:   group.GroupName,
-				MemberCount: group.MemberNum,
-				RoleInGroup: "admin",
+				CreatedAt:       int(group.CreatedAt.Unix()),
+				CreatorId:       group.CreatorID,
+				CreatorName:     group.CreatorName,
+				Description:     group.Description,
+				GroupId:         group.GroupID,
+				GroupName:       group.GroupName,
+				MemberCount:     group.MemberNum,
+				ParentId:        group.ParentID,
+				RequireAdmin2fa: group.RequireAdmin2FA,
+				JoinPolicy:      gen.GroupJoinPolicy(group.EffectiveJoinPolicy()),
+				RoleInGroup:     "admin",
 			}
 		} else {
 			genGroups[i] = struct {
-				CreatedAt   int           `json:"createdAt,omitempty"`
-				CreatorId   int           `json:"creatorId,omitempty"`
-				CreatorName string        `json:"creatorName,omitempty"`
-				Description string        `json:"description,omitempty"`
-				GroupId     int           `json:"groupId,omitempty"`
-				GroupName   string        `json:"groupName,omitempty"`
-				MemberCount int           `json:"memberCount,omitempty"`
-				RoleInGroup gen.GroupRole `json:"roleInGroup,omitempty"`
+				CreatedAt       int                 `json:"createdAt,omitempty"`
+				CreatorId       int                 `json:"creatorId,omitempty"`
+				CreatorName     string              `json:"creatorName,omitempty"`
+				Description     string              `json:"description,omitempty"`
+				GroupId         int                 `json:"groupId,omitempty"`
+				GroupName       string              `json:"groupName,omitempty"`
+				JoinPolicy      gen.GroupJoinPolicy `json:"joinPolicy"`
+				MemberCount     int                 `json:"memberCount,omitempty"`
+				ParentId        int                 `json:"parentId,omitempty"`
+				RequireAdmin2fa bool                `json:"requireAdmin2fa"`
+				RoleInGroup     gen.GroupRole       `json:"roleInGroup,omitempty"`
 			}{
-				CreatedAt:   int(group.CreatedAt.Unix()),
-				CreatorId:   group.CreatorID,
-				CreatorName: group.CreatorName,
-				Description: group.Description,
-				GroupId:     group.GroupID,
-				GroupName:   group.GroupName,
-				MemberCount: group.MemberNum,
-				RoleInGroup: "member",
+				CreatedAt:       int(group.CreatedAt.Unix()),
+				CreatorId:       group.CreatorID,
+				CreatorName:     group.CreatorName,
+				Description:     group.Description,
+				GroupId:         group.GroupID,
+				GroupName:       group.GroupName,
+				MemberCount:     group.MemberNum,
+				ParentId:        group.ParentID,
+				RequireAdmin2fa: group.RequireAdmin2FA,
+				JoinPolicy:      gen.GroupJoinPolicy(group.EffectiveJoinPolicy()),
+				RoleInGroup:     "member",
 			}
 		}
 	}
@@ -126,14 +172,17 @@ func (h *GroupsHandler) GetGroups(ctx context.Context, request gen.GetGroupsRequ
 		return &gen.GetGroups200JSONResponse{
 			Code: "0",
 			Data: []struct {
-				CreatedAt   int           `json:"createdAt,omitempty"`
-				CreatorId   int           `json:"creatorId,omitempty"`
-				CreatorName string        `json:"creatorName,omitempty"`
-				Description string        `json:"description,omitempty"`
-				GroupId     int           `json:"groupId,omitempty"`
-				GroupName   string        `json:"groupName,omitempty"`
-				MemberCount int           `json:"memberCount,omitempty"`
-				RoleInGroup gen.GroupRole `json:"roleInGroup,omitempty"`
+				CreatedAt       int                 `json:"createdAt,omitempty"`
+				CreatorId       int                 `json:"creatorId,omitempty"`
+				CreatorName     string              `json:"creatorName,omitempty"`
+				Description     string              `json:"description,omitempty"`
+				GroupId         int                 `json:"groupId,omitempty"`
+				GroupName       string              `json:"groupName,omitempty"`
+				JoinPolicy      gen.GroupJoinPolicy `json:"joinPolicy"`
+				MemberCount     int                 `json:"memberCount,omitempty"`
+				ParentId        int                 `json:"parentId,omitempty"`
+				RequireAdmin2fa bool                `json:"requireAdmin2fa"`
+				RoleInGroup     gen.GroupRole       `json:"roleInGroup,omitempty"`
 			}{},
 		}, nil
 	}
@@ -144,42 +193,47 @@ func (h *GroupsHandler) GetGroups(ctx context.Context, request gen.GetGroupsRequ
 	}, nil
 }
 
-// 任何登录用户可以创建用户组，并自动成为该组管理员
+// 任何登录用户可以创建用户组，并自动成为该组管理员；指定上级用户组时需要具有上级用户组的 subgroup.manage 权限
 func (h *GroupsHandler) PostGroups(ctx context.Context, request gen.PostGroupsRequestObject) (gen.PostGroupsResponseObject, error) {
 	userID, ok := ctx.Value("userID").(int)
 	if !ok {
 		return nil, appErrors.ErrJwtParseFailed
 	}
-	username, ok := ctx.Value("username").(string)
-	if !ok {
-		return nil, appErrors.ErrJwtParseFailed
-	}
 	groupName := request.Body.GroupName
 	description := request.Body.Description
 
-	group, err := h.groupsService.CreateGroup(ctx, groupName, description, username, userID)
+	var group *models.Group
+	var err error
+	if request.Body.ParentId > 0 {
+		group, err = h.groupsService.CreateSubgroup(ctx, request.Body.ParentId, groupName, description, userID)
+	} else {
+		group, err = h.groupsService.CreateGroup(ctx, groupName, description, userID)
+	}
 	if err != nil {
+		switch {
+		case errors.Is(err, appErrors.ErrGroupParentNotFound):
+			return &gen.PostGroups404JSONResponse{Code: "1", Message: err.Error()}, nil
+		case errors.Is(err, appErrors.ErrGroupHierarchyTooDeep):
+			return &gen.PostGroups400JSONResponse{Code: "1", Message: err.Error()}, nil
+		case errors.Is(err, appErrors.ErrRolePermissionDenied), errors.Is(err, appErrors.ErrGroupMemberNotFound):
+			return &gen.PostGroups403JSONResponse{Code: "1", Message: permissionDeniedMessage(err, "没有在该用户组下创建下级用户组的权限")}, nil
+		}
 		return nil, err
 	}
 
 	return &gen.PostGroups201JSONResponse{
 		Code: "0",
-		Data: struct {
-			CreatedAt   int    `json:"createdAt,omitempty"`
-			CreatorId   int    `json:"creatorId,omitempty"`
-			CreatorName string `json:"creatorName,omitempty"`
-			Description string `json:"description,omitempty"`
-			GroupId     int    `json:"groupId,omitempty"`
-			GroupName   string `json:"groupName,omitempty"`
-			MemberCount int    `json:"memberCount,omitempty"`
-		}{
-			CreatedAt:   int(group.CreatedAt.Unix()),
-			CreatorId:   group.CreatorID,
-			CreatorName: group.CreatorName,
-			Description: group.Description,
-			GroupId:     group.GroupID,
-			GroupName:   group.GroupName,
-			MemberCount: group.MemberNum,
+		Data: gen.Group{
+			CreatedAt:       int(group.CreatedAt.Unix()),
+			CreatorId:       group.CreatorID,
+			CreatorName:     group.CreatorName,
+			Description:     group.Description,
+			GroupId:         group.GroupID,
+			GroupName:       group.GroupName,
+			MemberCount:     group.MemberNum,
+			ParentId:        group.ParentID,
+			RequireAdmin2fa: group.RequireAdmin2FA,
+			JoinPolicy:      gen.GroupJoinPolicy(group.EffectiveJoinPolicy()),
 		},
 	}, nil
 }
@@ -202,18 +256,21 @@ func (h *GroupsHandler) GetGroupsGroupId(ctx context.Context, request gen.GetGro
 	return &gen.GetGroupsGroupId200JSONResponse{
 		Code: "0",
 		Data: gen.Group{
-			GroupId:     group.GroupID,
-			GroupName:   group.GroupName,
-			Description: group.Description,
-			CreatorId:   group.CreatorID,
-			CreatorName: group.CreatorName,
-			CreatedAt:   int(group.CreatedAt.Unix()),
-			MemberCount: group.MemberNum,
+			GroupId:         group.GroupID,
+			GroupName:       group.GroupName,
+			Description:     group.Description,
+			CreatorId:       group.CreatorID,
+			CreatorName:     group.CreatorName,
+			CreatedAt:       int(group.CreatedAt.Unix()),
+			MemberCount:     group.MemberNum,
+			ParentId:        group.ParentID,
+			RequireAdmin2fa: group.RequireAdmin2FA,
+			JoinPolicy:      gen.GroupJoinPolicy(group.EffectiveJoinPolicy()),
 		},
 	}, nil
 }
 
-// 更新用户组修改用户组的名称或描述。需要是该组管理员
+// 更新用户组修改用户组的名称或描述。需要具有修改用户组的权限
 func (h *GroupsHandler) PutGroupsGroupId(ctx context.Context, request gen.PutGroupsGroupIdRequestObject) (gen.PutGroupsGroupIdResponseObject, error) {
 	userID, ok := ctx.Value("userID").(int)
 	if !ok {
@@ -234,8 +291,8 @@ func (h *GroupsHandler) PutGroupsGroupId(ctx context.Context, request gen.PutGro
 		return nil, err
 	}
 
-	// 检查用户是否是群组管理员
-	if err := h.groupsService.CheckMemberPermission(ctx, groupID, userID); err != nil {
+	// 检查用户是否具有修改用户组的权限
+	if err := h.groupsService.CheckPermission(ctx, groupID, userID, models.PermissionGroupUpdate); err != nil {
 		if errors.Is(err, appErrors.ErrGroupMemberNotFound) {
 			return &gen.PutGroupsGroupId404JSONResponse{
 				Code:    "1",
@@ -244,6 +301,52 @@ func (h *GroupsHandler) PutGroupsGroupId(ctx context.Context, request gen.PutGro
 
 		}
 		if errors.Is(err, appErrors.ErrRolePermissionDenied) {
+			return &gen.PutGroupsGroupId403JSONResponse{
+				Code:    "1",
+				Message: permissionDeniedMessage(err, "权限不足"),
+			}, nil
+		}
+		return nil, err
+	}
+	// 设置是否要求管理员开启两步验证
+	if request.Body.RequireAdmin2fa != nil {
+		if err := h.groupsService.SetRequireAdminTwoFactor(ctx, groupID, userID, *request.Body.RequireAdmin2fa); err != nil {
+			if errors.Is(err, appErrors.ErrAdminTwoFactorRequired) {
+				return &gen.PutGroupsGroupId403JSONResponse{
+					Code:    "1",
+					Message: "开启该要求前请先为自己开启两步验证",
+				}, nil
+			}
+			if errors.Is(err, appErrors.ErrRolePermissionDenied) {
+				return &gen.PutGroupsGroupId403JSONResponse{
+					Code:    "1",
+					Message: "权限不足",
+				}, nil
+			}
+			return nil, err
+		}
+	}
+	// 设置加入方式
+	if request.Body.JoinPolicy != "" {
+		if err := h.groupsService.SetJoinPolicy(ctx, groupID, userID, string(request.Body.JoinPolicy)); err != nil {
+			if errors.Is(err, appErrors.ErrJoinPolicyInvalid) {
+				return &gen.PutGroupsGroupId400JSONResponse{
+					Code:    "1",
+					Message: err.Error(),
+				}, nil
+			}
+			if errors.Is(err, appErrors.ErrAdminTwoFactorRequired) {
+				return &gen.PutGroupsGroupId403JSONResponse{
+					Code:    "1",
+					Message: adminTwoFactorRequiredMessage,
+				}, nil
+			}
+			if errors.Is(err, appErrors.ErrRolePermissionDenied) {
+				return &gen.PutGroupsGroupId403JSONResponse{
+					Code:    "1",
+					Message: "权限不足",
+				}, nil
+			}
 			return nil, err
 		}
 	}
@@ -252,7 +355,7 @@ func (h *GroupsHandler) PutGroupsGroupId(ctx context.Context, request gen.PutGro
 		if errors.Is(err, appErrors.ErrRolePermissionDenied) {
 			return &gen.PutGroupsGroupId403JSONResponse{
 				Code:    "1",
-				Message: "权限不足",
+				Message: permissionDeniedMessage(err, "权限不足"),
 			}, nil
 		}
 		return nil, err
@@ -262,19 +365,22 @@ func (h *GroupsHandler) PutGroupsGroupId(ctx context.Context, request gen.PutGro
 	return &gen.PutGroupsGroupId200JSONResponse{
 		Code: "0",
 		Data: gen.Group{
-			GroupId:     group.GroupID,
-			GroupName:   group.GroupName,
-			Description: group.Description,
-			CreatorId:   group.CreatorID,
-			CreatorName: group.CreatorName,
-			CreatedAt:   int(group.CreatedAt.Unix()),
-			MemberCount: group.MemberNum,
+			GroupId:         group.GroupID,
+			GroupName:       group.GroupName,
+			Description:     group.Description,
+			CreatorId:       group.CreatorID,
+			CreatorName:     group.CreatorName,
+			CreatedAt:       int(group.CreatedAt.Unix()),
+			MemberCount:     group.MemberNum,
+			ParentId:        group.ParentID,
+			RequireAdmin2fa: group.RequireAdmin2FA,
+			JoinPolicy:      gen.GroupJoinPolicy(group.EffectiveJoinPolicy()),
 		},
 	}, nil
 
 }
 
-// 删除指定的用户组及其关联数据。需要是该组的创建者
+// 删除指定的用户组及其关联数据。需要具有删除用户组的权限，默认只有所有者具有
 func (h *GroupsHandler) DeleteGroupsGroupId(ctx context.Context, request gen.DeleteGroupsGroupIdRequestObject) (gen.DeleteGroupsGroupIdResponseObject, error) {
 	userID, ok := ctx.Value("userID").(int)
 	if !ok {
@@ -283,8 +389,7 @@ func (h *GroupsHandler) DeleteGroupsGroupId(ctx context.Context, request gen.Del
 	groupID := request.GroupId
 
 	// 检查用户组是否存在
-	group, err := h.groupsService.GetGroupByGroupID(ctx, groupID)
-	if err != nil {
+	if _, err := h.groupsService.GetGroupByGroupID(ctx, groupID); err != nil {
 		if errors.Is(err, appErrors.ErrGroupNotFound) {
 			return &gen.DeleteGroupsGroupId404JSONResponse{
 				Code:    "1",
@@ -294,32 +399,24 @@ func (h *GroupsHandler) DeleteGroupsGroupId(ctx context.Context, request gen.Del
 		return nil, err
 	}
 
-	// 检查用户是否是群组成员
-	if err := h.groupsService.CheckMemberPermission(ctx, groupID, userID); err != nil {
+	// 检查用户是否具有删除用户组的权限，默认只有所有者具有
+	if err := h.groupsService.CheckPermission(ctx, groupID, userID, models.PermissionGroupDelete); err != nil {
 		if errors.Is(err, appErrors.ErrGroupMemberNotFound) {
 			return &gen.DeleteGroupsGroupId404JSONResponse{
 				Code:    "1",
 				Message: "您不是该组成员",
 			}, nil
 		}
-		if !errors.Is(err, appErrors.ErrRolePermissionDenied) {
+		if errors.Is(err, appErrors.ErrRolePermissionDenied) {
 			return &gen.DeleteGroupsGroupId403JSONResponse{
 				Code:    "1",
-				Message: "权限不足",
+				Message: permissionDeniedMessage(err, "只有群组所有者可以删除群组"),
 			}, nil
 		}
+		return nil, err
 	}
 
-	// 检查是否是群组创建者
-	if userID != group.CreatorID {
-		return &gen.DeleteGroupsGroupId403JSONResponse{
-			Code:    "1",
-			Message: "只有群组创建者可以删除群组",
-		}, nil
-	}
-
-	err = h.groupsService.DeleteGroup(ctx, groupID, userID)
-	if err != nil {
+	if err := h.groupsService.DeleteGroup(ctx, groupID, userID); err != nil {
 		return nil, err
 	}
 
@@ -337,12 +434,12 @@ func (h *GroupsHandler) GetGroupsGroupIdJoinRequests(ctx context.Context, reques
 		return nil, appErrors.ErrJwtParseFailed
 	}
 
-	// 检查用户是否是组的管理员
-	if err := h.groupsService.CheckMemberPermission(ctx, request.GroupId, userID); err != nil {
+	// 检查用户是否具有审批加入申请的权限
+	if err := h.groupsService.CheckPermission(ctx, request.GroupId, userID, models.PermissionMemberReview); err != nil {
 		if errors.Is(err, appErrors.ErrRolePermissionDenied) {
 			return &gen.GetGroupsGroupIdJoinRequests403JSONResponse{
 				Code:    "1",
-				Message: "权限不足",
+				Message: permissionDeniedMessage(err, "权限不足"),
 			}, nil
 		}
 		if errors.Is(err, appErrors.ErrGroupNotFound) {
@@ -374,7 +471,7 @@ func (h *GroupsHandler) GetGroupsGroupIdJoinRequests(ctx context.Context, reques
 		if errors.Is(err, appErrors.ErrRolePermissionDenied) {
 			return &gen.GetGroupsGroupIdJoinRequests403JSONResponse{
 				Code:    "1",
-				Message: "权限不足",
+				Message: permissionDeniedMessage(err, "权限不足"),
 			}, nil
 		}
 		return nil, err
@@ -412,14 +509,10 @@ func (h *GroupsHandler) PostGroupsGroupIdJoinRequests(ctx context.Context, reque
 	if !ok {
 		return nil, appErrors.ErrJwtParseFailed
 	}
-	username, ok := ctx.Value("username").(string)
-	if !ok {
-		return nil, appErrors.ErrJwtParseFailed
-	}
 	groupID := request.GroupId
 	reason := request.Body.Reason
 
-	application, err := h.groupsService.CreateJoinApplication(ctx, groupID, userID, username, reason)
+	application, err := h.groupsService.CreateJoinApplication(ctx, groupID, userID, reason)
 	if err != nil {
 		if errors.Is(err, appErrors.ErrGroupNotFound) {
 			return &gen.PostGroupsGroupIdJoinRequests404JSONResponse{
@@ -439,8 +532,19 @@ func (h *GroupsHandler) PostGroupsGroupIdJoinRequests(ctx context.Context, reque
 				Message: "您已经提交过加入申请",
 			}, nil
 		}
+		if errors.Is(err, appErrors.ErrGroupInviteOnly) || errors.Is(err, appErrors.ErrGroupClosed) {
+			return &gen.PostGroupsGroupIdJoinRequests403JSONResponse{
+				Code:    "1",
+				Message: err.Error(),
+			}, nil
+		}
 		return nil, err
 	}
+	h.metrics.JoinApplication(metrics.EventCreated)
+	// 开放加入的用户组申请即时通过
+	if application.Status == "accepted" {
+		h.metrics.JoinApplication(metrics.EventApproved)
+	}
 
 	return &gen.PostGroupsGroupIdJoinRequests201JSONResponse{
 		Code: "0",
@@ -469,8 +573,8 @@ func (h *GroupsHandler) PutGroupsGroupIdJoinRequestsRequestId(ctx context.Contex
 	requestID := request.RequestId
 	action := request.Body.Action
 
-	// 检查用户是否在用户组中
-	if err := h.groupsService.CheckMemberPermission(ctx, groupID, userID); err != nil {
+	// 检查用户是否具有审批加入申请的权限
+	if err := h.groupsService.CheckPermission(ctx, groupID, userID, models.PermissionMemberReview); err != nil {
 		if errors.Is(err, appErrors.ErrGroupMemberNotFound) {
 			return &gen.PutGroupsGroupIdJoinRequestsRequestId403JSONResponse{
 				Code:    "1",
@@ -480,7 +584,7 @@ func (h *GroupsHandler) PutGroupsGroupIdJoinRequestsRequestId(ctx context.Contex
 		if errors.Is(err, appErrors.ErrRolePermissionDenied) {
 			return &gen.PutGroupsGroupIdJoinRequestsRequestId403JSONResponse{
 				Code:    "1",
-				Message: "权限不足",
+				Message: permissionDeniedMessage(err, "权限不足"),
 			}, nil
 		}
 		return nil, err
@@ -497,7 +601,7 @@ func (h *GroupsHandler) PutGroupsGroupIdJoinRequestsRequestId(ctx context.Contex
 		if errors.Is(err, appErrors.ErrRolePermissionDenied) {
 			return &gen.PutGroupsGroupIdJoinRequestsRequestId403JSONResponse{
 				Code:    "1",
-				Message: "权限不足",
+				Message: permissionDeniedMessage(err, "权限不足"),
 			}, nil
 		}
 		if errors.Is(err, appErrors.ErrJoinApplicationNotFound) {
@@ -545,11 +649,22 @@ func (h *GroupsHandler) PutGroupsGroupIdJoinRequestsRequestId(ctx context.Contex
 		if errors.Is(processErr, appErrors.ErrRolePermissionDenied) {
 			return &gen.PutGroupsGroupIdJoinRequestsRequestId403JSONResponse{
 				Code:    "1",
-				Message: "权限不足",
+				Message: permissionDeniedMessage(processErr, "权限不足"),
+			}, nil
+		}
+		if errors.Is(processErr, appErrors.ErrGroupInviteOnly) || errors.Is(processErr, appErrors.ErrGroupClosed) {
+			return &gen.PutGroupsGroupIdJoinRequestsRequestId403JSONResponse{
+				Code:    "1",
+				Message: processErr.Error(),
 			}, nil
 		}
 		return nil, processErr
 	}
+	if action == "approve" {
+		h.metrics.JoinApplication(metrics.EventApproved)
+	} else {
+		h.metrics.JoinApplication(metrics.EventRejected)
+	}
 
 	return &gen.PutGroupsGroupIdJoinRequestsRequestId200JSONResponse{
 		Code: "0",
@@ -576,8 +691,12 @@ func (h *GroupsHandler) GetGroupsGroupIdMembers(ctx context.Context, request gen
 		return nil, err
 	}
 
-	// 检查当前用户是否是群组成员
-	if err := h.groupsService.CheckUserExistInGroup(ctx, groupID, userID); err != nil {
+	// 检查当前用户是否是群组成员，上级用户组的管理员按查看成员资料的权限判断
+	err = h.groupsService.CheckUserExistInGroup(ctx, groupID, userID)
+	if errors.Is(err, appErrors.ErrGroupMemberNotFound) && h.groupsService.CheckPermission(ctx, groupID, userID, models.PermissionMemberProfile) == nil {
+		err = nil
+	}
+	if err != nil {
 		if errors.Is(err, appErrors.ErrGroupMemberNotFound) {
 			return &gen.GetGroupsGroupIdMembers403JSONResponse{
 				Code:    "1",
@@ -589,13 +708,36 @@ func (h *GroupsHandler) GetGroupsGroupIdMembers(ctx context.Context, request gen
 		}
 	}
 
+	// 成员资料按当前用户在组内的角色与成员的隐私设置过滤
+	profiles, err := h.groupsService.GetMemberProfiles(ctx, members, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 担任自定义角色的成员展示角色名称
+	roleNames, err := h.groupsService.RoleNames(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+
 	genMembers := make([]gen.GroupMember, len(members))
 	for i, m := range members {
+		profile := profiles[m.UserID]
+		role := m.Role
+		if name, ok := roleNames[m.RoleID]; ok && m.Role == "member" {
+			role = name
+		}
 		genMembers[i] = gen.GroupMember{
-			UserId:   m.UserID,
-			Username: m.Username,
-			Role:     m.Role,
-			JoinedAt: int(m.CreatedAt.Unix()),
+			UserId:        m.UserID,
+			Username:      m.Username,
+			Role:          role,
+			JoinedAt:      int(m.CreatedAt.Unix()),
+			DisplayName:   profile.DisplayName,
+			AvatarUrl:     profile.AvatarURL,
+			RealName:      profile.RealName,
+			StudentNumber: profile.StudentNumber,
+			Email:         profile.Email,
+			Phone:         profile.Phone,
 		}
 	}
 
@@ -615,8 +757,7 @@ func (h *GroupsHandler) DeleteGroupsGroupIdMembersUserId(ctx context.Context, re
 	targetUserID := request.UserId
 
 	// 检查用户组是否存在
-	group, err := h.groupsService.GetGroupByGroupID(ctx, groupID)
-	if err != nil {
+	if _, err := h.groupsService.GetGroupByGroupID(ctx, groupID); err != nil {
 		if errors.Is(err, appErrors.ErrGroupNotFound) {
 			return &gen.DeleteGroupsGroupIdMembersUserId404JSONResponse{
 				Code:    "1",
@@ -627,27 +768,25 @@ func (h *GroupsHandler) DeleteGroupsGroupIdMembersUserId(ctx context.Context, re
 	}
 
 	// 检查目标用户是否是群组成员
-	if err := h.groupsService.CheckMemberPermission(ctx, groupID, targetUserID); err != nil {
+	if err := h.groupsService.CheckUserExistInGroup(ctx, groupID, targetUserID); err != nil {
 		if errors.Is(err, appErrors.ErrGroupMemberNotFound) {
 			return &gen.DeleteGroupsGroupIdMembersUserId404JSONResponse{
 				Code:    "1",
 				Message: "指定用户不是该组成员",
 			}, nil
 		}
-		if !errors.Is(err, appErrors.ErrRolePermissionDenied) {
+		return nil, err
+	}
+
+	// 检查操作者是否具有移除成员的权限
+	if err := h.groupsService.CheckPermission(ctx, groupID, userID, models.PermissionMemberRemove); err != nil {
+		if errors.Is(err, appErrors.ErrRolePermissionDenied) || errors.Is(err, appErrors.ErrGroupMemberNotFound) {
 			return &gen.DeleteGroupsGroupIdMembersUserId403JSONResponse{
 				Code:    "1",
-				Message: "权限不足",
+				Message: permissionDeniedMessage(err, "权限不足"),
 			}, nil
 		}
-	}
-
-	// 检查操作者是否有权限
-	if userID != group.CreatorID {
-		return &gen.DeleteGroupsGroupIdMembersUserId403JSONResponse{
-			Code:    "1",
-			Message: "权限不足",
-		}, nil
+		return nil, err
 	}
 
 	// 不能删除自己
@@ -659,12 +798,22 @@ func (h *GroupsHandler) DeleteGroupsGroupIdMembersUserId(ctx context.Context, re
 	}
 
 	// 执行删除操作
-	err = h.groupsService.RemoveMemberFromGroup(ctx, groupID, targetUserID, userID)
-	if err != nil {
-		if errors.Is(err, appErrors.ErrRolePermissionDenied) {
+	if err := h.groupsService.RemoveMemberFromGroup(ctx, groupID, targetUserID, userID); err != nil {
+		switch {
+		case errors.Is(err, appErrors.ErrRolePermissionDenied):
+			return &gen.DeleteGroupsGroupIdMembersUserId403JSONResponse{
+				Code:    "1",
+				Message: permissionDeniedMessage(err, "权限不足"),
+			}, nil
+		case errors.Is(err, appErrors.ErrGroupOwnerRequired):
+			return &gen.DeleteGroupsGroupIdMembersUserId403JSONResponse{
+				Code:    "1",
+				Message: "不能移除用户组所有者",
+			}, nil
+		case errors.Is(err, appErrors.ErrGroupOwnerOnly):
 			return &gen.DeleteGroupsGroupIdMembersUserId403JSONResponse{
 				Code:    "1",
-				Message: "权限不足",
+				Message: "只有用户组所有者可以移除其他管理员",
 			}, nil
 		}
 		return nil, err
@@ -684,7 +833,7 @@ func (h *GroupsHandler) GetGroupsGroupIdMyStatus(ctx context.Context, request ge
 	}
 	groupID := request.GroupId
 
-	userStatus, requestID, err := h.groupsService.GetUserGroupStatus(ctx, groupID, userID)
+	userStatus, err := h.groupsService.GetUserGroupStatus(ctx, groupID, userID)
 	if err != nil {
 		if errors.Is(err, appErrors.ErrGroupNotFound) {
 			return &gen.GetGroupsGroupIdMyStatus404JSONResponse{
@@ -692,55 +841,73 @@ func (h *GroupsHandler) GetGroupsGroupIdMyStatus(ctx context.Context, request ge
 				Message: "用户组不存在",
 			}, nil
 		}
-		if errors.Is(err, appErrors.ErrGroupMemberNotFound) {
-			if userStatus == "none" {
-				return &gen.GetGroupsGroupIdMyStatus200JSONResponse{
-					Code: "0",
-					Data: struct {
-						JoinRequestId int                       `json:"joinRequestId,omitempty"`
-						Message       string                    `json:"message,omitempty"`
-						Status        gen.GroupMembershipStatus `json:"status"`
-					}{
-						Message: "您未申请加入该用户组",
-						Status:  gen.GroupMembershipStatusNone,
-					},
-				}, nil
-			}
-		}
 		return nil, err
 	}
 	var status gen.GroupMembershipStatus
 	var joinRequestId int
 	var message string
 
-	switch userStatus {
+	switch userStatus.Status {
 	case "pending":
 		status = gen.GroupMembershipStatusPending
-		joinRequestId = requestID
+		joinRequestId = userStatus.RequestID
 		message = "您的加入申请正在等待审核"
 	case "rejected":
 		status = gen.GroupMembershipStatusRejected
-		joinRequestId = requestID
+		joinRequestId = userStatus.RequestID
 		message = "您的加入申请已被拒绝"
 	case "admin":
 		status = "admin"
 		message = "您是该用户组的管理员"
-	default:
+	case "member":
 		status = gen.GroupMembershipStatusMember
 		message = "您是该用户组的成员"
+	default:
+		// 没有申请记录，或申请通过后已被移出用户组
+		status = gen.GroupMembershipStatusNone
+		message = joinPolicyMessage(userStatus.JoinPolicy)
 	}
 
 	return &gen.GetGroupsGroupIdMyStatus200JSONResponse{
 		Code: "0",
 		Data: struct {
+			CanApply      bool                      `json:"canApply"`
+			JoinPolicy    gen.GroupJoinPolicy       `json:"joinPolicy"`
 			JoinRequestId int                       `json:"joinRequestId,omitempty"`
 			Message       string                    `json:"message,omitempty"`
+			Permissions   []gen.GroupPermission     `json:"permissions,omitempty"`
+			Role          string                    `json:"role,omitempty"`
 			Status        gen.GroupMembershipStatus `json:"status"`
 		}{
+			CanApply:      userStatus.CanApply,
+			JoinPolicy:    gen.GroupJoinPolicy(userStatus.JoinPolicy),
 			JoinRequestId: joinRequestId,
 			Message:       message,
+			Permissions:   toGenGroupPermissions(userStatus.Permissions),
+			Role:          userStatus.Role,
 			Status:        status,
 		},
 	}, nil
 
 }
+
+// joinPolicyMessage 非组成员查看状态时，按用户组的加入方式给出提示
+func joinPolicyMessage(policy string) string {
+	switch policy {
+	case models.JoinPolicyOpen:
+		return "提交申请即可直接加入该用户组"
+	case models.JoinPolicyInviteOnly:
+		return "该用户组仅可通过邀请码加入"
+	case models.JoinPolicyClosed:
+		return "该用户组已停止接受新成员"
+	}
+	return "您未申请加入该用户组"
+}
+
+// permissionDeniedMessage 权限不足时的提示，因未开启两步验证被拒绝时给出具体原因
+func permissionDeniedMessage(err error, message string) string {
+	if errors.Is(err, appErrors.ErrAdminTwoFactorRequired) {
+		return adminTwoFactorRequiredMessage
+	}
+	return message
+}
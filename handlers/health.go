@@ -0,0 +1,62 @@
+package handlers
+
+import (
+	"TeamTickBackend/app"
+	"TeamTickBackend/pkg/health"
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// 单次就绪检查的超时时间，避免依赖卡住导致探针超时
+const readinessTimeout = 3 * time.Second
+
+type HealthHandler struct {
+	registry *health.Registry
+	log      *slog.Logger
+}
+
+func NewHealthHandler(container *app.AppContainer) *HealthHandler {
+	return &HealthHandler{registry: container.Health, log: container.Logger.Module("health")}
+}
+
+// Healthz 存活探针，只要进程能处理请求即返回成功
+func (h *HealthHandler) Healthz(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"code": "0",
+		"data": gin.H{"status": "ok"},
+	})
+}
+
+// Readyz 就绪探针，执行所有已注册的就绪检查
+func (h *HealthHandler) Readyz(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), readinessTimeout)
+	defer cancel()
+
+	results, ready := h.registry.Check(ctx)
+	// 响应中只返回检查名称与状态，失败原因写入日志
+	for _, result := range results {
+		if result.Err != nil {
+			h.log.WarnContext(ctx, "readiness check failed", slog.String("check", result.Name), slog.String("error", result.Err.Error()))
+		}
+	}
+	if !ready {
+		message := "依赖服务不可用"
+		if h.registry.Draining() {
+			message = "服务正在关闭"
+		}
+		c.JSON(http.StatusServiceUnavailable, gin.H{
+			"code":    "1",
+			"message": message,
+			"data":    gin.H{"status": "unavailable", "checks": results},
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"code": "0",
+		"data": gin.H{"status": "ready", "checks": results},
+	})
+}
@@ -0,0 +1,26 @@
+package handlers
+
+import (
+	"TeamTickBackend/app"
+	"TeamTickBackend/pkg"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// JWKS响应的缓存时间，新密钥应至少提前该时间加入配置，保证其它服务在密钥生效前已取得公钥
+const jwksMaxAge = "300"
+
+type JWKSHandler struct {
+	jwtHandler pkg.JwtHandler
+}
+
+func NewJWKSHandler(container *app.AppContainer) *JWKSHandler {
+	return &JWKSHandler{jwtHandler: container.JwtHandler}
+}
+
+// JWKS 按 RFC 7517 返回验证访问令牌的公钥集合，不使用统一响应格式
+func (h *JWKSHandler) JWKS(c *gin.Context) {
+	c.Header("Cache-Control", "public, max-age="+jwksMaxAge)
+	c.JSON(http.StatusOK, h.jwtHandler.JWKS())
+}
@@ -0,0 +1,128 @@
+package handlers
+
+import (
+	"TeamTickBackend/app"
+	"TeamTickBackend/gen"
+	appErrors "TeamTickBackend/pkg/errors"
+	"TeamTickBackend/pkg/metrics"
+	service "TeamTickBackend/services"
+	"context"
+	"errors"
+)
+
+func newOIDCService(container *app.AppContainer) *service.OIDCService {
+	return service.NewOIDCService(
+		container.DaoFactory.UserDAO,
+		container.DaoFactory.UserIdentityDAO,
+		container.DaoFactory.OIDCLoginStateDAO,
+		container.DaoFactory.TransactionManager,
+		container.OIDCProvider,
+		container.Config.OIDC,
+		container.ServiceLogger,
+	)
+}
+
+// 发起统一身份认证登录，返回身份提供方的授权地址
+func (h *AuthHandler) PostAuthOidcAuthorize(ctx context.Context, request gen.PostAuthOidcAuthorizeRequestObject) (gen.PostAuthOidcAuthorizeResponseObject, error) {
+	authorization, err := h.oidcService.BeginLogin(ctx, service.DeviceInfo{
+		ID:   request.Body.DeviceId,
+		Name: request.Body.DeviceName,
+	})
+	if err != nil {
+		if errors.Is(err, appErrors.ErrOIDCDisabled) {
+			return &gen.PostAuthOidcAuthorize403JSONResponse{
+				Code:    "1",
+				Message: err.Error(),
+			}, nil
+		}
+		if errors.Is(err, appErrors.ErrOIDCProviderUnavailable) {
+			return &gen.PostAuthOidcAuthorize502JSONResponse{
+				Code:    "1",
+				Message: err.Error(),
+			}, nil
+		}
+		return nil, err
+	}
+	response := gen.PostAuthOidcAuthorize200JSONResponse{Code: "0"}
+	response.Data.AuthorizationUrl = authorization.URL
+	response.Data.State = authorization.State
+	return response, nil
+}
+
+// 完成统一身份认证登录，签发与密码登录相同的令牌
+func (h *AuthHandler) PostAuthOidcCallback(ctx context.Context, request gen.PostAuthOidcCallbackRequestObject) (gen.PostAuthOidcCallbackResponseObject, error) {
+	user, device, err := h.oidcService.Complete(ctx, request.Body.Code, request.Body.State)
+	if err != nil {
+		switch {
+		case errors.Is(err, appErrors.ErrOIDCStateInvalid):
+			return &gen.PostAuthOidcCallback400JSONResponse{Code: "1", Message: err.Error()}, nil
+		case errors.Is(err, appErrors.ErrOIDCExchangeFailed):
+			h.metrics.Login(metrics.ResultFailure)
+			return &gen.PostAuthOidcCallback401JSONResponse{Code: "1", Message: err.Error()}, nil
+		case errors.Is(err, appErrors.ErrOIDCDisabled), errors.Is(err, appErrors.ErrOIDCAccountNotLinked), errors.Is(err, appErrors.ErrUserDisabled):
+			return &gen.PostAuthOidcCallback403JSONResponse{Code: "1", Message: err.Error()}, nil
+		case errors.Is(err, appErrors.ErrOIDCIdentityLinked):
+			return &gen.PostAuthOidcCallback409JSONResponse{Code: "1", Message: err.Error()}, nil
+		case errors.Is(err, appErrors.ErrOIDCProviderUnavailable):
+			return &gen.PostAuthOidcCallback502JSONResponse{Code: "1", Message: err.Error()}, nil
+		}
+		return nil, err
+	}
+
+	// 与密码登录一致，开启两步验证时先签发挑战令牌
+	if user.TwoFactorEnabled {
+		mfaToken, err := h.twoFactorService.StartChallenge(ctx, user, device)
+		if err != nil {
+			return nil, err
+		}
+		h.metrics.Login(metrics.ResultMFARequired)
+		response := gen.PostAuthOidcCallback200JSONResponse{Code: "0"}
+		response.Data.MfaRequired = true
+		response.Data.MfaToken = mfaToken
+		response.Data.UserId = user.UserID
+		response.Data.Username = user.Username
+		return response, nil
+	}
+	h.metrics.Login(metrics.ResultSuccess)
+
+	pair, err := h.tokenService.StartSession(ctx, user, withRequestInfo(ctx, device))
+	if err != nil {
+		return nil, err
+	}
+
+	response := gen.PostAuthOidcCallback200JSONResponse{Code: "0"}
+	response.Data.Token = pair.AccessToken
+	response.Data.RefreshToken = pair.RefreshToken
+	response.Data.ExpiresIn = int(h.tokenExpiry.Seconds())
+	response.Data.UserId = user.UserID
+	response.Data.Username = user.Username
+	return response, nil
+}
+
+// 为当前用户发起统一身份认证账号关联
+func (h *UserHandler) PostUsersMeOidcLink(ctx context.Context, request gen.PostUsersMeOidcLinkRequestObject) (gen.PostUsersMeOidcLinkResponseObject, error) {
+	userID, ok := ctx.Value("userID").(int)
+	if !ok {
+		return nil, appErrors.ErrJwtParseFailed
+	}
+	authorization, err := h.oidcService.BeginLink(ctx, userID)
+	if err != nil {
+		if errors.Is(err, appErrors.ErrOIDCDisabled) {
+			return &gen.PostUsersMeOidcLink403JSONResponse{
+				Code:    "1",
+				Message: err.Error(),
+			}, nil
+		}
+		if errors.Is(err, appErrors.ErrOIDCProviderUnavailable) {
+			return &gen.PostUsersMeOidcLink502JSONResponse{
+				Code:    "1",
+				Message: err.Error(),
+			}, nil
+		}
+		return nil, err
+	}
+	response := gen.PostUsersMeOidcLink200JSONResponse{Code: "0"}
+	response.Data.AuthorizationUrl = authorization.URL
+	response.Data.State = authorization.State
+	return response, nil
+}
@@ -0,0 +1,97 @@
+package handlers
+
+import (
+	"TeamTickBackend/dal/models"
+	"TeamTickBackend/gen"
+	appErrors "TeamTickBackend/pkg/errors"
+	service "TeamTickBackend/services"
+	"context"
+	"errors"
+)
+
+// 个人资料格式错误，均返回400
+var profileValidationErrors = []error{
+	appErrors.ErrProfileNameInvalid,
+	appErrors.ErrProfileStudentNumberInvalid,
+	appErrors.ErrProfileEmailInvalid,
+	appErrors.ErrProfilePhoneInvalid,
+	appErrors.ErrProfileAvatarInvalid,
+}
+
+// 修改个人资料与隐私设置
+func (h *UserHandler) PutUsersMe(ctx context.Context, request gen.PutUsersMeRequestObject) (gen.PutUsersMeResponseObject, error) {
+	userID, ok := ctx.Value("userID").(int)
+	if !ok {
+		return nil, appErrors.ErrJwtParseFailed
+	}
+	body := request.Body
+	update := service.ProfileUpdate{
+		DisplayName:   body.DisplayName,
+		RealName:      body.RealName,
+		StudentNumber: body.StudentNumber,
+		Email:         body.Email,
+		Phone:         body.Phone,
+		AvatarURL:     body.AvatarUrl,
+	}
+	if body.Privacy != nil {
+		update.ShareRealName = body.Privacy.RealName
+		update.ShareStudentNumber = body.Privacy.StudentNumber
+		update.ShareEmail = body.Privacy.Email
+		update.SharePhone = body.Privacy.Phone
+	}
+
+	user, err := h.userService.UpdateProfile(ctx, userID, update)
+	if err != nil {
+		for _, validationErr := range profileValidationErrors {
+			if errors.Is(err, validationErr) {
+				return &gen.PutUsersMe400JSONResponse{Code: "1", Message: err.Error()}, nil
+			}
+		}
+		if errors.Is(err, appErrors.ErrUserNotFound) {
+			return &gen.PutUsersMe401JSONResponse{Code: "1", Message: "用户未登录"}, nil
+		}
+		return nil, err
+	}
+	return &gen.PutUsersMe200JSONResponse{Code: "0", Data: toGenProfileUser(user)}, nil
+}
+
+// 修改用户名，其他表中冗余的用户名同步更新
+func (h *UserHandler) PutUsersMeUsername(ctx context.Context, request gen.PutUsersMeUsernameRequestObject) (gen.PutUsersMeUsernameResponseObject, error) {
+	userID, ok := ctx.Value("userID").(int)
+	if !ok {
+		return nil, appErrors.ErrJwtParseFailed
+	}
+	user, err := h.userService.ChangeUsername(ctx, userID, request.Body.Username)
+	if err != nil {
+		switch {
+		case errors.Is(err, appErrors.ErrUsernameInvalid):
+			return &gen.PutUsersMeUsername400JSONResponse{Code: "1", Message: err.Error()}, nil
+		case errors.Is(err, appErrors.ErrUserAlreadyExists):
+			return &gen.PutUsersMeUsername409JSONResponse{Code: "1", Message: "用户名已被占用"}, nil
+		case errors.Is(err, appErrors.ErrUserNotFound):
+			return &gen.PutUsersMeUsername401JSONResponse{Code: "1", Message: "用户未登录"}, nil
+		}
+		return nil, err
+	}
+	return &gen.PutUsersMeUsername200JSONResponse{Code: "0", Data: toGenProfileUser(user)}, nil
+}
+
+// toGenProfileUser 本人查看的完整资料，包括隐私设置
+func toGenProfileUser(user *models.User) gen.User {
+	return gen.User{
+		UserId:        user.UserID,
+		Username:      user.Username,
+		DisplayName:   user.DisplayName,
+		RealName:      user.RealName,
+		StudentNumber: user.StudentNumber,
+		Email:         user.Email,
+		Phone:         user.Phone,
+		AvatarUrl:     user.AvatarURL,
+		Privacy: &gen.ProfilePrivacy{
+			RealName:      user.ShareRealName,
+			StudentNumber: user.ShareStudentNumber,
+			Email:         user.ShareEmail,
+			Phone:         user.SharePhone,
+		},
+	}
+}
@@ -0,0 +1,69 @@
+package handlers
+
+import (
+	"TeamTickBackend/app"
+	"TeamTickBackend/dal/models"
+	"TeamTickBackend/gen"
+	"TeamTickBackend/middlewares"
+	"TeamTickBackend/pkg"
+	appErrors "TeamTickBackend/pkg/errors"
+	"context"
+	"errors"
+)
+
+// NewSessionTracker 供 AuthMiddleware 校验登录会话并记录最近活跃时间，所有路由共用同一实例
+func NewSessionTracker(container *app.AppContainer) middlewares.SessionTracker {
+	return newTokenService(container)
+}
+
+// 列出当前用户的登录会话
+func (h *UserHandler) GetUsersMeSessions(ctx context.Context, request gen.GetUsersMeSessionsRequestObject) (gen.GetUsersMeSessionsResponseObject, error) {
+	userID, ok := ctx.Value("userID").(int)
+	if !ok {
+		return nil, appErrors.ErrJwtParseFailed
+	}
+	// 使用个人访问令牌调用时没有当前会话
+	currentSessionID := ""
+	if payload, ok := ctx.Value("tokenPayload").(pkg.JwtPayload); ok {
+		currentSessionID = payload.SessionID
+	}
+	sessions, err := h.tokenService.ListSessions(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	data := make([]gen.Session, 0, len(sessions))
+	for _, session := range sessions {
+		data = append(data, toGenSession(session, currentSessionID))
+	}
+	return gen.GetUsersMeSessions200JSONResponse{Code: "0", Data: data}, nil
+}
+
+// 退出指定会话
+func (h *UserHandler) DeleteUsersMeSessionsSessionId(ctx context.Context, request gen.DeleteUsersMeSessionsSessionIdRequestObject) (gen.DeleteUsersMeSessionsSessionIdResponseObject, error) {
+	userID, ok := ctx.Value("userID").(int)
+	if !ok {
+		return nil, appErrors.ErrJwtParseFailed
+	}
+	if err := h.tokenService.RevokeSession(ctx, userID, request.SessionId); err != nil {
+		if errors.Is(err, appErrors.ErrSessionNotFound) {
+			return &gen.DeleteUsersMeSessionsSessionId404JSONResponse{Code: "1", Message: err.Error()}, nil
+		}
+		return nil, err
+	}
+	return gen.DeleteUsersMeSessionsSessionId200JSONResponse{Code: "0"}, nil
+}
+
+func toGenSession(session *models.UserSession, currentSessionID string) gen.Session {
+	return gen.Session{
+		Id:         session.ID,
+		DeviceId:   session.DeviceID,
+		DeviceName: session.DeviceName,
+		UserAgent:  session.UserAgent,
+		Ip:         session.IP,
+		LastSeenAt: session.LastSeenAt,
+		LastSeenIp: session.LastSeenIP,
+		CreatedAt:  session.CreatedAt,
+		ExpiresAt:  session.ExpiresAt,
+		Current:    currentSessionID != "" && session.SessionID == currentSessionID,
+	}
+}
@@ -4,10 +4,14 @@ import (
 	"TeamTickBackend/app"
 	"TeamTickBackend/dal/models"
 	"TeamTickBackend/gen"
+	"TeamTickBackend/middlewares"
 	appErrors "TeamTickBackend/pkg/errors"
+	"TeamTickBackend/pkg/metrics"
 	service "TeamTickBackend/services"
+	"bytes"
 	"context"
 	"errors"
+	"fmt"
 	"time"
 )
 
@@ -15,6 +19,7 @@ type TaskHandler struct {
 	taskService         *service.TaskService
 	groupsService       *service.GroupsService
 	auditRequestService *service.AuditRequestService
+	metrics             *metrics.Metrics
 }
 
 func NewTaskHandler(container *app.AppContainer) (gen.CheckinTasksServerInterface, gen.CheckinRecordsServerInterface) {
@@ -23,12 +28,20 @@ func NewTaskHandler(container *app.AppContainer) (gen.CheckinTasksServerInterfac
 		container.DaoFactory.TaskRecordDAO,
 		container.DaoFactory.TransactionManager,
 		container.DaoFactory.GroupDAO,
+		container.DaoFactory.DenormalizationDAO,
+		container.DaoFactory.UserDAO,
+		container.ServiceLogger,
 	)
 	GroupsService := service.NewGroupsService(
 		container.DaoFactory.GroupDAO,
 		container.DaoFactory.GroupMemberDAO,
 		container.DaoFactory.JoinApplicationDAO,
+		container.DaoFactory.UserDAO,
+		container.DaoFactory.DenormalizationDAO,
+		container.DaoFactory.GroupRoleChangeDAO,
+		container.DaoFactory.GroupRoleDAO,
 		container.DaoFactory.TransactionManager,
+		container.ServiceLogger,
 	)
 	AuditRequestService := service.NewAuditRequestService(
 		container.DaoFactory.TransactionManager,
@@ -36,13 +49,34 @@ func NewTaskHandler(container *app.AppContainer) (gen.CheckinTasksServerInterfac
 		container.DaoFactory.TaskRecordDAO,
 		container.DaoFactory.TaskDAO,
 		container.DaoFactory.GroupDAO,
+		container.DaoFactory.UserDAO,
 	)
 	handler := &TaskHandler{
 		taskService:         TaskService,
 		groupsService:       GroupsService,
 		auditRequestService: AuditRequestService,
+		metrics:             container.Metrics,
 	}
-	return gen.NewCheckinTasksStrictHandler(handler, nil), gen.NewCheckinRecordsStrictHandler(handler, nil)
+	return gen.NewCheckinTasksStrictHandler(handler, []gen.CheckinTasksStrictMiddlewareFunc{middlewares.RequireAccessTokenScope}),
+		gen.NewCheckinRecordsStrictHandler(handler, []gen.CheckinRecordsStrictMiddlewareFunc{middlewares.RequireAccessTokenScope})
+}
+
+// checkInMethods 任务启用的校验方式，用作签到指标的method标签
+func checkInMethods(task *models.Task) []string {
+	var methods []string
+	if task.GPS {
+		methods = append(methods, "gps")
+	}
+	if task.WiFi {
+		methods = append(methods, "wifi")
+	}
+	if task.NFC {
+		methods = append(methods, "nfc")
+	}
+	if task.Face {
+		methods = append(methods, "face")
+	}
+	return methods
 }
 
 // convertToCheckinTask 将 models.Task 转换为 gen.CheckinTask
@@ -125,12 +159,12 @@ func (h *TaskHandler) DeleteCheckinTasksTaskId(ctx context.Context, request gen.
 		return nil, err
 	}
 
-	// 验证用户是否是组的管理员
-	if err := h.groupsService.CheckMemberPermission(ctx, task.GroupID, userID); err != nil {
+	// 验证用户是否具有删除任务的权限
+	if err := h.groupsService.CheckPermission(ctx, task.GroupID, userID, models.PermissionTaskDelete); err != nil {
 		if errors.Is(err, appErrors.ErrRolePermissionDenied) {
 			return gen.DeleteCheckinTasksTaskId403JSONResponse{
 				Code:    "1",
-				Message: "没有权限删除该任务",
+				Message: permissionDeniedMessage(err, "没有权限删除该任务"),
 			}, nil
 		}
 		if errors.Is(err, appErrors.ErrGroupMemberNotFound) {
@@ -154,7 +188,7 @@ func (h *TaskHandler) DeleteCheckinTasksTaskId(ctx context.Context, request gen.
 	}, nil
 }
 
-// 获取指定签到任务的详细信息。需要是任务所属组成员
+// 获取指定签到任务的详细信息。需要是任务所属组或其下级用户组的成员
 func (h *TaskHandler) GetCheckinTasksTaskId(ctx context.Context, request gen.GetCheckinTasksTaskIdRequestObject) (gen.GetCheckinTasksTaskIdResponseObject, error) {
 	// 从上下文中获取当前用户ID
 	userID, ok := ctx.Value("userID").(int)
@@ -174,8 +208,12 @@ func (h *TaskHandler) GetCheckinTasksTaskId(ctx context.Context, request gen.Get
 		return nil, err
 	}
 
-	// 验证用户是否是组的成员
-	if err := h.groupsService.CheckUserExistInGroup(ctx, task.GroupID, userID); err != nil {
+	// 验证用户是否是组或其下级用户组的成员，上级用户组的管理员按查看任务的权限判断
+	err = h.groupsService.CheckUserInGroupTree(ctx, task.GroupID, userID)
+	if errors.Is(err, appErrors.ErrGroupMemberNotFound) && h.groupsService.CheckPermission(ctx, task.GroupID, userID, models.PermissionTaskView) == nil {
+		err = nil
+	}
+	if err != nil {
 		if !errors.Is(err, appErrors.ErrRolePermissionDenied) {
 			return gen.GetCheckinTasksTaskId403JSONResponse{
 				Code:    "1",
@@ -220,8 +258,8 @@ func (h *TaskHandler) PutCheckinTasksTaskId(ctx context.Context, request gen.Put
 		return nil, err
 	}
 
-	// 验证用户是否是组的管理员
-	if err := h.groupsService.CheckMemberPermission(ctx, task.GroupID, userID); err != nil {
+	// 验证用户是否具有修改任务的权限
+	if err := h.groupsService.CheckPermission(ctx, task.GroupID, userID, models.PermissionTaskUpdate); err != nil {
 		if errors.Is(err, appErrors.ErrGroupMemberNotFound) {
 			return gen.PutCheckinTasksTaskId404JSONResponse{
 				Code:    "1",
@@ -231,7 +269,7 @@ func (h *TaskHandler) PutCheckinTasksTaskId(ctx context.Context, request gen.Put
 		if errors.Is(err, appErrors.ErrRolePermissionDenied) {
 			return gen.PutCheckinTasksTaskId403JSONResponse{
 				Code:    "1",
-				Message: "没有权限修改该任务",
+				Message: permissionDeniedMessage(err, "没有权限修改该任务"),
 			}, nil
 		}
 		return nil, err
@@ -291,8 +329,8 @@ func (h *TaskHandler) PostCheckinTasksTaskIdVerify(ctx context.Context, request
 		return nil, err
 	}
 
-	// 验证用户是否是组的成员
-	if err := h.groupsService.CheckUserExistInGroup(ctx, task.GroupID, userID); err != nil {
+	// 验证用户是否是组或其下级用户组的成员
+	if err := h.groupsService.CheckUserInGroupTree(ctx, task.GroupID, userID); err != nil {
 		if errors.Is(err, appErrors.ErrGroupMemberNotFound) {
 			return &gen.PostCheckinTasksTaskIdVerify403JSONResponse{
 				Code:    "1",
@@ -353,6 +391,7 @@ func (h *TaskHandler) PostCheckinTasksTaskIdVerify(ctx context.Context, request
 			request.Body.VerificationData.LocationInfo.Location.Longitude,
 			request.TaskId,
 		)
+		h.metrics.Verification("gps", metrics.Result(isValid))
 		if !isValid {
 			return &gen.PostCheckinTasksTaskIdVerify200JSONResponse{
 				Code: "0",
@@ -382,6 +421,7 @@ func (h *TaskHandler) PostCheckinTasksTaskIdVerify(ctx context.Context, request
 			request.Body.VerificationData.WifiInfo.Bssid,
 			request.TaskId,
 		)
+		h.metrics.Verification("wifi", metrics.Result(isValid))
 		if !isValid {
 			return &gen.PostCheckinTasksTaskIdVerify200JSONResponse{
 				Code: "0",
@@ -411,6 +451,7 @@ func (h *TaskHandler) PostCheckinTasksTaskIdVerify(ctx context.Context, request
 			request.Body.VerificationData.NfcInfo.TagName,
 			request.TaskId,
 		)
+		h.metrics.Verification("nfc", metrics.Result(isValid))
 		if !isValid {
 			return &gen.PostCheckinTasksTaskIdVerify200JSONResponse{
 				Code: "0",
@@ -453,8 +494,8 @@ func (h *TaskHandler) GetGroupsGroupIdCheckinTasks(ctx context.Context, request
 		return nil, appErrors.ErrJwtParseFailed
 	}
 
-	// 验证用户是否是组的管理员
-	if err := h.groupsService.CheckMemberPermission(ctx, request.GroupId, userID); err != nil {
+	// 验证用户是否具有查看组内任务的权限
+	if err := h.groupsService.CheckPermission(ctx, request.GroupId, userID, models.PermissionTaskView); err != nil {
 		if errors.Is(err, appErrors.ErrGroupMemberNotFound) {
 			return gen.GetGroupsGroupIdCheckinTasks404JSONResponse{
 				Code:    "1",
@@ -464,7 +505,7 @@ func (h *TaskHandler) GetGroupsGroupIdCheckinTasks(ctx context.Context, request
 		if errors.Is(err, appErrors.ErrRolePermissionDenied) {
 			return gen.GetGroupsGroupIdCheckinTasks403JSONResponse{
 				Code:    "1",
-				Message: "没有权限查看该组",
+				Message: permissionDeniedMessage(err, "没有权限查看该组"),
 			}, nil
 		}
 		return nil, err
@@ -569,12 +610,12 @@ func (h *TaskHandler) PostGroupsGroupIdCheckinTasks(ctx context.Context, request
 		return nil, err
 	}
 
-	// 验证用户是否是组的管理员
-	if err := h.groupsService.CheckMemberPermission(ctx, request.GroupId, userID); err != nil {
+	// 验证用户是否具有发布任务的权限
+	if err := h.groupsService.CheckPermission(ctx, request.GroupId, userID, models.PermissionTaskCreate); err != nil {
 		if errors.Is(err, appErrors.ErrRolePermissionDenied) {
 			return &gen.PostGroupsGroupIdCheckinTasks403JSONResponse{
 				Code:    "1",
-				Message: "没有权限创建任务",
+				Message: permissionDeniedMessage(err, "没有权限创建任务"),
 			}, nil
 		}
 		if errors.Is(err, appErrors.ErrGroupMemberNotFound) {
@@ -843,7 +884,7 @@ func (h *TaskHandler) GetUsersMeCheckinTasks(ctx context.Context, request gen.Ge
 	}, nil
 }
 
-// 用户针对某个签到任务执行签到操作。根据任务配置的校验方式提供相应数据。需要登录且是任务所属组成员
+// 用户针对某个签到任务执行签到操作。根据任务配置的校验方式提供相应数据。需要登录且是任务所属组或其下级用户组的成员
 func (h *TaskHandler) PostCheckinTasksTaskIdCheckin(ctx context.Context, request gen.PostCheckinTasksTaskIdCheckinRequestObject) (gen.PostCheckinTasksTaskIdCheckinResponseObject, error) {
 	// 从上下文中获取用户ID
 	userID, ok := ctx.Value("userID").(int)
@@ -918,8 +959,8 @@ func (h *TaskHandler) PostCheckinTasksTaskIdCheckin(ctx context.Context, request
 	}
 	GroupId := task.GroupID
 
-	// 检查用户是否是该组成员
-	if err := h.groupsService.CheckUserExistInGroup(ctx, GroupId, userID); err != nil {
+	// 检查用户是否是该组或其下级用户组的成员，上级用户组的任务对下级用户组成员同样生效
+	if err := h.groupsService.CheckUserInGroupTree(ctx, GroupId, userID); err != nil {
 		if errors.Is(err, appErrors.ErrGroupMemberNotFound) {
 			return &gen.PostCheckinTasksTaskIdCheckin403JSONResponse{
 				Code:    "1",
@@ -930,6 +971,7 @@ func (h *TaskHandler) PostCheckinTasksTaskIdCheckin(ctx context.Context, request
 	}
 	// 调用服务执行签到
 	record, err := h.taskService.CheckInTask(ctx, request.TaskId, userID, request.Body.VerificationData.LocationInfo.Location.Latitude, request.Body.VerificationData.LocationInfo.Location.Longitude, time.Now())
+	h.metrics.CheckIn(checkInMethods(task), metrics.Result(err == nil))
 	if err != nil {
 		if errors.Is(err, appErrors.ErrTaskRecordAlreadyExists) {
 			return &gen.PostCheckinTasksTaskIdCheckin409JSONResponse{
@@ -994,6 +1036,44 @@ func (h *TaskHandler) PostCheckinTasksTaskIdCheckin(ctx context.Context, request
 	}, nil
 }
 
+// 具有 records.export 权限的成员以CSV格式导出签到任务的签到记录
+func (h *TaskHandler) GetCheckinTasksTaskIdRecordsExport(ctx context.Context, request gen.GetCheckinTasksTaskIdRecordsExportRequestObject) (gen.GetCheckinTasksTaskIdRecordsExportResponseObject, error) {
+	userID, ok := ctx.Value("userID").(int)
+	if !ok {
+		return nil, appErrors.ErrJwtParseFailed
+	}
+	task, err := h.taskService.GetTaskByTaskID(ctx, request.TaskId)
+	if err != nil {
+		if errors.Is(err, appErrors.ErrTaskNotFound) {
+			return &gen.GetCheckinTasksTaskIdRecordsExport404JSONResponse{
+				Code:    "1",
+				Message: "任务不存在",
+			}, nil
+		}
+		return nil, err
+	}
+	if err := h.groupsService.CheckPermission(ctx, task.GroupID, userID, models.PermissionRecordsExport); err != nil {
+		if errors.Is(err, appErrors.ErrGroupMemberNotFound) || errors.Is(err, appErrors.ErrRolePermissionDenied) {
+			return &gen.GetCheckinTasksTaskIdRecordsExport403JSONResponse{
+				Code:    "1",
+				Message: permissionDeniedMessage(err, "没有权限导出该任务的签到记录"),
+			}, nil
+		}
+		return nil, err
+	}
+	var body bytes.Buffer
+	if err := h.taskService.ExportTaskRecords(ctx, request.TaskId, &body); err != nil {
+		return nil, err
+	}
+	return &gen.GetCheckinTasksTaskIdRecordsExport200TextcsvResponse{
+		Body:          &body,
+		ContentLength: int64(body.Len()),
+		Headers: gen.GetCheckinTasksTaskIdRecordsExport200ResponseHeaders{
+			ContentDisposition: fmt.Sprintf(`attachment; filename="task-%d-records.csv"`, request.TaskId),
+		},
+	}, nil
+}
+
 // 用户组管理员查看某个签到任务的所有成功签到记录
 func (h *TaskHandler) GetCheckinTasksTaskIdRecords(ctx context.Context, request gen.GetCheckinTasksTaskIdRecordsRequestObject) (gen.GetCheckinTasksTaskIdRecordsResponseObject, error) {
 	// 从上下文中获取用户ID
@@ -1013,8 +1093,8 @@ func (h *TaskHandler) GetCheckinTasksTaskIdRecords(ctx context.Context, request
 		return nil, err
 	}
 
-	// 检查用户是否是组的管理员
-	if err := h.groupsService.CheckMemberPermission(ctx, task.GroupID, userID); err != nil {
+	// 检查用户是否具有查看签到记录的权限
+	if err := h.groupsService.CheckPermission(ctx, task.GroupID, userID, models.PermissionRecordsView); err != nil {
 		if errors.Is(err, appErrors.ErrGroupMemberNotFound) {
 			return &gen.GetCheckinTasksTaskIdRecords404JSONResponse{
 				Code:    "1",
@@ -1024,7 +1104,7 @@ func (h *TaskHandler) GetCheckinTasksTaskIdRecords(ctx context.Context, request
 		if errors.Is(err, appErrors.ErrRolePermissionDenied) {
 			return &gen.GetCheckinTasksTaskIdRecords403JSONResponse{
 				Code:    "1",
-				Message: "没有权限查看该任务",
+				Message: permissionDeniedMessage(err, "没有权限查看该任务"),
 			}, nil
 		}
 		return nil, err
@@ -0,0 +1,152 @@
+package handlers
+
+import (
+	"TeamTickBackend/gen"
+	appErrors "TeamTickBackend/pkg/errors"
+	"context"
+	"errors"
+)
+
+// 查询当前用户的两步验证状态
+func (h *UserHandler) GetUsersMe2fa(ctx context.Context, request gen.GetUsersMe2faRequestObject) (gen.GetUsersMe2faResponseObject, error) {
+	userID, ok := ctx.Value("userID").(int)
+	if !ok {
+		return nil, appErrors.ErrJwtParseFailed
+	}
+	status, err := h.twoFactorService.Status(ctx, userID)
+	if err != nil {
+		if errors.Is(err, appErrors.ErrUserNotFound) {
+			return &gen.GetUsersMe2fa401JSONResponse{
+				Code:    "1",
+				Message: "用户未登录",
+			}, nil
+		}
+		return nil, err
+	}
+	response := gen.GetUsersMe2fa200JSONResponse{Code: "0"}
+	response.Data.Enabled = status.Enabled
+	response.Data.RecoveryCodesRemaining = int(status.RecoveryCodesRemaining)
+	return response, nil
+}
+
+// 开始绑定两步验证，返回密钥与otpauth URI
+func (h *UserHandler) PostUsersMe2faEnroll(ctx context.Context, request gen.PostUsersMe2faEnrollRequestObject) (gen.PostUsersMe2faEnrollResponseObject, error) {
+	userID, ok := ctx.Value("userID").(int)
+	if !ok {
+		return nil, appErrors.ErrJwtParseFailed
+	}
+	enrollment, err := h.twoFactorService.BeginEnrollment(ctx, userID)
+	if err != nil {
+		if errors.Is(err, appErrors.ErrTwoFactorAlreadyEnabled) {
+			return &gen.PostUsersMe2faEnroll409JSONResponse{
+				Code:    "1",
+				Message: err.Error(),
+			}, nil
+		}
+		if errors.Is(err, appErrors.ErrUserNotFound) {
+			return &gen.PostUsersMe2faEnroll401JSONResponse{
+				Code:    "1",
+				Message: "用户未登录",
+			}, nil
+		}
+		return nil, err
+	}
+	response := gen.PostUsersMe2faEnroll200JSONResponse{Code: "0"}
+	response.Data.Secret = enrollment.Secret
+	response.Data.OtpauthUri = enrollment.URI
+	return response, nil
+}
+
+// 提交验证码确认绑定，返回恢复码
+func (h *UserHandler) PostUsersMe2faConfirm(ctx context.Context, request gen.PostUsersMe2faConfirmRequestObject) (gen.PostUsersMe2faConfirmResponseObject, error) {
+	userID, ok := ctx.Value("userID").(int)
+	if !ok {
+		return nil, appErrors.ErrJwtParseFailed
+	}
+	recoveryCodes, err := h.twoFactorService.ConfirmEnrollment(ctx, userID, request.Body.Code)
+	if err != nil {
+		if errors.Is(err, appErrors.ErrTwoFactorCodeInvalid) || errors.Is(err, appErrors.ErrTwoFactorNotEnrolled) {
+			return &gen.PostUsersMe2faConfirm400JSONResponse{
+				Code:    "1",
+				Message: err.Error(),
+			}, nil
+		}
+		if errors.Is(err, appErrors.ErrTwoFactorAlreadyEnabled) {
+			return &gen.PostUsersMe2faConfirm409JSONResponse{
+				Code:    "1",
+				Message: err.Error(),
+			}, nil
+		}
+		if errors.Is(err, appErrors.ErrUserNotFound) {
+			return &gen.PostUsersMe2faConfirm401JSONResponse{
+				Code:    "1",
+				Message: "用户未登录",
+			}, nil
+		}
+		return nil, err
+	}
+	response := gen.PostUsersMe2faConfirm200JSONResponse{Code: "0"}
+	response.Data.RecoveryCodes = recoveryCodes
+	return response, nil
+}
+
+// 关闭两步验证
+func (h *UserHandler) PostUsersMe2faDisable(ctx context.Context, request gen.PostUsersMe2faDisableRequestObject) (gen.PostUsersMe2faDisableResponseObject, error) {
+	userID, ok := ctx.Value("userID").(int)
+	if !ok {
+		return nil, appErrors.ErrJwtParseFailed
+	}
+	if err := h.twoFactorService.Disable(ctx, userID, request.Body.Code); err != nil {
+		if errors.Is(err, appErrors.ErrTwoFactorCodeInvalid) ||
+			errors.Is(err, appErrors.ErrTwoFactorNotEnabled) ||
+			errors.Is(err, appErrors.ErrTwoFactorNotEnrolled) {
+			return &gen.PostUsersMe2faDisable400JSONResponse{
+				Code:    "1",
+				Message: err.Error(),
+			}, nil
+		}
+		if errors.Is(err, appErrors.ErrTwoFactorRequiredByGroup) {
+			return &gen.PostUsersMe2faDisable409JSONResponse{
+				Code:    "1",
+				Message: err.Error(),
+			}, nil
+		}
+		if errors.Is(err, appErrors.ErrUserNotFound) {
+			return &gen.PostUsersMe2faDisable401JSONResponse{
+				Code:    "1",
+				Message: "用户未登录",
+			}, nil
+		}
+		return nil, err
+	}
+	return &gen.PostUsersMe2faDisable200JSONResponse{Code: "0"}, nil
+}
+
+// 重新生成恢复码
+func (h *UserHandler) PostUsersMe2faRecoveryCodes(ctx context.Context, request gen.PostUsersMe2faRecoveryCodesRequestObject) (gen.PostUsersMe2faRecoveryCodesResponseObject, error) {
+	userID, ok := ctx.Value("userID").(int)
+	if !ok {
+		return nil, appErrors.ErrJwtParseFailed
+	}
+	recoveryCodes, err := h.twoFactorService.RegenerateRecoveryCodes(ctx, userID, request.Body.Code)
+	if err != nil {
+		if errors.Is(err, appErrors.ErrTwoFactorCodeInvalid) ||
+			errors.Is(err, appErrors.ErrTwoFactorNotEnabled) ||
+			errors.Is(err, appErrors.ErrTwoFactorNotEnrolled) {
+			return &gen.PostUsersMe2faRecoveryCodes400JSONResponse{
+				Code:    "1",
+				Message: err.Error(),
+			}, nil
+		}
+		if errors.Is(err, appErrors.ErrUserNotFound) {
+			return &gen.PostUsersMe2faRecoveryCodes401JSONResponse{
+				Code:    "1",
+				Message: "用户未登录",
+			}, nil
+		}
+		return nil, err
+	}
+	response := gen.PostUsersMe2faRecoveryCodes200JSONResponse{Code: "0"}
+	response.Data.RecoveryCodes = recoveryCodes
+	return response, nil
+}
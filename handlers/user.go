@@ -3,6 +3,7 @@ package handlers
 import (
 	"TeamTickBackend/app"
 	"TeamTickBackend/gen"
+	"TeamTickBackend/middlewares"
 	"TeamTickBackend/services"
 	"context"
 	"errors"
@@ -10,18 +11,31 @@ import (
 )
 
 type UserHandler struct {
-	userService service.UserService
+	userService     service.UserService
+	passwordService *service.PasswordService
+	twoFactorService *service.TwoFactorService
+	oidcService     *service.OIDCService
+	accessTokenService *service.AccessTokenService
+	tokenService    *service.TokenService
 }
 
 func NewUserHandler(container *app.AppContainer) gen.UsersServerInterface {
 	userService := service.NewUserService(
 		container.DaoFactory.UserDAO,
+		container.DaoFactory.DenormalizationDAO,
 		container.DaoFactory.TransactionManager,
+		container.ServiceLogger,
 	)
+	tokenService := newTokenService(container)
 	handler := &UserHandler{
-		userService: *userService,
+		userService:     *userService,
+		passwordService: newPasswordService(container, tokenService),
+		twoFactorService: newTwoFactorService(container, newLoginGuard(container)),
+		oidcService:     newOIDCService(container),
+		accessTokenService: newAccessTokenService(container),
+		tokenService:    tokenService,
 	}
-	return gen.NewUsersStrictHandler(handler,nil)
+	return gen.NewUsersStrictHandler(handler, []gen.UsersStrictMiddlewareFunc{middlewares.RequireAccessTokenScope})
 }
 
 func (h *UserHandler) GetUsersMe(ctx context.Context, request gen.GetUsersMeRequestObject) (gen.GetUsersMeResponseObject, error) {
@@ -40,14 +54,33 @@ func (h *UserHandler) GetUsersMe(ctx context.Context, request gen.GetUsersMeRequ
 		return nil, err
 	}
 
-	userId := user.UserID
-	genUser := gen.User{
-		UserId:   userId,
-		Username: user.Username,
-	}
-
 	return &gen.GetUsersMe200JSONResponse{
 		Code: "0",
-		Data: genUser,
+		Data: toGenProfileUser(user),
 	}, nil
 }
+
+// 修改密码，成功后所有会话失效
+func (h *UserHandler) PutUsersMePassword(ctx context.Context, request gen.PutUsersMePasswordRequestObject) (gen.PutUsersMePasswordResponseObject, error) {
+	userID, ok := ctx.Value("userID").(int)
+	if !ok {
+		return nil, appErrors.ErrJwtParseFailed
+	}
+	err := h.passwordService.ChangePassword(ctx, userID, request.Body.OldPassword, request.Body.NewPassword)
+	if err != nil {
+		if errors.Is(err, appErrors.ErrOldPasswordIncorrect) {
+			return &gen.PutUsersMePassword400JSONResponse{
+				Code:    "1",
+				Message: err.Error(),
+			}, nil
+		}
+		if errors.Is(err, appErrors.ErrUserNotFound) {
+			return &gen.PutUsersMePassword401JSONResponse{
+				Code:    "1",
+				Message: "用户未登录",
+			}, nil
+		}
+		return nil, err
+	}
+	return &gen.PutUsersMePassword200JSONResponse{Code: "0"}, nil
+}
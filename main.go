@@ -2,11 +2,189 @@ package main
 
 import (
 	"TeamTickBackend/app"
+	"TeamTickBackend/config"
+	"TeamTickBackend/pkg"
+	"TeamTickBackend/pkg/logger"
+	"TeamTickBackend/pkg/oidc/oidctest"
 	"TeamTickBackend/router"
+	service "TeamTickBackend/services"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
 )
 
+const usage = `用法: teamtick [-config 配置文件] <命令> [参数]
+
+命令:
+  serve                      启动HTTP服务（默认）
+  migrate up                 执行所有未执行的数据库迁移
+  migrate down [N]           回滚最近N个迁移（默认1）
+  migrate status             查看迁移执行状态
+  migrate to <版本>          迁移到指定版本（0表示全部回滚）
+  migrate baseline <版本>    将已有数据库标记为已执行到指定版本
+
+运维命令（复用业务服务，无需直接修改数据库）:
+  user create <用户名> [密码]           创建用户，省略密码时从标准输入读取
+  user reset-password <用户名> [密码]   重置用户密码
+  user revoke-sessions <用户名>         吊销用户的全部登录会话
+  user unlock <用户名>                  解除用户名的登录锁定
+  user set-role <用户名> <角色>         设置平台角色（user 或 super_admin），记录在管理操作日志中
+  group transfer-owner <组ID> <用户ID>  将用户组转让给组内成员
+  task close <任务ID>                   提前结束签到任务
+  recount-members [组ID...]             按成员表重新统计成员数量，省略组ID时处理所有用户组
+  check-consistency [--repair]          检查冗余存储的用户名、组名、任务名，--repair 时按来源数据修复
+
+密钥命令:
+  jwt generate-key <RS256|EdDSA>    生成访问令牌签名私钥（PKCS#8 PEM），输出到标准输出
+
+开发命令:
+  mock-oidc                  按 oidc.issuer_url 和 oidc.client_id 启动模拟的统一身份认证服务，
+                             授权时直接以 login_hint 参数（默认 mock-user）指定的用户登录
+`
+
 func main() {
-	container := app.NewAppContainer()
+	configPath := flag.String("config", os.Getenv("TEAMTICK_CONFIG"), "配置文件路径(YAML或TOML)")
+	flag.Usage = func() {
+		fmt.Fprint(flag.CommandLine.Output(), usage)
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	cfg, err := config.Load(*configPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid configuration: %v\n", err)
+		os.Exit(1)
+	}
+	appLogger, err := logger.New(cfg.Log, os.Stdout)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid log configuration: %v\n", err)
+		os.Exit(1)
+	}
+	logger.SetDefault(appLogger)
+
+	command, args := "serve", flag.Args()
+	if len(args) > 0 {
+		command, args = args[0], args[1:]
+	}
+	switch command {
+	case "serve":
+		if err := serve(cfg, appLogger); err != nil {
+			slog.Error("server exited", slog.String("error", err.Error()))
+			os.Exit(1)
+		}
+	case "migrate":
+		if err := runMigrate(cfg, appLogger, args); err != nil {
+			slog.Error("migrate failed", slog.String("error", err.Error()))
+			os.Exit(1)
+		}
+	case "user", "group", "task", "recount-members", "check-consistency":
+		if err := runAdmin(cfg, appLogger, command, args); err != nil {
+			slog.Error(command+" failed", slog.String("error", err.Error()))
+			os.Exit(1)
+		}
+	case "jwt":
+		if err := runJWT(args); err != nil {
+			slog.Error("jwt failed", slog.String("error", err.Error()))
+			os.Exit(1)
+		}
+	case "mock-oidc":
+		if err := runMockOIDC(cfg); err != nil {
+			slog.Error("mock-oidc failed", slog.String("error", err.Error()))
+			os.Exit(1)
+		}
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+}
+
+func serve(cfg *config.Config, appLogger *logger.Logger) error {
+	log := appLogger.Module("server")
+	container, err := app.NewAppContainer(cfg, appLogger)
+	if err != nil {
+		return err
+	}
 	router := router.SetupRouter(container)
-	router.Run(":8080")
-}
\ No newline at end of file
+	server := &http.Server{
+		Addr:         cfg.Server.Addr,
+		Handler:      router,
+		ReadTimeout:  cfg.Server.ReadTimeout,
+		WriteTimeout: cfg.Server.WriteTimeout,
+		ErrorLog:     slog.NewLogLogger(log.Handler(), slog.LevelError),
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	serverErr := make(chan error, 1)
+	go func() {
+		serverErr <- server.ListenAndServe()
+	}()
+	log.Info("server started", slog.String("addr", cfg.Server.Addr), slog.String("env", cfg.Env),
+		slog.String("database", cfg.Database.Driver))
+	if cfg.Consistency.CheckInterval > 0 {
+		consistency := service.NewConsistencyService(container.DaoFactory.DenormalizationDAO, container.DaoFactory.TransactionManager, container.ServiceLogger)
+		go consistency.RunChecker(ctx, cfg.Consistency.CheckInterval, cfg.Consistency.Repair)
+	}
+
+	select {
+	case err := <-serverErr:
+		if err != nil && err != http.ErrServerClosed {
+			return err
+		}
+		return nil
+	case <-ctx.Done():
+	}
+
+	// 收到退出信号：先让就绪探针失败，再等待进行中的请求（含事务）完成
+	stop()
+	log.Info("shutting down, waiting for in-flight requests", slog.Duration("timeout", cfg.Server.ShutdownTimeout))
+	container.Health.MarkDraining()
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.Server.ShutdownTimeout)
+	defer cancel()
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		log.Error("graceful shutdown failed", slog.String("error", err.Error()))
+	}
+	if err := container.Close(); err != nil {
+		log.Error("failed to release resources", slog.String("error", err.Error()))
+	}
+	log.Info("server stopped")
+	return nil
+}
+
+// runMockOIDC 在 oidc.issuer_url 上运行模拟身份提供方，不做任何认证，禁止在生产环境使用
+func runMockOIDC(cfg *config.Config) error {
+	if cfg.IsProduction() {
+		return errors.New("mock-oidc is for development only")
+	}
+	issuer, err := url.Parse(cfg.OIDC.IssuerURL)
+	if err != nil || issuer.Scheme != "http" || issuer.Host == "" || strings.Trim(issuer.Path, "/") != "" {
+		return fmt.Errorf("oidc.issuer_url must be a local http URL without a path, got %q", cfg.OIDC.IssuerURL)
+	}
+	if cfg.OIDC.ClientID == "" {
+		return errors.New("oidc.client_id is required")
+	}
+	slog.Info("mock oidc provider started", slog.String("issuer", cfg.OIDC.IssuerURL), slog.String("client_id", cfg.OIDC.ClientID))
+	return oidctest.ListenAndServe(issuer.Host, cfg.OIDC.IssuerURL, cfg.OIDC.ClientID)
+}
+
+// runJWT 生成签名私钥，公钥由服务启动后通过 /.well-known/jwks.json 公开
+func runJWT(args []string) error {
+	if len(args) != 2 || args[0] != "generate-key" {
+		return errors.New("usage: jwt generate-key <RS256|EdDSA>")
+	}
+	key, err := pkg.GenerateSigningKey(args[1])
+	if err != nil {
+		return err
+	}
+	_, err = os.Stdout.Write(key)
+	return err
+}
@@ -0,0 +1,90 @@
+package middlewares
+
+import (
+	"TeamTickBackend/pkg"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	strictgin "github.com/oapi-codegen/runtime/strictmiddleware/gin"
+)
+
+// AccessTokenKey 使用个人访问令牌鉴权时，上下文中保存 pkg.AccessTokenIdentity 的键
+const AccessTokenKey = "accessToken"
+
+// accessTokenOperationScopes 生成接口（operationId）所需的权限范围
+// 未列出的接口（打卡、提交审核、密码与两步验证、令牌管理、平台管理等）不允许使用个人访问令牌调用
+var accessTokenOperationScopes = map[string]string{
+	"GetUsersMe": pkg.ScopeProfileRead,
+
+	"GetGroups":                pkg.ScopeGroupsRead,
+	"GetGroupsGroupId":         pkg.ScopeGroupsRead,
+	"GetGroupsGroupIdMembers":  pkg.ScopeGroupsRead,
+	"GetGroupsGroupIdMyStatus": pkg.ScopeGroupsRead,
+	"GetGroupsGroupIdRoles":    pkg.ScopeGroupsRead,
+
+	"PostGroups":                    pkg.ScopeGroupsWrite,
+	"PostGroupsGroupIdJoinRequests": pkg.ScopeGroupsWrite,
+	"PostGroupsJoinByCode":          pkg.ScopeGroupsWrite,
+
+	"PutGroupsGroupId":                      pkg.ScopeGroupsAdmin,
+	"DeleteGroupsGroupId":                   pkg.ScopeGroupsAdmin,
+	"GetGroupsGroupIdJoinRequests":          pkg.ScopeGroupsAdmin,
+	"PutGroupsGroupIdJoinRequestsRequestId": pkg.ScopeGroupsAdmin,
+	"DeleteGroupsGroupIdMembersUserId":      pkg.ScopeGroupsAdmin,
+	"GetGroupsGroupIdInvites":               pkg.ScopeGroupsAdmin,
+	"PostGroupsGroupIdInvites":              pkg.ScopeGroupsAdmin,
+	"DeleteGroupsGroupIdInvitesInviteId":    pkg.ScopeGroupsAdmin,
+	"PutGroupsGroupIdMembersUserIdRole":     pkg.ScopeGroupsAdmin,
+	"PostGroupsGroupIdTransferOwnership":    pkg.ScopeGroupsAdmin,
+	"GetGroupsGroupIdRoleChanges":           pkg.ScopeGroupsAdmin,
+	"PostGroupsGroupIdRoles":                pkg.ScopeGroupsAdmin,
+	"PutGroupsGroupIdRolesRoleId":           pkg.ScopeGroupsAdmin,
+	"DeleteGroupsGroupIdRolesRoleId":        pkg.ScopeGroupsAdmin,
+	"PutGroupsGroupIdParent":                pkg.ScopeGroupsAdmin,
+
+	"GetUsersMeCheckinTasks":       pkg.ScopeTasksRead,
+	"GetGroupsGroupIdCheckinTasks": pkg.ScopeTasksRead,
+	"GetCheckinTasksTaskId":        pkg.ScopeTasksRead,
+
+	"PostGroupsGroupIdCheckinTasks": pkg.ScopeTasksWrite,
+	"PutCheckinTasksTaskId":         pkg.ScopeTasksWrite,
+	"DeleteCheckinTasksTaskId":      pkg.ScopeTasksWrite,
+
+	"GetUsersMeCheckinRecords":           pkg.ScopeRecordsRead,
+	"GetCheckinTasksTaskIdRecords":       pkg.ScopeRecordsRead,
+	"GetCheckinTasksTaskIdRecordsExport": pkg.ScopeRecordsRead,
+	"GetGroupsGroupIdStats":              pkg.ScopeRecordsRead,
+
+	"GetUsersMeAuditRequests":       pkg.ScopeAuditsRead,
+	"GetGroupsGroupIdAuditRequests": pkg.ScopeAuditsRead,
+
+	"PutAuditRequestsAuditRequestId": pkg.ScopeAuditsWrite,
+}
+
+// RequireAccessTokenScope 生成代码的 StrictMiddleware，使用个人访问令牌调用时检查接口所需的权限范围，
+// 使用JWT调用时直接放行
+func RequireAccessTokenScope(f strictgin.StrictGinHandlerFunc, operationID string) strictgin.StrictGinHandlerFunc {
+	required, allowed := accessTokenOperationScopes[operationID]
+	return func(c *gin.Context, request interface{}) (interface{}, error) {
+		value, ok := c.Get(AccessTokenKey)
+		if !ok {
+			return f(c, request)
+		}
+		identity, _ := value.(pkg.AccessTokenIdentity)
+		if !allowed {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+				"code":    "1",
+				"message": "该接口不支持使用个人访问令牌调用",
+			})
+			return nil, nil
+		}
+		if !identity.HasScope(required) {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+				"code":    "1",
+				"message": "个人访问令牌缺少权限范围：" + required,
+			})
+			return nil, nil
+		}
+		return f(c, request)
+	}
+}
@@ -0,0 +1,36 @@
+package middlewares
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PlatformAdminChecker 判断用户是否为平台管理员
+type PlatformAdminChecker interface {
+	IsPlatformAdmin(ctx context.Context, userID int) (bool, error)
+}
+
+// PlatformAdminMiddleware 仅允许平台管理员访问，需注册在 AuthMiddleware 之后
+func PlatformAdminMiddleware(admins PlatformAdminChecker) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ok, err := admins.IsPlatformAdmin(c.Request.Context(), c.GetInt("userID"))
+		if err != nil {
+			c.Error(err)
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{
+				"code":    "1",
+				"message": "internal server error",
+			})
+			return
+		}
+		if !ok {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+				"code":    "1",
+				"message": "需要平台管理员权限",
+			})
+			return
+		}
+		c.Next()
+	}
+}
@@ -2,44 +2,137 @@ package middlewares
 
 import (
 	"TeamTickBackend/pkg"
+	appErrors "TeamTickBackend/pkg/errors"
 	"context"
+	"errors"
 	"net/http"
 	"time"
 
 	"github.com/gin-gonic/gin"
 )
 
-// 认证中间件，错误处理日志待完善
-func AuthMiddleware(jwtToken pkg.JwtHandler) gin.HandlerFunc {
+// AccessTokenAuthenticator 校验个人访问令牌
+type AccessTokenAuthenticator interface {
+	AuthenticateAccessToken(ctx context.Context, token, clientIP string) (pkg.AccessTokenIdentity, error)
+}
+
+// SessionTracker 确认JWT所属的登录会话仍然有效并记录最近活跃时间
+type SessionTracker interface {
+	TouchSession(ctx context.Context, payload pkg.JwtPayload, clientIP string) error
+}
+
+// 认证中间件，同时接受JWT与个人访问令牌（ttpat_ 前缀），accessTokens 为nil时只接受JWT
+// 个人访问令牌的权限范围由生成代码的 RequireAccessTokenScope 按接口检查
+// sessions 不为nil时JWT所属的会话须未被吊销，并记录会话的最近活跃时间
+func AuthMiddleware(jwtToken pkg.JwtHandler, accessTokens AccessTokenAuthenticator, sessions SessionTracker) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		token := c.GetHeader("Authorization")
-		if token == "" {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
-				"code":    "1",
-				"message": "missing authorization",
-			})
+		if accessTokens != nil && pkg.IsAccessToken(c.GetHeader("Authorization")) {
+			if !authenticateAccessToken(c, accessTokens) {
+				return
+			}
+			c.Next()
 			return
 		}
+		if !authenticate(c, jwtToken) {
+			return
+		}
+		if sessions != nil && !touchSession(c, sessions) {
+			return
+		}
+		c.Next()
+	}
+}
+
+// touchSession 会话已被吊销时中止请求并返回false
+func touchSession(c *gin.Context, sessions SessionTracker) bool {
+	payload := c.MustGet("tokenPayload").(pkg.JwtPayload)
+	err := sessions.TouchSession(c.Request.Context(), payload, c.ClientIP())
+	if err == nil {
+		return true
+	}
+	if errors.Is(err, appErrors.ErrSessionRevoked) {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+			"code":    "1",
+			"message": "invalid token:" + err.Error(),
+		})
+		return false
+	}
+	c.Error(err)
+	c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{
+		"code":    "1",
+		"message": "internal server error",
+	})
+	return false
+}
 
-		payload, err := jwtToken.ParseJWTToken(token)
-		if err != nil {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
-				"code":    "1",
-				"message": "invalid token:" + err.Error(),
-			})
+// AuthForSecuredOperations 用于公开接口与需登录接口混合的路由组（如 /auth），
+// 以生成代码的 HandlerMiddlewares 方式注册，仅对OpenAPI中声明了security的接口校验令牌：
+// 生成的包装函数会先在上下文中写入 scopesKey
+func AuthForSecuredOperations(jwtToken pkg.JwtHandler, scopesKey string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if _, secured := c.Get(scopesKey); !secured {
 			return
 		}
+		authenticate(c, jwtToken)
+	}
+}
 
-		c.Set("username", payload.Username)
-		c.Set("userID", payload.UserID)
-		c.Set("authenticated", true)
-		c.Set("auth_time", time.Now().Unix())
+// authenticate 校验令牌并写入用户信息，失败时中止请求并返回false
+func authenticate(c *gin.Context, jwtToken pkg.JwtHandler) bool {
+	token := c.GetHeader("Authorization")
+	if token == "" {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+			"code":    "1",
+			"message": "missing authorization",
+		})
+		return false
+	}
 
-		// 同时存储到请求上下文（handlers层接受的是标准库Context）
-		ctx := context.WithValue(c.Request.Context(), "userID", payload.UserID)
-		ctx = context.WithValue(ctx, "username", payload.Username)
-		c.Request = c.Request.WithContext(ctx)
+	payload, err := jwtToken.ParseJWTToken(token)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+			"code":    "1",
+			"message": "invalid token:" + err.Error(),
+		})
+		return false
+	}
 
-		c.Next()
+	c.Set("username", payload.Username)
+	c.Set("userID", payload.UserID)
+	// 完整的令牌信息，登出等需要jti/sid的接口使用
+	c.Set("tokenPayload", payload)
+	c.Set("authenticated", true)
+	c.Set("auth_time", time.Now().Unix())
+
+	// 同时存储到请求上下文（handlers层接受的是标准库Context）
+	ctx := context.WithValue(c.Request.Context(), "userID", payload.UserID)
+	ctx = context.WithValue(ctx, "username", payload.Username)
+	ctx = context.WithValue(ctx, "tokenPayload", payload)
+	c.Request = c.Request.WithContext(ctx)
+	return true
+}
+
+// authenticateAccessToken 校验个人访问令牌并写入用户信息，不写入 tokenPayload，
+// 依赖会话的接口（登出等）因此无法使用个人访问令牌
+func authenticateAccessToken(c *gin.Context, accessTokens AccessTokenAuthenticator) bool {
+	identity, err := accessTokens.AuthenticateAccessToken(c.Request.Context(), c.GetHeader("Authorization"), c.ClientIP())
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+			"code":    "1",
+			"message": "invalid token:" + err.Error(),
+		})
+		return false
 	}
+
+	c.Set("username", identity.Username)
+	c.Set("userID", identity.UserID)
+	c.Set(AccessTokenKey, identity)
+	c.Set("authenticated", true)
+	c.Set("auth_time", time.Now().Unix())
+
+	ctx := context.WithValue(c.Request.Context(), "userID", identity.UserID)
+	ctx = context.WithValue(ctx, "username", identity.Username)
+	ctx = context.WithValue(ctx, AccessTokenKey, identity)
+	c.Request = c.Request.WithContext(ctx)
+	return true
 }
@@ -0,0 +1,21 @@
+package middlewares
+
+import (
+	"TeamTickBackend/pkg/metrics"
+
+	"github.com/gin-gonic/gin"
+)
+
+// MetricsMiddleware 按路由模板记录请求耗时与状态码，未匹配到路由的请求记为unmatched，避免标签基数膨胀
+func MetricsMiddleware(m *metrics.Metrics) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		done := m.HTTPStarted()
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+		done(c.Request.Method, route, c.Writer.Status())
+	}
+}
@@ -0,0 +1,52 @@
+package middlewares
+
+import (
+	"TeamTickBackend/pkg/logger"
+	"crypto/rand"
+	"encoding/hex"
+
+	"github.com/gin-gonic/gin"
+)
+
+// 请求ID的请求/响应头
+const RequestIDHeader = "X-Request-ID"
+
+// 允许沿用的上游请求ID最大长度
+const maxRequestIDLength = 128
+
+// RequestIDMiddleware 为每个请求生成请求ID（或沿用上游网关传入的ID），
+// 写入响应头并存入上下文，日志会自动带上 request_id 字段
+func RequestIDMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := c.GetHeader(RequestIDHeader)
+		if !validRequestID(requestID) {
+			requestID = newRequestID()
+		}
+		c.Set(logger.RequestIDKey, requestID)
+		c.Header(RequestIDHeader, requestID)
+
+		// 同时存储到请求上下文（handlers层接受的是标准库Context）
+		c.Request = c.Request.WithContext(logger.WithRequestID(c.Request.Context(), requestID))
+
+		c.Next()
+	}
+}
+
+func newRequestID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// validRequestID 只接受长度受限的可打印ASCII，避免日志注入
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] < 0x21 || id[i] > 0x7e {
+			return false
+		}
+	}
+	return true
+}
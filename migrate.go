@@ -0,0 +1,123 @@
+package main
+
+import (
+	"TeamTickBackend/config"
+	db "TeamTickBackend/dal"
+	"TeamTickBackend/dal/migrations"
+	"TeamTickBackend/pkg/logger"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
+)
+
+// runMigrate 执行 migrate 子命令
+func runMigrate(cfg *config.Config, appLogger *logger.Logger, args []string) error {
+	if len(args) == 0 {
+		return errors.New("missing action, expected one of up, down, status, to, baseline")
+	}
+	if cfg.Database.Driver == db.DriverMemory {
+		return errors.New("the memory driver has no schema to migrate")
+	}
+	// 迁移子命令始终显式执行，不依赖 auto_migrate 配置
+	dbCfg := cfg.Database
+	dbCfg.AutoMigrate = false
+	gormDB, err := db.InitDB(dbCfg, appLogger.Module("gorm"))
+	if err != nil {
+		return err
+	}
+	migrator, err := migrations.NewMigrator(gormDB)
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+
+	action, args := args[0], args[1:]
+	switch action {
+	case "up":
+		executed, err := migrator.Up(ctx)
+		printExecuted("applied", executed)
+		return err
+	case "down":
+		steps := 1
+		if len(args) > 0 {
+			if steps, err = strconv.Atoi(args[0]); err != nil || steps <= 0 {
+				return fmt.Errorf("invalid step count %q", args[0])
+			}
+		}
+		executed, err := migrator.Down(ctx, steps)
+		printExecuted("reverted", executed)
+		return err
+	case "to":
+		version, err := versionArg(args)
+		if err != nil {
+			return err
+		}
+		executed, err := migrator.To(ctx, version)
+		printExecuted("executed", executed)
+		return err
+	case "baseline":
+		version, err := versionArg(args)
+		if err != nil {
+			return err
+		}
+		if err := migrator.Baseline(ctx, version); err != nil {
+			return err
+		}
+		fmt.Printf("baselined at version %d\n", version)
+		return nil
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+		printStatus(statuses)
+		return nil
+	default:
+		return fmt.Errorf("unknown action %q", action)
+	}
+}
+
+func versionArg(args []string) (int, error) {
+	if len(args) == 0 {
+		return 0, errors.New("missing target version")
+	}
+	version, err := strconv.Atoi(args[0])
+	if err != nil || version < 0 {
+		return 0, fmt.Errorf("invalid version %q", args[0])
+	}
+	return version, nil
+}
+
+func printExecuted(verb string, executed []*migrations.Migration) {
+	if len(executed) == 0 {
+		fmt.Println("no migrations to run")
+		return
+	}
+	for _, migration := range executed {
+		fmt.Printf("%s %04d_%s\n", verb, migration.Version, migration.Name)
+	}
+}
+
+func printStatus(statuses []migrations.Status) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tNAME\tSTATUS\tAPPLIED AT")
+	for _, status := range statuses {
+		state, appliedAt := "pending", ""
+		if status.Applied {
+			state = "applied"
+			appliedAt = status.AppliedAt.Format(time.RFC3339)
+		}
+		if status.ChecksumMismatch {
+			state = "checksum mismatch"
+		}
+		if status.Missing {
+			state = "missing file"
+		}
+		fmt.Fprintf(w, "%04d\t%s\t%s\t%s\n", status.Version, status.Name, state, appliedAt)
+	}
+	w.Flush()
+}
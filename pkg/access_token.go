@@ -0,0 +1,66 @@
+package pkg
+
+import (
+	"strings"
+)
+
+// AccessTokenPrefix 个人访问令牌的前缀，用于与JWT区分，也便于密钥扫描工具识别
+const AccessTokenPrefix = "ttpat_"
+
+// 个人访问令牌的权限范围，令牌只能调用声明了对应范围的接口，且不超出用户本身的权限
+const (
+	ScopeProfileRead = "profile:read"
+	ScopeGroupsRead  = "groups:read"
+	ScopeGroupsWrite = "groups:write"
+	ScopeGroupsAdmin = "groups:admin"
+	ScopeTasksRead   = "tasks:read"
+	ScopeTasksWrite  = "tasks:write"
+	ScopeRecordsRead = "records:read"
+	ScopeAuditsRead  = "audits:read"
+	ScopeAuditsWrite = "audits:write"
+)
+
+// AccessTokenScopes 全部可用的权限范围
+var AccessTokenScopes = []string{
+	ScopeProfileRead,
+	ScopeGroupsRead,
+	ScopeGroupsWrite,
+	ScopeGroupsAdmin,
+	ScopeTasksRead,
+	ScopeTasksWrite,
+	ScopeRecordsRead,
+	ScopeAuditsRead,
+	ScopeAuditsWrite,
+}
+
+// AccessTokenIdentity 个人访问令牌校验通过后的身份
+type AccessTokenIdentity struct {
+	TokenID  int
+	UserID   int
+	Username string
+	Scopes   []string
+}
+
+// HasScope 判断令牌是否具有指定的权限范围
+func (i AccessTokenIdentity) HasScope(scope string) bool {
+	for _, s := range i.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// IsAccessToken 判断Authorization中的令牌是否为个人访问令牌，支持带 Bearer 前缀
+func IsAccessToken(token string) bool {
+	return strings.HasPrefix(TrimBearer(token), AccessTokenPrefix)
+}
+
+// TrimBearer 去掉Authorization头中的 Bearer 前缀
+func TrimBearer(token string) string {
+	token = strings.TrimSpace(token)
+	if len(token) > 7 && strings.ToUpper(token[:7]) == "BEARER " {
+		return strings.TrimSpace(token[7:])
+	}
+	return token
+}
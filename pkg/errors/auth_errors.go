@@ -1,6 +1,9 @@
 package errors
 
-import "net/http"
+import (
+	"net/http"
+	"time"
+)
 
 var (
 	ErrUserNotFound = &AppError{
@@ -13,11 +16,80 @@ var (
 		Status:  http.StatusConflict,
 	}
 
-	ErrInvalidPassword = &AppError{
-		Message: "密码错误",
+	// 登录失败统一返回该错误，不区分用户不存在与密码错误
+	ErrInvalidCredentials = &AppError{
+		Message: "用户名或密码错误",
+		Status:  http.StatusUnauthorized,
+	}
+
+	ErrLoginLocked = &AppError{
+		Message: "登录失败次数过多，请稍后再试",
+		Status:  http.StatusTooManyRequests,
+	}
+
+	ErrPlatformAdminRequired = &AppError{
+		Message: "需要平台管理员权限",
+		Status:  http.StatusForbidden,
+	}
+
+	// 账号被平台管理员停用，不能登录，已签发的令牌均失效
+	ErrUserDisabled = &AppError{
+		Message: "账号已被停用",
+		Status:  http.StatusForbidden,
+	}
+
+	ErrUserAlreadyDisabled = &AppError{
+		Message: "账号已处于停用状态",
+		Status:  http.StatusConflict,
+	}
+
+	ErrUserNotDisabled = &AppError{
+		Message: "账号未被停用",
+		Status:  http.StatusConflict,
+	}
+
+	// 平台管理员不能停用自己或修改自己的平台角色，避免平台失去管理员
+	ErrAdminSelfAction = &AppError{
+		Message: "不能对自己的账号执行该操作",
+		Status:  http.StatusBadRequest,
+	}
+
+	ErrPlatformRoleInvalid = &AppError{
+		Message: "无效的平台角色",
+		Status:  http.StatusBadRequest,
+	}
+
+	ErrTwoFactorAlreadyEnabled = &AppError{
+		Message: "两步验证已开启",
+		Status:  http.StatusConflict,
+	}
+
+	ErrTwoFactorNotEnrolled = &AppError{
+		Message: "请先生成两步验证密钥",
+		Status:  http.StatusBadRequest,
+	}
+
+	ErrTwoFactorNotEnabled = &AppError{
+		Message: "两步验证未开启",
+		Status:  http.StatusBadRequest,
+	}
+
+	ErrTwoFactorCodeInvalid = &AppError{
+		Message: "验证码错误",
+		Status:  http.StatusBadRequest,
+	}
+
+	ErrMFAChallengeInvalid = &AppError{
+		Message: "登录验证已失效，请重新登录",
 		Status:  http.StatusUnauthorized,
 	}
 
+	// 用户是要求管理员开启两步验证的用户组的管理员，不能关闭两步验证
+	ErrTwoFactorRequiredByGroup = &AppError{
+		Message: "所管理的用户组要求开启两步验证，无法关闭",
+		Status:  http.StatusConflict,
+	}
+
 	ErrPasswordEncryption = &AppError{
 		Message: "密码加密失败",
 		Status:  http.StatusInternalServerError,
@@ -27,4 +99,132 @@ var (
 		Message: "用户创建失败",
 		Status:  http.StatusInternalServerError,
 	}
+
+	ErrPasswordUpdateFailed = &AppError{
+		Message: "密码更新失败",
+		Status:  http.StatusInternalServerError,
+	}
+
+	ErrOldPasswordIncorrect = &AppError{
+		Message: "原密码错误",
+		Status:  http.StatusBadRequest,
+	}
+
+	ErrResetTokenInvalid = &AppError{
+		Message: "重置令牌无效或已过期",
+		Status:  http.StatusBadRequest,
+	}
+
+	ErrPasswordResetDisabled = &AppError{
+		Message: "自助重置密码功能未开启",
+		Status:  http.StatusForbidden,
+	}
+
+	ErrNotificationFailed = &AppError{
+		Message: "重置密码通知发送失败",
+		Status:  http.StatusInternalServerError,
+	}
+
+	ErrOIDCDisabled = &AppError{
+		Message: "统一身份认证登录未开启",
+		Status:  http.StatusForbidden,
+	}
+
+	ErrOIDCStateInvalid = &AppError{
+		Message: "登录请求无效或已过期，请重新发起登录",
+		Status:  http.StatusBadRequest,
+	}
+
+	ErrOIDCExchangeFailed = &AppError{
+		Message: "统一身份认证校验失败",
+		Status:  http.StatusUnauthorized,
+	}
+
+	ErrOIDCAccountNotLinked = &AppError{
+		Message: "该统一身份认证账号尚未关联用户，请先使用密码登录后关联",
+		Status:  http.StatusForbidden,
+	}
+
+	ErrOIDCIdentityLinked = &AppError{
+		Message: "该统一身份认证账号已关联其他用户",
+		Status:  http.StatusConflict,
+	}
+
+	ErrOIDCProviderUnavailable = &AppError{
+		Message: "统一身份认证服务暂时不可用",
+		Status:  http.StatusBadGateway,
+	}
+
+	ErrUsernameInvalid = &AppError{
+		Message: "用户名长度为3-50个字符，且不能包含空白或控制字符",
+		Status:  http.StatusBadRequest,
+	}
+
+	ErrProfileNameInvalid = &AppError{
+		Message: "昵称或姓名不能包含控制字符",
+		Status:  http.StatusBadRequest,
+	}
+
+	ErrProfileStudentNumberInvalid = &AppError{
+		Message: "学号或工号只能包含字母、数字和连字符",
+		Status:  http.StatusBadRequest,
+	}
+
+	ErrProfileEmailInvalid = &AppError{
+		Message: "邮箱格式不正确",
+		Status:  http.StatusBadRequest,
+	}
+
+	ErrProfilePhoneInvalid = &AppError{
+		Message: "手机号格式不正确",
+		Status:  http.StatusBadRequest,
+	}
+
+	ErrProfileAvatarInvalid = &AppError{
+		Message: "头像地址必须是http或https链接",
+		Status:  http.StatusBadRequest,
+	}
+
+	ErrAccessTokensDisabled = &AppError{
+		Message: "个人访问令牌未启用",
+		Status:  http.StatusForbidden,
+	}
+
+	ErrAccessTokenInvalid = &AppError{
+		Message: "个人访问令牌无效或已过期",
+		Status:  http.StatusUnauthorized,
+	}
+
+	ErrAccessTokenScopeInvalid = &AppError{
+		Message: "无效的令牌权限范围",
+		Status:  http.StatusBadRequest,
+	}
+
+	ErrAccessTokenExpiryInvalid = &AppError{
+		Message: "令牌有效期超出允许范围",
+		Status:  http.StatusBadRequest,
+	}
+
+	ErrAccessTokenLimitReached = &AppError{
+		Message: "有效的个人访问令牌数量已达上限",
+		Status:  http.StatusConflict,
+	}
+
+	ErrAccessTokenNotFound = &AppError{
+		Message: "个人访问令牌不存在",
+		Status:  http.StatusNotFound,
+	}
 )
+
+// LoginLockedError 登录被临时锁定，RetryAfter 为剩余等待时间，errors.Is 匹配 ErrLoginLocked
+type LoginLockedError struct {
+	RetryAfter time.Duration
+}
+
+func (e *LoginLockedError) Error() string {
+	return ErrLoginLocked.Message
+}
+
+func (e *LoginLockedError) Is(target error) bool {
+	return target == ErrLoginLocked
+}
@@ -73,5 +73,117 @@ var (
 		Status:  http.StatusUnauthorized,
 	}
 
+	ErrAdminTwoFactorRequired = &AppError{
+		Message: "该用户组要求管理员开启两步验证",
+		Status:  http.StatusForbidden,
+	}
+
+	ErrGroupInviteNotFound = &AppError{
+		Message: "邀请码不存在",
+		Status:  http.StatusNotFound,
+	}
+
+	ErrGroupInviteUnavailable = &AppError{
+		Message: "邀请码已失效",
+		Status:  http.StatusGone,
+	}
+
+	ErrGroupInviteInvalid = &AppError{
+		Message: "邀请码设置不合法",
+		Status:  http.StatusBadRequest,
+	}
+
+	ErrGroupInviteLimitReached = &AppError{
+		Message: "有效邀请码数量已达上限",
+		Status:  http.StatusConflict,
+	}
+
+	ErrJoinPolicyInvalid = &AppError{
+		Message: "加入方式不合法",
+		Status:  http.StatusBadRequest,
+	}
+
+	ErrGroupInviteOnly = &AppError{
+		Message: "该用户组仅可通过邀请码加入",
+		Status:  http.StatusForbidden,
+	}
+
+	ErrGroupClosed = &AppError{
+		Message: "该用户组已停止接受新成员",
+		Status:  http.StatusForbidden,
+	}
+
+	ErrGroupRoleInvalid = &AppError{
+		Message: "组内角色不合法",
+		Status:  http.StatusBadRequest,
+	}
+
+	ErrGroupOwnerOnly = &AppError{
+		Message: "仅用户组所有者可以执行该操作",
+		Status:  http.StatusForbidden,
+	}
+
+	ErrGroupOwnerRequired = &AppError{
+		Message: "用户组所有者必须保留管理员身份，请先转让所有权",
+		Status:  http.StatusConflict,
+	}
+
+	ErrGroupAlreadyOwner = &AppError{
+		Message: "该成员已是用户组所有者",
+		Status:  http.StatusConflict,
+	}
+
+	ErrGroupPermissionInvalid = &AppError{
+		Message: "组内权限不合法",
+		Status:  http.StatusBadRequest,
+	}
+
+	ErrGroupPermissionExceeded = &AppError{
+		Message: "不能授予自己不具有的权限",
+		Status:  http.StatusForbidden,
+	}
+
+	ErrGroupRoleNotFound = &AppError{
+		Message: "自定义角色不存在",
+		Status:  http.StatusNotFound,
+	}
+
+	ErrGroupRoleExists = &AppError{
+		Message: "角色名称已存在",
+		Status:  http.StatusConflict,
+	}
+
+	ErrGroupRoleInUse = &AppError{
+		Message: "仍有成员担任该角色，请先调整这些成员的角色",
+		Status:  http.StatusConflict,
+	}
+
+	ErrGroupParentNotFound = &AppError{
+		Message: "上级用户组不存在",
+		Status:  http.StatusNotFound,
+	}
+
+	ErrGroupHierarchyCycle = &AppError{
+		Message: "上级用户组不能是该用户组自身或其下级用户组",
+		Status:  http.StatusBadRequest,
+	}
+
+	ErrGroupHierarchyTooDeep = &AppError{
+		Message: "用户组层级超过上限",
+		Status:  http.StatusBadRequest,
+	}
+
 	//待完善
 )
+
+// AdminTwoFactorRequiredError 用户组要求管理员开启两步验证而操作者未开启，
+// errors.Is 同时匹配 ErrAdminTwoFactorRequired 与 ErrRolePermissionDenied，未单独处理的调用方按权限不足处理
+type AdminTwoFactorRequiredError struct{}
+
+func (e *AdminTwoFactorRequiredError) Error() string {
+	return ErrAdminTwoFactorRequired.Message
+}
+
+func (e *AdminTwoFactorRequiredError) Is(target error) bool {
+	return target == ErrAdminTwoFactorRequired || target == ErrRolePermissionDenied
+}
@@ -14,4 +14,34 @@ var (
 		Message: "令牌生成失败",
 		Status:  http.StatusInternalServerError,
 	}
-)
\ No newline at end of file
+
+	ErrTokenRevoked = &AppError{
+		Message: "令牌已被吊销",
+		Status:  http.StatusUnauthorized,
+	}
+
+	ErrRefreshTokenInvalid = &AppError{
+		Message: "刷新令牌无效",
+		Status:  http.StatusUnauthorized,
+	}
+
+	ErrRefreshTokenExpired = &AppError{
+		Message: "刷新令牌已过期",
+		Status:  http.StatusUnauthorized,
+	}
+
+	ErrRefreshTokenReused = &AppError{
+		Message: "刷新令牌已被使用，该登录会话已失效",
+		Status:  http.StatusUnauthorized,
+	}
+
+	ErrSessionNotFound = &AppError{
+		Message: "登录会话不存在",
+		Status:  http.StatusNotFound,
+	}
+
+	ErrSessionRevoked = &AppError{
+		Message: "登录会话已失效，请重新登录",
+		Status:  http.StatusUnauthorized,
+	}
+)
@@ -0,0 +1,86 @@
+package health
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// CheckFunc 就绪检查函数，返回nil表示依赖可用
+type CheckFunc func(ctx context.Context) error
+
+// CheckResult 单项就绪检查结果，Err 可能包含内部地址等信息，只写入日志不返回给客户端
+type CheckResult struct {
+	Name       string `json:"name"`
+	Healthy    bool   `json:"healthy"`
+	Err        error  `json:"-"`
+	DurationMs int64  `json:"duration_ms"`
+}
+
+// Registry 就绪检查注册表，各子系统通过 Register 注册自身依赖的检查
+type Registry struct {
+	mu       sync.RWMutex
+	names    []string
+	checks   map[string]CheckFunc
+	draining atomic.Bool
+}
+
+func NewRegistry() *Registry {
+	return &Registry{checks: make(map[string]CheckFunc)}
+}
+
+// Register 注册就绪检查，同名检查会被覆盖
+func (r *Registry) Register(name string, check CheckFunc) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, exists := r.checks[name]; !exists {
+		r.names = append(r.names, name)
+	}
+	r.checks[name] = check
+}
+
+// MarkDraining 标记服务正在关闭，此后就绪检查始终失败，使负载均衡尽快摘除流量
+func (r *Registry) MarkDraining() {
+	r.draining.Store(true)
+}
+
+// Draining 服务是否正在关闭
+func (r *Registry) Draining() bool {
+	return r.draining.Load()
+}
+
+// Check 并发执行所有就绪检查，按注册顺序返回结果
+func (r *Registry) Check(ctx context.Context) ([]CheckResult, bool) {
+	r.mu.RLock()
+	names := append([]string(nil), r.names...)
+	checks := make([]CheckFunc, len(names))
+	for i, name := range names {
+		checks[i] = r.checks[name]
+	}
+	r.mu.RUnlock()
+
+	results := make([]CheckResult, len(names))
+	var wg sync.WaitGroup
+	for i := range names {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			start := time.Now()
+			err := checks[i](ctx)
+			results[i] = CheckResult{
+				Name:       names[i],
+				Healthy:    err == nil,
+				Err:        err,
+				DurationMs: time.Since(start).Milliseconds(),
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	ready := !r.Draining()
+	for _, result := range results {
+		ready = ready && result.Healthy
+	}
+	return results, ready
+}
@@ -5,50 +5,84 @@ import (
 	appErrors "TeamTickBackend/pkg/errors"
 	"errors"
 	"fmt"
-	"log"
-	"strings"
+	"log/slog"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 )
 
+func init() {
+	// 签发时间精确到毫秒，用户级吊销按签发时间判断，需要区分同一秒内吊销前后签发的令牌
+	jwt.TimePrecision = time.Millisecond
+}
+
 type JwtHandler interface {
-	GenerateJWTToken(username string, userID int) (string, error)
+	// GenerateJWTToken 签发访问令牌，sessionID 为可选的登录会话ID（刷新令牌家族）
+	GenerateJWTToken(username string, userID int, sessionID ...string) (string, error)
 	ParseJWTToken(tokenString string) (JwtPayload, error)
+	// JWKS 当前公开的验证公钥，供其它服务校验本服务签发的令牌
+	JWKS() JSONWebKeySet
+}
+
+// RevocationChecker 判断令牌是否已被吊销，ParseJWTToken 在签名与有效期校验通过后调用
+type RevocationChecker interface {
+	IsRevoked(payload JwtPayload) bool
 }
 
 type JwtTokenImpl struct {
-	jwtConfig *config.JWTConfig
+	jwtConfig  *config.JWTConfig
+	keys       *keySet
+	revocation RevocationChecker
+	log        *slog.Logger
 }
 
-func NewJwtHandler() (JwtHandler, error) {
-	jwtConfig, err := config.GetJWTConfig()
+// revocation 为nil时不检查吊销状态
+func NewJwtHandler(jwtConfig *config.JWTConfig, revocation RevocationChecker, log *slog.Logger) (JwtHandler, error) {
+	if jwtConfig == nil || (jwtConfig.SecretKey == "" && len(jwtConfig.Keys) == 0) {
+		return nil, appErrors.ErrTokenConfigMissing
+	}
+	keys, err := newKeySet(jwtConfig)
 	if err != nil {
-		return nil, appErrors.ErrTokenConfigMissing.WithError(err)
+		return nil, err
 	}
 	return &JwtTokenImpl{
-		jwtConfig: jwtConfig,
+		jwtConfig:  jwtConfig,
+		keys:       keys,
+		revocation: revocation,
+		log:        log,
 	}, nil
 }
 
 type TokenClaims struct {
-	Username string `json:"username"`
-	UserID   int    `json:"user_id"`
+	Username  string `json:"username"`
+	UserID    int    `json:"user_id"`
+	SessionID string `json:"sid,omitempty"`
 	jwt.RegisteredClaims
 }
 
 type JwtPayload struct {
 	Username string
 	UserID   int
+	// TokenID 令牌唯一标识（jti）
+	TokenID string
+	// SessionID 登录会话ID，未绑定会话的令牌为空
+	SessionID string
+	IssuedAt  time.Time
+	ExpiresAt time.Time
 }
 
-// 根据hs256算法以及用户id、用户名生成jwt
-func (s *JwtTokenImpl) GenerateJWTToken(username string, userID int) (string, error) {
+// 使用当前生效的签名密钥生成jwt，非对称密钥在头部写入kid；未配置非对称密钥时使用hs256共享密钥
+func (s *JwtTokenImpl) GenerateJWTToken(username string, userID int, sessionID ...string) (string, error) {
+	tokenID, err := GenerateOpaqueToken()
+	if err != nil {
+		return "", fmt.Errorf("failed to generate JWT token id: %w", err)
+	}
 	now := time.Now()
 	claims := TokenClaims{
 		Username: username,
 		UserID:   userID,
 		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        tokenID,
 			ExpiresAt: jwt.NewNumericDate(now.Add(s.jwtConfig.TokenExpiry)),
 			IssuedAt:  jwt.NewNumericDate(now),
 			NotBefore: jwt.NewNumericDate(now),
@@ -56,32 +90,38 @@ func (s *JwtTokenImpl) GenerateJWTToken(username string, userID int) (string, er
 			Subject:   fmt.Sprintf("user:%d", userID),
 		},
 	}
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	signedToken, err := token.SignedString(s.jwtConfig.SecretKey)
+	if len(sessionID) > 0 {
+		claims.SessionID = sessionID[0]
+	}
+	kid, method, key, err := s.keys.signer(now)
 	if err != nil {
-		log.Printf("invalid JWT signature: %v", err)
+		s.log.Error("no jwt signing key", slog.String("error", err.Error()))
+		return "", fmt.Errorf("failed to generate JWT token: %w", err)
+	}
+	token := jwt.NewWithClaims(method, claims)
+	if kid != "" {
+		token.Header["kid"] = kid
+	}
+	signedToken, err := token.SignedString(key)
+	if err != nil {
+		s.log.Error("sign jwt failed", slog.Int("user_id", userID), slog.String("error", err.Error()))
 		return "", fmt.Errorf("failed to generate JWT token: %w", err)
 	}
 	return signedToken, nil
 }
 
-// 解析JWT，错误日志处理待完善
+// 解析JWT
 func (s *JwtTokenImpl) ParseJWTToken(tokenString string) (JwtPayload, error) {
-	tokenString = strings.TrimSpace(tokenString)
-	if len(tokenString) > 7 && strings.ToUpper(tokenString[:7]) == "BEARER " {
-		tokenString = tokenString[7:]
-	}
+	tokenString = TrimBearer(tokenString)
 
 	token, err := jwt.ParseWithClaims(tokenString, &TokenClaims{}, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected token signing method: %v", token.Header["alg"])
-		}
-		return s.jwtConfig.SecretKey, nil
+		return s.keys.verificationKey(token, time.Now())
 	})
 
 	//错误解析
 	jwtErrPayload := JwtPayload{}
 	if err != nil {
+		s.log.Debug("parse jwt failed", slog.String("error", err.Error()))
 		if errors.Is(err, jwt.ErrTokenMalformed) {
 			return jwtErrPayload, jwt.ErrTokenMalformed
 		} else if errors.Is(err, jwt.ErrTokenUnverifiable) {
@@ -105,8 +145,26 @@ func (s *JwtTokenImpl) ParseJWTToken(tokenString string) (JwtPayload, error) {
 	if claims.NotBefore != nil && now.Before(claims.NotBefore.Time) {
 		return jwtErrPayload, jwt.ErrTokenNotValidYet
 	}
-	return JwtPayload{
-		Username: claims.Username,
-		UserID:   claims.UserID,
-	}, nil
+	payload := JwtPayload{
+		Username:  claims.Username,
+		UserID:    claims.UserID,
+		TokenID:   claims.ID,
+		SessionID: claims.SessionID,
+	}
+	if claims.IssuedAt != nil {
+		payload.IssuedAt = claims.IssuedAt.Time
+	}
+	if claims.ExpiresAt != nil {
+		payload.ExpiresAt = claims.ExpiresAt.Time
+	}
+	if s.revocation != nil && s.revocation.IsRevoked(payload) {
+		s.log.Debug("revoked jwt rejected", slog.Int("user_id", payload.UserID), slog.String("jti", payload.TokenID))
+		return jwtErrPayload, appErrors.ErrTokenRevoked
+	}
+	return payload, nil
+}
+
+// JWKS 公开全部未过期的非对称公钥，HS256共享密钥不公开
+func (s *JwtTokenImpl) JWKS() JSONWebKeySet {
+	return s.keys.jwks(time.Now())
 }
@@ -0,0 +1,234 @@
+package pkg
+
+import (
+	"TeamTickBackend/config"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// RSA密钥的最小长度
+const minRSAKeyBits = 2048
+
+// JSONWebKey RFC 7517 公钥，RSA 使用 n/e，Ed25519 使用 crv/x
+type JSONWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+}
+
+// JSONWebKeySet /.well-known/jwks.json 的响应
+type JSONWebKeySet struct {
+	Keys []JSONWebKey `json:"keys"`
+}
+
+// signingKey 一个非对称签名密钥，private 为nil时仅用于校验
+type signingKey struct {
+	id         string
+	method     jwt.SigningMethod
+	private    crypto.Signer
+	public     crypto.PublicKey
+	activeFrom time.Time
+	expiresAt  time.Time
+}
+
+func (k *signingKey) expired(now time.Time) bool {
+	return !k.expiresAt.IsZero() && !now.Before(k.expiresAt)
+}
+
+// keySet 令牌签名与校验使用的全部密钥
+type keySet struct {
+	// keys 按 activeFrom 升序
+	keys []*signingKey
+	// secret HS256共享密钥，用于签发（未配置非对称密钥时）或校验不带kid的旧令牌
+	secret []byte
+}
+
+func newKeySet(cfg *config.JWTConfig) (*keySet, error) {
+	set := &keySet{}
+	if cfg.SecretKey != "" {
+		set.secret = []byte(cfg.SecretKey)
+	}
+	for _, keyCfg := range cfg.Keys {
+		key, err := loadSigningKey(keyCfg)
+		if err != nil {
+			return nil, fmt.Errorf("load jwt key %q: %w", keyCfg.KID, err)
+		}
+		set.keys = append(set.keys, key)
+	}
+	sort.SliceStable(set.keys, func(i, j int) bool {
+		return set.keys[i].activeFrom.Before(set.keys[j].activeFrom)
+	})
+	return set, nil
+}
+
+// signer 返回当前用于签发的密钥：已生效且未过期、ActiveFrom 最晚的私钥；没有时回退到HS256共享密钥（kid为空）
+func (s *keySet) signer(now time.Time) (kid string, method jwt.SigningMethod, key any, err error) {
+	for i := len(s.keys) - 1; i >= 0; i-- {
+		k := s.keys[i]
+		if k.private != nil && !now.Before(k.activeFrom) && !k.expired(now) {
+			return k.id, k.method, k.private, nil
+		}
+	}
+	if s.secret != nil {
+		return "", jwt.SigningMethodHS256, s.secret, nil
+	}
+	return "", nil, nil, errors.New("no active jwt signing key")
+}
+
+// verificationKey 按令牌头部的kid选择公钥，并要求签名算法与密钥一致；不带kid的令牌只能用HS256共享密钥校验
+func (s *keySet) verificationKey(token *jwt.Token, now time.Time) (any, error) {
+	kid, _ := token.Header["kid"].(string)
+	if kid == "" {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok || s.secret == nil {
+			return nil, fmt.Errorf("unexpected token signing method: %v", token.Header["alg"])
+		}
+		return s.secret, nil
+	}
+	for _, k := range s.keys {
+		if k.id != kid {
+			continue
+		}
+		if k.expired(now) {
+			return nil, fmt.Errorf("jwt key %q has expired", kid)
+		}
+		if token.Method.Alg() != k.method.Alg() {
+			return nil, fmt.Errorf("unexpected token signing method %v for key %q", token.Header["alg"], kid)
+		}
+		return k.public, nil
+	}
+	return nil, fmt.Errorf("unknown jwt key %q", kid)
+}
+
+// jwks 公开全部未过期的公钥，包括尚未生效的密钥
+func (s *keySet) jwks(now time.Time) JSONWebKeySet {
+	set := JSONWebKeySet{Keys: []JSONWebKey{}}
+	for _, k := range s.keys {
+		if k.expired(now) {
+			continue
+		}
+		jwk := JSONWebKey{Kid: k.id, Use: "sig", Alg: k.method.Alg()}
+		switch public := k.public.(type) {
+		case *rsa.PublicKey:
+			jwk.Kty = "RSA"
+			jwk.N = base64.RawURLEncoding.EncodeToString(public.N.Bytes())
+			jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(public.E)).Bytes())
+		case ed25519.PublicKey:
+			jwk.Kty = "OKP"
+			jwk.Crv = "Ed25519"
+			jwk.X = base64.RawURLEncoding.EncodeToString(public)
+		}
+		set.Keys = append(set.Keys, jwk)
+	}
+	return set
+}
+
+func loadSigningKey(cfg config.JWTKeyConfig) (*signingKey, error) {
+	key := &signingKey{id: cfg.KID, activeFrom: cfg.ActiveFrom, expiresAt: cfg.ExpiresAt}
+	switch cfg.Algorithm {
+	case "RS256":
+		key.method = jwt.SigningMethodRS256
+	case "EdDSA":
+		key.method = jwt.SigningMethodEdDSA
+	default:
+		return nil, fmt.Errorf("unsupported algorithm %q", cfg.Algorithm)
+	}
+
+	if cfg.PrivateKeyFile != "" {
+		block, err := readPEM(cfg.PrivateKeyFile)
+		if err != nil {
+			return nil, err
+		}
+		var parsed any
+		if block.Type == "RSA PRIVATE KEY" {
+			parsed, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+		} else {
+			parsed, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("parse private key: %w", err)
+		}
+		signer, ok := parsed.(crypto.Signer)
+		if !ok {
+			return nil, fmt.Errorf("unsupported private key type %T", parsed)
+		}
+		key.private = signer
+		key.public = signer.Public()
+	} else {
+		block, err := readPEM(cfg.PublicKeyFile)
+		if err != nil {
+			return nil, err
+		}
+		if key.public, err = x509.ParsePKIXPublicKey(block.Bytes); err != nil {
+			return nil, fmt.Errorf("parse public key: %w", err)
+		}
+	}
+
+	switch public := key.public.(type) {
+	case *rsa.PublicKey:
+		if key.method != jwt.SigningMethodRS256 {
+			return nil, errors.New("RSA key requires algorithm RS256")
+		}
+		if public.N.BitLen() < minRSAKeyBits {
+			return nil, fmt.Errorf("RSA key must be at least %d bits", minRSAKeyBits)
+		}
+	case ed25519.PublicKey:
+		if key.method != jwt.SigningMethodEdDSA {
+			return nil, errors.New("Ed25519 key requires algorithm EdDSA")
+		}
+	default:
+		return nil, fmt.Errorf("unsupported key type %T", key.public)
+	}
+	return key, nil
+}
+
+func readPEM(path string) (*pem.Block, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("%s: no PEM data found", path)
+	}
+	return block, nil
+}
+
+// GenerateSigningKey 生成新的签名私钥，返回PKCS#8 PEM
+func GenerateSigningKey(algorithm string) ([]byte, error) {
+	var private any
+	var err error
+	switch algorithm {
+	case "RS256":
+		private, err = rsa.GenerateKey(rand.Reader, 3072)
+	case "EdDSA":
+		_, private, err = ed25519.GenerateKey(rand.Reader)
+	default:
+		return nil, fmt.Errorf("unsupported algorithm %q, use RS256 or EdDSA", algorithm)
+	}
+	if err != nil {
+		return nil, err
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(private)
+	if err != nil {
+		return nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), nil
+}
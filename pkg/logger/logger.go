@@ -0,0 +1,147 @@
+package logger
+
+import (
+	"TeamTickBackend/config"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+	"sync/atomic"
+)
+
+// RequestIDKey 请求ID在 gin.Context 中的键，请求上下文使用 WithRequestID 写入
+const RequestIDKey = "requestID"
+
+// ctxKey 请求ID在标准库 Context 中的键，私有类型避免与其它包的键冲突
+type ctxKey struct{}
+
+// WithRequestID 返回带有请求ID的上下文
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, ctxKey{}, requestID)
+}
+
+// Logger 基于slog的结构化日志，按模块设置日志级别
+type Logger struct {
+	handler      slog.Handler
+	defaultLevel slog.Level
+	levels       map[string]slog.Level
+}
+
+// New 根据日志配置创建Logger，format为json时输出JSON，否则输出key=value文本
+func New(cfg config.LogConfig, w io.Writer) (*Logger, error) {
+	defaultLevel, err := ParseLevel(cfg.Level)
+	if err != nil {
+		return nil, err
+	}
+	levels := make(map[string]slog.Level, len(cfg.Modules))
+	for module, value := range cfg.Modules {
+		level, err := ParseLevel(value)
+		if err != nil {
+			return nil, fmt.Errorf("log.modules.%s: %w", module, err)
+		}
+		levels[module] = level
+	}
+
+	// 底层handler输出所有级别，由模块handler按模块级别过滤
+	options := &slog.HandlerOptions{Level: slog.LevelDebug}
+	var handler slog.Handler
+	if cfg.Format == "json" {
+		handler = slog.NewJSONHandler(w, options)
+	} else {
+		handler = slog.NewTextHandler(w, options)
+	}
+	return &Logger{handler: handler, defaultLevel: defaultLevel, levels: levels}, nil
+}
+
+// Module 返回指定模块的logger，日志中带有 module 字段并使用该模块的级别
+func (l *Logger) Module(name string) *slog.Logger {
+	level, ok := l.levels[name]
+	if !ok {
+		level = l.defaultLevel
+	}
+	return slog.New(&moduleHandler{
+		next:  l.handler.WithAttrs([]slog.Attr{slog.String("module", name)}),
+		level: level,
+	})
+}
+
+// Enabled 指定模块在该级别是否输出日志
+func (l *Logger) Enabled(module string, level slog.Level) bool {
+	moduleLevel, ok := l.levels[module]
+	if !ok {
+		moduleLevel = l.defaultLevel
+	}
+	return level >= moduleLevel
+}
+
+// ParseLevel 解析 debug/info/warn/error
+func ParseLevel(value string) (slog.Level, error) {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(strings.ToLower(value))); err != nil {
+		return slog.LevelInfo, fmt.Errorf("invalid log level %q", value)
+	}
+	return level, nil
+}
+
+// moduleHandler 按模块级别过滤，并从上下文中补充请求ID
+type moduleHandler struct {
+	next  slog.Handler
+	level slog.Level
+}
+
+func (h *moduleHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return level >= h.level
+}
+
+func (h *moduleHandler) Handle(ctx context.Context, record slog.Record) error {
+	if requestID := RequestIDFromContext(ctx); requestID != "" {
+		record.AddAttrs(slog.String("request_id", requestID))
+	}
+	return h.next.Handle(ctx, record)
+}
+
+func (h *moduleHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &moduleHandler{next: h.next.WithAttrs(attrs), level: h.level}
+}
+
+func (h *moduleHandler) WithGroup(name string) slog.Handler {
+	return &moduleHandler{next: h.next.WithGroup(name), level: h.level}
+}
+
+// RequestIDFromContext 读取请求ID，兼容 gin.Context 与标准库 Context
+func RequestIDFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	if requestID, ok := ctx.Value(ctxKey{}).(string); ok {
+		return requestID
+	}
+	// gin.Context 以字符串键读取 c.Set 存入的值
+	requestID, _ := ctx.Value(RequestIDKey).(string)
+	return requestID
+}
+
+var defaultLogger atomic.Pointer[Logger]
+
+func init() {
+	l, _ := New(config.LogConfig{Level: "info", Format: "text"}, os.Stderr)
+	defaultLogger.Store(l)
+}
+
+// SetDefault 设置全局Logger，同时替换slog的默认logger
+func SetDefault(l *Logger) {
+	defaultLogger.Store(l)
+	slog.SetDefault(l.Module("app"))
+}
+
+// Default 返回全局Logger
+func Default() *Logger {
+	return defaultLogger.Load()
+}
+
+// Module 使用全局Logger创建模块logger，供无法通过构造函数注入的代码使用
+func Module(name string) *slog.Logger {
+	return Default().Module(name)
+}
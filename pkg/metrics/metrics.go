@@ -0,0 +1,174 @@
+package metrics
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "teamtick"
+
+// 结果标签取值
+const (
+	ResultSuccess = "success"
+	ResultFailure = "failure"
+	// ResultLocked 登录因失败次数过多被拒绝
+	ResultLocked = "locked"
+	// ResultMFARequired 密码校验通过，等待提交两步验证码
+	ResultMFARequired = "mfa_required"
+)
+
+// 审核类事件标签取值
+const (
+	EventCreated  = "created"
+	EventApproved = "approved"
+	EventRejected = "rejected"
+)
+
+// Metrics 应用的全部Prometheus指标，使用独立的Registry，通过 AppContainer 注入
+type Metrics struct {
+	registry *prometheus.Registry
+
+	httpDuration     *prometheus.HistogramVec
+	httpInFlight     prometheus.Gauge
+	txDuration       *prometheus.HistogramVec
+	checkIns         *prometheus.CounterVec
+	verifications    *prometheus.CounterVec
+	auditRequests    *prometheus.CounterVec
+	joinApplications *prometheus.CounterVec
+	logins           *prometheus.CounterVec
+}
+
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		httpDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "HTTP请求耗时，route为注册的路由模板",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route", "status"}),
+		httpInFlight: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "http_requests_in_flight",
+			Help:      "正在处理的HTTP请求数",
+		}),
+		txDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "db_transaction_duration_seconds",
+			Help:      "WithTransaction 事务耗时，result为commit或rollback",
+			Buckets:   []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+		}, []string{"result"}),
+		checkIns: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "checkins_total",
+			Help:      "签到次数，按任务启用的校验方式与结果统计",
+		}, []string{"method", "result"}),
+		verifications: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "checkin_verifications_total",
+			Help:      "签到校验次数，按校验方式与结果统计",
+		}, []string{"method", "result"}),
+		auditRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "audit_requests_total",
+			Help:      "签到审核申请事件数（created/approved/rejected）",
+		}, []string{"event"}),
+		joinApplications: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "join_applications_total",
+			Help:      "加入用户组申请事件数（created/approved/rejected）",
+		}, []string{"event"}),
+		logins: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "login_attempts_total",
+			Help:      "登录尝试次数（success/failure/locked/mfa_required）",
+		}, []string{"result"}),
+	}
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.httpDuration,
+		m.httpInFlight,
+		m.txDuration,
+		m.checkIns,
+		m.verifications,
+		m.auditRequests,
+		m.joinApplications,
+		m.logins,
+	)
+	return m
+}
+
+// Handler 暴露 /metrics
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{Registry: m.registry})
+}
+
+// RegisterDB 采集连接池状态（sqlDB.Stats()）
+func (m *Metrics) RegisterDB(name string, db *sql.DB) {
+	m.registry.MustRegister(collectors.NewDBStatsCollector(db, name))
+}
+
+// HTTPStarted 请求开始，返回请求结束时调用的回调
+func (m *Metrics) HTTPStarted() func(method, route string, status int) {
+	start := time.Now()
+	m.httpInFlight.Inc()
+	return func(method, route string, status int) {
+		m.httpInFlight.Dec()
+		m.httpDuration.WithLabelValues(method, route, strconv.Itoa(status)).Observe(time.Since(start).Seconds())
+	}
+}
+
+// ObserveTransaction 记录事务耗时，实现 dao.TransactionObserver
+func (m *Metrics) ObserveTransaction(_ context.Context, duration time.Duration, err error) {
+	result := "commit"
+	if err != nil {
+		result = "rollback"
+	}
+	m.txDuration.WithLabelValues(result).Observe(duration.Seconds())
+}
+
+// CheckIn 记录一次签到，methods为任务启用的校验方式，未启用任何校验时记为none
+func (m *Metrics) CheckIn(methods []string, result string) {
+	if len(methods) == 0 {
+		methods = []string{"none"}
+	}
+	for _, method := range methods {
+		m.checkIns.WithLabelValues(method, result).Inc()
+	}
+}
+
+// Verification 记录一次签到校验
+func (m *Metrics) Verification(method string, result string) {
+	m.verifications.WithLabelValues(method, result).Inc()
+}
+
+// AuditRequest 记录签到审核申请事件
+func (m *Metrics) AuditRequest(event string) {
+	m.auditRequests.WithLabelValues(event).Inc()
+}
+
+// JoinApplication 记录加入申请事件
+func (m *Metrics) JoinApplication(event string) {
+	m.joinApplications.WithLabelValues(event).Inc()
+}
+
+// Login 记录一次登录尝试
+func (m *Metrics) Login(result string) {
+	m.logins.WithLabelValues(result).Inc()
+}
+
+// Result 将布尔结果转换为result标签
+func Result(ok bool) string {
+	if ok {
+		return ResultSuccess
+	}
+	return ResultFailure
+}
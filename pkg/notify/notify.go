@@ -0,0 +1,78 @@
+package notify
+
+import (
+	"TeamTickBackend/config"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+)
+
+// PasswordResetMessage 重置密码通知，Token 为明文令牌，只在投递时出现
+type PasswordResetMessage struct {
+	UserID    int       `json:"user_id"`
+	Username  string    `json:"username"`
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// Sender 通知投递接口，接入邮件、短信等渠道时新增实现并在 NewSender 中注册
+type Sender interface {
+	SendPasswordReset(ctx context.Context, message PasswordResetMessage) error
+}
+
+// NewSender 按配置创建投递实现
+func NewSender(cfg config.PasswordResetConfig, log *slog.Logger) (Sender, error) {
+	switch cfg.Sender {
+	case "log":
+		return &LogSender{log: log}, nil
+	case "file":
+		return &FileSender{path: cfg.FilePath}, nil
+	default:
+		return nil, fmt.Errorf("unknown notification sender %q", cfg.Sender)
+	}
+}
+
+// LogSender 将通知写入日志，仅用于本地开发
+type LogSender struct {
+	log *slog.Logger
+}
+
+func (s *LogSender) SendPasswordReset(ctx context.Context, message PasswordResetMessage) error {
+	s.log.InfoContext(ctx, "password reset requested",
+		slog.Int("user_id", message.UserID),
+		slog.String("username", message.Username),
+		slog.String("token", message.Token),
+		slog.Time("expires_at", message.ExpiresAt))
+	return nil
+}
+
+// FileSender 将通知以JSON行追加到文件，便于开发与联调时由脚本读取
+type FileSender struct {
+	path string
+	mu   sync.Mutex
+}
+
+func (s *FileSender) SendPasswordReset(ctx context.Context, message PasswordResetMessage) error {
+	line, err := json.Marshal(struct {
+		Type string `json:"type"`
+		PasswordResetMessage
+	}{Type: "password_reset", PasswordResetMessage: message})
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	file, err := os.OpenFile(s.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return fmt.Errorf("open %s: %w", s.path, err)
+	}
+	defer file.Close()
+	if _, err := file.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("write %s: %w", s.path, err)
+	}
+	return nil
+}
@@ -0,0 +1,302 @@
+// Package oidctest 提供用于测试与本地开发的模拟 OpenID Connect 身份提供方
+//
+// 授权端点不显示登录页面，直接以当前用户（或请求中 login_hint 指定的用户）同意授权并重定向回客户端；
+// 令牌端点校验授权码、redirect_uri 与 PKCE（仅支持 S256），签发 RS256 签名的 ID Token
+package oidctest
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	keyID         = "mock"
+	codeExpiry    = time.Minute
+	idTokenExpiry = 5 * time.Minute
+)
+
+// User 授权时使用的用户，Claims 为写入 ID Token 的附加声明
+type User struct {
+	Subject string
+	Claims  map[string]any
+}
+
+// authRequest 授权码对应的授权请求
+type authRequest struct {
+	user          User
+	clientID      string
+	redirectURI   string
+	nonce         string
+	codeChallenge string
+	expiresAt     time.Time
+}
+
+// Provider 模拟身份提供方，Issuer 为对外地址，只接受 ClientID 对应的客户端
+type Provider struct {
+	Issuer   string
+	ClientID string
+
+	key   *rsa.PrivateKey
+	mu    sync.Mutex
+	user  User
+	codes map[string]*authRequest
+}
+
+// New 创建模拟身份提供方，默认用户的 subject 为 mock-user
+func New(issuer, clientID string) (*Provider, error) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, err
+	}
+	return &Provider{
+		Issuer:   issuer,
+		ClientID: clientID,
+		key:      key,
+		user:     User{Subject: "mock-user", Claims: map[string]any{"preferred_username": "mock-user"}},
+		codes:    make(map[string]*authRequest),
+	}, nil
+}
+
+// Server 运行在本地随机端口上的模拟身份提供方
+type Server struct {
+	*Provider
+	server *httptest.Server
+}
+
+// NewServer 在本地随机端口启动模拟身份提供方，用完后调用 Close
+func NewServer(clientID string) (*Server, error) {
+	server := httptest.NewUnstartedServer(nil)
+	provider, err := New("http://"+server.Listener.Addr().String(), clientID)
+	if err != nil {
+		server.Close()
+		return nil, err
+	}
+	server.Config.Handler = provider.Handler()
+	server.Start()
+	return &Server{Provider: provider, server: server}, nil
+}
+
+// Close 停止服务
+func (s *Server) Close() {
+	s.server.Close()
+}
+
+// ListenAndServe 在指定地址上运行模拟身份提供方，用于本地开发
+func ListenAndServe(addr, issuer, clientID string) error {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	provider, err := New(issuer, clientID)
+	if err != nil {
+		listener.Close()
+		return err
+	}
+	return http.Serve(listener, provider.Handler())
+}
+
+// SetUser 设置之后的授权请求使用的用户
+func (p *Provider) SetUser(user User) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.user = user
+}
+
+// Authorize 代替浏览器访问授权地址，返回重定向中携带的授权码和 state
+func (p *Provider) Authorize(authorizationURL string) (code, state string, err error) {
+	client := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}}
+	resp, err := client.Get(authorizationURL)
+	if err != nil {
+		return "", "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusFound {
+		return "", "", fmt.Errorf("authorize: unexpected status %d", resp.StatusCode)
+	}
+	location, err := url.Parse(resp.Header.Get("Location"))
+	if err != nil {
+		return "", "", err
+	}
+	query := location.Query()
+	if query.Get("error") != "" {
+		return "", "", fmt.Errorf("authorize: %s", query.Get("error"))
+	}
+	return query.Get("code"), query.Get("state"), nil
+}
+
+// Handler 身份提供方的HTTP端点
+func (p *Provider) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /.well-known/openid-configuration", p.handleDiscovery)
+	mux.HandleFunc("GET /jwks", p.handleJWKS)
+	mux.HandleFunc("GET /authorize", p.handleAuthorize)
+	mux.HandleFunc("POST /token", p.handleToken)
+	return mux
+}
+
+func (p *Provider) handleDiscovery(w http.ResponseWriter, r *http.Request) {
+	base := strings.TrimSuffix(p.Issuer, "/")
+	writeJSON(w, http.StatusOK, map[string]any{
+		"issuer":                                p.Issuer,
+		"authorization_endpoint":                base + "/authorize",
+		"token_endpoint":                        base + "/token",
+		"jwks_uri":                              base + "/jwks",
+		"response_types_supported":              []string{"code"},
+		"subject_types_supported":               []string{"public"},
+		"id_token_signing_alg_values_supported": []string{"RS256"},
+		"code_challenge_methods_supported":      []string{"S256"},
+	})
+}
+
+func (p *Provider) handleJWKS(w http.ResponseWriter, r *http.Request) {
+	pub := p.key.PublicKey
+	writeJSON(w, http.StatusOK, map[string]any{
+		"keys": []map[string]string{{
+			"kty": "RSA",
+			"alg": "RS256",
+			"use": "sig",
+			"kid": keyID,
+			"n":   base64.RawURLEncoding.EncodeToString(pub.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes()),
+		}},
+	})
+}
+
+func (p *Provider) handleAuthorize(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	redirectURI, err := url.Parse(query.Get("redirect_uri"))
+	if err != nil || redirectURI.Scheme == "" {
+		http.Error(w, "invalid redirect_uri", http.StatusBadRequest)
+		return
+	}
+	if query.Get("client_id") != p.ClientID {
+		http.Error(w, "unknown client_id", http.StatusBadRequest)
+		return
+	}
+	redirect := func(params url.Values) {
+		params.Set("state", query.Get("state"))
+		redirectURI.RawQuery = params.Encode()
+		http.Redirect(w, r, redirectURI.String(), http.StatusFound)
+	}
+	if query.Get("response_type") != "code" {
+		redirect(url.Values{"error": {"unsupported_response_type"}})
+		return
+	}
+	if query.Get("code_challenge_method") != "S256" || query.Get("code_challenge") == "" {
+		redirect(url.Values{"error": {"invalid_request"}, "error_description": {"S256 code_challenge is required"}})
+		return
+	}
+
+	p.mu.Lock()
+	user := p.user
+	if hint := query.Get("login_hint"); hint != "" {
+		user = User{Subject: hint, Claims: map[string]any{"preferred_username": hint}}
+	}
+	code := randomString()
+	p.codes[code] = &authRequest{
+		user:          user,
+		clientID:      p.ClientID,
+		redirectURI:   query.Get("redirect_uri"),
+		nonce:         query.Get("nonce"),
+		codeChallenge: query.Get("code_challenge"),
+		expiresAt:     time.Now().Add(codeExpiry),
+	}
+	p.mu.Unlock()
+	redirect(url.Values{"code": {code}})
+}
+
+func (p *Provider) handleToken(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		tokenError(w, "invalid_request")
+		return
+	}
+	clientID, _, ok := r.BasicAuth()
+	if !ok {
+		clientID = r.PostForm.Get("client_id")
+	}
+	if r.PostForm.Get("grant_type") != "authorization_code" {
+		tokenError(w, "unsupported_grant_type")
+		return
+	}
+
+	// 授权码只能使用一次，无论校验是否通过都作废
+	p.mu.Lock()
+	request := p.codes[r.PostForm.Get("code")]
+	delete(p.codes, r.PostForm.Get("code"))
+	p.mu.Unlock()
+	if request == nil || time.Now().After(request.expiresAt) ||
+		request.clientID != clientID || request.redirectURI != r.PostForm.Get("redirect_uri") {
+		tokenError(w, "invalid_grant")
+		return
+	}
+	challenge := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+	if base64.RawURLEncoding.EncodeToString(challenge[:]) != request.codeChallenge {
+		tokenError(w, "invalid_grant")
+		return
+	}
+
+	idToken, err := p.signIDToken(request)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"access_token": randomString(),
+		"token_type":   "Bearer",
+		"expires_in":   int(idTokenExpiry / time.Second),
+		"id_token":     idToken,
+	})
+}
+
+func (p *Provider) signIDToken(request *authRequest) (string, error) {
+	now := time.Now()
+	claims := jwt.MapClaims{}
+	for name, value := range request.user.Claims {
+		claims[name] = value
+	}
+	claims["iss"] = p.Issuer
+	claims["sub"] = request.user.Subject
+	claims["aud"] = request.clientID
+	claims["iat"] = now.Unix()
+	claims["exp"] = now.Add(idTokenExpiry).Unix()
+	if request.nonce != "" {
+		claims["nonce"] = request.nonce
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = keyID
+	return token.SignedString(p.key)
+}
+
+func tokenError(w http.ResponseWriter, code string) {
+	writeJSON(w, http.StatusBadRequest, map[string]string{"error": code})
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func randomString() string {
+	buf := make([]byte, 24)
+	if _, err := rand.Read(buf); err != nil {
+		panic(err)
+	}
+	return base64.RawURLEncoding.EncodeToString(buf)
+}
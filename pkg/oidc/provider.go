@@ -0,0 +1,121 @@
+// Package oidc 封装 OpenID Connect 授权码流程（PKCE）中与身份提供方交互的部分
+package oidc
+
+import (
+	"TeamTickBackend/config"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	gooidc "github.com/coreos/go-oidc/v3/oidc"
+	"golang.org/x/oauth2"
+)
+
+// 与身份提供方通信的超时时间
+const requestTimeout = 10 * time.Second
+
+// ErrDiscovery 无法获取身份提供方的配置，通常是网络问题或 issuer_url 配置错误
+var ErrDiscovery = errors.New("oidc: provider discovery failed")
+
+// Claims 已校验签名、签发方、受众和有效期的 ID Token 声明
+type Claims struct {
+	Issuer  string
+	Subject string
+	Nonce   string
+	// Raw 全部声明，用于读取 preferred_username 等可选声明
+	Raw map[string]any
+}
+
+// String 读取字符串类型的声明，不存在或类型不符时返回空字符串
+func (c *Claims) String(name string) string {
+	value, _ := c.Raw[name].(string)
+	return value
+}
+
+// Provider 身份提供方
+type Provider interface {
+	// AuthCodeURL 生成授权地址，携带 state、nonce 和由 codeVerifier 计算的 S256 code_challenge
+	AuthCodeURL(ctx context.Context, state, nonce, codeVerifier string) (string, error)
+	// Exchange 用授权码和 codeVerifier 换取并校验 ID Token，nonce 由调用方比对
+	Exchange(ctx context.Context, code, codeVerifier string) (*Claims, error)
+}
+
+// provider 基于 go-oidc 的实现，首次使用时才进行服务发现，身份提供方暂时不可用不影响服务启动
+type provider struct {
+	cfg    config.OIDCConfig
+	client *http.Client
+
+	mu       sync.Mutex
+	oauth2   *oauth2.Config
+	verifier *gooidc.IDTokenVerifier
+}
+
+// NewProvider 按配置创建身份提供方
+func NewProvider(cfg config.OIDCConfig) Provider {
+	return &provider{
+		cfg:    cfg,
+		client: &http.Client{Timeout: requestTimeout},
+	}
+}
+
+// discover 获取并缓存端点与签名公钥，失败时下次调用重试
+func (p *provider) discover(ctx context.Context) (*oauth2.Config, *gooidc.IDTokenVerifier, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.oauth2 != nil {
+		return p.oauth2, p.verifier, nil
+	}
+	discovered, err := gooidc.NewProvider(p.clientContext(ctx), p.cfg.IssuerURL)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %v", ErrDiscovery, err)
+	}
+	p.oauth2 = &oauth2.Config{
+		ClientID:     p.cfg.ClientID,
+		ClientSecret: p.cfg.ClientSecret,
+		Endpoint:     discovered.Endpoint(),
+		RedirectURL:  p.cfg.RedirectURL,
+		Scopes:       p.cfg.Scopes,
+	}
+	// go-oidc 只从发现请求的context中保留HTTP客户端，请求结束后公钥集合仍可按需刷新
+	p.verifier = discovered.Verifier(&gooidc.Config{ClientID: p.cfg.ClientID})
+	return p.oauth2, p.verifier, nil
+}
+
+func (p *provider) clientContext(ctx context.Context) context.Context {
+	return gooidc.ClientContext(ctx, p.client)
+}
+
+func (p *provider) AuthCodeURL(ctx context.Context, state, nonce, codeVerifier string) (string, error) {
+	oauth2Config, _, err := p.discover(ctx)
+	if err != nil {
+		return "", err
+	}
+	return oauth2Config.AuthCodeURL(state, gooidc.Nonce(nonce), oauth2.S256ChallengeOption(codeVerifier)), nil
+}
+
+func (p *provider) Exchange(ctx context.Context, code, codeVerifier string) (*Claims, error) {
+	oauth2Config, verifier, err := p.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+	token, err := oauth2Config.Exchange(p.clientContext(ctx), code, oauth2.VerifierOption(codeVerifier))
+	if err != nil {
+		return nil, fmt.Errorf("exchange authorization code: %w", err)
+	}
+	rawIDToken, ok := token.Extra("id_token").(string)
+	if !ok || rawIDToken == "" {
+		return nil, errors.New("token response does not contain an id_token")
+	}
+	idToken, err := verifier.Verify(p.clientContext(ctx), rawIDToken)
+	if err != nil {
+		return nil, fmt.Errorf("verify id_token: %w", err)
+	}
+	claims := &Claims{Issuer: idToken.Issuer, Subject: idToken.Subject, Nonce: idToken.Nonce}
+	if err := idToken.Claims(&claims.Raw); err != nil {
+		return nil, fmt.Errorf("decode id_token claims: %w", err)
+	}
+	return claims, nil
+}
@@ -0,0 +1,230 @@
+package revocation
+
+import (
+	"TeamTickBackend/dal/dao"
+	"TeamTickBackend/dal/models"
+	"TeamTickBackend/pkg"
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	// 请求路径上触发同步时单次查询的超时
+	syncTimeout = 2 * time.Second
+	// 清理数据库中过期记录的间隔
+	purgeInterval = time.Hour
+)
+
+// Store 访问令牌吊销存储，吊销记录写入数据库，校验时只查询进程内缓存
+//
+// 缓存按 syncInterval 从数据库增量同步，因此其它实例（或运维命令）产生的吊销
+// 最多延迟一个同步间隔生效；本实例产生的吊销立即生效。
+// 同步失败时沿用已有缓存并在下个间隔重试，不影响请求处理。
+type Store struct {
+	dao          dao.TokenRevocationDAO
+	tokenExpiry  time.Duration
+	syncInterval time.Duration
+	log          *slog.Logger
+
+	mu       sync.RWMutex
+	tokens   map[string]time.Time // jti -> 过期时间
+	sessions map[string]time.Time // sid -> 过期时间
+	cutoffs  map[int]int64        // userID -> 毫秒时间戳
+	nextSync time.Time
+
+	// syncMu 保证同一时间只有一个同步在进行，synced/lastPurge 仅在持有时读写
+	syncMu    sync.Mutex
+	synced    time.Time
+	lastPurge time.Time
+}
+
+// NewStore tokenExpiry 为访问令牌有效期，决定会话吊销与用户级吊销需要保留多久
+func NewStore(revocationDao dao.TokenRevocationDAO, tokenExpiry, syncInterval time.Duration, log *slog.Logger) *Store {
+	return &Store{
+		dao:          revocationDao,
+		tokenExpiry:  tokenExpiry,
+		syncInterval: syncInterval,
+		log:          log,
+		tokens:       make(map[string]time.Time),
+		sessions:     make(map[string]time.Time),
+		cutoffs:      make(map[int]int64),
+	}
+}
+
+// IsRevoked 实现 pkg.RevocationChecker
+func (s *Store) IsRevoked(payload pkg.JwtPayload) bool {
+	s.syncIfStale()
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if payload.TokenID != "" {
+		if _, ok := s.tokens[payload.TokenID]; ok {
+			return true
+		}
+	}
+	if payload.SessionID != "" {
+		if _, ok := s.sessions[payload.SessionID]; ok {
+			return true
+		}
+	}
+	if cutoff, ok := s.cutoffs[payload.UserID]; ok && payload.IssuedAt.UnixMilli() < cutoff {
+		return true
+	}
+	return false
+}
+
+// RevokeToken 吊销单个访问令牌直到其过期
+func (s *Store) RevokeToken(ctx context.Context, payload pkg.JwtPayload, tx ...*gorm.DB) error {
+	if payload.TokenID == "" {
+		return nil
+	}
+	return s.revoke(ctx, &models.TokenRevocation{
+		Kind:      models.RevocationKindToken,
+		Value:     payload.TokenID,
+		UserID:    payload.UserID,
+		ExpiresAt: payload.ExpiresAt,
+		RevokedAt: time.Now(),
+	}, tx...)
+}
+
+// RevokeSession 吊销登录会话下签发的所有访问令牌
+// 会话的刷新令牌同时被吊销后不会再签发新令牌，记录保留一个访问令牌有效期即可
+func (s *Store) RevokeSession(ctx context.Context, userID int, sessionID string, tx ...*gorm.DB) error {
+	now := time.Now()
+	return s.revoke(ctx, &models.TokenRevocation{
+		Kind:      models.RevocationKindSession,
+		Value:     sessionID,
+		UserID:    userID,
+		ExpiresAt: now.Add(s.tokenExpiry),
+		RevokedAt: now,
+	}, tx...)
+}
+
+// RevokeUser 吊销用户此前签发的所有访问令牌
+func (s *Store) RevokeUser(ctx context.Context, userID int, tx ...*gorm.DB) error {
+	cutoff := &models.UserTokenCutoff{UserID: userID, RevokedBefore: time.Now().UnixMilli()}
+	if err := s.dao.SaveUserCutoff(ctx, cutoff, tx...); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	s.applyCutoff(cutoff)
+	s.mu.Unlock()
+	return nil
+}
+
+func (s *Store) revoke(ctx context.Context, revocation *models.TokenRevocation, tx ...*gorm.DB) error {
+	if err := s.dao.Create(ctx, revocation, tx...); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	s.applyRevocation(revocation)
+	s.mu.Unlock()
+	return nil
+}
+
+// Sync 从数据库增量加载吊销记录，启动时调用一次完成全量加载
+func (s *Store) Sync(ctx context.Context) error {
+	s.syncMu.Lock()
+	defer s.syncMu.Unlock()
+	return s.sync(ctx)
+}
+
+// syncIfStale 缓存过期时同步，已有同步在进行时直接使用当前缓存
+func (s *Store) syncIfStale() {
+	s.mu.RLock()
+	stale := !time.Now().Before(s.nextSync)
+	s.mu.RUnlock()
+	if !stale || !s.syncMu.TryLock() {
+		return
+	}
+	defer s.syncMu.Unlock()
+	ctx, cancel := context.WithTimeout(context.Background(), syncTimeout)
+	defer cancel()
+	if err := s.sync(ctx); err != nil {
+		s.log.Warn("sync token revocations failed", slog.String("error", err.Error()))
+	}
+}
+
+func (s *Store) sync(ctx context.Context) error {
+	now := time.Now()
+	// 失败时同样等待一个间隔再重试，避免数据库故障时每个请求都触发查询
+	s.mu.Lock()
+	s.nextSync = now.Add(s.syncInterval)
+	s.mu.Unlock()
+
+	// 回退一个同步间隔，容忍实例之间的时钟偏差与DATETIME的秒级精度
+	var since time.Time
+	if !s.synced.IsZero() {
+		since = s.synced.Add(-s.syncInterval)
+	}
+	revocations, err := s.dao.ListSince(ctx, since, now)
+	if err != nil {
+		return err
+	}
+	cutoffs, err := s.dao.ListUserCutoffsSince(ctx, since.UnixMilli())
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	for _, revocation := range revocations {
+		s.applyRevocation(revocation)
+	}
+	for _, cutoff := range cutoffs {
+		s.applyCutoff(cutoff)
+	}
+	s.evict(now)
+	s.mu.Unlock()
+	s.synced = now
+
+	if now.Sub(s.lastPurge) >= purgeInterval {
+		s.lastPurge = now
+		if _, err := s.dao.DeleteExpired(ctx, now); err != nil {
+			s.log.Warn("purge expired token revocations failed", slog.String("error", err.Error()))
+		}
+		if _, err := s.dao.DeleteUserCutoffsBefore(ctx, now.Add(-s.tokenExpiry).UnixMilli()); err != nil {
+			s.log.Warn("purge user token cutoffs failed", slog.String("error", err.Error()))
+		}
+	}
+	return nil
+}
+
+// 以下方法需持有 mu 写锁
+
+func (s *Store) applyRevocation(revocation *models.TokenRevocation) {
+	switch revocation.Kind {
+	case models.RevocationKindToken:
+		s.tokens[revocation.Value] = revocation.ExpiresAt
+	case models.RevocationKindSession:
+		s.sessions[revocation.Value] = revocation.ExpiresAt
+	}
+}
+
+func (s *Store) applyCutoff(cutoff *models.UserTokenCutoff) {
+	if cutoff.RevokedBefore > s.cutoffs[cutoff.UserID] {
+		s.cutoffs[cutoff.UserID] = cutoff.RevokedBefore
+	}
+}
+
+// evict 移除已过期的缓存项，缓存大小只与有效期内的吊销数量相关
+func (s *Store) evict(now time.Time) {
+	for jti, expiresAt := range s.tokens {
+		if !expiresAt.After(now) {
+			delete(s.tokens, jti)
+		}
+	}
+	for sid, expiresAt := range s.sessions {
+		if !expiresAt.After(now) {
+			delete(s.sessions, sid)
+		}
+	}
+	oldest := now.Add(-s.tokenExpiry).UnixMilli()
+	for userID, cutoff := range s.cutoffs {
+		if cutoff < oldest {
+			delete(s.cutoffs, userID)
+		}
+	}
+}
@@ -10,34 +10,71 @@ import (
 )
 
 func SetupRouter(container *app.AppContainer) *gin.Engine {
-	router := gin.Default()
-	router.Use(middlewares.RecoverMiddleware())
+	gin.SetMode(container.Config.Server.Mode)
+	router := gin.New()
+	// 为空时不信任任何代理，ClientIP 取连接地址，避免伪造 X-Forwarded-For 绕过按IP的登录限制
+	// 格式已在配置校验中检查
+	if err := router.SetTrustedProxies(container.Config.Server.TrustedProxies); err != nil {
+		panic(err)
+	}
+	router.Use(middlewares.RequestIDMiddleware())
+	if container.Config.Metrics.Enabled {
+		router.Use(middlewares.MetricsMiddleware(container.Metrics))
+	}
+	router.Use(middlewares.AccessLogMiddleware(container.Logger.Module("http")))
+	router.Use(middlewares.RecoverMiddleware(container.Logger.Module("http")))
 	router.Use(middlewares.ResponseMiddleware())
 
+	// 存活与就绪探针，无需鉴权
+	healthHandler := handlers.NewHealthHandler(container)
+	router.GET("/healthz", healthHandler.Healthz)
+	router.GET("/readyz", healthHandler.Readyz)
+	// 访问令牌的验证公钥，供其它服务校验令牌，无需鉴权
+	router.GET("/.well-known/jwks.json", handlers.NewJWKSHandler(container).JWKS)
+	if container.Config.Metrics.Enabled {
+		router.GET(container.Config.Metrics.Path, gin.WrapH(container.Metrics.Handler()))
+	}
+
+	// 认证路由大多无需登录，仅对声明了security的接口（如登出）校验令牌
 	authHandler := handlers.NewAuthHandler(container)
-	gen.RegisterAuthHandlers(router, authHandler)
+	gen.RegisterAuthHandlersWithOptions(router, authHandler, gen.AuthGinServerOptions{
+		Middlewares: []gen.AuthMiddlewareFunc{
+			gen.AuthMiddlewareFunc(middlewares.AuthForSecuredOperations(container.JwtHandler, gen.JWT鉴权Scopes)),
+		},
+	})
+
+	// 需登录的路由同时接受JWT与个人访问令牌，JWT所属的登录会话须未被吊销
+	accessTokens := handlers.NewAccessTokenAuthenticator(container)
+	sessions := handlers.NewSessionTracker(container)
 
 	userHandler := handlers.NewUserHandler(container)
 	userRouter := router.Group("")
-	userRouter.Use(middlewares.AuthMiddleware(container.JwtHandler))
+	userRouter.Use(middlewares.AuthMiddleware(container.JwtHandler, accessTokens, sessions))
 	gen.RegisterUsersHandlers(userRouter, userHandler)
 
 	groupsHandler := handlers.NewGroupsHandler(container)
 	groupsRouter := router.Group("")
-	groupsRouter.Use(middlewares.AuthMiddleware(container.JwtHandler))
+	groupsRouter.Use(middlewares.AuthMiddleware(container.JwtHandler, accessTokens, sessions))
 	gen.RegisterGroupsHandlers(groupsRouter, groupsHandler)
 
 	taskHandler, checkinRecordsHandler := handlers.NewTaskHandler(container)
 	taskRouter := router.Group("")
-	taskRouter.Use(middlewares.AuthMiddleware(container.JwtHandler))
+	taskRouter.Use(middlewares.AuthMiddleware(container.JwtHandler, accessTokens, sessions))
 	gen.RegisterCheckinTasksHandlers(taskRouter, taskHandler)
 	gen.RegisterCheckinRecordsHandlers(taskRouter, checkinRecordsHandler)
 
 	// 注册审核请求相关路由
 	auditRequestHandler := handlers.NewAuditRequestHandler(container)
 	auditRequestRouter := router.Group("")
-	auditRequestRouter.Use(middlewares.AuthMiddleware(container.JwtHandler))
+	auditRequestRouter.Use(middlewares.AuthMiddleware(container.JwtHandler, accessTokens, sessions))
 	gen.RegisterAuditRequestsHandlers(auditRequestRouter, auditRequestHandler)
 
+	// 平台管理接口，仅配置中的平台管理员与平台角色为 super_admin 的用户可以访问
+	adminHandler := handlers.NewAdminHandler(container)
+	adminRouter := router.Group("")
+	adminRouter.Use(middlewares.AuthMiddleware(container.JwtHandler, accessTokens, sessions))
+	adminRouter.Use(middlewares.PlatformAdminMiddleware(handlers.NewPlatformAdminChecker(container)))
+	gen.RegisterAdminHandlers(adminRouter, adminHandler)
+
 	return router
 }
@@ -0,0 +1,177 @@
+package service
+
+import (
+	"TeamTickBackend/config"
+	"TeamTickBackend/dal/dao"
+	"TeamTickBackend/dal/models"
+	"TeamTickBackend/pkg"
+	appErrors "TeamTickBackend/pkg/errors"
+	"context"
+	"errors"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// 距上次记录超过该间隔才更新最近使用时间，避免每次请求都写库
+const accessTokenLastUsedInterval = time.Minute
+
+// 展示给用户辨认令牌的末尾字符数
+const accessTokenHintLength = 4
+
+// AccessTokenService 个人访问令牌：创建、列出、吊销以及请求鉴权
+type AccessTokenService struct {
+	tokenDao dao.PersonalAccessTokenDAO
+	userDao  dao.UserDAO
+	cfg      config.AccessTokensConfig
+	now      func() time.Time
+	log      *slog.Logger
+}
+
+func NewAccessTokenService(
+	tokenDao dao.PersonalAccessTokenDAO,
+	userDao dao.UserDAO,
+	cfg config.AccessTokensConfig,
+	log *slog.Logger,
+) *AccessTokenService {
+	return &AccessTokenService{
+		tokenDao: tokenDao,
+		userDao:  userDao,
+		cfg:      cfg,
+		now:      time.Now,
+		log:      log,
+	}
+}
+
+// 创建令牌，expiresIn 为0时使用默认有效期；返回的明文令牌只出现这一次
+func (s *AccessTokenService) Create(ctx context.Context, userID int, name string, scopes []string, expiresIn time.Duration) (*models.PersonalAccessToken, string, error) {
+	if !s.cfg.Enabled {
+		return nil, "", appErrors.ErrAccessTokensDisabled
+	}
+	normalized, err := normalizeAccessTokenScopes(scopes)
+	if err != nil {
+		return nil, "", err
+	}
+	if expiresIn == 0 {
+		expiresIn = s.cfg.DefaultExpiry
+	}
+	if expiresIn < 0 || expiresIn > s.cfg.MaxExpiry {
+		return nil, "", appErrors.ErrAccessTokenExpiryInvalid
+	}
+
+	now := s.now()
+	active, err := s.tokenDao.CountActiveByUserID(ctx, userID, now)
+	if err != nil {
+		return nil, "", appErrors.ErrDatabaseOperation.WithError(err)
+	}
+	if active >= int64(s.cfg.MaxPerUser) {
+		return nil, "", appErrors.ErrAccessTokenLimitReached
+	}
+
+	random, err := pkg.GenerateOpaqueToken()
+	if err != nil {
+		return nil, "", appErrors.ErrTokenGenerationFailed.WithError(err)
+	}
+	plaintext := pkg.AccessTokenPrefix + random
+	token := &models.PersonalAccessToken{
+		UserID:    userID,
+		Name:      name,
+		TokenHash: pkg.HashOpaqueToken(plaintext),
+		TokenHint: plaintext[len(plaintext)-accessTokenHintLength:],
+		Scopes:    strings.Join(normalized, " "),
+		ExpiresAt: now.Add(expiresIn),
+		CreatedAt: now,
+	}
+	if err := s.tokenDao.Create(ctx, token); err != nil {
+		return nil, "", appErrors.ErrDatabaseOperation.WithError(err)
+	}
+	s.log.InfoContext(ctx, "access token created",
+		slog.Int("user_id", userID), slog.Int("token_id", token.ID), slog.String("scopes", token.Scopes))
+	return token, plaintext, nil
+}
+
+// 列出用户未吊销的令牌，包括已过期的令牌
+func (s *AccessTokenService) List(ctx context.Context, userID int) ([]*models.PersonalAccessToken, error) {
+	tokens, err := s.tokenDao.ListByUserID(ctx, userID)
+	if err != nil {
+		return nil, appErrors.ErrDatabaseOperation.WithError(err)
+	}
+	return tokens, nil
+}
+
+// 吊销令牌，只能吊销自己的令牌
+func (s *AccessTokenService) Revoke(ctx context.Context, userID, tokenID int) error {
+	revoked, err := s.tokenDao.Revoke(ctx, tokenID, userID, s.now())
+	if err != nil {
+		return appErrors.ErrDatabaseOperation.WithError(err)
+	}
+	if !revoked {
+		return appErrors.ErrAccessTokenNotFound
+	}
+	s.log.InfoContext(ctx, "access token revoked", slog.Int("user_id", userID), slog.Int("token_id", tokenID))
+	return nil
+}
+
+// AuthenticateAccessToken 校验请求携带的个人访问令牌，并记录最近使用时间与IP
+func (s *AccessTokenService) AuthenticateAccessToken(ctx context.Context, token, clientIP string) (pkg.AccessTokenIdentity, error) {
+	if !s.cfg.Enabled {
+		return pkg.AccessTokenIdentity{}, appErrors.ErrAccessTokensDisabled
+	}
+	record, err := s.tokenDao.GetByTokenHash(ctx, pkg.HashOpaqueToken(pkg.TrimBearer(token)))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return pkg.AccessTokenIdentity{}, appErrors.ErrAccessTokenInvalid
+		}
+		return pkg.AccessTokenIdentity{}, appErrors.ErrDatabaseOperation.WithError(err)
+	}
+	now := s.now()
+	if record.RevokedAt != nil || !now.Before(record.ExpiresAt) {
+		return pkg.AccessTokenIdentity{}, appErrors.ErrAccessTokenInvalid
+	}
+	user, err := s.userDao.GetByID(ctx, record.UserID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return pkg.AccessTokenIdentity{}, appErrors.ErrAccessTokenInvalid
+		}
+		return pkg.AccessTokenIdentity{}, appErrors.ErrDatabaseOperation.WithError(err)
+	}
+	if user.DisabledAt != nil {
+		return pkg.AccessTokenIdentity{}, appErrors.ErrUserDisabled
+	}
+
+	if record.LastUsedAt == nil || now.Sub(*record.LastUsedAt) >= accessTokenLastUsedInterval || record.LastUsedIP != clientIP {
+		// 记录失败不影响本次请求
+		if err := s.tokenDao.UpdateLastUsed(ctx, record.ID, now, clientIP); err != nil {
+			s.log.WarnContext(ctx, "failed to update access token last used", slog.Int("token_id", record.ID), slog.Any("error", err))
+		}
+	}
+
+	return pkg.AccessTokenIdentity{
+		TokenID:  record.ID,
+		UserID:   user.UserID,
+		Username: user.Username,
+		Scopes:   strings.Fields(record.Scopes),
+	}, nil
+}
+
+// normalizeAccessTokenScopes 校验权限范围并去重，按 pkg.AccessTokenScopes 的顺序返回
+func normalizeAccessTokenScopes(scopes []string) ([]string, error) {
+	if len(scopes) == 0 {
+		return nil, appErrors.ErrAccessTokenScopeInvalid
+	}
+	for _, scope := range scopes {
+		if !slices.Contains(pkg.AccessTokenScopes, scope) {
+			return nil, appErrors.ErrAccessTokenScopeInvalid
+		}
+	}
+	normalized := make([]string, 0, len(scopes))
+	for _, scope := range pkg.AccessTokenScopes {
+		if slices.Contains(scopes, scope) {
+			normalized = append(normalized, scope)
+		}
+	}
+	return normalized, nil
+}
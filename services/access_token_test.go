@@ -0,0 +1,114 @@
+package service
+
+import (
+	"TeamTickBackend/config"
+	"TeamTickBackend/dal/dao"
+	"TeamTickBackend/pkg"
+	apperrors "TeamTickBackend/pkg/errors"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupAccessTokenTest(t *testing.T) (*AccessTokenService, *dao.DAOFactory, *time.Time) {
+	factory := dao.NewMemoryDAOFactory()
+	seedUsers(t, factory, "alice")
+	service := NewAccessTokenService(factory.PersonalAccessTokenDAO, factory.UserDAO, config.AccessTokensConfig{
+		Enabled:       true,
+		DefaultExpiry: 30 * 24 * time.Hour,
+		MaxExpiry:     90 * 24 * time.Hour,
+		MaxPerUser:    2,
+	}, discardLogger())
+	now := time.Now()
+	service.now = func() time.Time { return now }
+	return service, factory, &now
+}
+
+func TestAccessToken_CreateAndAuthenticate(t *testing.T) {
+	service, factory, now := setupAccessTokenTest(t)
+	ctx := context.Background()
+
+	token, plaintext, err := service.Create(ctx, 1, "导出脚本", []string{pkg.ScopeRecordsRead, pkg.ScopeTasksRead, pkg.ScopeRecordsRead}, 0)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(plaintext, pkg.AccessTokenPrefix))
+	assert.Equal(t, "tasks:read records:read", token.Scopes)
+	assert.Equal(t, now.Add(30*24*time.Hour), token.ExpiresAt)
+	assert.Equal(t, plaintext[len(plaintext)-4:], token.TokenHint)
+	// 只保存摘要
+	assert.NotContains(t, token.TokenHash, plaintext)
+
+	identity, err := service.AuthenticateAccessToken(ctx, "Bearer "+plaintext, "10.0.0.1")
+	require.NoError(t, err)
+	assert.Equal(t, 1, identity.UserID)
+	assert.Equal(t, "alice", identity.Username)
+	assert.True(t, identity.HasScope(pkg.ScopeRecordsRead))
+	assert.False(t, identity.HasScope(pkg.ScopeTasksWrite))
+
+	stored, err := factory.PersonalAccessTokenDAO.GetByTokenHash(ctx, pkg.HashOpaqueToken(plaintext))
+	require.NoError(t, err)
+	require.NotNil(t, stored.LastUsedAt)
+	assert.Equal(t, *now, *stored.LastUsedAt)
+	assert.Equal(t, "10.0.0.1", stored.LastUsedIP)
+
+	_, err = service.AuthenticateAccessToken(ctx, plaintext+"x", "10.0.0.1")
+	assert.ErrorIs(t, err, apperrors.ErrAccessTokenInvalid)
+}
+
+func TestAccessToken_ExpiredAndRevoked(t *testing.T) {
+	service, _, now := setupAccessTokenTest(t)
+	ctx := context.Background()
+
+	expiring, expiringPlain, err := service.Create(ctx, 1, "短期", []string{pkg.ScopeProfileRead}, 24*time.Hour)
+	require.NoError(t, err)
+	_, revokedPlain, err := service.Create(ctx, 1, "吊销", []string{pkg.ScopeProfileRead}, 0)
+	require.NoError(t, err)
+
+	tokens, err := service.List(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, tokens, 2)
+	require.NoError(t, service.Revoke(ctx, 1, tokens[0].ID))
+	assert.ErrorIs(t, service.Revoke(ctx, 1, tokens[0].ID), apperrors.ErrAccessTokenNotFound)
+	// 不能吊销其他用户的令牌
+	assert.ErrorIs(t, service.Revoke(ctx, 2, expiring.ID), apperrors.ErrAccessTokenNotFound)
+
+	_, err = service.AuthenticateAccessToken(ctx, revokedPlain, "")
+	assert.ErrorIs(t, err, apperrors.ErrAccessTokenInvalid)
+
+	*now = now.Add(24 * time.Hour)
+	_, err = service.AuthenticateAccessToken(ctx, expiringPlain, "")
+	assert.ErrorIs(t, err, apperrors.ErrAccessTokenInvalid)
+
+	// 已过期的令牌仍然列出，已吊销的不再列出
+	tokens, err = service.List(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, tokens, 1)
+	assert.Equal(t, expiring.ID, tokens[0].ID)
+}
+
+func TestAccessToken_CreateValidation(t *testing.T) {
+	service, _, now := setupAccessTokenTest(t)
+	ctx := context.Background()
+
+	_, _, err := service.Create(ctx, 1, "空", nil, 0)
+	assert.ErrorIs(t, err, apperrors.ErrAccessTokenScopeInvalid)
+	_, _, err = service.Create(ctx, 1, "未知", []string{"admin:all"}, 0)
+	assert.ErrorIs(t, err, apperrors.ErrAccessTokenScopeInvalid)
+	_, _, err = service.Create(ctx, 1, "太长", []string{pkg.ScopeTasksRead}, 91*24*time.Hour)
+	assert.ErrorIs(t, err, apperrors.ErrAccessTokenExpiryInvalid)
+
+	for i := 0; i < 2; i++ {
+		_, _, err = service.Create(ctx, 1, "令牌", []string{pkg.ScopeTasksRead}, 24*time.Hour)
+		require.NoError(t, err)
+	}
+	_, _, err = service.Create(ctx, 1, "超限", []string{pkg.ScopeTasksRead}, 0)
+	assert.ErrorIs(t, err, apperrors.ErrAccessTokenLimitReached)
+
+	// 过期的令牌不计入上限
+	*now = now.Add(24 * time.Hour)
+	_, _, err = service.Create(ctx, 1, "新令牌", []string{pkg.ScopeTasksRead}, 0)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,425 @@
+package service
+
+import (
+	"TeamTickBackend/dal/dao"
+	"TeamTickBackend/dal/models"
+	appErrors "TeamTickBackend/pkg/errors"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// 平台管理列表接口的分页大小
+const (
+	defaultAdminPageSize = 20
+	maxAdminPageSize     = 100
+)
+
+// AdminActor 执行平台管理操作的用户，写入操作日志；运维命令以 UserID 0 执行
+type AdminActor struct {
+	UserID   int
+	Username string
+	IP       string
+}
+
+// AdminService 平台管理：查询用户与用户组、停用账号、设置平台角色、强制删除用户组与查看平台概况，
+// 以及运维命令使用的重置密码、吊销会话、转让用户组、结束任务与重新统计成员数量
+// 每个管理操作都在同一事务中写入操作日志，操作失败时日志一并回滚
+type AdminService struct {
+	userDao            dao.UserDAO
+	groupDao           dao.GroupDAO
+	adminActionDao     dao.AdminActionDAO
+	statsDao           dao.PlatformStatsDAO
+	transactionManager dao.TransactionManager
+	groupsService      *GroupsService
+	tokenService       *TokenService
+	passwordService    *PasswordService
+	taskService        *TaskService
+	loginGuard         *LoginGuard
+	// bootstrapAdmins 配置 admin.user_ids 中的用户，不论平台角色都视为平台管理员
+	bootstrapAdmins map[int]struct{}
+	now             func() time.Time
+	log             *slog.Logger
+}
+
+func NewAdminService(
+	userDao dao.UserDAO,
+	groupDao dao.GroupDAO,
+	adminActionDao dao.AdminActionDAO,
+	statsDao dao.PlatformStatsDAO,
+	transactionManager dao.TransactionManager,
+	groupsService *GroupsService,
+	tokenService *TokenService,
+	passwordService *PasswordService,
+	taskService *TaskService,
+	loginGuard *LoginGuard,
+	adminUserIDs []int,
+	log *slog.Logger,
+) *AdminService {
+	bootstrapAdmins := make(map[int]struct{}, len(adminUserIDs))
+	for _, id := range adminUserIDs {
+		bootstrapAdmins[id] = struct{}{}
+	}
+	return &AdminService{
+		userDao:            userDao,
+		groupDao:           groupDao,
+		adminActionDao:     adminActionDao,
+		statsDao:           statsDao,
+		transactionManager: transactionManager,
+		groupsService:      groupsService,
+		tokenService:       tokenService,
+		passwordService:    passwordService,
+		taskService:        taskService,
+		loginGuard:         loginGuard,
+		bootstrapAdmins:    bootstrapAdmins,
+		now:                time.Now,
+		log:                log,
+	}
+}
+
+// IsPlatformAdmin 用户在配置的平台管理员列表中，或平台角色为超级管理员且未被停用
+func (s *AdminService) IsPlatformAdmin(ctx context.Context, userID int) (bool, error) {
+	if _, ok := s.bootstrapAdmins[userID]; ok {
+		return true, nil
+	}
+	user, err := s.userDao.GetByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return false, nil
+		}
+		return false, appErrors.ErrDatabaseOperation.WithError(err)
+	}
+	return user.PlatformRole == models.PlatformRoleSuperAdmin && user.DisabledAt == nil, nil
+}
+
+// ListUsers 按关键字与停用状态分页查询用户，page 从1开始
+func (s *AdminService) ListUsers(ctx context.Context, keyword string, disabled *bool, page, pageSize int) ([]*models.User, int64, error) {
+	offset, limit := pageBounds(page, pageSize)
+	users, total, err := s.userDao.Search(ctx, strings.TrimSpace(keyword), disabled, offset, limit)
+	if err != nil {
+		return nil, 0, appErrors.ErrDatabaseOperation.WithError(err)
+	}
+	return users, total, nil
+}
+
+// ListGroups 按名称或创建者分页查询用户组，page 从1开始
+func (s *AdminService) ListGroups(ctx context.Context, keyword string, page, pageSize int) ([]*models.Group, int64, error) {
+	offset, limit := pageBounds(page, pageSize)
+	groups, total, err := s.groupDao.Search(ctx, strings.TrimSpace(keyword), offset, limit)
+	if err != nil {
+		return nil, 0, appErrors.ErrDatabaseOperation.WithError(err)
+	}
+	return groups, total, nil
+}
+
+// DisableUser 停用账号并吊销其全部会话，此前签发的访问令牌与刷新令牌立即失效
+func (s *AdminService) DisableUser(ctx context.Context, actor AdminActor, userID int, reason string) (*models.User, error) {
+	if userID == actor.UserID {
+		return nil, appErrors.ErrAdminSelfAction
+	}
+	reason = strings.TrimSpace(reason)
+	var user *models.User
+	err := s.transactionManager.WithTransaction(ctx, func(tx *gorm.DB) error {
+		var err error
+		user, err = s.getUser(ctx, userID, tx)
+		if err != nil {
+			return err
+		}
+		if user.DisabledAt != nil {
+			return appErrors.ErrUserAlreadyDisabled
+		}
+		now := s.now()
+		if err := s.userDao.UpdateDisabled(ctx, userID, &now, reason, tx); err != nil {
+			return appErrors.ErrDatabaseOperation.WithError(err)
+		}
+		if err := s.tokenService.revokeAllSessions(ctx, userID, tx); err != nil {
+			return err
+		}
+		user.DisabledAt = &now
+		user.DisabledReason = reason
+		return s.record(ctx, actor, models.AdminActionDisableUser, models.AdminTargetUser, userID, user.Username, reason, tx)
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.log.WarnContext(ctx, "user disabled", slog.Int("user_id", userID), slog.Int("actor_id", actor.UserID))
+	return user, nil
+}
+
+// EnableUser 恢复被停用的账号，用户需要重新登录
+func (s *AdminService) EnableUser(ctx context.Context, actor AdminActor, userID int) (*models.User, error) {
+	var user *models.User
+	err := s.transactionManager.WithTransaction(ctx, func(tx *gorm.DB) error {
+		var err error
+		user, err = s.getUser(ctx, userID, tx)
+		if err != nil {
+			return err
+		}
+		if user.DisabledAt == nil {
+			return appErrors.ErrUserNotDisabled
+		}
+		if err := s.userDao.UpdateDisabled(ctx, userID, nil, "", tx); err != nil {
+			return appErrors.ErrDatabaseOperation.WithError(err)
+		}
+		user.DisabledAt = nil
+		user.DisabledReason = ""
+		return s.record(ctx, actor, models.AdminActionEnableUser, models.AdminTargetUser, userID, user.Username, "", tx)
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.log.InfoContext(ctx, "user enabled", slog.Int("user_id", userID), slog.Int("actor_id", actor.UserID))
+	return user, nil
+}
+
+// SetPlatformRole 设置平台角色，配置中的平台管理员不受平台角色影响
+func (s *AdminService) SetPlatformRole(ctx context.Context, actor AdminActor, userID int, role string) (*models.User, error) {
+	if role != models.PlatformRoleUser && role != models.PlatformRoleSuperAdmin {
+		return nil, appErrors.ErrPlatformRoleInvalid
+	}
+	if userID == actor.UserID {
+		return nil, appErrors.ErrAdminSelfAction
+	}
+	var user *models.User
+	err := s.transactionManager.WithTransaction(ctx, func(tx *gorm.DB) error {
+		var err error
+		user, err = s.getUser(ctx, userID, tx)
+		if err != nil {
+			return err
+		}
+		if user.PlatformRole == role {
+			return nil
+		}
+		if err := s.userDao.UpdatePlatformRole(ctx, userID, role, tx); err != nil {
+			return appErrors.ErrDatabaseOperation.WithError(err)
+		}
+		detail := fmt.Sprintf("%s -> %s", user.PlatformRole, role)
+		user.PlatformRole = role
+		return s.record(ctx, actor, models.AdminActionSetPlatformRole, models.AdminTargetUser, userID, user.Username, detail, tx)
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.log.WarnContext(ctx, "platform role changed", slog.Int("user_id", userID), slog.String("role", role), slog.Int("actor_id", actor.UserID))
+	return user, nil
+}
+
+// ForceDeleteGroup 不校验组内角色，删除用户组及其成员
+func (s *AdminService) ForceDeleteGroup(ctx context.Context, actor AdminActor, groupID int, reason string) error {
+	reason = strings.TrimSpace(reason)
+	err := s.transactionManager.WithTransaction(ctx, func(tx *gorm.DB) error {
+		group, err := s.groupDao.GetByGroupID(ctx, groupID, tx)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return appErrors.ErrGroupNotFound
+			}
+			return appErrors.ErrDatabaseOperation.WithError(err)
+		}
+		if err := s.groupsService.deleteGroup(ctx, groupID, tx); err != nil {
+			return err
+		}
+		return s.record(ctx, actor, models.AdminActionDeleteGroup, models.AdminTargetGroup, groupID, group.GroupName, reason, tx)
+	})
+	if err != nil {
+		return err
+	}
+	s.log.WarnContext(ctx, "group force deleted", slog.Int("group_id", groupID), slog.Int("actor_id", actor.UserID))
+	return nil
+}
+
+// UnlockLogin 解除用户名和（或）IP的登录锁定，登录失败计数不在事务中，解除后再写入操作日志
+func (s *AdminService) UnlockLogin(ctx context.Context, actor AdminActor, username, clientIP string) (bool, error) {
+	unlocked, err := s.loginGuard.Unlock(ctx, username, clientIP)
+	if err != nil {
+		return false, err
+	}
+	detail := strings.TrimSpace(strings.Join([]string{username, clientIP}, " "))
+	if err := s.record(ctx, actor, models.AdminActionUnlockLogin, models.AdminTargetLogin, 0, detail, fmt.Sprintf("unlocked=%t", unlocked), nil); err != nil {
+		return false, err
+	}
+	return unlocked, nil
+}
+
+// ResetPassword 重置用户密码，同时使重置密码令牌失效并吊销其全部会话
+func (s *AdminService) ResetPassword(ctx context.Context, actor AdminActor, userID int, newPassword string) (*models.User, error) {
+	var user *models.User
+	err := s.transactionManager.WithTransaction(ctx, func(tx *gorm.DB) error {
+		var err error
+		user, err = s.getUser(ctx, userID, tx)
+		if err != nil {
+			return err
+		}
+		if err := s.passwordService.updatePassword(ctx, userID, newPassword, tx); err != nil {
+			return err
+		}
+		return s.record(ctx, actor, models.AdminActionResetPassword, models.AdminTargetUser, userID, user.Username, "", tx)
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.log.WarnContext(ctx, "password reset by admin", slog.Int("user_id", userID), slog.Int("actor_id", actor.UserID))
+	return user, nil
+}
+
+// RevokeSessions 吊销用户的全部登录会话，账号保持可用
+func (s *AdminService) RevokeSessions(ctx context.Context, actor AdminActor, userID int) (*models.User, error) {
+	var user *models.User
+	err := s.transactionManager.WithTransaction(ctx, func(tx *gorm.DB) error {
+		var err error
+		user, err = s.getUser(ctx, userID, tx)
+		if err != nil {
+			return err
+		}
+		if err := s.tokenService.revokeAllSessions(ctx, userID, tx); err != nil {
+			return err
+		}
+		return s.record(ctx, actor, models.AdminActionRevokeSessions, models.AdminTargetUser, userID, user.Username, "", tx)
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.log.WarnContext(ctx, "all sessions revoked", slog.Int("user_id", userID), slog.Int("actor_id", actor.UserID))
+	return user, nil
+}
+
+// TransferGroupOwnership 不校验组内角色，将用户组转让给组内成员
+func (s *AdminService) TransferGroupOwnership(ctx context.Context, actor AdminActor, groupID, newOwnerID int) (*models.Group, error) {
+	var group *models.Group
+	err := s.transactionManager.WithTransaction(ctx, func(tx *gorm.DB) error {
+		previous, err := s.groupDao.GetByGroupID(ctx, groupID, tx)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return appErrors.ErrGroupNotFound
+			}
+			return appErrors.ErrDatabaseOperation.WithError(err)
+		}
+		previousOwner := previous.CreatorName
+		group, err = s.groupsService.transferOwnership(ctx, groupID, newOwnerID, GroupOperator{Username: actor.Username}, tx)
+		if err != nil {
+			return err
+		}
+		detail := fmt.Sprintf("%s -> %s", previousOwner, group.CreatorName)
+		return s.record(ctx, actor, models.AdminActionTransferGroupOwner, models.AdminTargetGroup, groupID, group.GroupName, detail, tx)
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.log.WarnContext(ctx, "group ownership transferred", slog.Int("group_id", groupID), slog.Int("owner_id", newOwnerID), slog.Int("actor_id", actor.UserID))
+	return group, nil
+}
+
+// CloseTask 提前结束签到任务
+func (s *AdminService) CloseTask(ctx context.Context, actor AdminActor, taskID int) (*models.Task, error) {
+	var task *models.Task
+	err := s.transactionManager.WithTransaction(ctx, func(tx *gorm.DB) error {
+		var err error
+		task, err = s.taskService.closeTask(ctx, taskID, tx)
+		if err != nil {
+			return err
+		}
+		return s.record(ctx, actor, models.AdminActionCloseTask, models.AdminTargetTask, taskID, task.TaskName, "", tx)
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.log.WarnContext(ctx, "task closed", slog.Int("task_id", taskID), slog.Int("actor_id", actor.UserID))
+	return task, nil
+}
+
+// RecountMembers 重新统计用户组成员数量，只为数量被修正的用户组写入操作日志
+func (s *AdminService) RecountMembers(ctx context.Context, actor AdminActor, groupIDs ...int) ([]MemberRecount, error) {
+	var results []MemberRecount
+	err := s.transactionManager.WithTransaction(ctx, func(tx *gorm.DB) error {
+		var err error
+		results, err = s.groupsService.recountMembers(ctx, tx, groupIDs...)
+		if err != nil {
+			return err
+		}
+		for _, result := range results {
+			if result.Before == result.After {
+				continue
+			}
+			group, err := s.groupDao.GetByGroupID(ctx, result.GroupID, tx)
+			if err != nil {
+				return appErrors.ErrDatabaseOperation.WithError(err)
+			}
+			detail := fmt.Sprintf("member_num %d -> %d", result.Before, result.After)
+			if err := s.record(ctx, actor, models.AdminActionRecountMembers, models.AdminTargetGroup, result.GroupID, group.GroupName, detail, tx); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// Stats 平台概况
+func (s *AdminService) Stats(ctx context.Context) (*models.PlatformStats, error) {
+	stats, err := s.statsDao.Collect(ctx, s.now())
+	if err != nil {
+		return nil, appErrors.ErrDatabaseOperation.WithError(err)
+	}
+	return stats, nil
+}
+
+// ListActions 按条件分页查询操作日志，按时间倒序
+func (s *AdminService) ListActions(ctx context.Context, filter models.AdminActionFilter, page, pageSize int) ([]*models.AdminAction, int64, error) {
+	offset, limit := pageBounds(page, pageSize)
+	actions, total, err := s.adminActionDao.List(ctx, filter, offset, limit)
+	if err != nil {
+		return nil, 0, appErrors.ErrDatabaseOperation.WithError(err)
+	}
+	return actions, total, nil
+}
+
+// record 写入操作日志，tx 为nil时单独写入
+func (s *AdminService) record(ctx context.Context, actor AdminActor, action, targetType string, targetID int, targetName, detail string, tx *gorm.DB) error {
+	err := s.adminActionDao.Create(ctx, &models.AdminAction{
+		ActorID:    actor.UserID,
+		ActorName:  actor.Username,
+		Action:     action,
+		TargetType: targetType,
+		TargetID:   targetID,
+		TargetName: truncate(targetName, 128),
+		Detail:     truncate(detail, 1024),
+		IP:         actor.IP,
+		CreatedAt:  s.now(),
+	}, tx)
+	if err != nil {
+		return appErrors.ErrDatabaseOperation.WithError(err)
+	}
+	return nil
+}
+
+func (s *AdminService) getUser(ctx context.Context, userID int, tx *gorm.DB) (*models.User, error) {
+	user, err := s.userDao.GetByID(ctx, userID, tx)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, appErrors.ErrUserNotFound
+		}
+		return nil, appErrors.ErrDatabaseOperation.WithError(err)
+	}
+	return user, nil
+}
+
+// pageBounds 将从1开始的页码转换为 offset 与 limit，pageSize 超出范围时使用默认值或上限
+func pageBounds(page, pageSize int) (int, int) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = defaultAdminPageSize
+	}
+	if pageSize > maxAdminPageSize {
+		pageSize = maxAdminPageSize
+	}
+	return (page - 1) * pageSize, pageSize
+}
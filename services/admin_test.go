@@ -0,0 +1,225 @@
+package service
+
+import (
+	"TeamTickBackend/config"
+	"TeamTickBackend/dal/dao"
+	"TeamTickBackend/dal/models"
+	"TeamTickBackend/pkg"
+	appErrors "TeamTickBackend/pkg/errors"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// adminFixture alice（用户ID 1）为普通用户，root（用户ID 2）为配置中的平台管理员
+type adminFixture struct {
+	factory *dao.DAOFactory
+	tokens  *TokenService
+	auth    *AuthService
+	groups  *GroupsService
+	admin   *AdminService
+	root    AdminActor
+}
+
+func setupAdminTest(t *testing.T) *adminFixture {
+	tokens, _, factory := setupTokenServiceTest(t)
+	ctx := context.Background()
+	hash, err := pkg.GenerateFromPassword("secret123")
+	require.NoError(t, err)
+	require.NoError(t, factory.UserDAO.UpdatePassword(ctx, 1, hash))
+	require.NoError(t, factory.UserDAO.Create(ctx, &models.User{Username: "root", Password: hash}))
+	groups := newMemoryGroupsService(t, factory)
+	return &adminFixture{
+		factory: factory,
+		tokens:  tokens,
+		auth:    NewAuthService(factory.UserDAO, factory.TransactionManager, new(mockJwtHandler), nil, nil, discardLogger()),
+		groups:  groups,
+		admin: NewAdminService(factory.UserDAO, factory.GroupDAO, factory.AdminActionDAO, factory.PlatformStatsDAO, factory.TransactionManager,
+			groups, tokens, NewPasswordService(factory.UserDAO, factory.PasswordResetTokenDAO, factory.TransactionManager, tokens, nil, time.Minute, discardLogger()),
+			newMemoryTaskService(t, factory),
+			NewLoginGuard(factory.LoginAttemptDAO, config.LoginProtectionConfig{}, discardLogger()), []int{2}, discardLogger()),
+		root: AdminActor{UserID: 2, Username: "root", IP: "10.0.0.1"},
+	}
+}
+
+func TestDisableUser_RevokesSessionsAndBlocksLogin(t *testing.T) {
+	f := setupAdminTest(t)
+	ctx := context.Background()
+	pair := startSession(t, f.tokens, DeviceInfo{ID: "phone-1"})
+
+	user, err := f.admin.DisableUser(ctx, f.root, 1, " 发布广告 ")
+	require.NoError(t, err)
+	assert.NotNil(t, user.DisabledAt)
+	assert.Equal(t, "发布广告", user.DisabledReason)
+
+	_, err = f.tokens.Refresh(ctx, pair.RefreshToken, "phone-1")
+	assert.ErrorIs(t, err, appErrors.ErrRefreshTokenInvalid)
+	_, err = f.auth.VerifyCredentials(ctx, "alice", "secret123", "")
+	assert.ErrorIs(t, err, appErrors.ErrUserDisabled)
+	// 密码错误时不提示账号已停用
+	_, err = f.auth.VerifyCredentials(ctx, "alice", "wrong", "")
+	assert.ErrorIs(t, err, appErrors.ErrInvalidCredentials)
+
+	_, err = f.admin.DisableUser(ctx, f.root, 1, "")
+	assert.ErrorIs(t, err, appErrors.ErrUserAlreadyDisabled)
+	_, err = f.admin.DisableUser(ctx, f.root, 2, "")
+	assert.ErrorIs(t, err, appErrors.ErrAdminSelfAction)
+
+	_, err = f.admin.EnableUser(ctx, f.root, 1)
+	require.NoError(t, err)
+	_, err = f.auth.VerifyCredentials(ctx, "alice", "secret123", "")
+	assert.NoError(t, err)
+
+	actions, total, err := f.admin.ListActions(ctx, models.AdminActionFilter{TargetType: models.AdminTargetUser, TargetID: 1}, 1, 10)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+	assert.Equal(t, models.AdminActionEnableUser, actions[0].Action)
+	assert.Equal(t, models.AdminActionDisableUser, actions[1].Action)
+	assert.Equal(t, "发布广告", actions[1].Detail)
+	assert.Equal(t, "alice", actions[1].TargetName)
+	assert.Equal(t, "10.0.0.1", actions[1].IP)
+}
+
+func TestPlatformRole_GrantsAdminAccess(t *testing.T) {
+	f := setupAdminTest(t)
+	ctx := context.Background()
+
+	isAdmin, err := f.admin.IsPlatformAdmin(ctx, 2)
+	require.NoError(t, err)
+	assert.True(t, isAdmin)
+	isAdmin, err = f.admin.IsPlatformAdmin(ctx, 1)
+	require.NoError(t, err)
+	assert.False(t, isAdmin)
+
+	_, err = f.admin.SetPlatformRole(ctx, f.root, 1, "owner")
+	assert.ErrorIs(t, err, appErrors.ErrPlatformRoleInvalid)
+	_, err = f.admin.SetPlatformRole(ctx, f.root, 2, models.PlatformRoleUser)
+	assert.ErrorIs(t, err, appErrors.ErrAdminSelfAction)
+
+	_, err = f.admin.SetPlatformRole(ctx, f.root, 1, models.PlatformRoleSuperAdmin)
+	require.NoError(t, err)
+	isAdmin, err = f.admin.IsPlatformAdmin(ctx, 1)
+	require.NoError(t, err)
+	assert.True(t, isAdmin)
+
+	// 停用的超级管理员不再具有平台管理员权限
+	_, err = f.admin.DisableUser(ctx, f.root, 1, "")
+	require.NoError(t, err)
+	isAdmin, err = f.admin.IsPlatformAdmin(ctx, 1)
+	require.NoError(t, err)
+	assert.False(t, isAdmin)
+
+	stats, err := f.admin.Stats(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), stats.Users)
+	assert.Equal(t, int64(1), stats.DisabledUsers)
+	assert.Equal(t, int64(1), stats.SuperAdmins)
+}
+
+func TestForceDeleteGroup_RecordsAction(t *testing.T) {
+	f := setupAdminTest(t)
+	ctx := context.Background()
+	group, err := f.groups.CreateGroup(ctx, "广告群", "", 1)
+	require.NoError(t, err)
+
+	groups, total, err := f.admin.ListGroups(ctx, "广告", 1, 10)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	assert.Equal(t, group.GroupID, groups[0].GroupID)
+
+	require.NoError(t, f.admin.ForceDeleteGroup(ctx, f.root, group.GroupID, "违规内容"))
+	_, err = f.factory.GroupDAO.GetByGroupID(ctx, group.GroupID)
+	assert.Error(t, err)
+	members, err := f.factory.GroupMemberDAO.GetMembersByGroupID(ctx, group.GroupID)
+	require.NoError(t, err)
+	assert.Empty(t, members)
+
+	// 失败的操作不写入日志
+	err = f.admin.ForceDeleteGroup(ctx, f.root, group.GroupID, "")
+	assert.ErrorIs(t, err, appErrors.ErrGroupNotFound)
+	actions, total, err := f.admin.ListActions(ctx, models.AdminActionFilter{Action: models.AdminActionDeleteGroup}, 1, 10)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	assert.Equal(t, "广告群", actions[0].TargetName)
+	assert.Equal(t, "违规内容", actions[0].Detail)
+}
+
+func TestMaintenanceOperations_RecordActions(t *testing.T) {
+	f := setupAdminTest(t)
+	ctx := context.Background()
+	pair := startSession(t, f.tokens, DeviceInfo{ID: "phone-1"})
+
+	_, err := f.admin.ResetPassword(ctx, f.root, 1, "newpass123")
+	require.NoError(t, err)
+	_, err = f.auth.VerifyCredentials(ctx, "alice", "newpass123", "")
+	require.NoError(t, err)
+	_, err = f.tokens.Refresh(ctx, pair.RefreshToken, "phone-1")
+	assert.ErrorIs(t, err, appErrors.ErrRefreshTokenInvalid)
+	_, err = f.admin.RevokeSessions(ctx, f.root, 1)
+	require.NoError(t, err)
+
+	group, err := f.groups.CreateGroup(ctx, "实训一组", "", 1)
+	require.NoError(t, err)
+	// 直接写入成员表，member_num 与实际成员数不一致
+	require.NoError(t, f.factory.GroupMemberDAO.Create(ctx, &models.GroupMember{GroupID: group.GroupID, UserID: 2, Username: "root", Role: "member"}))
+	results, err := f.admin.RecountMembers(ctx, f.root)
+	require.NoError(t, err)
+	assert.Equal(t, []MemberRecount{{GroupID: group.GroupID, Before: 1, After: 2}}, results)
+	// 没有修正的用户组不写入日志
+	_, err = f.admin.RecountMembers(ctx, f.root, group.GroupID)
+	require.NoError(t, err)
+
+	group, err = f.admin.TransferGroupOwnership(ctx, f.root, group.GroupID, 2)
+	require.NoError(t, err)
+	assert.Equal(t, 2, group.CreatorID)
+
+	now := time.Now()
+	task := &models.Task{TaskName: "晨会", GroupID: group.GroupID, StartTime: now.Add(-time.Hour), EndTime: now.Add(time.Hour)}
+	require.NoError(t, f.factory.TaskDAO.Create(ctx, task))
+	_, err = f.admin.CloseTask(ctx, f.root, task.TaskID)
+	require.NoError(t, err)
+	// 失败的操作不写入日志
+	_, err = f.admin.CloseTask(ctx, f.root, task.TaskID)
+	assert.ErrorIs(t, err, appErrors.ErrTaskHasEnded)
+
+	actions, total, err := f.admin.ListActions(ctx, models.AdminActionFilter{}, 1, 10)
+	require.NoError(t, err)
+	require.Equal(t, int64(5), total)
+	recorded := make(map[string]*models.AdminAction, len(actions))
+	for _, action := range actions {
+		recorded[action.Action] = action
+	}
+	assert.Equal(t, "alice", recorded[models.AdminActionResetPassword].TargetName)
+	assert.Equal(t, "alice", recorded[models.AdminActionRevokeSessions].TargetName)
+	assert.Equal(t, "member_num 1 -> 2", recorded[models.AdminActionRecountMembers].Detail)
+	assert.Equal(t, "alice -> root", recorded[models.AdminActionTransferGroupOwner].Detail)
+	assert.Equal(t, models.AdminTargetTask, recorded[models.AdminActionCloseTask].TargetType)
+	assert.Equal(t, "晨会", recorded[models.AdminActionCloseTask].TargetName)
+}
+
+func TestListUsers_SearchAndPaging(t *testing.T) {
+	f := setupAdminTest(t)
+	ctx := context.Background()
+	seedUsers(t, f.factory, "bob", "Bobby", "carol")
+
+	users, total, err := f.admin.ListUsers(ctx, "BOB", nil, 1, 1)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+	require.Len(t, users, 1)
+	assert.Equal(t, "bob", users[0].Username)
+	users, _, err = f.admin.ListUsers(ctx, "BOB", nil, 2, 1)
+	require.NoError(t, err)
+	require.Len(t, users, 1)
+	assert.Equal(t, "Bobby", users[0].Username)
+
+	_, err = f.admin.DisableUser(ctx, f.root, 5, "")
+	require.NoError(t, err)
+	disabled := true
+	users, total, err = f.admin.ListUsers(ctx, "", &disabled, 0, 0)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	assert.Equal(t, "carol", users[0].Username)
+}
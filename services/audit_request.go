@@ -17,6 +17,7 @@ type AuditRequestService struct {
 	taskRecordDAO       dao.TaskRecordDAO
 	taskDAO             dao.TaskDAO
 	groupDAO            dao.GroupDAO
+	userDAO             dao.UserDAO
 }
 
 func NewAuditRequestService(
@@ -25,6 +26,7 @@ func NewAuditRequestService(
 	taskRecordDAO dao.TaskRecordDAO,
 	taskDAO dao.TaskDAO,
 	groupDAO dao.GroupDAO,
+	userDAO dao.UserDAO,
 ) *AuditRequestService {
 	return &AuditRequestService{
 		transactionManager,
@@ -32,6 +34,7 @@ func NewAuditRequestService(
 		taskRecordDAO,
 		taskDAO,
 		groupDAO,
+		userDAO,
 	}
 }
 
@@ -103,6 +106,12 @@ func (s *AuditRequestService) GetAuditRequestByGroupIDWithStatus(ctx context.Con
 					requests = append(requests, req)
 				}
 			}
+		} else if status == "approved" || status == "rejected" {
+			for _, req := range auditRequests {
+				if req.Status == status {
+					requests = append(requests, req)
+				}
+			}
 		}
 		return nil
 	})
@@ -116,7 +125,7 @@ func (s *AuditRequestService) GetAuditRequestByGroupIDWithStatus(ctx context.Con
 func (s *AuditRequestService) CreateAuditRequest(
 	ctx context.Context,
 	taskID, userID int,
-	username, reason string,
+	reason string,
 	image string,
 ) (*models.CheckApplication, error) {
 	var request models.CheckApplication
@@ -142,6 +151,10 @@ func (s *AuditRequestService) CreateAuditRequest(
 			}
 			return appErrors.ErrDatabaseOperation.WithError(err)
 		}
+		username, err := currentUsername(ctx, s.userDAO, userID, tx)
+		if err != nil {
+			return err
+		}
 		newRequest := models.CheckApplication{
 			TaskID:        taskID,
 			GroupID:       task.GroupID,
@@ -184,9 +197,19 @@ func (s *AuditRequestService) UpdateAuditRequest(ctx context.Context, requestID
 			if err := s.checkApplicationDAO.Update(ctx, "approved", requestID, tx); err != nil {
 				return appErrors.ErrAuditRequestUpdateFailed.WithError(err)
 			}
+			group, err := s.groupDAO.GetByGroupID(ctx, request.GroupID, tx)
+			if err != nil {
+				if errors.Is(err, gorm.ErrRecordNotFound) {
+					return appErrors.ErrGroupNotFound
+				}
+				return appErrors.ErrDatabaseOperation.WithError(err)
+			}
 			// 创建签到记录
 			record := models.TaskRecord{
 				TaskID:     request.TaskID,
+				TaskName:   request.TaskName,
+				GroupID:    request.GroupID,
+				GroupName:  group.GroupName,
 				UserID:     request.UserID,
 				Username:   request.Username,
 				SignedTime: time.Now(),
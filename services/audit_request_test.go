@@ -85,6 +85,7 @@ func setupAuditRequestServiceTest() (*AuditRequestService, *mockCheckApplication
 		mockTaskRecordDao,
 		mockTaskDao,
 		mockGroupDao,
+		new(mockUserDAO),
 	)
 
 	return auditRequestService, mockCheckApplicationDao, mockTaskDao, mockTaskRecordDao, mockGroupDao, mockTxManager
@@ -548,6 +549,7 @@ func TestCreateAuditRequest_Success(t *testing.T) {
 	mockTaskDao.On("GetByTaskID", ctx, taskID, mock.AnythingOfType("[]*gorm.DB")).Return(task, nil)
 	mockCheckApplicationDao.On("GetByTaskIDAndUserID", ctx, taskID, userID, mock.AnythingOfType("[]*gorm.DB")).Return(nil, gorm.ErrRecordNotFound)
 	mockGroupDao.On("GetByGroupID", ctx, task.GroupID, mock.AnythingOfType("[]*gorm.DB")).Return(group, nil)
+	auditRequestService.userDAO.(*mockUserDAO).On("GetByID", ctx, userID, mock.AnythingOfType("[]*gorm.DB")).Return(&models.User{UserID: userID, Username: username}, nil)
 	mockCheckApplicationDao.On("Create", ctx, mock.AnythingOfType("*models.CheckApplication"), mock.AnythingOfType("[]*gorm.DB")).Return(nil).Run(func(args mock.Arguments) {
 		application := args.Get(1).(*models.CheckApplication)
 		application.ID = 1
@@ -557,7 +559,7 @@ func TestCreateAuditRequest_Success(t *testing.T) {
 	})
 
 	// 调用函数
-	createdRequest, err := auditRequestService.CreateAuditRequest(ctx, taskID, userID, username, reason, image)
+	createdRequest, err := auditRequestService.CreateAuditRequest(ctx, taskID, userID, reason, image)
 
 	// 断言
 	assert.NoError(t, err)
@@ -586,7 +588,6 @@ func TestCreateAuditRequest_TaskNotFound(t *testing.T) {
 	// 测试数据
 	taskID := 999 // 不存在的任务ID
 	userID := 1
-	username := "user1"
 	reason := "网络问题导致无法正常签到"
 	image := "base64encodedimage"
 
@@ -595,7 +596,7 @@ func TestCreateAuditRequest_TaskNotFound(t *testing.T) {
 	mockTaskDao.On("GetByTaskID", ctx, taskID, mock.AnythingOfType("[]*gorm.DB")).Return(nil, gorm.ErrRecordNotFound)
 
 	// 调用函数
-	createdRequest, err := auditRequestService.CreateAuditRequest(ctx, taskID, userID, username, reason, image)
+	createdRequest, err := auditRequestService.CreateAuditRequest(ctx, taskID, userID, reason, image)
 
 	// 断言
 	assert.Error(t, err)
@@ -614,7 +615,6 @@ func TestCreateAuditRequest_RequestAlreadyExists(t *testing.T) {
 	// 测试数据
 	taskID := 1
 	userID := 1
-	username := "user1"
 	reason := "网络问题导致无法正常签到"
 	image := "base64encodedimage"
 
@@ -640,7 +640,7 @@ func TestCreateAuditRequest_RequestAlreadyExists(t *testing.T) {
 	mockCheckApplicationDao.On("GetByTaskIDAndUserID", ctx, taskID, userID, mock.AnythingOfType("[]*gorm.DB")).Return(existingRequest, nil)
 
 	// 调用函数
-	createdRequest, err := auditRequestService.CreateAuditRequest(ctx, taskID, userID, username, reason, image)
+	createdRequest, err := auditRequestService.CreateAuditRequest(ctx, taskID, userID, reason, image)
 
 	// 断言
 	assert.Error(t, err)
@@ -660,7 +660,6 @@ func TestCreateAuditRequest_GroupNotFound(t *testing.T) {
 	// 测试数据
 	taskID := 1
 	userID := 1
-	username := "user1"
 	reason := "网络问题导致无法正常签到"
 	image := "base64encodedimage"
 
@@ -679,7 +678,7 @@ func TestCreateAuditRequest_GroupNotFound(t *testing.T) {
 	mockGroupDao.On("GetByGroupID", ctx, task.GroupID, mock.AnythingOfType("[]*gorm.DB")).Return(nil, gorm.ErrRecordNotFound)
 
 	// 调用函数
-	createdRequest, err := auditRequestService.CreateAuditRequest(ctx, taskID, userID, username, reason, image)
+	createdRequest, err := auditRequestService.CreateAuditRequest(ctx, taskID, userID, reason, image)
 
 	// 断言
 	assert.Error(t, err)
@@ -696,13 +695,14 @@ func TestCreateAuditRequest_GroupNotFound(t *testing.T) {
 // --- UpdateAuditRequest 测试 ---
 
 func TestUpdateAuditRequest_Approve_Success(t *testing.T) {
-	auditRequestService, mockCheckApplicationDao, _, mockTaskRecordDao, _, mockTxManager := setupAuditRequestServiceTest()
+	auditRequestService, mockCheckApplicationDao, _, mockTaskRecordDao, mockGroupDao, mockTxManager := setupAuditRequestServiceTest()
 	ctx := context.Background()
 	requestID := 1
 
 	// 预期的申请
 	request := &models.CheckApplication{
 		ID:            requestID,
+		GroupID:       1,
 		TaskID:        1,
 		TaskName:      "测试任务",
 		UserID:        1,
@@ -718,6 +718,7 @@ func TestUpdateAuditRequest_Approve_Success(t *testing.T) {
 	mockTxManager.On("WithTransaction", ctx, mock.AnythingOfType("func(*gorm.DB) error")).Return(nil)
 	mockCheckApplicationDao.On("GetByID", ctx, requestID, mock.AnythingOfType("[]*gorm.DB")).Return(request, nil)
 	mockCheckApplicationDao.On("Update", ctx, "approved", requestID, mock.AnythingOfType("[]*gorm.DB")).Return(nil)
+	mockGroupDao.On("GetByGroupID", ctx, 1, mock.AnythingOfType("[]*gorm.DB")).Return(&models.Group{GroupID: 1, GroupName: "测试组"}, nil)
 	mockTaskRecordDao.On("Create", ctx, mock.AnythingOfType("*models.TaskRecord"), mock.AnythingOfType("[]*gorm.DB")).Return(nil)
 
 	// 调用函数
@@ -730,6 +731,7 @@ func TestUpdateAuditRequest_Approve_Success(t *testing.T) {
 	mockTxManager.AssertExpectations(t)
 	mockCheckApplicationDao.AssertExpectations(t)
 	mockTaskRecordDao.AssertExpectations(t)
+	mockGroupDao.AssertExpectations(t)
 }
 
 func TestUpdateAuditRequest_Reject_Success(t *testing.T) {
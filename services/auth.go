@@ -7,6 +7,8 @@ import (
 	appErrors "TeamTickBackend/pkg/errors"
 	"context"
 	"errors"
+	"log/slog"
+	"sync"
 
 	"gorm.io/gorm"
 )
@@ -15,17 +17,34 @@ type AuthService struct {
 	userDao dao.UserDAO
 	transactionManager dao.TransactionManager
 	jwtHandler pkg.JwtHandler
+	// loginGuard 为nil时不限制登录失败次数
+	loginGuard *LoginGuard
+	// tokenService 为nil时 AuthLogin 签发不绑定会话的令牌
+	tokenService *TokenService
+	log *slog.Logger
 }
 
+// 用户不存在时用于比对的密码摘要，使两种失败情况的耗时一致
+var dummyPasswordHash = sync.OnceValue(func() string {
+	hash, _ := pkg.GenerateFromPassword("teamtick-dummy-password")
+	return hash
+})
+
 func NewAuthService(
 	userDao dao.UserDAO,
 	transactionManager dao.TransactionManager,
 	jwtHandler pkg.JwtHandler,
+	loginGuard *LoginGuard,
+	tokenService *TokenService,
+	log *slog.Logger,
 ) *AuthService {
 	return &AuthService{
 		userDao: userDao,
 		transactionManager: transactionManager,
 		jwtHandler: jwtHandler,
+		loginGuard: loginGuard,
+		tokenService: tokenService,
+		log: log,
 	}
 }
 
@@ -64,35 +83,69 @@ func (s *AuthService) AuthRegister(ctx context.Context, username, password strin
 
 }
 
-func (s *AuthService) AuthLogin(ctx context.Context, username, password string) (*models.User, string, error) {
-	var existUser models.User
-	var userToken string
+func (s *AuthService) AuthLogin(ctx context.Context, username, password, clientIP string) (*models.User, string, error) {
+	user, err := s.VerifyCredentials(ctx, username, password, clientIP)
+	if err != nil {
+		return nil, "", err
+	}
+	//开启登录会话并签发绑定会话的token，会话出现在 GET /users/me/sessions 中
+	if s.tokenService != nil {
+		pair, err := s.tokenService.StartSession(ctx, user, DeviceInfo{IP: clientIP})
+		if err != nil {
+			return nil, "", err
+		}
+		return user, pair.AccessToken, nil
+	}
+	//生成不绑定会话的token
+	token, err := s.jwtHandler.GenerateJWTToken(user.Username, user.UserID)
+	if err != nil {
+		return nil, "", appErrors.ErrTokenGenerationFailed.WithError(err)
+	}
+	return user, token, nil
+}
 
+// 校验用户名与密码，登录接口在此之后由TokenService开启会话并签发令牌
+// 用户不存在与密码错误统一返回 ErrInvalidCredentials；用户名或IP被锁定时返回 ErrLoginLocked，此时不校验密码
+func (s *AuthService) VerifyCredentials(ctx context.Context, username, password, clientIP string) (*models.User, error) {
+	if s.loginGuard != nil {
+		if err := s.loginGuard.Check(ctx, username, clientIP); err != nil {
+			s.log.WarnContext(ctx, "login rejected", slog.String("username", username), slog.String("client_ip", clientIP), slog.String("error", err.Error()))
+			return nil, err
+		}
+	}
+
+	var existUser models.User
 	err := s.transactionManager.WithTransaction(ctx, func(tx *gorm.DB) error {
 		//检查用户是否存在
 		user, err := s.userDao.GetByUsername(ctx, username, tx)
 		if err != nil {
 			if errors.Is(err, gorm.ErrRecordNotFound) {
-				return appErrors.ErrUserNotFound
+				pkg.CheckPassword(dummyPasswordHash(), password)
+				return appErrors.ErrInvalidCredentials
 			}
 			return appErrors.ErrDatabaseOperation.WithError(err)
 		}
 		//检查密码是否正确
 		if !pkg.CheckPassword(user.Password, password) {
-			return appErrors.ErrInvalidPassword
+			return appErrors.ErrInvalidCredentials
 		}
-		existUser = *user
-		//生成token
-		token, err := s.jwtHandler.GenerateJWTToken(user.Username, user.UserID)
-		if err != nil {
-			return appErrors.ErrTokenGenerationFailed.WithError(err)
+		//密码正确后才提示账号已停用，避免泄露账号状态
+		if user.DisabledAt != nil {
+			return appErrors.ErrUserDisabled
 		}
-		userToken = token
-
+		existUser = *user
 		return nil
 	})
 	if err != nil {
-		return nil, "", err
+		s.log.WarnContext(ctx, "login failed", slog.String("username", username), slog.String("client_ip", clientIP), slog.String("error", err.Error()))
+		if s.loginGuard != nil && errors.Is(err, appErrors.ErrInvalidCredentials) {
+			s.loginGuard.RecordFailure(ctx, username, clientIP)
+		}
+		return nil, err
+	}
+	// 开启两步验证的用户在第二步通过后才清除失败计数
+	if s.loginGuard != nil && !existUser.TwoFactorEnabled {
+		s.loginGuard.RecordSuccess(ctx, username)
 	}
-	return &existUser, userToken, nil
+	return &existUser, nil
 }
@@ -53,6 +53,53 @@ func (m *mockUserDAO) GetByID(ctx context.Context, id int, tx ...*gorm.DB) (*mod
 	return userArg.(*models.User), args.Error(1)
 }
 
+func (m *mockUserDAO) UpdatePassword(ctx context.Context, userID int, password string, tx ...*gorm.DB) error {
+	args := m.Called(ctx, userID, password, tx)
+	return args.Error(0)
+}
+
+func (m *mockUserDAO) UpdateTwoFactorEnabled(ctx context.Context, userID int, enabled bool, tx ...*gorm.DB) error {
+	args := m.Called(ctx, userID, enabled, tx)
+	return args.Error(0)
+}
+
+func (m *mockUserDAO) UpdateProfile(ctx context.Context, userID int, profile *models.User, tx ...*gorm.DB) error {
+	args := m.Called(ctx, userID, profile, tx)
+	return args.Error(0)
+}
+
+func (m *mockUserDAO) GetByIDs(ctx context.Context, ids []int, tx ...*gorm.DB) ([]*models.User, error) {
+	args := m.Called(ctx, ids, tx)
+	usersArg := args.Get(0)
+	if usersArg == nil {
+		return nil, args.Error(1)
+	}
+	return usersArg.([]*models.User), args.Error(1)
+}
+
+func (m *mockUserDAO) UpdateUsername(ctx context.Context, userID int, username string, tx ...*gorm.DB) error {
+	args := m.Called(ctx, userID, username, tx)
+	return args.Error(0)
+}
+
+func (m *mockUserDAO) Search(ctx context.Context, keyword string, disabled *bool, offset, limit int, tx ...*gorm.DB) ([]*models.User, int64, error) {
+	args := m.Called(ctx, keyword, disabled, offset, limit, tx)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).([]*models.User), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *mockUserDAO) UpdateDisabled(ctx context.Context, userID int, disabledAt *time.Time, reason string, tx ...*gorm.DB) error {
+	args := m.Called(ctx, userID, disabledAt, reason, tx)
+	return args.Error(0)
+}
+
+func (m *mockUserDAO) UpdatePlatformRole(ctx context.Context, userID int, role string, tx ...*gorm.DB) error {
+	args := m.Called(ctx, userID, role, tx)
+	return args.Error(0)
+}
+
 // Mock TransactionManager
 type mockTransactionManager struct {
 	mock.Mock
@@ -74,7 +121,7 @@ type mockJwtHandler struct {
 	mock.Mock
 }
 
-func (m *mockJwtHandler) GenerateJWTToken(username string, userID int) (string, error) {
+func (m *mockJwtHandler) GenerateJWTToken(username string, userID int, sessionID ...string) (string, error) {
 	args := m.Called(username, userID)
 	return args.String(0), args.Error(1)
 }
@@ -89,13 +136,17 @@ func (m *mockJwtHandler) ParseJWTToken(tokenString string) (pkg.JwtPayload, erro
 	return payloadArg.(pkg.JwtPayload), args.Error(1)
 }
 
+func (m *mockJwtHandler) JWKS() pkg.JSONWebKeySet {
+	return pkg.JSONWebKeySet{}
+}
+
 // --- 测试准备 ---
 
 func setupAuthServiceTest() (*AuthService, *mockUserDAO, *mockTransactionManager, *mockJwtHandler) {
 	mockUserDao := new(mockUserDAO)
 	mockTxManager := new(mockTransactionManager)
 	mockJwt := new(mockJwtHandler)
-	authService := NewAuthService(mockUserDao, mockTxManager, mockJwt)
+	authService := NewAuthService(mockUserDao, mockTxManager, mockJwt, nil, nil, discardLogger())
 	return authService, mockUserDao, mockTxManager, mockJwt
 }
 
@@ -188,7 +239,7 @@ func TestAuthLogin_Success(t *testing.T) {
 	mockJwt.On("GenerateJWTToken", username, userID).Return(expectedToken, nil)
 
 	// 调用函数
-	loggedInUser, token, err := authService.AuthLogin(ctx, username, password)
+	loggedInUser, token, err := authService.AuthLogin(ctx, username, password, "")
 
 	// 断言
 	assert.NoError(t, err)
@@ -213,11 +264,11 @@ func TestAuthLogin_UserNotFound(t *testing.T) {
 	mockUserDao.On("GetByUsername", ctx, username, mock.AnythingOfType("[]*gorm.DB")).Return(nil, gorm.ErrRecordNotFound)
 
 	// 调用函数
-	loggedInUser, token, err := authService.AuthLogin(ctx, username, password)
+	loggedInUser, token, err := authService.AuthLogin(ctx, username, password, "")
 
-	// 断言
+	// 断言：与密码错误返回相同的错误，无法据此判断用户是否存在
 	assert.Error(t, err)
-	assert.True(t, errors.Is(err, apperrors.ErrUserNotFound))
+	assert.True(t, errors.Is(err, apperrors.ErrInvalidCredentials))
 	assert.Nil(t, loggedInUser)
 	assert.Empty(t, token)
 
@@ -249,11 +300,11 @@ func TestAuthLogin_InvalidPassword(t *testing.T) {
 	mockUserDao.On("GetByUsername", ctx, username, mock.AnythingOfType("[]*gorm.DB")).Return(foundUser, nil)
 
 	// 调用函数
-	loggedInUser, token, err := authService.AuthLogin(ctx, username, wrongPassword)
+	loggedInUser, token, err := authService.AuthLogin(ctx, username, wrongPassword, "")
 
 	// 断言
 	assert.Error(t, err)
-	assert.True(t, errors.Is(err, apperrors.ErrInvalidPassword))
+	assert.True(t, errors.Is(err, apperrors.ErrInvalidCredentials))
 	assert.Nil(t, loggedInUser)
 	assert.Empty(t, token)
 
@@ -0,0 +1,102 @@
+package service
+
+import (
+	"TeamTickBackend/dal/dao"
+	"TeamTickBackend/dal/models"
+	appErrors "TeamTickBackend/pkg/errors"
+	"context"
+	"log/slog"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ConsistencyService 检查并修复其他表中冗余存储的用户名、用户组名称与任务名称
+// 改名操作在事务内同步冗余列，该服务用于发现并修复历史数据或旧令牌写入造成的不一致
+type ConsistencyService struct {
+	denormalizationDao dao.DenormalizationDAO
+	transactionManager dao.TransactionManager
+	log                *slog.Logger
+}
+
+func NewConsistencyService(
+	denormalizationDao dao.DenormalizationDAO,
+	transactionManager dao.TransactionManager,
+	log *slog.Logger,
+) *ConsistencyService {
+	return &ConsistencyService{
+		denormalizationDao: denormalizationDao,
+		transactionManager: transactionManager,
+		log:                log,
+	}
+}
+
+// Check 统计每个冗余列中与来源数据不一致的行数
+func (s *ConsistencyService) Check(ctx context.Context) ([]models.ColumnDrift, error) {
+	drifts, err := s.denormalizationDao.CountDrift(ctx)
+	if err != nil {
+		return nil, appErrors.ErrDatabaseOperation.WithError(err)
+	}
+	return drifts, nil
+}
+
+// Repair 按来源数据修复所有不一致的冗余列，返回每列修复的行数
+func (s *ConsistencyService) Repair(ctx context.Context) ([]models.ColumnDrift, error) {
+	var repaired []models.ColumnDrift
+	err := s.transactionManager.WithTransaction(ctx, func(tx *gorm.DB) error {
+		result, err := s.denormalizationDao.Repair(ctx, "", 0, tx)
+		if err != nil {
+			return appErrors.ErrDatabaseOperation.WithError(err)
+		}
+		repaired = result
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return repaired, nil
+}
+
+// RunChecker 每隔 interval 检查一次，发现不一致时记录日志，repair 为true时随即修复；ctx 取消后返回
+func (s *ConsistencyService) RunChecker(ctx context.Context, interval time.Duration, repair bool) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.checkOnce(ctx, repair)
+		}
+	}
+}
+
+func (s *ConsistencyService) checkOnce(ctx context.Context, repair bool) {
+	drifts, err := s.Check(ctx)
+	if err != nil {
+		s.log.ErrorContext(ctx, "consistency check failed", slog.String("error", err.Error()))
+		return
+	}
+	var total int64
+	for _, drift := range drifts {
+		if drift.Rows == 0 {
+			continue
+		}
+		total += drift.Rows
+		s.log.WarnContext(ctx, "denormalized column drift",
+			slog.String("table", drift.Table), slog.String("column", drift.Column), slog.Int64("rows", drift.Rows))
+	}
+	if total == 0 || !repair {
+		return
+	}
+	repaired, err := s.Repair(ctx)
+	if err != nil {
+		s.log.ErrorContext(ctx, "consistency repair failed", slog.String("error", err.Error()))
+		return
+	}
+	var fixed int64
+	for _, drift := range repaired {
+		fixed += drift.Rows
+	}
+	s.log.InfoContext(ctx, "denormalized columns repaired", slog.Int64("rows", fixed))
+}
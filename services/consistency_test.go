@@ -0,0 +1,212 @@
+package service
+
+import (
+	"TeamTickBackend/dal/dao"
+	"TeamTickBackend/dal/models"
+	appErrors "TeamTickBackend/pkg/errors"
+	"bytes"
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// consistencyFixture 内存DAO上的一个用户组：teacher 创建，zhang3 加入、签到并提交审核申请
+type consistencyFixture struct {
+	factory     *dao.DAOFactory
+	users       *UserService
+	groups      *GroupsService
+	tasks       *TaskService
+	consistency *ConsistencyService
+	group       *models.Group
+	task        *models.Task
+}
+
+func setupConsistencyTest(t *testing.T) *consistencyFixture {
+	factory := dao.NewMemoryDAOFactory()
+	ctx := context.Background()
+	f := &consistencyFixture{
+		factory:     factory,
+		users:       NewUserService(factory.UserDAO, factory.DenormalizationDAO, factory.TransactionManager, discardLogger()),
+		groups:      newMemoryGroupsService(t, factory),
+		tasks:       newMemoryTaskService(t, factory),
+		consistency: NewConsistencyService(factory.DenormalizationDAO, factory.TransactionManager, discardLogger()),
+	}
+	audits := NewAuditRequestService(factory.TransactionManager, factory.CheckApplicationDAO, factory.TaskRecordDAO, factory.TaskDAO, factory.GroupDAO, factory.UserDAO)
+	seedUsers(t, factory, "teacher", "zhang3")
+	var err error
+	f.group, err = f.groups.CreateGroup(ctx, "实训一组", "", 1)
+	require.NoError(t, err)
+	_, err = f.groups.CreateJoinApplication(ctx, f.group.GroupID, 2, "加入")
+	require.NoError(t, err)
+	_, err = f.groups.AddMemberToGroup(ctx, f.group.GroupID, 2, 1, "zhang3")
+	require.NoError(t, err)
+	now := time.Now()
+	f.task, err = f.tasks.CreateTask(ctx, "早签到", "", f.group.GroupID, now.Add(-time.Hour), now.Add(time.Hour), 30.0, 120.0, 0, true, false, false, false)
+	require.NoError(t, err)
+	_, err = f.tasks.CheckInTask(ctx, f.task.TaskID, 2, 30.0, 120.0, now)
+	require.NoError(t, err)
+	_, err = audits.CreateAuditRequest(ctx, f.task.TaskID, 2, "定位失败", "")
+	require.NoError(t, err)
+	require.NoError(t, factory.GroupInviteDAO.Create(ctx, &models.GroupInvite{GroupID: f.group.GroupID, Code: "ABCD2345", Role: "member", CreatedBy: 2, CreatedByName: "zhang3", CreatedAt: now}))
+	return f
+}
+
+func totalDrift(drifts []models.ColumnDrift) int64 {
+	var total int64
+	for _, drift := range drifts {
+		total += drift.Rows
+	}
+	return total
+}
+
+func TestChangeUsername_Propagates(t *testing.T) {
+	f := setupConsistencyTest(t)
+	ctx := context.Background()
+
+	drifts, err := f.consistency.Check(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, totalDrift(drifts))
+
+	_, err = f.users.ChangeUsername(ctx, 2, "zhangsan")
+	require.NoError(t, err)
+	_, err = f.users.ChangeUsername(ctx, 1, "laoshi")
+	require.NoError(t, err)
+
+	member, err := f.factory.GroupMemberDAO.GetMemberByGroupIDAndUserID(ctx, f.group.GroupID, 2)
+	require.NoError(t, err)
+	assert.Equal(t, "zhangsan", member.Username)
+	record, err := f.factory.TaskRecordDAO.GetByTaskIDAndUserID(ctx, f.task.TaskID, 2)
+	require.NoError(t, err)
+	assert.Equal(t, "zhangsan", record.Username)
+	applications, err := f.factory.CheckApplicationDAO.GetByUserID(ctx, 2)
+	require.NoError(t, err)
+	assert.Equal(t, "zhangsan", applications[0].Username)
+	joinApplication, err := f.factory.JoinApplicationDAO.GetByGroupIDAndUserID(ctx, f.group.GroupID, 2)
+	require.NoError(t, err)
+	assert.Equal(t, "zhangsan", joinApplication.Username)
+	invites, err := f.factory.GroupInviteDAO.ListByGroupID(ctx, f.group.GroupID)
+	require.NoError(t, err)
+	assert.Equal(t, "zhangsan", invites[0].CreatedByName)
+	group, err := f.factory.GroupDAO.GetByGroupID(ctx, f.group.GroupID)
+	require.NoError(t, err)
+	assert.Equal(t, "laoshi", group.CreatorName)
+
+	drifts, err = f.consistency.Check(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, totalDrift(drifts))
+}
+
+// 改名后旧访问令牌在过期前仍然有效，之后的写入同样使用 users 表中的新用户名
+func TestChangeUsername_LaterWritesUseCurrentName(t *testing.T) {
+	f := setupConsistencyTest(t)
+	ctx := context.Background()
+	_, err := f.users.ChangeUsername(ctx, 2, "zhangsan")
+	require.NoError(t, err)
+
+	now := time.Now()
+	task, err := f.tasks.CreateTask(ctx, "晚签到", "", f.group.GroupID, now.Add(-time.Hour), now.Add(time.Hour), 30.0, 120.0, 0, true, false, false, false)
+	require.NoError(t, err)
+	record, err := f.tasks.CheckInTask(ctx, task.TaskID, 2, 30.0, 120.0, now)
+	require.NoError(t, err)
+	assert.Equal(t, "zhangsan", record.Username)
+	other, err := f.groups.CreateGroup(ctx, "实训二组", "", 2)
+	require.NoError(t, err)
+	assert.Equal(t, "zhangsan", other.CreatorName)
+
+	drifts, err := f.consistency.Check(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, totalDrift(drifts))
+}
+
+func TestChangeUsername_Invalid(t *testing.T) {
+	f := setupConsistencyTest(t)
+	ctx := context.Background()
+
+	_, err := f.users.ChangeUsername(ctx, 2, "teacher")
+	assert.ErrorIs(t, err, appErrors.ErrUserAlreadyExists)
+	_, err = f.users.ChangeUsername(ctx, 2, "zh")
+	assert.ErrorIs(t, err, appErrors.ErrUsernameInvalid)
+	_, err = f.users.ChangeUsername(ctx, 2, "zhang san")
+	assert.ErrorIs(t, err, appErrors.ErrUsernameInvalid)
+
+	// 改为当前用户名不做任何修改
+	user, err := f.users.ChangeUsername(ctx, 2, "zhang3")
+	require.NoError(t, err)
+	assert.Equal(t, "zhang3", user.Username)
+}
+
+func TestRenameGroupAndTask_Propagates(t *testing.T) {
+	f := setupConsistencyTest(t)
+	ctx := context.Background()
+
+	_, err := f.groups.UpdateGroup(ctx, f.group.GroupID, 1, "实训二组", "")
+	require.NoError(t, err)
+	task := f.task
+	_, err = f.tasks.UpdateTask(ctx, task.TaskID, "晚签到", "", task.StartTime, task.EndTime, task.Latitude, task.Longitude, task.Radius, true, false, false, false)
+	require.NoError(t, err)
+
+	member, err := f.factory.GroupMemberDAO.GetMemberByGroupIDAndUserID(ctx, f.group.GroupID, 2)
+	require.NoError(t, err)
+	assert.Equal(t, "实训二组", member.GroupName)
+	record, err := f.factory.TaskRecordDAO.GetByTaskIDAndUserID(ctx, task.TaskID, 2)
+	require.NoError(t, err)
+	assert.Equal(t, "实训二组", record.GroupName)
+	assert.Equal(t, "晚签到", record.TaskName)
+	applications, err := f.factory.CheckApplicationDAO.GetByUserID(ctx, 2)
+	require.NoError(t, err)
+	assert.Equal(t, "晚签到", applications[0].TaskName)
+}
+
+func TestConsistencyService_RepairsDrift(t *testing.T) {
+	f := setupConsistencyTest(t)
+	ctx := context.Background()
+
+	// 绕过服务层改名，模拟升级前的历史数据
+	require.NoError(t, f.factory.UserDAO.UpdateUsername(ctx, 2, "zhangsan"))
+	require.NoError(t, f.factory.GroupDAO.UpdateMessage(ctx, f.group.GroupID, "实训二组", ""))
+
+	drifts, err := f.consistency.Check(ctx)
+	require.NoError(t, err)
+	byColumn := make(map[string]int64)
+	for _, drift := range drifts {
+		byColumn[drift.Table+"."+drift.Column] = drift.Rows
+	}
+	assert.Equal(t, map[string]int64{
+		"groups.creator_name":              0,
+		"group_member.username":            1,
+		"group_member.group_name":          2,
+		"tasks_record.username":            1,
+		"tasks_record.group_name":          1,
+		"tasks_record.task_name":           0,
+		"check_application.username":       1,
+		"check_application.admin_username": 0,
+		"check_application.task_name":      0,
+		"join_application.username":        1,
+		"group_invites.created_by_name":    1,
+	}, byColumn)
+
+	repaired, err := f.consistency.Repair(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(8), totalDrift(repaired))
+
+	drifts, err = f.consistency.Check(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, totalDrift(drifts))
+}
+
+func TestConsistencyService_CheckOnceLogsDrift(t *testing.T) {
+	f := setupConsistencyTest(t)
+	ctx := context.Background()
+	var buf bytes.Buffer
+	f.consistency.log = slog.New(slog.NewTextHandler(&buf, nil))
+	require.NoError(t, f.factory.UserDAO.UpdateUsername(ctx, 2, "zhangsan"))
+
+	f.consistency.checkOnce(ctx, true)
+	assert.Contains(t, buf.String(), "denormalized column drift")
+	assert.Contains(t, buf.String(), "table=group_member column=username rows=1")
+	assert.Contains(t, buf.String(), "denormalized columns repaired")
+}
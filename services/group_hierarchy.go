@@ -0,0 +1,216 @@
+package service
+
+import (
+	"TeamTickBackend/dal/models"
+	appErrors "TeamTickBackend/pkg/errors"
+	"context"
+	"errors"
+	"slices"
+
+	"gorm.io/gorm"
+)
+
+// 在上级用户组下创建下级用户组，创建者需要具有上级用户组的 subgroup.manage 权限，并成为下级用户组的所有者
+func (s *GroupsService) CreateSubgroup(ctx context.Context, parentID int, groupName, description string, creatorID int) (*models.Group, error) {
+	var createdGroup models.Group
+	err := s.transactionManager.WithTransaction(ctx, func(tx *gorm.DB) error {
+		if _, err := s.getGroup(ctx, parentID, tx); err != nil {
+			if errors.Is(err, appErrors.ErrGroupNotFound) {
+				return appErrors.ErrGroupParentNotFound
+			}
+			return err
+		}
+		if err := s.CheckPermission(ctx, parentID, creatorID, models.PermissionSubgroups); err != nil {
+			return err
+		}
+		depth, err := s.groupDepth(ctx, parentID, tx)
+		if err != nil {
+			return err
+		}
+		if depth+1 > models.GroupMaxDepth {
+			return appErrors.ErrGroupHierarchyTooDeep
+		}
+		createdGroup = models.Group{
+			GroupName:   groupName,
+			Description: description,
+			CreatorID:   creatorID,
+			ParentID:    parentID,
+		}
+		return s.createGroup(ctx, &createdGroup, tx)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &createdGroup, nil
+}
+
+// 调整用户组的上级用户组，parentID 为0时成为顶级用户组。操作者需要具有该用户组、原上级用户组与新上级用户组的
+// subgroup.manage 权限；新的上级用户组不能是该用户组自身或其下级用户组，调整后的层级不能超过 GroupMaxDepth
+func (s *GroupsService) SetParentGroup(ctx context.Context, groupID, parentID, operatorID int) (*models.Group, error) {
+	var updatedGroup models.Group
+	err := s.transactionManager.WithTransaction(ctx, func(tx *gorm.DB) error {
+		group, err := s.getGroup(ctx, groupID, tx)
+		if err != nil {
+			return err
+		}
+		if err := s.CheckPermission(ctx, groupID, operatorID, models.PermissionSubgroups); err != nil {
+			return err
+		}
+		if group.ParentID == parentID {
+			updatedGroup = *group
+			return nil
+		}
+		// 移出原上级用户组同样需要原上级用户组的权限，下级用户组的管理员不能自行脱离上级用户组
+		if group.ParentID != 0 {
+			if err := s.CheckPermission(ctx, group.ParentID, operatorID, models.PermissionSubgroups); err != nil {
+				return err
+			}
+		}
+		if parentID != 0 {
+			if err := s.checkNewParent(ctx, group, parentID, operatorID, tx); err != nil {
+				return err
+			}
+		}
+		if err := s.groupDao.UpdateParent(ctx, groupID, parentID, tx); err != nil {
+			return appErrors.ErrGroupUpdateFailed.WithError(err)
+		}
+		group.ParentID = parentID
+		updatedGroup = *group
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &updatedGroup, nil
+}
+
+// checkNewParent 校验新的上级用户组存在、操作者具有其 subgroup.manage 权限、不会成环且层级不超过上限
+func (s *GroupsService) checkNewParent(ctx context.Context, group *models.Group, parentID, operatorID int, tx *gorm.DB) error {
+	if parentID == group.GroupID {
+		return appErrors.ErrGroupHierarchyCycle
+	}
+	if _, err := s.getGroup(ctx, parentID, tx); err != nil {
+		if errors.Is(err, appErrors.ErrGroupNotFound) {
+			return appErrors.ErrGroupParentNotFound
+		}
+		return err
+	}
+	if err := s.CheckPermission(ctx, parentID, operatorID, models.PermissionSubgroups); err != nil {
+		return err
+	}
+	descendants, err := s.groupDao.GetDescendants(ctx, group.GroupID, tx)
+	if err != nil {
+		return appErrors.ErrDatabaseOperation.WithError(err)
+	}
+	// 该用户组连同下级用户组的层数，下级用户组按层级排列，上级用户组总是先出现
+	height := 1
+	levels := map[int]int{group.GroupID: 1}
+	for _, descendant := range descendants {
+		if descendant.GroupID == parentID {
+			return appErrors.ErrGroupHierarchyCycle
+		}
+		levels[descendant.GroupID] = levels[descendant.ParentID] + 1
+		height = max(height, levels[descendant.GroupID])
+	}
+	depth, err := s.groupDepth(ctx, parentID, tx)
+	if err != nil {
+		return err
+	}
+	if depth+height > models.GroupMaxDepth {
+		return appErrors.ErrGroupHierarchyTooDeep
+	}
+	return nil
+}
+
+// groupDepth 用户组所在的层级，顶级用户组为第1层
+func (s *GroupsService) groupDepth(ctx context.Context, groupID int, tx *gorm.DB) (int, error) {
+	ancestors, err := s.groupDao.GetAncestors(ctx, groupID, tx)
+	if err != nil {
+		return 0, appErrors.ErrDatabaseOperation.WithError(err)
+	}
+	return len(ancestors) + 1, nil
+}
+
+// 检查用户是否为用户组或其任一下级用户组的成员，上级用户组的签到任务对下级用户组的成员同样生效
+func (s *GroupsService) CheckUserInGroupTree(ctx context.Context, groupID, userID int) error {
+	err := s.CheckUserExistInGroup(ctx, groupID, userID)
+	if !errors.Is(err, appErrors.ErrGroupMemberNotFound) {
+		return err
+	}
+	descendants, err := s.groupDao.GetDescendants(ctx, groupID)
+	if err != nil {
+		return appErrors.ErrDatabaseOperation.WithError(err)
+	}
+	if len(descendants) == 0 {
+		return appErrors.ErrGroupMemberNotFound
+	}
+	members, err := s.groupMemberDao.GetByUserIDAndGroupIDs(ctx, userID, groupIDs(descendants))
+	if err != nil {
+		return appErrors.ErrDatabaseOperation.WithError(err)
+	}
+	if len(members) == 0 {
+		return appErrors.ErrGroupMemberNotFound
+	}
+	return nil
+}
+
+// inheritedPermissions 用户从上级用户组继承的权限：任一上级用户组的管理员在下级用户组中具有管理员权限。
+// 上级用户组要求管理员开启两步验证而用户未开启时不从该用户组继承，没有其他可继承的上级用户组时
+// 返回 *appErrors.AdminTwoFactorRequiredError
+func (s *GroupsService) inheritedPermissions(ctx context.Context, groupID, userID int, tx ...*gorm.DB) ([]string, error) {
+	ancestors, err := s.groupDao.GetAncestors(ctx, groupID, tx...)
+	if err != nil {
+		return nil, appErrors.ErrDatabaseOperation.WithError(err)
+	}
+	if len(ancestors) == 0 {
+		return nil, nil
+	}
+	members, err := s.groupMemberDao.GetByUserIDAndGroupIDs(ctx, userID, groupIDs(ancestors), tx...)
+	if err != nil {
+		return nil, appErrors.ErrDatabaseOperation.WithError(err)
+	}
+	ancestorByID := make(map[int]*models.Group, len(ancestors))
+	for _, ancestor := range ancestors {
+		ancestorByID[ancestor.GroupID] = ancestor
+	}
+	var twoFactorErr error
+	for _, member := range members {
+		if member.Role != "admin" {
+			continue
+		}
+		if err := s.adminTwoFactorError(ctx, ancestorByID[member.GroupID], userID); err != nil {
+			if !errors.Is(err, appErrors.ErrAdminTwoFactorRequired) {
+				return nil, err
+			}
+			twoFactorErr = err
+			continue
+		}
+		return models.AdminPermissions, nil
+	}
+	return nil, twoFactorErr
+}
+
+// mergePermissions 合并两组权限，按 models.GroupPermissions 的顺序返回
+func mergePermissions(granted, inherited []string) []string {
+	if len(inherited) == 0 {
+		return granted
+	}
+	if len(granted) == 0 {
+		return inherited
+	}
+	merged := make([]string, 0, len(models.GroupPermissions))
+	for _, permission := range models.GroupPermissions {
+		if slices.Contains(granted, permission) || slices.Contains(inherited, permission) {
+			merged = append(merged, permission)
+		}
+	}
+	return merged
+}
+
+func groupIDs(groups []*models.Group) []int {
+	ids := make([]int, 0, len(groups))
+	for _, group := range groups {
+		ids = append(ids, group.GroupID)
+	}
+	return ids
+}
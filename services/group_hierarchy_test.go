@@ -0,0 +1,182 @@
+package service
+
+import (
+	"TeamTickBackend/dal/dao"
+	"TeamTickBackend/dal/models"
+	appErrors "TeamTickBackend/pkg/errors"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupHierarchyTest 内存DAO上 teacher（用户ID 1）创建的学院，学院下有 teacher 创建的班级；
+// assistant（用户ID 2）是学院的普通成员，student1、student2（用户ID 3、4）是班级的普通成员
+func setupHierarchyTest(t *testing.T) (*GroupsService, *dao.DAOFactory, *models.Group, *models.Group) {
+	factory := dao.NewMemoryDAOFactory()
+	ctx := context.Background()
+	seedUsers(t, factory, "teacher", "assistant", "student1", "student2")
+	groups := newMemoryGroupsService(t, factory)
+	college, err := groups.CreateGroup(ctx, "计算机学院", "", 1)
+	require.NoError(t, err)
+	class, err := groups.CreateSubgroup(ctx, college.GroupID, "软件工程1班", "", 1)
+	require.NoError(t, err)
+	_, err = groups.AddMemberToGroup(ctx, college.GroupID, 2, 1, "assistant")
+	require.NoError(t, err)
+	for userID, name := range map[int]string{3: "student1", 4: "student2"} {
+		_, err := groups.AddMemberToGroup(ctx, class.GroupID, userID, 1, name)
+		require.NoError(t, err)
+	}
+	return groups, factory, college, class
+}
+
+func TestSubgroup_InheritsTasksAndAdmins(t *testing.T) {
+	groups, factory, college, class := setupHierarchyTest(t)
+	ctx := context.Background()
+	assert.Equal(t, college.GroupID, class.ParentID)
+
+	// 学院的任务对班级成员同样生效
+	now := time.Now()
+	require.NoError(t, factory.TaskDAO.Create(ctx, &models.Task{TaskName: "开学典礼", GroupID: college.GroupID, StartTime: now.Add(-time.Hour), EndTime: now.Add(time.Hour)}))
+	require.NoError(t, factory.TaskDAO.Create(ctx, &models.Task{TaskName: "班会", GroupID: class.GroupID, StartTime: now.Add(-time.Hour), EndTime: now.Add(time.Hour)}))
+	tasks, err := factory.TaskDAO.GetActiveTasksByUserID(ctx, 3)
+	require.NoError(t, err)
+	assert.Len(t, tasks, 2)
+	tasks, err = factory.TaskDAO.GetByUserID(ctx, 2)
+	require.NoError(t, err)
+	require.Len(t, tasks, 1)
+	assert.Equal(t, "开学典礼", tasks[0].TaskName)
+	assert.NoError(t, groups.CheckUserInGroupTree(ctx, college.GroupID, 3))
+	assert.ErrorIs(t, groups.CheckUserInGroupTree(ctx, class.GroupID, 2), appErrors.ErrGroupMemberNotFound)
+
+	// 学院的普通成员在班级中没有权限，成为学院管理员后不需要加入班级即可管理班级
+	assert.ErrorIs(t, groups.CheckPermission(ctx, class.GroupID, 2, models.PermissionMemberRemove), appErrors.ErrGroupMemberNotFound)
+	_, err = groups.SetMemberRole(ctx, college.GroupID, 2, "admin", roleOwner)
+	require.NoError(t, err)
+	assert.NoError(t, groups.CheckPermission(ctx, class.GroupID, 2, models.PermissionMemberRemove))
+	assert.ErrorIs(t, groups.CheckPermission(ctx, class.GroupID, 2, models.PermissionGroupDelete), appErrors.ErrRolePermissionDenied)
+	status, err := groups.GetUserGroupStatus(ctx, class.GroupID, 2)
+	require.NoError(t, err)
+	assert.Equal(t, "none", status.Status)
+	assert.Contains(t, status.Permissions, models.PermissionTaskCreate)
+	// 权限只向下继承
+	assert.ErrorIs(t, groups.CheckPermission(ctx, college.GroupID, 3, models.PermissionTaskView), appErrors.ErrGroupMemberNotFound)
+
+	// 管理员的用户组列表包含下级用户组
+	list, err := groups.GetGroupsByUserID(ctx, 2)
+	require.NoError(t, err)
+	assert.Equal(t, []int{college.GroupID, class.GroupID}, groupIDs(list))
+	list, err = groups.GetGroupsByUserID(ctx, 2, "created")
+	require.NoError(t, err)
+	assert.Equal(t, []int{college.GroupID, class.GroupID}, groupIDs(list))
+	list, err = groups.GetGroupsByUserID(ctx, 3)
+	require.NoError(t, err)
+	assert.Equal(t, []int{class.GroupID}, groupIDs(list))
+}
+
+func TestSubgroup_InheritedAdminRequiresAncestorTwoFactor(t *testing.T) {
+	groups, factory, college, class := setupHierarchyTest(t)
+	ctx := context.Background()
+	_, err := groups.SetMemberRole(ctx, college.GroupID, 2, "admin", roleOwner)
+	require.NoError(t, err)
+	// 学院要求管理员开启两步验证，班级没有要求
+	require.NoError(t, factory.GroupDAO.UpdateRequireAdmin2FA(ctx, college.GroupID, true))
+
+	err = groups.CheckPermission(ctx, class.GroupID, 2, models.PermissionMemberRemove)
+	assert.ErrorIs(t, err, appErrors.ErrAdminTwoFactorRequired)
+	status, err := groups.GetUserGroupStatus(ctx, class.GroupID, 2)
+	require.NoError(t, err)
+	assert.Empty(t, status.Permissions)
+
+	require.NoError(t, factory.UserDAO.UpdateTwoFactorEnabled(ctx, 2, true))
+	assert.NoError(t, groups.CheckPermission(ctx, class.GroupID, 2, models.PermissionMemberRemove))
+	status, err = groups.GetUserGroupStatus(ctx, class.GroupID, 2)
+	require.NoError(t, err)
+	assert.Contains(t, status.Permissions, models.PermissionMemberRemove)
+}
+
+func TestSetParentGroup_RejectsCyclesAndDepth(t *testing.T) {
+	groups, factory, college, class := setupHierarchyTest(t)
+	ctx := context.Background()
+
+	// 不能挂在自身或下级用户组下
+	_, err := groups.SetParentGroup(ctx, college.GroupID, college.GroupID, 1)
+	assert.ErrorIs(t, err, appErrors.ErrGroupHierarchyCycle)
+	_, err = groups.SetParentGroup(ctx, college.GroupID, class.GroupID, 1)
+	assert.ErrorIs(t, err, appErrors.ErrGroupHierarchyCycle)
+	_, err = groups.SetParentGroup(ctx, class.GroupID, 99, 1)
+	assert.ErrorIs(t, err, appErrors.ErrGroupParentNotFound)
+	// 班级成员不能调整班级的上级用户组
+	_, err = groups.SetParentGroup(ctx, class.GroupID, 0, 3)
+	assert.ErrorIs(t, err, appErrors.ErrRolePermissionDenied)
+
+	// 层级最多 GroupMaxDepth 层
+	parent := class
+	for depth := 3; depth <= models.GroupMaxDepth; depth++ {
+		parent, err = groups.CreateSubgroup(ctx, parent.GroupID, "小组", "", 1)
+		require.NoError(t, err)
+	}
+	_, err = groups.CreateSubgroup(ctx, parent.GroupID, "小组", "", 1)
+	assert.ErrorIs(t, err, appErrors.ErrGroupHierarchyTooDeep)
+	other, err := groups.CreateGroup(ctx, "数学学院", "", 1)
+	require.NoError(t, err)
+	_, err = groups.SetParentGroup(ctx, college.GroupID, other.GroupID, 1)
+	assert.ErrorIs(t, err, appErrors.ErrGroupHierarchyTooDeep)
+
+	// 班级移到数学学院下，整棵子树随之移动
+	moved, err := groups.SetParentGroup(ctx, class.GroupID, other.GroupID, 1)
+	require.NoError(t, err)
+	assert.Equal(t, other.GroupID, moved.ParentID)
+	ancestors, err := factory.GroupDAO.GetAncestors(ctx, parent.GroupID)
+	require.NoError(t, err)
+	assert.Equal(t, other.GroupID, ancestors[len(ancestors)-1].GroupID)
+
+	// 删除中间层级的用户组时，下级用户组挂到其上级用户组下
+	require.NoError(t, groups.DeleteGroup(ctx, class.GroupID, 1))
+	children, err := factory.GroupDAO.GetDescendants(ctx, other.GroupID)
+	require.NoError(t, err)
+	require.NotEmpty(t, children)
+	assert.Equal(t, other.GroupID, children[0].ParentID)
+}
+
+func TestGroupStats_RollsUpSubgroups(t *testing.T) {
+	groups, factory, college, class := setupHierarchyTest(t)
+	ctx := context.Background()
+	stats := NewGroupStatsService(factory.GroupDAO, factory.GroupMemberDAO, factory.GroupStatsDAO, groups)
+	lab, err := groups.CreateSubgroup(ctx, class.GroupID, "实验小组", "", 1)
+	require.NoError(t, err)
+	_, err = groups.AddMemberToGroup(ctx, lab.GroupID, 3, 1, "student1")
+	require.NoError(t, err)
+
+	now := time.Now()
+	for _, task := range []*models.Task{
+		{TaskName: "开学典礼", GroupID: college.GroupID, StartTime: now.Add(-time.Hour), EndTime: now.Add(time.Hour)},
+		{TaskName: "班会", GroupID: class.GroupID, StartTime: now.Add(-2 * time.Hour), EndTime: now.Add(-time.Hour)},
+		{TaskName: "实验", GroupID: lab.GroupID, StartTime: now.Add(-time.Hour), EndTime: now.Add(time.Hour)},
+	} {
+		require.NoError(t, factory.TaskDAO.Create(ctx, task))
+	}
+	for userID, taskID := range map[int]int{3: 2, 4: 2} {
+		require.NoError(t, factory.TaskRecordDAO.Create(ctx, &models.TaskRecord{TaskID: taskID, GroupID: class.GroupID, UserID: userID}))
+	}
+	require.NoError(t, factory.TaskRecordDAO.Create(ctx, &models.TaskRecord{TaskID: 3, GroupID: lab.GroupID, UserID: 3}))
+
+	total, children, err := stats.Stats(ctx, college.GroupID, 1)
+	require.NoError(t, err)
+	// teacher、assistant、student1、student2，student1 同时在班级与实验小组中只计一次
+	assert.Equal(t, models.GroupStats{GroupID: college.GroupID, GroupName: "计算机学院", Subgroups: 2, Members: 4, Tasks: 3, ActiveTasks: 2, CheckIns: 3}, *total)
+	require.Len(t, children, 1)
+	assert.Equal(t, models.GroupStats{GroupID: class.GroupID, GroupName: "软件工程1班", ParentID: college.GroupID, Subgroups: 1, Members: 3, Tasks: 2, ActiveTasks: 1, CheckIns: 3}, *children[0])
+
+	// 查看统计需要 records.view 权限，学院管理员可以查看下级用户组的统计
+	_, _, err = stats.Stats(ctx, class.GroupID, 3)
+	assert.ErrorIs(t, err, appErrors.ErrRolePermissionDenied)
+	_, err = groups.SetMemberRole(ctx, college.GroupID, 2, "admin", roleOwner)
+	require.NoError(t, err)
+	total, children, err = stats.Stats(ctx, lab.GroupID, 2)
+	require.NoError(t, err)
+	assert.Equal(t, 2, total.Members)
+	assert.Empty(t, children)
+}
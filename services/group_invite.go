@@ -0,0 +1,261 @@
+package service
+
+import (
+	"TeamTickBackend/config"
+	"TeamTickBackend/dal/dao"
+	"TeamTickBackend/dal/models"
+	appErrors "TeamTickBackend/pkg/errors"
+	"context"
+	"crypto/rand"
+	"encoding/base32"
+	"errors"
+	"log/slog"
+	"net/url"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// 邀请码使用大写字母与数字2-7，8位约40位随机数
+var inviteCodeEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// 生成邀请码时与已有邀请码重复的重试次数
+const inviteCodeAttempts = 3
+
+// InviteOptions 创建邀请码的选项
+type InviteOptions struct {
+	// Role 加入后的组内角色，为空时为普通成员
+	Role string
+	// MaxUses 可使用次数上限，为0时不限次数
+	MaxUses int
+	// ExpiresIn 有效时长，为0时长期有效
+	ExpiresIn time.Duration
+}
+
+// GroupInviteService 用户组邀请码：具有 member.invite 权限的成员创建与撤销邀请码，用户凭邀请码无需审批直接加入用户组
+type GroupInviteService struct {
+	inviteDao          dao.GroupInviteDAO
+	joinApplicationDao dao.JoinApplicationDAO
+	transactionManager dao.TransactionManager
+	groupsService      *GroupsService
+	config             config.GroupInvitesConfig
+	now                func() time.Time
+	log                *slog.Logger
+}
+
+func NewGroupInviteService(
+	inviteDao dao.GroupInviteDAO,
+	joinApplicationDao dao.JoinApplicationDAO,
+	transactionManager dao.TransactionManager,
+	groupsService *GroupsService,
+	cfg config.GroupInvitesConfig,
+	log *slog.Logger,
+) *GroupInviteService {
+	return &GroupInviteService{
+		inviteDao:          inviteDao,
+		joinApplicationDao: joinApplicationDao,
+		transactionManager: transactionManager,
+		groupsService:      groupsService,
+		config:             cfg,
+		now:                time.Now,
+		log:                log,
+	}
+}
+
+// CreateInvite 创建邀请码，需要具有 member.invite 权限
+func (s *GroupInviteService) CreateInvite(ctx context.Context, groupID, operatorID int, options InviteOptions) (*models.GroupInvite, error) {
+	if options.Role == "" {
+		options.Role = "member"
+	}
+	if options.Role != "member" && options.Role != "admin" {
+		return nil, appErrors.ErrGroupInviteInvalid
+	}
+	if options.MaxUses < 0 || options.ExpiresIn < 0 {
+		return nil, appErrors.ErrGroupInviteInvalid
+	}
+	operatorPermissions, err := s.checkOperator(ctx, groupID, operatorID)
+	if err != nil {
+		return nil, err
+	}
+	// 只有组管理员可以创建以管理员身份加入的邀请码
+	if options.Role == "admin" && !containsAll(operatorPermissions, models.AdminPermissions) {
+		return nil, appErrors.ErrGroupPermissionExceeded
+	}
+	invites, err := s.inviteDao.ListByGroupID(ctx, groupID)
+	if err != nil {
+		return nil, appErrors.ErrDatabaseOperation.WithError(err)
+	}
+	now := s.now()
+	active := 0
+	for _, invite := range invites {
+		if invite.Usable(now) {
+			active++
+		}
+	}
+	if active >= s.config.MaxPerGroup {
+		return nil, appErrors.ErrGroupInviteLimitReached
+	}
+
+	operatorName, err := currentUsername(ctx, s.groupsService.userDao, operatorID, nil)
+	if err != nil {
+		return nil, err
+	}
+	invite := &models.GroupInvite{
+		GroupID:       groupID,
+		Role:          options.Role,
+		CreatedBy:     operatorID,
+		CreatedByName: operatorName,
+		MaxUses:       options.MaxUses,
+		CreatedAt:     now,
+	}
+	if options.ExpiresIn > 0 {
+		expiresAt := now.Add(options.ExpiresIn)
+		invite.ExpiresAt = &expiresAt
+	}
+	for attempt := 1; ; attempt++ {
+		invite.Code, err = generateInviteCode()
+		if err != nil {
+			return nil, appErrors.ErrDatabaseOperation.WithError(err)
+		}
+		err = s.inviteDao.Create(ctx, invite)
+		if err == nil {
+			break
+		}
+		if !errors.Is(err, gorm.ErrDuplicatedKey) || attempt == inviteCodeAttempts {
+			return nil, appErrors.ErrDatabaseOperation.WithError(err)
+		}
+	}
+	s.log.InfoContext(ctx, "group invite created",
+		slog.Int("group_id", groupID), slog.Int("invite_id", invite.ID), slog.String("role", invite.Role), slog.Int("operator_id", operatorID))
+	return invite, nil
+}
+
+// ListInvites 查询用户组未撤销的邀请码，包括已过期与已用完的
+func (s *GroupInviteService) ListInvites(ctx context.Context, groupID, operatorID int) ([]*models.GroupInvite, error) {
+	if _, err := s.checkOperator(ctx, groupID, operatorID); err != nil {
+		return nil, err
+	}
+	invites, err := s.inviteDao.ListByGroupID(ctx, groupID)
+	if err != nil {
+		return nil, appErrors.ErrDatabaseOperation.WithError(err)
+	}
+	return invites, nil
+}
+
+// RevokeInvite 撤销邀请码，已通过该邀请码加入的成员不受影响
+func (s *GroupInviteService) RevokeInvite(ctx context.Context, groupID, inviteID, operatorID int) error {
+	if _, err := s.checkOperator(ctx, groupID, operatorID); err != nil {
+		return err
+	}
+	revoked, err := s.inviteDao.Revoke(ctx, inviteID, groupID, s.now())
+	if err != nil {
+		return appErrors.ErrDatabaseOperation.WithError(err)
+	}
+	if !revoked {
+		return appErrors.ErrGroupInviteNotFound
+	}
+	s.log.InfoContext(ctx, "group invite revoked", slog.Int("group_id", groupID), slog.Int("invite_id", inviteID), slog.Int("operator_id", operatorID))
+	return nil
+}
+
+// JoinByCode 凭邀请码加入用户组，除已关闭的用户组外不受加入方式限制；与 AddMemberToGroup 相同地添加成员并更新成员数量，
+// 同一事务中计入邀请码使用次数，并将该用户待审批的加入申请标记为已通过
+func (s *GroupInviteService) JoinByCode(ctx context.Context, code string, userID int) (*models.GroupMember, error) {
+	code = normalizeInviteCode(code)
+	if code == "" {
+		return nil, appErrors.ErrGroupInviteNotFound
+	}
+	var member *models.GroupMember
+	err := s.transactionManager.WithTransaction(ctx, func(tx *gorm.DB) error {
+		invite, err := s.inviteDao.GetByCode(ctx, code, tx)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return appErrors.ErrGroupInviteNotFound
+			}
+			return appErrors.ErrDatabaseOperation.WithError(err)
+		}
+		now := s.now()
+		if !invite.Usable(now) {
+			return appErrors.ErrGroupInviteUnavailable
+		}
+		// 仅限邀请的用户组同样接受邀请码，已关闭的用户组不接受任何人加入
+		group, err := s.groupsService.getGroup(ctx, invite.GroupID, tx)
+		if err != nil {
+			return err
+		}
+		if group.EffectiveJoinPolicy() == models.JoinPolicyClosed {
+			return appErrors.ErrGroupClosed
+		}
+		username, err := currentUsername(ctx, s.groupsService.userDao, userID, tx)
+		if err != nil {
+			return err
+		}
+		member, err = s.groupsService.addMember(ctx, invite.GroupID, userID, username, invite.Role, tx)
+		if err != nil {
+			return err
+		}
+		// 并发使用最后一个名额时只有一个能成功，其余回滚
+		consumed, err := s.inviteDao.ConsumeUse(ctx, invite.ID, now, tx)
+		if err != nil {
+			return appErrors.ErrDatabaseOperation.WithError(err)
+		}
+		if !consumed {
+			return appErrors.ErrGroupInviteUnavailable
+		}
+		application, err := s.joinApplicationDao.GetByGroupIDAndUserID(ctx, invite.GroupID, userID, tx)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil
+			}
+			return appErrors.ErrDatabaseOperation.WithError(err)
+		}
+		if application.Status == "pending" {
+			if err := s.joinApplicationDao.UpdateStatus(ctx, application.RequestID, "accepted", tx); err != nil {
+				return appErrors.ErrJoinApplicationUpdateFailed.WithError(err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.log.InfoContext(ctx, "joined group by invite", slog.Int("group_id", member.GroupID), slog.Int("user_id", userID), slog.String("role", member.Role))
+	return member, nil
+}
+
+// Link 邀请链接，未配置前端加入页面地址时为空
+func (s *GroupInviteService) Link(code string) string {
+	if s.config.LinkBaseURL == "" {
+		return ""
+	}
+	u, err := url.Parse(s.config.LinkBaseURL)
+	if err != nil {
+		return ""
+	}
+	query := u.Query()
+	query.Set("code", code)
+	u.RawQuery = query.Encode()
+	return u.String()
+}
+
+// checkOperator 用户组存在且操作者具有 member.invite 权限，返回操作者的全部权限
+func (s *GroupInviteService) checkOperator(ctx context.Context, groupID, operatorID int) ([]string, error) {
+	if _, err := s.groupsService.GetGroupByGroupID(ctx, groupID); err != nil {
+		return nil, err
+	}
+	return s.groupsService.checkOperatorPermission(ctx, groupID, operatorID, models.PermissionMemberInvite)
+}
+
+func generateInviteCode() (string, error) {
+	buf := make([]byte, 5)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return inviteCodeEncoding.EncodeToString(buf), nil
+}
+
+// normalizeInviteCode 输入邀请码时忽略大小写、空格与连字符
+func normalizeInviteCode(code string) string {
+	return strings.NewReplacer("-", "", " ", "").Replace(strings.ToUpper(strings.TrimSpace(code)))
+}
@@ -0,0 +1,183 @@
+package service
+
+import (
+	"TeamTickBackend/config"
+	"TeamTickBackend/dal/dao"
+	"TeamTickBackend/dal/models"
+	appErrors "TeamTickBackend/pkg/errors"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// inviteFixture 内存DAO上 teacher（用户ID 1）创建的用户组，student1 至 student3 尚未加入
+type inviteFixture struct {
+	factory *dao.DAOFactory
+	groups  *GroupsService
+	invites *GroupInviteService
+	group   *models.Group
+}
+
+func setupInviteTest(t *testing.T) *inviteFixture {
+	factory := dao.NewMemoryDAOFactory()
+	ctx := context.Background()
+	seedUsers(t, factory, "teacher", "student1", "student2", "student3")
+	groups := newMemoryGroupsService(t, factory)
+	group, err := groups.CreateGroup(ctx, "软件工程", "", 1)
+	require.NoError(t, err)
+	return &inviteFixture{
+		factory: factory,
+		groups:  groups,
+		invites: NewGroupInviteService(factory.GroupInviteDAO, factory.JoinApplicationDAO, factory.TransactionManager, groups,
+			config.GroupInvitesConfig{LinkBaseURL: "https://teamtick.example.edu/join?from=share", MaxPerGroup: 2}, discardLogger()),
+		group: group,
+	}
+}
+
+func TestJoinByCode_AddsMemberAndEnforcesMaxUses(t *testing.T) {
+	f := setupInviteTest(t)
+	ctx := context.Background()
+	invite, err := f.invites.CreateInvite(ctx, f.group.GroupID, 1, InviteOptions{MaxUses: 1})
+	require.NoError(t, err)
+	assert.Len(t, invite.Code, 8)
+	assert.Equal(t, "member", invite.Role)
+	assert.Equal(t, "https://teamtick.example.edu/join?code="+invite.Code+"&from=share", f.invites.Link(invite.Code))
+
+	// 待审批的加入申请一并标记为已通过
+	_, err = f.groups.CreateJoinApplication(ctx, f.group.GroupID, 2, "")
+	require.NoError(t, err)
+	member, err := f.invites.JoinByCode(ctx, " "+invite.Code[:4]+"-"+invite.Code[4:]+" ", 2)
+	require.NoError(t, err)
+	assert.Equal(t, "member", member.Role)
+	assert.Equal(t, "软件工程", member.GroupName)
+	application, err := f.factory.JoinApplicationDAO.GetByGroupIDAndUserID(ctx, f.group.GroupID, 2)
+	require.NoError(t, err)
+	assert.Equal(t, "accepted", application.Status)
+
+	group, err := f.groups.GetGroupByGroupID(ctx, f.group.GroupID)
+	require.NoError(t, err)
+	assert.Equal(t, 2, group.MemberNum)
+
+	_, err = f.invites.JoinByCode(ctx, invite.Code, 3)
+	assert.ErrorIs(t, err, appErrors.ErrGroupInviteUnavailable)
+	_, err = f.invites.JoinByCode(ctx, "AAAAAAAA", 3)
+	assert.ErrorIs(t, err, appErrors.ErrGroupInviteNotFound)
+
+	// 已是成员时不计入使用次数
+	unlimited, err := f.invites.CreateInvite(ctx, f.group.GroupID, 1, InviteOptions{Role: "admin"})
+	require.NoError(t, err)
+	_, err = f.invites.JoinByCode(ctx, unlimited.Code, 2)
+	assert.ErrorIs(t, err, appErrors.ErrGroupMemberAlreadyExists)
+	member, err = f.invites.JoinByCode(ctx, unlimited.Code, 3)
+	require.NoError(t, err)
+	assert.Equal(t, "admin", member.Role)
+	stored, err := f.factory.GroupInviteDAO.GetByID(ctx, unlimited.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, stored.Uses)
+}
+
+func TestRevokeInvite_BlocksFurtherJoins(t *testing.T) {
+	f := setupInviteTest(t)
+	ctx := context.Background()
+	invite, err := f.invites.CreateInvite(ctx, f.group.GroupID, 1, InviteOptions{ExpiresIn: time.Hour})
+	require.NoError(t, err)
+	require.NotNil(t, invite.ExpiresAt)
+
+	// 只有组管理员可以管理邀请码
+	_, err = f.invites.JoinByCode(ctx, invite.Code, 2)
+	require.NoError(t, err)
+	assert.ErrorIs(t, f.invites.RevokeInvite(ctx, f.group.GroupID, invite.ID, 2), appErrors.ErrRolePermissionDenied)
+	_, err = f.invites.CreateInvite(ctx, f.group.GroupID, 2, InviteOptions{})
+	assert.ErrorIs(t, err, appErrors.ErrRolePermissionDenied)
+
+	require.NoError(t, f.invites.RevokeInvite(ctx, f.group.GroupID, invite.ID, 1))
+	assert.ErrorIs(t, f.invites.RevokeInvite(ctx, f.group.GroupID, invite.ID, 1), appErrors.ErrGroupInviteNotFound)
+	_, err = f.invites.JoinByCode(ctx, invite.Code, 3)
+	assert.ErrorIs(t, err, appErrors.ErrGroupInviteUnavailable)
+	invites, err := f.invites.ListInvites(ctx, f.group.GroupID, 1)
+	require.NoError(t, err)
+	assert.Empty(t, invites)
+
+	// 过期的邀请码不可使用
+	expired, err := f.invites.CreateInvite(ctx, f.group.GroupID, 1, InviteOptions{ExpiresIn: time.Hour})
+	require.NoError(t, err)
+	f.invites.now = func() time.Time { return time.Now().Add(2 * time.Hour) }
+	_, err = f.invites.JoinByCode(ctx, expired.Code, 3)
+	assert.ErrorIs(t, err, appErrors.ErrGroupInviteUnavailable)
+}
+
+func TestCreateInvite_ValidatesOptionsAndLimit(t *testing.T) {
+	f := setupInviteTest(t)
+	ctx := context.Background()
+	_, err := f.invites.CreateInvite(ctx, f.group.GroupID, 1, InviteOptions{Role: "owner"})
+	assert.ErrorIs(t, err, appErrors.ErrGroupInviteInvalid)
+	_, err = f.invites.CreateInvite(ctx, f.group.GroupID, 1, InviteOptions{MaxUses: -1})
+	assert.ErrorIs(t, err, appErrors.ErrGroupInviteInvalid)
+	_, err = f.invites.CreateInvite(ctx, 99, 1, InviteOptions{})
+	assert.ErrorIs(t, err, appErrors.ErrGroupNotFound)
+
+	for i := 0; i < 2; i++ {
+		_, err = f.invites.CreateInvite(ctx, f.group.GroupID, 1, InviteOptions{})
+		require.NoError(t, err)
+	}
+	_, err = f.invites.CreateInvite(ctx, f.group.GroupID, 1, InviteOptions{})
+	assert.ErrorIs(t, err, appErrors.ErrGroupInviteLimitReached)
+}
+
+func TestJoinPolicy_OpenAcceptsApplicationsImmediately(t *testing.T) {
+	f := setupInviteTest(t)
+	ctx := context.Background()
+	assert.ErrorIs(t, f.groups.SetJoinPolicy(ctx, f.group.GroupID, 1, "public"), appErrors.ErrJoinPolicyInvalid)
+	require.NoError(t, f.groups.SetJoinPolicy(ctx, f.group.GroupID, 1, models.JoinPolicyOpen))
+
+	application, err := f.groups.CreateJoinApplication(ctx, f.group.GroupID, 2, "")
+	require.NoError(t, err)
+	assert.Equal(t, "accepted", application.Status)
+	status, err := f.groups.GetUserGroupStatus(ctx, f.group.GroupID, 2)
+	require.NoError(t, err)
+	assert.Equal(t, "member", status.Status)
+	assert.False(t, status.CanApply)
+
+	group, err := f.groups.GetGroupByGroupID(ctx, f.group.GroupID)
+	require.NoError(t, err)
+	assert.Equal(t, 2, group.MemberNum)
+	assert.Equal(t, models.JoinPolicyOpen, group.JoinPolicy)
+}
+
+func TestJoinPolicy_InviteOnlyAndClosed(t *testing.T) {
+	f := setupInviteTest(t)
+	ctx := context.Background()
+	invite, err := f.invites.CreateInvite(ctx, f.group.GroupID, 1, InviteOptions{})
+	require.NoError(t, err)
+	pending, err := f.groups.CreateJoinApplication(ctx, f.group.GroupID, 2, "")
+	require.NoError(t, err)
+
+	// 改为仅限邀请时待审批的申请被拒绝，且不再接受新的申请
+	require.NoError(t, f.groups.SetJoinPolicy(ctx, f.group.GroupID, 1, models.JoinPolicyInviteOnly))
+	application, err := f.factory.JoinApplicationDAO.GetByGroupIDAndUserID(ctx, f.group.GroupID, 2)
+	require.NoError(t, err)
+	assert.Equal(t, "rejected", application.Status)
+	assert.Equal(t, appErrors.ErrGroupInviteOnly.Error(), application.RejectReason)
+	assert.ErrorIs(t, f.groups.ApproveJoinApplication(ctx, f.group.GroupID, 2, 1, pending.RequestID, "student1"), appErrors.ErrGroupInviteOnly)
+	_, err = f.groups.CreateJoinApplication(ctx, f.group.GroupID, 3, "")
+	assert.ErrorIs(t, err, appErrors.ErrGroupInviteOnly)
+	status, err := f.groups.GetUserGroupStatus(ctx, f.group.GroupID, 3)
+	require.NoError(t, err)
+	assert.Equal(t, models.JoinPolicyInviteOnly, status.JoinPolicy)
+	assert.False(t, status.CanApply)
+
+	// 仅限邀请的用户组仍可凭邀请码加入，关闭后不可
+	_, err = f.invites.JoinByCode(ctx, invite.Code, 3)
+	require.NoError(t, err)
+	require.NoError(t, f.groups.SetJoinPolicy(ctx, f.group.GroupID, 1, models.JoinPolicyClosed))
+	_, err = f.invites.JoinByCode(ctx, invite.Code, 4)
+	assert.ErrorIs(t, err, appErrors.ErrGroupClosed)
+	_, err = f.groups.CreateJoinApplication(ctx, f.group.GroupID, 4, "")
+	assert.ErrorIs(t, err, appErrors.ErrGroupClosed)
+
+	// 只有组管理员可以修改加入方式
+	assert.ErrorIs(t, f.groups.SetJoinPolicy(ctx, f.group.GroupID, 3, models.JoinPolicyOpen), appErrors.ErrRolePermissionDenied)
+}
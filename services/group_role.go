@@ -0,0 +1,243 @@
+package service
+
+import (
+	"TeamTickBackend/dal/models"
+	appErrors "TeamTickBackend/pkg/errors"
+	"context"
+	"errors"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"gorm.io/gorm"
+)
+
+// 自定义角色名称与说明的长度上限（字符数）
+const (
+	groupRoleNameMaxLength        = 20
+	groupRoleDescriptionMaxLength = 255
+)
+
+// GroupRoleInput 创建或修改自定义角色的内容
+type GroupRoleInput struct {
+	Name        string
+	Description string
+	// Permissions 角色的组内权限，见 models.GroupPermissions
+	Permissions []string
+}
+
+// 查询用户组的自定义角色，组成员均可查看
+func (s *GroupsService) ListCustomRoles(ctx context.Context, groupID, viewerID int) ([]*models.GroupRole, error) {
+	if _, err := s.GetGroupByGroupID(ctx, groupID); err != nil {
+		return nil, err
+	}
+	if err := s.CheckUserExistInGroup(ctx, groupID, viewerID); err != nil {
+		return nil, err
+	}
+	roles, err := s.groupRoleDao.ListByGroupID(ctx, groupID)
+	if err != nil {
+		return nil, appErrors.ErrDatabaseOperation.WithError(err)
+	}
+	return roles, nil
+}
+
+// 创建自定义角色，操作者需要具有 role.manage 权限，且只能授予自己具有的权限
+func (s *GroupsService) CreateCustomRole(ctx context.Context, groupID, operatorID int, input GroupRoleInput) (*models.GroupRole, error) {
+	name, description, permissions, err := normalizeGroupRoleInput(input)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.GetGroupByGroupID(ctx, groupID); err != nil {
+		return nil, err
+	}
+	operatorPermissions, err := s.checkOperatorPermission(ctx, groupID, operatorID, models.PermissionRoleManage)
+	if err != nil {
+		return nil, err
+	}
+	if !containsAll(operatorPermissions, permissions) {
+		return nil, appErrors.ErrGroupPermissionExceeded
+	}
+	now := time.Now()
+	role := &models.GroupRole{
+		GroupID:     groupID,
+		Name:        name,
+		Description: description,
+		Permissions: strings.Join(permissions, " "),
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	if err := s.groupRoleDao.Create(ctx, role); err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, appErrors.ErrGroupRoleExists
+		}
+		return nil, appErrors.ErrDatabaseOperation.WithError(err)
+	}
+	s.log.InfoContext(ctx, "group role created",
+		slog.Int("group_id", groupID), slog.Int("role_id", role.ID), slog.String("permissions", role.Permissions), slog.Int("operator_id", operatorID))
+	return role, nil
+}
+
+// 修改自定义角色，担任该角色的成员随即按新的权限校验；操作者必须同时具有修改前后的全部权限
+func (s *GroupsService) UpdateCustomRole(ctx context.Context, groupID, roleID, operatorID int, input GroupRoleInput) (*models.GroupRole, error) {
+	name, description, permissions, err := normalizeGroupRoleInput(input)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.GetGroupByGroupID(ctx, groupID); err != nil {
+		return nil, err
+	}
+	operatorPermissions, err := s.checkOperatorPermission(ctx, groupID, operatorID, models.PermissionRoleManage)
+	if err != nil {
+		return nil, err
+	}
+	var updatedRole models.GroupRole
+	err = s.transactionManager.WithTransaction(ctx, func(tx *gorm.DB) error {
+		role, err := s.getCustomRole(ctx, groupID, roleID, tx)
+		if err != nil {
+			return err
+		}
+		if !containsAll(operatorPermissions, role.PermissionList()) || !containsAll(operatorPermissions, permissions) {
+			return appErrors.ErrGroupPermissionExceeded
+		}
+		role.Name = name
+		role.Description = description
+		role.Permissions = strings.Join(permissions, " ")
+		role.UpdatedAt = time.Now()
+		if err := s.groupRoleDao.Update(ctx, role, tx); err != nil {
+			if errors.Is(err, gorm.ErrDuplicatedKey) {
+				return appErrors.ErrGroupRoleExists
+			}
+			return appErrors.ErrDatabaseOperation.WithError(err)
+		}
+		updatedRole = *role
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.log.InfoContext(ctx, "group role updated",
+		slog.Int("group_id", groupID), slog.Int("role_id", roleID), slog.String("permissions", updatedRole.Permissions), slog.Int("operator_id", operatorID))
+	return &updatedRole, nil
+}
+
+// 删除自定义角色，仍有成员担任该角色时不能删除
+func (s *GroupsService) DeleteCustomRole(ctx context.Context, groupID, roleID, operatorID int) error {
+	if _, err := s.GetGroupByGroupID(ctx, groupID); err != nil {
+		return err
+	}
+	operatorPermissions, err := s.checkOperatorPermission(ctx, groupID, operatorID, models.PermissionRoleManage)
+	if err != nil {
+		return err
+	}
+	err = s.transactionManager.WithTransaction(ctx, func(tx *gorm.DB) error {
+		role, err := s.getCustomRole(ctx, groupID, roleID, tx)
+		if err != nil {
+			return err
+		}
+		if !containsAll(operatorPermissions, role.PermissionList()) {
+			return appErrors.ErrGroupPermissionExceeded
+		}
+		members, err := s.groupMemberDao.GetMembersByGroupID(ctx, groupID, tx)
+		if err != nil {
+			return appErrors.ErrDatabaseOperation.WithError(err)
+		}
+		for _, member := range members {
+			if member.RoleID == roleID {
+				return appErrors.ErrGroupRoleInUse
+			}
+		}
+		if err := s.groupRoleDao.Delete(ctx, roleID, tx); err != nil {
+			return appErrors.ErrDatabaseOperation.WithError(err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	s.log.InfoContext(ctx, "group role deleted", slog.Int("group_id", groupID), slog.Int("role_id", roleID), slog.Int("operator_id", operatorID))
+	return nil
+}
+
+// RoleNames 用户组自定义角色ID -> 名称，供成员列表展示成员担任的角色
+func (s *GroupsService) RoleNames(ctx context.Context, groupID int) (map[int]string, error) {
+	roles, err := s.groupRoleDao.ListByGroupID(ctx, groupID)
+	if err != nil {
+		return nil, appErrors.ErrDatabaseOperation.WithError(err)
+	}
+	names := make(map[int]string, len(roles))
+	for _, role := range roles {
+		names[role.ID] = role.Name
+	}
+	return names, nil
+}
+
+// getCustomRole 在调用方的事务中查询本组的自定义角色，其他用户组的角色按不存在处理
+func (s *GroupsService) getCustomRole(ctx context.Context, groupID, roleID int, tx *gorm.DB) (*models.GroupRole, error) {
+	role, err := s.groupRoleDao.GetByID(ctx, roleID, tx)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, appErrors.ErrGroupRoleNotFound
+		}
+		return nil, appErrors.ErrDatabaseOperation.WithError(err)
+	}
+	if role.GroupID != groupID {
+		return nil, appErrors.ErrGroupRoleNotFound
+	}
+	return role, nil
+}
+
+// findRoleByName 在调用方的事务中按名称查询本组的自定义角色
+func (s *GroupsService) findRoleByName(ctx context.Context, groupID int, name string, tx *gorm.DB) (*models.GroupRole, error) {
+	roles, err := s.groupRoleDao.ListByGroupID(ctx, groupID, tx)
+	if err != nil {
+		return nil, appErrors.ErrDatabaseOperation.WithError(err)
+	}
+	for _, role := range roles {
+		if role.Name == name {
+			return role, nil
+		}
+	}
+	return nil, appErrors.ErrGroupRoleNotFound
+}
+
+// normalizeGroupRoleInput 校验角色名称与说明，权限去重后按 models.GroupPermissions 的顺序返回；
+// 名称不能与内置角色 admin、member 及 owner 重复
+func normalizeGroupRoleInput(input GroupRoleInput) (string, string, []string, error) {
+	name := strings.TrimSpace(input.Name)
+	description := strings.TrimSpace(input.Description)
+	if name == "" || utf8.RuneCountInString(name) > groupRoleNameMaxLength ||
+		utf8.RuneCountInString(description) > groupRoleDescriptionMaxLength {
+		return "", "", nil, appErrors.ErrGroupRoleInvalid
+	}
+	switch strings.ToLower(name) {
+	case "admin", "member", models.GroupRoleOwner:
+		return "", "", nil, appErrors.ErrGroupRoleInvalid
+	}
+	if len(input.Permissions) == 0 {
+		return "", "", nil, appErrors.ErrGroupPermissionInvalid
+	}
+	for _, permission := range input.Permissions {
+		if !models.ValidGroupPermission(permission) {
+			return "", "", nil, appErrors.ErrGroupPermissionInvalid
+		}
+	}
+	permissions := make([]string, 0, len(input.Permissions))
+	for _, permission := range models.GroupPermissions {
+		if slices.Contains(input.Permissions, permission) {
+			permissions = append(permissions, permission)
+		}
+	}
+	return name, description, permissions, nil
+}
+
+// containsAll granted 包含 required 中的全部权限
+func containsAll(granted, required []string) bool {
+	for _, permission := range required {
+		if !slices.Contains(granted, permission) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,236 @@
+package service
+
+import (
+	"TeamTickBackend/dal/dao"
+	"TeamTickBackend/dal/models"
+	appErrors "TeamTickBackend/pkg/errors"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	roleOwner   = GroupOperator{UserID: 1}
+	roleStudent = GroupOperator{UserID: 2}
+)
+
+// setupRoleTest 内存DAO上 teacher（用户ID 1）创建的用户组，student1、student2 为普通成员
+func setupRoleTest(t *testing.T) (*GroupsService, *dao.DAOFactory, *models.Group) {
+	factory := dao.NewMemoryDAOFactory()
+	ctx := context.Background()
+	seedUsers(t, factory, "teacher", "student1", "student2")
+	groups := newMemoryGroupsService(t, factory)
+	group, err := groups.CreateGroup(ctx, "软件工程", "", 1)
+	require.NoError(t, err)
+	for userID, name := range map[int]string{2: "student1", 3: "student2"} {
+		_, err := groups.AddMemberToGroup(ctx, group.GroupID, userID, 1, name)
+		require.NoError(t, err)
+	}
+	return groups, factory, group
+}
+
+func TestSetMemberRole_PromoteAndDemote(t *testing.T) {
+	groups, factory, group := setupRoleTest(t)
+	ctx := context.Background()
+
+	_, err := groups.SetMemberRole(ctx, group.GroupID, 3, "owner", roleOwner)
+	assert.ErrorIs(t, err, appErrors.ErrGroupRoleInvalid)
+	_, err = groups.SetMemberRole(ctx, group.GroupID, 3, "admin", roleStudent)
+	assert.ErrorIs(t, err, appErrors.ErrRolePermissionDenied)
+	_, err = groups.SetMemberRole(ctx, group.GroupID, 9, "admin", roleOwner)
+	assert.ErrorIs(t, err, appErrors.ErrGroupMemberNotFound)
+
+	member, err := groups.SetMemberRole(ctx, group.GroupID, 2, "admin", roleOwner)
+	require.NoError(t, err)
+	assert.Equal(t, "admin", member.Role)
+	// 新管理员同样可以设置其他管理员
+	_, err = groups.SetMemberRole(ctx, group.GroupID, 3, "admin", roleStudent)
+	require.NoError(t, err)
+
+	// 只有所有者可以撤销其他管理员，所有者不能被撤销
+	_, err = groups.SetMemberRole(ctx, group.GroupID, 3, "member", roleStudent)
+	assert.ErrorIs(t, err, appErrors.ErrGroupOwnerOnly)
+	_, err = groups.SetMemberRole(ctx, group.GroupID, 1, "member", roleOwner)
+	assert.ErrorIs(t, err, appErrors.ErrGroupOwnerRequired)
+	_, err = groups.SetMemberRole(ctx, group.GroupID, 3, "member", roleOwner)
+	require.NoError(t, err)
+	// 管理员可以卸任自己
+	_, err = groups.SetMemberRole(ctx, group.GroupID, 2, "member", roleStudent)
+	require.NoError(t, err)
+	// 角色未变化时不写入记录
+	_, err = groups.SetMemberRole(ctx, group.GroupID, 2, "member", roleOwner)
+	require.NoError(t, err)
+
+	changes, total, err := groups.ListRoleChanges(ctx, group.GroupID, 1, 1, 10)
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), total)
+	assert.Equal(t, models.GroupRoleChangeDemote, changes[0].Action)
+	assert.Equal(t, "student1", changes[0].OperatorName)
+	assert.Equal(t, models.GroupRoleChangePromote, changes[3].Action)
+	assert.Equal(t, "member", changes[3].FromRole)
+	assert.Equal(t, "admin", changes[3].ToRole)
+	assert.Equal(t, 1, changes[3].OperatorID)
+	_, _, err = groups.ListRoleChanges(ctx, group.GroupID, 2, 1, 10)
+	assert.ErrorIs(t, err, appErrors.ErrRolePermissionDenied)
+
+	stored, err := factory.GroupMemberDAO.GetMemberByGroupIDAndUserID(ctx, group.GroupID, 2)
+	require.NoError(t, err)
+	assert.Equal(t, "member", stored.Role)
+}
+
+func TestSetMemberRole_RecordsCurrentOperatorName(t *testing.T) {
+	groups, factory, group := setupRoleTest(t)
+	ctx := context.Background()
+	users := NewUserService(factory.UserDAO, factory.DenormalizationDAO, factory.TransactionManager, discardLogger())
+
+	// 改名后令牌中的用户名已过期，变更记录应使用 users 表中的当前用户名
+	_, err := users.ChangeUsername(ctx, 1, "teacher_wang")
+	require.NoError(t, err)
+	_, err = groups.SetMemberRole(ctx, group.GroupID, 2, "admin", roleOwner)
+	require.NoError(t, err)
+
+	changes, _, err := groups.ListRoleChanges(ctx, group.GroupID, 1, 1, 10)
+	require.NoError(t, err)
+	require.Len(t, changes, 1)
+	assert.Equal(t, "teacher_wang", changes[0].OperatorName)
+}
+
+func TestTransferOwnership_KeepsGroupOwned(t *testing.T) {
+	groups, factory, group := setupRoleTest(t)
+	ctx := context.Background()
+
+	// 只有所有者可以转让，包括其他管理员
+	_, err := groups.SetMemberRole(ctx, group.GroupID, 3, "admin", roleOwner)
+	require.NoError(t, err)
+	_, err = groups.TransferOwnership(ctx, group.GroupID, 3, GroupOperator{UserID: 3})
+	assert.ErrorIs(t, err, appErrors.ErrGroupOwnerOnly)
+	_, err = groups.TransferOwnership(ctx, group.GroupID, 1, roleOwner)
+	assert.ErrorIs(t, err, appErrors.ErrGroupAlreadyOwner)
+	_, err = groups.TransferOwnership(ctx, group.GroupID, 9, roleOwner)
+	assert.ErrorIs(t, err, appErrors.ErrGroupMemberNotFound)
+
+	transferred, err := groups.TransferOwnership(ctx, group.GroupID, 2, roleOwner)
+	require.NoError(t, err)
+	assert.Equal(t, 2, transferred.CreatorID)
+	assert.Equal(t, "student1", transferred.CreatorName)
+	newOwner, err := factory.GroupMemberDAO.GetMemberByGroupIDAndUserID(ctx, group.GroupID, 2)
+	require.NoError(t, err)
+	assert.Equal(t, "admin", newOwner.Role)
+
+	// 原所有者保留管理员身份，可由新所有者撤销，但不能再转让或移出新所有者
+	_, err = groups.TransferOwnership(ctx, group.GroupID, 3, roleOwner)
+	assert.ErrorIs(t, err, appErrors.ErrGroupOwnerOnly)
+	assert.ErrorIs(t, groups.RemoveMemberFromGroup(ctx, group.GroupID, 2, 1), appErrors.ErrGroupOwnerRequired)
+	_, err = groups.SetMemberRole(ctx, group.GroupID, 2, "member", roleOwner)
+	assert.ErrorIs(t, err, appErrors.ErrGroupOwnerRequired)
+	_, err = groups.SetMemberRole(ctx, group.GroupID, 1, "member", roleStudent)
+	require.NoError(t, err)
+
+	changes, _, err := groups.ListRoleChanges(ctx, group.GroupID, 2, 1, 10)
+	require.NoError(t, err)
+	require.Len(t, changes, 4)
+	assert.Equal(t, models.GroupRoleChangeTransfer, changes[1].Action)
+	assert.Equal(t, 2, changes[1].UserID)
+	assert.Equal(t, models.GroupRoleOwner, changes[1].ToRole)
+	assert.Equal(t, models.GroupRoleChangeTransfer, changes[2].Action)
+	assert.Equal(t, 1, changes[2].UserID)
+	assert.Equal(t, models.GroupRoleOwner, changes[2].FromRole)
+	assert.Equal(t, "admin", changes[2].ToRole)
+}
+
+func TestCustomRole_GrantsPermissions(t *testing.T) {
+	groups, factory, group := setupRoleTest(t)
+	ctx := context.Background()
+
+	_, err := groups.CreateCustomRole(ctx, group.GroupID, 1, GroupRoleInput{Name: "Admin", Permissions: []string{models.PermissionTaskView}})
+	assert.ErrorIs(t, err, appErrors.ErrGroupRoleInvalid)
+	_, err = groups.CreateCustomRole(ctx, group.GroupID, 1, GroupRoleInput{Name: "助教", Permissions: []string{"task.fly"}})
+	assert.ErrorIs(t, err, appErrors.ErrGroupPermissionInvalid)
+	_, err = groups.CreateCustomRole(ctx, group.GroupID, 2, GroupRoleInput{Name: "助教", Permissions: []string{models.PermissionTaskView}})
+	assert.ErrorIs(t, err, appErrors.ErrRolePermissionDenied)
+
+	// 权限去重并按固定顺序保存
+	role, err := groups.CreateCustomRole(ctx, group.GroupID, 1, GroupRoleInput{
+		Name:        " 助教 ",
+		Description: "发布任务、审核补签",
+		Permissions: []string{models.PermissionAuditReview, models.PermissionTaskCreate, models.PermissionAuditReview},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "助教", role.Name)
+	assert.Equal(t, "task.create audit.review", role.Permissions)
+	_, err = groups.CreateCustomRole(ctx, group.GroupID, 1, GroupRoleInput{Name: "助教", Permissions: []string{models.PermissionTaskView}})
+	assert.ErrorIs(t, err, appErrors.ErrGroupRoleExists)
+
+	_, err = groups.SetMemberRole(ctx, group.GroupID, 2, "审核员", roleOwner)
+	assert.ErrorIs(t, err, appErrors.ErrGroupRoleNotFound)
+	member, err := groups.SetMemberRole(ctx, group.GroupID, 2, "助教", roleOwner)
+	require.NoError(t, err)
+	assert.Equal(t, "member", member.Role)
+	assert.Equal(t, role.ID, member.RoleID)
+
+	assert.NoError(t, groups.CheckPermission(ctx, group.GroupID, 2, models.PermissionTaskCreate))
+	assert.NoError(t, groups.CheckPermission(ctx, group.GroupID, 2, models.PermissionAuditReview))
+	assert.ErrorIs(t, groups.CheckPermission(ctx, group.GroupID, 2, models.PermissionTaskDelete), appErrors.ErrRolePermissionDenied)
+	assert.ErrorIs(t, groups.CheckPermission(ctx, group.GroupID, 3, models.PermissionTaskCreate), appErrors.ErrRolePermissionDenied)
+	status, err := groups.GetUserGroupStatus(ctx, group.GroupID, 2)
+	require.NoError(t, err)
+	assert.Equal(t, "助教", status.Role)
+	assert.Equal(t, []string{models.PermissionTaskCreate, models.PermissionAuditReview}, status.Permissions)
+
+	// 修改角色后担任该角色的成员随即按新的权限校验，且只能授予自己具有的权限
+	_, err = groups.UpdateCustomRole(ctx, group.GroupID, role.ID, 1, GroupRoleInput{
+		Name:        "助教",
+		Permissions: []string{models.PermissionTaskCreate, models.PermissionMemberRole},
+	})
+	require.NoError(t, err)
+	assert.ErrorIs(t, groups.CheckPermission(ctx, group.GroupID, 2, models.PermissionAuditReview), appErrors.ErrRolePermissionDenied)
+	_, err = groups.SetMemberRole(ctx, group.GroupID, 3, "助教", roleStudent)
+	require.NoError(t, err)
+	_, err = groups.SetMemberRole(ctx, group.GroupID, 3, "admin", roleStudent)
+	assert.ErrorIs(t, err, appErrors.ErrGroupPermissionExceeded)
+
+	changes, _, err := groups.ListRoleChanges(ctx, group.GroupID, 1, 1, 10)
+	require.NoError(t, err)
+	require.Len(t, changes, 2)
+	assert.Equal(t, models.GroupRoleChangeAssign, changes[0].Action)
+	assert.Equal(t, "member", changes[0].FromRole)
+	assert.Equal(t, "助教", changes[0].ToRole)
+
+	stored, err := factory.GroupMemberDAO.GetMemberByGroupIDAndUserID(ctx, group.GroupID, 3)
+	require.NoError(t, err)
+	assert.Equal(t, role.ID, stored.RoleID)
+}
+
+func TestCustomRole_EscalationAndInUse(t *testing.T) {
+	groups, _, group := setupRoleTest(t)
+	ctx := context.Background()
+	_, err := groups.SetMemberRole(ctx, group.GroupID, 2, "admin", roleOwner)
+	require.NoError(t, err)
+
+	// 管理员不具有删除用户组的权限，也不能授予该权限
+	assert.ErrorIs(t, groups.CheckPermission(ctx, group.GroupID, 2, models.PermissionGroupDelete), appErrors.ErrRolePermissionDenied)
+	assert.NoError(t, groups.CheckPermission(ctx, group.GroupID, 1, models.PermissionGroupDelete))
+	_, err = groups.CreateCustomRole(ctx, group.GroupID, 2, GroupRoleInput{Name: "副组长", Permissions: []string{models.PermissionGroupDelete}})
+	assert.ErrorIs(t, err, appErrors.ErrGroupPermissionExceeded)
+
+	role, err := groups.CreateCustomRole(ctx, group.GroupID, 2, GroupRoleInput{Name: "审核员", Permissions: []string{models.PermissionAuditView}})
+	require.NoError(t, err)
+	_, err = groups.SetMemberRole(ctx, group.GroupID, 3, "审核员", roleStudent)
+	require.NoError(t, err)
+	assert.ErrorIs(t, groups.DeleteCustomRole(ctx, group.GroupID, role.ID, 2), appErrors.ErrGroupRoleInUse)
+	_, err = groups.SetMemberRole(ctx, group.GroupID, 3, "member", roleStudent)
+	require.NoError(t, err)
+	require.NoError(t, groups.DeleteCustomRole(ctx, group.GroupID, role.ID, 2))
+	assert.ErrorIs(t, groups.DeleteCustomRole(ctx, group.GroupID, role.ID, 2), appErrors.ErrGroupRoleNotFound)
+	roles, err := groups.ListCustomRoles(ctx, group.GroupID, 3)
+	require.NoError(t, err)
+	assert.Empty(t, roles)
+
+	// 其他管理员只能由所有者移出
+	_, err = groups.SetMemberRole(ctx, group.GroupID, 3, "admin", roleOwner)
+	require.NoError(t, err)
+	assert.ErrorIs(t, groups.RemoveMemberFromGroup(ctx, group.GroupID, 3, 2), appErrors.ErrGroupOwnerOnly)
+	require.NoError(t, groups.RemoveMemberFromGroup(ctx, group.GroupID, 3, 1))
+}
@@ -0,0 +1,106 @@
+package service
+
+import (
+	"TeamTickBackend/dal/dao"
+	"TeamTickBackend/dal/models"
+	appErrors "TeamTickBackend/pkg/errors"
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// GroupStatsService 用户组统计：用户组连同全部下级用户组汇总的成员、任务与签到数据
+type GroupStatsService struct {
+	groupDao       dao.GroupDAO
+	groupMemberDao dao.GroupMemberDAO
+	statsDao       dao.GroupStatsDAO
+	groupsService  *GroupsService
+	now            func() time.Time
+}
+
+func NewGroupStatsService(
+	groupDao dao.GroupDAO,
+	groupMemberDao dao.GroupMemberDAO,
+	statsDao dao.GroupStatsDAO,
+	groupsService *GroupsService,
+) *GroupStatsService {
+	return &GroupStatsService{
+		groupDao:       groupDao,
+		groupMemberDao: groupMemberDao,
+		statsDao:       statsDao,
+		groupsService:  groupsService,
+		now:            time.Now,
+	}
+}
+
+// Stats 查询用户组汇总全部下级用户组后的统计数据，以及每个直接下级用户组各自汇总的统计数据，需要具有 records.view 权限
+// 下级用户组按层级一次查出，成员与签到数据各用一次查询按用户组取回后在内存中逐层汇总
+func (s *GroupStatsService) Stats(ctx context.Context, groupID, viewerID int) (*models.GroupStats, []*models.GroupStats, error) {
+	group, err := s.groupDao.GetByGroupID(ctx, groupID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, appErrors.ErrGroupNotFound
+		}
+		return nil, nil, appErrors.ErrDatabaseOperation.WithError(err)
+	}
+	if err := s.groupsService.CheckPermission(ctx, groupID, viewerID, models.PermissionRecordsView); err != nil {
+		return nil, nil, err
+	}
+	descendants, err := s.groupDao.GetDescendants(ctx, groupID)
+	if err != nil {
+		return nil, nil, appErrors.ErrDatabaseOperation.WithError(err)
+	}
+	nodes := append([]*models.Group{group}, descendants...)
+	ids := groupIDs(nodes)
+	members, err := s.groupMemberDao.GetMembersByGroupIDs(ctx, ids)
+	if err != nil {
+		return nil, nil, appErrors.ErrDatabaseOperation.WithError(err)
+	}
+	activities, err := s.statsDao.Collect(ctx, ids, s.now())
+	if err != nil {
+		return nil, nil, appErrors.ErrDatabaseOperation.WithError(err)
+	}
+
+	stats := make(map[int]*models.GroupStats, len(nodes))
+	users := make(map[int]map[int]struct{}, len(nodes))
+	for _, node := range nodes {
+		stat := &models.GroupStats{GroupID: node.GroupID, GroupName: node.GroupName, ParentID: node.ParentID}
+		if activity, ok := activities[node.GroupID]; ok {
+			stat.Tasks = activity.Tasks
+			stat.ActiveTasks = activity.ActiveTasks
+			stat.CheckIns = activity.CheckIns
+		}
+		stats[node.GroupID] = stat
+		users[node.GroupID] = make(map[int]struct{})
+	}
+	for _, member := range members {
+		users[member.GroupID][member.UserID] = struct{}{}
+	}
+	// 下级用户组按层级排列，倒序遍历时每个用户组都在其上级用户组之前完成汇总
+	for i := len(nodes) - 1; i >= 0; i-- {
+		node := nodes[i]
+		stat := stats[node.GroupID]
+		stat.Members = len(users[node.GroupID])
+		if i == 0 {
+			break
+		}
+		parent := stats[node.ParentID]
+		parent.Subgroups += stat.Subgroups + 1
+		parent.Tasks += stat.Tasks
+		parent.ActiveTasks += stat.ActiveTasks
+		parent.CheckIns += stat.CheckIns
+		for userID := range users[node.GroupID] {
+			users[node.ParentID][userID] = struct{}{}
+		}
+	}
+
+	children := make([]*models.GroupStats, 0)
+	for _, descendant := range descendants {
+		if descendant.ParentID == groupID {
+			children = append(children, stats[descendant.GroupID])
+		}
+	}
+	return stats[groupID], children, nil
+}
@@ -6,6 +6,10 @@ import (
 	appErrors "TeamTickBackend/pkg/errors"
 	"context"
 	"errors"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
 
 	"gorm.io/gorm"
 )
@@ -14,50 +18,50 @@ type GroupsService struct {
 	groupDao           dao.GroupDAO
 	groupMemberDao     dao.GroupMemberDAO
 	joinApplicationDao dao.JoinApplicationDAO
+	userDao            dao.UserDAO
+	denormalizationDao dao.DenormalizationDAO
+	groupRoleChangeDao dao.GroupRoleChangeDAO
+	groupRoleDao       dao.GroupRoleDAO
 	transactionManager dao.TransactionManager
+	log                *slog.Logger
 }
 
 func NewGroupsService(
 	groupDao dao.GroupDAO,
 	groupMemberDao dao.GroupMemberDAO,
 	joinApplicationDao dao.JoinApplicationDAO,
+	userDao dao.UserDAO,
+	denormalizationDao dao.DenormalizationDAO,
+	groupRoleChangeDao dao.GroupRoleChangeDAO,
+	groupRoleDao dao.GroupRoleDAO,
 	transactionManager dao.TransactionManager,
+	log *slog.Logger,
 ) *GroupsService {
 
 	return &GroupsService{
 		groupDao:           groupDao,
 		groupMemberDao:     groupMemberDao,
 		joinApplicationDao: joinApplicationDao,
+		userDao:            userDao,
+		denormalizationDao: denormalizationDao,
+		groupRoleChangeDao: groupRoleChangeDao,
+		groupRoleDao:       groupRoleDao,
 		transactionManager: transactionManager,
+		log:                log,
 	}
 }
 
 // 创建用户组
-func (s *GroupsService) CreateGroup(ctx context.Context, groupName, description, creatorName string, creatorID int) (*models.Group, error) {
+func (s *GroupsService) CreateGroup(ctx context.Context, groupName, description string, creatorID int) (*models.Group, error) {
 	var createdGroup models.Group
 
 	err := s.transactionManager.WithTransaction(ctx, func(tx *gorm.DB) error {
-		group := models.Group{
+		createdGroup = models.Group{
 			GroupName:   groupName,
 			Description: description,
 			CreatorID:   creatorID,
-			CreatorName: creatorName,
 		}
-		//创建用户组
-		if err := s.groupDao.Create(ctx, &group, tx); err != nil {
-			return appErrors.ErrGroupCreationFailed.WithError(err)
-		}
-		//添加用户组管理员
-		if err := s.groupMemberDao.Create(ctx, &models.GroupMember{
-			GroupID:  group.GroupID,
-			UserID:   creatorID,
-			Username: creatorName,
-			Role:     "admin",
-		}, tx); err != nil {
-			return appErrors.ErrGroupMemberCreationFailed.WithError(err)
-		}
-		createdGroup = group
-		return nil
+		return s.createGroup(ctx, &createdGroup, tx)
 	})
 	if err != nil {
 		return nil, err
@@ -65,6 +69,30 @@ func (s *GroupsService) CreateGroup(ctx context.Context, groupName, description,
 	return &createdGroup, nil
 }
 
+// createGroup 在调用方的事务中创建用户组，并将创建者添加为组管理员，创建者的用户名从 users 表读取
+func (s *GroupsService) createGroup(ctx context.Context, group *models.Group, tx *gorm.DB) error {
+	creatorName, err := currentUsername(ctx, s.userDao, group.CreatorID, tx)
+	if err != nil {
+		return err
+	}
+	group.CreatorName = creatorName
+	//创建用户组
+	if err := s.groupDao.Create(ctx, group, tx); err != nil {
+		return appErrors.ErrGroupCreationFailed.WithError(err)
+	}
+	//添加用户组管理员
+	if err := s.groupMemberDao.Create(ctx, &models.GroupMember{
+		GroupID:   group.GroupID,
+		UserID:    group.CreatorID,
+		GroupName: group.GroupName,
+		Username:  group.CreatorName,
+		Role:      "admin",
+	}, tx); err != nil {
+		return appErrors.ErrGroupMemberCreationFailed.WithError(err)
+	}
+	return nil
+}
+
 // 通过GroupID查询用户组信息
 func (s *GroupsService) GetGroupByGroupID(ctx context.Context, groupID int) (*models.Group, error) {
 	var group models.Group
@@ -116,13 +144,17 @@ func (s *GroupsService) UpdateGroup(ctx context.Context, groupID, operatorID int
 	var updatedGroup models.Group
 	err := s.transactionManager.WithTransaction(ctx, func(tx *gorm.DB) error {
 		//检查操作员权限
-		if err := s.CheckMemberPermission(ctx, groupID, operatorID); err != nil {
-			return appErrors.ErrRolePermissionDenied.WithError(err)
+		if err := s.CheckPermission(ctx, groupID, operatorID, models.PermissionGroupUpdate); err != nil {
+			return operatorPermissionError(err)
 		}
 		//更新用户组信息
 		if err := s.groupDao.UpdateMessage(ctx, groupID, groupName, description, tx); err != nil {
 			return appErrors.ErrGroupUpdateFailed.WithError(err)
 		}
+		//同步成员与签到记录中冗余的用户组名称
+		if _, err := s.denormalizationDao.Repair(ctx, models.DenormSourceGroup, groupID, tx); err != nil {
+			return appErrors.ErrGroupUpdateFailed.WithError(err)
+		}
 		//查询更新后的用户组信息
 		group, err := s.groupDao.GetByGroupID(ctx, groupID, tx)
 		if err != nil {
@@ -137,7 +169,7 @@ func (s *GroupsService) UpdateGroup(ctx context.Context, groupID, operatorID int
 	return &updatedGroup, nil
 }
 
-// 检查用户组成员权限
+// 检查用户是否为组管理员，接口与各项管理操作按具体权限使用 CheckPermission 校验
 func (s *GroupsService) CheckMemberPermission(ctx context.Context, groupID, userID int) error {
 	member, err := s.groupMemberDao.GetMemberByGroupIDAndUserID(ctx, groupID, userID)
 	if err != nil {
@@ -147,11 +179,187 @@ func (s *GroupsService) CheckMemberPermission(ctx context.Context, groupID, user
 		return appErrors.ErrDatabaseOperation.WithError(err)
 	}
 	if member.Role == "admin" {
-		return nil
+		return s.checkAdminTwoFactor(ctx, groupID, userID)
 	}
 	return appErrors.ErrRolePermissionDenied
 }
 
+// CheckPermission 检查成员在用户组中是否具有指定的组内权限（models.Permission*）：所有者具有全部权限，
+// 组管理员具有除删除用户组外的全部权限，其余成员具有所担任自定义角色的权限。非组成员返回 ErrGroupMemberNotFound；
+// 持有自定义角色的成员与管理员一样受用户组两步验证要求的约束
+func (s *GroupsService) CheckPermission(ctx context.Context, groupID, userID int, permission string) error {
+	_, err := s.memberGrant(ctx, groupID, userID, permission)
+	return err
+}
+
+// memberGrant 校验成员具有指定权限，返回成员的全部权限
+// 上级用户组的管理员对下级用户组具有管理员权限，不需要是下级用户组的成员
+func (s *GroupsService) memberGrant(ctx context.Context, groupID, userID int, permission string) ([]string, error) {
+	member, err := s.groupMemberDao.GetMemberByGroupIDAndUserID(ctx, groupID, userID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, appErrors.ErrDatabaseOperation.WithError(err)
+	}
+	var inherited []string
+	if member == nil || (member.Role != "admin" && member.RoleID == 0) {
+		inherited, err = s.inheritedPermissions(ctx, groupID, userID)
+		if err != nil {
+			return nil, err
+		}
+		if len(inherited) == 0 {
+			if member == nil {
+				return nil, appErrors.ErrGroupMemberNotFound
+			}
+			return nil, appErrors.ErrRolePermissionDenied
+		}
+	}
+	// 用户组不存在时不在这里报错，交由调用方按原有逻辑处理
+	group, err := s.groupDao.GetByGroupID(ctx, groupID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, appErrors.ErrDatabaseOperation.WithError(err)
+	}
+	if group != nil {
+		if err := s.adminTwoFactorError(ctx, group, userID); err != nil {
+			return nil, err
+		}
+	}
+	permissions := inherited
+	if inherited == nil {
+		_, permissions, err = s.memberRole(ctx, group, member)
+		if err != nil {
+			return nil, err
+		}
+		// 担任自定义角色的成员同时可能是上级用户组的管理员
+		if !slices.Contains(permissions, permission) && member.Role != "admin" && group != nil && group.ParentID != 0 {
+			inherited, err = s.inheritedPermissions(ctx, groupID, userID)
+			if err != nil {
+				return nil, err
+			}
+			permissions = mergePermissions(permissions, inherited)
+		}
+	}
+	if !slices.Contains(permissions, permission) {
+		return nil, appErrors.ErrRolePermissionDenied
+	}
+	return permissions, nil
+}
+
+// memberRole 成员的角色名称与权限，group 为 nil 时不判断所有者
+func (s *GroupsService) memberRole(ctx context.Context, group *models.Group, member *models.GroupMember, tx ...*gorm.DB) (string, []string, error) {
+	if member.Role == "admin" {
+		if group != nil && member.UserID == group.CreatorID {
+			return member.Role, models.GroupPermissions, nil
+		}
+		return member.Role, models.AdminPermissions, nil
+	}
+	if member.RoleID == 0 {
+		return member.Role, nil, nil
+	}
+	role, err := s.groupRoleDao.GetByID(ctx, member.RoleID, tx...)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return member.Role, nil, nil
+		}
+		return "", nil, appErrors.ErrDatabaseOperation.WithError(err)
+	}
+	if role.GroupID != member.GroupID {
+		return member.Role, nil, nil
+	}
+	return role.Name, role.PermissionList(), nil
+}
+
+// checkAdminTwoFactor 用户组要求管理员开启两步验证时，未开启的管理员返回 *appErrors.AdminTwoFactorRequiredError
+// 用户组不存在时不在这里报错，交由调用方按原有逻辑处理
+func (s *GroupsService) checkAdminTwoFactor(ctx context.Context, groupID, userID int) error {
+	group, err := s.groupDao.GetByGroupID(ctx, groupID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return appErrors.ErrDatabaseOperation.WithError(err)
+	}
+	return s.adminTwoFactorError(ctx, group, userID)
+}
+
+func (s *GroupsService) adminTwoFactorError(ctx context.Context, group *models.Group, userID int) error {
+	if !group.RequireAdmin2FA {
+		return nil
+	}
+	user, err := s.userDao.GetByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return appErrors.ErrUserNotFound
+		}
+		return appErrors.ErrDatabaseOperation.WithError(err)
+	}
+	if !user.TwoFactorEnabled {
+		return &appErrors.AdminTwoFactorRequiredError{}
+	}
+	return nil
+}
+
+// operatorPermissionError 操作者权限校验失败时统一返回权限不足，因未开启两步验证被拒绝时保留原错误以便接口给出提示
+func operatorPermissionError(err error) error {
+	if errors.Is(err, appErrors.ErrAdminTwoFactorRequired) {
+		return err
+	}
+	return appErrors.ErrRolePermissionDenied.WithError(err)
+}
+
+// 设置是否要求管理员开启两步验证，开启要求的操作者自己必须已开启两步验证
+func (s *GroupsService) SetRequireAdminTwoFactor(ctx context.Context, groupID, operatorID int, require bool) error {
+	if err := s.CheckPermission(ctx, groupID, operatorID, models.PermissionGroupUpdate); err != nil {
+		return err
+	}
+	if require {
+		user, err := s.userDao.GetByID(ctx, operatorID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return appErrors.ErrUserNotFound
+			}
+			return appErrors.ErrDatabaseOperation.WithError(err)
+		}
+		if !user.TwoFactorEnabled {
+			return &appErrors.AdminTwoFactorRequiredError{}
+		}
+	}
+	if err := s.groupDao.UpdateRequireAdmin2FA(ctx, groupID, require); err != nil {
+		return appErrors.ErrGroupUpdateFailed.WithError(err)
+	}
+	return nil
+}
+
+// 设置用户组的加入方式，改为仅限邀请或关闭时，待审批的加入申请一并拒绝
+func (s *GroupsService) SetJoinPolicy(ctx context.Context, groupID, operatorID int, policy string) error {
+	if !models.ValidJoinPolicy(policy) {
+		return appErrors.ErrJoinPolicyInvalid
+	}
+	if err := s.CheckPermission(ctx, groupID, operatorID, models.PermissionGroupUpdate); err != nil {
+		return err
+	}
+	return s.transactionManager.WithTransaction(ctx, func(tx *gorm.DB) error {
+		if err := s.groupDao.UpdateJoinPolicy(ctx, groupID, policy, tx); err != nil {
+			return appErrors.ErrGroupUpdateFailed.WithError(err)
+		}
+		if policy != models.JoinPolicyInviteOnly && policy != models.JoinPolicyClosed {
+			return nil
+		}
+		pending, err := s.joinApplicationDao.GetByGroupIDAndStatus(ctx, groupID, "pending", tx)
+		if err != nil {
+			return appErrors.ErrDatabaseOperation.WithError(err)
+		}
+		rejectReason := joinPolicyError(&models.Group{JoinPolicy: policy}).Error()
+		for _, application := range pending {
+			if err := s.joinApplicationDao.UpdateStatus(ctx, application.RequestID, "rejected", tx); err != nil {
+				return appErrors.ErrJoinApplicationUpdateFailed.WithError(err)
+			}
+			if err := s.joinApplicationDao.UpdateRejectReason(ctx, application.RequestID, rejectReason, tx); err != nil {
+				return appErrors.ErrJoinApplicationUpdateFailed.WithError(err)
+			}
+		}
+		return nil
+	})
+}
+
 // 检查用户是否存在于用户组
 func (s *GroupsService) CheckUserExistInGroup(ctx context.Context, groupID, userID int) error {
 	_, err := s.groupMemberDao.GetMemberByGroupIDAndUserID(ctx, groupID, userID)
@@ -168,45 +376,90 @@ func (s *GroupsService) CheckUserExistInGroup(ctx context.Context, groupID, user
 // MVP版本申请加入暂时为直接加入，不需要审批，直接调用该函数
 // 但是迭代版本需要审批，审批通过则会执行 往用户-用户组表添加组员，更新用户组成员数量，更新申请表中记录的状态三个行为，需要放在一个事务中
 func (s *GroupsService) AddMemberToGroup(ctx context.Context, groupID, userID, operatorID int, username string) (*models.GroupMember, error) {
-	var member models.GroupMember
+	var member *models.GroupMember
 	err := s.transactionManager.WithTransaction(ctx, func(tx *gorm.DB) error {
 		//检查操作员权限
 		// if err:=s.CheckMemberPermission(ctx,groupID,operatorID);err!=nil{
 		// 	return apperrors.ErrRolePermissionDenied.WithError(err)
 		// }
-		//检查用户组是否存在
-		existMember, err := s.groupMemberDao.GetMemberByGroupIDAndUserID(ctx, groupID, userID, tx)
-		if err == nil && existMember != nil {
-			return appErrors.ErrGroupMemberAlreadyExists
-		}
-		newMember := models.GroupMember{
-			GroupID:  groupID,
-			UserID:   userID,
-			Username: username,
-		}
-		//创建用户组成员
-		if err := s.groupMemberDao.Create(ctx, &newMember, tx); err != nil {
-			return appErrors.ErrGroupMemberCreationFailed.WithError(err)
-		}
-		//更新用户组成员数量
-		if err := s.groupDao.UpdateMemberNum(ctx, groupID, true, tx); err != nil {
-			return appErrors.ErrGroupUpdateFailed.WithError(err)
-		}
-		member = newMember
-		return nil
+		var err error
+		member, err = s.addMember(ctx, groupID, userID, username, "", tx)
+		return err
 	})
 	if err != nil {
 		return nil, err
 	}
-	return &member, nil
+	return member, nil
+}
+
+// getGroup 在调用方的事务中查询用户组
+func (s *GroupsService) getGroup(ctx context.Context, groupID int, tx *gorm.DB) (*models.Group, error) {
+	group, err := s.groupDao.GetByGroupID(ctx, groupID, tx)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, appErrors.ErrGroupNotFound
+		}
+		return nil, appErrors.ErrDatabaseOperation.WithError(err)
+	}
+	return group, nil
+}
+
+// addMember 在调用方的事务中添加成员并更新成员数量，role 为空时为普通成员，供邀请码加入复用
+func (s *GroupsService) addMember(ctx context.Context, groupID, userID int, username, role string, tx *gorm.DB) (*models.GroupMember, error) {
+	//检查用户组是否存在
+	existMember, err := s.groupMemberDao.GetMemberByGroupIDAndUserID(ctx, groupID, userID, tx)
+	if err == nil && existMember != nil {
+		return nil, appErrors.ErrGroupMemberAlreadyExists
+	}
+	group, err := s.groupDao.GetByGroupID(ctx, groupID, tx)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, appErrors.ErrGroupNotFound
+		}
+		return nil, appErrors.ErrDatabaseOperation.WithError(err)
+	}
+	newMember := models.GroupMember{
+		GroupID:   groupID,
+		UserID:    userID,
+		GroupName: group.GroupName,
+		Username:  username,
+		Role:      role,
+	}
+	//创建用户组成员
+	if err := s.groupMemberDao.Create(ctx, &newMember, tx); err != nil {
+		return nil, appErrors.ErrGroupMemberCreationFailed.WithError(err)
+	}
+	//更新用户组成员数量
+	if err := s.groupDao.UpdateMemberNum(ctx, groupID, true, tx); err != nil {
+		return nil, appErrors.ErrGroupUpdateFailed.WithError(err)
+	}
+	return &newMember, nil
 }
 
 // 删除用户组中的用户
 func (s *GroupsService) RemoveMemberFromGroup(ctx context.Context, groupID, userID, operatorID int) error {
 	err := s.transactionManager.WithTransaction(ctx, func(tx *gorm.DB) error {
 		//检查操作员权限
-		if err := s.CheckMemberPermission(ctx, groupID, operatorID); err != nil {
-			return appErrors.ErrRolePermissionDenied.WithError(err)
+		if err := s.CheckPermission(ctx, groupID, operatorID, models.PermissionMemberRemove); err != nil {
+			return operatorPermissionError(err)
+		}
+		//所有者不能被移出用户组，其他管理员只能由所有者移出
+		group, err := s.getGroup(ctx, groupID, tx)
+		if err != nil {
+			return err
+		}
+		if userID == group.CreatorID {
+			return appErrors.ErrGroupOwnerRequired
+		}
+		member, err := s.groupMemberDao.GetMemberByGroupIDAndUserID(ctx, groupID, userID, tx)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return appErrors.ErrGroupMemberNotFound
+			}
+			return appErrors.ErrDatabaseOperation.WithError(err)
+		}
+		if member.Role == "admin" && operatorID != group.CreatorID {
+			return appErrors.ErrGroupOwnerOnly
 		}
 		//删除用户组成员
 		if err := s.groupMemberDao.Delete(ctx, groupID, userID, tx); err != nil {
@@ -244,12 +497,44 @@ func (s *GroupsService) GetMembersByGroupID(ctx context.Context, groupID int) ([
 	return members, nil
 }
 
+// 查询成员的个人资料，按 viewerID 在组内是否具有查看成员资料的权限与成员的隐私设置过滤，返回 用户ID -> 资料
+func (s *GroupsService) GetMemberProfiles(ctx context.Context, members []*models.GroupMember, viewerID int) (map[int]MemberProfile, error) {
+	ids := make([]int, 0, len(members))
+	for _, member := range members {
+		ids = append(ids, member.UserID)
+	}
+	// 与其他管理操作一致按 member.profile 权限判断，包括上级用户组的管理员与两步验证要求
+	viewerIsAdmin := false
+	if len(members) > 0 {
+		_, err := s.memberGrant(ctx, members[0].GroupID, viewerID, models.PermissionMemberProfile)
+		switch {
+		case err == nil:
+			viewerIsAdmin = true
+		case errors.Is(err, appErrors.ErrAdminTwoFactorRequired), errors.Is(err, appErrors.ErrRolePermissionDenied),
+			errors.Is(err, appErrors.ErrGroupMemberNotFound):
+			// 没有查看成员资料的权限，按普通成员过滤
+		default:
+			return nil, err
+		}
+	}
+	users, err := s.userDao.GetByIDs(ctx, ids)
+	if err != nil {
+		return nil, appErrors.ErrDatabaseOperation.WithError(err)
+	}
+	profiles := make(map[int]MemberProfile, len(users))
+	for _, user := range users {
+		profiles[user.UserID] = visibleProfile(user, viewerIsAdmin, user.UserID == viewerID)
+	}
+	return profiles, nil
+}
+
 // 创建用户申请加入记录(返回值？是否需要返回申请记录)
-func (s *GroupsService) CreateJoinApplication(ctx context.Context, groupID, userID int, username, reason string) (*models.JoinApplication, error) {
+// 按用户组的加入方式处理：开放的用户组自动通过并添加成员，仅限邀请与已关闭的用户组不接受申请
+func (s *GroupsService) CreateJoinApplication(ctx context.Context, groupID, userID int, reason string) (*models.JoinApplication, error) {
 	var application models.JoinApplication
 	err := s.transactionManager.WithTransaction(ctx, func(tx *gorm.DB) error {
 		//检查用户组是否存在
-		_, err := s.groupDao.GetByGroupID(ctx, groupID, tx)
+		group, err := s.groupDao.GetByGroupID(ctx, groupID, tx)
 		if err != nil {
 			if errors.Is(err, gorm.ErrRecordNotFound) {
 				return appErrors.ErrGroupNotFound
@@ -261,11 +546,27 @@ func (s *GroupsService) CreateJoinApplication(ctx context.Context, groupID, user
 		if err == nil && member != nil {
 			return appErrors.ErrGroupMemberAlreadyExists
 		}
+		//检查用户组是否接受申请
+		if err := joinPolicyError(group); err != nil {
+			return err
+		}
 		//检查用户是否已存在申请记录
 		existApplication, err := s.joinApplicationDao.GetByGroupIDAndUserID(ctx, groupID, userID, tx)
 		if err == nil && existApplication != nil && existApplication.Status == "pending" {
 			return appErrors.ErrJoinApplicationAlreadyExists
 		}
+		username, err := currentUsername(ctx, s.userDao, userID, tx)
+		if err != nil {
+			return err
+		}
+		if group.EffectiveJoinPolicy() == models.JoinPolicyOpen {
+			accepted, err := s.acceptOpenApplication(ctx, group, userID, username, reason, existApplication, tx)
+			if err != nil {
+				return err
+			}
+			application = *accepted
+			return nil
+		}
 		//创建申请记录
 		newApplication := models.JoinApplication{
 			GroupID:  groupID,
@@ -285,13 +586,51 @@ func (s *GroupsService) CreateJoinApplication(ctx context.Context, groupID, user
 	return &application, nil
 }
 
+// acceptOpenApplication 开放的用户组直接记录为已通过的申请并添加成员；用户此前有已处理的申请记录时更新该记录
+func (s *GroupsService) acceptOpenApplication(ctx context.Context, group *models.Group, userID int, username, reason string, existApplication *models.JoinApplication, tx *gorm.DB) (*models.JoinApplication, error) {
+	var application models.JoinApplication
+	if existApplication != nil {
+		if err := s.joinApplicationDao.UpdateStatus(ctx, existApplication.RequestID, "accepted", tx); err != nil {
+			return nil, appErrors.ErrJoinApplicationUpdateFailed.WithError(err)
+		}
+		application = *existApplication
+		application.Status = "accepted"
+	} else {
+		application = models.JoinApplication{
+			GroupID:  group.GroupID,
+			UserID:   userID,
+			Username: username,
+			Reason:   reason,
+			Status:   "accepted",
+		}
+		if err := s.joinApplicationDao.Create(ctx, &application, tx); err != nil {
+			return nil, appErrors.ErrJoinApplicationCreationFailed.WithError(err)
+		}
+	}
+	if _, err := s.addMember(ctx, group.GroupID, userID, username, "", tx); err != nil {
+		return nil, err
+	}
+	return &application, nil
+}
+
+// joinPolicyError 用户组不接受加入申请时返回对应的错误
+func joinPolicyError(group *models.Group) error {
+	switch group.EffectiveJoinPolicy() {
+	case models.JoinPolicyInviteOnly:
+		return appErrors.ErrGroupInviteOnly
+	case models.JoinPolicyClosed:
+		return appErrors.ErrGroupClosed
+	}
+	return nil
+}
+
 // 查看用户组加入申请列表（待审批）
 func (s *GroupsService) GetJoinApplicationsByGroupID(ctx context.Context, groupID, operatorID int, filter ...string) ([]*models.JoinApplication, error) {
 	var applications []*models.JoinApplication
 	err := s.transactionManager.WithTransaction(ctx, func(tx *gorm.DB) error {
 		//检查操作员权限
-		if err := s.CheckMemberPermission(ctx, groupID, operatorID); err != nil {
-			return appErrors.ErrRolePermissionDenied.WithError(err)
+		if err := s.CheckPermission(ctx, groupID, operatorID, models.PermissionMemberReview); err != nil {
+			return operatorPermissionError(err)
 		}
 		//检查用户组是否存在
 		if _, err := s.groupDao.GetByGroupID(ctx, groupID, tx); err != nil {
@@ -362,18 +701,30 @@ func (s *GroupsService) GetJoinApplicationsByGroupID(ctx context.Context, groupI
 func (s *GroupsService) ApproveJoinApplication(ctx context.Context, groupID, userID, operatorID, requestID int, username string) error {
 	err := s.transactionManager.WithTransaction(ctx, func(tx *gorm.DB) error {
 		//检查操作员权限
-		if err := s.CheckMemberPermission(ctx, groupID, operatorID); err != nil {
-			return appErrors.ErrRolePermissionDenied.WithError(err)
+		if err := s.CheckPermission(ctx, groupID, operatorID, models.PermissionMemberReview); err != nil {
+			return operatorPermissionError(err)
 		}
 		//更新用户组成员数量
 		if err := s.groupDao.UpdateMemberNum(ctx, groupID, true, tx); err != nil {
 			return appErrors.ErrGroupUpdateFailed.WithError(err)
 		}
+		group, err := s.groupDao.GetByGroupID(ctx, groupID, tx)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return appErrors.ErrGroupNotFound
+			}
+			return appErrors.ErrDatabaseOperation.WithError(err)
+		}
+		//用户组已改为仅限邀请或已关闭时不再通过申请
+		if err := joinPolicyError(group); err != nil {
+			return err
+		}
 		//添加用户组成员
 		if err := s.groupMemberDao.Create(ctx, &models.GroupMember{
-			GroupID:  groupID,
-			UserID:   userID,
-			Username: username,
+			GroupID:   groupID,
+			UserID:    userID,
+			GroupName: group.GroupName,
+			Username:  username,
 		}, tx); err != nil {
 			return appErrors.ErrGroupMemberCreationFailed.WithError(err)
 		}
@@ -393,8 +744,8 @@ func (s *GroupsService) ApproveJoinApplication(ctx context.Context, groupID, use
 func (s *GroupsService) RejectJoinApplication(ctx context.Context, groupID, userID, operatorID, requestID int, username, rejectReason string) error {
 	err := s.transactionManager.WithTransaction(ctx, func(tx *gorm.DB) error {
 		//检查操作员权限
-		if err := s.CheckMemberPermission(ctx, groupID, operatorID); err != nil {
-			return appErrors.ErrRolePermissionDenied.WithError(err)
+		if err := s.CheckPermission(ctx, groupID, operatorID, models.PermissionMemberReview); err != nil {
+			return operatorPermissionError(err)
 		}
 		//更新申请记录状态
 		if err := s.joinApplicationDao.UpdateStatus(ctx, requestID, "rejected", tx); err != nil {
@@ -412,28 +763,14 @@ func (s *GroupsService) RejectJoinApplication(ctx context.Context, groupID, user
 	return nil
 }
 
-// 删除用户组
+// 删除用户组，默认只有所有者具有该权限
 func (s *GroupsService) DeleteGroup(ctx context.Context, groupID, operatorID int) error {
 	err := s.transactionManager.WithTransaction(ctx, func(tx *gorm.DB) error {
 		//检查操作员权限
-		if err := s.CheckMemberPermission(ctx, groupID, operatorID); err != nil {
-			return appErrors.ErrRolePermissionDenied.WithError(err)
+		if err := s.CheckPermission(ctx, groupID, operatorID, models.PermissionGroupDelete); err != nil {
+			return operatorPermissionError(err)
 		}
-		//删除用户组
-		if err := s.groupDao.Delete(ctx, groupID, tx); err != nil {
-			return appErrors.ErrGroupDeletionFailed.WithError(err)
-		}
-		//删除用户组成员
-		groupMembers, err := s.groupMemberDao.GetMembersByGroupID(ctx, groupID, tx)
-		if err != nil {
-			return appErrors.ErrDatabaseOperation.WithError(err)
-		}
-		for _, member := range groupMembers {
-			if err := s.groupMemberDao.Delete(ctx, groupID, member.UserID, tx); err != nil {
-				return appErrors.ErrGroupMemberDeletionFailed.WithError(err)
-			}
-		}
-		return nil
+		return s.deleteGroup(ctx, groupID, tx)
 	})
 	if err != nil {
 		return err
@@ -441,19 +778,65 @@ func (s *GroupsService) DeleteGroup(ctx context.Context, groupID, operatorID int
 	return nil
 }
 
+// deleteGroup 在调用方的事务中删除用户组及其成员，供平台管理员强制删除复用
+// 下级用户组改为挂在被删除用户组的上级用户组下
+func (s *GroupsService) deleteGroup(ctx context.Context, groupID int, tx *gorm.DB) error {
+	parentID := 0
+	group, err := s.groupDao.GetByGroupID(ctx, groupID, tx)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return appErrors.ErrDatabaseOperation.WithError(err)
+	}
+	if group != nil {
+		parentID = group.ParentID
+	}
+	if err := s.groupDao.ReparentChildren(ctx, groupID, parentID, tx); err != nil {
+		return appErrors.ErrGroupDeletionFailed.WithError(err)
+	}
+	//删除用户组
+	if err := s.groupDao.Delete(ctx, groupID, tx); err != nil {
+		return appErrors.ErrGroupDeletionFailed.WithError(err)
+	}
+	//删除用户组成员
+	groupMembers, err := s.groupMemberDao.GetMembersByGroupID(ctx, groupID, tx)
+	if err != nil {
+		return appErrors.ErrDatabaseOperation.WithError(err)
+	}
+	for _, member := range groupMembers {
+		if err := s.groupMemberDao.Delete(ctx, groupID, member.UserID, tx); err != nil {
+			return appErrors.ErrGroupMemberDeletionFailed.WithError(err)
+		}
+	}
+	return nil
+}
+
+// UserGroupStatus 用户在用户组中的状态与用户组的加入方式
+type UserGroupStatus struct {
+	// Status 组成员为组内角色，否则为加入申请的状态，没有申请记录时为 none
+	Status    string
+	RequestID int
+	// JoinPolicy 用户组的加入方式
+	JoinPolicy string
+	// CanApply 用户当前可以提交加入申请：不是组成员、没有待审批的申请且用户组接受申请
+	CanApply bool
+	// Role 组成员担任的角色，持有自定义角色时为角色名称
+	Role string
+	// Permissions 组成员具有的组内权限
+	Permissions []string
+}
+
 // 查询当前登录用户在指定用户组中的状态，包括未关联、申请中、普通成员、管理员等(返回申请记录，可在handlers层根据记录的status构建对应的响应)
-func (s *GroupsService) GetUserGroupStatus(ctx context.Context, groupID, userID int) (string, int, error) {
-	var status string
-	var requestID int
+func (s *GroupsService) GetUserGroupStatus(ctx context.Context, groupID, userID int) (*UserGroupStatus, error) {
+	var status UserGroupStatus
 	err := s.transactionManager.WithTransaction(ctx, func(tx *gorm.DB) error {
 		//检查用户组是否存在
-		_, err := s.groupDao.GetByGroupID(ctx, groupID, tx)
+		group, err := s.groupDao.GetByGroupID(ctx, groupID, tx)
 		if err != nil {
 			if errors.Is(err, gorm.ErrRecordNotFound) {
 				return appErrors.ErrGroupNotFound
 			}
 			return appErrors.ErrDatabaseOperation.WithError(err)
 		}
+		status.JoinPolicy = group.EffectiveJoinPolicy()
 		// 检查是否为组成员
 		member, err := s.groupMemberDao.GetMemberByGroupIDAndUserID(ctx, groupID, userID, tx)
 		if err != nil {
@@ -461,11 +844,23 @@ func (s *GroupsService) GetUserGroupStatus(ctx context.Context, groupID, userID
 				return appErrors.ErrDatabaseOperation.WithError(err)
 			}
 		}
+		// 上级用户组的管理员在下级用户组中具有管理员权限
+		var inherited []string
+		if group.ParentID != 0 {
+			inherited, err = s.inheritedPermissions(ctx, groupID, userID, tx)
+			// 未满足上级用户组两步验证要求的管理员不列出继承的权限
+			if err != nil && !errors.Is(err, appErrors.ErrAdminTwoFactorRequired) {
+				return err
+			}
+		}
 		if member != nil {
-			status = member.Role
-			requestID = 0
-			return nil
+			status.Status = member.Role
+			status.RequestID = 0
+			status.Role, status.Permissions, err = s.memberRole(ctx, group, member, tx)
+			status.Permissions = mergePermissions(status.Permissions, inherited)
+			return err
 		}
+		status.Permissions = inherited
 		// 非组成员，查看申请记录
 		Application, err := s.joinApplicationDao.GetByGroupIDAndUserID(ctx, groupID, userID, tx)
 		if err != nil {
@@ -474,16 +869,304 @@ func (s *GroupsService) GetUserGroupStatus(ctx context.Context, groupID, userID
 			}
 		}
 		if Application == nil {
-			status = "none"
-			requestID = 0
+			status.Status = "none"
+			status.RequestID = 0
 		} else {
-			status = Application.Status
-			requestID = Application.RequestID
+			status.Status = Application.Status
+			status.RequestID = Application.RequestID
+		}
+		status.CanApply = status.Status != "pending" && group.AcceptsApplications()
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &status, nil
+}
+
+// GroupOperator 执行组内角色变更的用户，运维命令的 UserID 为0
+type GroupOperator struct {
+	UserID int
+	// Username 运维命令在变更记录中的操作者名称，UserID 不为0时从 users 表读取当前用户名
+	Username string
+}
+
+// 设置成员的组内角色：admin、member 或用户组的自定义角色名称。操作者需要具有 member.role 权限，
+// 只有组管理员可以任命管理员，设置自定义角色时操作者必须具有该角色的全部权限；组管理员可以卸任自己的管理员身份，
+// 撤销其他管理员只能由所有者操作，所有者必须保留管理员身份。角色未变化时不写入变更记录
+func (s *GroupsService) SetMemberRole(ctx context.Context, groupID, userID int, role string, operator GroupOperator) (*models.GroupMember, error) {
+	role = strings.TrimSpace(role)
+	if role == "" || role == models.GroupRoleOwner {
+		return nil, appErrors.ErrGroupRoleInvalid
+	}
+	var updatedMember models.GroupMember
+	err := s.transactionManager.WithTransaction(ctx, func(tx *gorm.DB) error {
+		group, err := s.getGroup(ctx, groupID, tx)
+		if err != nil {
+			return err
+		}
+		operatorPermissions, err := s.checkOperatorPermission(ctx, groupID, operator.UserID, models.PermissionMemberRole)
+		if err != nil {
+			return err
+		}
+		member, err := s.groupMemberDao.GetMemberByGroupIDAndUserID(ctx, groupID, userID, tx)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return appErrors.ErrGroupMemberNotFound
+			}
+			return appErrors.ErrDatabaseOperation.WithError(err)
+		}
+		updatedMember = *member
+		// 目标角色：自定义角色的成员在成员表中的角色为 member
+		baseRole, roleID := role, 0
+		var grantedPermissions []string
+		switch role {
+		case "admin":
+			grantedPermissions = models.AdminPermissions
+		case "member":
+		default:
+			customRole, err := s.findRoleByName(ctx, groupID, role, tx)
+			if err != nil {
+				return err
+			}
+			baseRole, roleID = "member", customRole.ID
+			grantedPermissions = customRole.PermissionList()
+		}
+		if member.Role == baseRole && member.RoleID == roleID {
+			return nil
+		}
+		if !containsAll(operatorPermissions, grantedPermissions) {
+			return appErrors.ErrGroupPermissionExceeded
+		}
+		fromRole, currentPermissions, err := s.memberRole(ctx, group, member, tx)
+		if err != nil {
+			return err
+		}
+		if member.Role == "admin" {
+			if userID == group.CreatorID {
+				return appErrors.ErrGroupOwnerRequired
+			}
+			if userID != operator.UserID && operator.UserID != group.CreatorID {
+				return appErrors.ErrGroupOwnerOnly
+			}
+		} else if !containsAll(operatorPermissions, currentPermissions) {
+			// 不能调整权限高于自己的自定义角色
+			return appErrors.ErrGroupPermissionExceeded
+		}
+		action := models.GroupRoleChangeDemote
+		switch {
+		case role == "admin":
+			action = models.GroupRoleChangePromote
+		case roleID != 0:
+			action = models.GroupRoleChangeAssign
+		}
+		if member.Role != baseRole {
+			if err := s.groupMemberDao.UpdateRole(ctx, groupID, userID, baseRole, tx); err != nil {
+				return appErrors.ErrGroupUpdateFailed.WithError(err)
+			}
+		}
+		if member.RoleID != roleID {
+			if err := s.groupMemberDao.UpdateRoleID(ctx, groupID, userID, roleID, tx); err != nil {
+				return appErrors.ErrGroupUpdateFailed.WithError(err)
+			}
+		}
+		if err := s.recordRoleChange(ctx, member, action, fromRole, role, operator, tx); err != nil {
+			return err
 		}
+		updatedMember.Role = baseRole
+		updatedMember.RoleID = roleID
 		return nil
 	})
 	if err != nil {
-		return "", 0, err
+		return nil, err
+	}
+	return &updatedMember, nil
+}
+
+// 转让用户组所有权：新所有者必须是组成员，并被设置为管理员；原所有者保留管理员身份。
+// 通过接口操作时操作者必须是当前所有者，运维命令（operator.UserID 为0）不做该校验
+func (s *GroupsService) TransferOwnership(ctx context.Context, groupID, newOwnerID int, operator GroupOperator) (*models.Group, error) {
+	var updatedGroup *models.Group
+	err := s.transactionManager.WithTransaction(ctx, func(tx *gorm.DB) error {
+		var err error
+		updatedGroup, err = s.transferOwnership(ctx, groupID, newOwnerID, operator, tx)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return updatedGroup, nil
+}
+
+// transferOwnership 在调用方的事务中转让所有权，返回转让后的用户组
+func (s *GroupsService) transferOwnership(ctx context.Context, groupID, newOwnerID int, operator GroupOperator, tx *gorm.DB) (*models.Group, error) {
+	//检查用户组是否存在
+	group, err := s.groupDao.GetByGroupID(ctx, groupID, tx)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, appErrors.ErrGroupNotFound
+		}
+		return nil, appErrors.ErrDatabaseOperation.WithError(err)
+	}
+	if operator.UserID != 0 {
+		if operator.UserID != group.CreatorID {
+			return nil, appErrors.ErrGroupOwnerOnly
+		}
+		if _, err := s.checkOperatorPermission(ctx, groupID, operator.UserID, models.PermissionMemberRole); err != nil {
+			return nil, err
+		}
+	}
+	if newOwnerID == group.CreatorID {
+		return nil, appErrors.ErrGroupAlreadyOwner
+	}
+	//新所有者必须是组成员
+	member, err := s.groupMemberDao.GetMemberByGroupIDAndUserID(ctx, groupID, newOwnerID, tx)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, appErrors.ErrGroupMemberNotFound
+		}
+		return nil, appErrors.ErrDatabaseOperation.WithError(err)
+	}
+	if member.Role != "admin" {
+		if err := s.groupMemberDao.UpdateRole(ctx, groupID, newOwnerID, "admin", tx); err != nil {
+			return nil, appErrors.ErrGroupUpdateFailed.WithError(err)
+		}
+	}
+	if member.RoleID != 0 {
+		if err := s.groupMemberDao.UpdateRoleID(ctx, groupID, newOwnerID, 0, tx); err != nil {
+			return nil, appErrors.ErrGroupUpdateFailed.WithError(err)
+		}
+	}
+	if err := s.groupDao.UpdateCreator(ctx, groupID, newOwnerID, member.Username, tx); err != nil {
+		return nil, appErrors.ErrGroupUpdateFailed.WithError(err)
+	}
+	//原所有者仍是组成员时记录其降为管理员
+	previousOwner, err := s.groupMemberDao.GetMemberByGroupIDAndUserID(ctx, groupID, group.CreatorID, tx)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, appErrors.ErrDatabaseOperation.WithError(err)
+	}
+	if err == nil {
+		if err := s.recordRoleChange(ctx, previousOwner, models.GroupRoleChangeTransfer, models.GroupRoleOwner, previousOwner.Role, operator, tx); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.recordRoleChange(ctx, member, models.GroupRoleChangeTransfer, member.Role, models.GroupRoleOwner, operator, tx); err != nil {
+		return nil, err
+	}
+	group, err = s.groupDao.GetByGroupID(ctx, groupID, tx)
+	if err != nil {
+		return nil, appErrors.ErrDatabaseOperation.WithError(err)
+	}
+	return group, nil
+}
+
+// 查询用户组的角色变更记录，按时间倒序分页，需要具有 member.role 权限
+func (s *GroupsService) ListRoleChanges(ctx context.Context, groupID, operatorID, page, pageSize int) ([]*models.GroupRoleChange, int64, error) {
+	if _, err := s.GetGroupByGroupID(ctx, groupID); err != nil {
+		return nil, 0, err
+	}
+	if _, err := s.checkOperatorPermission(ctx, groupID, operatorID, models.PermissionMemberRole); err != nil {
+		return nil, 0, err
+	}
+	offset, limit := pageBounds(page, pageSize)
+	changes, total, err := s.groupRoleChangeDao.ListByGroupID(ctx, groupID, offset, limit)
+	if err != nil {
+		return nil, 0, appErrors.ErrDatabaseOperation.WithError(err)
+	}
+	return changes, total, nil
+}
+
+// checkOperatorPermission 操作者具有指定权限，返回操作者的全部权限；非组成员同样返回权限不足，
+// 不使用 operatorPermissionError 包装，以便接口层通过 errors.Is 区分权限不足与数据库错误
+func (s *GroupsService) checkOperatorPermission(ctx context.Context, groupID, operatorID int, permission string) ([]string, error) {
+	permissions, err := s.memberGrant(ctx, groupID, operatorID, permission)
+	if errors.Is(err, appErrors.ErrGroupMemberNotFound) {
+		return nil, appErrors.ErrRolePermissionDenied
+	}
+	return permissions, err
+}
+
+// recordRoleChange 在调用方的事务中写入角色变更记录
+func (s *GroupsService) recordRoleChange(ctx context.Context, member *models.GroupMember, action, fromRole, toRole string, operator GroupOperator, tx *gorm.DB) error {
+	operatorName := operator.Username
+	if operator.UserID != 0 {
+		var err error
+		operatorName, err = currentUsername(ctx, s.userDao, operator.UserID, tx)
+		if err != nil {
+			return err
+		}
+	}
+	err := s.groupRoleChangeDao.Create(ctx, &models.GroupRoleChange{
+		GroupID:      member.GroupID,
+		UserID:       member.UserID,
+		Username:     member.Username,
+		Action:       action,
+		FromRole:     fromRole,
+		ToRole:       toRole,
+		OperatorID:   operator.UserID,
+		OperatorName: operatorName,
+		CreatedAt:    time.Now(),
+	}, tx)
+	if err != nil {
+		return appErrors.ErrDatabaseOperation.WithError(err)
+	}
+	return nil
+}
+
+// MemberRecount 重新统计成员数量的结果
+type MemberRecount struct {
+	GroupID int
+	Before  int
+	After   int
+}
+
+// 按 group_member 重新统计用户组成员数量并修正 member_num，未指定groupIDs时处理所有用户组
+func (s *GroupsService) RecountMembers(ctx context.Context, groupIDs ...int) ([]MemberRecount, error) {
+	var results []MemberRecount
+	err := s.transactionManager.WithTransaction(ctx, func(tx *gorm.DB) error {
+		var err error
+		results, err = s.recountMembers(ctx, tx, groupIDs...)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// recountMembers 在调用方的事务中重新统计成员数量
+func (s *GroupsService) recountMembers(ctx context.Context, tx *gorm.DB, groupIDs ...int) ([]MemberRecount, error) {
+	var results []MemberRecount
+	var groups []*models.Group
+	if len(groupIDs) == 0 {
+		allGroups, err := s.groupDao.List(ctx, tx)
+		if err != nil {
+			return nil, appErrors.ErrDatabaseOperation.WithError(err)
+		}
+		groups = allGroups
+	}
+	for _, groupID := range groupIDs {
+		group, err := s.groupDao.GetByGroupID(ctx, groupID, tx)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, appErrors.ErrGroupNotFound
+			}
+			return nil, appErrors.ErrDatabaseOperation.WithError(err)
+		}
+		groups = append(groups, group)
+	}
+	for _, group := range groups {
+		members, err := s.groupMemberDao.GetMembersByGroupID(ctx, group.GroupID, tx)
+		if err != nil {
+			return nil, appErrors.ErrDatabaseOperation.WithError(err)
+		}
+		result := MemberRecount{GroupID: group.GroupID, Before: group.MemberNum, After: len(members)}
+		if result.Before != result.After {
+			if err := s.groupDao.SetMemberNum(ctx, group.GroupID, result.After, tx); err != nil {
+				return nil, appErrors.ErrGroupUpdateFailed.WithError(err)
+			}
+		}
+		results = append(results, result)
 	}
-	return status, requestID, nil
+	return results, nil
 }
@@ -75,6 +75,69 @@ func (m *mockGroupDAO) Delete(ctx context.Context, groupID int, tx ...*gorm.DB)
 	return args.Error(0)
 }
 
+func (m *mockGroupDAO) List(ctx context.Context, tx ...*gorm.DB) ([]*models.Group, error) {
+	args := m.Called(ctx, tx)
+	groupsArg := args.Get(0)
+	if groupsArg == nil {
+		return nil, args.Error(1)
+	}
+	return groupsArg.([]*models.Group), args.Error(1)
+}
+
+func (m *mockGroupDAO) UpdateCreator(ctx context.Context, groupID, creatorID int, creatorName string, tx ...*gorm.DB) error {
+	args := m.Called(ctx, groupID, creatorID, creatorName, tx)
+	return args.Error(0)
+}
+
+func (m *mockGroupDAO) SetMemberNum(ctx context.Context, groupID, memberNum int, tx ...*gorm.DB) error {
+	args := m.Called(ctx, groupID, memberNum, tx)
+	return args.Error(0)
+}
+
+func (m *mockGroupDAO) UpdateRequireAdmin2FA(ctx context.Context, groupID int, require bool, tx ...*gorm.DB) error {
+	args := m.Called(ctx, groupID, require, tx)
+	return args.Error(0)
+}
+
+func (m *mockGroupDAO) UpdateJoinPolicy(ctx context.Context, groupID int, policy string, tx ...*gorm.DB) error {
+	args := m.Called(ctx, groupID, policy, tx)
+	return args.Error(0)
+}
+
+func (m *mockGroupDAO) Search(ctx context.Context, keyword string, offset, limit int, tx ...*gorm.DB) ([]*models.Group, int64, error) {
+	args := m.Called(ctx, keyword, offset, limit, tx)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).([]*models.Group), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *mockGroupDAO) UpdateParent(ctx context.Context, groupID, parentID int, tx ...*gorm.DB) error {
+	args := m.Called(ctx, groupID, parentID, tx)
+	return args.Error(0)
+}
+
+func (m *mockGroupDAO) ReparentChildren(ctx context.Context, parentID, newParentID int, tx ...*gorm.DB) error {
+	args := m.Called(ctx, parentID, newParentID, tx)
+	return args.Error(0)
+}
+
+func (m *mockGroupDAO) GetAncestors(ctx context.Context, groupID int, tx ...*gorm.DB) ([]*models.Group, error) {
+	args := m.Called(ctx, groupID, tx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Group), args.Error(1)
+}
+
+func (m *mockGroupDAO) GetDescendants(ctx context.Context, groupID int, tx ...*gorm.DB) ([]*models.Group, error) {
+	args := m.Called(ctx, groupID, tx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Group), args.Error(1)
+}
+
 // Mock GroupMemberDAO
 type mockGroupMemberDAO struct {
 	mock.Mock
@@ -111,6 +174,32 @@ func (m *mockGroupMemberDAO) Delete(ctx context.Context, groupID, userID int, tx
 	return args.Error(0)
 }
 
+func (m *mockGroupMemberDAO) UpdateRole(ctx context.Context, groupID, userID int, role string, tx ...*gorm.DB) error {
+	args := m.Called(ctx, groupID, userID, role, tx)
+	return args.Error(0)
+}
+
+func (m *mockGroupMemberDAO) UpdateRoleID(ctx context.Context, groupID, userID, roleID int, tx ...*gorm.DB) error {
+	args := m.Called(ctx, groupID, userID, roleID, tx)
+	return args.Error(0)
+}
+
+func (m *mockGroupMemberDAO) GetMembersByGroupIDs(ctx context.Context, groupIDs []int, tx ...*gorm.DB) ([]*models.GroupMember, error) {
+	args := m.Called(ctx, groupIDs, tx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.GroupMember), args.Error(1)
+}
+
+func (m *mockGroupMemberDAO) GetByUserIDAndGroupIDs(ctx context.Context, userID int, groupIDs []int, tx ...*gorm.DB) ([]*models.GroupMember, error) {
+	args := m.Called(ctx, userID, groupIDs, tx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.GroupMember), args.Error(1)
+}
+
 // Mock JoinApplicationDAO
 type mockJoinApplicationDAO struct {
 	mock.Mock
@@ -177,6 +266,86 @@ func (m *mockJoinApplicationDAO) GetByGroupID(ctx context.Context, groupID int,
 	return applicationsArg.([]*models.JoinApplication), args.Error(1)
 }
 
+// Mock DenormalizationDAO
+type mockDenormalizationDAO struct {
+	mock.Mock
+}
+
+func (m *mockDenormalizationDAO) CountDrift(ctx context.Context, tx ...*gorm.DB) ([]models.ColumnDrift, error) {
+	args := m.Called(ctx, tx)
+	driftsArg := args.Get(0)
+	if driftsArg == nil {
+		return nil, args.Error(1)
+	}
+	return driftsArg.([]models.ColumnDrift), args.Error(1)
+}
+
+func (m *mockDenormalizationDAO) Repair(ctx context.Context, source string, sourceID int, tx ...*gorm.DB) ([]models.ColumnDrift, error) {
+	args := m.Called(ctx, source, sourceID, tx)
+	driftsArg := args.Get(0)
+	if driftsArg == nil {
+		return nil, args.Error(1)
+	}
+	return driftsArg.([]models.ColumnDrift), args.Error(1)
+}
+
+// Mock GroupRoleChangeDAO
+type mockGroupRoleChangeDAO struct {
+	mock.Mock
+}
+
+func (m *mockGroupRoleChangeDAO) Create(ctx context.Context, change *models.GroupRoleChange, tx ...*gorm.DB) error {
+	args := m.Called(ctx, change, tx)
+	return args.Error(0)
+}
+
+func (m *mockGroupRoleChangeDAO) ListByGroupID(ctx context.Context, groupID, offset, limit int, tx ...*gorm.DB) ([]*models.GroupRoleChange, int64, error) {
+	args := m.Called(ctx, groupID, offset, limit, tx)
+	changesArg := args.Get(0)
+	if changesArg == nil {
+		return nil, 0, args.Error(2)
+	}
+	return changesArg.([]*models.GroupRoleChange), args.Get(1).(int64), args.Error(2)
+}
+
+// Mock GroupRoleDAO
+type mockGroupRoleDAO struct {
+	mock.Mock
+}
+
+func (m *mockGroupRoleDAO) Create(ctx context.Context, role *models.GroupRole, tx ...*gorm.DB) error {
+	args := m.Called(ctx, role, tx)
+	return args.Error(0)
+}
+
+func (m *mockGroupRoleDAO) GetByID(ctx context.Context, id int, tx ...*gorm.DB) (*models.GroupRole, error) {
+	args := m.Called(ctx, id, tx)
+	roleArg := args.Get(0)
+	if roleArg == nil {
+		return nil, args.Error(1)
+	}
+	return roleArg.(*models.GroupRole), args.Error(1)
+}
+
+func (m *mockGroupRoleDAO) ListByGroupID(ctx context.Context, groupID int, tx ...*gorm.DB) ([]*models.GroupRole, error) {
+	args := m.Called(ctx, groupID, tx)
+	rolesArg := args.Get(0)
+	if rolesArg == nil {
+		return nil, args.Error(1)
+	}
+	return rolesArg.([]*models.GroupRole), args.Error(1)
+}
+
+func (m *mockGroupRoleDAO) Update(ctx context.Context, role *models.GroupRole, tx ...*gorm.DB) error {
+	args := m.Called(ctx, role, tx)
+	return args.Error(0)
+}
+
+func (m *mockGroupRoleDAO) Delete(ctx context.Context, id int, tx ...*gorm.DB) error {
+	args := m.Called(ctx, id, tx)
+	return args.Error(0)
+}
+
 // --- 测试准备 ---
 
 func setupGroupServiceTest() (*GroupsService, *mockGroupDAO, *mockGroupMemberDAO, *mockJoinApplicationDAO, *mockTransactionManager) {
@@ -189,7 +358,12 @@ func setupGroupServiceTest() (*GroupsService, *mockGroupDAO, *mockGroupMemberDAO
 		mockGroupDao,
 		mockGroupMemberDao,
 		mockJoinApplicationDao,
+		new(mockUserDAO),
+		new(mockDenormalizationDAO),
+		new(mockGroupRoleChangeDAO),
+		new(mockGroupRoleDAO),
 		mockTxManager,
+		discardLogger(),
 	)
 
 	return groupsService, mockGroupDao, mockGroupMemberDao, mockJoinApplicationDao, mockTxManager
@@ -207,6 +381,7 @@ func TestCreateGroup_Success(t *testing.T) {
 
 	// Mock期望
 	mockTxManager.On("WithTransaction", ctx, mock.AnythingOfType("func(*gorm.DB) error")).Return(nil)
+	groupsService.userDao.(*mockUserDAO).On("GetByID", ctx, creatorID, mock.AnythingOfType("[]*gorm.DB")).Return(&models.User{UserID: creatorID, Username: creatorName}, nil)
 	mockGroupDao.On("Create", ctx, mock.AnythingOfType("*models.Group"), mock.AnythingOfType("[]*gorm.DB")).Return(nil).Run(func(args mock.Arguments) {
 		// 验证传给Create的群组对象
 		groupArg := args.Get(1).(*models.Group)
@@ -225,7 +400,7 @@ func TestCreateGroup_Success(t *testing.T) {
 	})
 
 	// 调用函数
-	createdGroup, err := groupsService.CreateGroup(ctx, groupName, description, creatorName, creatorID)
+	createdGroup, err := groupsService.CreateGroup(ctx, groupName, description, creatorID)
 
 	// 断言
 	assert.NoError(t, err)
@@ -447,6 +622,8 @@ func TestUpdateGroup_Success(t *testing.T) {
 	mockGroupMemberDao.On("GetMemberByGroupIDAndUserID", ctx, groupID, operatorID, mock.AnythingOfType("[]*gorm.DB")).Return(adminMember, nil)
 	mockGroupDao.On("UpdateMessage", ctx, groupID, groupName, description, mock.AnythingOfType("[]*gorm.DB")).Return(nil)
 	mockGroupDao.On("GetByGroupID", ctx, groupID, mock.AnythingOfType("[]*gorm.DB")).Return(updatedGroup, nil)
+	mockDenormalizationDao := groupsService.denormalizationDao.(*mockDenormalizationDAO)
+	mockDenormalizationDao.On("Repair", ctx, models.DenormSourceGroup, groupID, mock.AnythingOfType("[]*gorm.DB")).Return([]models.ColumnDrift{}, nil)
 
 	// 调用函数
 	result, err := groupsService.UpdateGroup(ctx, groupID, operatorID, groupName, description)
@@ -460,10 +637,11 @@ func TestUpdateGroup_Success(t *testing.T) {
 	mockTxManager.AssertExpectations(t)
 	mockGroupDao.AssertExpectations(t)
 	mockGroupMemberDao.AssertExpectations(t)
+	mockDenormalizationDao.AssertExpectations(t)
 }
 
 func TestUpdateGroup_PermissionDenied(t *testing.T) {
-	groupsService, _, mockGroupMemberDao, _, mockTxManager := setupGroupServiceTest()
+	groupsService, mockGroupDao, mockGroupMemberDao, _, mockTxManager := setupGroupServiceTest()
 	ctx := context.Background()
 	groupID := 1
 	operatorID := 2 // 非管理员用户
@@ -479,6 +657,7 @@ func TestUpdateGroup_PermissionDenied(t *testing.T) {
 	// Mock期望
 	mockTxManager.On("WithTransaction", ctx, mock.AnythingOfType("func(*gorm.DB) error")).Return(nil)
 	mockGroupMemberDao.On("GetMemberByGroupIDAndUserID", ctx, groupID, operatorID, mock.AnythingOfType("[]*gorm.DB")).Return(member, nil)
+	mockGroupDao.On("GetAncestors", ctx, groupID, mock.AnythingOfType("[]*gorm.DB")).Return([]*models.Group{}, nil)
 
 	// 调用函数
 	result, err := groupsService.UpdateGroup(ctx, groupID, operatorID, groupName, description)
@@ -495,7 +674,7 @@ func TestUpdateGroup_PermissionDenied(t *testing.T) {
 }
 
 func TestUpdateGroup_NotMember(t *testing.T) {
-	groupsService, _, mockGroupMemberDao, _, mockTxManager := setupGroupServiceTest()
+	groupsService, mockGroupDao, mockGroupMemberDao, _, mockTxManager := setupGroupServiceTest()
 	ctx := context.Background()
 	groupID := 1
 	operatorID := 999 // 非成员用户
@@ -505,6 +684,7 @@ func TestUpdateGroup_NotMember(t *testing.T) {
 	// Mock期望
 	mockTxManager.On("WithTransaction", ctx, mock.AnythingOfType("func(*gorm.DB) error")).Return(nil)
 	mockGroupMemberDao.On("GetMemberByGroupIDAndUserID", ctx, groupID, operatorID, mock.AnythingOfType("[]*gorm.DB")).Return(nil, gorm.ErrRecordNotFound)
+	mockGroupDao.On("GetAncestors", ctx, groupID, mock.AnythingOfType("[]*gorm.DB")).Return([]*models.Group{}, nil)
 
 	// 调用函数
 	result, err := groupsService.UpdateGroup(ctx, groupID, operatorID, groupName, description)
@@ -524,7 +704,7 @@ func TestUpdateGroup_NotMember(t *testing.T) {
 // --- CheckMemberPermission 测试 ---
 
 func TestCheckMemberPermission_AdminSuccess(t *testing.T) {
-	groupsService, _, mockGroupMemberDao, _, _ := setupGroupServiceTest()
+	groupsService, mockGroupDao, mockGroupMemberDao, _, _ := setupGroupServiceTest()
 	ctx := context.Background()
 	groupID := 1
 	userID := 1
@@ -537,6 +717,7 @@ func TestCheckMemberPermission_AdminSuccess(t *testing.T) {
 
 	// Mock期望
 	mockGroupMemberDao.On("GetMemberByGroupIDAndUserID", ctx, groupID, userID, mock.AnythingOfType("[]*gorm.DB")).Return(member, nil)
+	mockGroupDao.On("GetByGroupID", ctx, groupID, mock.AnythingOfType("[]*gorm.DB")).Return(&models.Group{GroupID: groupID}, nil)
 
 	// 调用函数
 	err := groupsService.CheckMemberPermission(ctx, groupID, userID)
@@ -607,11 +788,13 @@ func TestAddMemberToGroup_Success(t *testing.T) {
 	// Mock期望
 	mockTxManager.On("WithTransaction", ctx, mock.AnythingOfType("func(*gorm.DB) error")).Return(nil)
 	mockGroupMemberDao.On("GetMemberByGroupIDAndUserID", ctx, groupID, userID, mock.AnythingOfType("[]*gorm.DB")).Return(nil, gorm.ErrRecordNotFound)
+	mockGroupDao.On("GetByGroupID", ctx, groupID, mock.AnythingOfType("[]*gorm.DB")).Return(&models.Group{GroupID: groupID, GroupName: "测试群组"}, nil)
 	mockGroupMemberDao.On("Create", ctx, mock.AnythingOfType("*models.GroupMember"), mock.AnythingOfType("[]*gorm.DB")).Return(nil).Run(func(args mock.Arguments) {
 		memberArg := args.Get(1).(*models.GroupMember)
 		assert.Equal(t, groupID, memberArg.GroupID)
 		assert.Equal(t, userID, memberArg.UserID)
 		assert.Equal(t, username, memberArg.Username)
+		assert.Equal(t, "测试群组", memberArg.GroupName)
 	})
 	mockGroupDao.On("UpdateMemberNum", ctx, groupID, true, mock.AnythingOfType("[]*gorm.DB")).Return(nil)
 
@@ -680,6 +863,9 @@ func TestRemoveMemberFromGroup_Success(t *testing.T) {
 	// Mock期望
 	mockTxManager.On("WithTransaction", ctx, mock.AnythingOfType("func(*gorm.DB) error")).Return(nil)
 	mockGroupMemberDao.On("GetMemberByGroupIDAndUserID", ctx, groupID, operatorID, mock.AnythingOfType("[]*gorm.DB")).Return(adminMember, nil)
+	mockGroupDao.On("GetByGroupID", ctx, groupID, mock.AnythingOfType("[]*gorm.DB")).Return(&models.Group{GroupID: groupID}, nil)
+	// 被移除的是普通成员，管理员即可移除
+	mockGroupMemberDao.On("GetMemberByGroupIDAndUserID", ctx, groupID, userID, mock.AnythingOfType("[]*gorm.DB")).Return(&models.GroupMember{GroupID: groupID, UserID: userID, Role: "member"}, nil)
 	mockGroupMemberDao.On("Delete", ctx, groupID, userID, mock.AnythingOfType("[]*gorm.DB")).Return(nil)
 	mockGroupDao.On("UpdateMemberNum", ctx, groupID, false, mock.AnythingOfType("[]*gorm.DB")).Return(nil)
 
@@ -711,6 +897,7 @@ func TestRemoveMemberFromGroup_NoPermission(t *testing.T) {
 	// Mock期望
 	mockTxManager.On("WithTransaction", ctx, mock.AnythingOfType("func(*gorm.DB) error")).Return(nil)
 	mockGroupMemberDao.On("GetMemberByGroupIDAndUserID", ctx, groupID, operatorID, mock.AnythingOfType("[]*gorm.DB")).Return(member, nil)
+	mockGroupDao.On("GetAncestors", ctx, groupID, mock.AnythingOfType("[]*gorm.DB")).Return([]*models.Group{}, nil)
 
 	// 调用函数
 	err := groupsService.RemoveMemberFromGroup(ctx, groupID, userID, operatorID)
@@ -805,6 +992,8 @@ func TestCreateJoinApplication_Success(t *testing.T) {
 	mockTxManager.On("WithTransaction", ctx, mock.AnythingOfType("func(*gorm.DB) error")).Return(nil)
 	mockGroupDao.On("GetByGroupID", ctx, groupID, mock.AnythingOfType("[]*gorm.DB")).Return(group, nil)
 	mockGroupMemberDao.On("GetMemberByGroupIDAndUserID", ctx, groupID, userID, mock.AnythingOfType("[]*gorm.DB")).Return(nil, gorm.ErrRecordNotFound)
+	mockJoinApplicationDao.On("GetByGroupIDAndUserID", ctx, groupID, userID, mock.AnythingOfType("[]*gorm.DB")).Return(nil, gorm.ErrRecordNotFound)
+	groupsService.userDao.(*mockUserDAO).On("GetByID", ctx, userID, mock.AnythingOfType("[]*gorm.DB")).Return(&models.User{UserID: userID, Username: username}, nil)
 	mockJoinApplicationDao.On("Create", ctx, mock.AnythingOfType("*models.JoinApplication"), mock.AnythingOfType("[]*gorm.DB")).Return(nil).Run(func(args mock.Arguments) {
 		appArg := args.Get(1).(*models.JoinApplication)
 		assert.Equal(t, groupID, appArg.GroupID)
@@ -814,7 +1003,7 @@ func TestCreateJoinApplication_Success(t *testing.T) {
 	})
 
 	// 调用函数
-	application, err := groupsService.CreateJoinApplication(ctx, groupID, userID, username, reason)
+	application, err := groupsService.CreateJoinApplication(ctx, groupID, userID, reason)
 
 	// 断言
 	assert.NoError(t, err)
@@ -837,7 +1026,6 @@ func TestCreateJoinApplication_GroupNotFound(t *testing.T) {
 	ctx := context.Background()
 	groupID := 999 // 不存在的群组
 	userID := 2
-	username := "applicant"
 	reason := "我想加入这个群组"
 
 	// Mock期望
@@ -845,7 +1033,7 @@ func TestCreateJoinApplication_GroupNotFound(t *testing.T) {
 	mockGroupDao.On("GetByGroupID", ctx, groupID, mock.AnythingOfType("[]*gorm.DB")).Return(nil, gorm.ErrRecordNotFound)
 
 	// 调用函数
-	application, err := groupsService.CreateJoinApplication(ctx, groupID, userID, username, reason)
+	application, err := groupsService.CreateJoinApplication(ctx, groupID, userID, reason)
 
 	// 断言
 	assert.Error(t, err)
@@ -881,7 +1069,7 @@ func TestCreateJoinApplication_AlreadyMember(t *testing.T) {
 	mockGroupMemberDao.On("GetMemberByGroupIDAndUserID", ctx, groupID, userID, mock.AnythingOfType("[]*gorm.DB")).Return(existingMember, nil)
 
 	// 调用函数
-	application, err := groupsService.CreateJoinApplication(ctx, groupID, userID, username, reason)
+	application, err := groupsService.CreateJoinApplication(ctx, groupID, userID, reason)
 
 	// 断言
 	assert.Error(t, err)
@@ -968,6 +1156,7 @@ func TestGetJoinApplicationsByGroupID_NoPermission(t *testing.T) {
 	// Mock期望
 	mockTxManager.On("WithTransaction", ctx, mock.AnythingOfType("func(*gorm.DB) error")).Return(nil)
 	mockGroupMemberDao.On("GetMemberByGroupIDAndUserID", ctx, groupID, operatorID, mock.AnythingOfType("[]*gorm.DB")).Return(member, nil)
+	mockGroupDao.On("GetAncestors", ctx, groupID, mock.AnythingOfType("[]*gorm.DB")).Return([]*models.Group{}, nil)
 
 	// 调用函数
 	applications, err := groupsService.GetJoinApplicationsByGroupID(ctx, groupID, operatorID)
@@ -1123,7 +1312,7 @@ func TestGetJoinApplicationsByGroupID_StatusFilter(t *testing.T) {
 	ctx := context.Background()
 	groupID := 1
 	operatorID := 1      // 管理员
-	filter := "accepted" // 指定状态过滤
+	filter := "approved" // 指定状态过滤，对应申请记录的 accepted 状态
 	adminMember := &models.GroupMember{
 		GroupID:  groupID,
 		UserID:   operatorID,
@@ -1150,7 +1339,7 @@ func TestGetJoinApplicationsByGroupID_StatusFilter(t *testing.T) {
 	mockTxManager.On("WithTransaction", ctx, mock.AnythingOfType("func(*gorm.DB) error")).Return(nil)
 	mockGroupMemberDao.On("GetMemberByGroupIDAndUserID", ctx, groupID, operatorID, mock.AnythingOfType("[]*gorm.DB")).Return(adminMember, nil)
 	mockGroupDao.On("GetByGroupID", ctx, groupID, mock.AnythingOfType("[]*gorm.DB")).Return(group, nil)
-	mockJoinApplicationDao.On("GetByGroupIDAndStatus", ctx, groupID, filter, mock.AnythingOfType("[]*gorm.DB")).Return(expectedApplications, nil)
+	mockJoinApplicationDao.On("GetByGroupIDAndStatus", ctx, groupID, "accepted", mock.AnythingOfType("[]*gorm.DB")).Return(expectedApplications, nil)
 
 	// 调用函数
 	applications, err := groupsService.GetJoinApplicationsByGroupID(ctx, groupID, operatorID, filter)
@@ -1172,7 +1361,7 @@ func TestGetJoinApplicationsByGroupID_StatusFilter(t *testing.T) {
 // --- RejectJoinApplication 测试 ---
 
 func TestRejectJoinApplication_Success(t *testing.T) {
-	groupsService, _, mockGroupMemberDao, mockJoinApplicationDao, mockTxManager := setupGroupServiceTest()
+	groupsService, mockGroupDao, mockGroupMemberDao, mockJoinApplicationDao, mockTxManager := setupGroupServiceTest()
 	ctx := context.Background()
 	groupID := 1
 	userID := 2
@@ -1190,6 +1379,7 @@ func TestRejectJoinApplication_Success(t *testing.T) {
 	// Mock期望
 	mockTxManager.On("WithTransaction", ctx, mock.AnythingOfType("func(*gorm.DB) error")).Return(nil)
 	mockGroupMemberDao.On("GetMemberByGroupIDAndUserID", ctx, groupID, operatorID, mock.AnythingOfType("[]*gorm.DB")).Return(adminMember, nil)
+	mockGroupDao.On("GetByGroupID", ctx, groupID, mock.AnythingOfType("[]*gorm.DB")).Return(&models.Group{GroupID: groupID}, nil)
 	mockJoinApplicationDao.On("UpdateStatus", ctx, requestID, "rejected", mock.AnythingOfType("[]*gorm.DB")).Return(nil)
 	mockJoinApplicationDao.On("UpdateRejectReason", ctx, requestID, rejectReason, mock.AnythingOfType("[]*gorm.DB")).Return(nil)
 
@@ -1206,7 +1396,7 @@ func TestRejectJoinApplication_Success(t *testing.T) {
 }
 
 func TestRejectJoinApplication_PermissionDenied(t *testing.T) {
-	groupsService, _, mockGroupMemberDao, _, mockTxManager := setupGroupServiceTest()
+	groupsService, mockGroupDao, mockGroupMemberDao, _, mockTxManager := setupGroupServiceTest()
 	ctx := context.Background()
 	groupID := 1
 	userID := 2
@@ -1224,6 +1414,7 @@ func TestRejectJoinApplication_PermissionDenied(t *testing.T) {
 	// Mock期望
 	mockTxManager.On("WithTransaction", ctx, mock.AnythingOfType("func(*gorm.DB) error")).Return(nil)
 	mockGroupMemberDao.On("GetMemberByGroupIDAndUserID", ctx, groupID, operatorID, mock.AnythingOfType("[]*gorm.DB")).Return(member, nil)
+	mockGroupDao.On("GetAncestors", ctx, groupID, mock.AnythingOfType("[]*gorm.DB")).Return([]*models.Group{}, nil)
 
 	// 调用函数
 	err := groupsService.RejectJoinApplication(ctx, groupID, userID, operatorID, requestID, username, rejectReason)
@@ -1244,7 +1435,7 @@ func TestDeleteGroup_Success(t *testing.T) {
 	groupsService, mockGroupDao, mockGroupMemberDao, _, mockTxManager := setupGroupServiceTest()
 	ctx := context.Background()
 	groupID := 1
-	operatorID := 1 // 管理员
+	operatorID := 1 // 所有者
 	adminMember := &models.GroupMember{
 		GroupID:  groupID,
 		UserID:   operatorID,
@@ -1255,7 +1446,11 @@ func TestDeleteGroup_Success(t *testing.T) {
 	// Mock期望
 	mockTxManager.On("WithTransaction", ctx, mock.AnythingOfType("func(*gorm.DB) error")).Return(nil)
 	mockGroupMemberDao.On("GetMemberByGroupIDAndUserID", ctx, groupID, operatorID, mock.AnythingOfType("[]*gorm.DB")).Return(adminMember, nil)
+	mockGroupDao.On("GetByGroupID", ctx, groupID, mock.AnythingOfType("[]*gorm.DB")).Return(&models.Group{GroupID: groupID, CreatorID: operatorID}, nil)
+	mockGroupDao.On("ReparentChildren", ctx, groupID, 0, mock.AnythingOfType("[]*gorm.DB")).Return(nil)
 	mockGroupDao.On("Delete", ctx, groupID, mock.AnythingOfType("[]*gorm.DB")).Return(nil)
+	mockGroupMemberDao.On("GetMembersByGroupID", ctx, groupID, mock.AnythingOfType("[]*gorm.DB")).Return([]*models.GroupMember{adminMember}, nil)
+	mockGroupMemberDao.On("Delete", ctx, groupID, operatorID, mock.AnythingOfType("[]*gorm.DB")).Return(nil)
 
 	// 调用函数
 	err := groupsService.DeleteGroup(ctx, groupID, operatorID)
@@ -1270,7 +1465,7 @@ func TestDeleteGroup_Success(t *testing.T) {
 }
 
 func TestDeleteGroup_PermissionDenied(t *testing.T) {
-	groupsService, _, mockGroupMemberDao, _, mockTxManager := setupGroupServiceTest()
+	groupsService, mockGroupDao, mockGroupMemberDao, _, mockTxManager := setupGroupServiceTest()
 	ctx := context.Background()
 	groupID := 1
 	operatorID := 2 // 非管理员
@@ -1284,6 +1479,7 @@ func TestDeleteGroup_PermissionDenied(t *testing.T) {
 	// Mock期望
 	mockTxManager.On("WithTransaction", ctx, mock.AnythingOfType("func(*gorm.DB) error")).Return(nil)
 	mockGroupMemberDao.On("GetMemberByGroupIDAndUserID", ctx, groupID, operatorID, mock.AnythingOfType("[]*gorm.DB")).Return(member, nil)
+	mockGroupDao.On("GetAncestors", ctx, groupID, mock.AnythingOfType("[]*gorm.DB")).Return([]*models.Group{}, nil)
 
 	// 调用函数
 	err := groupsService.DeleteGroup(ctx, groupID, operatorID)
@@ -1327,12 +1523,14 @@ func TestGetUserGroupStatus_Success(t *testing.T) {
 	mockJoinApplicationDao.On("GetByGroupIDAndUserID", ctx, groupID, userID, mock.AnythingOfType("[]*gorm.DB")).Return(application, nil)
 
 	// 调用函数
-	status, requestID, err := groupsService.GetUserGroupStatus(ctx, groupID, userID)
+	status, err := groupsService.GetUserGroupStatus(ctx, groupID, userID)
 
 	// 断言
 	assert.NoError(t, err)
-	assert.Equal(t, application.Status, status)
-	assert.Equal(t, application.RequestID, requestID)
+	assert.Equal(t, application.Status, status.Status)
+	assert.Equal(t, application.RequestID, status.RequestID)
+	assert.Equal(t, models.JoinPolicyApproval, status.JoinPolicy)
+	assert.False(t, status.CanApply) // 已有待审批的申请
 
 	// 验证mock调用
 	mockTxManager.AssertExpectations(t)
@@ -1359,12 +1557,13 @@ func TestGetUserGroupStatus_NotFound(t *testing.T) {
 	mockJoinApplicationDao.On("GetByGroupIDAndUserID", ctx, groupID, userID, mock.AnythingOfType("[]*gorm.DB")).Return(nil, gorm.ErrRecordNotFound)
 
 	// 调用函数
-	status, requestID, err := groupsService.GetUserGroupStatus(ctx, groupID, userID)
+	status, err := groupsService.GetUserGroupStatus(ctx, groupID, userID)
 
 	// 断言
 	assert.NoError(t, err) // 根据实现，这种情况应该不报错而是返回"none"状态
-	assert.Equal(t, "none", status)
-	assert.Equal(t, 0, requestID)
+	assert.Equal(t, "none", status.Status)
+	assert.Equal(t, 0, status.RequestID)
+	assert.True(t, status.CanApply)
 
 	// 验证mock调用
 	mockTxManager.AssertExpectations(t)
@@ -1396,12 +1595,13 @@ func TestGetUserGroupStatus_Member(t *testing.T) {
 	mockGroupMemberDao.On("GetMemberByGroupIDAndUserID", ctx, groupID, userID, mock.AnythingOfType("[]*gorm.DB")).Return(member, nil)
 
 	// 调用函数
-	status, requestID, err := groupsService.GetUserGroupStatus(ctx, groupID, userID)
+	status, err := groupsService.GetUserGroupStatus(ctx, groupID, userID)
 
 	// 断言
 	assert.NoError(t, err)
-	assert.Equal(t, member.Role, status) // 返回角色为状态
-	assert.Equal(t, 0, requestID)        // 组成员的requestID应为0
+	assert.Equal(t, member.Role, status.Status) // 返回角色为状态
+	assert.Equal(t, 0, status.RequestID)        // 组成员的requestID应为0
+	assert.False(t, status.CanApply)
 
 	// 验证mock调用
 	mockTxManager.AssertExpectations(t)
@@ -1429,6 +1629,7 @@ func TestApproveJoinApplication_Success(t *testing.T) {
 	// Mock期望
 	mockTxManager.On("WithTransaction", ctx, mock.AnythingOfType("func(*gorm.DB) error")).Return(nil)
 	mockGroupMemberDao.On("GetMemberByGroupIDAndUserID", ctx, groupID, operatorID, mock.AnythingOfType("[]*gorm.DB")).Return(adminMember, nil)
+	mockGroupDao.On("GetByGroupID", ctx, groupID, mock.AnythingOfType("[]*gorm.DB")).Return(&models.Group{GroupID: groupID}, nil)
 	mockGroupDao.On("UpdateMemberNum", ctx, groupID, true, mock.AnythingOfType("[]*gorm.DB")).Return(nil)
 	mockGroupMemberDao.On("Create", ctx, mock.AnythingOfType("*models.GroupMember"), mock.AnythingOfType("[]*gorm.DB")).Return(nil).Run(func(args mock.Arguments) {
 		memberArg := args.Get(1).(*models.GroupMember)
@@ -1452,7 +1653,7 @@ func TestApproveJoinApplication_Success(t *testing.T) {
 }
 
 func TestApproveJoinApplication_PermissionDenied(t *testing.T) {
-	groupsService, _, mockGroupMemberDao, _, mockTxManager := setupGroupServiceTest()
+	groupsService, mockGroupDao, mockGroupMemberDao, _, mockTxManager := setupGroupServiceTest()
 	ctx := context.Background()
 	groupID := 1
 	userID := 2
@@ -1469,6 +1670,7 @@ func TestApproveJoinApplication_PermissionDenied(t *testing.T) {
 	// Mock期望
 	mockTxManager.On("WithTransaction", ctx, mock.AnythingOfType("func(*gorm.DB) error")).Return(nil)
 	mockGroupMemberDao.On("GetMemberByGroupIDAndUserID", ctx, groupID, operatorID, mock.AnythingOfType("[]*gorm.DB")).Return(member, nil)
+	mockGroupDao.On("GetAncestors", ctx, groupID, mock.AnythingOfType("[]*gorm.DB")).Return([]*models.Group{}, nil)
 
 	// 调用函数
 	err := groupsService.ApproveJoinApplication(ctx, groupID, userID, operatorID, requestID, username)
@@ -1482,3 +1684,95 @@ func TestApproveJoinApplication_PermissionDenied(t *testing.T) {
 	mockTxManager.AssertExpectations(t)
 	mockGroupMemberDao.AssertExpectations(t)
 }
+
+// --- TransferOwnership 测试 ---
+
+func TestTransferOwnership_Success(t *testing.T) {
+	groupsService, mockGroupDao, mockGroupMemberDao, _, mockTxManager := setupGroupServiceTest()
+	ctx := context.Background()
+	groupID := 1
+	newOwnerID := 2
+	group := &models.Group{GroupID: groupID, GroupName: "测试组", CreatorID: 1, CreatorName: "owner", MemberNum: 2}
+	transferredGroup := &models.Group{GroupID: groupID, GroupName: "测试组", CreatorID: newOwnerID, CreatorName: "member", MemberNum: 2}
+	member := &models.GroupMember{GroupID: groupID, UserID: newOwnerID, Username: "member", Role: "member"}
+	previousOwner := &models.GroupMember{GroupID: groupID, UserID: 1, Username: "owner", Role: "admin"}
+	mockRoleChangeDao := groupsService.groupRoleChangeDao.(*mockGroupRoleChangeDAO)
+
+	// Mock期望
+	mockTxManager.On("WithTransaction", ctx, mock.AnythingOfType("func(*gorm.DB) error")).Return(nil)
+	mockGroupDao.On("GetByGroupID", ctx, groupID, mock.AnythingOfType("[]*gorm.DB")).Return(group, nil).Once()
+	mockGroupMemberDao.On("GetMemberByGroupIDAndUserID", ctx, groupID, newOwnerID, mock.AnythingOfType("[]*gorm.DB")).Return(member, nil)
+	mockGroupMemberDao.On("UpdateRole", ctx, groupID, newOwnerID, "admin", mock.AnythingOfType("[]*gorm.DB")).Return(nil)
+	mockGroupDao.On("UpdateCreator", ctx, groupID, newOwnerID, "member", mock.AnythingOfType("[]*gorm.DB")).Return(nil)
+	mockGroupMemberDao.On("GetMemberByGroupIDAndUserID", ctx, groupID, 1, mock.AnythingOfType("[]*gorm.DB")).Return(previousOwner, nil)
+	mockRoleChangeDao.On("Create", ctx, mock.MatchedBy(func(change *models.GroupRoleChange) bool {
+		return change.UserID == 1 && change.FromRole == models.GroupRoleOwner && change.ToRole == "admin"
+	}), mock.AnythingOfType("[]*gorm.DB")).Return(nil).Once()
+	mockRoleChangeDao.On("Create", ctx, mock.MatchedBy(func(change *models.GroupRoleChange) bool {
+		return change.UserID == newOwnerID && change.FromRole == "member" && change.ToRole == models.GroupRoleOwner && change.OperatorName == "cli"
+	}), mock.AnythingOfType("[]*gorm.DB")).Return(nil).Once()
+	mockGroupDao.On("GetByGroupID", ctx, groupID, mock.AnythingOfType("[]*gorm.DB")).Return(transferredGroup, nil).Once()
+
+	// 调用函数
+	result, err := groupsService.TransferOwnership(ctx, groupID, newOwnerID, GroupOperator{Username: "cli"})
+
+	// 断言
+	assert.NoError(t, err)
+	assert.Equal(t, transferredGroup, result)
+
+	// 验证mock调用
+	mockTxManager.AssertExpectations(t)
+	mockGroupDao.AssertExpectations(t)
+	mockGroupMemberDao.AssertExpectations(t)
+	mockRoleChangeDao.AssertExpectations(t)
+}
+
+func TestTransferOwnership_NotMember(t *testing.T) {
+	groupsService, mockGroupDao, mockGroupMemberDao, _, mockTxManager := setupGroupServiceTest()
+	ctx := context.Background()
+	groupID := 1
+	newOwnerID := 9
+
+	// Mock期望
+	mockTxManager.On("WithTransaction", ctx, mock.AnythingOfType("func(*gorm.DB) error")).Return(nil)
+	mockGroupDao.On("GetByGroupID", ctx, groupID, mock.AnythingOfType("[]*gorm.DB")).Return(&models.Group{GroupID: groupID}, nil)
+	mockGroupMemberDao.On("GetMemberByGroupIDAndUserID", ctx, groupID, newOwnerID, mock.AnythingOfType("[]*gorm.DB")).Return(nil, gorm.ErrRecordNotFound)
+
+	// 调用函数
+	result, err := groupsService.TransferOwnership(ctx, groupID, newOwnerID, GroupOperator{Username: "cli"})
+
+	// 断言
+	assert.ErrorIs(t, err, appErrors.ErrGroupMemberNotFound)
+	assert.Nil(t, result)
+	mockGroupDao.AssertNotCalled(t, "UpdateCreator", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+// --- RecountMembers 测试 ---
+
+func TestRecountMembers_AllGroups(t *testing.T) {
+	groupsService, mockGroupDao, mockGroupMemberDao, _, mockTxManager := setupGroupServiceTest()
+	ctx := context.Background()
+	groups := []*models.Group{
+		{GroupID: 1, MemberNum: 2},
+		{GroupID: 2, MemberNum: 5},
+	}
+
+	// Mock期望：组1数量正确，组2实际只有1名成员
+	mockTxManager.On("WithTransaction", ctx, mock.AnythingOfType("func(*gorm.DB) error")).Return(nil)
+	mockGroupDao.On("List", ctx, mock.AnythingOfType("[]*gorm.DB")).Return(groups, nil)
+	mockGroupMemberDao.On("GetMembersByGroupID", ctx, 1, mock.AnythingOfType("[]*gorm.DB")).Return([]*models.GroupMember{{UserID: 1}, {UserID: 2}}, nil)
+	mockGroupMemberDao.On("GetMembersByGroupID", ctx, 2, mock.AnythingOfType("[]*gorm.DB")).Return([]*models.GroupMember{{UserID: 3}}, nil)
+	mockGroupDao.On("SetMemberNum", ctx, 2, 1, mock.AnythingOfType("[]*gorm.DB")).Return(nil)
+
+	// 调用函数
+	results, err := groupsService.RecountMembers(ctx)
+
+	// 断言
+	assert.NoError(t, err)
+	assert.Equal(t, []MemberRecount{
+		{GroupID: 1, Before: 2, After: 2},
+		{GroupID: 2, Before: 5, After: 1},
+	}, results)
+	mockGroupDao.AssertNumberOfCalls(t, "SetMemberNum", 1)
+	mockGroupMemberDao.AssertExpectations(t)
+}
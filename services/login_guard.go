@@ -0,0 +1,179 @@
+package service
+
+import (
+	"TeamTickBackend/config"
+	"TeamTickBackend/dal/dao"
+	"TeamTickBackend/dal/models"
+	appErrors "TeamTickBackend/pkg/errors"
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// 清理过期计数记录的间隔
+const loginAttemptPurgeInterval = time.Hour
+
+// LoginGuard 登录防暴力破解
+//
+// 按用户名与客户端IP分别累计连续失败次数，计数与封禁截止时间保存在数据库中，
+// 多个实例共享同一份状态。同一用户名超过免检次数后每次失败的等待时间翻倍，
+// 达到锁定阈值后锁定一段时间；同一IP只在达到较高的阈值后锁定。
+// 不存在的用户名同样计数，避免通过锁定行为判断用户是否存在。
+type LoginGuard struct {
+	attemptDao dao.LoginAttemptDAO
+	cfg        config.LoginProtectionConfig
+	now        func() time.Time
+	log        *slog.Logger
+
+	purgeMu   sync.Mutex
+	lastPurge time.Time
+}
+
+func NewLoginGuard(attemptDao dao.LoginAttemptDAO, cfg config.LoginProtectionConfig, log *slog.Logger) *LoginGuard {
+	return &LoginGuard{
+		attemptDao: attemptDao,
+		cfg:        cfg,
+		now:        time.Now,
+		log:        log,
+	}
+}
+
+// Check 用户名或IP处于封禁期时返回 *appErrors.LoginLockedError
+func (g *LoginGuard) Check(ctx context.Context, username, clientIP string) error {
+	now := g.now()
+	var blockedUntil time.Time
+	for _, key := range g.keys(username, clientIP) {
+		attempt, err := g.attemptDao.Get(ctx, key.scope, key.subject)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				continue
+			}
+			return appErrors.ErrDatabaseOperation.WithError(err)
+		}
+		if attempt.BlockedUntil != nil && attempt.BlockedUntil.After(blockedUntil) {
+			blockedUntil = *attempt.BlockedUntil
+		}
+	}
+	if blockedUntil.After(now) {
+		return &appErrors.LoginLockedError{RetryAfter: blockedUntil.Sub(now)}
+	}
+	return nil
+}
+
+// RecordFailure 记录一次登录失败，按失败次数延长封禁；写入失败只记录日志，不影响登录接口的返回
+func (g *LoginGuard) RecordFailure(ctx context.Context, username, clientIP string) {
+	now := g.now()
+	windowStart := now.Add(-g.cfg.FailureWindow)
+	for _, key := range g.keys(username, clientIP) {
+		attempt, err := g.attemptDao.RecordFailure(ctx, key.scope, key.subject, now, windowStart)
+		if err != nil {
+			g.log.ErrorContext(ctx, "record login failure failed",
+				slog.String("scope", key.scope), slog.String("error", err.Error()))
+			continue
+		}
+		delay, lockout := g.blockDuration(key.scope, attempt.Failures)
+		if delay <= 0 {
+			continue
+		}
+		if err := g.attemptDao.ExtendBlock(ctx, key.scope, key.subject, now.Add(delay)); err != nil {
+			g.log.ErrorContext(ctx, "extend login block failed",
+				slog.String("scope", key.scope), slog.String("error", err.Error()))
+			continue
+		}
+		if lockout {
+			g.log.WarnContext(ctx, "login locked out",
+				slog.String("scope", key.scope),
+				slog.String("subject", key.subject),
+				slog.Int("failures", attempt.Failures),
+				slog.Duration("duration", delay))
+		}
+	}
+	g.purgeIfDue(ctx, now)
+}
+
+// RecordSuccess 登录成功后清除用户名的失败计数；IP计数保留，避免用一个可登录的账号重置IP计数
+func (g *LoginGuard) RecordSuccess(ctx context.Context, username string) {
+	if _, err := g.attemptDao.Delete(ctx, models.LoginAttemptScopeUsername, normalizeLoginSubject(username)); err != nil {
+		g.log.ErrorContext(ctx, "reset login failures failed", slog.String("error", err.Error()))
+	}
+}
+
+// Unlock 清除用户名和（或）IP的失败计数与封禁，返回是否存在需要清除的记录
+func (g *LoginGuard) Unlock(ctx context.Context, username, clientIP string) (bool, error) {
+	unlocked := false
+	for _, key := range g.keys(username, clientIP) {
+		deleted, err := g.attemptDao.Delete(ctx, key.scope, key.subject)
+		if err != nil {
+			return false, appErrors.ErrDatabaseOperation.WithError(err)
+		}
+		unlocked = unlocked || deleted
+	}
+	return unlocked, nil
+}
+
+// blockDuration 第n次连续失败后的封禁时长，lockout 表示达到锁定阈值
+func (g *LoginGuard) blockDuration(scope string, failures int) (delay time.Duration, lockout bool) {
+	threshold := g.cfg.LockoutThreshold
+	if scope == models.LoginAttemptScopeIP {
+		threshold = g.cfg.IPLockoutThreshold
+	}
+	if failures >= threshold {
+		return g.cfg.LockoutDuration, true
+	}
+	if scope == models.LoginAttemptScopeIP || failures <= g.cfg.FreeAttempts {
+		return 0, false
+	}
+	delay = g.cfg.BaseDelay
+	for i := g.cfg.FreeAttempts + 1; i < failures && delay < g.cfg.MaxDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, g.cfg.MaxDelay), false
+}
+
+// purgeIfDue 定期清理超出统计窗口且不在封禁期的记录，多实例重复执行无副作用
+func (g *LoginGuard) purgeIfDue(ctx context.Context, now time.Time) {
+	if !g.purgeMu.TryLock() {
+		return
+	}
+	defer g.purgeMu.Unlock()
+	if now.Sub(g.lastPurge) < loginAttemptPurgeInterval {
+		return
+	}
+	g.lastPurge = now
+	before := now.Add(-g.cfg.FailureWindow)
+	if _, err := g.attemptDao.DeleteStale(ctx, before); err != nil {
+		g.log.WarnContext(ctx, "purge login attempts failed", slog.String("error", err.Error()))
+	}
+}
+
+type loginAttemptKey struct {
+	scope   string
+	subject string
+}
+
+// keys 需要检查与计数的维度，空值跳过
+func (g *LoginGuard) keys(username, clientIP string) []loginAttemptKey {
+	keys := make([]loginAttemptKey, 0, 2)
+	if subject := normalizeLoginSubject(username); subject != "" {
+		keys = append(keys, loginAttemptKey{scope: models.LoginAttemptScopeUsername, subject: subject})
+	}
+	if clientIP != "" {
+		keys = append(keys, loginAttemptKey{scope: models.LoginAttemptScopeIP, subject: clientIP})
+	}
+	return keys
+}
+
+// normalizeLoginSubject 用户名不区分大小写计数（MySQL默认排序规则下用户名同样不区分大小写），
+// 超出列宽的部分截断，这样的用户名不可能存在
+func normalizeLoginSubject(username string) string {
+	subject := []rune(strings.ToLower(strings.TrimSpace(username)))
+	if len(subject) > 64 {
+		subject = subject[:64]
+	}
+	return string(subject)
+}
@@ -0,0 +1,137 @@
+package service
+
+import (
+	"TeamTickBackend/config"
+	"TeamTickBackend/dal/dao"
+	"TeamTickBackend/pkg"
+	apperrors "TeamTickBackend/pkg/errors"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// 免检3次，等待时间从1s翻倍、最多4s，第6次失败锁定30分钟，同一IP失败5次锁定
+func setupLoginGuardTest(t *testing.T) (*LoginGuard, *AuthService, *time.Time) {
+	factory := dao.NewMemoryDAOFactory()
+	guard := NewLoginGuard(factory.LoginAttemptDAO, config.LoginProtectionConfig{
+		Enabled:            true,
+		FreeAttempts:       3,
+		BaseDelay:          time.Second,
+		MaxDelay:           4 * time.Second,
+		LockoutThreshold:   6,
+		LockoutDuration:    30 * time.Minute,
+		IPLockoutThreshold: 5,
+		FailureWindow:      time.Hour,
+	}, discardLogger())
+	now := time.Now()
+	guard.now = func() time.Time { return now }
+	authService := NewAuthService(factory.UserDAO, factory.TransactionManager, new(mockJwtHandler), guard, nil, discardLogger())
+	_, err := authService.AuthRegister(context.Background(), "alice", "secret1")
+	require.NoError(t, err)
+	return guard, authService, &now
+}
+
+// retryAfter 返回锁定剩余时间，未锁定时为0
+func retryAfter(t *testing.T, err error) time.Duration {
+	if err == nil {
+		return 0
+	}
+	var locked *apperrors.LoginLockedError
+	require.ErrorAs(t, err, &locked)
+	assert.ErrorIs(t, err, apperrors.ErrLoginLocked)
+	return locked.RetryAfter
+}
+
+func TestLoginGuard_ExponentialBackoffAndLockout(t *testing.T) {
+	guard, _, now := setupLoginGuardTest(t)
+	ctx := context.Background()
+
+	expected := []time.Duration{0, 0, 0, time.Second, 2 * time.Second, 30 * time.Minute}
+	for i, delay := range expected {
+		guard.RecordFailure(ctx, "alice", "")
+		assert.Equal(t, delay, retryAfter(t, guard.Check(ctx, "alice", "")), "第%d次失败后", i+1)
+		*now = now.Add(delay)
+	}
+
+	// 计数不区分用户名大小写
+	guard.RecordFailure(ctx, "ALICE", "")
+	assert.Equal(t, 30*time.Minute, retryAfter(t, guard.Check(ctx, "Alice", "")))
+
+	unlocked, err := guard.Unlock(ctx, "alice", "")
+	require.NoError(t, err)
+	assert.True(t, unlocked)
+	assert.NoError(t, guard.Check(ctx, "alice", ""))
+}
+
+func TestLoginGuard_FailureWindowResetsCount(t *testing.T) {
+	guard, _, now := setupLoginGuardTest(t)
+	ctx := context.Background()
+
+	for i := 0; i < 4; i++ {
+		guard.RecordFailure(ctx, "alice", "")
+	}
+	assert.Equal(t, time.Second, retryAfter(t, guard.Check(ctx, "alice", "")))
+
+	*now = now.Add(2 * time.Hour)
+	guard.RecordFailure(ctx, "alice", "")
+	assert.NoError(t, guard.Check(ctx, "alice", ""))
+}
+
+func TestLoginGuard_UniformErrorsAndLockedLogin(t *testing.T) {
+	guard, authService, _ := setupLoginGuardTest(t)
+	ctx := context.Background()
+
+	_, err := authService.VerifyCredentials(ctx, "alice", "wrong-password", "10.0.0.1")
+	assert.ErrorIs(t, err, apperrors.ErrInvalidCredentials)
+	_, err = authService.VerifyCredentials(ctx, "nobody", "secret1", "10.0.0.2")
+	assert.ErrorIs(t, err, apperrors.ErrInvalidCredentials)
+
+	// 登录成功清除用户名计数
+	_, err = authService.VerifyCredentials(ctx, "alice", "secret1", "10.0.0.1")
+	require.NoError(t, err)
+	for i := 0; i < 6; i++ {
+		guard.RecordFailure(ctx, "alice", "")
+	}
+	_, err = authService.VerifyCredentials(ctx, "alice", "secret1", "10.0.0.1")
+	assert.Equal(t, 30*time.Minute, retryAfter(t, err), "前一次成功登录后重新计数，第6次失败锁定")
+
+	// 不存在的用户名同样计数，连同上面的一次共失败4次后需要等待
+	for i := 0; i < 3; i++ {
+		_, err = authService.VerifyCredentials(ctx, "nobody", "secret1", "")
+	}
+	assert.ErrorIs(t, err, apperrors.ErrInvalidCredentials)
+	_, err = authService.VerifyCredentials(ctx, "nobody", "secret1", "")
+	assert.ErrorIs(t, err, apperrors.ErrLoginLocked)
+
+	_, err = guard.Unlock(ctx, "alice", "")
+	require.NoError(t, err)
+	user, err := authService.VerifyCredentials(ctx, "alice", "secret1", "10.0.0.1")
+	require.NoError(t, err)
+	assert.True(t, pkg.CheckPassword(user.Password, "secret1"))
+}
+
+func TestLoginGuard_IPLockoutAcrossUsernames(t *testing.T) {
+	guard, authService, _ := setupLoginGuardTest(t)
+	ctx := context.Background()
+
+	for _, username := range []string{"u1", "u2", "u3", "u4", "u5"} {
+		_, err := authService.VerifyCredentials(ctx, username, "guess", "10.0.0.9")
+		assert.ErrorIs(t, err, apperrors.ErrInvalidCredentials)
+	}
+	_, err := authService.VerifyCredentials(ctx, "alice", "secret1", "10.0.0.9")
+	assert.Equal(t, 30*time.Minute, retryAfter(t, err))
+
+	// 其它IP不受影响，且登录成功不清除IP计数
+	_, err = authService.VerifyCredentials(ctx, "alice", "secret1", "10.0.0.8")
+	require.NoError(t, err)
+	assert.Error(t, guard.Check(ctx, "", "10.0.0.9"))
+
+	unlocked, err := guard.Unlock(ctx, "", "10.0.0.9")
+	require.NoError(t, err)
+	assert.True(t, unlocked)
+	_, err = authService.VerifyCredentials(ctx, "alice", "secret1", "10.0.0.9")
+	assert.NoError(t, err)
+}
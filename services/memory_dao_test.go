@@ -0,0 +1,148 @@
+package service
+
+import (
+	"TeamTickBackend/dal/dao"
+	"TeamTickBackend/dal/models"
+	apperrors "TeamTickBackend/pkg/errors"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// 使用内存DAO运行真实的服务逻辑，无需数据库
+
+// discardLogger 丢弃服务日志，需要断言日志内容的测试自行传入写到缓冲区的logger
+func discardLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(io.Discard, nil))
+}
+
+// newMemoryGroupsService 基于内存DAO构造用户组服务
+func newMemoryGroupsService(t *testing.T, factory *dao.DAOFactory) *GroupsService {
+	t.Helper()
+	return NewGroupsService(factory.GroupDAO, factory.GroupMemberDAO, factory.JoinApplicationDAO, factory.UserDAO, factory.DenormalizationDAO, factory.GroupRoleChangeDAO, factory.GroupRoleDAO, factory.TransactionManager, discardLogger())
+}
+
+// newMemoryTaskService 基于内存DAO构造签到任务服务
+func newMemoryTaskService(t *testing.T, factory *dao.DAOFactory) *TaskService {
+	t.Helper()
+	return NewTaskService(factory.TaskDAO, factory.TaskRecordDAO, factory.TransactionManager, factory.GroupDAO, factory.DenormalizationDAO, factory.UserDAO, discardLogger())
+}
+
+// seedUsers 按顺序创建用户，内存DAO中用户ID从1开始递增
+func seedUsers(t *testing.T, factory *dao.DAOFactory, names ...string) {
+	t.Helper()
+	for _, name := range names {
+		require.NoError(t, factory.UserDAO.Create(context.Background(), &models.User{Username: name, Password: "x"}))
+	}
+}
+
+func TestMemoryDAO_RegisterDuplicateUsername(t *testing.T) {
+	factory := dao.NewMemoryDAOFactory()
+	jwtHandler := new(mockJwtHandler)
+	authService := NewAuthService(factory.UserDAO, factory.TransactionManager, jwtHandler, nil, nil, discardLogger())
+	ctx := context.Background()
+
+	user, err := authService.AuthRegister(ctx, "alice", "password123")
+	require.NoError(t, err)
+	assert.Equal(t, 1, user.UserID)
+
+	_, err = authService.AuthRegister(ctx, "alice", "password456")
+	assert.ErrorIs(t, err, apperrors.ErrUserAlreadyExists)
+
+	// 绕过服务层直接写入时由唯一约束拦截
+	err = factory.UserDAO.Create(ctx, &models.User{Username: "alice", Password: "x"})
+	assert.ErrorIs(t, err, gorm.ErrDuplicatedKey)
+}
+
+func TestMemoryDAO_CheckInTwice(t *testing.T) {
+	factory := dao.NewMemoryDAOFactory()
+	groupsService := newMemoryGroupsService(t, factory)
+	taskService := newMemoryTaskService(t, factory)
+	ctx := context.Background()
+	seedUsers(t, factory, "alice")
+
+	group, err := groupsService.CreateGroup(ctx, "实训一组", "", 1)
+	require.NoError(t, err)
+	assert.Equal(t, 1, group.MemberNum)
+
+	now := time.Now()
+	task, err := taskService.CreateTask(ctx, "早签到", "", group.GroupID, now.Add(-time.Hour), now.Add(time.Hour), 30.0, 120.0, 0, true, false, false, false)
+	require.NoError(t, err)
+	assert.Equal(t, 50, task.Radius)
+
+	active, err := factory.TaskDAO.GetActiveTasksByUserID(ctx, 1)
+	require.NoError(t, err)
+	assert.Len(t, active, 1)
+
+	record, err := taskService.CheckInTask(ctx, task.TaskID, 1, 30.0, 120.0, now)
+	require.NoError(t, err)
+	assert.Equal(t, group.GroupName, record.GroupName)
+	assert.Equal(t, "alice", record.Username)
+
+	_, err = taskService.CheckInTask(ctx, task.TaskID, 1, 30.0, 120.0, now)
+	assert.ErrorIs(t, err, apperrors.ErrTaskRecordAlreadyExists)
+
+	// idx_task_user_id
+	err = factory.TaskRecordDAO.Create(ctx, &models.TaskRecord{TaskID: task.TaskID, UserID: 1})
+	assert.ErrorIs(t, err, gorm.ErrDuplicatedKey)
+}
+
+func TestMemoryDAO_JoinApplicationUnique(t *testing.T) {
+	factory := dao.NewMemoryDAOFactory()
+	ctx := context.Background()
+
+	application := &models.JoinApplication{GroupID: 1, UserID: 2, Username: "bob", Reason: "加入"}
+	require.NoError(t, factory.JoinApplicationDAO.Create(ctx, application))
+	assert.Equal(t, "pending", application.Status)
+
+	// idx_groupid_userid
+	err := factory.JoinApplicationDAO.Create(ctx, &models.JoinApplication{GroupID: 1, UserID: 2, Username: "bob"})
+	assert.ErrorIs(t, err, gorm.ErrDuplicatedKey)
+}
+
+func TestMemoryDAO_TransactionRollback(t *testing.T) {
+	factory := dao.NewMemoryDAOFactory()
+	ctx := context.Background()
+	errAbort := errors.New("abort")
+
+	err := factory.TransactionManager.WithTransaction(ctx, func(tx *gorm.DB) error {
+		group := &models.Group{GroupName: "临时组", CreatorID: 1, CreatorName: "alice"}
+		if err := factory.GroupDAO.Create(ctx, group, tx); err != nil {
+			return err
+		}
+		if err := factory.GroupDAO.UpdateMemberNum(ctx, group.GroupID, true, tx); err != nil {
+			return err
+		}
+		return errAbort
+	})
+	assert.ErrorIs(t, err, errAbort)
+
+	_, err = factory.GroupDAO.GetByGroupID(ctx, 1)
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+
+	// panic 同样回滚并继续向上抛出
+	assert.Panics(t, func() {
+		_ = factory.TransactionManager.WithTransaction(ctx, func(tx *gorm.DB) error {
+			_ = factory.UserDAO.Create(ctx, &models.User{Username: "carol"}, tx)
+			panic("boom")
+		})
+	})
+	_, err = factory.UserDAO.GetByUsername(ctx, "carol")
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+
+	// 提交后的修改可见
+	err = factory.TransactionManager.WithTransaction(ctx, func(tx *gorm.DB) error {
+		return factory.UserDAO.Create(ctx, &models.User{Username: "dave"}, tx)
+	})
+	require.NoError(t, err)
+	user, err := factory.UserDAO.GetByUsername(ctx, "dave")
+	require.NoError(t, err)
+	assert.Equal(t, "dave", user.Username)
+}
@@ -0,0 +1,307 @@
+package service
+
+import (
+	"TeamTickBackend/config"
+	"TeamTickBackend/dal/dao"
+	"TeamTickBackend/dal/models"
+	"TeamTickBackend/pkg"
+	appErrors "TeamTickBackend/pkg/errors"
+	"TeamTickBackend/pkg/oidc"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/oauth2"
+	"gorm.io/gorm"
+)
+
+// 自动创建用户时的用户名限制，与注册接口一致
+const (
+	oidcUsernameMinLength = 3
+	oidcUsernameMaxLength = 50
+	// 用户名冲突时追加数字后缀的最大尝试次数，之后改用随机用户名
+	oidcUsernameAttempts = 20
+)
+
+// OIDCAuthorization 发起授权的结果，前端将浏览器跳转到 URL，回调时原样提交 state
+type OIDCAuthorization struct {
+	URL   string
+	State string
+}
+
+// OIDCService OpenID Connect 授权码登录（PKCE）：发起授权、处理回调、按 subject 关联或自动创建用户
+type OIDCService struct {
+	userDao            dao.UserDAO
+	identityDao        dao.UserIdentityDAO
+	stateDao           dao.OIDCLoginStateDAO
+	transactionManager dao.TransactionManager
+	provider           oidc.Provider
+	cfg                config.OIDCConfig
+	now                func() time.Time
+	log                *slog.Logger
+}
+
+// NewOIDCService provider 为nil表示功能未开启
+func NewOIDCService(
+	userDao dao.UserDAO,
+	identityDao dao.UserIdentityDAO,
+	stateDao dao.OIDCLoginStateDAO,
+	transactionManager dao.TransactionManager,
+	provider oidc.Provider,
+	cfg config.OIDCConfig,
+	log *slog.Logger,
+) *OIDCService {
+	return &OIDCService{
+		userDao:            userDao,
+		identityDao:        identityDao,
+		stateDao:           stateDao,
+		transactionManager: transactionManager,
+		provider:           provider,
+		cfg:                cfg,
+		now:                time.Now,
+		log:                log,
+	}
+}
+
+// BeginLogin 发起登录，device 在回调完成后用于创建会话
+func (s *OIDCService) BeginLogin(ctx context.Context, device DeviceInfo) (*OIDCAuthorization, error) {
+	return s.begin(ctx, &models.OIDCLoginState{DeviceID: device.ID, DeviceName: device.Name})
+}
+
+// BeginLink 已登录用户发起关联，回调完成后将外部身份关联到该用户
+func (s *OIDCService) BeginLink(ctx context.Context, userID int) (*OIDCAuthorization, error) {
+	return s.begin(ctx, &models.OIDCLoginState{LinkUserID: userID})
+}
+
+func (s *OIDCService) begin(ctx context.Context, loginState *models.OIDCLoginState) (*OIDCAuthorization, error) {
+	if s.provider == nil {
+		return nil, appErrors.ErrOIDCDisabled
+	}
+	state, err := pkg.GenerateOpaqueToken()
+	if err != nil {
+		return nil, appErrors.ErrTokenGenerationFailed.WithError(err)
+	}
+	nonce, err := pkg.GenerateOpaqueToken()
+	if err != nil {
+		return nil, appErrors.ErrTokenGenerationFailed.WithError(err)
+	}
+	verifier := oauth2.GenerateVerifier()
+
+	authURL, err := s.provider.AuthCodeURL(ctx, state, nonce, verifier)
+	if err != nil {
+		s.log.ErrorContext(ctx, "oidc discovery failed", slog.String("error", err.Error()))
+		return nil, appErrors.ErrOIDCProviderUnavailable
+	}
+	now := s.now()
+	loginState.StateHash = pkg.HashOpaqueToken(state)
+	loginState.CodeVerifier = verifier
+	loginState.Nonce = nonce
+	loginState.ExpiresAt = now.Add(s.cfg.StateExpiry)
+	loginState.CreatedAt = now
+	if err := s.stateDao.Create(ctx, loginState); err != nil {
+		return nil, appErrors.ErrDatabaseOperation.WithError(err)
+	}
+	// 顺带清理过期的授权请求，失败不影响登录
+	if _, err := s.stateDao.DeleteExpired(ctx, now); err != nil {
+		s.log.WarnContext(ctx, "purge oidc login states failed", slog.String("error", err.Error()))
+	}
+	return &OIDCAuthorization{URL: authURL, State: state}, nil
+}
+
+// Complete 处理回调：消费 state，用授权码和 PKCE code_verifier 换取并校验 ID Token，
+// 再按 issuer + subject 找到关联的用户；未关联时按配置自动创建用户，发起的是关联请求时关联到发起的用户。
+// 返回的设备信息为发起登录时提交的设备
+func (s *OIDCService) Complete(ctx context.Context, code, state string) (*models.User, DeviceInfo, error) {
+	if s.provider == nil {
+		return nil, DeviceInfo{}, appErrors.ErrOIDCDisabled
+	}
+	loginState, err := s.consumeState(ctx, state)
+	if err != nil {
+		return nil, DeviceInfo{}, err
+	}
+	device := DeviceInfo{ID: loginState.DeviceID, Name: loginState.DeviceName}
+
+	claims, err := s.provider.Exchange(ctx, code, loginState.CodeVerifier)
+	if err != nil {
+		if errors.Is(err, oidc.ErrDiscovery) {
+			s.log.ErrorContext(ctx, "oidc discovery failed", slog.String("error", err.Error()))
+			return nil, DeviceInfo{}, appErrors.ErrOIDCProviderUnavailable
+		}
+		s.log.WarnContext(ctx, "oidc code exchange failed", slog.String("error", err.Error()))
+		return nil, DeviceInfo{}, appErrors.ErrOIDCExchangeFailed
+	}
+	if claims.Subject == "" || claims.Nonce != loginState.Nonce {
+		s.log.WarnContext(ctx, "oidc id_token nonce mismatch", slog.String("issuer", claims.Issuer))
+		return nil, DeviceInfo{}, appErrors.ErrOIDCExchangeFailed
+	}
+
+	var user *models.User
+	err = s.transactionManager.WithTransaction(ctx, func(tx *gorm.DB) error {
+		identity, err := s.identityDao.GetByIssuerSubject(ctx, claims.Issuer, claims.Subject, tx)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return appErrors.ErrDatabaseOperation.WithError(err)
+		}
+		switch {
+		case loginState.LinkUserID != 0:
+			if identity != nil {
+				if identity.UserID != loginState.LinkUserID {
+					return appErrors.ErrOIDCIdentityLinked
+				}
+			} else if err := s.link(ctx, loginState.LinkUserID, claims, tx); err != nil {
+				return err
+			}
+			user, err = s.getUser(ctx, loginState.LinkUserID, tx)
+			return err
+		case identity != nil:
+			user, err = s.getUser(ctx, identity.UserID, tx)
+			return err
+		case s.cfg.AutoProvision:
+			user, err = s.provision(ctx, claims, tx)
+			return err
+		default:
+			return appErrors.ErrOIDCAccountNotLinked
+		}
+	})
+	if err != nil {
+		return nil, DeviceInfo{}, err
+	}
+	if user.DisabledAt != nil {
+		return nil, DeviceInfo{}, appErrors.ErrUserDisabled
+	}
+	return user, device, nil
+}
+
+// consumeState 取出并删除授权请求状态，state 只能使用一次
+func (s *OIDCService) consumeState(ctx context.Context, state string) (*models.OIDCLoginState, error) {
+	loginState, err := s.stateDao.GetByStateHash(ctx, pkg.HashOpaqueToken(state))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, appErrors.ErrOIDCStateInvalid
+		}
+		return nil, appErrors.ErrDatabaseOperation.WithError(err)
+	}
+	deleted, err := s.stateDao.Delete(ctx, loginState.ID)
+	if err != nil {
+		return nil, appErrors.ErrDatabaseOperation.WithError(err)
+	}
+	if !deleted || !s.now().Before(loginState.ExpiresAt) {
+		return nil, appErrors.ErrOIDCStateInvalid
+	}
+	return loginState, nil
+}
+
+func (s *OIDCService) link(ctx context.Context, userID int, claims *oidc.Claims, tx *gorm.DB) error {
+	err := s.identityDao.Create(ctx, &models.UserIdentity{
+		UserID:    userID,
+		Issuer:    claims.Issuer,
+		Subject:   claims.Subject,
+		CreatedAt: s.now(),
+	}, tx)
+	if err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return appErrors.ErrOIDCIdentityLinked
+		}
+		return appErrors.ErrDatabaseOperation.WithError(err)
+	}
+	s.log.InfoContext(ctx, "oidc identity linked", slog.Int("user_id", userID), slog.String("issuer", claims.Issuer))
+	return nil
+}
+
+// provision 为首次登录的外部身份创建用户，密码为空因此不能使用密码登录，可通过重置密码设置
+func (s *OIDCService) provision(ctx context.Context, claims *oidc.Claims, tx *gorm.DB) (*models.User, error) {
+	username, err := s.availableUsername(ctx, claims.String(s.cfg.UsernameClaim), tx)
+	if err != nil {
+		return nil, err
+	}
+	user := &models.User{Username: username}
+	if err := s.userDao.Create(ctx, user, tx); err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, appErrors.ErrUserAlreadyExists
+		}
+		return nil, appErrors.ErrUserCreationFailed.WithError(err)
+	}
+	if err := s.link(ctx, user.UserID, claims, tx); err != nil {
+		return nil, err
+	}
+	s.log.InfoContext(ctx, "oidc user provisioned", slog.Int("user_id", user.UserID), slog.String("username", username))
+	return user, nil
+}
+
+// availableUsername 由声明生成未被占用的用户名，冲突时追加数字后缀，声明不可用时使用随机用户名
+func (s *OIDCService) availableUsername(ctx context.Context, claim string, tx *gorm.DB) (string, error) {
+	base := sanitizeUsername(claim)
+	if utf8.RuneCountInString(base) >= oidcUsernameMinLength {
+		for i := 1; i <= oidcUsernameAttempts; i++ {
+			candidate := base
+			if i > 1 {
+				suffix := fmt.Sprintf("_%d", i)
+				candidate = truncate(base, oidcUsernameMaxLength-len(suffix)) + suffix
+			}
+			taken, err := s.usernameTaken(ctx, candidate, tx)
+			if err != nil {
+				return "", err
+			}
+			if !taken {
+				return candidate, nil
+			}
+		}
+	}
+	buf := make([]byte, 6)
+	if _, err := rand.Read(buf); err != nil {
+		return "", appErrors.ErrTokenGenerationFailed.WithError(err)
+	}
+	return "user_" + hex.EncodeToString(buf), nil
+}
+
+func (s *OIDCService) usernameTaken(ctx context.Context, username string, tx *gorm.DB) (bool, error) {
+	_, err := s.userDao.GetByUsername(ctx, username, tx)
+	if err == nil {
+		return true, nil
+	}
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return false, nil
+	}
+	return false, appErrors.ErrDatabaseOperation.WithError(err)
+}
+
+func (s *OIDCService) getUser(ctx context.Context, userID int, tx *gorm.DB) (*models.User, error) {
+	user, err := s.userDao.GetByID(ctx, userID, tx)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, appErrors.ErrUserNotFound
+		}
+		return nil, appErrors.ErrDatabaseOperation.WithError(err)
+	}
+	return user, nil
+}
+
+// sanitizeUsername 只保留字母、数字、下划线、连字符和点，并截断到用户名最大长度
+func sanitizeUsername(value string) string {
+	value = strings.TrimSpace(value)
+	if at := strings.IndexByte(value, '@'); at >= 0 {
+		value = value[:at]
+	}
+	var b strings.Builder
+	for _, r := range value {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '-' || r == '.' {
+			b.WriteRune(r)
+		}
+	}
+	return truncate(b.String(), oidcUsernameMaxLength)
+}
+
+// truncate 按字符截断，与注册接口的长度校验一致
+func truncate(value string, maxLength int) string {
+	runes := []rune(value)
+	if len(runes) <= maxLength {
+		return value
+	}
+	return string(runes[:maxLength])
+}
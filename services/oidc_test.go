@@ -0,0 +1,179 @@
+package service
+
+import (
+	"TeamTickBackend/config"
+	"TeamTickBackend/dal/dao"
+	apperrors "TeamTickBackend/pkg/errors"
+	"TeamTickBackend/pkg/oidc"
+	"TeamTickBackend/pkg/oidc/oidctest"
+	"context"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type oidcTestEnv struct {
+	factory *dao.DAOFactory
+	idp     *oidctest.Server
+	service *OIDCService
+	auth    *AuthService
+	now     *time.Time
+}
+
+func setupOIDCTest(t *testing.T, autoProvision bool) *oidcTestEnv {
+	idp, err := oidctest.NewServer("teamtick")
+	require.NoError(t, err)
+	t.Cleanup(idp.Close)
+
+	cfg := config.OIDCConfig{
+		Enabled:       true,
+		IssuerURL:     idp.Issuer,
+		ClientID:      "teamtick",
+		RedirectURL:   "http://localhost:3000/oidc/callback",
+		Scopes:        []string{"openid", "profile"},
+		UsernameClaim: "preferred_username",
+		AutoProvision: autoProvision,
+		StateExpiry:   10 * time.Minute,
+	}
+	factory := dao.NewMemoryDAOFactory()
+	service := NewOIDCService(factory.UserDAO, factory.UserIdentityDAO, factory.OIDCLoginStateDAO,
+		factory.TransactionManager, oidc.NewProvider(cfg), cfg, discardLogger())
+	now := time.Now()
+	service.now = func() time.Time { return now }
+	return &oidcTestEnv{
+		factory: factory,
+		idp:     idp,
+		service: service,
+		auth:    NewAuthService(factory.UserDAO, factory.TransactionManager, new(mockJwtHandler), nil, nil, discardLogger()),
+		now:     &now,
+	}
+}
+
+// login 以指定用户走完一次授权码流程
+func (env *oidcTestEnv) login(t *testing.T, subject, username string) (*OIDCAuthorization, string) {
+	env.idp.SetUser(oidctest.User{Subject: subject, Claims: map[string]any{"preferred_username": username}})
+	authorization, err := env.service.BeginLogin(context.Background(), DeviceInfo{ID: "browser-1", Name: "Chrome"})
+	require.NoError(t, err)
+	code, state, err := env.idp.Authorize(authorization.URL)
+	require.NoError(t, err)
+	require.Equal(t, authorization.State, state)
+	return authorization, code
+}
+
+func TestOIDC_AuthorizationURLUsesPKCE(t *testing.T) {
+	env := setupOIDCTest(t, true)
+
+	authorization, err := env.service.BeginLogin(context.Background(), DeviceInfo{})
+	require.NoError(t, err)
+	authURL, err := url.Parse(authorization.URL)
+	require.NoError(t, err)
+	query := authURL.Query()
+	assert.Equal(t, "teamtick", query.Get("client_id"))
+	assert.Equal(t, "http://localhost:3000/oidc/callback", query.Get("redirect_uri"))
+	assert.Equal(t, "S256", query.Get("code_challenge_method"))
+	assert.NotEmpty(t, query.Get("code_challenge"))
+	assert.NotEmpty(t, query.Get("nonce"))
+	assert.Equal(t, authorization.State, query.Get("state"))
+}
+
+func TestOIDC_ProvisionAndRepeatLogin(t *testing.T) {
+	env := setupOIDCTest(t, true)
+	ctx := context.Background()
+	_, err := env.auth.AuthRegister(ctx, "alice", "secret1")
+	require.NoError(t, err)
+
+	// 用户名已被占用时追加数字后缀
+	authorization, code := env.login(t, "sub-alice", "alice")
+	user, device, err := env.service.Complete(ctx, code, authorization.State)
+	require.NoError(t, err)
+	assert.Equal(t, "alice_2", user.Username)
+	assert.Empty(t, user.Password, "自动创建的用户不能使用密码登录")
+	assert.Equal(t, DeviceInfo{ID: "browser-1", Name: "Chrome"}, device)
+
+	// 同一 subject 再次登录对应同一用户，即使用户名声明发生变化
+	authorization, code = env.login(t, "sub-alice", "alice.renamed")
+	again, _, err := env.service.Complete(ctx, code, authorization.State)
+	require.NoError(t, err)
+	assert.Equal(t, user.UserID, again.UserID)
+
+	// 声明不可用时生成随机用户名
+	authorization, code = env.login(t, "sub-x", "@@")
+	anonymous, _, err := env.service.Complete(ctx, code, authorization.State)
+	require.NoError(t, err)
+	assert.Regexp(t, `^user_[0-9a-f]{12}$`, anonymous.Username)
+}
+
+func TestOIDC_LinkExistingUser(t *testing.T) {
+	env := setupOIDCTest(t, false)
+	ctx := context.Background()
+	alice, err := env.auth.AuthRegister(ctx, "alice", "secret1")
+	require.NoError(t, err)
+	bob, err := env.auth.AuthRegister(ctx, "bob", "secret2")
+	require.NoError(t, err)
+
+	// 未开启自动创建时，未关联的身份不能登录
+	authorization, code := env.login(t, "sub-alice", "alice")
+	_, _, err = env.service.Complete(ctx, code, authorization.State)
+	assert.ErrorIs(t, err, apperrors.ErrOIDCAccountNotLinked)
+
+	link, err := env.service.BeginLink(ctx, alice.UserID)
+	require.NoError(t, err)
+	code, _, err = env.idp.Authorize(link.URL)
+	require.NoError(t, err)
+	linked, _, err := env.service.Complete(ctx, code, link.State)
+	require.NoError(t, err)
+	assert.Equal(t, alice.UserID, linked.UserID)
+
+	authorization, code = env.login(t, "sub-alice", "alice")
+	user, _, err := env.service.Complete(ctx, code, authorization.State)
+	require.NoError(t, err)
+	assert.Equal(t, alice.UserID, user.UserID)
+
+	// 已关联的身份不能再关联到其他用户
+	link, err = env.service.BeginLink(ctx, bob.UserID)
+	require.NoError(t, err)
+	code, _, err = env.idp.Authorize(link.URL)
+	require.NoError(t, err)
+	_, _, err = env.service.Complete(ctx, code, link.State)
+	assert.ErrorIs(t, err, apperrors.ErrOIDCIdentityLinked)
+}
+
+func TestOIDC_StateIsSingleUseAndExpires(t *testing.T) {
+	env := setupOIDCTest(t, true)
+	ctx := context.Background()
+
+	_, _, err := env.service.Complete(ctx, "code", "unknown-state")
+	assert.ErrorIs(t, err, apperrors.ErrOIDCStateInvalid)
+
+	authorization, code := env.login(t, "sub-alice", "alice")
+	_, _, err = env.service.Complete(ctx, code, authorization.State)
+	require.NoError(t, err)
+	_, _, err = env.service.Complete(ctx, code, authorization.State)
+	assert.ErrorIs(t, err, apperrors.ErrOIDCStateInvalid)
+
+	authorization, code = env.login(t, "sub-alice", "alice")
+	*env.now = env.now.Add(11 * time.Minute)
+	_, _, err = env.service.Complete(ctx, code, authorization.State)
+	assert.ErrorIs(t, err, apperrors.ErrOIDCStateInvalid)
+}
+
+func TestOIDC_CodeBoundToVerifier(t *testing.T) {
+	env := setupOIDCTest(t, true)
+	ctx := context.Background()
+
+	// 授权码来自另一次授权请求，code_verifier 不匹配，令牌端点拒绝
+	_, code := env.login(t, "sub-alice", "alice")
+	other, err := env.service.BeginLogin(ctx, DeviceInfo{})
+	require.NoError(t, err)
+	_, _, err = env.service.Complete(ctx, code, other.State)
+	assert.ErrorIs(t, err, apperrors.ErrOIDCExchangeFailed)
+
+	// 功能未开启
+	disabled := NewOIDCService(env.factory.UserDAO, env.factory.UserIdentityDAO, env.factory.OIDCLoginStateDAO,
+		env.factory.TransactionManager, nil, config.OIDCConfig{}, discardLogger())
+	_, err = disabled.BeginLogin(ctx, DeviceInfo{})
+	assert.ErrorIs(t, err, apperrors.ErrOIDCDisabled)
+}
@@ -0,0 +1,171 @@
+package service
+
+import (
+	"TeamTickBackend/dal/dao"
+	"TeamTickBackend/dal/models"
+	"TeamTickBackend/pkg"
+	appErrors "TeamTickBackend/pkg/errors"
+	"TeamTickBackend/pkg/notify"
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// PasswordService 修改密码与自助重置密码，密码变更后吊销用户的全部会话
+type PasswordService struct {
+	userDao            dao.UserDAO
+	resetTokenDao      dao.PasswordResetTokenDAO
+	transactionManager dao.TransactionManager
+	tokenService       *TokenService
+	sender             notify.Sender
+	resetTokenExpiry   time.Duration
+	log                *slog.Logger
+}
+
+func NewPasswordService(
+	userDao dao.UserDAO,
+	resetTokenDao dao.PasswordResetTokenDAO,
+	transactionManager dao.TransactionManager,
+	tokenService *TokenService,
+	sender notify.Sender,
+	resetTokenExpiry time.Duration,
+	log *slog.Logger,
+) *PasswordService {
+	return &PasswordService{
+		userDao:            userDao,
+		resetTokenDao:      resetTokenDao,
+		transactionManager: transactionManager,
+		tokenService:       tokenService,
+		sender:             sender,
+		resetTokenExpiry:   resetTokenExpiry,
+		log:                log,
+	}
+}
+
+// 已登录用户修改密码，需校验原密码
+func (s *PasswordService) ChangePassword(ctx context.Context, userID int, oldPassword, newPassword string) error {
+	err := s.transactionManager.WithTransaction(ctx, func(tx *gorm.DB) error {
+		user, err := s.userDao.GetByID(ctx, userID, tx)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return appErrors.ErrUserNotFound
+			}
+			return appErrors.ErrDatabaseOperation.WithError(err)
+		}
+		if !pkg.CheckPassword(user.Password, oldPassword) {
+			return appErrors.ErrOldPasswordIncorrect
+		}
+		return s.updatePassword(ctx, user.UserID, newPassword, tx)
+	})
+	if err != nil {
+		return err
+	}
+	s.log.InfoContext(ctx, "password changed", slog.Int("user_id", userID))
+	return nil
+}
+
+// 申请重置密码：生成一次性令牌并通过sender投递，之前未使用的令牌作废
+// 用户不存在时同样返回成功，避免通过该接口探测用户名
+func (s *PasswordService) RequestReset(ctx context.Context, username string) error {
+	if s.sender == nil {
+		return appErrors.ErrPasswordResetDisabled
+	}
+	token, err := pkg.GenerateOpaqueToken()
+	if err != nil {
+		return appErrors.ErrTokenGenerationFailed.WithError(err)
+	}
+	var message *notify.PasswordResetMessage
+	err = s.transactionManager.WithTransaction(ctx, func(tx *gorm.DB) error {
+		user, err := s.userDao.GetByUsername(ctx, username, tx)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil
+			}
+			return appErrors.ErrDatabaseOperation.WithError(err)
+		}
+		now := time.Now()
+		if err := s.resetTokenDao.InvalidateByUserID(ctx, user.UserID, now, tx); err != nil {
+			return appErrors.ErrDatabaseOperation.WithError(err)
+		}
+		record := &models.PasswordResetToken{
+			UserID:    user.UserID,
+			TokenHash: pkg.HashOpaqueToken(token),
+			ExpiresAt: now.Add(s.resetTokenExpiry),
+		}
+		if err := s.resetTokenDao.Create(ctx, record, tx); err != nil {
+			return appErrors.ErrDatabaseOperation.WithError(err)
+		}
+		message = &notify.PasswordResetMessage{
+			UserID:    user.UserID,
+			Username:  user.Username,
+			Token:     token,
+			ExpiresAt: record.ExpiresAt,
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if message == nil {
+		s.log.InfoContext(ctx, "password reset requested for unknown user", slog.String("username", username))
+		return nil
+	}
+	// 令牌提交后再投递，投递失败时用户可重新申请
+	if err := s.sender.SendPasswordReset(ctx, *message); err != nil {
+		return appErrors.ErrNotificationFailed.WithError(err)
+	}
+	return nil
+}
+
+// 使用重置令牌设置新密码，令牌只能使用一次
+func (s *PasswordService) ConfirmReset(ctx context.Context, token, newPassword string) error {
+	if s.sender == nil {
+		return appErrors.ErrPasswordResetDisabled
+	}
+	var userID int
+	err := s.transactionManager.WithTransaction(ctx, func(tx *gorm.DB) error {
+		record, err := s.resetTokenDao.GetByTokenHash(ctx, pkg.HashOpaqueToken(token), tx)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return appErrors.ErrResetTokenInvalid
+			}
+			return appErrors.ErrDatabaseOperation.WithError(err)
+		}
+		now := time.Now()
+		if record.UsedAt != nil || !now.Before(record.ExpiresAt) {
+			return appErrors.ErrResetTokenInvalid
+		}
+		marked, err := s.resetTokenDao.MarkUsed(ctx, record.ID, now, tx)
+		if err != nil {
+			return appErrors.ErrDatabaseOperation.WithError(err)
+		}
+		if !marked {
+			return appErrors.ErrResetTokenInvalid
+		}
+		userID = record.UserID
+		return s.updatePassword(ctx, record.UserID, newPassword, tx)
+	})
+	if err != nil {
+		return err
+	}
+	s.log.InfoContext(ctx, "password reset", slog.Int("user_id", userID))
+	return nil
+}
+
+// updatePassword 更新密码、作废未使用的重置令牌并吊销全部会话
+func (s *PasswordService) updatePassword(ctx context.Context, userID int, newPassword string, tx *gorm.DB) error {
+	hashedPassword, err := pkg.GenerateFromPassword(newPassword)
+	if err != nil {
+		return appErrors.ErrPasswordEncryption.WithError(err)
+	}
+	if err := s.userDao.UpdatePassword(ctx, userID, hashedPassword, tx); err != nil {
+		return appErrors.ErrPasswordUpdateFailed.WithError(err)
+	}
+	if err := s.resetTokenDao.InvalidateByUserID(ctx, userID, time.Now(), tx); err != nil {
+		return appErrors.ErrDatabaseOperation.WithError(err)
+	}
+	return s.tokenService.revokeAllSessions(ctx, userID, tx)
+}
@@ -0,0 +1,134 @@
+package service
+
+import (
+	"TeamTickBackend/dal/dao"
+	"TeamTickBackend/dal/models"
+	"TeamTickBackend/pkg"
+	apperrors "TeamTickBackend/pkg/errors"
+	"TeamTickBackend/pkg/notify"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// 记录投递内容的sender
+type recordingSender struct {
+	messages []notify.PasswordResetMessage
+}
+
+func (s *recordingSender) SendPasswordReset(ctx context.Context, message notify.PasswordResetMessage) error {
+	s.messages = append(s.messages, message)
+	return nil
+}
+
+// 密码测试复用令牌测试的内存环境，另建一个密码为 old-secret 的用户bob
+func setupPasswordServiceTest(t *testing.T) (*PasswordService, *TokenService, pkg.JwtHandler, *dao.DAOFactory, *recordingSender) {
+	tokenService, jwtHandler, factory := setupTokenServiceTest(t)
+	hashed, err := pkg.GenerateFromPassword("old-secret")
+	require.NoError(t, err)
+	require.NoError(t, factory.UserDAO.Create(context.Background(), &models.User{Username: "bob", Password: hashed}))
+	sender := &recordingSender{}
+	passwordService := NewPasswordService(factory.UserDAO, factory.PasswordResetTokenDAO, factory.TransactionManager, tokenService, sender, time.Minute, discardLogger())
+	return passwordService, tokenService, jwtHandler, factory, sender
+}
+
+func bobSession(t *testing.T, tokenService *TokenService) *TokenPair {
+	pair, err := tokenService.StartSession(context.Background(), &models.User{UserID: 2, Username: "bob"}, DeviceInfo{})
+	require.NoError(t, err)
+	return pair
+}
+
+func TestChangePassword_RevokesSessions(t *testing.T) {
+	passwordService, tokenService, jwtHandler, factory, _ := setupPasswordServiceTest(t)
+	ctx := context.Background()
+	session := bobSession(t, tokenService)
+
+	time.Sleep(2 * time.Millisecond)
+	require.NoError(t, passwordService.ChangePassword(ctx, 2, "old-secret", "new-secret"))
+
+	user, err := factory.UserDAO.GetByID(ctx, 2)
+	require.NoError(t, err)
+	assert.True(t, pkg.CheckPassword(user.Password, "new-secret"))
+	_, err = jwtHandler.ParseJWTToken(session.AccessToken)
+	assert.ErrorIs(t, err, apperrors.ErrTokenRevoked)
+	_, err = tokenService.Refresh(ctx, session.RefreshToken, "")
+	assert.ErrorIs(t, err, apperrors.ErrRefreshTokenInvalid)
+}
+
+func TestChangePassword_WrongOldPassword(t *testing.T) {
+	passwordService, tokenService, jwtHandler, factory, _ := setupPasswordServiceTest(t)
+	ctx := context.Background()
+	session := bobSession(t, tokenService)
+
+	err := passwordService.ChangePassword(ctx, 2, "wrong", "new-secret")
+	assert.ErrorIs(t, err, apperrors.ErrOldPasswordIncorrect)
+
+	// 密码与会话均不受影响
+	user, err := factory.UserDAO.GetByID(ctx, 2)
+	require.NoError(t, err)
+	assert.True(t, pkg.CheckPassword(user.Password, "old-secret"))
+	_, err = jwtHandler.ParseJWTToken(session.AccessToken)
+	assert.NoError(t, err)
+}
+
+func TestPasswordReset_SingleUse(t *testing.T) {
+	passwordService, tokenService, jwtHandler, factory, sender := setupPasswordServiceTest(t)
+	ctx := context.Background()
+	session := bobSession(t, tokenService)
+
+	require.NoError(t, passwordService.RequestReset(ctx, "bob"))
+	require.Len(t, sender.messages, 1)
+	message := sender.messages[0]
+	assert.Equal(t, 2, message.UserID)
+
+	// 数据库只保存摘要
+	record, err := factory.PasswordResetTokenDAO.GetByTokenHash(ctx, pkg.HashOpaqueToken(message.Token))
+	require.NoError(t, err)
+	assert.NotEqual(t, message.Token, record.TokenHash)
+
+	time.Sleep(2 * time.Millisecond)
+	require.NoError(t, passwordService.ConfirmReset(ctx, message.Token, "reset-secret"))
+	user, err := factory.UserDAO.GetByID(ctx, 2)
+	require.NoError(t, err)
+	assert.True(t, pkg.CheckPassword(user.Password, "reset-secret"))
+	_, err = jwtHandler.ParseJWTToken(session.AccessToken)
+	assert.ErrorIs(t, err, apperrors.ErrTokenRevoked)
+
+	// 令牌只能使用一次
+	err = passwordService.ConfirmReset(ctx, message.Token, "another-secret")
+	assert.ErrorIs(t, err, apperrors.ErrResetTokenInvalid)
+}
+
+func TestPasswordReset_NewRequestInvalidatesPrevious(t *testing.T) {
+	passwordService, _, _, _, sender := setupPasswordServiceTest(t)
+	ctx := context.Background()
+
+	require.NoError(t, passwordService.RequestReset(ctx, "bob"))
+	require.NoError(t, passwordService.RequestReset(ctx, "bob"))
+	require.Len(t, sender.messages, 2)
+
+	err := passwordService.ConfirmReset(ctx, sender.messages[0].Token, "reset-secret")
+	assert.ErrorIs(t, err, apperrors.ErrResetTokenInvalid)
+	assert.NoError(t, passwordService.ConfirmReset(ctx, sender.messages[1].Token, "reset-secret"))
+}
+
+func TestPasswordReset_ExpiredAndUnknown(t *testing.T) {
+	passwordService, _, _, factory, sender := setupPasswordServiceTest(t)
+	ctx := context.Background()
+
+	// 用户不存在时不投递也不报错
+	require.NoError(t, passwordService.RequestReset(ctx, "nobody"))
+	assert.Empty(t, sender.messages)
+
+	err := factory.PasswordResetTokenDAO.Create(ctx, &models.PasswordResetToken{
+		UserID:    2,
+		TokenHash: pkg.HashOpaqueToken("expired-token"),
+		ExpiresAt: time.Now().Add(-time.Minute),
+	})
+	require.NoError(t, err)
+	assert.ErrorIs(t, passwordService.ConfirmReset(ctx, "expired-token", "reset-secret"), apperrors.ErrResetTokenInvalid)
+	assert.ErrorIs(t, passwordService.ConfirmReset(ctx, "unknown-token", "reset-secret"), apperrors.ErrResetTokenInvalid)
+}
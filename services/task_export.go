@@ -0,0 +1,54 @@
+package service
+
+import (
+	"TeamTickBackend/dal/models"
+	"context"
+	"encoding/csv"
+	"io"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// taskRecordsCSVHeader 导出签到记录的表头
+var taskRecordsCSVHeader = []string{"记录ID", "用户ID", "用户名", "签到时间", "纬度", "经度"}
+
+// utf8BOM 写在CSV开头，表格软件据此按 UTF-8 识别中文
+const utf8BOM = "\uFEFF"
+
+// ExportTaskRecords 以CSV格式将签到任务的全部签到记录写入 w，按签到时间排序，首行为表头
+// 调用方负责校验 records.export 权限
+func (s *TaskService) ExportTaskRecords(ctx context.Context, taskID int, w io.Writer) error {
+	records, err := s.GetTaskRecordsByTaskID(ctx, taskID)
+	if err != nil {
+		return err
+	}
+	slices.SortStableFunc(records, func(a, b *models.TaskRecord) int {
+		if c := a.SignedTime.Compare(b.SignedTime); c != 0 {
+			return c
+		}
+		return a.RecordID - b.RecordID
+	})
+	if _, err := io.WriteString(w, utf8BOM); err != nil {
+		return err
+	}
+	writer := csv.NewWriter(w)
+	if err := writer.Write(taskRecordsCSVHeader); err != nil {
+		return err
+	}
+	for _, record := range records {
+		row := []string{
+			strconv.Itoa(record.RecordID),
+			strconv.Itoa(record.UserID),
+			record.Username,
+			record.SignedTime.Format(time.RFC3339),
+			strconv.FormatFloat(record.Latitude, 'f', -1, 64),
+			strconv.FormatFloat(record.Longitude, 'f', -1, 64),
+		}
+		if err := writer.Write(row); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
@@ -0,0 +1,38 @@
+package service
+
+import (
+	"TeamTickBackend/dal/dao"
+	"TeamTickBackend/dal/models"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportTaskRecords_CSVOrderedBySignedTime(t *testing.T) {
+	factory := dao.NewMemoryDAOFactory()
+	taskService := newMemoryTaskService(t, factory)
+	ctx := context.Background()
+	signed := time.Date(2026, 3, 1, 8, 0, 0, 0, time.UTC)
+	// 后签到的记录先写入
+	require.NoError(t, factory.TaskRecordDAO.Create(ctx, &models.TaskRecord{TaskID: 7, UserID: 2, Username: "张三, 班长", SignedTime: signed.Add(time.Minute), Latitude: 30.5, Longitude: 120.25}))
+	require.NoError(t, factory.TaskRecordDAO.Create(ctx, &models.TaskRecord{TaskID: 7, UserID: 1, Username: "alice", SignedTime: signed}))
+	require.NoError(t, factory.TaskRecordDAO.Create(ctx, &models.TaskRecord{TaskID: 8, UserID: 1, Username: "alice", SignedTime: signed}))
+
+	var buf bytes.Buffer
+	require.NoError(t, taskService.ExportTaskRecords(ctx, 7, &buf))
+	content, ok := strings.CutPrefix(buf.String(), utf8BOM)
+	require.True(t, ok)
+	rows, err := csv.NewReader(strings.NewReader(content)).ReadAll()
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{
+		taskRecordsCSVHeader,
+		{"2", "1", "alice", "2026-03-01T08:00:00Z", "0", "0"},
+		{"1", "2", "张三, 班长", "2026-03-01T08:01:00Z", "30.5", "120.25"},
+	}, rows)
+}
@@ -5,6 +5,7 @@ import (
 	"TeamTickBackend/dal/models"
 	"context"
 	"errors"
+	"log/slog"
 	"math"
 	"time"
 
@@ -18,6 +19,9 @@ type TaskService struct {
 	taskRecordDao      dao.TaskRecordDAO
 	groupDao           dao.GroupDAO
 	transactionManager dao.TransactionManager
+	denormalizationDao dao.DenormalizationDAO
+	userDao            dao.UserDAO
+	log                *slog.Logger
 }
 
 func NewTaskService(
@@ -25,12 +29,18 @@ func NewTaskService(
 	taskRecordDao dao.TaskRecordDAO,
 	transactionManager dao.TransactionManager,
 	groupDao           dao.GroupDAO,
+	denormalizationDao dao.DenormalizationDAO,
+	userDao dao.UserDAO,
+	log *slog.Logger,
 ) *TaskService {
 	return &TaskService{
 		taskDao:            taskDao,
 		taskRecordDao:      taskRecordDao,
 		transactionManager: transactionManager,
 		groupDao:           groupDao,
+		denormalizationDao: denormalizationDao,
+		userDao:            userDao,
+		log:                log,
 	}
 }
 
@@ -271,10 +281,16 @@ func (s *TaskService) CheckInTask(
 			}
 			return appErrors.ErrDatabaseOperation.WithError(err)
 		}
+		username, err := currentUsername(ctx, s.userDao, userID, tx)
+		if err != nil {
+			return err
+		}
 		createdTaskRecord := models.TaskRecord{
 			TaskID:     taskID,
+			TaskName:   task.TaskName,
 			GroupID:    task.GroupID,
 			UserID:     userID,
+			Username:   username,
 			Latitude:   latitude,
 			Longitude:  longitude,
 			GroupName:  group.GroupName,
@@ -290,8 +306,12 @@ func (s *TaskService) CheckInTask(
 		return nil
 	})
 	if err != nil {
+		s.log.WarnContext(ctx, "check-in failed",
+			slog.Int("task_id", taskID), slog.Int("user_id", userID), slog.String("error", err.Error()))
 		return nil, err
 	}
+	s.log.InfoContext(ctx, "check-in recorded",
+		slog.Int("task_id", taskID), slog.Int("user_id", userID), slog.Int("record_id", taskRecord.RecordID))
 	return &taskRecord, nil
 
 }
@@ -352,7 +372,7 @@ func (s *TaskService) UpdateTask(
 ) (*models.Task, error) {
 	var task models.Task
 	err := s.transactionManager.WithTransaction(ctx, func(tx *gorm.DB) error {
-		_, err := s.taskDao.GetByTaskID(ctx, taskID, tx)
+		oldTask, err := s.taskDao.GetByTaskID(ctx, taskID, tx)
 		if err != nil {
 			if errors.Is(err, gorm.ErrRecordNotFound) {
 				return appErrors.ErrTaskNotFound
@@ -387,6 +407,12 @@ func (s *TaskService) UpdateTask(
 			}
 			return appErrors.ErrDatabaseOperation.WithError(err)
 		}
+		//任务改名后同步签到记录与审核申请中冗余的任务名称
+		if nowTask.TaskName != oldTask.TaskName {
+			if _, err := s.denormalizationDao.Repair(ctx, models.DenormSourceTask, taskID, tx); err != nil {
+				return appErrors.ErrTaskUpdateFailed.WithError(err)
+			}
+		}
 		task = *nowTask
 		return nil
 	})
@@ -409,3 +435,40 @@ func (s *TaskService) DeleteTask(ctx context.Context, taskID int) error {
 	}
 	return nil
 }
+
+// 提前结束签到任务：结束时间设置为当前时间，尚未开始的任务开始时间同时提前
+func (s *TaskService) CloseTask(ctx context.Context, taskID int) (*models.Task, error) {
+	var task *models.Task
+	err := s.transactionManager.WithTransaction(ctx, func(tx *gorm.DB) error {
+		var err error
+		task, err = s.closeTask(ctx, taskID, tx)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+// closeTask 在调用方的事务中提前结束签到任务
+func (s *TaskService) closeTask(ctx context.Context, taskID int, tx *gorm.DB) (*models.Task, error) {
+	existTask, err := s.taskDao.GetByTaskID(ctx, taskID, tx)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, appErrors.ErrTaskNotFound
+		}
+		return nil, appErrors.ErrDatabaseOperation.WithError(err)
+	}
+	now := time.Now()
+	if !existTask.EndTime.After(now) {
+		return nil, appErrors.ErrTaskHasEnded
+	}
+	existTask.EndTime = now
+	if existTask.StartTime.After(now) {
+		existTask.StartTime = now
+	}
+	if err := s.taskDao.UpdateTask(ctx, taskID, existTask, tx); err != nil {
+		return nil, appErrors.ErrTaskUpdateFailed.WithError(err)
+	}
+	return existTask, nil
+}
@@ -170,6 +170,9 @@ func setupTaskServiceTest() (*TaskService, *mockTaskDAO, *mockTaskRecordDAO, *mo
 		mockTaskRecordDao,
 		mockTxManager,
 		mockGroupDao,
+		new(mockDenormalizationDAO),
+		new(mockUserDAO),
+		discardLogger(),
 	)
 
 	return taskService, mockTaskDao, mockTaskRecordDao, mockTxManager
@@ -490,6 +493,9 @@ func TestUpdateTask_Success(t *testing.T) {
 	mockTaskDao.On("GetByTaskID", ctx, taskID, mock.AnythingOfType("[]*gorm.DB")).Return(originalTask, nil).Once()
 	mockTaskDao.On("UpdateTask", ctx, taskID, mock.AnythingOfType("*models.Task"), mock.AnythingOfType("[]*gorm.DB")).Return(nil)
 	mockTaskDao.On("GetByTaskID", ctx, taskID, mock.AnythingOfType("[]*gorm.DB")).Return(updatedTask, nil).Once()
+	// 任务改名，同步冗余的任务名称
+	mockDenormalizationDao := taskService.denormalizationDao.(*mockDenormalizationDAO)
+	mockDenormalizationDao.On("Repair", ctx, models.DenormSourceTask, taskID, mock.AnythingOfType("[]*gorm.DB")).Return([]models.ColumnDrift{}, nil)
 
 	// 调用函数
 	result, err := taskService.UpdateTask(ctx, taskID, taskName, description, startTime, endTime,
@@ -503,6 +509,7 @@ func TestUpdateTask_Success(t *testing.T) {
 	// 验证mock调用
 	mockTxManager.AssertExpectations(t)
 	mockTaskDao.AssertExpectations(t)
+	mockDenormalizationDao.AssertExpectations(t)
 }
 
 func TestUpdateTask_NotFound(t *testing.T) {
@@ -540,3 +547,57 @@ func TestUpdateTask_NotFound(t *testing.T) {
 	mockTxManager.AssertExpectations(t)
 	mockTaskDao.AssertExpectations(t)
 }
+
+// --- CloseTask 测试 ---
+
+func TestCloseTask_Success(t *testing.T) {
+	taskService, mockTaskDao, _, mockTxManager := setupTaskServiceTest()
+	ctx := context.Background()
+	taskID := 1
+	task := &models.Task{
+		TaskID:    taskID,
+		TaskName:  "未开始的任务",
+		StartTime: time.Now().Add(time.Hour),
+		EndTime:   time.Now().Add(2 * time.Hour),
+	}
+
+	// Mock期望
+	mockTxManager.On("WithTransaction", ctx, mock.AnythingOfType("func(*gorm.DB) error")).Return(nil)
+	mockTaskDao.On("GetByTaskID", ctx, taskID, mock.AnythingOfType("[]*gorm.DB")).Return(task, nil)
+	mockTaskDao.On("UpdateTask", ctx, taskID, mock.AnythingOfType("*models.Task"), mock.AnythingOfType("[]*gorm.DB")).Return(nil)
+
+	// 调用函数
+	result, err := taskService.CloseTask(ctx, taskID)
+
+	// 断言：结束时间为当前时间，开始时间不晚于结束时间
+	assert.NoError(t, err)
+	assert.WithinDuration(t, time.Now(), result.EndTime, time.Second)
+	assert.False(t, result.StartTime.After(result.EndTime))
+
+	// 验证mock调用
+	mockTxManager.AssertExpectations(t)
+	mockTaskDao.AssertExpectations(t)
+}
+
+func TestCloseTask_AlreadyEnded(t *testing.T) {
+	taskService, mockTaskDao, _, mockTxManager := setupTaskServiceTest()
+	ctx := context.Background()
+	taskID := 1
+	task := &models.Task{
+		TaskID:    taskID,
+		StartTime: time.Now().Add(-2 * time.Hour),
+		EndTime:   time.Now().Add(-time.Hour),
+	}
+
+	// Mock期望
+	mockTxManager.On("WithTransaction", ctx, mock.AnythingOfType("func(*gorm.DB) error")).Return(nil)
+	mockTaskDao.On("GetByTaskID", ctx, taskID, mock.AnythingOfType("[]*gorm.DB")).Return(task, nil)
+
+	// 调用函数
+	result, err := taskService.CloseTask(ctx, taskID)
+
+	// 断言
+	assert.ErrorIs(t, err, appErrors.ErrTaskHasEnded)
+	assert.Nil(t, result)
+	mockTaskDao.AssertNotCalled(t, "UpdateTask", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,378 @@
+package service
+
+import (
+	"TeamTickBackend/dal/dao"
+	"TeamTickBackend/dal/models"
+	"TeamTickBackend/pkg"
+	appErrors "TeamTickBackend/pkg/errors"
+	"TeamTickBackend/pkg/revocation"
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// DeviceInfo 登录时客户端上报的设备信息，刷新令牌与设备ID绑定
+// UserAgent 与 IP 由服务端从请求中获取，记录在登录会话中
+type DeviceInfo struct {
+	ID        string
+	Name      string
+	UserAgent string
+	IP        string
+}
+
+// 同一会话距上次记录活跃超过该间隔才再次查询并更新会话，避免每次请求都访问数据库
+const sessionTouchInterval = time.Minute
+
+// 进程内记录的会话活跃时间条目数上限，超过后清空重新记录
+const sessionTouchCacheSize = 10000
+
+// TokenPair 登录或刷新成功后返回的令牌
+type TokenPair struct {
+	AccessToken  string
+	RefreshToken string
+}
+
+type TokenService struct {
+	refreshTokenDao    dao.RefreshTokenDAO
+	sessionDao         dao.UserSessionDAO
+	userDao            dao.UserDAO
+	transactionManager dao.TransactionManager
+	jwtHandler         pkg.JwtHandler
+	revocations        *revocation.Store
+	refreshTokenExpiry time.Duration
+	log                *slog.Logger
+
+	// touchMu 保护 touched：会话ID -> 本实例最近一次确认会话有效的时间与IP
+	touchMu sync.Mutex
+	touched map[string]sessionTouch
+}
+
+type sessionTouch struct {
+	at time.Time
+	ip string
+}
+
+func NewTokenService(
+	refreshTokenDao dao.RefreshTokenDAO,
+	sessionDao dao.UserSessionDAO,
+	userDao dao.UserDAO,
+	transactionManager dao.TransactionManager,
+	jwtHandler pkg.JwtHandler,
+	revocations *revocation.Store,
+	refreshTokenExpiry time.Duration,
+	log *slog.Logger,
+) *TokenService {
+	return &TokenService{
+		refreshTokenDao:    refreshTokenDao,
+		sessionDao:         sessionDao,
+		userDao:            userDao,
+		transactionManager: transactionManager,
+		jwtHandler:         jwtHandler,
+		revocations:        revocations,
+		refreshTokenExpiry: refreshTokenExpiry,
+		touched:            make(map[string]sessionTouch),
+		log:                log,
+	}
+}
+
+// 登录成功后开启会话：生成新的令牌家族，家族ID作为会话ID写入访问令牌（sid）
+func (s *TokenService) StartSession(ctx context.Context, user *models.User, device DeviceInfo) (*TokenPair, error) {
+	sessionID, err := pkg.GenerateOpaqueToken()
+	if err != nil {
+		return nil, appErrors.ErrTokenGenerationFailed.WithError(err)
+	}
+	var pair TokenPair
+	err = s.transactionManager.WithTransaction(ctx, func(tx *gorm.DB) error {
+		refreshToken, err := s.createRefreshToken(ctx, user.UserID, sessionID, device, tx)
+		if err != nil {
+			return err
+		}
+		if err := s.createSession(ctx, user.UserID, sessionID, device, tx); err != nil {
+			return err
+		}
+		accessToken, err := s.jwtHandler.GenerateJWTToken(user.Username, user.UserID, sessionID)
+		if err != nil {
+			return appErrors.ErrTokenGenerationFailed.WithError(err)
+		}
+		pair = TokenPair{AccessToken: accessToken, RefreshToken: refreshToken}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &pair, nil
+}
+
+// 登出：吊销当前访问令牌以及所属会话，会话内的刷新令牌与其它访问令牌一并失效
+func (s *TokenService) Logout(ctx context.Context, payload pkg.JwtPayload) error {
+	err := s.transactionManager.WithTransaction(ctx, func(tx *gorm.DB) error {
+		if err := s.revocations.RevokeToken(ctx, payload, tx); err != nil {
+			return appErrors.ErrDatabaseOperation.WithError(err)
+		}
+		if payload.SessionID == "" {
+			return nil
+		}
+		return s.revokeSession(ctx, payload.UserID, payload.SessionID, tx)
+	})
+	if err != nil {
+		return err
+	}
+	s.log.InfoContext(ctx, "user logged out", slog.Int("user_id", payload.UserID))
+	return nil
+}
+
+// revokeSession 在调用方的事务中吊销会话：会话记录、刷新令牌家族以及会话下签发的访问令牌
+func (s *TokenService) revokeSession(ctx context.Context, userID int, sessionID string, tx *gorm.DB) error {
+	now := time.Now()
+	if _, err := s.sessionDao.RevokeBySessionID(ctx, sessionID, now, tx); err != nil {
+		return appErrors.ErrDatabaseOperation.WithError(err)
+	}
+	if err := s.refreshTokenDao.RevokeFamily(ctx, sessionID, now, tx); err != nil {
+		return appErrors.ErrDatabaseOperation.WithError(err)
+	}
+	if err := s.revocations.RevokeSession(ctx, userID, sessionID, tx); err != nil {
+		return appErrors.ErrDatabaseOperation.WithError(err)
+	}
+	return nil
+}
+
+// 吊销用户的全部会话：所有刷新令牌失效，此前签发的访问令牌被拒绝
+func (s *TokenService) RevokeAllSessions(ctx context.Context, userID int) error {
+	err := s.transactionManager.WithTransaction(ctx, func(tx *gorm.DB) error {
+		if _, err := s.userDao.GetByID(ctx, userID, tx); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return appErrors.ErrUserNotFound
+			}
+			return appErrors.ErrDatabaseOperation.WithError(err)
+		}
+		return s.revokeAllSessions(ctx, userID, tx)
+	})
+	if err != nil {
+		return err
+	}
+	s.log.WarnContext(ctx, "all sessions revoked", slog.Int("user_id", userID))
+	return nil
+}
+
+// revokeAllSessions 在调用方的事务中吊销用户的全部会话，供修改密码等操作复用
+func (s *TokenService) revokeAllSessions(ctx context.Context, userID int, tx *gorm.DB) error {
+	now := time.Now()
+	if err := s.sessionDao.RevokeByUserID(ctx, userID, now, tx); err != nil {
+		return appErrors.ErrDatabaseOperation.WithError(err)
+	}
+	if err := s.refreshTokenDao.RevokeByUserID(ctx, userID, now, tx); err != nil {
+		return appErrors.ErrDatabaseOperation.WithError(err)
+	}
+	if err := s.revocations.RevokeUser(ctx, userID, tx); err != nil {
+		return appErrors.ErrDatabaseOperation.WithError(err)
+	}
+	return nil
+}
+
+// 使用刷新令牌换取新的访问令牌与刷新令牌，旧令牌立即失效
+// 已使用过的令牌再次出现，或设备ID不匹配时，视为令牌泄露，吊销整个令牌家族
+func (s *TokenService) Refresh(ctx context.Context, refreshToken, deviceID string) (*TokenPair, error) {
+	var pair TokenPair
+	var compromised error
+	err := s.transactionManager.WithTransaction(ctx, func(tx *gorm.DB) error {
+		token, err := s.refreshTokenDao.GetByTokenHash(ctx, pkg.HashOpaqueToken(refreshToken), tx)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return appErrors.ErrRefreshTokenInvalid
+			}
+			return appErrors.ErrDatabaseOperation.WithError(err)
+		}
+		if token.RevokedAt != nil {
+			return appErrors.ErrRefreshTokenInvalid
+		}
+		now := time.Now()
+		if token.UsedAt != nil {
+			compromised = appErrors.ErrRefreshTokenReused
+		} else if token.DeviceID != deviceID {
+			compromised = appErrors.ErrRefreshTokenInvalid
+		}
+		if compromised == nil && !now.Before(token.ExpiresAt) {
+			return appErrors.ErrRefreshTokenExpired
+		}
+		if compromised == nil {
+			// 条件更新失败说明并发请求已抢先使用了该令牌
+			marked, err := s.refreshTokenDao.MarkUsed(ctx, token.ID, now, tx)
+			if err != nil {
+				return appErrors.ErrDatabaseOperation.WithError(err)
+			}
+			if !marked {
+				compromised = appErrors.ErrRefreshTokenReused
+			}
+		}
+		if compromised != nil {
+			// 吊销需要提交，因此这里不返回错误；会话下已签发的访问令牌一并吊销
+			if err := s.revokeSession(ctx, token.UserID, token.FamilyID, tx); err != nil {
+				return err
+			}
+			s.log.WarnContext(ctx, "refresh token family revoked",
+				slog.Int("user_id", token.UserID), slog.String("reason", compromised.Error()))
+			return nil
+		}
+
+		user, err := s.userDao.GetByID(ctx, token.UserID, tx)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return appErrors.ErrRefreshTokenInvalid
+			}
+			return appErrors.ErrDatabaseOperation.WithError(err)
+		}
+		// 停用账号时已吊销全部刷新令牌，此处防止停用与刷新并发
+		if user.DisabledAt != nil {
+			return appErrors.ErrRefreshTokenInvalid
+		}
+		device := DeviceInfo{ID: token.DeviceID, Name: token.DeviceName}
+		newToken, err := s.createRefreshToken(ctx, user.UserID, token.FamilyID, device, tx)
+		if err != nil {
+			return err
+		}
+		if err := s.extendSession(ctx, user.UserID, token.FamilyID, device, tx); err != nil {
+			return err
+		}
+		accessToken, err := s.jwtHandler.GenerateJWTToken(user.Username, user.UserID, token.FamilyID)
+		if err != nil {
+			return appErrors.ErrTokenGenerationFailed.WithError(err)
+		}
+		pair = TokenPair{AccessToken: accessToken, RefreshToken: newToken}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if compromised != nil {
+		return nil, compromised
+	}
+	return &pair, nil
+}
+
+func (s *TokenService) createRefreshToken(ctx context.Context, userID int, familyID string, device DeviceInfo, tx *gorm.DB) (string, error) {
+	token, err := pkg.GenerateOpaqueToken()
+	if err != nil {
+		return "", appErrors.ErrTokenGenerationFailed.WithError(err)
+	}
+	record := &models.RefreshToken{
+		UserID:     userID,
+		FamilyID:   familyID,
+		TokenHash:  pkg.HashOpaqueToken(token),
+		DeviceID:   device.ID,
+		DeviceName: device.Name,
+		ExpiresAt:  time.Now().Add(s.refreshTokenExpiry),
+	}
+	if err := s.refreshTokenDao.Create(ctx, record, tx); err != nil {
+		return "", appErrors.ErrDatabaseOperation.WithError(err)
+	}
+	return token, nil
+}
+
+func (s *TokenService) createSession(ctx context.Context, userID int, sessionID string, device DeviceInfo, tx *gorm.DB) error {
+	now := time.Now()
+	session := &models.UserSession{
+		UserID:     userID,
+		SessionID:  sessionID,
+		DeviceID:   device.ID,
+		DeviceName: device.Name,
+		UserAgent:  truncate(device.UserAgent, 255),
+		IP:         device.IP,
+		LastSeenAt: now,
+		LastSeenIP: device.IP,
+		ExpiresAt:  now.Add(s.refreshTokenExpiry),
+		CreatedAt:  now,
+	}
+	if err := s.sessionDao.Create(ctx, session, tx); err != nil {
+		return appErrors.ErrDatabaseOperation.WithError(err)
+	}
+	return nil
+}
+
+// extendSession 刷新后顺延会话的过期时间；升级前登录的会话没有记录，此时补建
+func (s *TokenService) extendSession(ctx context.Context, userID int, sessionID string, device DeviceInfo, tx *gorm.DB) error {
+	if _, err := s.sessionDao.GetBySessionID(ctx, sessionID, tx); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return s.createSession(ctx, userID, sessionID, device, tx)
+		}
+		return appErrors.ErrDatabaseOperation.WithError(err)
+	}
+	if err := s.sessionDao.UpdateExpiresAt(ctx, sessionID, time.Now().Add(s.refreshTokenExpiry), tx); err != nil {
+		return appErrors.ErrDatabaseOperation.WithError(err)
+	}
+	return nil
+}
+
+// 列出用户的有效会话
+func (s *TokenService) ListSessions(ctx context.Context, userID int) ([]*models.UserSession, error) {
+	sessions, err := s.sessionDao.ListActiveByUserID(ctx, userID, time.Now())
+	if err != nil {
+		return nil, appErrors.ErrDatabaseOperation.WithError(err)
+	}
+	return sessions, nil
+}
+
+// 吊销用户自己的某个会话（如退出其它设备），会话内的刷新令牌与访问令牌一并失效
+func (s *TokenService) RevokeSession(ctx context.Context, userID, id int) error {
+	err := s.transactionManager.WithTransaction(ctx, func(tx *gorm.DB) error {
+		session, err := s.sessionDao.GetByID(ctx, id, tx)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return appErrors.ErrSessionNotFound
+			}
+			return appErrors.ErrDatabaseOperation.WithError(err)
+		}
+		if session.UserID != userID || session.RevokedAt != nil {
+			return appErrors.ErrSessionNotFound
+		}
+		return s.revokeSession(ctx, userID, session.SessionID, tx)
+	})
+	if err != nil {
+		return err
+	}
+	s.log.InfoContext(ctx, "session revoked", slog.Int("user_id", userID), slog.Int("session_id", id))
+	return nil
+}
+
+// TouchSession 供认证中间件调用：确认访问令牌所属会话未被吊销并记录最近活跃时间与IP
+// 同一会话在 sessionTouchInterval 内且IP未变化时直接放行；未绑定会话的令牌，以及升级前登录、
+// 尚未刷新过的会话没有记录，同样放行
+func (s *TokenService) TouchSession(ctx context.Context, payload pkg.JwtPayload, clientIP string) error {
+	if payload.SessionID == "" {
+		return nil
+	}
+	now := time.Now()
+	s.touchMu.Lock()
+	last, ok := s.touched[payload.SessionID]
+	s.touchMu.Unlock()
+	if ok && now.Sub(last.at) < sessionTouchInterval && last.ip == clientIP {
+		return nil
+	}
+
+	session, err := s.sessionDao.GetBySessionID(ctx, payload.SessionID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return appErrors.ErrDatabaseOperation.WithError(err)
+	}
+	if session.RevokedAt != nil || session.UserID != payload.UserID {
+		return appErrors.ErrSessionRevoked
+	}
+	if err := s.sessionDao.UpdateLastSeen(ctx, payload.SessionID, now, clientIP); err != nil {
+		// 记录失败不影响本次请求
+		s.log.WarnContext(ctx, "failed to update session last seen", slog.Int("session_id", session.ID), slog.Any("error", err))
+		return nil
+	}
+
+	s.touchMu.Lock()
+	if len(s.touched) >= sessionTouchCacheSize {
+		s.touched = make(map[string]sessionTouch)
+	}
+	s.touched[payload.SessionID] = sessionTouch{at: now, ip: clientIP}
+	s.touchMu.Unlock()
+	return nil
+}
@@ -0,0 +1,153 @@
+package service
+
+import (
+	"TeamTickBackend/config"
+	"TeamTickBackend/pkg"
+	"crypto"
+	"crypto/x509"
+	"encoding/pem"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeSigningKey 生成私钥写入临时目录，publicOnly 时只写入公钥
+func writeSigningKey(t *testing.T, kid, algorithm string, publicOnly bool, activeFrom, expiresAt time.Time) config.JWTKeyConfig {
+	key, err := pkg.GenerateSigningKey(algorithm)
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), kid+".pem")
+	keyCfg := config.JWTKeyConfig{KID: kid, Algorithm: algorithm, ActiveFrom: activeFrom, ExpiresAt: expiresAt}
+	if publicOnly {
+		block, _ := pem.Decode(key)
+		private, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		require.NoError(t, err)
+		der, err := x509.MarshalPKIXPublicKey(private.(crypto.Signer).Public())
+		require.NoError(t, err)
+		key = pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+		keyCfg.PublicKeyFile = path
+	} else {
+		keyCfg.PrivateKeyFile = path
+	}
+	require.NoError(t, os.WriteFile(path, key, 0o600))
+	return keyCfg
+}
+
+func newSigningTestHandler(t *testing.T, secret string, keys ...config.JWTKeyConfig) pkg.JwtHandler {
+	handler, err := pkg.NewJwtHandler(&config.JWTConfig{
+		SecretKey:   secret,
+		Issuer:      "test",
+		TokenExpiry: time.Minute,
+		Keys:        keys,
+	}, nil, slog.Default())
+	require.NoError(t, err)
+	return handler
+}
+
+func tokenHeader(t *testing.T, token string) map[string]any {
+	parsed, _, err := jwt.NewParser().ParseUnverified(token, &pkg.TokenClaims{})
+	require.NoError(t, err)
+	return parsed.Header
+}
+
+func TestJwtSigning_AsymmetricKeysSelectedByKid(t *testing.T) {
+	now := time.Now()
+	rsaKey := writeSigningKey(t, "rsa-1", "RS256", false, now.Add(-time.Hour), time.Time{})
+	edKey := writeSigningKey(t, "ed-1", "EdDSA", false, now.Add(-time.Minute), time.Time{})
+	handler := newSigningTestHandler(t, "", rsaKey, edKey)
+
+	// 已生效的密钥中选择 active_from 最晚的签发
+	token, err := handler.GenerateJWTToken("alice", 1, "session-1")
+	require.NoError(t, err)
+	header := tokenHeader(t, token)
+	assert.Equal(t, "ed-1", header["kid"])
+	assert.Equal(t, "EdDSA", header["alg"])
+	payload, err := handler.ParseJWTToken("Bearer " + token)
+	require.NoError(t, err)
+	assert.Equal(t, 1, payload.UserID)
+	assert.Equal(t, "session-1", payload.SessionID)
+
+	// 轮换前由旧密钥签发的令牌仍可校验
+	old := newSigningTestHandler(t, "", rsaKey)
+	token, err = old.GenerateJWTToken("alice", 1)
+	require.NoError(t, err)
+	assert.Equal(t, "RS256", tokenHeader(t, token)["alg"])
+	_, err = handler.ParseJWTToken(token)
+	assert.NoError(t, err)
+
+	jwks := handler.JWKS()
+	require.Len(t, jwks.Keys, 2)
+	assert.Equal(t, pkg.JSONWebKey{Kty: "RSA", Kid: "rsa-1", Use: "sig", Alg: "RS256", N: jwks.Keys[0].N, E: "AQAB"}, jwks.Keys[0])
+	assert.Equal(t, "OKP", jwks.Keys[1].Kty)
+	assert.Equal(t, "Ed25519", jwks.Keys[1].Crv)
+	assert.NotEmpty(t, jwks.Keys[1].X)
+}
+
+func TestJwtSigning_ScheduledRotation(t *testing.T) {
+	now := time.Now()
+	current := writeSigningKey(t, "2025-01", "RS256", false, now.Add(-24*time.Hour), now.Add(2*time.Hour))
+	next := writeSigningKey(t, "2025-02", "EdDSA", false, now.Add(time.Hour), time.Time{})
+	handler := newSigningTestHandler(t, "", current, next)
+
+	// 尚未生效的密钥已在JWKS中公开，但不用于签发
+	token, err := handler.GenerateJWTToken("alice", 1)
+	require.NoError(t, err)
+	assert.Equal(t, "2025-01", tokenHeader(t, token)["kid"])
+	var kids []string
+	for _, key := range handler.JWKS().Keys {
+		kids = append(kids, key.Kid)
+	}
+	assert.Equal(t, []string{"2025-01", "2025-02"}, kids)
+
+	// 密钥过期后不再接受其签发的令牌，也不再公开
+	current.ExpiresAt = now.Add(-time.Second)
+	current.ActiveFrom = now.Add(-48 * time.Hour)
+	next.ActiveFrom = now.Add(-time.Hour)
+	rotated := newSigningTestHandler(t, "", current, next)
+	_, err = rotated.ParseJWTToken(token)
+	assert.ErrorIs(t, err, jwt.ErrTokenUnverifiable)
+	require.Len(t, rotated.JWKS().Keys, 1)
+	assert.Equal(t, "2025-02", rotated.JWKS().Keys[0].Kid)
+}
+
+func TestJwtSigning_RejectsUnknownKidAndAlgorithmMismatch(t *testing.T) {
+	now := time.Now()
+	key := writeSigningKey(t, "rsa-1", "RS256", false, now.Add(-time.Hour), time.Time{})
+	handler := newSigningTestHandler(t, "legacy-secret", key)
+
+	// 未知kid
+	other := newSigningTestHandler(t, "", writeSigningKey(t, "rsa-2", "RS256", false, now.Add(-time.Hour), time.Time{}))
+	token, err := other.GenerateJWTToken("alice", 1)
+	require.NoError(t, err)
+	_, err = handler.ParseJWTToken(token)
+	assert.ErrorIs(t, err, jwt.ErrTokenUnverifiable)
+
+	// 用共享密钥伪造带kid的HS256令牌
+	forged := jwt.NewWithClaims(jwt.SigningMethodHS256, pkg.TokenClaims{Username: "alice", UserID: 1})
+	forged.Header["kid"] = "rsa-1"
+	signed, err := forged.SignedString([]byte("legacy-secret"))
+	require.NoError(t, err)
+	_, err = handler.ParseJWTToken(signed)
+	assert.ErrorIs(t, err, jwt.ErrTokenUnverifiable)
+
+	// 切换前以共享密钥签发的不带kid的令牌仍可校验，共享密钥不公开
+	legacy := newSigningTestHandler(t, "legacy-secret")
+	token, err = legacy.GenerateJWTToken("alice", 1)
+	require.NoError(t, err)
+	assert.NotContains(t, tokenHeader(t, token), "kid")
+	_, err = handler.ParseJWTToken(token)
+	assert.NoError(t, err)
+	assert.Empty(t, legacy.JWKS().Keys)
+
+	// 只配置公钥的密钥仅用于校验
+	verifyOnly := writeSigningKey(t, "rsa-old", "RS256", true, now.Add(-time.Hour), time.Time{})
+	onlyPublic := newSigningTestHandler(t, "", verifyOnly)
+	_, err = onlyPublic.GenerateJWTToken("alice", 1)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no active jwt signing key")
+}
@@ -0,0 +1,262 @@
+package service
+
+import (
+	"TeamTickBackend/config"
+	"TeamTickBackend/dal/dao"
+	"TeamTickBackend/dal/models"
+	"TeamTickBackend/pkg"
+	apperrors "TeamTickBackend/pkg/errors"
+	"TeamTickBackend/pkg/revocation"
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// 令牌测试使用内存DAO与真实的JwtHandler，验证轮换、重复使用检测与吊销的完整流程
+func setupTokenServiceTest(t *testing.T) (*TokenService, pkg.JwtHandler, *dao.DAOFactory) {
+	factory := dao.NewMemoryDAOFactory()
+	jwtConfig := &config.JWTConfig{SecretKey: "test-secret", Issuer: "test", TokenExpiry: time.Minute}
+	revocations := revocation.NewStore(factory.TokenRevocationDAO, jwtConfig.TokenExpiry, time.Minute, slog.Default())
+	jwtHandler, err := pkg.NewJwtHandler(jwtConfig, revocations, slog.Default())
+	require.NoError(t, err)
+	seedUsers(t, factory, "alice")
+	tokenService := NewTokenService(factory.RefreshTokenDAO, factory.UserSessionDAO, factory.UserDAO, factory.TransactionManager, jwtHandler, revocations, time.Hour, discardLogger())
+	return tokenService, jwtHandler, factory
+}
+
+// startSession 以alice（用户ID为1）登录
+func startSession(t *testing.T, tokenService *TokenService, device DeviceInfo) *TokenPair {
+	pair, err := tokenService.StartSession(context.Background(), &models.User{UserID: 1, Username: "alice"}, device)
+	require.NoError(t, err)
+	return pair
+}
+
+func TestRefresh_RotatesToken(t *testing.T) {
+	tokenService, jwtHandler, _ := setupTokenServiceTest(t)
+	ctx := context.Background()
+
+	login := startSession(t, tokenService, DeviceInfo{ID: "phone-1", Name: "iPhone"})
+	refreshToken := login.RefreshToken
+
+	pair, err := tokenService.Refresh(ctx, refreshToken, "phone-1")
+	require.NoError(t, err)
+	assert.NotEqual(t, refreshToken, pair.RefreshToken)
+
+	// 刷新得到的访问令牌与登录时属于同一会话
+	loginPayload, err := jwtHandler.ParseJWTToken(login.AccessToken)
+	require.NoError(t, err)
+	payload, err := jwtHandler.ParseJWTToken(pair.AccessToken)
+	require.NoError(t, err)
+	assert.Equal(t, 1, payload.UserID)
+	assert.Equal(t, loginPayload.SessionID, payload.SessionID)
+	assert.NotEqual(t, loginPayload.TokenID, payload.TokenID)
+
+	// 新令牌可以继续使用
+	_, err = tokenService.Refresh(ctx, pair.RefreshToken, "phone-1")
+	assert.NoError(t, err)
+}
+
+func TestRefresh_ReuseRevokesFamily(t *testing.T) {
+	tokenService, jwtHandler, factory := setupTokenServiceTest(t)
+	ctx := context.Background()
+
+	refreshToken := startSession(t, tokenService, DeviceInfo{ID: "phone-1"}).RefreshToken
+	pair, err := tokenService.Refresh(ctx, refreshToken, "phone-1")
+	require.NoError(t, err)
+
+	// 已轮换的旧令牌被再次使用
+	_, err = tokenService.Refresh(ctx, refreshToken, "phone-1")
+	assert.ErrorIs(t, err, apperrors.ErrRefreshTokenReused)
+
+	// 吊销已提交，同一家族中最新的令牌也随之失效
+	latest, err := factory.RefreshTokenDAO.GetByTokenHash(ctx, pkg.HashOpaqueToken(pair.RefreshToken))
+	require.NoError(t, err)
+	assert.NotNil(t, latest.RevokedAt)
+	_, err = tokenService.Refresh(ctx, pair.RefreshToken, "phone-1")
+	assert.ErrorIs(t, err, apperrors.ErrRefreshTokenInvalid)
+	// 会话下已签发的访问令牌同样被拒绝
+	_, err = jwtHandler.ParseJWTToken(pair.AccessToken)
+	assert.ErrorIs(t, err, apperrors.ErrTokenRevoked)
+}
+
+func TestRefresh_DeviceMismatch(t *testing.T) {
+	tokenService, _, _ := setupTokenServiceTest(t)
+	ctx := context.Background()
+
+	refreshToken := startSession(t, tokenService, DeviceInfo{ID: "phone-1"}).RefreshToken
+
+	_, err := tokenService.Refresh(ctx, refreshToken, "laptop-2")
+	assert.ErrorIs(t, err, apperrors.ErrRefreshTokenInvalid)
+
+	// 设备不匹配视为泄露，原设备也无法继续使用
+	_, err = tokenService.Refresh(ctx, refreshToken, "phone-1")
+	assert.ErrorIs(t, err, apperrors.ErrRefreshTokenInvalid)
+}
+
+func TestRefresh_Expired(t *testing.T) {
+	tokenService, _, factory := setupTokenServiceTest(t)
+	ctx := context.Background()
+
+	err := factory.RefreshTokenDAO.Create(ctx, &models.RefreshToken{
+		UserID:    1,
+		FamilyID:  "family",
+		TokenHash: pkg.HashOpaqueToken("expired-token"),
+		ExpiresAt: time.Now().Add(-time.Minute),
+	})
+	require.NoError(t, err)
+
+	_, err = tokenService.Refresh(ctx, "expired-token", "")
+	assert.ErrorIs(t, err, apperrors.ErrRefreshTokenExpired)
+	_, err = tokenService.Refresh(ctx, "unknown-token", "")
+	assert.ErrorIs(t, err, apperrors.ErrRefreshTokenInvalid)
+}
+
+func TestLogout_RevokesSession(t *testing.T) {
+	tokenService, jwtHandler, _ := setupTokenServiceTest(t)
+	ctx := context.Background()
+
+	login := startSession(t, tokenService, DeviceInfo{ID: "phone-1"})
+	refreshed, err := tokenService.Refresh(ctx, login.RefreshToken, "phone-1")
+	require.NoError(t, err)
+	other := startSession(t, tokenService, DeviceInfo{ID: "laptop-2"})
+
+	payload, err := jwtHandler.ParseJWTToken(refreshed.AccessToken)
+	require.NoError(t, err)
+	require.NoError(t, tokenService.Logout(ctx, payload))
+
+	// 同一会话的访问令牌与刷新令牌全部失效
+	_, err = jwtHandler.ParseJWTToken(refreshed.AccessToken)
+	assert.ErrorIs(t, err, apperrors.ErrTokenRevoked)
+	_, err = jwtHandler.ParseJWTToken(login.AccessToken)
+	assert.ErrorIs(t, err, apperrors.ErrTokenRevoked)
+	_, err = tokenService.Refresh(ctx, refreshed.RefreshToken, "phone-1")
+	assert.ErrorIs(t, err, apperrors.ErrRefreshTokenInvalid)
+
+	// 其它设备上的会话不受影响
+	_, err = jwtHandler.ParseJWTToken(other.AccessToken)
+	assert.NoError(t, err)
+}
+
+func TestRevokeAllSessions(t *testing.T) {
+	tokenService, jwtHandler, _ := setupTokenServiceTest(t)
+	ctx := context.Background()
+
+	phone := startSession(t, tokenService, DeviceInfo{ID: "phone-1"})
+	laptop := startSession(t, tokenService, DeviceInfo{ID: "laptop-2"})
+
+	// 用户级吊销按毫秒级签发时间判断，同一毫秒内签发的令牌不受影响
+	time.Sleep(2 * time.Millisecond)
+	require.NoError(t, tokenService.RevokeAllSessions(ctx, 1))
+
+	for _, pair := range []*TokenPair{phone, laptop} {
+		_, err := jwtHandler.ParseJWTToken(pair.AccessToken)
+		assert.ErrorIs(t, err, apperrors.ErrTokenRevoked)
+	}
+	_, err := tokenService.Refresh(ctx, laptop.RefreshToken, "laptop-2")
+	assert.ErrorIs(t, err, apperrors.ErrRefreshTokenInvalid)
+
+	// 吊销之后重新登录获得的令牌有效
+	time.Sleep(2 * time.Millisecond)
+	relogin := startSession(t, tokenService, DeviceInfo{ID: "phone-1"})
+	_, err = jwtHandler.ParseJWTToken(relogin.AccessToken)
+	assert.NoError(t, err)
+
+	assert.ErrorIs(t, tokenService.RevokeAllSessions(ctx, 99), apperrors.ErrUserNotFound)
+}
+
+func TestSessions_ListAndRevoke(t *testing.T) {
+	tokenService, jwtHandler, _ := setupTokenServiceTest(t)
+	ctx := context.Background()
+
+	phone := startSession(t, tokenService, DeviceInfo{ID: "phone-1", Name: "iPhone", UserAgent: "TeamTick/1.0 (iOS)", IP: "10.0.0.1"})
+	laptop := startSession(t, tokenService, DeviceInfo{ID: "laptop-2", Name: "MacBook", IP: "10.0.0.2"})
+
+	sessions, err := tokenService.ListSessions(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, sessions, 2)
+	var phoneSession *models.UserSession
+	for _, session := range sessions {
+		if session.DeviceID == "phone-1" {
+			phoneSession = session
+		}
+	}
+	require.NotNil(t, phoneSession)
+	assert.Equal(t, "iPhone", phoneSession.DeviceName)
+	assert.Equal(t, "TeamTick/1.0 (iOS)", phoneSession.UserAgent)
+	assert.Equal(t, "10.0.0.1", phoneSession.IP)
+	phonePayload, err := jwtHandler.ParseJWTToken(phone.AccessToken)
+	require.NoError(t, err)
+	assert.Equal(t, phonePayload.SessionID, phoneSession.SessionID)
+
+	// 只能退出自己的会话
+	assert.ErrorIs(t, tokenService.RevokeSession(ctx, 2, phoneSession.ID), apperrors.ErrSessionNotFound)
+	require.NoError(t, tokenService.RevokeSession(ctx, 1, phoneSession.ID))
+	assert.ErrorIs(t, tokenService.RevokeSession(ctx, 1, phoneSession.ID), apperrors.ErrSessionNotFound)
+
+	_, err = jwtHandler.ParseJWTToken(phone.AccessToken)
+	assert.ErrorIs(t, err, apperrors.ErrTokenRevoked)
+	_, err = tokenService.Refresh(ctx, phone.RefreshToken, "phone-1")
+	assert.ErrorIs(t, err, apperrors.ErrRefreshTokenInvalid)
+	_, err = jwtHandler.ParseJWTToken(laptop.AccessToken)
+	assert.NoError(t, err)
+
+	sessions, err = tokenService.ListSessions(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, sessions, 1)
+	assert.Equal(t, "laptop-2", sessions[0].DeviceID)
+}
+
+func TestSessions_TouchSession(t *testing.T) {
+	tokenService, jwtHandler, factory := setupTokenServiceTest(t)
+	ctx := context.Background()
+
+	login := startSession(t, tokenService, DeviceInfo{ID: "phone-1", IP: "10.0.0.1"})
+	payload, err := jwtHandler.ParseJWTToken(login.AccessToken)
+	require.NoError(t, err)
+
+	require.NoError(t, tokenService.TouchSession(ctx, payload, "10.0.0.9"))
+	session, err := factory.UserSessionDAO.GetBySessionID(ctx, payload.SessionID)
+	require.NoError(t, err)
+	assert.Equal(t, "10.0.0.9", session.LastSeenIP)
+
+	// 未绑定会话的令牌与没有会话记录的令牌放行
+	assert.NoError(t, tokenService.TouchSession(ctx, pkg.JwtPayload{UserID: 1}, ""))
+	assert.NoError(t, tokenService.TouchSession(ctx, pkg.JwtPayload{UserID: 1, SessionID: "legacy"}, ""))
+
+	// 刷新令牌被重复使用导致会话被吊销后，会话下仍未过期的访问令牌被拒绝
+	refreshed, err := tokenService.Refresh(ctx, login.RefreshToken, "phone-1")
+	require.NoError(t, err)
+	refreshedPayload, err := jwtHandler.ParseJWTToken(refreshed.AccessToken)
+	require.NoError(t, err)
+	_, err = tokenService.Refresh(ctx, login.RefreshToken, "phone-1")
+	require.ErrorIs(t, err, apperrors.ErrRefreshTokenReused)
+	_, err = jwtHandler.ParseJWTToken(refreshed.AccessToken)
+	assert.ErrorIs(t, err, apperrors.ErrTokenRevoked)
+	assert.ErrorIs(t, tokenService.TouchSession(ctx, refreshedPayload, "10.0.0.1"), apperrors.ErrSessionRevoked)
+}
+
+func TestSessions_RefreshBackfillsLegacySession(t *testing.T) {
+	tokenService, _, factory := setupTokenServiceTest(t)
+	ctx := context.Background()
+
+	// 升级前登录的会话只有刷新令牌，没有会话记录
+	err := factory.RefreshTokenDAO.Create(ctx, &models.RefreshToken{
+		UserID:     1,
+		FamilyID:   "legacy-family",
+		TokenHash:  pkg.HashOpaqueToken("legacy-token"),
+		DeviceID:   "phone-1",
+		DeviceName: "iPhone",
+		ExpiresAt:  time.Now().Add(time.Hour),
+	})
+	require.NoError(t, err)
+
+	_, err = tokenService.Refresh(ctx, "legacy-token", "phone-1")
+	require.NoError(t, err)
+	session, err := factory.UserSessionDAO.GetBySessionID(ctx, "legacy-family")
+	require.NoError(t, err)
+	assert.Equal(t, "iPhone", session.DeviceName)
+}
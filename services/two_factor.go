@@ -0,0 +1,470 @@
+package service
+
+import (
+	"TeamTickBackend/config"
+	"TeamTickBackend/dal/dao"
+	"TeamTickBackend/dal/models"
+	"TeamTickBackend/pkg"
+	appErrors "TeamTickBackend/pkg/errors"
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base32"
+	"errors"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/pquerna/otp"
+	"github.com/pquerna/otp/totp"
+	"gorm.io/gorm"
+)
+
+// TOTP参数，与主流验证器App的默认值一致
+const (
+	totpPeriod = 30 * time.Second
+	totpDigits = otp.DigitsSix
+	// 允许前后各一个时间步的时钟偏差
+	totpSkew = 1
+)
+
+var totpValidateOpts = totp.ValidateOpts{
+	Period:    uint(totpPeriod / time.Second),
+	Digits:    totpDigits,
+	Algorithm: otp.AlgorithmSHA1,
+}
+
+// 恢复码使用小写Base32字符，去掉易混淆的填充
+var recoveryCodeEncoding = base32.NewEncoding("abcdefghijklmnopqrstuvwxyz234567").WithPadding(base32.NoPadding)
+
+// TwoFactorStatus 用户的两步验证状态
+type TwoFactorStatus struct {
+	Enabled                bool
+	RecoveryCodesRemaining int64
+}
+
+// TOTPEnrollment 开始绑定时返回的密钥与otpauth URI，供验证器App扫码或手动输入
+type TOTPEnrollment struct {
+	Secret string
+	URI    string
+}
+
+// TwoFactorService TOTP两步验证：绑定、恢复码、关闭以及两步登录的第二步
+type TwoFactorService struct {
+	userDao            dao.UserDAO
+	secretDao          dao.TOTPSecretDAO
+	recoveryCodeDao    dao.RecoveryCodeDAO
+	challengeDao       dao.MFAChallengeDAO
+	groupDao           dao.GroupDAO
+	groupMemberDao     dao.GroupMemberDAO
+	transactionManager dao.TransactionManager
+	loginGuard         *LoginGuard
+	cfg                config.TwoFactorConfig
+	now                func() time.Time
+	log                *slog.Logger
+}
+
+func NewTwoFactorService(
+	userDao dao.UserDAO,
+	secretDao dao.TOTPSecretDAO,
+	recoveryCodeDao dao.RecoveryCodeDAO,
+	challengeDao dao.MFAChallengeDAO,
+	groupDao dao.GroupDAO,
+	groupMemberDao dao.GroupMemberDAO,
+	transactionManager dao.TransactionManager,
+	loginGuard *LoginGuard,
+	cfg config.TwoFactorConfig,
+	log *slog.Logger,
+) *TwoFactorService {
+	return &TwoFactorService{
+		userDao:            userDao,
+		secretDao:          secretDao,
+		recoveryCodeDao:    recoveryCodeDao,
+		challengeDao:       challengeDao,
+		groupDao:           groupDao,
+		groupMemberDao:     groupMemberDao,
+		transactionManager: transactionManager,
+		loginGuard:         loginGuard,
+		cfg:                cfg,
+		now:                time.Now,
+		log:                log,
+	}
+}
+
+// 查询两步验证状态
+func (s *TwoFactorService) Status(ctx context.Context, userID int) (*TwoFactorStatus, error) {
+	user, err := s.getUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	status := &TwoFactorStatus{Enabled: user.TwoFactorEnabled}
+	if user.TwoFactorEnabled {
+		remaining, err := s.recoveryCodeDao.CountUnused(ctx, userID)
+		if err != nil {
+			return nil, appErrors.ErrDatabaseOperation.WithError(err)
+		}
+		status.RecoveryCodesRemaining = remaining
+	}
+	return status, nil
+}
+
+// 开始绑定：生成新密钥，确认前不生效；重复调用会替换尚未确认的密钥
+func (s *TwoFactorService) BeginEnrollment(ctx context.Context, userID int) (*TOTPEnrollment, error) {
+	user, err := s.getUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.TwoFactorEnabled {
+		return nil, appErrors.ErrTwoFactorAlreadyEnabled
+	}
+	key, err := totp.Generate(totp.GenerateOpts{
+		Issuer:      s.cfg.Issuer,
+		AccountName: user.Username,
+		Period:      totpValidateOpts.Period,
+		Digits:      totpValidateOpts.Digits,
+		Algorithm:   totpValidateOpts.Algorithm,
+	})
+	if err != nil {
+		return nil, appErrors.ErrTokenGenerationFailed.WithError(err)
+	}
+	if err := s.secretDao.Save(ctx, &models.TOTPSecret{
+		UserID:    userID,
+		Secret:    key.Secret(),
+		CreatedAt: s.now(),
+	}); err != nil {
+		return nil, appErrors.ErrDatabaseOperation.WithError(err)
+	}
+	return &TOTPEnrollment{Secret: key.Secret(), URI: key.URL()}, nil
+}
+
+// 确认绑定：校验验证器App生成的验证码，开启两步验证并返回一组新的恢复码（明文只返回这一次）
+func (s *TwoFactorService) ConfirmEnrollment(ctx context.Context, userID int, code string) ([]string, error) {
+	var recoveryCodes []string
+	err := s.transactionManager.WithTransaction(ctx, func(tx *gorm.DB) error {
+		user, err := s.getUser(ctx, userID, tx)
+		if err != nil {
+			return err
+		}
+		if user.TwoFactorEnabled {
+			return appErrors.ErrTwoFactorAlreadyEnabled
+		}
+		secret, err := s.secretDao.Get(ctx, userID, tx)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return appErrors.ErrTwoFactorNotEnrolled
+			}
+			return appErrors.ErrDatabaseOperation.WithError(err)
+		}
+		if err := s.verifyTOTP(ctx, secret, code, tx); err != nil {
+			return err
+		}
+		if err := s.secretDao.Confirm(ctx, userID, s.now(), tx); err != nil {
+			return appErrors.ErrDatabaseOperation.WithError(err)
+		}
+		if err := s.userDao.UpdateTwoFactorEnabled(ctx, userID, true, tx); err != nil {
+			return appErrors.ErrDatabaseOperation.WithError(err)
+		}
+		recoveryCodes, err = s.replaceRecoveryCodes(ctx, userID, tx)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.log.InfoContext(ctx, "two-factor enabled", slog.Int("user_id", userID))
+	return recoveryCodes, nil
+}
+
+// 关闭两步验证，需提交验证码或恢复码；在要求管理员开启两步验证的用户组中担任管理员或持有自定义角色时不允许关闭
+func (s *TwoFactorService) Disable(ctx context.Context, userID int, code string) error {
+	err := s.transactionManager.WithTransaction(ctx, func(tx *gorm.DB) error {
+		user, err := s.getUser(ctx, userID, tx)
+		if err != nil {
+			return err
+		}
+		if !user.TwoFactorEnabled {
+			return appErrors.ErrTwoFactorNotEnabled
+		}
+		groups, err := s.groupDao.GetGroupsByUserIDAndfilter(ctx, userID, "created", tx)
+		if err != nil {
+			return appErrors.ErrDatabaseOperation.WithError(err)
+		}
+		for _, group := range groups {
+			if group.RequireAdmin2FA {
+				return appErrors.ErrTwoFactorRequiredByGroup
+			}
+		}
+		if err := s.checkCustomRoles(ctx, userID, tx); err != nil {
+			return err
+		}
+		if err := s.verifyCode(ctx, userID, code, tx); err != nil {
+			return err
+		}
+		if err := s.secretDao.Delete(ctx, userID, tx); err != nil {
+			return appErrors.ErrDatabaseOperation.WithError(err)
+		}
+		if err := s.recoveryCodeDao.DeleteByUserID(ctx, userID, tx); err != nil {
+			return appErrors.ErrDatabaseOperation.WithError(err)
+		}
+		if err := s.userDao.UpdateTwoFactorEnabled(ctx, userID, false, tx); err != nil {
+			return appErrors.ErrDatabaseOperation.WithError(err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	s.log.InfoContext(ctx, "two-factor disabled", slog.Int("user_id", userID))
+	return nil
+}
+
+// checkCustomRoles 用户在要求管理员开启两步验证的用户组中持有自定义角色时返回 ErrTwoFactorRequiredByGroup
+func (s *TwoFactorService) checkCustomRoles(ctx context.Context, userID int, tx *gorm.DB) error {
+	groups, err := s.groupDao.GetGroupsByUserID(ctx, userID, tx)
+	if err != nil {
+		return appErrors.ErrDatabaseOperation.WithError(err)
+	}
+	var groupIDs []int
+	for _, group := range groups {
+		if group.RequireAdmin2FA {
+			groupIDs = append(groupIDs, group.GroupID)
+		}
+	}
+	if len(groupIDs) == 0 {
+		return nil
+	}
+	members, err := s.groupMemberDao.GetByUserIDAndGroupIDs(ctx, userID, groupIDs, tx)
+	if err != nil {
+		return appErrors.ErrDatabaseOperation.WithError(err)
+	}
+	for _, member := range members {
+		if member.RoleID != 0 {
+			return appErrors.ErrTwoFactorRequiredByGroup
+		}
+	}
+	return nil
+}
+
+// 重新生成恢复码，之前的恢复码全部作废
+func (s *TwoFactorService) RegenerateRecoveryCodes(ctx context.Context, userID int, code string) ([]string, error) {
+	var recoveryCodes []string
+	err := s.transactionManager.WithTransaction(ctx, func(tx *gorm.DB) error {
+		user, err := s.getUser(ctx, userID, tx)
+		if err != nil {
+			return err
+		}
+		if !user.TwoFactorEnabled {
+			return appErrors.ErrTwoFactorNotEnabled
+		}
+		if err := s.verifyCode(ctx, userID, code, tx); err != nil {
+			return err
+		}
+		recoveryCodes, err = s.replaceRecoveryCodes(ctx, userID, tx)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return recoveryCodes, nil
+}
+
+// StartChallenge 密码校验通过后签发两步登录挑战，返回的令牌只在本次响应中出现一次
+func (s *TwoFactorService) StartChallenge(ctx context.Context, user *models.User, device DeviceInfo) (string, error) {
+	token, err := pkg.GenerateOpaqueToken()
+	if err != nil {
+		return "", appErrors.ErrTokenGenerationFailed.WithError(err)
+	}
+	now := s.now()
+	if err := s.challengeDao.Create(ctx, &models.MFAChallenge{
+		UserID:     user.UserID,
+		TokenHash:  pkg.HashOpaqueToken(token),
+		DeviceID:   device.ID,
+		DeviceName: device.Name,
+		ExpiresAt:  now.Add(s.cfg.ChallengeExpiry),
+		CreatedAt:  now,
+	}); err != nil {
+		return "", appErrors.ErrDatabaseOperation.WithError(err)
+	}
+	// 顺带清理过期的挑战，失败不影响登录
+	if _, err := s.challengeDao.DeleteExpired(ctx, now); err != nil {
+		s.log.WarnContext(ctx, "purge mfa challenges failed", slog.String("error", err.Error()))
+	}
+	return token, nil
+}
+
+// CompleteChallenge 两步登录的第二步：校验挑战令牌与验证码（或恢复码），成功后返回用户与第一步提交的设备信息
+//
+// 挑战令牌过期、已使用或错误次数达到上限时返回 ErrMFAChallengeInvalid，需要重新输入密码；
+// 验证码错误同样计入登录防暴力破解的失败次数
+func (s *TwoFactorService) CompleteChallenge(ctx context.Context, token, code, clientIP string) (*models.User, DeviceInfo, error) {
+	challenge, err := s.challengeDao.GetByTokenHash(ctx, pkg.HashOpaqueToken(token))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, DeviceInfo{}, appErrors.ErrMFAChallengeInvalid
+		}
+		return nil, DeviceInfo{}, appErrors.ErrDatabaseOperation.WithError(err)
+	}
+	if challenge.UsedAt != nil || !s.now().Before(challenge.ExpiresAt) || challenge.Attempts >= s.cfg.MaxChallengeAttempts {
+		return nil, DeviceInfo{}, appErrors.ErrMFAChallengeInvalid
+	}
+	user, err := s.getUser(ctx, challenge.UserID)
+	if err != nil {
+		return nil, DeviceInfo{}, err
+	}
+	// 挑战签发后账号被停用，需重新登录
+	if user.DisabledAt != nil {
+		return nil, DeviceInfo{}, appErrors.ErrMFAChallengeInvalid
+	}
+	if s.loginGuard != nil {
+		if err := s.loginGuard.Check(ctx, user.Username, clientIP); err != nil {
+			return nil, DeviceInfo{}, err
+		}
+	}
+
+	err = s.transactionManager.WithTransaction(ctx, func(tx *gorm.DB) error {
+		if err := s.verifyCode(ctx, user.UserID, code, tx); err != nil {
+			return err
+		}
+		marked, err := s.challengeDao.MarkUsed(ctx, challenge.ID, s.now(), tx)
+		if err != nil {
+			return appErrors.ErrDatabaseOperation.WithError(err)
+		}
+		if !marked {
+			return appErrors.ErrMFAChallengeInvalid
+		}
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, appErrors.ErrTwoFactorCodeInvalid) {
+			if incErr := s.challengeDao.IncrementAttempts(ctx, challenge.ID); incErr != nil {
+				return nil, DeviceInfo{}, appErrors.ErrDatabaseOperation.WithError(incErr)
+			}
+			if s.loginGuard != nil {
+				s.loginGuard.RecordFailure(ctx, user.Username, clientIP)
+			}
+		}
+		return nil, DeviceInfo{}, err
+	}
+	if s.loginGuard != nil {
+		s.loginGuard.RecordSuccess(ctx, user.Username)
+	}
+	return user, DeviceInfo{ID: challenge.DeviceID, Name: challenge.DeviceName}, nil
+}
+
+// verifyCode 校验已绑定的验证码，或消耗一个恢复码
+func (s *TwoFactorService) verifyCode(ctx context.Context, userID int, code string, tx *gorm.DB) error {
+	code = strings.TrimSpace(code)
+	if !isTOTPCode(code) {
+		marked, err := s.recoveryCodeDao.MarkUsed(ctx, userID, pkg.HashOpaqueToken(normalizeRecoveryCode(code)), s.now(), tx)
+		if err != nil {
+			return appErrors.ErrDatabaseOperation.WithError(err)
+		}
+		if !marked {
+			return appErrors.ErrTwoFactorCodeInvalid
+		}
+		s.log.InfoContext(ctx, "recovery code used", slog.Int("user_id", userID))
+		return nil
+	}
+	secret, err := s.secretDao.Get(ctx, userID, tx)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return appErrors.ErrTwoFactorNotEnrolled
+		}
+		return appErrors.ErrDatabaseOperation.WithError(err)
+	}
+	if secret.ConfirmedAt == nil {
+		return appErrors.ErrTwoFactorNotEnrolled
+	}
+	return s.verifyTOTP(ctx, secret, code, tx)
+}
+
+// verifyTOTP 校验验证码并记录其时间步，同一时间步及更早的验证码不能再次使用
+func (s *TwoFactorService) verifyTOTP(ctx context.Context, secret *models.TOTPSecret, code string, tx *gorm.DB) error {
+	code = strings.TrimSpace(code)
+	if !isTOTPCode(code) {
+		return appErrors.ErrTwoFactorCodeInvalid
+	}
+	now := s.now()
+	for skew := -totpSkew; skew <= totpSkew; skew++ {
+		at := now.Add(time.Duration(skew) * totpPeriod)
+		expected, err := totp.GenerateCodeCustom(secret.Secret, at, totpValidateOpts)
+		if err != nil {
+			return appErrors.ErrTwoFactorCodeInvalid.WithError(err)
+		}
+		if subtle.ConstantTimeCompare([]byte(expected), []byte(code)) != 1 {
+			continue
+		}
+		advanced, err := s.secretDao.AdvanceStep(ctx, secret.UserID, at.Unix()/int64(totpPeriod/time.Second), tx)
+		if err != nil {
+			return appErrors.ErrDatabaseOperation.WithError(err)
+		}
+		if !advanced {
+			return appErrors.ErrTwoFactorCodeInvalid
+		}
+		return nil
+	}
+	return appErrors.ErrTwoFactorCodeInvalid
+}
+
+// replaceRecoveryCodes 作废旧恢复码并生成新的一组，返回明文
+func (s *TwoFactorService) replaceRecoveryCodes(ctx context.Context, userID int, tx *gorm.DB) ([]string, error) {
+	if err := s.recoveryCodeDao.DeleteByUserID(ctx, userID, tx); err != nil {
+		return nil, appErrors.ErrDatabaseOperation.WithError(err)
+	}
+	codes := make([]string, s.cfg.RecoveryCodes)
+	records := make([]*models.RecoveryCode, s.cfg.RecoveryCodes)
+	now := s.now()
+	for i := range codes {
+		code, err := generateRecoveryCode()
+		if err != nil {
+			return nil, appErrors.ErrTokenGenerationFailed.WithError(err)
+		}
+		codes[i] = code
+		records[i] = &models.RecoveryCode{
+			UserID:    userID,
+			CodeHash:  pkg.HashOpaqueToken(normalizeRecoveryCode(code)),
+			CreatedAt: now,
+		}
+	}
+	if err := s.recoveryCodeDao.CreateBatch(ctx, records, tx); err != nil {
+		return nil, appErrors.ErrDatabaseOperation.WithError(err)
+	}
+	return codes, nil
+}
+
+func (s *TwoFactorService) getUser(ctx context.Context, userID int, tx ...*gorm.DB) (*models.User, error) {
+	user, err := s.userDao.GetByID(ctx, userID, tx...)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, appErrors.ErrUserNotFound
+		}
+		return nil, appErrors.ErrDatabaseOperation.WithError(err)
+	}
+	return user, nil
+}
+
+// generateRecoveryCode 生成形如 abcde-fghij 的恢复码（50位随机数）
+func generateRecoveryCode() (string, error) {
+	buf := make([]byte, 7)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	code := recoveryCodeEncoding.EncodeToString(buf)[:10]
+	return code[:5] + "-" + code[5:], nil
+}
+
+// normalizeRecoveryCode 输入恢复码时忽略大小写、空格与连字符
+func normalizeRecoveryCode(code string) string {
+	return strings.NewReplacer("-", "", " ", "").Replace(strings.ToLower(strings.TrimSpace(code)))
+}
+
+func isTOTPCode(code string) bool {
+	if len(code) != int(totpDigits) {
+		return false
+	}
+	for _, c := range code {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,247 @@
+package service
+
+import (
+	"TeamTickBackend/config"
+	"TeamTickBackend/dal/dao"
+	"TeamTickBackend/dal/models"
+	apperrors "TeamTickBackend/pkg/errors"
+	"context"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/pquerna/otp/totp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type twoFactorTestEnv struct {
+	factory   *dao.DAOFactory
+	service   *TwoFactorService
+	auth      *AuthService
+	groups    *GroupsService
+	now       *time.Time
+	user      *models.User
+	secret    string
+	recovered []string
+}
+
+func setupTwoFactorTest(t *testing.T) *twoFactorTestEnv {
+	factory := dao.NewMemoryDAOFactory()
+	service := NewTwoFactorService(factory.UserDAO, factory.TOTPSecretDAO, factory.RecoveryCodeDAO, factory.MFAChallengeDAO,
+		factory.GroupDAO, factory.GroupMemberDAO, factory.TransactionManager, nil, config.TwoFactorConfig{
+			Issuer:               "TeamTick",
+			ChallengeExpiry:      5 * time.Minute,
+			MaxChallengeAttempts: 3,
+			RecoveryCodes:        4,
+		}, discardLogger())
+	now := time.Date(2025, 6, 1, 8, 0, 0, 0, time.UTC)
+	service.now = func() time.Time { return now }
+	auth := NewAuthService(factory.UserDAO, factory.TransactionManager, new(mockJwtHandler), nil, nil, discardLogger())
+	user, err := auth.AuthRegister(context.Background(), "alice", "secret1")
+	require.NoError(t, err)
+	return &twoFactorTestEnv{
+		factory: factory,
+		service: service,
+		auth:    auth,
+		groups:  newMemoryGroupsService(t, factory),
+		now:     &now,
+		user:    user,
+	}
+}
+
+// code 生成当前时间的验证码，并将时间推进一个时间步，避免下一次校验被当作重放
+func (env *twoFactorTestEnv) code(t *testing.T) string {
+	code, err := totp.GenerateCode(env.secret, *env.now)
+	require.NoError(t, err)
+	*env.now = env.now.Add(totpPeriod)
+	return code
+}
+
+func (env *twoFactorTestEnv) enable(t *testing.T) {
+	ctx := context.Background()
+	enrollment, err := env.service.BeginEnrollment(ctx, env.user.UserID)
+	require.NoError(t, err)
+	env.secret = enrollment.Secret
+	env.recovered, err = env.service.ConfirmEnrollment(ctx, env.user.UserID, env.code(t))
+	require.NoError(t, err)
+}
+
+func TestTwoFactor_EnrollmentAndRecoveryCodes(t *testing.T) {
+	env := setupTwoFactorTest(t)
+	ctx := context.Background()
+
+	enrollment, err := env.service.BeginEnrollment(ctx, env.user.UserID)
+	require.NoError(t, err)
+	uri, err := url.Parse(enrollment.URI)
+	require.NoError(t, err)
+	assert.Equal(t, "otpauth", uri.Scheme)
+	assert.Equal(t, "/TeamTick:alice", uri.Path)
+	assert.Equal(t, enrollment.Secret, uri.Query().Get("secret"))
+	env.secret = enrollment.Secret
+
+	// 确认前未开启，错误的验证码不能确认
+	_, err = env.service.ConfirmEnrollment(ctx, env.user.UserID, "000000")
+	assert.ErrorIs(t, err, apperrors.ErrTwoFactorCodeInvalid)
+	status, err := env.service.Status(ctx, env.user.UserID)
+	require.NoError(t, err)
+	assert.False(t, status.Enabled)
+
+	codes, err := env.service.ConfirmEnrollment(ctx, env.user.UserID, env.code(t))
+	require.NoError(t, err)
+	require.Len(t, codes, 4)
+	assert.Regexp(t, `^[a-z2-7]{5}-[a-z2-7]{5}$`, codes[0])
+	_, err = env.service.BeginEnrollment(ctx, env.user.UserID)
+	assert.ErrorIs(t, err, apperrors.ErrTwoFactorAlreadyEnabled)
+
+	// 恢复码不区分大小写、只能使用一次
+	regenerated, err := env.service.RegenerateRecoveryCodes(ctx, env.user.UserID, " "+codes[0]+" ")
+	require.NoError(t, err)
+	_, err = env.service.RegenerateRecoveryCodes(ctx, env.user.UserID, codes[1])
+	assert.ErrorIs(t, err, apperrors.ErrTwoFactorCodeInvalid, "重新生成后旧恢复码作废")
+	_, err = env.service.RegenerateRecoveryCodes(ctx, env.user.UserID, regenerated[0])
+	require.NoError(t, err)
+
+	status, err = env.service.Status(ctx, env.user.UserID)
+	require.NoError(t, err)
+	assert.True(t, status.Enabled)
+	assert.EqualValues(t, 4, status.RecoveryCodesRemaining)
+
+	require.NoError(t, env.service.Disable(ctx, env.user.UserID, env.code(t)))
+	user, err := env.factory.UserDAO.GetByID(ctx, env.user.UserID)
+	require.NoError(t, err)
+	assert.False(t, user.TwoFactorEnabled)
+	_, err = env.factory.TOTPSecretDAO.Get(ctx, env.user.UserID)
+	assert.Error(t, err)
+}
+
+func TestTwoFactor_CodeCannotBeReplayed(t *testing.T) {
+	env := setupTwoFactorTest(t)
+	env.enable(t)
+	ctx := context.Background()
+
+	code, err := totp.GenerateCode(env.secret, *env.now)
+	require.NoError(t, err)
+	_, err = env.service.RegenerateRecoveryCodes(ctx, env.user.UserID, code)
+	require.NoError(t, err)
+	_, err = env.service.RegenerateRecoveryCodes(ctx, env.user.UserID, code)
+	assert.ErrorIs(t, err, apperrors.ErrTwoFactorCodeInvalid)
+
+	// 上一个时间步的验证码仍在允许的偏差内，但早于已使用的时间步
+	previous, err := totp.GenerateCode(env.secret, env.now.Add(-totpPeriod))
+	require.NoError(t, err)
+	_, err = env.service.RegenerateRecoveryCodes(ctx, env.user.UserID, previous)
+	assert.ErrorIs(t, err, apperrors.ErrTwoFactorCodeInvalid)
+}
+
+func TestTwoFactor_TwoStepLogin(t *testing.T) {
+	env := setupTwoFactorTest(t)
+	env.enable(t)
+	ctx := context.Background()
+
+	user, err := env.auth.VerifyCredentials(ctx, "alice", "secret1", "")
+	require.NoError(t, err)
+	require.True(t, user.TwoFactorEnabled)
+
+	token, err := env.service.StartChallenge(ctx, user, DeviceInfo{ID: "phone-1", Name: "iPhone"})
+	require.NoError(t, err)
+
+	_, _, err = env.service.CompleteChallenge(ctx, "unknown", env.code(t), "")
+	assert.ErrorIs(t, err, apperrors.ErrMFAChallengeInvalid)
+	_, _, err = env.service.CompleteChallenge(ctx, token, "123456", "")
+	assert.ErrorIs(t, err, apperrors.ErrTwoFactorCodeInvalid)
+
+	loggedIn, device, err := env.service.CompleteChallenge(ctx, token, env.code(t), "")
+	require.NoError(t, err)
+	assert.Equal(t, user.UserID, loggedIn.UserID)
+	assert.Equal(t, DeviceInfo{ID: "phone-1", Name: "iPhone"}, device)
+
+	// 挑战令牌只能使用一次
+	_, _, err = env.service.CompleteChallenge(ctx, token, env.code(t), "")
+	assert.ErrorIs(t, err, apperrors.ErrMFAChallengeInvalid)
+
+	// 恢复码同样可以完成登录
+	token, err = env.service.StartChallenge(ctx, user, DeviceInfo{})
+	require.NoError(t, err)
+	_, _, err = env.service.CompleteChallenge(ctx, token, env.recovered[0], "")
+	require.NoError(t, err)
+}
+
+func TestTwoFactor_ChallengeAttemptsAndExpiry(t *testing.T) {
+	env := setupTwoFactorTest(t)
+	env.enable(t)
+	ctx := context.Background()
+
+	token, err := env.service.StartChallenge(ctx, env.user, DeviceInfo{})
+	require.NoError(t, err)
+	for i := 0; i < 3; i++ {
+		_, _, err = env.service.CompleteChallenge(ctx, token, "123456", "")
+		assert.ErrorIs(t, err, apperrors.ErrTwoFactorCodeInvalid)
+	}
+	_, _, err = env.service.CompleteChallenge(ctx, token, env.code(t), "")
+	assert.ErrorIs(t, err, apperrors.ErrMFAChallengeInvalid, "错误次数达到上限后需重新登录")
+
+	token, err = env.service.StartChallenge(ctx, env.user, DeviceInfo{})
+	require.NoError(t, err)
+	*env.now = env.now.Add(6 * time.Minute)
+	_, _, err = env.service.CompleteChallenge(ctx, token, env.code(t), "")
+	assert.ErrorIs(t, err, apperrors.ErrMFAChallengeInvalid)
+}
+
+func TestTwoFactor_GroupRequiresAdminTwoFactor(t *testing.T) {
+	env := setupTwoFactorTest(t)
+	ctx := context.Background()
+
+	group, err := env.groups.CreateGroup(ctx, "实训一组", "", env.user.UserID)
+	require.NoError(t, err)
+	bob, err := env.auth.AuthRegister(ctx, "bob", "secret2")
+	require.NoError(t, err)
+	require.NoError(t, env.factory.GroupMemberDAO.Create(ctx, &models.GroupMember{
+		GroupID: group.GroupID, UserID: bob.UserID, Username: "bob", Role: "admin",
+	}))
+
+	// 操作者自己未开启两步验证时不能开启该要求
+	err = env.groups.SetRequireAdminTwoFactor(ctx, group.GroupID, env.user.UserID, true)
+	assert.ErrorIs(t, err, apperrors.ErrAdminTwoFactorRequired)
+
+	env.enable(t)
+	require.NoError(t, env.groups.SetRequireAdminTwoFactor(ctx, group.GroupID, env.user.UserID, true))
+	assert.NoError(t, env.groups.CheckMemberPermission(ctx, group.GroupID, env.user.UserID))
+
+	// 未开启两步验证的管理员失去管理权限，按权限不足处理
+	err = env.groups.CheckMemberPermission(ctx, group.GroupID, bob.UserID)
+	assert.ErrorIs(t, err, apperrors.ErrAdminTwoFactorRequired)
+	assert.ErrorIs(t, err, apperrors.ErrRolePermissionDenied)
+	err = env.groups.DeleteGroup(ctx, group.GroupID, bob.UserID)
+	assert.ErrorIs(t, err, apperrors.ErrAdminTwoFactorRequired)
+
+	// 仍担任该组管理员时不能关闭两步验证
+	err = env.service.Disable(ctx, env.user.UserID, env.code(t))
+	assert.ErrorIs(t, err, apperrors.ErrTwoFactorRequiredByGroup)
+
+	require.NoError(t, env.groups.SetRequireAdminTwoFactor(ctx, group.GroupID, env.user.UserID, false))
+	assert.NoError(t, env.groups.CheckMemberPermission(ctx, group.GroupID, bob.UserID))
+	assert.NoError(t, env.service.Disable(ctx, env.user.UserID, env.code(t)))
+}
+
+func TestTwoFactor_CustomRoleBlocksDisable(t *testing.T) {
+	env := setupTwoFactorTest(t)
+	ctx := context.Background()
+	env.enable(t)
+
+	bob, err := env.auth.AuthRegister(ctx, "bob", "secret2")
+	require.NoError(t, err)
+	group, err := env.groups.CreateGroup(ctx, "实训一组", "", bob.UserID)
+	require.NoError(t, err)
+	_, err = env.groups.AddMemberToGroup(ctx, group.GroupID, env.user.UserID, bob.UserID, "")
+	require.NoError(t, err)
+	require.NoError(t, env.factory.GroupDAO.UpdateRequireAdmin2FA(ctx, group.GroupID, true))
+	require.NoError(t, env.factory.GroupMemberDAO.UpdateRoleID(ctx, group.GroupID, env.user.UserID, 1))
+
+	// 持有自定义角色的成员同样受该要求约束
+	err = env.service.Disable(ctx, env.user.UserID, env.code(t))
+	assert.ErrorIs(t, err, apperrors.ErrTwoFactorRequiredByGroup)
+
+	require.NoError(t, env.factory.GroupMemberDAO.UpdateRoleID(ctx, group.GroupID, env.user.UserID, 0))
+	assert.NoError(t, env.service.Disable(ctx, env.user.UserID, env.code(t)))
+}
@@ -6,22 +6,35 @@ import (
 	appErrors "TeamTickBackend/pkg/errors"
 	"context"
 	"errors"
+	"log/slog"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
 
 	"gorm.io/gorm"
 )
 
 type UserService struct {
 	userDao dao.UserDAO
+	denormalizationDao dao.DenormalizationDAO
 	transactionManager dao.TransactionManager
+	log *slog.Logger
 }
 
 func NewUserService(
 	userDao dao.UserDAO,
+	denormalizationDao dao.DenormalizationDAO,
 	transactionManager dao.TransactionManager,
+	log *slog.Logger,
 ) *UserService {
 	return &UserService{
 		userDao: userDao,
+		denormalizationDao: denormalizationDao,
 		transactionManager: transactionManager,
+		log: log,
 	}
 }
 
@@ -43,3 +56,211 @@ func (s *UserService) GetUserMe(ctx context.Context, userID int) (*models.User,
 	}
 	return &existUser, nil
 }
+
+var (
+	studentNumberPattern = regexp.MustCompile(`^[A-Za-z0-9-]+$`)
+	phonePattern         = regexp.MustCompile(`^\+?[0-9][0-9-]{4,30}$`)
+)
+
+// ProfileUpdate 修改个人资料，nil 表示保持不变，空字符串表示清除
+type ProfileUpdate struct {
+	DisplayName   *string
+	RealName      *string
+	StudentNumber *string
+	Email         *string
+	Phone         *string
+	AvatarURL     *string
+
+	ShareRealName      *bool
+	ShareStudentNumber *bool
+	ShareEmail         *bool
+	SharePhone         *bool
+}
+
+// 修改个人资料与隐私设置，返回修改后的用户
+func (s *UserService) UpdateProfile(ctx context.Context, userID int, update ProfileUpdate) (*models.User, error) {
+	var updatedUser models.User
+	err := s.transactionManager.WithTransaction(ctx, func(tx *gorm.DB) error {
+		user, err := s.userDao.GetByID(ctx, userID, tx)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return appErrors.ErrUserNotFound
+			}
+			return appErrors.ErrDatabaseOperation.WithError(err)
+		}
+		applyProfileString(&user.DisplayName, update.DisplayName)
+		applyProfileString(&user.RealName, update.RealName)
+		applyProfileString(&user.StudentNumber, update.StudentNumber)
+		applyProfileString(&user.Email, update.Email)
+		applyProfileString(&user.Phone, update.Phone)
+		applyProfileString(&user.AvatarURL, update.AvatarURL)
+		applyProfileBool(&user.ShareRealName, update.ShareRealName)
+		applyProfileBool(&user.ShareStudentNumber, update.ShareStudentNumber)
+		applyProfileBool(&user.ShareEmail, update.ShareEmail)
+		applyProfileBool(&user.SharePhone, update.SharePhone)
+		if err := validateProfile(user); err != nil {
+			return err
+		}
+		if err := s.userDao.UpdateProfile(ctx, userID, user, tx); err != nil {
+			return appErrors.ErrDatabaseOperation.WithError(err)
+		}
+		updatedUser = *user
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &updatedUser, nil
+}
+
+// 修改用户名，并在同一事务中同步其他表冗余存储的用户名
+// 已签发的访问令牌中仍是旧用户名，刷新令牌后生效；写入冗余列时以 users 表为准，不受旧令牌影响
+func (s *UserService) ChangeUsername(ctx context.Context, userID int, username string) (*models.User, error) {
+	if !validUsername(username) {
+		return nil, appErrors.ErrUsernameInvalid
+	}
+	var updatedUser models.User
+	var oldUsername string
+	err := s.transactionManager.WithTransaction(ctx, func(tx *gorm.DB) error {
+		user, err := s.userDao.GetByID(ctx, userID, tx)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return appErrors.ErrUserNotFound
+			}
+			return appErrors.ErrDatabaseOperation.WithError(err)
+		}
+		oldUsername = user.Username
+		if user.Username == username {
+			updatedUser = *user
+			return nil
+		}
+		//检查用户名是否已被占用
+		existing, err := s.userDao.GetByUsername(ctx, username, tx)
+		if err == nil && existing.UserID != userID {
+			return appErrors.ErrUserAlreadyExists
+		}
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return appErrors.ErrDatabaseOperation.WithError(err)
+		}
+		if err := s.userDao.UpdateUsername(ctx, userID, username, tx); err != nil {
+			if errors.Is(err, gorm.ErrDuplicatedKey) {
+				return appErrors.ErrUserAlreadyExists
+			}
+			return appErrors.ErrDatabaseOperation.WithError(err)
+		}
+		//同步用户组、成员、签到记录与各类申请中的用户名
+		if _, err := s.denormalizationDao.Repair(ctx, models.DenormSourceUser, userID, tx); err != nil {
+			return appErrors.ErrDatabaseOperation.WithError(err)
+		}
+		user.Username = username
+		updatedUser = *user
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if oldUsername != username {
+		s.log.InfoContext(ctx, "username changed", slog.Int("user_id", userID),
+			slog.String("old_username", oldUsername), slog.String("username", username))
+	}
+	return &updatedUser, nil
+}
+
+// validUsername 与注册接口一致限制长度，并拒绝空白与控制字符
+func validUsername(username string) bool {
+	length := utf8.RuneCountInString(username)
+	if length < 3 || length > 50 {
+		return false
+	}
+	for _, r := range username {
+		if unicode.IsSpace(r) || unicode.IsControl(r) {
+			return false
+		}
+	}
+	return true
+}
+
+func applyProfileString(field *string, value *string) {
+	if value != nil {
+		*field = strings.TrimSpace(*value)
+	}
+}
+
+func applyProfileBool(field *bool, value *bool) {
+	if value != nil {
+		*field = *value
+	}
+}
+
+// validateProfile 校验格式，长度已由接口参数校验限制；空值表示未填写
+func validateProfile(user *models.User) error {
+	for _, name := range []string{user.DisplayName, user.RealName} {
+		if strings.IndexFunc(name, unicode.IsControl) >= 0 {
+			return appErrors.ErrProfileNameInvalid
+		}
+	}
+	if user.StudentNumber != "" && !studentNumberPattern.MatchString(user.StudentNumber) {
+		return appErrors.ErrProfileStudentNumberInvalid
+	}
+	if user.Email != "" {
+		address, err := mail.ParseAddress(user.Email)
+		if err != nil || address.Address != user.Email {
+			return appErrors.ErrProfileEmailInvalid
+		}
+	}
+	if user.Phone != "" && !phonePattern.MatchString(user.Phone) {
+		return appErrors.ErrProfilePhoneInvalid
+	}
+	if user.AvatarURL != "" {
+		u, err := url.Parse(user.AvatarURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return appErrors.ErrProfileAvatarInvalid
+		}
+	}
+	return nil
+}
+
+// MemberProfile 用户组成员列表中展示的个人资料，不可见的字段为空
+type MemberProfile struct {
+	DisplayName   string
+	AvatarURL     string
+	RealName      string
+	StudentNumber string
+	Email         string
+	Phone         string
+}
+
+// visibleProfile 按隐私设置返回查看者可见的资料：昵称与头像对同组成员公开，
+// 其余字段仅在查看者是组管理员且用户允许展示时可见，用户本人可以看到全部
+func visibleProfile(user *models.User, viewerIsAdmin, isSelf bool) MemberProfile {
+	profile := MemberProfile{
+		DisplayName: user.DisplayName,
+		AvatarURL:   user.AvatarURL,
+	}
+	if isSelf || (viewerIsAdmin && user.ShareRealName) {
+		profile.RealName = user.RealName
+	}
+	if isSelf || (viewerIsAdmin && user.ShareStudentNumber) {
+		profile.StudentNumber = user.StudentNumber
+	}
+	if isSelf || (viewerIsAdmin && user.ShareEmail) {
+		profile.Email = user.Email
+	}
+	if isSelf || (viewerIsAdmin && user.SharePhone) {
+		profile.Phone = user.Phone
+	}
+	return profile
+}
+
+// currentUsername 在调用方的事务中读取用户当前的用户名。写入冗余列时以 users 表为准，
+// 不使用访问令牌中的用户名：改名后旧令牌在过期前仍然有效，其中的用户名已经过时
+func currentUsername(ctx context.Context, userDao dao.UserDAO, userID int, tx *gorm.DB) (string, error) {
+	user, err := userDao.GetByID(ctx, userID, tx)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return "", appErrors.ErrUserNotFound
+		}
+		return "", appErrors.ErrDatabaseOperation.WithError(err)
+	}
+	return user.Username, nil
+}
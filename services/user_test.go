@@ -1,6 +1,7 @@
 package service
 
 import (
+	"TeamTickBackend/dal/dao"
 	"TeamTickBackend/dal/models"
 	appErrors "TeamTickBackend/pkg/errors"
 	"context"
@@ -9,6 +10,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 	"gorm.io/gorm"
 )
 
@@ -50,7 +52,9 @@ func setupUserServiceTest() (*UserService, *mockUserDAO, *mockTransactionManager
 	
 	userService := NewUserService(
 		mockUserDao,
+		new(mockDenormalizationDAO),
 		mockTxManager,
+		discardLogger(),
 	)
 	
 	return userService, mockUserDao, mockTxManager
@@ -106,3 +110,137 @@ func TestGetUserMe_UserNotFound(t *testing.T) {
 	mockTxManager.AssertExpectations(t)
 	mockUserDao.AssertExpectations(t)
 }
+
+// --- UpdateProfile 测试 ---
+
+func strPtr(s string) *string { return &s }
+
+func boolPtr(b bool) *bool { return &b }
+
+func TestUpdateProfile_Success(t *testing.T) {
+	factory := dao.NewMemoryDAOFactory()
+	ctx := context.Background()
+	seedUsers(t, factory, "zhang3")
+	userService := NewUserService(factory.UserDAO, factory.DenormalizationDAO, factory.TransactionManager, discardLogger())
+
+	user, err := userService.UpdateProfile(ctx, 1, ProfileUpdate{
+		DisplayName:   strPtr(" 小张 "),
+		RealName:      strPtr("张三"),
+		StudentNumber: strPtr("2021-123456"),
+		Email:         strPtr("zhang3@example.edu.cn"),
+		Phone:         strPtr("+86-13800000000"),
+		SharePhone:    boolPtr(true),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "小张", user.DisplayName)
+	// 默认向组管理员展示姓名与学号，不展示邮箱
+	assert.True(t, user.ShareRealName)
+	assert.True(t, user.ShareStudentNumber)
+	assert.False(t, user.ShareEmail)
+	assert.True(t, user.SharePhone)
+
+	// 未提交的字段保持不变，空字符串清除
+	user, err = userService.UpdateProfile(ctx, 1, ProfileUpdate{Email: strPtr("")})
+	require.NoError(t, err)
+	assert.Empty(t, user.Email)
+	stored, err := factory.UserDAO.GetByID(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "张三", stored.RealName)
+	assert.Empty(t, stored.Email)
+}
+
+func TestUpdateProfile_Validation(t *testing.T) {
+	factory := dao.NewMemoryDAOFactory()
+	ctx := context.Background()
+	seedUsers(t, factory, "zhang3")
+	userService := NewUserService(factory.UserDAO, factory.DenormalizationDAO, factory.TransactionManager, discardLogger())
+
+	cases := []struct {
+		update ProfileUpdate
+		err    error
+	}{
+		{ProfileUpdate{DisplayName: strPtr("a\nb")}, appErrors.ErrProfileNameInvalid},
+		{ProfileUpdate{StudentNumber: strPtr("2021 123")}, appErrors.ErrProfileStudentNumberInvalid},
+		{ProfileUpdate{Email: strPtr("Zhang <zhang3@example.edu.cn>")}, appErrors.ErrProfileEmailInvalid},
+		{ProfileUpdate{Phone: strPtr("138-abc")}, appErrors.ErrProfilePhoneInvalid},
+		{ProfileUpdate{AvatarURL: strPtr("javascript:alert(1)")}, appErrors.ErrProfileAvatarInvalid},
+	}
+	for _, c := range cases {
+		_, err := userService.UpdateProfile(ctx, 1, c.update)
+		assert.ErrorIs(t, err, c.err)
+	}
+	_, err := userService.UpdateProfile(ctx, 99, ProfileUpdate{})
+	assert.ErrorIs(t, err, appErrors.ErrUserNotFound)
+}
+
+func TestGetMemberProfiles_Privacy(t *testing.T) {
+	factory := dao.NewMemoryDAOFactory()
+	ctx := context.Background()
+	groupsService := newMemoryGroupsService(t, factory)
+	userService := NewUserService(factory.UserDAO, factory.DenormalizationDAO, factory.TransactionManager, discardLogger())
+	seedUsers(t, factory, "teacher", "zhang3", "li4")
+	group, err := groupsService.CreateGroup(ctx, "实训一组", "", 1)
+	require.NoError(t, err)
+	for _, id := range []int{2, 3} {
+		_, err = groupsService.AddMemberToGroup(ctx, group.GroupID, id, 1, "")
+		require.NoError(t, err)
+	}
+	_, err = userService.UpdateProfile(ctx, 2, ProfileUpdate{
+		DisplayName:   strPtr("小张"),
+		RealName:      strPtr("张三"),
+		StudentNumber: strPtr("2021123456"),
+		Email:         strPtr("zhang3@example.edu.cn"),
+	})
+	require.NoError(t, err)
+	members, err := groupsService.GetMembersByGroupID(ctx, group.GroupID)
+	require.NoError(t, err)
+
+	// 组管理员看到允许展示的字段，邮箱默认不展示
+	profiles, err := groupsService.GetMemberProfiles(ctx, members, 1)
+	require.NoError(t, err)
+	assert.Equal(t, MemberProfile{DisplayName: "小张", RealName: "张三", StudentNumber: "2021123456"}, profiles[2])
+
+	// 普通成员只能看到昵称与头像
+	profiles, err = groupsService.GetMemberProfiles(ctx, members, 3)
+	require.NoError(t, err)
+	assert.Equal(t, MemberProfile{DisplayName: "小张"}, profiles[2])
+
+	// 本人可以看到全部
+	profiles, err = groupsService.GetMemberProfiles(ctx, members, 2)
+	require.NoError(t, err)
+	assert.Equal(t, "zhang3@example.edu.cn", profiles[2].Email)
+
+	// 关闭展示后组管理员也看不到
+	_, err = userService.UpdateProfile(ctx, 2, ProfileUpdate{ShareRealName: boolPtr(false)})
+	require.NoError(t, err)
+	profiles, err = groupsService.GetMemberProfiles(ctx, members, 1)
+	require.NoError(t, err)
+	assert.Empty(t, profiles[2].RealName)
+}
+
+func TestGetMemberProfiles_AdminTwoFactorRequired(t *testing.T) {
+	factory := dao.NewMemoryDAOFactory()
+	ctx := context.Background()
+	groupsService := newMemoryGroupsService(t, factory)
+	userService := NewUserService(factory.UserDAO, factory.DenormalizationDAO, factory.TransactionManager, discardLogger())
+	seedUsers(t, factory, "teacher", "zhang3")
+	group, err := groupsService.CreateGroup(ctx, "实训一组", "", 1)
+	require.NoError(t, err)
+	_, err = groupsService.AddMemberToGroup(ctx, group.GroupID, 2, 1, "")
+	require.NoError(t, err)
+	_, err = userService.UpdateProfile(ctx, 2, ProfileUpdate{RealName: strPtr("张三")})
+	require.NoError(t, err)
+	require.NoError(t, factory.GroupDAO.UpdateRequireAdmin2FA(ctx, group.GroupID, true))
+	members, err := groupsService.GetMembersByGroupID(ctx, group.GroupID)
+	require.NoError(t, err)
+
+	// 未开启两步验证的管理员按普通成员过滤
+	profiles, err := groupsService.GetMemberProfiles(ctx, members, 1)
+	require.NoError(t, err)
+	assert.Empty(t, profiles[2].RealName)
+
+	require.NoError(t, factory.UserDAO.UpdateTwoFactorEnabled(ctx, 1, true))
+	profiles, err = groupsService.GetMemberProfiles(ctx, members, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "张三", profiles[2].RealName)
+}